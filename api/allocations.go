@@ -350,6 +350,7 @@ type AllocatedDeviceResource struct {
 	Type      string
 	Name      string
 	DeviceIDs []string
+	Shares    uint64
 }
 
 // AllocIndexSort reverse sorts allocs by CreateIndex.
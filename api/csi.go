@@ -328,6 +328,10 @@ type CSIVolume struct {
 	NodesExpected       int
 	ResourceExhausted   time.Time
 
+	// NodeStats is the usage and condition of the volume as reported by the
+	// node plugin on each node with a claim on the volume, keyed by node ID
+	NodeStats map[string]*CSIVolumeStats
+
 	CreateIndex uint64
 	ModifyIndex uint64
 
@@ -335,6 +339,19 @@ type CSIVolume struct {
 	ExtraKeysHCL []string `hcl1:",unusedKeys" json:"-"`
 }
 
+// CSIVolumeStats is the usage and condition of a volume as reported by the
+// node plugin where the volume is published
+type CSIVolumeStats struct {
+	TotalBytes      int64
+	UsedBytes       int64
+	AvailableBytes  int64
+	TotalInodes     int64
+	UsedInodes      int64
+	AvailableInodes int64
+	Abnormal        bool
+	Message         string
+}
+
 // CSIVolumeCapability is a requested attachment and access mode for a
 // volume
 type CSIVolumeCapability struct {
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/url"
+)
+
+// HostVolumes is used to access the dynamic host volume endpoints.
+type HostVolumes struct {
+	client *Client
+}
+
+// HostVolumes returns a handle on the dynamic host volume endpoints.
+func (c *Client) HostVolumes() *HostVolumes {
+	return &HostVolumes{client: c}
+}
+
+// List returns the dynamic host volumes.
+func (v *HostVolumes) List(q *QueryOptions) ([]*HostVolume, *QueryMeta, error) {
+	var resp []*HostVolume
+	qm, err := v.client.query("/v1/volumes?type=host", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Info is used to retrieve a single dynamic host volume.
+func (v *HostVolumes) Info(id string, q *QueryOptions) (*HostVolume, *QueryMeta, error) {
+	var resp HostVolume
+	qm, err := v.client.query("/v1/volume/host/"+url.PathEscape(id), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Create has a client create a dynamic host volume. The volume is created on
+// its NodeID if set, or on a node matching its constraints otherwise.
+func (v *HostVolumes) Create(vol *HostVolume, w *WriteOptions) (*HostVolume, *WriteMeta, error) {
+	req := HostVolumeCreateRequest{
+		Volume: vol,
+	}
+
+	resp := &HostVolumeCreateResponse{}
+	meta, err := v.client.write("/v1/volume/host/create", req, resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Volume, meta, nil
+}
+
+// Delete has the client delete a dynamic host volume.
+func (v *HostVolumes) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	return v.client.delete("/v1/volume/host/"+url.PathEscape(id), nil, w)
+}
+
+// HostVolume is a host volume created on demand by a Nomad client, which
+// fingerprints it into the node's host volumes.
+type HostVolume struct {
+	ID        string
+	Name      string
+	Namespace string
+
+	// PluginID is the plugin the client runs to provision the volume. The
+	// client creates a directory if it's empty.
+	PluginID    string        `mapstructure:"plugin_id"`
+	NodeID      string        `mapstructure:"node_id"`
+	Constraints []*Constraint `mapstructure:"constraint"`
+
+	RequestedCapacityMinBytes int64             `mapstructure:"capacity_min"`
+	RequestedCapacityMaxBytes int64             `mapstructure:"capacity_max"`
+	Parameters                map[string]string `mapstructure:"parameters"`
+
+	HostPath      string
+	CapacityBytes int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+	WriteRequest
+}
+
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+	WriteMeta
+}
@@ -0,0 +1,61 @@
+package api
+
+// ImagePrefetches is used to query the image prefetch endpoints.
+type ImagePrefetches struct {
+	client *Client
+}
+
+// ImagePrefetches returns a new handle on the image prefetches.
+func (c *Client) ImagePrefetches() *ImagePrefetches {
+	return &ImagePrefetches{client: c}
+}
+
+// List is used to dump all of the image prefetches.
+func (p *ImagePrefetches) List(q *QueryOptions) ([]*ImagePrefetch, *QueryMeta, error) {
+	var resp []*ImagePrefetch
+	qm, err := p.client.query("/v1/image-prefetches", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Info is used to query a single image prefetch by its name.
+func (p *ImagePrefetches) Info(name string, q *QueryOptions) (*ImagePrefetch, *QueryMeta, error) {
+	var resp ImagePrefetch
+	qm, err := p.client.query("/v1/image-prefetch/"+name, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Register is used to create or update an image prefetch.
+func (p *ImagePrefetches) Register(prefetch *ImagePrefetch, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := p.client.write("/v1/image-prefetch/"+prefetch.Name, prefetch, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Delete is used to delete an image prefetch.
+func (p *ImagePrefetches) Delete(name string, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := p.client.delete("/v1/image-prefetch/"+name, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// ImagePrefetch lists images task drivers pull onto the selected nodes before
+// allocations need them.
+type ImagePrefetch struct {
+	Name        string
+	Driver      string
+	Images      []string
+	Datacenters []string
+	NodeClass   string
+	CreateIndex uint64
+	ModifyIndex uint64
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImagePrefetches_CRUD(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	prefetches := c.ImagePrefetches()
+
+	_, _, err := prefetches.Info("cuda", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found")
+
+	prefetch := &ImagePrefetch{
+		Name:        "cuda",
+		Driver:      "docker",
+		Images:      []string{"nvidia/cuda:11.7.0-base-ubuntu22.04"},
+		Datacenters: []string{"dc1"},
+	}
+	wm, err := prefetches.Register(prefetch, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	resp, qm, err := prefetches.List(nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Len(t, resp, 1)
+	require.Equal(t, prefetch.Images, resp[0].Images)
+
+	out, _, err := prefetches.Info("cuda", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"dc1"}, out.Datacenters)
+
+	wm, err = prefetches.Delete("cuda", nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	resp, _, err = prefetches.List(nil)
+	require.NoError(t, err)
+	require.Empty(t, resp)
+}
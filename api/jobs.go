@@ -421,19 +421,36 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 
 func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	payload []byte, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
-	var resp JobDispatchResponse
 	req := &JobDispatchRequest{
 		JobID:   jobID,
 		Meta:    meta,
 		Payload: payload,
 	}
-	wm, err := j.client.write("/v1/job/"+url.PathEscape(jobID)+"/dispatch", req, &resp, q)
+	return j.DispatchOpts(req, q)
+}
+
+// DispatchOpts is used to dispatch a parameterized job with the options set
+// in the request.
+func (j *Jobs) DispatchOpts(req *JobDispatchRequest, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
+	var resp JobDispatchResponse
+	wm, err := j.client.write("/v1/job/"+url.PathEscape(req.JobID)+"/dispatch", req, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
 	return &resp, wm, nil
 }
 
+// DispatchQueue is used to retrieve the dispatch queue of a parameterized job
+// with max_concurrent set.
+func (j *Jobs) DispatchQueue(jobID string, q *QueryOptions) (*JobDispatchQueue, *QueryMeta, error) {
+	var resp JobDispatchQueue
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/dispatch-queue", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Revert is used to revert the given job to the passed version. If
 // enforceVersion is set, the job is only reverted if the current version is at
 // the passed version.
@@ -496,6 +513,8 @@ type UpdateStrategy struct {
 	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
 	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
 	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+
+	Analysis *DeploymentAnalysis `mapstructure:"analysis" hcl:"analysis,block"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -557,6 +576,8 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = boolToPtr(*u.AutoPromote)
 	}
 
+	copy.Analysis = u.Analysis.Copy()
+
 	return copy
 }
 
@@ -600,6 +621,13 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = boolToPtr(*o.AutoPromote)
 	}
+
+	if o.Analysis != nil {
+		if u.Analysis == nil {
+			u.Analysis = &DeploymentAnalysis{}
+		}
+		u.Analysis.Merge(o.Analysis)
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -640,6 +668,10 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	if u.Analysis != nil {
+		u.Analysis.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -684,9 +716,90 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.Analysis != nil {
+		return false
+	}
+
 	return true
 }
 
+// DeploymentAnalysis configures a Prometheus-compatible metrics query that is
+// evaluated while allocations of a deployment are watched for health.
+type DeploymentAnalysis struct {
+	Address      *string        `mapstructure:"address" hcl:"address,optional"`
+	Query        *string        `mapstructure:"query" hcl:"query,optional"`
+	Interval     *time.Duration `mapstructure:"interval" hcl:"interval,optional"`
+	Operator     *string        `mapstructure:"operator" hcl:"operator,optional"`
+	Threshold    *float64       `mapstructure:"threshold" hcl:"threshold,optional"`
+	FailureLimit *int           `mapstructure:"failure_limit" hcl:"failure_limit,optional"`
+}
+
+func (a *DeploymentAnalysis) Copy() *DeploymentAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	copy := new(DeploymentAnalysis)
+	copy.Merge(a)
+	return copy
+}
+
+func (a *DeploymentAnalysis) Merge(o *DeploymentAnalysis) {
+	if o == nil {
+		return
+	}
+
+	if o.Address != nil {
+		a.Address = stringToPtr(*o.Address)
+	}
+
+	if o.Query != nil {
+		a.Query = stringToPtr(*o.Query)
+	}
+
+	if o.Interval != nil {
+		a.Interval = timeToPtr(*o.Interval)
+	}
+
+	if o.Operator != nil {
+		a.Operator = stringToPtr(*o.Operator)
+	}
+
+	if o.Threshold != nil {
+		a.Threshold = float64ToPtr(*o.Threshold)
+	}
+
+	if o.FailureLimit != nil {
+		a.FailureLimit = intToPtr(*o.FailureLimit)
+	}
+}
+
+func (a *DeploymentAnalysis) Canonicalize() {
+	if a.Address == nil {
+		a.Address = stringToPtr("")
+	}
+
+	if a.Query == nil {
+		a.Query = stringToPtr("")
+	}
+
+	if a.Interval == nil {
+		a.Interval = timeToPtr(10 * time.Second)
+	}
+
+	if a.Operator == nil {
+		a.Operator = stringToPtr("<")
+	}
+
+	if a.Threshold == nil {
+		a.Threshold = float64ToPtr(0)
+	}
+
+	if a.FailureLimit == nil {
+		a.FailureLimit = intToPtr(0)
+	}
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy `hcl:"strategy,block"`
 	Regions  []*MultiregionRegion `hcl:"region,block"`
@@ -823,11 +936,126 @@ func (p *PeriodicConfig) GetLocation() (*time.Location, error) {
 	return time.LoadLocation(*p.TimeZone)
 }
 
+// JobGCPolicy configures how the dead children of a periodic or
+// parameterized job are garbage collected. A count or age of zero means
+// children are not limited by it.
+type JobGCPolicy struct {
+	KeepSuccessful *int           `mapstructure:"keep_successful" hcl:"keep_successful,optional"`
+	KeepFailed     *int           `mapstructure:"keep_failed" hcl:"keep_failed,optional"`
+	MaxAge         *time.Duration `mapstructure:"max_age" hcl:"max_age,optional"`
+}
+
+func (p *JobGCPolicy) Canonicalize() {
+	if p.KeepSuccessful == nil {
+		p.KeepSuccessful = intToPtr(0)
+	}
+	if p.KeepFailed == nil {
+		p.KeepFailed = intToPtr(0)
+	}
+	if p.MaxAge == nil {
+		p.MaxAge = timeToPtr(0)
+	}
+}
+
 // ParameterizedJobConfig is used to configure the parameterized job.
 type ParameterizedJobConfig struct {
-	Payload      string   `hcl:"payload,optional"`
-	MetaRequired []string `mapstructure:"meta_required" hcl:"meta_required,optional"`
-	MetaOptional []string `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	Payload      string               `hcl:"payload,optional"`
+	MetaRequired []string             `mapstructure:"meta_required" hcl:"meta_required,optional"`
+	MetaOptional []string             `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	Retry        *DispatchRetryPolicy `hcl:"retry,block"`
+
+	// MaxConcurrent limits the number of dispatched jobs scheduled at the
+	// same time, queueing the rest. Zero means no limit.
+	MaxConcurrent int `mapstructure:"max_concurrent" hcl:"max_concurrent,optional"`
+}
+
+func (p *ParameterizedJobConfig) Canonicalize() {
+	if p.Retry != nil {
+		p.Retry.Canonicalize()
+	}
+}
+
+// DispatchRetryPolicy configures how dispatched jobs that fail are dispatched
+// again.
+type DispatchRetryPolicy struct {
+	// Attempts is the maximum number of times a failed dispatched job is
+	// retried.
+	Attempts *int `mapstructure:"attempts" hcl:"attempts,optional"`
+
+	// Delay is the duration to wait before the first retry. The delay
+	// function determines how subsequent retries are delayed.
+	Delay *time.Duration `mapstructure:"delay" hcl:"delay,optional"`
+
+	// DelayFunction determines how the delay progressively changes on
+	// subsequent retries. Valid values are "exponential", "constant", and
+	// "fibonacci".
+	DelayFunction *string `mapstructure:"delay_function" hcl:"delay_function,optional"`
+
+	// MaxDelay is an upper bound on the delay.
+	MaxDelay *time.Duration `mapstructure:"max_delay" hcl:"max_delay,optional"`
+
+	// ExitCodes is the set of task exit codes that are retried. If empty,
+	// any failure is retried.
+	ExitCodes []int `mapstructure:"exit_codes" hcl:"exit_codes,optional"`
+}
+
+func (r *DispatchRetryPolicy) Canonicalize() {
+	if r.Attempts == nil {
+		r.Attempts = intToPtr(3)
+	}
+	if r.Delay == nil {
+		r.Delay = timeToPtr(30 * time.Second)
+	}
+	if r.DelayFunction == nil {
+		r.DelayFunction = stringToPtr("exponential")
+	}
+	if r.MaxDelay == nil {
+		r.MaxDelay = timeToPtr(1 * time.Hour)
+	}
+}
+
+// DispatchRetryTracker tracks the previous attempts of a dispatched job that
+// was retried.
+type DispatchRetryTracker struct {
+	Events []*DispatchRetryEvent
+
+	// RetryJobID is the ID of the dispatched job retrying this one
+	RetryJobID string
+}
+
+// DispatchRetryEvent is used to keep track of a previous attempt of a
+// dispatched job.
+type DispatchRetryEvent struct {
+	// RetryTime is the timestamp of the retry
+	RetryTime int64
+
+	// PrevJobID is the ID of the dispatched job that failed
+	PrevJobID string
+
+	// Delay is the retry delay associated with the attempt
+	Delay time.Duration
+}
+
+const (
+	JobDependencyConditionSuccess  = "success"
+	JobDependencyConditionFailure  = "failure"
+	JobDependencyConditionComplete = "complete"
+)
+
+// JobDependency declares that a batch job is not scheduled until an upstream
+// job in the same namespace has completed with the given condition.
+type JobDependency struct {
+	JobID     *string `mapstructure:"job_id" hcl:"job_id,optional"`
+	Condition *string `mapstructure:"condition" hcl:"condition,optional"`
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.JobID == nil {
+		d.JobID = stringToPtr("")
+	}
+	if d.Condition == nil {
+		d.Condition = stringToPtr(JobDependencyConditionSuccess)
+	}
 }
 
 // Job is used to serialize a job.
@@ -850,8 +1078,10 @@ type Job struct {
 	Spreads          []*Spread               `hcl:"spread,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
+	GC               *JobGCPolicy            `hcl:"gc,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
+	DependsOn        []*JobDependency        `mapstructure:"depends_on" hcl:"depends_on,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
 	ConsulToken      *string                 `mapstructure:"consul_token" hcl:"consul_token,optional"`
 	VaultToken       *string                 `mapstructure:"vault_token" hcl:"vault_token,optional"`
@@ -862,6 +1092,7 @@ type Job struct {
 	ParentID                 *string
 	Dispatched               bool
 	DispatchIdempotencyToken *string
+	DispatchRetryTracker     *DispatchRetryTracker
 	Payload                  []byte
 	ConsulNamespace          *string `mapstructure:"consul_namespace"`
 	VaultNamespace           *string `mapstructure:"vault_namespace"`
@@ -961,6 +1192,12 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+	if j.ParameterizedJob != nil {
+		j.ParameterizedJob.Canonicalize()
+	}
+	if j.GC != nil {
+		j.GC.Canonicalize()
+	}
 	if j.Update != nil {
 		j.Update.Canonicalize()
 	} else if *j.Type == JobTypeService {
@@ -980,6 +1217,9 @@ func (j *Job) Canonicalize() {
 	for _, a := range j.Affinities {
 		a.Canonicalize()
 	}
+	for _, d := range j.DependsOn {
+		d.Canonicalize()
+	}
 }
 
 // LookupTaskGroup finds a task group by name
@@ -1331,9 +1571,11 @@ type DesiredUpdates struct {
 }
 
 type JobDispatchRequest struct {
-	JobID   string
-	Payload []byte
-	Meta    map[string]string
+	JobID     string
+	Payload   []byte
+	Meta      map[string]string
+	DependsOn []*JobDependency
+	Priority  int
 }
 
 type JobDispatchResponse struct {
@@ -1344,6 +1586,24 @@ type JobDispatchResponse struct {
 	WriteMeta
 }
 
+// JobDispatchQueue is the dispatch queue of a parameterized job.
+type JobDispatchQueue struct {
+	JobID         string
+	Namespace     string
+	MaxConcurrent int
+	Running       int
+	Queued        []*JobDispatchQueueEntry
+}
+
+// JobDispatchQueueEntry is a dispatched job waiting in the dispatch queue.
+type JobDispatchQueueEntry struct {
+	JobID       string
+	EvalID      string
+	Priority    int
+	SubmitTime  int64
+	CreateIndex uint64
+}
+
 // JobVersionsResponse is used for a job get versions request
 type JobVersionsResponse struct {
 	Versions []*Job
@@ -325,9 +325,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr(""),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -409,9 +410,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr(""),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -498,9 +500,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("bar"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -684,9 +687,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Unlimited:     boolToPtr(true),
 						},
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -926,9 +930,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("bar"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -973,9 +978,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("baz"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -1102,9 +1108,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("bar"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -1154,9 +1161,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("baz"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(20 * time.Second),
@@ -427,6 +427,39 @@ func (n *Nodes) ToggleEligibility(nodeID string, eligible bool, q *WriteOptions)
 	return &resp, nil
 }
 
+// NodeUpdateMaintenanceRequest is used to update the maintenance windows of
+// a node.
+type NodeUpdateMaintenanceRequest struct {
+	NodeID             string
+	MaintenanceWindows []*NodeMaintenanceWindow
+}
+
+// NodeMaintenanceUpdateResponse is used to respond to a node maintenance
+// windows update
+type NodeMaintenanceUpdateResponse struct {
+	NodeModifyIndex uint64
+	WriteMeta
+}
+
+// UpdateMaintenanceWindows is used to set the maintenance windows of the node.
+// The windows replace those previously set through the API but not the ones
+// declared in the client's configuration. Passing no windows removes the
+// windows set through the API.
+func (n *Nodes) UpdateMaintenanceWindows(nodeID string, windows []*NodeMaintenanceWindow, q *WriteOptions) (*NodeMaintenanceUpdateResponse, error) {
+	req := &NodeUpdateMaintenanceRequest{
+		NodeID:             nodeID,
+		MaintenanceWindows: windows,
+	}
+
+	var resp NodeMaintenanceUpdateResponse
+	wm, err := n.client.write("/v1/node/"+nodeID+"/maintenance", req, &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	resp.WriteMeta = *wm
+	return &resp, nil
+}
+
 // Allocations is used to return the allocations associated with a node.
 func (n *Nodes) Allocations(nodeID string, q *QueryOptions) ([]*Allocation, *QueryMeta, error) {
 	var resp []*Allocation
@@ -468,6 +501,17 @@ func (n *Nodes) Stats(nodeID string, q *QueryOptions) (*HostStats, error) {
 	return &resp, nil
 }
 
+// Plugins returns the plugins loaded by the given node along with their
+// versions and health.
+func (n *Nodes) Plugins(nodeID string, q *QueryOptions) ([]*NodePlugin, error) {
+	var resp []*NodePlugin
+	path := fmt.Sprintf("/v1/client/plugins?node_id=%s", nodeID)
+	if _, err := n.client.query(path, &resp, q); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (n *Nodes) GC(nodeID string, q *QueryOptions) error {
 	path := fmt.Sprintf("/v1/client/gc?node_id=%s", nodeID)
 	_, err := n.client.query(path, nil, q)
@@ -493,6 +537,19 @@ func (n *Nodes) Purge(nodeID string, q *QueryOptions) (*NodePurgeResponse, *Quer
 	return &resp, qm, nil
 }
 
+// NodePlugin describes a plugin loaded by a node.
+type NodePlugin struct {
+	Name              string
+	Type              string
+	Version           string
+	ApiVersion        string
+	Internal          bool
+	Path              string
+	SHA256            string
+	Healthy           *bool
+	HealthDescription string
+}
+
 // NodePurgeResponse is used to deserialize a Purge response.
 type NodePurgeResponse struct {
 	EvalIDs         []string
@@ -563,10 +620,32 @@ type Node struct {
 	CSIControllerPlugins  map[string]*CSIInfo
 	CSINodePlugins        map[string]*CSIInfo
 	LastDrain             *DrainMetadata
+	MaintenanceWindows    []*NodeMaintenanceWindow
 	CreateIndex           uint64
 	ModifyIndex           uint64
 }
 
+const (
+	NodeMaintenanceWindowSourceConfig = "config"
+	NodeMaintenanceWindowSourceAPI    = "api"
+)
+
+// NodeMaintenanceWindow is a recurring window of time during which the node
+// doesn't accept new placements of service jobs.
+type NodeMaintenanceWindow struct {
+	// Cron is the cron expression of the start of the window
+	Cron string
+
+	// Duration is how long the window stays open
+	Duration time.Duration
+
+	// TimeZone is the time zone the cron expression is evaluated in
+	TimeZone string
+
+	// Source is where the window was declared, either "config" or "api"
+	Source string
+}
+
 type NodeResources struct {
 	Cpu      NodeCpuResources
 	Memory   NodeMemoryResources
@@ -643,6 +722,10 @@ type CSINodeInfo struct {
 
 	// SupportsCondition indicates plugin support for VOLUME_CONDITION
 	SupportsCondition bool
+
+	// VolumeStats is the usage and condition of each volume published on
+	// the node, keyed by volume ID
+	VolumeStats map[string]*CSIVolumeStats
 }
 
 // CSIControllerInfo is the fingerprinted data from a CSI Plugin that is specific to
@@ -14,6 +14,7 @@ type Resources struct {
 	DiskMB      *int               `mapstructure:"disk" hcl:"disk,optional"`
 	Networks    []*NetworkResource `hcl:"network,block"`
 	Devices     []*RequestedDevice `hcl:"device,block"`
+	IO          *IOResources       `hcl:"io,block"`
 
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
@@ -94,6 +95,24 @@ func (r *Resources) Merge(other *Resources) {
 	if len(other.Devices) != 0 {
 		r.Devices = other.Devices
 	}
+	if other.IO != nil {
+		r.IO = other.IO
+	}
+}
+
+// IOResources configures the block I/O weight and limits of a task.
+type IOResources struct {
+	Weight  *int             `hcl:"weight,optional"`
+	Devices []*IODeviceLimit `hcl:"device,block"`
+}
+
+// IODeviceLimit throttles the block I/O of a task on a single device.
+type IODeviceLimit struct {
+	Path      string `hcl:",label"`
+	ReadBps   uint64 `mapstructure:"read_bps" hcl:"read_bps,optional"`
+	WriteBps  uint64 `mapstructure:"write_bps" hcl:"write_bps,optional"`
+	ReadIOPS  uint64 `mapstructure:"read_iops" hcl:"read_iops,optional"`
+	WriteIOPS uint64 `mapstructure:"write_iops" hcl:"write_iops,optional"`
 }
 
 type Port struct {
@@ -190,6 +209,10 @@ type NodeDevice struct {
 	// Locality stores HW locality information for the node to optionally be
 	// used when making placement decisions.
 	Locality *NodeDeviceLocality
+
+	// Shares is the number of shares the device exposes when it can be
+	// shared between allocations.
+	Shares uint64
 }
 
 // Attribute is used to describe the value of an attribute, optionally
@@ -264,6 +287,14 @@ type RequestedDevice struct {
 	// Affinities are a set of affinites to apply when selecting the device
 	// to use.
 	Affinities []*Affinity `hcl:"affinity,block"`
+
+	// OnUnhealthy is how the client reacts when an allocated instance of the
+	// device becomes unhealthy: "ignore", "restart" or "reschedule".
+	OnUnhealthy string `mapstructure:"on_unhealthy" hcl:"on_unhealthy,optional"`
+
+	// Shares is the number of shares requested on each device instance.
+	// When set, the instances may be shared with other allocations.
+	Shares uint64 `mapstructure:"shares" hcl:"shares,optional"`
 }
 
 func (d *RequestedDevice) Canonicalize() {
@@ -41,11 +41,21 @@ type CpuStats struct {
 	Measured         []string
 }
 
-// ResourceUsage holds information related to cpu and memory stats
+// IOStats holds block I/O usage related stats
+type IOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+	Measured   []string
+}
+
+// ResourceUsage holds information related to cpu, memory and block I/O stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
 	DeviceStats []*DeviceGroupStats
+	IOStats     *IOStats
 }
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -62,6 +72,25 @@ type AllocResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Tasks         map[string]*TaskResourceUsage
 	Timestamp     int64
+	NetworkStats  *NetworkStats
+	DiskStats     *DiskStats
+}
+
+// NetworkStats holds the usage of an allocation's shared network
+type NetworkStats struct {
+	RxBytes    uint64
+	TxBytes    uint64
+	RxMBits    float64
+	TxMBits    float64
+	LimitMBits int
+	Measured   []string
+}
+
+// DiskStats holds the usage of an allocation's ephemeral disk
+type DiskStats struct {
+	Used     uint64
+	Size     uint64
+	Measured []string
 }
 
 // RestartPolicy defines how the Nomad client restarts
@@ -289,16 +318,18 @@ func (s *Spread) Canonicalize() {
 
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
-	Sticky  *bool `hcl:"sticky,optional"`
-	Migrate *bool `hcl:"migrate,optional"`
-	SizeMB  *int  `mapstructure:"size" hcl:"size,optional"`
+	Sticky     *bool `hcl:"sticky,optional"`
+	Migrate    *bool `hcl:"migrate,optional"`
+	Checkpoint *bool `hcl:"checkpoint,optional"`
+	SizeMB     *int  `mapstructure:"size" hcl:"size,optional"`
 }
 
 func DefaultEphemeralDisk() *EphemeralDisk {
 	return &EphemeralDisk{
-		Sticky:  boolToPtr(false),
-		Migrate: boolToPtr(false),
-		SizeMB:  intToPtr(300),
+		Sticky:     boolToPtr(false),
+		Migrate:    boolToPtr(false),
+		Checkpoint: boolToPtr(false),
+		SizeMB:     intToPtr(300),
 	}
 }
 
@@ -309,6 +340,9 @@ func (e *EphemeralDisk) Canonicalize() {
 	if e.Migrate == nil {
 		e.Migrate = boolToPtr(false)
 	}
+	if e.Checkpoint == nil {
+		e.Checkpoint = boolToPtr(false)
+	}
 	if e.SizeMB == nil {
 		e.SizeMB = intToPtr(300)
 	}
@@ -432,6 +466,7 @@ type TaskGroup struct {
 	ShutdownDelay             *time.Duration            `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
 	StopAfterClientDisconnect *time.Duration            `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	MaxClientDisconnect       *time.Duration            `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
+	ExpectedDuration          *time.Duration            `mapstructure:"expected_duration" hcl:"expected_duration,optional"`
 	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
 	Consul                    *Consul                   `hcl:"consul,block"`
 }
@@ -934,6 +969,7 @@ type TaskState struct {
 	LastRestart time.Time
 	StartedAt   time.Time
 	FinishedAt  time.Time
+	Health      string
 	Events      []*TaskEvent
 
 	// Experimental -  TaskHandle is based on drivers.TaskHandle and used
@@ -964,11 +1000,15 @@ const (
 	TaskDownloadingArtifacts   = "Downloading Artifacts"
 	TaskArtifactDownloadFailed = "Failed Artifact Download"
 	TaskSiblingFailed          = "Sibling Task Failed"
+	TaskDiskExceeded           = "Disk Resources Exceeded"
+	TaskDeviceUnhealthy        = "Device Unhealthy"
 	TaskSignaling              = "Signaling"
 	TaskRestartSignal          = "Restart Signaled"
 	TaskLeaderDead             = "Leader Task Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
 	TaskClientReconnected      = "Reconnected"
+	TaskCheckpointed           = "Checkpointed"
+	TaskCheckpointRestored     = "Restored From Checkpoint"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -3,6 +3,7 @@ package allocdir
 import (
 	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
@@ -58,6 +60,12 @@ var (
 	// directory
 	TaskSecrets = "secrets"
 
+	// TaskCheckpoint is the name of the directory inside each task's local
+	// directory that task checkpoints are written to. Keeping it in the local
+	// directory ensures checkpoints are moved along with migrated
+	// ephemeral disks.
+	TaskCheckpoint = ".checkpoint"
+
 	// TaskDirs is the set of directories created in each tasks directory.
 	TaskDirs = map[string]os.FileMode{TmpDirName: os.ModeSticky | 0777}
 
@@ -91,6 +99,9 @@ type AllocDir struct {
 	// built is true if Build has successfully run
 	built bool
 
+	// quota enforces the ephemeral disk size if set by SetDiskQuota
+	quota *diskQuota
+
 	mu sync.RWMutex
 
 	logger hclog.Logger
@@ -120,12 +131,46 @@ func NewAllocDir(logger hclog.Logger, clientAllocDir, allocID string) *AllocDir
 	}
 }
 
+// SetDiskQuota enforces the ephemeral disk size on the directories tasks
+// write to. It must be called before Build and before any TaskDir is created.
+func (d *AllocDir) SetDiskQuota(mode DiskQuotaMode, sizeMB int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if mode == DiskQuotaDisabled || sizeMB <= 0 {
+		d.quota = nil
+		return
+	}
+	d.quota = newDiskQuota(d.logger, mode, sizeMB, filepath.Base(d.AllocDir), d.AllocDir)
+}
+
+// DiskQuotaEnabled returns true if the ephemeral disk size is enforced.
+func (d *AllocDir) DiskQuotaEnabled() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.quota != nil
+}
+
+// DiskUsage returns the usage of the ephemeral disk, or nil if its size is
+// not enforced or the alloc dir is not built.
+func (d *AllocDir) DiskUsage() (*cstructs.DiskStats, error) {
+	d.mu.RLock()
+	quota := d.quota
+	d.mu.RUnlock()
+
+	if quota == nil {
+		return nil, nil
+	}
+	return quota.stats()
+}
+
 // NewTaskDir creates a new TaskDir and adds it to the AllocDirs TaskDirs map.
 func (d *AllocDir) NewTaskDir(name string) *TaskDir {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	td := newTaskDir(d.logger, d.clientAllocDir, d.AllocDir, name)
+	td.quota = d.quota
 	d.TaskDirs[name] = td
 	return td
 }
@@ -232,7 +277,7 @@ func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 	dataDir := filepath.Join(d.SharedDir, SharedDataDir)
 	if fileInfo, err := os.Stat(otherDataDir); fileInfo != nil && err == nil {
 		os.Remove(dataDir) // remove an empty data dir if it exists
-		if err := os.Rename(otherDataDir, dataDir); err != nil {
+		if err := moveDir(otherDataDir, dataDir); err != nil {
 			return fmt.Errorf("error moving data dir: %v", err)
 		}
 	}
@@ -251,7 +296,7 @@ func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 			}
 			localDir := filepath.Join(newTaskDir, TaskLocal)
 			os.Remove(localDir) // remove an empty local dir if it exists
-			if err := os.Rename(otherTaskLocal, localDir); err != nil {
+			if err := moveDir(otherTaskLocal, localDir); err != nil {
 				return fmt.Errorf("error moving task %q local dir: %v", task.Name, err)
 			}
 		}
@@ -272,6 +317,12 @@ func (d *AllocDir) Destroy() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to remove alloc dir %q: %v", d.AllocDir, err))
 	}
 
+	if d.quota != nil {
+		if err := d.quota.destroy(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to remove ephemeral disk quota: %v", err))
+		}
+	}
+
 	// Unset built since the alloc dir has been destroyed.
 	d.mu.Lock()
 	d.built = false
@@ -310,6 +361,14 @@ func (d *AllocDir) UnmountAll() error {
 		}
 	}
 
+	// Unmount the ephemeral disk after the task dirs it is mounted in
+	if d.quota != nil {
+		if err := d.quota.unmount(); err != nil {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("failed to unmount ephemeral disk: %v", err))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -320,11 +379,23 @@ func (d *AllocDir) Build() error {
 		return fmt.Errorf("Failed to make the alloc directory %v: %v", d.AllocDir, err)
 	}
 
+	if d.quota != nil {
+		if err := d.quota.setup(); err != nil {
+			return fmt.Errorf("Failed to set up ephemeral disk quota: %v", err)
+		}
+	}
+
 	// Make the shared directory and make it available to all user/groups.
 	if err := os.MkdirAll(d.SharedDir, 0777); err != nil {
 		return err
 	}
 
+	if d.quota != nil {
+		if err := d.quota.addDir(d.SharedDir); err != nil {
+			return err
+		}
+	}
+
 	// Make the shared directory have non-root permissions.
 	if err := dropDirPermissions(d.SharedDir, os.ModePerm); err != nil {
 		return err
@@ -348,6 +419,23 @@ func (d *AllocDir) Build() error {
 	return nil
 }
 
+// Chown changes the owner of the shared alloc directory and the directories
+// within it. It does not recurse into the directories, so must be called after
+// Build and before any task writes files.
+func (d *AllocDir) Chown(uid, gid int) error {
+	dirs := []string{d.SharedDir}
+	for _, dir := range SharedAllocDirs {
+		dirs = append(dirs, filepath.Join(d.SharedDir, dir))
+	}
+
+	for _, dir := range dirs {
+		if err := os.Chown(dir, uid, gid); err != nil {
+			return fmt.Errorf("Couldn't change owner/group of %v to (uid: %v, gid: %v): %v", dir, uid, gid, err)
+		}
+	}
+	return nil
+}
+
 // List returns the list of files at a path relative to the alloc dir
 func (d *AllocDir) List(path string) ([]*cstructs.AllocFileInfo, error) {
 	if escapes, err := escapingfs.PathEscapesAllocDir(d.AllocDir, "", path); err != nil {
@@ -535,6 +623,60 @@ func fileCopy(src, dst string, uid, gid int, perm os.FileMode) error {
 	return nil
 }
 
+// moveDir renames src to dst, copying it when they are on different
+// filesystems or under different project quotas.
+func moveDir(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		uid, gid := getOwner(info)
+
+		switch {
+		case info.IsDir():
+			if err := os.MkdirAll(target, info.Mode().Perm()); err != nil {
+				return err
+			}
+			if err := os.Chmod(target, info.Mode()); err != nil {
+				return err
+			}
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			return fileCopy(path, target, uid, gid, info.Mode().Perm())
+		default:
+			// Sockets and pipes are only meaningful to running tasks
+			return nil
+		}
+
+		if uid != idUnsupported && gid != idUnsupported {
+			return os.Lchown(target, uid, gid)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy %q to %q: %v", src, dst, err)
+	}
+	return os.RemoveAll(src)
+}
+
 // pathExists is a helper function to check if the path exists.
 func pathExists(path string) bool {
 	if _, err := os.Stat(path); err != nil {
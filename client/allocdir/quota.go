@@ -0,0 +1,199 @@
+package allocdir
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// DiskQuotaMode selects how the size of an allocation's ephemeral disk is
+// enforced on its alloc dir.
+type DiskQuotaMode string
+
+const (
+	// DiskQuotaDisabled does not enforce the ephemeral disk size
+	DiskQuotaDisabled DiskQuotaMode = ""
+
+	// DiskQuotaAuto uses project quotas if the filesystem of the client's
+	// alloc dir supports them and falls back to a loopback filesystem
+	DiskQuotaAuto DiskQuotaMode = "auto"
+
+	// DiskQuotaProject uses XFS or ext4 project quotas
+	DiskQuotaProject DiskQuotaMode = "project"
+
+	// DiskQuotaLoopback stores the ephemeral disk in a loopback mounted
+	// filesystem image sized to the ephemeral disk
+	DiskQuotaLoopback DiskQuotaMode = "loopback"
+)
+
+// Validate returns an error if the mode is unknown.
+func (m DiskQuotaMode) Validate() error {
+	switch m {
+	case DiskQuotaDisabled, DiskQuotaAuto, DiskQuotaProject, DiskQuotaLoopback:
+		return nil
+	default:
+		return fmt.Errorf("unknown disk quota mode %q, must be one of %q, %q or %q",
+			m, DiskQuotaAuto, DiskQuotaProject, DiskQuotaLoopback)
+	}
+}
+
+const (
+	// diskQuotaMinHeadroomMB is the minimum space written past the ephemeral
+	// disk size before writes fail. It leaves the client time to notice the
+	// disk is exceeded and kill the tasks with an explanatory event, rather
+	// than tasks failing on full disk errors.
+	diskQuotaMinHeadroomMB = 16
+)
+
+// diskQuotaHeadroomMB returns the space that may be written past the
+// ephemeral disk size before writes fail.
+func diskQuotaHeadroomMB(sizeMB int) int {
+	if headroom := sizeMB / 10; headroom > diskQuotaMinHeadroomMB {
+		return headroom
+	}
+	return diskQuotaMinHeadroomMB
+}
+
+// quotaEnforcer limits the space used by the directories tasks write to.
+// Implementations must find the state they left on disk, so the quota
+// survives client restarts.
+type quotaEnforcer interface {
+	// setup prepares the quota before any directory is added. It is called
+	// again when a restored alloc dir is built.
+	setup() error
+
+	// addDir places the directory under the quota, along with any content
+	// it already has.
+	addDir(dir string) error
+
+	// usage returns the bytes used under the quota
+	usage() (uint64, error)
+
+	// unmount releases the mounts made for the quota
+	unmount() error
+
+	// destroy releases the quota once the alloc dir is removed
+	destroy() error
+}
+
+// diskQuota enforces the ephemeral disk size on the shared alloc dir and the
+// local and tmp dirs of the tasks. The task secrets and chroots are not
+// counted as they are not part of the ephemeral disk.
+type diskQuota struct {
+	mode   DiskQuotaMode
+	sizeMB int
+
+	// allocID and allocDir are the allocation's ID and directory
+	allocID  string
+	allocDir string
+
+	// enforcer is resolved from the mode on first use
+	enforcer quotaEnforcer
+
+	lock   sync.Mutex
+	logger hclog.Logger
+}
+
+func newDiskQuota(logger hclog.Logger, mode DiskQuotaMode, sizeMB int, allocID, allocDir string) *diskQuota {
+	return &diskQuota{
+		mode:     mode,
+		sizeMB:   sizeMB,
+		allocID:  allocID,
+		allocDir: allocDir,
+		logger:   logger.Named("disk_quota"),
+	}
+}
+
+// resolveLocked returns the enforcer, picking it from the state left on disk
+// by a previous client or else from the mode. The lock must be held.
+func (q *diskQuota) resolveLocked() (quotaEnforcer, error) {
+	if q.enforcer != nil {
+		return q.enforcer, nil
+	}
+
+	if os.Geteuid() != 0 {
+		return nil, fmt.Errorf("enforcing ephemeral disk sizes requires running as root")
+	}
+
+	enforcer, err := newQuotaEnforcer(q)
+	if err != nil {
+		return nil, err
+	}
+	q.enforcer = enforcer
+	return enforcer, nil
+}
+
+func (q *diskQuota) setup() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	enforcer, err := q.resolveLocked()
+	if err != nil {
+		return err
+	}
+	return enforcer.setup()
+}
+
+func (q *diskQuota) addDir(dir string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	enforcer, err := q.resolveLocked()
+	if err != nil {
+		return err
+	}
+	if err := enforcer.addDir(dir); err != nil {
+		return fmt.Errorf("failed to apply ephemeral disk quota to %q: %v", dir, err)
+	}
+	return nil
+}
+
+// stats returns the usage of the ephemeral disk, or nil if it has not been
+// set up.
+func (q *diskQuota) stats() (*cstructs.DiskStats, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.enforcer == nil {
+		return nil, nil
+	}
+
+	used, err := q.enforcer.usage()
+	if err != nil {
+		return nil, err
+	}
+	return &cstructs.DiskStats{
+		Used:     used,
+		Size:     uint64(q.sizeMB) * 1024 * 1024,
+		Measured: []string{"Used", "Size"},
+	}, nil
+}
+
+func (q *diskQuota) unmount() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	// Nothing was set up by this or a previous client
+	if q.enforcer == nil && !pathExists(q.allocDir) {
+		return nil
+	}
+
+	enforcer, err := q.resolveLocked()
+	if err != nil {
+		return err
+	}
+	return enforcer.unmount()
+}
+
+func (q *diskQuota) destroy() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.enforcer == nil {
+		return nil
+	}
+	return q.enforcer.destroy()
+}
@@ -0,0 +1,553 @@
+package allocdir
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/moby/sys/mountinfo"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ephemeralDiskImage is the name of the filesystem image backing a
+	// loopback ephemeral disk in the alloc dir
+	ephemeralDiskImage = ".ephemeral-disk.img"
+
+	// ephemeralDiskMount is the name of the directory in the alloc dir the
+	// loopback ephemeral disk is mounted on
+	ephemeralDiskMount = ".ephemeral-disk"
+
+	// diskQuotaProjectIDBase and diskQuotaProjectIDRange bound the project
+	// IDs assigned to alloc dirs, leaving lower IDs to operators
+	diskQuotaProjectIDBase  = 0x4e000000
+	diskQuotaProjectIDRange = 0x00100000
+
+	// diskQuotaProjectIDProbes is the number of project IDs tried when the
+	// ID derived from the alloc ID is in use
+	diskQuotaProjectIDProbes = 1024
+)
+
+// Definitions from linux/fs.h and linux/quota.h that are missing from
+// golang.org/x/sys/unix
+const (
+	fsIocFsGetXattr    = 0x801c581f
+	fsIocFsSetXattr    = 0x401c5820
+	fsXflagProjInherit = 0x00000200
+
+	qGetQuota    = 0x800007
+	qSetQuota    = 0x800008
+	prjQuota     = 2
+	qifBLimits   = 1
+	qifDqblkSize = 1024
+)
+
+// fsxattr is struct fsxattr from linux/fs.h
+type fsxattr struct {
+	Xflags     uint32
+	Extsize    uint32
+	Nextents   uint32
+	Projid     uint32
+	Cowextsize uint32
+	Pad        [8]byte
+}
+
+// dqblk is struct if_dqblk from linux/quota.h
+type dqblk struct {
+	BHardlimit uint64
+	BSoftlimit uint64
+	CurSpace   uint64
+	IHardlimit uint64
+	ISoftlimit uint64
+	CurInodes  uint64
+	BTime      uint64
+	ITime      uint64
+	Valid      uint32
+}
+
+// projectIDLock serializes picking project IDs and setting their limits, so
+// concurrently built alloc dirs never share an ID
+var projectIDLock sync.Mutex
+
+// newQuotaEnforcer returns the enforcer a previous client set up for the
+// alloc dir, or else a new one for the quota's mode.
+func newQuotaEnforcer(q *diskQuota) (quotaEnforcer, error) {
+	loopback := &loopbackQuota{
+		allocDir:   q.allocDir,
+		image:      filepath.Join(q.allocDir, ephemeralDiskImage),
+		mountPoint: filepath.Join(q.allocDir, ephemeralDiskMount),
+		sizeMB:     q.sizeMB,
+		logger:     q.logger,
+	}
+	if pathExists(loopback.image) {
+		return loopback, nil
+	}
+
+	sharedDir := filepath.Join(q.allocDir, SharedAllocName)
+	if id, err := getProjectID(sharedDir); err == nil && isDiskQuotaProjectID(id) {
+		device, err := projectQuotaDevice(q.allocDir)
+		if err != nil {
+			return nil, err
+		}
+		return &projectQuota{device: device, id: id, allocID: q.allocID, sizeMB: q.sizeMB}, nil
+	}
+
+	switch q.mode {
+	case DiskQuotaProject:
+		device, err := projectQuotaDevice(q.allocDir)
+		if err != nil {
+			return nil, err
+		}
+		return &projectQuota{device: device, allocID: q.allocID, sizeMB: q.sizeMB}, nil
+	case DiskQuotaLoopback:
+		return loopback, nil
+	case DiskQuotaAuto:
+		device, err := projectQuotaDevice(q.allocDir)
+		if err == nil {
+			return &projectQuota{device: device, allocID: q.allocID, sizeMB: q.sizeMB}, nil
+		}
+		q.logger.Debug("project quotas unavailable, using a loopback filesystem", "error", err)
+		return loopback, nil
+	default:
+		return nil, fmt.Errorf("unknown disk quota mode %q", q.mode)
+	}
+}
+
+// projectQuota enforces the ephemeral disk size with XFS or ext4 project
+// quotas. The directories are assigned a project ID whose block limits are
+// set to the ephemeral disk size, and new files inherit the project ID.
+type projectQuota struct {
+	// device is the block device of the filesystem the alloc dir is on
+	device string
+
+	// id is the project ID, or zero until one is picked
+	id uint32
+
+	allocID string
+	sizeMB  int
+}
+
+func (p *projectQuota) setup() error {
+	projectIDLock.Lock()
+	defer projectIDLock.Unlock()
+
+	if p.id == 0 {
+		id, err := pickProjectID(p.device, p.allocID)
+		if err != nil {
+			return err
+		}
+		p.id = id
+	}
+
+	limitMB := uint64(p.sizeMB + diskQuotaHeadroomMB(p.sizeMB))
+	return setProjectQuota(p.device, p.id, &dqblk{
+		BSoftlimit: uint64(p.sizeMB) * 1024 * 1024 / qifDqblkSize,
+		BHardlimit: limitMB * 1024 * 1024 / qifDqblkSize,
+		Valid:      qifBLimits,
+	})
+}
+
+func (p *projectQuota) addDir(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		return setProjectID(path, p.id, info.IsDir())
+	})
+}
+
+func (p *projectQuota) usage() (uint64, error) {
+	quota, err := getProjectQuota(p.device, p.id)
+	if err != nil {
+		return 0, err
+	}
+	return quota.CurSpace, nil
+}
+
+func (p *projectQuota) unmount() error {
+	return nil
+}
+
+func (p *projectQuota) destroy() error {
+	if p.id == 0 {
+		return nil
+	}
+
+	// Clearing the limits releases the project ID to other alloc dirs
+	return setProjectQuota(p.device, p.id, &dqblk{Valid: qifBLimits})
+}
+
+// pickProjectID returns an unused project ID derived from the alloc ID. The
+// projectIDLock must be held until the ID's limits are set.
+func pickProjectID(device, allocID string) (uint32, error) {
+	h := fnv.New32a()
+	h.Write([]byte(allocID))
+	offset := h.Sum32() % diskQuotaProjectIDRange
+
+	for i := uint32(0); i < diskQuotaProjectIDProbes; i++ {
+		id := diskQuotaProjectIDBase + (offset+i)%diskQuotaProjectIDRange
+		quota, err := getProjectQuota(device, id)
+		if err != nil {
+			return 0, err
+		}
+		if quota.BHardlimit == 0 && quota.BSoftlimit == 0 && quota.CurSpace == 0 && quota.CurInodes == 0 {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("no unused project ID found")
+}
+
+// isDiskQuotaProjectID returns true if the project ID is in the range
+// assigned to alloc dirs.
+func isDiskQuotaProjectID(id uint32) bool {
+	return id >= diskQuotaProjectIDBase && id < diskQuotaProjectIDBase+diskQuotaProjectIDRange
+}
+
+// projectQuotaDevice returns the block device of the filesystem the path is
+// on if it enforces project quotas.
+func projectQuotaDevice(path string) (string, error) {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+
+	mounts, err := mountinfo.GetMounts(func(m *mountinfo.Info) (bool, bool) {
+		parent := m.Mountpoint == "/" || path == m.Mountpoint || strings.HasPrefix(path, m.Mountpoint+"/")
+		return !parent, false
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read mounts: %v", err)
+	}
+
+	mount, err := projectQuotaMount(mounts)
+	if err != nil {
+		return "", fmt.Errorf("%q: %v", path, err)
+	}
+	return mount.Source, nil
+}
+
+// projectQuotaMount returns the innermost of the mounts containing a path,
+// or an error if it does not enforce project quotas. Of the mounts on the
+// same mount point, the last one listed is the one visible.
+func projectQuotaMount(mounts []*mountinfo.Info) (*mountinfo.Info, error) {
+	var mount *mountinfo.Info
+	for _, m := range mounts {
+		if mount == nil || len(m.Mountpoint) >= len(mount.Mountpoint) {
+			mount = m
+		}
+	}
+	if mount == nil {
+		return nil, fmt.Errorf("mount not found")
+	}
+
+	if mount.FSType != "xfs" && mount.FSType != "ext4" {
+		return nil, fmt.Errorf("project quotas are not supported on %s filesystems", mount.FSType)
+	}
+
+	for _, option := range strings.Split(mount.VFSOptions, ",") {
+		switch option {
+		case "prjquota", "pquota":
+			return mount, nil
+		}
+	}
+	return nil, fmt.Errorf("filesystem mounted on %q does not enforce project quotas", mount.Mountpoint)
+}
+
+// getProjectID returns the project ID of a file or directory.
+func getProjectID(path string) (uint32, error) {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer unix.Close(fd)
+
+	var attr fsxattr
+	if err := ioctlFsxattr(fd, fsIocFsGetXattr, &attr); err != nil {
+		return 0, err
+	}
+	return attr.Projid, nil
+}
+
+// setProjectID sets the project ID of a file or directory. Directories are
+// marked so files created in them inherit the project ID.
+func setProjectID(path string, id uint32, inherit bool) error {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var attr fsxattr
+	if err := ioctlFsxattr(fd, fsIocFsGetXattr, &attr); err != nil {
+		return fmt.Errorf("failed to get attributes of %q: %v", path, err)
+	}
+
+	attr.Projid = id
+	if inherit {
+		attr.Xflags |= fsXflagProjInherit
+	}
+	if err := ioctlFsxattr(fd, fsIocFsSetXattr, &attr); err != nil {
+		return fmt.Errorf("failed to set project ID of %q: %v", path, err)
+	}
+	return nil
+}
+
+func ioctlFsxattr(fd int, req uintptr, attr *fsxattr) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), req, uintptr(unsafe.Pointer(attr)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func getProjectQuota(device string, id uint32) (*dqblk, error) {
+	var quota dqblk
+	if err := quotactl(qGetQuota, device, id, &quota); err != nil {
+		return nil, fmt.Errorf("failed to get quota of project %d: %v", id, err)
+	}
+	return &quota, nil
+}
+
+func setProjectQuota(device string, id uint32, quota *dqblk) error {
+	if err := quotactl(qSetQuota, device, id, quota); err != nil {
+		return fmt.Errorf("failed to set quota of project %d: %v", id, err)
+	}
+	return nil
+}
+
+func quotactl(cmd int, device string, id uint32, quota *dqblk) error {
+	devicePtr, err := unix.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+
+	// QCMD(cmd, type) from linux/quota.h
+	qcmd := uintptr(cmd<<8 | prjQuota)
+	_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, qcmd, uintptr(unsafe.Pointer(devicePtr)),
+		uintptr(id), uintptr(unsafe.Pointer(quota)), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// loopbackQuota enforces the ephemeral disk size by storing the directories
+// in a filesystem image of that size. The image is loop mounted in the alloc
+// dir and its directories are bind mounted over the alloc's directories.
+type loopbackQuota struct {
+	allocDir   string
+	image      string
+	mountPoint string
+	sizeMB     int
+	logger     hclog.Logger
+}
+
+func (l *loopbackQuota) setup() error {
+	if err := os.MkdirAll(l.mountPoint, 0700); err != nil {
+		return err
+	}
+
+	// The image stays mounted across client restarts
+	if mounted, err := mountinfo.Mounted(l.mountPoint); err != nil {
+		return err
+	} else if mounted {
+		return nil
+	}
+
+	if !pathExists(l.image) {
+		if err := l.createImage(); err != nil {
+			return err
+		}
+	}
+
+	device, loop, err := attachLoopDevice(l.image)
+	if err != nil {
+		return err
+	}
+	defer loop.Close()
+
+	if err := unix.Mount(device, l.mountPoint, "ext4", 0, ""); err != nil {
+		unix.IoctlSetInt(int(loop.Fd()), unix.LOOP_CLR_FD, 0)
+		return fmt.Errorf("failed to mount %q: %v", device, err)
+	}
+
+	l.logger.Debug("mounted ephemeral disk", "device", device, "path", l.mountPoint)
+	return nil
+}
+
+// createImage creates a sparse ext4 image sized to the ephemeral disk
+func (l *loopbackQuota) createImage() error {
+	f, err := os.OpenFile(l.image, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create ephemeral disk image: %v", err)
+	}
+
+	sizeMB := int64(l.sizeMB + diskQuotaHeadroomMB(l.sizeMB))
+	err = f.Truncate(sizeMB * 1024 * 1024)
+	f.Close()
+	if err != nil {
+		os.Remove(l.image)
+		return fmt.Errorf("failed to size ephemeral disk image: %v", err)
+	}
+
+	out, err := exec.Command("mkfs.ext4", "-q", "-F", "-m", "0", l.image).CombinedOutput()
+	if err != nil {
+		os.Remove(l.image)
+		return fmt.Errorf("failed to create ephemeral disk filesystem: %v: %s", err, out)
+	}
+	return nil
+}
+
+func (l *loopbackQuota) addDir(dir string) error {
+	if mounted, err := mountinfo.Mounted(dir); err != nil {
+		return err
+	} else if mounted {
+		return nil
+	}
+
+	rel, err := filepath.Rel(l.allocDir, dir)
+	if err != nil {
+		return err
+	}
+	src := filepath.Join(l.mountPoint, rel)
+	if err := os.MkdirAll(src, 0777); err != nil {
+		return err
+	}
+
+	// Move content written before the directory was added, such as
+	// migrated ephemeral disks, into the image
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := moveDir(filepath.Join(dir, entry.Name()), filepath.Join(src, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	if err := unix.Mount(src, dir, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("failed to bind mount %q: %v", src, err)
+	}
+	return nil
+}
+
+func (l *loopbackQuota) usage() (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(l.mountPoint, &stat); err != nil {
+		return 0, err
+	}
+	return (stat.Blocks - stat.Bfree) * uint64(stat.Bsize), nil
+}
+
+// unmount lazily unmounts the image and the directories bind mounted from
+// it, which also detaches the loop device.
+func (l *loopbackQuota) unmount() error {
+	// Mount points are listed with symlinks resolved
+	allocDir, err := filepath.EvalSymlinks(l.allocDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	mountPoint := filepath.Join(allocDir, ephemeralDiskMount)
+
+	mounts, err := mountinfo.GetMounts(mountinfo.PrefixFilter(allocDir))
+	if err != nil {
+		return fmt.Errorf("failed to read mounts: %v", err)
+	}
+
+	var image *mountinfo.Info
+	for _, mount := range mounts {
+		if mount.Mountpoint == mountPoint {
+			image = mount
+		}
+	}
+	if image == nil {
+		return nil
+	}
+
+	// Unmount the most nested mounts first
+	sort.Slice(mounts, func(i, j int) bool {
+		return strings.Count(mounts[i].Mountpoint, "/") > strings.Count(mounts[j].Mountpoint, "/")
+	})
+	for _, mount := range mounts {
+		if mount == image || mount.Major != image.Major || mount.Minor != image.Minor {
+			continue
+		}
+		if err := unix.Unmount(mount.Mountpoint, unix.MNT_DETACH); err != nil && err != unix.EINVAL {
+			return fmt.Errorf("failed to unmount %q: %v", mount.Mountpoint, err)
+		}
+	}
+
+	if err := unix.Unmount(mountPoint, unix.MNT_DETACH); err != nil && err != unix.EINVAL {
+		return fmt.Errorf("failed to unmount ephemeral disk: %v", err)
+	}
+	return nil
+}
+
+// destroy is a no-op as the image is removed along with the alloc dir
+func (l *loopbackQuota) destroy() error {
+	return nil
+}
+
+// attachLoopDevice attaches the image to a free loop device, which is
+// detached once it is unmounted and the returned file is closed.
+func attachLoopDevice(image string) (string, *os.File, error) {
+	control, err := os.OpenFile("/dev/loop-control", os.O_RDWR, 0)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open loop control device: %v", err)
+	}
+	defer control.Close()
+
+	file, err := os.OpenFile(image, os.O_RDWR, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	// Other processes may take the free device before it is attached
+	for i := 0; i < 10; i++ {
+		n, err := unix.IoctlRetInt(int(control.Fd()), unix.LOOP_CTL_GET_FREE)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to find a free loop device: %v", err)
+		}
+
+		device := fmt.Sprintf("/dev/loop%d", n)
+		loop, err := os.OpenFile(device, os.O_RDWR, 0)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to open %q: %v", device, err)
+		}
+
+		if err := unix.IoctlSetInt(int(loop.Fd()), unix.LOOP_SET_FD, int(file.Fd())); err != nil {
+			loop.Close()
+			if err == unix.EBUSY {
+				continue
+			}
+			return "", nil, fmt.Errorf("failed to attach %q: %v", device, err)
+		}
+
+		info := unix.LoopInfo64{Flags: unix.LO_FLAGS_AUTOCLEAR}
+		copy(info.File_name[:], image)
+		_, _, errno := unix.Syscall(unix.SYS_IOCTL, loop.Fd(), unix.LOOP_SET_STATUS64, uintptr(unsafe.Pointer(&info)))
+		if errno != 0 {
+			unix.IoctlSetInt(int(loop.Fd()), unix.LOOP_CLR_FD, 0)
+			loop.Close()
+			return "", nil, fmt.Errorf("failed to configure %q: %v", device, errno)
+		}
+
+		return device, loop, nil
+	}
+
+	return "", nil, fmt.Errorf("failed to find a free loop device")
+}
@@ -0,0 +1,125 @@
+package allocdir
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/moby/sys/mountinfo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskQuota_projectQuotaMount(t *testing.T) {
+	ci.Parallel(t)
+
+	parse := func(lines ...string) []*mountinfo.Info {
+		mounts, err := mountinfo.GetMountsFromReader(strings.NewReader(strings.Join(lines, "\n")), nil)
+		require.NoError(t, err)
+		return mounts
+	}
+
+	root := "22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw"
+	dataQuota := "30 22 8:17 / /var/lib/nomad rw,relatime shared:9 - xfs /dev/sdb1 rw,attr2,inode64,prjquota"
+	dataNoQuota := "30 22 8:17 / /var/lib/nomad rw,relatime shared:9 - ext4 /dev/sdb1 rw"
+	tmpfs := "31 30 0:40 / /var/lib/nomad rw,relatime shared:10 - tmpfs tmpfs rw"
+
+	_, err := projectQuotaMount(nil)
+	require.Error(t, err)
+
+	_, err = projectQuotaMount(parse(root))
+	require.EqualError(t, err, `filesystem mounted on "/" does not enforce project quotas`)
+
+	mount, err := projectQuotaMount(parse(root, dataQuota))
+	require.NoError(t, err)
+	require.Equal(t, "/dev/sdb1", mount.Source)
+
+	_, err = projectQuotaMount(parse(root, dataNoQuota))
+	require.Error(t, err)
+
+	// The last mount on a mount point hides the others
+	_, err = projectQuotaMount(parse(root, dataQuota, tmpfs))
+	require.EqualError(t, err, "project quotas are not supported on tmpfs filesystems")
+}
+
+func TestDiskQuota_Loopback(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+	if _, err := exec.LookPath("mkfs.ext4"); err != nil {
+		t.Skip("mkfs.ext4 not found")
+	}
+	if _, err := os.Stat("/dev/loop-control"); err != nil {
+		t.Skip("loop devices not available")
+	}
+
+	task := &structs.Task{Name: "web"}
+
+	// Start from an alloc dir without a quota to move onto the quota
+	prev := NewAllocDir(testlog.HCLogger(t), t.TempDir(), "prev")
+	require.NoError(t, prev.Build())
+	defer prev.Destroy()
+	prevTask := prev.NewTaskDir(task.Name)
+	require.NoError(t, prevTask.Build(false, nil))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(prev.SharedDir, SharedDataDir, "data"), []byte("data"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(prevTask.LocalDir, "local"), []byte("local"), 0644))
+
+	d := NewAllocDir(testlog.HCLogger(t), t.TempDir(), "next")
+	d.SetDiskQuota(DiskQuotaLoopback, 20)
+	require.NoError(t, d.Build())
+	defer d.Destroy()
+
+	// Building again, like a restored alloc, is idempotent
+	require.NoError(t, d.Build())
+
+	td := d.NewTaskDir(task.Name)
+	require.NoError(t, d.Move(prev, []*structs.Task{task}))
+	require.NoError(t, td.Build(false, nil))
+
+	// The directories tasks write to are on the ephemeral disk, along with
+	// the moved data
+	for _, dir := range []string{d.SharedDir, td.LocalDir, filepath.Join(td.Dir, TmpDirName)} {
+		mounted, err := mountinfo.Mounted(dir)
+		require.NoError(t, err)
+		require.True(t, mounted, dir)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(d.SharedDir, SharedDataDir, "data"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+	data, err = ioutil.ReadFile(filepath.Join(td.LocalDir, "local"))
+	require.NoError(t, err)
+	require.Equal(t, "local", string(data))
+
+	// Writes are measured
+	before, err := d.DiskUsage()
+	require.NoError(t, err)
+	require.Equal(t, uint64(20*1024*1024), before.Size)
+
+	chunk := bytes.Repeat([]byte("a"), 8*1024*1024)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(td.LocalDir, "big"), chunk, 0644))
+	after, err := d.DiskUsage()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, after.Used-before.Used, uint64(len(chunk)))
+
+	// Writing past the headroom fails
+	f, err := os.Create(filepath.Join(d.SharedDir, "bigger"))
+	require.NoError(t, err)
+	for i := 0; i < 5 && err == nil; i++ {
+		_, err = f.Write(chunk)
+	}
+	f.Close()
+	require.Error(t, err)
+
+	// Destroying unmounts the ephemeral disk before removing the alloc dir
+	require.NoError(t, d.Destroy())
+	require.NoDirExists(t, d.AllocDir)
+	mounts, err := mountinfo.GetMounts(mountinfo.PrefixFilter(d.AllocDir))
+	require.NoError(t, err)
+	require.Empty(t, mounts)
+}
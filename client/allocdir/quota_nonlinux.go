@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package allocdir
+
+import (
+	"fmt"
+)
+
+// newQuotaEnforcer returns an error as ephemeral disk sizes are only
+// enforced on Linux.
+func newQuotaEnforcer(q *diskQuota) (quotaEnforcer, error) {
+	return nil, fmt.Errorf("enforcing ephemeral disk sizes is only supported on Linux")
+}
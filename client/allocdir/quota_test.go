@@ -0,0 +1,38 @@
+package allocdir
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskQuotaMode_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, mode := range []DiskQuotaMode{DiskQuotaDisabled, DiskQuotaAuto, DiskQuotaProject, DiskQuotaLoopback} {
+		require.NoError(t, mode.Validate())
+	}
+	require.Error(t, DiskQuotaMode("xfs").Validate())
+}
+
+func TestAllocDir_SetDiskQuota(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewAllocDir(testlog.HCLogger(t), t.TempDir(), "test")
+	d.SetDiskQuota(DiskQuotaDisabled, 300)
+	require.False(t, d.DiskQuotaEnabled())
+
+	d.SetDiskQuota(DiskQuotaLoopback, 0)
+	require.False(t, d.DiskQuotaEnabled())
+
+	d.SetDiskQuota(DiskQuotaLoopback, 300)
+	require.True(t, d.DiskQuotaEnabled())
+	require.NotNil(t, d.NewTaskDir("web").quota)
+
+	// Usage is only reported once the alloc dir is built
+	stats, err := d.DiskUsage()
+	require.NoError(t, err)
+	require.Nil(t, stats)
+}
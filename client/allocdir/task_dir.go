@@ -39,10 +39,18 @@ type TaskDir struct {
 	// <task_dir>/secrets/
 	SecretsDir string
 
+	// CheckpointDir is the path to the task's checkpoint directory on the
+	// host. It is only created when the task is checkpointed.
+	// <task_dir>/local/.checkpoint/
+	CheckpointDir string
+
 	// skip embedding these paths in chroots. Used for avoiding embedding
 	// client.alloc_dir recursively.
 	skip map[string]struct{}
 
+	// quota enforces the ephemeral disk size on the local and tmp dirs
+	quota *diskQuota
+
 	logger hclog.Logger
 }
 
@@ -66,6 +74,7 @@ func newTaskDir(logger hclog.Logger, clientAllocDir, allocDir, taskName string)
 		SharedTaskDir:  filepath.Join(taskDir, SharedAllocName),
 		LocalDir:       filepath.Join(taskDir, TaskLocal),
 		SecretsDir:     filepath.Join(taskDir, TaskSecrets),
+		CheckpointDir:  filepath.Join(taskDir, TaskLocal, TaskCheckpoint),
 		skip:           skip,
 		logger:         logger,
 	}
@@ -89,6 +98,12 @@ func (t *TaskDir) Build(createChroot bool, chroot map[string]string) error {
 		return err
 	}
 
+	if t.quota != nil {
+		if err := t.quota.addDir(t.LocalDir); err != nil {
+			return err
+		}
+	}
+
 	if err := dropDirPermissions(t.LocalDir, os.ModePerm); err != nil {
 		return err
 	}
@@ -100,6 +115,12 @@ func (t *TaskDir) Build(createChroot bool, chroot map[string]string) error {
 			return err
 		}
 
+		if t.quota != nil {
+			if err := t.quota.addDir(absdir); err != nil {
+				return err
+			}
+		}
+
 		if err := dropDirPermissions(absdir, perms); err != nil {
 			return err
 		}
@@ -138,6 +159,23 @@ func (t *TaskDir) Build(createChroot bool, chroot map[string]string) error {
 	return nil
 }
 
+// Chown changes the owner of the task directory and the local, secrets and tmp
+// directories within it. It does not recurse into the directories, so must be
+// called after Build and before the task writes any files.
+func (t *TaskDir) Chown(uid, gid int) error {
+	dirs := []string{t.Dir, t.LocalDir, t.SecretsDir}
+	for dir := range TaskDirs {
+		dirs = append(dirs, filepath.Join(t.Dir, dir))
+	}
+
+	for _, dir := range dirs {
+		if err := os.Chown(dir, uid, gid); err != nil {
+			return fmt.Errorf("Couldn't change owner/group of %v to (uid: %v, gid: %v): %v", dir, uid, gid, err)
+		}
+	}
+	return nil
+}
+
 // buildChroot takes a mapping of absolute directory or file paths on the host
 // to their intended, relative location within the task directory. This
 // attempts hardlink and then defaults to copying. If the path exists on the
@@ -0,0 +1,161 @@
+package allochealth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// analysisQueryTimeout is the maximum time a single analysis query may
+	// take before it is considered inconclusive.
+	analysisQueryTimeout = 10 * time.Second
+)
+
+// analysisQueryData is the data available to the analysis query template.
+type analysisQueryData struct {
+	AllocID      string
+	AllocName    string
+	Namespace    string
+	JobID        string
+	JobVersion   uint64
+	TaskGroup    string
+	DeploymentID string
+	NodeID       string
+}
+
+// analyzer evaluates a deployment analysis query against a
+// Prometheus-compatible HTTP API.
+type analyzer struct {
+	analysis *structs.DeploymentAnalysis
+	query    string
+	client   *http.Client
+}
+
+// newAnalyzer returns an analyzer for the given allocation with the query
+// template already interpolated.
+func newAnalyzer(alloc *structs.Allocation, analysis *structs.DeploymentAnalysis) (*analyzer, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(analysis.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse analysis query: %v", err)
+	}
+
+	data := analysisQueryData{
+		AllocID:      alloc.ID,
+		AllocName:    alloc.Name,
+		Namespace:    alloc.Namespace,
+		JobID:        alloc.JobID,
+		TaskGroup:    alloc.TaskGroup,
+		DeploymentID: alloc.DeploymentID,
+		NodeID:       alloc.NodeID,
+	}
+	if alloc.Job != nil {
+		data.JobVersion = alloc.Job.Version
+	}
+
+	var query strings.Builder
+	if err := tmpl.Execute(&query, data); err != nil {
+		return nil, fmt.Errorf("failed to render analysis query: %v", err)
+	}
+
+	return &analyzer{
+		analysis: analysis,
+		query:    query.String(),
+		client:   &http.Client{Timeout: analysisQueryTimeout},
+	}, nil
+}
+
+// promResponse is the subset of the Prometheus HTTP API query response used
+// to evaluate an analysis.
+type promResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// evaluate runs the query and returns the values it produced. An empty
+// result is returned when the query matched no series.
+func (a *analyzer) evaluate(ctx context.Context) ([]float64, error) {
+	u, err := url.Parse(a.analysis.Address)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/api/v1/query"
+	u.RawQuery = url.Values{"query": []string{a.query}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pr promResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pr); err != nil {
+		return nil, fmt.Errorf("failed to decode query response (status %d): %v", resp.StatusCode, err)
+	}
+	if pr.Status != "success" {
+		return nil, fmt.Errorf("query failed: %s: %s", pr.ErrorType, pr.Error)
+	}
+
+	switch pr.Data.ResultType {
+	case "scalar":
+		var sample []interface{}
+		if err := json.Unmarshal(pr.Data.Result, &sample); err != nil {
+			return nil, fmt.Errorf("failed to decode scalar result: %v", err)
+		}
+		v, err := parseSampleValue(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+
+	case "vector":
+		var series []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(pr.Data.Result, &series); err != nil {
+			return nil, fmt.Errorf("failed to decode vector result: %v", err)
+		}
+		values := make([]float64, 0, len(series))
+		for _, s := range series {
+			v, err := parseSampleValue(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported query result type %q", pr.Data.ResultType)
+	}
+}
+
+// parseSampleValue parses a Prometheus [timestamp, "value"] sample pair.
+func parseSampleValue(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid sample %v", sample)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value %v", sample[1])
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,113 @@
+package allochealth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeMetricsServer returns a Prometheus-compatible HTTP API that answers
+// every query with a single series containing the given value.
+func newFakeMetricsServer(t *testing.T, value string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("query") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"missing query"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"alloc":"a"},"value":[%d,"%s"]}]}}`,
+			time.Now().Unix(), value)
+	}))
+}
+
+func TestAnalyzer_Query(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	analysis := &structs.DeploymentAnalysis{
+		Address: "http://127.0.0.1",
+		Query:   `sum(rate(errors{job="{{.JobID}}",alloc="{{.AllocID}}"}[1m]))`,
+	}
+
+	a, err := newAnalyzer(alloc, analysis)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(`sum(rate(errors{job="%s",alloc="%s"}[1m]))`, alloc.JobID, alloc.ID), a.query)
+
+	analysis.Query = `{{.Unknown}}`
+	_, err = newAnalyzer(alloc, analysis)
+	require.Error(t, err)
+}
+
+func TestAnalyzer_Evaluate(t *testing.T) {
+	ci.Parallel(t)
+
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/prom/api/v1/query", r.URL.Path)
+		require.Equal(t, "up", r.URL.Query().Get("query"))
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	a, err := newAnalyzer(mock.Alloc(), &structs.DeploymentAnalysis{
+		Address: srv.URL + "/prom/",
+		Query:   "up",
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		body   string
+		values []float64
+		err    string
+	}{
+		{
+			name:   "vector",
+			body:   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"1"]},{"metric":{},"value":[1,"0.25"]}]}}`,
+			values: []float64{1, 0.25},
+		},
+		{
+			name:   "empty vector",
+			body:   `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			values: []float64{},
+		},
+		{
+			name:   "scalar",
+			body:   `{"status":"success","data":{"resultType":"scalar","result":[1,"42"]}}`,
+			values: []float64{42},
+		},
+		{
+			name: "matrix",
+			body: `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			err:  `unsupported query result type "matrix"`,
+		},
+		{
+			name: "error",
+			body: `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			err:  "query failed: bad_data: parse error",
+		},
+	}
+
+	for _, tc := range cases {
+		body = tc.body
+		values, err := a.evaluate(context.Background())
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.values, values, tc.name)
+	}
+}
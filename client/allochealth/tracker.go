@@ -57,6 +57,11 @@ type Tracker struct {
 	// consulClient is used to look up the state of the task's checks
 	consulClient serviceregistration.Handler
 
+	// analysis is the deployment metric analysis to evaluate. It is only
+	// set when tracking the health of an allocation that is part of a
+	// deployment with an analysis configured.
+	analysis *structs.DeploymentAnalysis
+
 	// healthy is used to signal whether we have determined the allocation to be
 	// healthy or unhealthy
 	healthy chan bool
@@ -82,6 +87,14 @@ type Tracker struct {
 	// checksHealthy marks whether all the task's Consul checks are healthy
 	checksHealthy bool
 
+	// analysisHealthy marks whether the deployment analysis has passed at
+	// least once
+	analysisHealthy bool
+
+	// analysisFailure is the reason the deployment analysis failed. It is
+	// empty unless the analysis exceeded its failure limit.
+	analysisFailure string
+
 	// taskHealth contains the health state for each task
 	taskHealth map[string]*taskHealthState
 
@@ -129,6 +142,11 @@ func NewTracker(parentCtx context.Context, logger hclog.Logger, alloc *structs.A
 		t.consulCheckCount += len(s.Checks)
 	}
 
+	// Only allocations that are part of a deployment are analyzed
+	if alloc.DeploymentID != "" && t.tg.Update != nil {
+		t.analysis = t.tg.Update.Analysis
+	}
+
 	t.ctx, t.cancelFn = context.WithCancel(parentCtx)
 	return t
 }
@@ -139,6 +157,9 @@ func (t *Tracker) Start() {
 	if t.useChecks {
 		go t.watchConsulEvents()
 	}
+	if t.analysis != nil {
+		go t.watchAnalysis()
+	}
 }
 
 // HealthyCh returns a channel that will emit a boolean indicating the health of
@@ -168,6 +189,14 @@ func (t *Tracker) TaskEvents() map[string]*structs.TaskEvent {
 	deadline, _ := t.ctx.Deadline()
 	events := make(map[string]*structs.TaskEvent, len(t.tg.Tasks))
 
+	// A failed analysis applies to every task of the allocation
+	if t.analysisFailure != "" {
+		for task := range t.taskHealth {
+			events[task] = structs.NewTaskEvent(AllocHealthEventSource).SetMessage(t.analysisFailure)
+		}
+		return events
+	}
+
 	// Go through are task information and build the event map
 	for task, state := range t.taskHealth {
 		useChecks := t.tg.Update.HealthCheck == structs.UpdateStrategyHealthCheck_Checks
@@ -199,6 +228,11 @@ func (t *Tracker) setTaskHealth(healthy, terminal bool) {
 		return
 	}
 
+	// Likewise wait for the analysis to pass if one is required
+	if !terminal && healthy && t.analysis != nil && !t.analysisHealthy {
+		return
+	}
+
 	select {
 	case t.healthy <- healthy:
 	default:
@@ -218,8 +252,8 @@ func (t *Tracker) setCheckHealth(healthy bool) bool {
 	// as checks might be missing from unhealthy tasks
 	t.checksHealthy = healthy && t.tasksHealthy
 
-	// Only signal if we are healthy and so is the tasks
-	if !t.checksHealthy {
+	// Only signal if we are healthy and so is the tasks and analysis
+	if !t.checksHealthy || (t.analysis != nil && !t.analysisHealthy) {
 		return false
 	}
 
@@ -233,6 +267,35 @@ func (t *Tracker) setCheckHealth(healthy bool) bool {
 	return true
 }
 
+// setAnalysisHealth is used to mark the deployment analysis as either passing
+// or failed. A failed analysis immediately marks the allocation unhealthy,
+// while a passing analysis only signals health once the tasks (and checks if
+// required) are healthy as well.
+func (t *Tracker) setAnalysisHealth(healthy bool, reason string) {
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	if healthy {
+		t.analysisHealthy = true
+
+		requireConsul := t.useChecks && t.consulCheckCount > 0
+		if !t.tasksHealthy || (requireConsul && !t.checksHealthy) {
+			return
+		}
+	} else {
+		t.analysisHealthy = false
+		t.analysisFailure = reason
+	}
+
+	select {
+	case t.healthy <- healthy:
+	default:
+	}
+
+	// Shutdown the tracker
+	t.cancelFn()
+}
+
 // markAllocStopped is used to mark the allocation as having stopped.
 func (t *Tracker) markAllocStopped() {
 	close(t.allocStopped)
@@ -295,7 +358,9 @@ func (t *Tracker) watchTaskEvents() {
 				return
 			}
 
-			if state.State == structs.TaskStatePending {
+			// Tasks whose driver reports them not yet healthy are treated
+			// as not started when only using task states
+			if state.State == structs.TaskStatePending || (!t.useChecks && !taskStateHealthy(state)) {
 				latestStartTime = time.Time{}
 				break
 			} else if state.StartedAt.After(latestStartTime) {
@@ -328,9 +393,11 @@ func (t *Tracker) watchTaskEvents() {
 				}
 			}
 
-			// Set the timer since all tasks are started
+			// Set the timer since all tasks are started. The start time is
+			// also cleared when a task stops being healthy so the timer is
+			// set again once it recovers.
+			allStartedTime = latestStartTime
 			if !latestStartTime.IsZero() {
-				allStartedTime = latestStartTime
 				healthyTimer.Reset(t.minHealthyTime)
 			}
 		}
@@ -475,6 +542,73 @@ OUTER:
 	}
 }
 
+// watchAnalysis is a watcher that periodically evaluates the deployment
+// analysis query. It marks the analysis healthy after the first passing
+// evaluation and the allocation unhealthy once the number of failing
+// evaluations exceeds the failure limit. Evaluations that error or return no
+// data are inconclusive and neither pass nor fail.
+func (t *Tracker) watchAnalysis() {
+	a, err := newAnalyzer(t.alloc, t.analysis)
+	if err != nil {
+		t.setAnalysisHealth(false, fmt.Sprintf("Unhealthy because of invalid analysis: %v", err))
+		return
+	}
+
+	ticker := time.NewTicker(t.analysis.Interval)
+	defer ticker.Stop()
+
+	failures := 0
+	queryErr := false
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		values, err := a.evaluate(t.ctx)
+		if err != nil {
+			if t.ctx.Err() != nil {
+				return
+			}
+			if !queryErr {
+				queryErr = true
+				t.logger.Warn("error evaluating deployment analysis for allocation", "error", err, "alloc_id", t.alloc.ID)
+			}
+			continue
+		}
+		queryErr = false
+
+		if len(values) == 0 {
+			t.logger.Trace("deployment analysis query returned no data", "alloc_id", t.alloc.ID)
+			continue
+		}
+
+		var breach *float64
+		for i, v := range values {
+			if !t.analysis.Passes(v) {
+				breach = &values[i]
+				break
+			}
+		}
+
+		if breach == nil {
+			t.setAnalysisHealth(true, "")
+			continue
+		}
+
+		failures++
+		t.logger.Debug("deployment analysis failed", "alloc_id", t.alloc.ID,
+			"value", *breach, "failures", failures, "failure_limit", t.analysis.FailureLimit)
+		if failures > t.analysis.FailureLimit {
+			t.setAnalysisHealth(false, fmt.Sprintf("Unhealthy because analysis value %v is not %s %v",
+				*breach, t.analysis.Operator, t.analysis.Threshold))
+			return
+		}
+	}
+}
+
 // taskHealthState captures all known health information about a task. It is
 // largely used to determine if the task has contributed to the allocation being
 // unhealthy.
@@ -512,6 +646,10 @@ func (t *taskHealthState) event(deadline time.Time, healthyDeadline, minHealthyT
 				return "Unhealthy because of dead task", true
 			}
 		case structs.TaskStateRunning:
+			if !useChecks && !taskStateHealthy(t.state) {
+				return fmt.Sprintf("Task not healthy by healthy_deadline of %v", healthyDeadline), true
+			}
+
 			// We are running so check if we have been running long enough
 			if t.state.StartedAt.Add(minHealthyTime).After(deadline) {
 				return fmt.Sprintf("Task not running for min_healthy_time of %v by healthy_deadline of %v", minHealthyTime, healthyDeadline), true
@@ -549,3 +687,9 @@ func (t *taskHealthState) event(deadline time.Time, healthyDeadline, minHealthyT
 
 	return "", false
 }
+
+// taskStateHealthy returns false if the driver reports the task is not
+// healthy. Tasks whose driver does not report health are considered healthy.
+func taskStateHealthy(state *structs.TaskState) bool {
+	return state.Health == "" || state.Health == structs.TaskHealthHealthy
+}
@@ -548,3 +548,143 @@ func TestTracker_Checks_OnUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestTracker_TaskStates_DriverHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	alloc.Job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	alloc.Job.TaskGroups[0].Update.HealthCheck = structs.UpdateStrategyHealthCheck_TaskStates
+
+	// Synthesize a running task whose health check has not passed yet
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.TaskStates = map[string]*structs.TaskState{
+		task.Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now(),
+			Health:    structs.TaskHealthStarting,
+		},
+	}
+
+	logger := testlog.HCLogger(t)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
+
+	consul := regMock.NewServiceRegistrationHandler(logger)
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+		time.Millisecond, false)
+	tracker.Start()
+
+	select {
+	case <-time.After(50 * time.Millisecond):
+	case h := <-tracker.HealthyCh():
+		require.Fail(t, "unexpected health before the task is healthy", "healthy=%v", h)
+	}
+
+	events := tracker.TaskEvents()
+	require.Contains(t, events[task.Name].Message, "Task not healthy by healthy_deadline")
+
+	// The task becoming healthy marks the allocation healthy
+	healthyAlloc := alloc.Copy()
+	healthyAlloc.TaskStates[task.Name].Health = structs.TaskHealthHealthy
+	require.NoError(t, b.Send(healthyAlloc))
+
+	select {
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out while waiting for health")
+	case h := <-tracker.HealthyCh():
+		require.True(t, h)
+	}
+}
+
+func TestTracker_Analysis(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		value   string
+		healthy bool
+	}{
+		{name: "passing", value: "0.01", healthy: true},
+		{name: "breached", value: "0.5", healthy: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newFakeMetricsServer(t, tc.value)
+			defer srv.Close()
+
+			alloc := mock.Alloc()
+			alloc.DeploymentID = "d-1"
+			task := alloc.Job.TaskGroups[0].Tasks[0]
+			alloc.Job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+			alloc.Job.TaskGroups[0].Update.Analysis = &structs.DeploymentAnalysis{
+				Address:   srv.URL,
+				Query:     `error_ratio{alloc="{{.AllocID}}"}`,
+				Interval:  10 * time.Millisecond,
+				Operator:  structs.DeploymentAnalysisOperatorLess,
+				Threshold: 0.1,
+			}
+
+			// Synthesize running alloc and tasks
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+			alloc.TaskStates = map[string]*structs.TaskState{
+				task.Name: {
+					State:     structs.TaskStateRunning,
+					StartedAt: time.Now(),
+				},
+			}
+
+			logger := testlog.HCLogger(t)
+			b := cstructs.NewAllocBroadcaster(logger)
+			defer b.Close()
+
+			consul := regMock.NewServiceRegistrationHandler(logger)
+			ctx, cancelFn := context.WithCancel(context.Background())
+			defer cancelFn()
+
+			tracker := NewTracker(ctx, logger, alloc, b.Listen(), consul,
+				time.Millisecond, false)
+			tracker.Start()
+
+			select {
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timed out while waiting for health")
+			case h := <-tracker.HealthyCh():
+				require.Equal(t, tc.healthy, h)
+			}
+
+			if !tc.healthy {
+				events := tracker.TaskEvents()
+				require.Len(t, events, 1)
+				require.Contains(t, events[task.Name].Message, "analysis value 0.5 is not < 0.1")
+			}
+		})
+	}
+}
+
+func TestTracker_Analysis_NotDeployment(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	alloc.Job.TaskGroups[0].Update.Analysis = &structs.DeploymentAnalysis{
+		Address:  "http://127.0.0.1:1",
+		Query:    "up",
+		Interval: time.Second,
+		Operator: structs.DeploymentAnalysisOperatorGreater,
+	}
+
+	logger := testlog.HCLogger(t)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
+
+	tracker := NewTracker(context.Background(), logger, alloc, b.Listen(),
+		regMock.NewServiceRegistrationHandler(logger), time.Millisecond, false)
+	require.Nil(t, tracker.analysis)
+}
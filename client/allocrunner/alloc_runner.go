@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/serviceregistration"
@@ -126,6 +127,10 @@ type allocRunner struct {
 	// transistions.
 	runnerHooks []interfaces.RunnerHook
 
+	// networkHook manages the allocation network and is used to measure its
+	// usage. It is set when the runner hooks are initialized.
+	networkHook *networkHook
+
 	// hookState is the output of allocrunner hooks
 	hookState   *cstructs.AllocHookResources
 	hookStateMu sync.RWMutex
@@ -136,6 +141,12 @@ type allocRunner struct {
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// unhealthyDevices are the allocated device instances already reported
+	// unhealthy, so tasks are only restarted or failed once per instance
+	// until it's healthy again
+	unhealthyDevices     map[string]struct{}
+	unhealthyDevicesLock sync.Mutex
+
 	// allocBroadcaster sends client allocation updates to all listeners
 	allocBroadcaster *cstructs.AllocBroadcaster
 
@@ -157,6 +168,10 @@ type allocRunner struct {
 	// cpusetManager is responsible for configuring task cgroups if supported by the platform
 	cpusetManager cgutil.CpusetManager
 
+	// userNamespacePool allocates host ID ranges for user namespaces. It is
+	// nil if user namespaces are not enabled on the client.
+	userNamespacePool *userns.Pool
+
 	// devicemanager is used to mount devices as well as lookup device
 	// statistics
 	devicemanager devicemanager.Manager
@@ -224,6 +239,7 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		dynamicRegistry:          config.DynamicRegistry,
 		csiManager:               config.CSIManager,
 		cpusetManager:            config.CpusetManager,
+		userNamespacePool:        config.UserNamespacePool,
 		devicemanager:            config.DeviceManager,
 		driverManager:            config.DriverManager,
 		serversContactedCh:       config.ServersContactedCh,
@@ -240,6 +256,7 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 
 	// Create alloc dir
 	ar.allocDir = allocdir.NewAllocDir(ar.logger, config.ClientConfig.AllocDir, alloc.ID)
+	ar.allocDir.SetDiskQuota(allocdir.DiskQuotaMode(config.ClientConfig.DiskQuota), ephemeralDiskSizeMB(alloc))
 
 	ar.taskHookCoordinator = newTaskHookCoordinator(ar.logger, tg.Tasks)
 
@@ -445,6 +462,20 @@ func (ar *allocRunner) Restore() error {
 	ar.state.NetworkStatus = ns
 	ar.stateLock.Unlock()
 
+	// Reclaim the alloc's user namespace range before any new allocs are
+	// able to claim it.
+	if ar.userNamespacePool != nil {
+		uns, err := ar.stateDB.GetUserNamespace(ar.id)
+		if err != nil {
+			return err
+		}
+		if uns != nil {
+			if err := ar.userNamespacePool.Reserve(ar.id, uns.HostID); err != nil {
+				ar.logger.Warn("failed to reclaim user namespace range", "error", err)
+			}
+		}
+	}
+
 	states := make(map[string]*structs.TaskState)
 
 	// Restore task runners
@@ -1171,6 +1202,21 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 		}
 	}
 
+	// The network is shared by the tasks so it is only measured for the
+	// whole allocation
+	if taskFilter == "" && ar.networkHook != nil {
+		astat.NetworkStats = ar.networkHook.NetworkStats()
+	}
+
+	// The ephemeral disk is also shared by the tasks
+	if taskFilter == "" {
+		stats, err := ar.allocDir.DiskUsage()
+		if err != nil {
+			ar.logger.Debug("failed to measure ephemeral disk usage", "error", err)
+		}
+		astat.DiskStats = stats
+	}
+
 	return astat, nil
 }
 
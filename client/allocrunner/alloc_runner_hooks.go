@@ -141,16 +141,22 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 	// newNetworkHook.
 	builtTaskEnv := envBuilder.Build()
 
+	// Create the network hook, which is also used to measure the usage of the
+	// allocation network.
+	alloc := ar.Alloc()
+	ar.networkHook = newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, builtTaskEnv, config.StatsCollectionInterval)
+
 	// Create the alloc directory hook. This is run first to ensure the
 	// directory path exists for other hooks.
-	alloc := ar.Alloc()
 	ar.runnerHooks = []interfaces.RunnerHook{
 		newAllocDirHook(hookLogger, ar.allocDir),
+		newDiskQuotaHook(hookLogger, ar.allocDir, &allocDiskQuotaTaskKiller{ar: ar}, ephemeralDiskSizeMB(alloc)),
+		newUserNamespaceHook(hookLogger, ar.id, ar.allocDir, ar.userNamespacePool, ar.stateDB, &allocUserNamespaceSetter{ar: ar}),
 		newCgroupHook(ar.Alloc(), ar.cpusetManager),
 		newUpstreamAllocsHook(hookLogger, ar.prevAllocWatcher),
 		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
 		newAllocHealthWatcherHook(hookLogger, alloc, hs, ar.Listener(), ar.consulClient),
-		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, builtTaskEnv),
+		ar.networkHook,
 		newGroupServiceHook(groupServiceHookConfig{
 			alloc:               alloc,
 			namespace:           alloc.ServiceProviderNamespace(),
@@ -169,6 +175,15 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 	return nil
 }
 
+// ephemeralDiskSizeMB returns the size of the alloc's ephemeral disk
+func ephemeralDiskSizeMB(alloc *structs.Allocation) int {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.EphemeralDisk == nil {
+		return 0
+	}
+	return tg.EphemeralDisk.SizeMB
+}
+
 // prerun is used to run the runners prerun hooks.
 func (ar *allocRunner) prerun() error {
 	if ar.logger.IsTrace() {
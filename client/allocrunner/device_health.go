@@ -0,0 +1,124 @@
+package allocrunner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HandleUnhealthyDevices reacts to the device instances allocated to the
+// tasks becoming unhealthy, according to the on_unhealthy policy of the
+// device requests they were allocated for. unhealthy maps the IDs of all the
+// unhealthy device instances of the node to their health description.
+func (ar *allocRunner) HandleUnhealthyDevices(unhealthy map[string]string) {
+	alloc := ar.Alloc()
+	if alloc.AllocatedResources == nil || alloc.ClientTerminalStatus() {
+		return
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return
+	}
+
+	ar.unhealthyDevicesLock.Lock()
+	reported := make(map[string]struct{})
+	actions := make(map[string]*structs.TaskEvent)
+	policies := make(map[string]string)
+	for taskName, taskRes := range alloc.AllocatedResources.Tasks {
+		task := tg.LookupTask(taskName)
+		if task == nil || task.Resources == nil {
+			continue
+		}
+
+		policy := structs.DeviceOnUnhealthyIgnore
+		var reasons []string
+		for _, dev := range taskRes.Devices {
+			for _, id := range dev.DeviceIDs {
+				desc, ok := unhealthy[id]
+				if !ok {
+					continue
+				}
+				reported[id] = struct{}{}
+				if _, ok := ar.unhealthyDevices[id]; ok {
+					continue
+				}
+
+				p := deviceOnUnhealthyPolicy(task.Resources.Devices, dev)
+				if p == structs.DeviceOnUnhealthyIgnore {
+					continue
+				}
+				if p == structs.DeviceOnUnhealthyReschedule {
+					policy = p
+				} else if policy == structs.DeviceOnUnhealthyIgnore {
+					policy = p
+				}
+
+				reason := fmt.Sprintf("%s instance %s", dev.ID(), id)
+				if desc != "" {
+					reason = fmt.Sprintf("%s (%s)", reason, desc)
+				}
+				reasons = append(reasons, reason)
+			}
+		}
+
+		if policy == structs.DeviceOnUnhealthyIgnore {
+			continue
+		}
+		sort.Strings(reasons)
+		msg := fmt.Sprintf("Device unhealthy: %s", strings.Join(reasons, ", "))
+		actions[taskName] = structs.NewTaskEvent(structs.TaskDeviceUnhealthy).SetMessage(msg)
+		policies[taskName] = policy
+	}
+	ar.unhealthyDevices = reported
+	ar.unhealthyDevicesLock.Unlock()
+
+	var wg sync.WaitGroup
+	for taskName, event := range actions {
+		tr, ok := ar.tasks[taskName]
+		if !ok || tr.TaskState().State == structs.TaskStateDead {
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, tr *taskrunner.TaskRunner, event *structs.TaskEvent, policy string) {
+			defer wg.Done()
+
+			var err error
+			if policy == structs.DeviceOnUnhealthyReschedule {
+				ar.logger.Info("device unhealthy, failing task", "task_name", name)
+				err = tr.Kill(context.TODO(), event.SetFailsTask())
+			} else {
+				ar.logger.Info("device unhealthy, restarting task", "task_name", name)
+				err = tr.Restart(context.TODO(), event, true)
+			}
+			if err != nil && err != taskrunner.ErrTaskNotRunning {
+				ar.logger.Warn("error reacting to unhealthy device", "error", err, "task_name", name)
+			}
+		}(taskName, tr, event, policies[taskName])
+	}
+	wg.Wait()
+}
+
+// deviceOnUnhealthyPolicy returns the on_unhealthy policy of the device
+// request an allocated device was selected for. When several requests
+// match, the most disruptive policy wins.
+func deviceOnUnhealthyPolicy(requests []*structs.RequestedDevice, dev *structs.AllocatedDeviceResource) string {
+	policy := structs.DeviceOnUnhealthyIgnore
+	for _, req := range requests {
+		if !dev.ID().Matches(req.ID()) {
+			continue
+		}
+		switch req.OnUnhealthy {
+		case structs.DeviceOnUnhealthyReschedule:
+			return req.OnUnhealthy
+		case structs.DeviceOnUnhealthyRestart:
+			policy = req.OnUnhealthy
+		}
+	}
+	return policy
+}
@@ -0,0 +1,122 @@
+package allocrunner
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/devicemanager"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/device"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceOnUnhealthyPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	dev := &structs.AllocatedDeviceResource{
+		Vendor: "nvidia", Type: "gpu", Name: "1080ti", DeviceIDs: []string{"gpu-1"},
+	}
+
+	require.Equal(t, structs.DeviceOnUnhealthyIgnore, deviceOnUnhealthyPolicy(
+		[]*structs.RequestedDevice{{Name: "nvidia/gpu", Count: 1}}, dev))
+	require.Equal(t, structs.DeviceOnUnhealthyIgnore, deviceOnUnhealthyPolicy(
+		[]*structs.RequestedDevice{
+			{Name: "amd/gpu", Count: 1, OnUnhealthy: structs.DeviceOnUnhealthyReschedule},
+		}, dev))
+	require.Equal(t, structs.DeviceOnUnhealthyRestart, deviceOnUnhealthyPolicy(
+		[]*structs.RequestedDevice{
+			{Name: "gpu", Count: 1, OnUnhealthy: structs.DeviceOnUnhealthyRestart},
+		}, dev))
+
+	// The most disruptive policy of the matching requests wins
+	require.Equal(t, structs.DeviceOnUnhealthyReschedule, deviceOnUnhealthyPolicy(
+		[]*structs.RequestedDevice{
+			{Name: "gpu", Count: 1, OnUnhealthy: structs.DeviceOnUnhealthyRestart},
+			{Name: "nvidia/gpu/1080ti", Count: 1, OnUnhealthy: structs.DeviceOnUnhealthyReschedule},
+		}, dev))
+}
+
+func TestAllocRunner_HandleUnhealthyDevices_Reschedule(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.RestartPolicy.Attempts = 0
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.RestartPolicy.Attempts = 0
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+	task.Resources.Devices = []*structs.RequestedDevice{{
+		Name:        "nvidia/gpu",
+		Count:       1,
+		OnUnhealthy: structs.DeviceOnUnhealthyReschedule,
+	}}
+	alloc.AllocatedResources.Tasks[task.Name].Devices = []*structs.AllocatedDeviceResource{{
+		Vendor:    "nvidia",
+		Type:      "gpu",
+		Name:      "1080ti",
+		DeviceIDs: []string{"gpu-1"},
+	}}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+
+	// Setup the devicemanager
+	dm, ok := conf.DeviceManager.(*devicemanager.MockManager)
+	require.True(t, ok)
+	dm.ReserveF = func(d *structs.AllocatedDeviceResource) (*device.ContainerReservation, error) {
+		return &device.ContainerReservation{}, nil
+	}
+
+	ar, err := NewAllocRunner(conf)
+	require.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+	upd := conf.StateUpdater.(*MockStateUpdater)
+
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("no updates")
+		}
+		if last.ClientStatus != structs.AllocClientStatusRunning {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusRunning)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// Unhealthy devices not allocated to the tasks are ignored
+	ar.HandleUnhealthyDevices(map[string]string{"gpu-2": "overheating"})
+	require.Equal(t, structs.AllocClientStatusRunning, ar.AllocState().ClientStatus)
+
+	ar.HandleUnhealthyDevices(map[string]string{"gpu-1": "overheating"})
+
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last.ClientStatus != structs.AllocClientStatusFailed {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusFailed)
+		}
+		state := last.TaskStates[task.Name]
+		if !state.Failed {
+			return false, fmt.Errorf("task should have failed")
+		}
+		for _, e := range state.Events {
+			if e.Type == structs.TaskDeviceUnhealthy {
+				if e.Message != "Device unhealthy: nvidia/gpu/1080ti instance gpu-1 (overheating)" {
+					return false, fmt.Errorf("unexpected event message %q", e.Message)
+				}
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("missing %q event", structs.TaskDeviceUnhealthy)
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
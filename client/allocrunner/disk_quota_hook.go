@@ -0,0 +1,156 @@
+package allocrunner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// diskQuotaCheckInterval is how often the ephemeral disk usage is
+	// compared to its size
+	diskQuotaCheckInterval = 5 * time.Second
+)
+
+// diskUsageGetter returns the usage of an allocation's ephemeral disk
+type diskUsageGetter interface {
+	DiskQuotaEnabled() bool
+	DiskUsage() (*cstructs.DiskStats, error)
+}
+
+type diskQuotaTaskKiller interface {
+	KillTasks(event *structs.TaskEvent)
+}
+
+// allocDiskQuotaTaskKiller is a shim to allow the disk quota hook to kill
+// the alloc's tasks without full access to the alloc runner
+type allocDiskQuotaTaskKiller struct {
+	ar *allocRunner
+}
+
+// KillTasks kills the alloc's live tasks concurrently, emitting the event
+// for each of them.
+func (a *allocDiskQuotaTaskKiller) KillTasks(event *structs.TaskEvent) {
+	var wg sync.WaitGroup
+	for name, tr := range a.ar.tasks {
+		if tr.TaskState().State == structs.TaskStateDead {
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, tr *taskrunner.TaskRunner) {
+			defer wg.Done()
+			err := tr.Kill(context.TODO(), event.Copy())
+			if err != nil && err != taskrunner.ErrTaskNotRunning {
+				a.ar.logger.Warn("error killing task", "error", err, "task_name", name)
+			}
+		}(name, tr)
+	}
+	wg.Wait()
+}
+
+// diskQuotaHook is an alloc lifecycle hook that watches the usage of the
+// alloc's ephemeral disk and kills its tasks once the usage exceeds the
+// ephemeral disk size. The alloc dir only fails writes a little past the
+// size, so tasks are killed with an event explaining why they failed.
+type diskQuotaHook struct {
+	usage    diskUsageGetter
+	killer   diskQuotaTaskKiller
+	sizeMB   int
+	interval time.Duration
+
+	// cancel stops watching the usage
+	cancel     context.CancelFunc
+	cancelLock sync.Mutex
+
+	logger hclog.Logger
+}
+
+func newDiskQuotaHook(logger hclog.Logger, usage diskUsageGetter, killer diskQuotaTaskKiller, sizeMB int) *diskQuotaHook {
+	h := &diskQuotaHook{
+		usage:    usage,
+		killer:   killer,
+		sizeMB:   sizeMB,
+		interval: diskQuotaCheckInterval,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *diskQuotaHook) Name() string {
+	return "disk_quota"
+}
+
+func (h *diskQuotaHook) Prerun() error {
+	if !h.usage.DiskQuotaEnabled() {
+		return nil
+	}
+
+	h.cancelLock.Lock()
+	defer h.cancelLock.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go h.watch(ctx)
+	return nil
+}
+
+func (h *diskQuotaHook) Postrun() error {
+	h.stop()
+	return nil
+}
+
+func (h *diskQuotaHook) Destroy() error {
+	h.stop()
+	return nil
+}
+
+func (h *diskQuotaHook) Shutdown() {
+	h.stop()
+}
+
+func (h *diskQuotaHook) stop() {
+	h.cancelLock.Lock()
+	defer h.cancelLock.Unlock()
+
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// watch kills the tasks once the ephemeral disk usage exceeds its size
+func (h *diskQuotaHook) watch(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := h.usage.DiskUsage()
+		if err != nil {
+			h.logger.Warn("failed to measure ephemeral disk usage", "error", err)
+			continue
+		}
+		if stats == nil || stats.Used <= stats.Size {
+			continue
+		}
+
+		h.logger.Info("ephemeral disk size exceeded, killing tasks",
+			"used", stats.Used, "size", stats.Size)
+		event := structs.NewTaskEvent(structs.TaskDiskExceeded).
+			SetDiskLimit(int64(h.sizeMB)).
+			SetFailsTask()
+		h.killer.KillTasks(event)
+		return
+	}
+}
@@ -0,0 +1,112 @@
+package allocrunner
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// statically assert disk quota hook implements the expected interfaces
+var _ interfaces.RunnerPrerunHook = (*diskQuotaHook)(nil)
+var _ interfaces.RunnerPostrunHook = (*diskQuotaHook)(nil)
+var _ interfaces.RunnerDestroyHook = (*diskQuotaHook)(nil)
+var _ interfaces.ShutdownHook = (*diskQuotaHook)(nil)
+
+type mockDiskUsage struct {
+	enabled bool
+	stats   *cstructs.DiskStats
+	lock    sync.Mutex
+}
+
+func (m *mockDiskUsage) DiskQuotaEnabled() bool {
+	return m.enabled
+}
+
+func (m *mockDiskUsage) DiskUsage() (*cstructs.DiskStats, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.stats, nil
+}
+
+func (m *mockDiskUsage) setUsed(used uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stats = &cstructs.DiskStats{Used: used, Size: 10 * 1024 * 1024}
+}
+
+type mockTaskKiller struct {
+	events []*structs.TaskEvent
+	lock   sync.Mutex
+}
+
+func (m *mockTaskKiller) KillTasks(event *structs.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *mockTaskKiller) killed() []*structs.TaskEvent {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.events
+}
+
+func TestDiskQuotaHook_KillsTasks(t *testing.T) {
+	ci.Parallel(t)
+
+	usage := &mockDiskUsage{enabled: true}
+	usage.setUsed(5 * 1024 * 1024)
+	killer := &mockTaskKiller{}
+
+	h := newDiskQuotaHook(testlog.HCLogger(t), usage, killer, 10)
+	h.interval = 10 * time.Millisecond
+	require.NoError(t, h.Prerun())
+	defer h.Destroy()
+
+	// Usage below the size is tolerated
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, killer.killed())
+
+	usage.setUsed(11 * 1024 * 1024)
+	testutil.WaitForResult(func() (bool, error) {
+		return len(killer.killed()) == 1, nil
+	}, func(err error) {
+		t.Fatalf("tasks were not killed")
+	})
+
+	event := killer.killed()[0]
+	require.Equal(t, structs.TaskDiskExceeded, event.Type)
+	require.True(t, event.FailsTask)
+	require.Equal(t, int64(10), event.DiskLimit)
+	event.PopulateEventDisplayMessage()
+	require.Equal(t, "Ephemeral disk usage exceeded the 10 MB limit", event.DisplayMessage)
+
+	// Tasks are only killed once
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, killer.killed(), 1)
+}
+
+func TestDiskQuotaHook_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	usage := &mockDiskUsage{}
+	usage.setUsed(11 * 1024 * 1024)
+	killer := &mockTaskKiller{}
+
+	h := newDiskQuotaHook(testlog.HCLogger(t), usage, killer, 10)
+	h.interval = 10 * time.Millisecond
+	require.NoError(t, h.Prerun())
+	require.Nil(t, h.cancel)
+
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, killer.killed())
+	require.NoError(t, h.Postrun())
+}
@@ -3,8 +3,11 @@ package allocrunner
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -66,7 +69,8 @@ type networkHook struct {
 	alloc *structs.Allocation
 
 	// spec described the network namespace and is syncronized by specLock
-	spec *drivers.NetworkIsolationSpec
+	spec     *drivers.NetworkIsolationSpec
+	specLock sync.RWMutex
 
 	// networkConfigurator configures the network interfaces, routes, etc once
 	// the alloc network has been created
@@ -75,6 +79,16 @@ type networkHook struct {
 	// taskEnv is used to perform interpolation within the network blocks.
 	taskEnv *taskenv.TaskEnv
 
+	// statsInterval is the interval at which the usage of the network is
+	// collected
+	statsInterval time.Duration
+
+	// stats is the latest usage of the network and is synchronized by
+	// statsLock. statsCancel stops its collection.
+	stats       *cstructs.NetworkStats
+	statsCancel context.CancelFunc
+	statsLock   sync.RWMutex
+
 	logger hclog.Logger
 }
 
@@ -85,6 +99,7 @@ func newNetworkHook(logger hclog.Logger,
 	netConfigurator NetworkConfigurator,
 	networkStatusSetter networkStatusSetter,
 	taskEnv *taskenv.TaskEnv,
+	statsInterval time.Duration,
 ) *networkHook {
 	return &networkHook{
 		isolationSetter:     ns,
@@ -93,6 +108,7 @@ func newNetworkHook(logger hclog.Logger,
 		manager:             netManager,
 		networkConfigurator: netConfigurator,
 		taskEnv:             taskEnv,
+		statsInterval:       statsInterval,
 		logger:              logger,
 	}
 }
@@ -135,7 +151,9 @@ func (h *networkHook) Prerun() error {
 	}
 
 	if spec != nil {
+		h.specLock.Lock()
 		h.spec = spec
+		h.specLock.Unlock()
 		h.isolationSetter.SetNetworkIsolation(spec)
 	}
 
@@ -171,10 +189,16 @@ func (h *networkHook) Prerun() error {
 
 		h.networkStatusSetter.SetNetworkStatus(status)
 	}
+
+	if spec != nil {
+		h.startStatsCollection(spec)
+	}
 	return nil
 }
 
 func (h *networkHook) Postrun() error {
+	h.stopStatsCollection()
+
 	if h.spec == nil {
 		return nil
 	}
@@ -184,3 +208,72 @@ func (h *networkHook) Postrun() error {
 	}
 	return h.manager.DestroyNetwork(h.alloc.ID, h.spec)
 }
+
+// NetworkStats returns the latest usage of the allocation network, or nil if
+// the network configurator cannot measure it.
+func (h *networkHook) NetworkStats() *cstructs.NetworkStats {
+	h.statsLock.RLock()
+	defer h.statsLock.RUnlock()
+	return h.stats
+}
+
+// startStatsCollection starts collecting the usage of the allocation network
+// if the network configurator can measure it. The usage is collected by a
+// single goroutine, so that the throughput is measured over the collection
+// interval however often it is read.
+func (h *networkHook) startStatsCollection(spec *drivers.NetworkIsolationSpec) {
+	c, ok := h.networkConfigurator.(networkStatsCollector)
+	if !ok {
+		return
+	}
+
+	h.statsLock.Lock()
+	defer h.statsLock.Unlock()
+
+	// This shouldn't happen, but better safe than risk leaking a goroutine
+	if h.statsCancel != nil {
+		h.statsCancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.statsCancel = cancel
+	go h.collectStats(ctx, c, spec)
+}
+
+// stopStatsCollection stops collecting the usage of the allocation network
+func (h *networkHook) stopStatsCollection() {
+	h.statsLock.Lock()
+	defer h.statsLock.Unlock()
+
+	if h.statsCancel != nil {
+		h.statsCancel()
+		h.statsCancel = nil
+	}
+}
+
+// collectStats collects the usage of the allocation network every
+// statsInterval until ctx is done.
+func (h *networkHook) collectStats(ctx context.Context, c networkStatsCollector, spec *drivers.NetworkIsolationSpec) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			timer.Reset(h.statsInterval)
+		}
+
+		stats, err := c.NetworkStats(h.alloc, spec)
+		if err != nil {
+			h.logger.Debug("failed to measure allocation network usage", "error", err)
+		}
+
+		h.statsLock.Lock()
+		if ctx.Err() == nil {
+			h.stats = stats
+		}
+		h.statsLock.Unlock()
+	}
+}
@@ -1,10 +1,13 @@
 package allocrunner
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -86,7 +89,7 @@ func TestNetworkHook_Prerun_Postrun(t *testing.T) {
 	envBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
 
 	logger := testlog.HCLogger(t)
-	hook := newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build())
+	hook := newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build(), time.Second)
 	require.NoError(hook.Prerun())
 	require.True(setter.called)
 	require.False(destroyCalled)
@@ -97,10 +100,99 @@ func TestNetworkHook_Prerun_Postrun(t *testing.T) {
 	setter.called = false
 	destroyCalled = false
 	alloc.Job.TaskGroups[0].Networks[0].Mode = "host"
-	hook = newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build())
+	hook = newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build(), time.Second)
 	require.NoError(hook.Prerun())
 	require.False(setter.called)
 	require.False(destroyCalled)
 	require.NoError(hook.Postrun())
 	require.False(destroyCalled)
 }
+
+// countingNetworkConfigurator is a NetworkConfigurator whose usage grows by
+// 1000 bytes every time it is measured
+type countingNetworkConfigurator struct {
+	hostNetworkConfigurator
+
+	calls int
+	lock  sync.Mutex
+}
+
+func (c *countingNetworkConfigurator) NetworkStats(*structs.Allocation, *drivers.NetworkIsolationSpec) (*cstructs.NetworkStats, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calls++
+	return &cstructs.NetworkStats{
+		RxBytes: uint64(c.calls * 1000),
+		TxBytes: uint64(c.calls * 1000),
+	}, nil
+}
+
+func (c *countingNetworkConfigurator) Calls() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.calls
+}
+
+// Test that the usage of the network is measured by the hook at its own
+// interval, so interleaved readers neither skew nor observe each other.
+func TestNetworkHook_NetworkStats(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{
+		{
+			Mode: "bridge",
+		},
+	}
+	spec := &drivers.NetworkIsolationSpec{
+		Mode: drivers.NetIsolationModeGroup,
+		Path: "test",
+	}
+	nm := &testutils.MockDriver{
+		MockNetworkManager: testutils.MockNetworkManager{
+			CreateNetworkF: func(string, *drivers.NetworkCreateRequest) (*drivers.NetworkIsolationSpec, bool, error) {
+				return spec, false, nil
+			},
+			DestroyNetworkF: func(string, *drivers.NetworkIsolationSpec) error {
+				return nil
+			},
+		},
+	}
+	setter := &mockNetworkIsolationSetter{t: t, expectedSpec: spec}
+	statusSetter := &mockNetworkStatusSetter{t: t}
+	nc := &countingNetworkConfigurator{}
+
+	envBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
+	hook := newNetworkHook(testlog.HCLogger(t), setter, alloc, nm, nc, statusSetter, envBuilder.Build(), time.Hour)
+	require.Nil(t, hook.NetworkStats())
+	require.NoError(t, hook.Prerun())
+
+	require.Eventually(t, func() bool {
+		return hook.NetworkStats() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Two callers reading interleaved get the same measurement and do not
+	// trigger measurements of their own
+	var wg sync.WaitGroup
+	results := make([][]*cstructs.NetworkStats, 2)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				results[i] = append(results[i], hook.NetworkStats())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	expected := &cstructs.NetworkStats{RxBytes: 1000, TxBytes: 1000}
+	for _, stats := range results {
+		for _, s := range stats {
+			require.Equal(t, expected, s)
+		}
+	}
+	require.Equal(t, 1, nc.Calls())
+
+	require.NoError(t, hook.Postrun())
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -173,7 +174,7 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 
 	switch {
 	case netMode == "bridge":
-		c, err := newBridgeNetworkConfigurator(log, config.BridgeNetworkName, config.BridgeNetworkAllocSubnet, config.CNIPath, ignorePortMappingHostIP)
+		c, err := newBridgeNetworkConfigurator(log, config.BridgeNetworkName, config.BridgeNetworkAllocSubnet, config.CNIPath, ignorePortMappingHostIP, nodeLinkSpeed(config.Node))
 		if err != nil {
 			return nil, err
 		}
@@ -188,3 +189,16 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 		return &hostNetworkConfigurator{}, nil
 	}
 }
+
+// nodeLinkSpeed returns the fingerprinted speed of the node's network link in
+// Mb/s, or zero if it is unknown
+func nodeLinkSpeed(node *structs.Node) int {
+	if node == nil {
+		return 0
+	}
+	speed, err := strconv.Atoi(node.Attributes["network.link_speed"])
+	if err != nil {
+		return 0
+	}
+	return speed
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/pluginmanager"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/drivers/testutils"
 	"github.com/stretchr/testify/require"
@@ -63,6 +65,10 @@ func (m *mockDriverManager) Dispense(driver string) (drivers.DriverPlugin, error
 	return mockDrivers[driver], nil
 }
 
+func (m *mockDriverManager) ReloadPluginConfig(id loader.PluginID, config *base.Config) error {
+	return nil
+}
+
 func TestNewNetworkManager(t *testing.T) {
 	ci.Parallel(t)
 
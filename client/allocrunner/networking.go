@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -15,6 +16,12 @@ type NetworkConfigurator interface {
 	Teardown(context.Context, *structs.Allocation, *drivers.NetworkIsolationSpec) error
 }
 
+// networkStatsCollector is implemented by NetworkConfigurators able to
+// measure the usage of the allocation network they configured.
+type networkStatsCollector interface {
+	NetworkStats(*structs.Allocation, *drivers.NetworkIsolationSpec) (*cstructs.NetworkStats, error)
+}
+
 // hostNetworkConfigurator is a noop implementation of a NetworkConfigurator for
 // when the alloc join's a client host's network namespace and thus does not
 // require further configuration
@@ -49,3 +56,13 @@ func (s *synchronizedNetworkConfigurator) Teardown(ctx context.Context, allocati
 	defer networkingGlobalMutex.Unlock()
 	return s.nc.Teardown(ctx, allocation, spec)
 }
+
+// NetworkStats returns the usage of the allocation network if the wrapped
+// NetworkConfigurator can measure it. Reading it is not serialized with the
+// other network operations.
+func (s *synchronizedNetworkConfigurator) NetworkStats(allocation *structs.Allocation, spec *drivers.NetworkIsolationSpec) (*cstructs.NetworkStats, error) {
+	if c, ok := s.nc.(networkStatsCollector); ok {
+		return c.NetworkStats(allocation, spec)
+	}
+	return nil, nil
+}
@@ -3,11 +3,18 @@ package allocrunner
 import (
 	"context"
 	"fmt"
+	"net"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/coreos/go-iptables/iptables"
 	hclog "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
 )
 
 const (
@@ -26,6 +33,18 @@ const (
 	// cniAdminChainName is the name of the admin iptables chain used to allow
 	// forwarding traffic to allocations
 	cniAdminChainName = "NOMAD-ADMIN"
+
+	// bridgeShapingIfbPrefix is the prefix of the ifb device the traffic sent
+	// by an allocation is redirected to so it can be shaped
+	bridgeShapingIfbPrefix = "nmdifb"
+
+	// bridgeShapingLatency is the longest a packet may be queued by the token
+	// bucket shaping the allocation's bandwidth before it is dropped
+	bridgeShapingLatency = 25 * time.Millisecond
+
+	// bridgeShapingMinBurst is the smallest token bucket size in bytes, which
+	// must hold at least a full segmentation offloaded packet
+	bridgeShapingMinBurst = 64 * 1024
 )
 
 // bridgeNetworkConfigurator is a NetworkConfigurator which adds the alloc to a
@@ -36,13 +55,31 @@ type bridgeNetworkConfigurator struct {
 	allocSubnet string
 	bridgeName  string
 
+	// linkSpeed is the fingerprinted speed of the host's network link in
+	// Mb/s, or zero if unknown. Allocations asking for as much bandwidth are
+	// not shaped.
+	linkSpeed int
+
+	// lastStats is the previous sample of the allocation's network counters,
+	// used to measure its throughput
+	lastStats     *bridgeStatsSample
+	lastStatsLock sync.Mutex
+
 	logger hclog.Logger
 }
 
-func newBridgeNetworkConfigurator(log hclog.Logger, bridgeName, ipRange, cniPath string, ignorePortMappingHostIP bool) (*bridgeNetworkConfigurator, error) {
+// bridgeStatsSample is a sample of the bytes transferred by an allocation
+type bridgeStatsSample struct {
+	rxBytes   uint64
+	txBytes   uint64
+	timestamp time.Time
+}
+
+func newBridgeNetworkConfigurator(log hclog.Logger, bridgeName, ipRange, cniPath string, ignorePortMappingHostIP bool, linkSpeed int) (*bridgeNetworkConfigurator, error) {
 	b := &bridgeNetworkConfigurator{
 		bridgeName:  bridgeName,
 		allocSubnet: ipRange,
+		linkSpeed:   linkSpeed,
 		logger:      log,
 	}
 
@@ -120,18 +157,265 @@ func (b *bridgeNetworkConfigurator) generateAdminChainRule() []string {
 	return []string{"-o", b.bridgeName, "-d", b.allocSubnet, "-j", "ACCEPT"}
 }
 
-// Setup calls the CNI plugins with the add action
+// Setup calls the CNI plugins with the add action and shapes the bandwidth of
+// the allocation if the group network sets mbits
 func (b *bridgeNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec) (*structs.AllocNetworkStatus, error) {
 	if err := b.ensureForwardingRules(); err != nil {
 		return nil, fmt.Errorf("failed to initialize table forwarding rules: %v", err)
 	}
 
-	return b.cni.Setup(ctx, alloc, spec)
+	status, err := b.cni.Setup(ctx, alloc, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if mbits := b.bandwidthLimit(alloc); mbits > 0 {
+		if err := b.shapeBandwidth(alloc, spec, mbits); err != nil {
+			return nil, fmt.Errorf("failed to shape network bandwidth: %v", err)
+		}
+	}
+
+	return status, nil
 }
 
-// Teardown calls the CNI plugins with the delete action
+// Teardown calls the CNI plugins with the delete action and removes the
+// device used to shape the traffic sent by the allocation
 func (b *bridgeNetworkConfigurator) Teardown(ctx context.Context, alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec) error {
-	return b.cni.Teardown(ctx, alloc, spec)
+	err := b.cni.Teardown(ctx, alloc, spec)
+
+	// Deleting the veth pair removes the qdiscs on it but not the ifb device
+	if ifbErr := deleteLinkByName(bridgeShapingIfbName(alloc)); ifbErr != nil && err == nil {
+		err = fmt.Errorf("failed to delete ifb device: %v", ifbErr)
+	}
+	return err
+}
+
+// NetworkStats returns the bytes transferred by the allocation and its
+// throughput since the previous call, measured on the host side of its veth.
+// It is called periodically by a single collector, the network hook, so that
+// the throughput is measured over the collection interval.
+func (b *bridgeNetworkConfigurator) NetworkStats(alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec) (*cstructs.NetworkStats, error) {
+	hostVeth, err := allocHostVeth(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Traffic received by the host side of the veth is sent by the
+	// allocation and vice versa
+	counters := hostVeth.Attrs().Statistics
+	if counters == nil {
+		return nil, fmt.Errorf("no statistics for device %s", hostVeth.Attrs().Name)
+	}
+	sample := &bridgeStatsSample{
+		rxBytes:   counters.TxBytes,
+		txBytes:   counters.RxBytes,
+		timestamp: time.Now(),
+	}
+
+	stats := &cstructs.NetworkStats{
+		RxBytes:    sample.rxBytes,
+		TxBytes:    sample.txBytes,
+		LimitMBits: b.bandwidthLimit(alloc),
+		Measured:   []string{"RxBytes", "TxBytes"},
+	}
+
+	b.lastStatsLock.Lock()
+	defer b.lastStatsLock.Unlock()
+
+	// Counters going backwards mean the veth was recreated, in which case the
+	// throughput is measured from the next sample on
+	if last := b.lastStats; last != nil && sample.rxBytes >= last.rxBytes && sample.txBytes >= last.txBytes {
+		if elapsed := sample.timestamp.Sub(last.timestamp).Seconds(); elapsed > 0 {
+			stats.RxMBits = float64(sample.rxBytes-last.rxBytes) * 8 / elapsed / 1e6
+			stats.TxMBits = float64(sample.txBytes-last.txBytes) * 8 / elapsed / 1e6
+			stats.Measured = append(stats.Measured, "RxMBits", "TxMBits")
+		}
+	}
+	b.lastStats = sample
+
+	return stats, nil
+}
+
+// bandwidthLimit returns the bandwidth in Mb/s the allocation is shaped to in
+// each direction, or zero if it is not shaped.
+func (b *bridgeNetworkConfigurator) bandwidthLimit(alloc *structs.Allocation) int {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || len(tg.Networks) == 0 {
+		return 0
+	}
+
+	mbits := tg.Networks[0].MBits
+	if b.linkSpeed > 0 && mbits >= b.linkSpeed {
+		// The link bounds the allocation's bandwidth already
+		return 0
+	}
+	return mbits
+}
+
+// shapeBandwidth limits the bandwidth of the allocation in each direction
+// with token bucket filters on the host side of its veth. Traffic sent to the
+// allocation is shaped as it leaves the veth. Traffic sent by the allocation
+// arrives on the veth, where it can only be policed, so it is redirected to
+// an ifb device and shaped as it leaves that device instead.
+//
+// This mirrors the CNI bandwidth plugin.
+func (b *bridgeNetworkConfigurator) shapeBandwidth(alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec, mbits int) error {
+	hostVeth, err := allocHostVeth(spec)
+	if err != nil {
+		return err
+	}
+
+	rate := uint64(mbits) * 1000 * 1000 / 8
+	if err := addTokenBucket(hostVeth.Attrs().Index, rate); err != nil {
+		return fmt.Errorf("failed to shape traffic to allocation: %v", err)
+	}
+
+	// Remove an ifb device leaked by an earlier client before recreating it
+	ifbName := bridgeShapingIfbName(alloc)
+	if err := deleteLinkByName(ifbName); err != nil {
+		return fmt.Errorf("failed to delete ifb device: %v", err)
+	}
+	ifb := &netlink.Ifb{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:  ifbName,
+			Flags: net.FlagUp,
+			MTU:   hostVeth.Attrs().MTU,
+		},
+	}
+	if err := netlink.LinkAdd(ifb); err != nil {
+		return fmt.Errorf("failed to create ifb device: %v", err)
+	}
+	ifbLink, err := netlink.LinkByName(ifbName)
+	if err != nil {
+		return fmt.Errorf("failed to find ifb device: %v", err)
+	}
+
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: hostVeth.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err := netlink.QdiscAdd(ingress); err != nil {
+		return fmt.Errorf("failed to create ingress qdisc: %v", err)
+	}
+
+	redirect := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: hostVeth.Attrs().Index,
+			Parent:    ingress.Handle,
+			Priority:  1,
+			Protocol:  syscall.ETH_P_ALL,
+		},
+		ClassId:    netlink.MakeHandle(1, 1),
+		RedirIndex: ifbLink.Attrs().Index,
+		Actions: []netlink.Action{
+			&netlink.MirredAction{
+				MirredAction: netlink.TCA_EGRESS_REDIR,
+				Ifindex:      ifbLink.Attrs().Index,
+			},
+		},
+	}
+	if err := netlink.FilterAdd(redirect); err != nil {
+		return fmt.Errorf("failed to redirect traffic from allocation: %v", err)
+	}
+
+	if err := addTokenBucket(ifbLink.Attrs().Index, rate); err != nil {
+		return fmt.Errorf("failed to shape traffic from allocation: %v", err)
+	}
+
+	b.logger.Debug("shaped allocation network bandwidth", "alloc_id", alloc.ID, "mbits", mbits,
+		"veth", hostVeth.Attrs().Name, "ifb", ifbName)
+	return nil
+}
+
+// addTokenBucket adds a root token bucket filter qdisc limiting the traffic
+// leaving the link to rate bytes per second. It is the equivalent of
+//
+//	tc qdisc add dev <link> root tbf rate <rate> burst <burst> latency 25ms
+func addTokenBucket(linkIndex int, rate uint64) error {
+	// Allow bursts of 10ms worth of traffic
+	burst := rate / 100
+	if burst < bridgeShapingMinBurst {
+		burst = bridgeShapingMinBurst
+	}
+
+	// The buffer is the time needed to send a full burst, in ticks
+	buffer := netlink.Xmittime(rate, uint32(burst))
+	latency := uint64(bridgeShapingLatency / time.Microsecond)
+	limit := rate*latency/netlink.TIME_UNITS_PER_SEC + burst
+
+	return netlink.QdiscAdd(&netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rate,
+		Limit:  uint32(limit),
+		Buffer: buffer,
+	})
+}
+
+// allocHostVeth returns the host side of the veth connecting the allocation
+// network namespace to the bridge
+func allocHostVeth(spec *drivers.NetworkIsolationSpec) (netlink.Link, error) {
+	if spec == nil || spec.Path == "" {
+		return nil, fmt.Errorf("allocation network namespace path not set")
+	}
+
+	ns, err := netns.GetFromPath(spec.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open network namespace: %v", err)
+	}
+	defer ns.Close()
+
+	handle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netlink handle in network namespace: %v", err)
+	}
+	defer handle.Delete()
+
+	name := bridgeNetworkAllocIfPrefix + "0"
+	allocIf, err := handle.LinkByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find device %s in network namespace: %v", name, err)
+	}
+
+	// The parent of a veth is its peer, which lives in the host namespace
+	peerIndex := allocIf.Attrs().ParentIndex
+	if allocIf.Type() != "veth" || peerIndex == 0 {
+		return nil, fmt.Errorf("device %s in network namespace is not a veth", name)
+	}
+
+	hostVeth, err := netlink.LinkByIndex(peerIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find veth peer of %s: %v", name, err)
+	}
+	return hostVeth, nil
+}
+
+// bridgeShapingIfbName returns the name of the ifb device shaping the traffic
+// sent by the allocation, which must fit in 15 characters
+func bridgeShapingIfbName(alloc *structs.Allocation) string {
+	id := alloc.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	return bridgeShapingIfbPrefix + id
+}
+
+// deleteLinkByName deletes the named link, if it exists
+func deleteLinkByName(name string) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+	return netlink.LinkDel(link)
 }
 
 func buildNomadBridgeNetConfig(bridgeName, subnet string) []byte {
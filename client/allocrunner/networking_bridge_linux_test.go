@@ -0,0 +1,149 @@
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+func TestBridgeNetworkConfigurator_bandwidthLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		mbits     int
+		linkSpeed int
+		expected  int
+	}{
+		{name: "unset", mbits: 0, linkSpeed: 1000, expected: 0},
+		{name: "below link speed", mbits: 100, linkSpeed: 1000, expected: 100},
+		{name: "unknown link speed", mbits: 2000, linkSpeed: 0, expected: 2000},
+		{name: "link speed", mbits: 1000, linkSpeed: 1000, expected: 0},
+		{name: "above link speed", mbits: 2000, linkSpeed: 1000, expected: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alloc := mock.Alloc()
+			alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{
+				{Mode: "bridge", MBits: tc.mbits},
+			}
+
+			b := &bridgeNetworkConfigurator{linkSpeed: tc.linkSpeed}
+			require.Equal(t, tc.expected, b.bandwidthLimit(alloc))
+		})
+	}
+}
+
+func TestBridgeNetworkConfigurator_shapeBandwidth(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{
+		{Mode: "bridge", MBits: 100},
+	}
+
+	// Create an allocation network namespace with a veth, the way the CNI
+	// bridge plugin does
+	nsName := "nomad-test-" + uuid.Short()
+	allocNS, err := nsutil.NewNS(nsName)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, allocNS.Close())
+		require.NoError(t, nsutil.UnmountNS(allocNS.Path()))
+	}()
+
+	hostName := "nmdveth" + uuid.Short()[:6]
+	peerName := "nmdpeer" + uuid.Short()[:6]
+	require.NoError(t, netlink.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: hostName},
+		PeerName:  peerName,
+	}))
+	defer deleteLinkByName(hostName)
+
+	hostVeth, err := netlink.LinkByName(hostName)
+	require.NoError(t, err)
+	require.NoError(t, netlink.LinkSetUp(hostVeth))
+
+	peer, err := netlink.LinkByName(peerName)
+	require.NoError(t, err)
+	require.NoError(t, netlink.LinkSetNsFd(peer, int(allocNS.Fd())))
+
+	nsHandle, err := netns.GetFromPath(allocNS.Path())
+	require.NoError(t, err)
+	defer nsHandle.Close()
+	handle, err := netlink.NewHandleAt(nsHandle)
+	require.NoError(t, err)
+	defer handle.Delete()
+
+	peer, err = handle.LinkByName(peerName)
+	require.NoError(t, err)
+	require.NoError(t, handle.LinkSetName(peer, bridgeNetworkAllocIfPrefix+"0"))
+	require.NoError(t, handle.LinkSetUp(peer))
+
+	spec := &drivers.NetworkIsolationSpec{
+		Mode: drivers.NetIsolationModeGroup,
+		Path: allocNS.Path(),
+	}
+
+	found, err := allocHostVeth(spec)
+	require.NoError(t, err)
+	require.Equal(t, hostName, found.Attrs().Name)
+
+	b := &bridgeNetworkConfigurator{logger: testlog.HCLogger(t)}
+	require.NoError(t, b.shapeBandwidth(alloc, spec, 100))
+
+	ifbName := bridgeShapingIfbName(alloc)
+	defer deleteLinkByName(ifbName)
+
+	// Traffic to the allocation is shaped on the host veth and traffic from
+	// it on the ifb device
+	rate := uint64(100 * 1000 * 1000 / 8)
+	requireTokenBucket := func(link netlink.Link) {
+		qdiscs, err := netlink.QdiscList(link)
+		require.NoError(t, err)
+		for _, qdisc := range qdiscs {
+			if tbf, ok := qdisc.(*netlink.Tbf); ok {
+				require.Equal(t, rate, tbf.Rate)
+				return
+			}
+		}
+		t.Fatalf("no token bucket qdisc on %s: %v", link.Attrs().Name, qdiscs)
+	}
+	requireTokenBucket(hostVeth)
+
+	ifb, err := netlink.LinkByName(ifbName)
+	require.NoError(t, err)
+	requireTokenBucket(ifb)
+
+	filters, err := netlink.FilterList(hostVeth, netlink.MakeHandle(0xffff, 0))
+	require.NoError(t, err)
+	require.Len(t, filters, 1)
+
+	// The first sample only reports the counters
+	stats, err := b.NetworkStats(alloc, spec)
+	require.NoError(t, err)
+	require.Equal(t, 100, stats.LimitMBits)
+	require.ElementsMatch(t, []string{"RxBytes", "TxBytes"}, stats.Measured)
+
+	stats, err = b.NetworkStats(alloc, spec)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"RxBytes", "TxBytes", "RxMBits", "TxMBits"}, stats.Measured)
+
+	// Deleting the ifb device is idempotent
+	require.NoError(t, deleteLinkByName(ifbName))
+	require.NoError(t, deleteLinkByName(ifbName))
+	_, err = netlink.LinkByName(ifbName)
+	require.Error(t, err)
+}
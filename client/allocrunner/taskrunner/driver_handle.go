@@ -48,6 +48,16 @@ func (h *DriverHandle) Kill() error {
 	return h.driver.StopTask(h.taskID, h.killTimeout, h.killSignal)
 }
 
+// Checkpoint writes a checkpoint of the task to path. The driver must
+// implement drivers.CheckpointDriver.
+func (h *DriverHandle) Checkpoint(path string) error {
+	d, ok := h.driver.(drivers.CheckpointDriver)
+	if !ok {
+		return fmt.Errorf("driver does not support checkpointing tasks")
+	}
+	return d.CheckpointTask(h.taskID, path)
+}
+
 func (h *DriverHandle) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	return h.driver.TaskStats(ctx, h.taskID, interval)
 }
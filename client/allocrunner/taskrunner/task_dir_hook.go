@@ -67,6 +67,14 @@ func (h *taskDirHook) Prestart(ctx context.Context, req *interfaces.TaskPrestart
 		return err
 	}
 
+	// Root in the alloc's user namespace owns the task directories so the
+	// task can manage files within them.
+	if uns := h.runner.userNamespace(); uns != nil {
+		if err := h.runner.taskDir.Chown(int(uns.HostID), int(uns.HostID)); err != nil {
+			return err
+		}
+	}
+
 	// Update the environment variables based on the built task directory
 	setEnvvars(h.runner.envBuilder, fsi, h.runner.taskDir, h.runner.clientConfig)
 	resp.State = map[string]string{
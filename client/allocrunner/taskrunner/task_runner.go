@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -239,6 +240,11 @@ type TaskRunner struct {
 	networkIsolationLock sync.Mutex
 	networkIsolationSpec *drivers.NetworkIsolationSpec
 
+	// userNamespaceSpec is the range of host IDs allocated to the alloc for
+	// user namespaces, or nil if they are not enabled on the client.
+	userNamespaceLock sync.Mutex
+	userNamespaceSpec *drivers.UserNamespaceSpec
+
 	allocHookResources *cstructs.AllocHookResources
 
 	// serviceRegWrapper is the handler wrapper that is used by service hooks
@@ -845,8 +851,17 @@ func (tr *TaskRunner) runDriver() error {
 		return nil
 	}
 
+	// Resume the task from a migrated checkpoint if there is one
+	startedEvent := structs.TaskStarted
+	handle, net := tr.restoreTask(taskConfig)
+	if handle != nil {
+		startedEvent = structs.TaskCheckpointRestored
+	}
+
 	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	if handle == nil {
+		handle, net, err = tr.driver.StartTask(taskConfig)
+	}
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -886,7 +901,7 @@ func (tr *TaskRunner) runDriver() error {
 	tr.setDriverHandle(NewDriverHandle(tr.driver, taskConfig.ID, tr.Task(), net))
 
 	// Emit an event that we started
-	tr.UpdateState(structs.TaskStateRunning, structs.NewTaskEvent(structs.TaskStarted))
+	tr.UpdateState(structs.TaskStateRunning, structs.NewTaskEvent(startedEvent))
 	return nil
 }
 
@@ -955,12 +970,20 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 		return nil
 	}
 
-	// Kill the task using an exponential backoff in-case of failures.
-	result, killErr := tr.killTask(handle, resultCh)
-	if killErr != nil {
-		// We couldn't successfully destroy the resource created.
-		tr.logger.Error("failed to kill task. Resources may have been leaked", "error", killErr)
-		tr.setKillErr(killErr)
+	// Checkpoint the task instead of killing it if its allocation is being
+	// migrated along with its ephemeral disk. The driver stops the task once
+	// the checkpoint is written, so it only needs to be killed if
+	// checkpointing fails.
+	var result *drivers.ExitResult
+	var killErr error
+	if !tr.checkpointTask(handle) {
+		// Kill the task using an exponential backoff in-case of failures.
+		result, killErr = tr.killTask(handle, resultCh)
+		if killErr != nil {
+			// We couldn't successfully destroy the resource created.
+			tr.logger.Error("failed to kill task. Resources may have been leaked", "error", killErr)
+			tr.setKillErr(killErr)
+		}
 	}
 
 	if result != nil {
@@ -992,6 +1015,90 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 	}
 }
 
+// shouldCheckpoint returns whether the task should be checkpointed rather than
+// killed. Tasks are only checkpointed when their allocation is being migrated
+// with an ephemeral disk that has checkpointing enabled, and the driver
+// supports it.
+func (tr *TaskRunner) shouldCheckpoint() bool {
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		return false
+	}
+
+	alloc := tr.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.EphemeralDisk == nil {
+		return false
+	}
+	if !tg.EphemeralDisk.Migrate || !tg.EphemeralDisk.Checkpoint {
+		return false
+	}
+
+	return alloc.DesiredTransition.ShouldMigrate()
+}
+
+// checkpointTask checkpoints the task into its checkpoint directory if it
+// should be checkpointed. Returns true if the task was checkpointed and is
+// stopping, or false if it must still be killed.
+func (tr *TaskRunner) checkpointTask(handle *DriverHandle) bool {
+	if !tr.shouldCheckpoint() {
+		return false
+	}
+
+	path := tr.taskDir.CheckpointDir
+	if err := os.RemoveAll(path); err != nil {
+		tr.logger.Warn("failed to remove stale checkpoint", "path", path, "error", err)
+	}
+
+	if err := handle.Checkpoint(path); err != nil {
+		tr.logger.Error("failed to checkpoint task, killing it instead", "error", err)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+			SetDriverMessage(fmt.Sprintf("Failed to checkpoint task: %v", err)))
+
+		// Don't leave a partial checkpoint behind for the next allocation
+		if err := os.RemoveAll(path); err != nil {
+			tr.logger.Warn("failed to remove partial checkpoint", "path", path, "error", err)
+		}
+		return false
+	}
+
+	tr.logger.Info("checkpointed task for migration", "path", path)
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointed))
+	return true
+}
+
+// restoreTask restores the task from a checkpoint migrated from a previous
+// allocation if one exists and the driver supports it. Returns a nil handle
+// if the task could not be restored and should be started normally. The
+// checkpoint is always removed so that restarts of the task start it fresh.
+func (tr *TaskRunner) restoreTask(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork) {
+	path := tr.taskDir.CheckpointDir
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	defer func() {
+		if err := os.RemoveAll(path); err != nil {
+			tr.logger.Warn("failed to remove checkpoint", "path", path, "error", err)
+		}
+	}()
+
+	d, ok := tr.driver.(drivers.CheckpointDriver)
+	if !ok || tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		tr.logger.Warn("found checkpoint but driver does not support restoring it; starting task", "path", path)
+		return nil, nil
+	}
+
+	handle, net, err := d.RestoreTask(taskConfig, path)
+	if err != nil {
+		tr.logger.Error("failed to restore task from checkpoint; starting task", "error", err)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+			SetDriverMessage(fmt.Sprintf("Failed to restore task from checkpoint: %v", err)))
+		return nil, nil
+	}
+
+	tr.logger.Info("restored task from checkpoint", "path", path)
+	return handle, net
+}
+
 // killTask kills the task handle. In the case that killing fails,
 // killTask will retry with an exponential backoff and will give up at a
 // given limit. Returns an error if the task could not be killed.
@@ -1068,6 +1175,11 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		cpusetCpus[i] = fmt.Sprintf("%d", v)
 	}
 
+	var ioResources *structs.IOResources
+	if task.Resources != nil {
+		ioResources = task.Resources.IO.Copy()
+	}
+
 	return &drivers.TaskConfig{
 		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
 		Name:          task.Name,
@@ -1084,6 +1196,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 				CPUShares:        taskResources.Cpu.CpuShares,
 				CpusetCpus:       strings.Join(cpusetCpus, ","),
 				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
+				IO:               ioResources,
 			},
 			Ports: &ports,
 		},
@@ -1098,6 +1211,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		AllocID:          tr.allocID,
 		NetworkIsolation: tr.networkIsolationSpec,
 		DNS:              dns,
+		UserNamespace:    tr.userNamespace(),
 	}
 }
 
@@ -1305,6 +1419,16 @@ func (tr *TaskRunner) appendEvent(event *structs.TaskEvent) error {
 		metrics.IncrCounterWithLabels([]string{"client", "allocs", "restart"}, 1, tr.baseLabels)
 		tr.state.Restarts++
 		tr.state.LastRestart = time.Unix(0, event.Time)
+
+		// The driver reports the health of the restarted task anew
+		tr.state.Health = ""
+	}
+
+	// Track the health of the task reported by the driver
+	if event.Type == structs.TaskDriverMessage {
+		if health, ok := event.Details[drivers.TaskEventHealthAnnotation]; ok {
+			tr.state.Health = health
+		}
 	}
 
 	// Append event to slice
@@ -1354,6 +1478,20 @@ func (tr *TaskRunner) SetNetworkIsolation(n *drivers.NetworkIsolationSpec) {
 	tr.networkIsolationLock.Unlock()
 }
 
+// SetUserNamespace is called by the PreRun allocation hook after allocating
+// the range of host IDs for the allocation's user namespaces
+func (tr *TaskRunner) SetUserNamespace(s *drivers.UserNamespaceSpec) {
+	tr.userNamespaceLock.Lock()
+	tr.userNamespaceSpec = s
+	tr.userNamespaceLock.Unlock()
+}
+
+func (tr *TaskRunner) userNamespace() *drivers.UserNamespaceSpec {
+	tr.userNamespaceLock.Lock()
+	defer tr.userNamespaceLock.Unlock()
+	return tr.userNamespaceSpec
+}
+
 // triggerUpdate if there isn't already an update pending. Should be called
 // instead of calling updateHooks directly to serialize runs of update hooks.
 // TaskRunner state should be updated prior to triggering update hooks.
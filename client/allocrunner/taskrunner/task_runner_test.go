@@ -175,6 +175,42 @@ func runTestTaskRunner(t *testing.T, alloc *structs.Allocation, taskName string)
 	}
 }
 
+// TestTaskRunner_DriverHealth asserts the health reported by driver events is
+// tracked in the task state and cleared when the task restarts.
+func TestTaskRunner_DriverHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+
+	tr, err := NewTaskRunner(conf)
+	require.NoError(t, err)
+
+	tr.EmitEvent(&structs.TaskEvent{
+		Type:          structs.TaskDriverMessage,
+		Details:       map[string]string{drivers.TaskEventHealthAnnotation: structs.TaskHealthStarting},
+		DriverMessage: "Container health status is starting",
+	})
+	require.Equal(t, structs.TaskHealthStarting, tr.TaskState().Health)
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+		SetDriverMessage("Downloading image"))
+	require.Equal(t, structs.TaskHealthStarting, tr.TaskState().Health)
+
+	tr.EmitEvent(&structs.TaskEvent{
+		Type:          structs.TaskDriverMessage,
+		Details:       map[string]string{drivers.TaskEventHealthAnnotation: structs.TaskHealthHealthy},
+		DriverMessage: "Container health status is healthy",
+	})
+	require.Equal(t, structs.TaskHealthHealthy, tr.TaskState().Health)
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskRestarting))
+	require.Empty(t, tr.TaskState().Health)
+}
+
 func TestTaskRunner_BuildTaskConfig_CPU_Memory(t *testing.T) {
 	ci.Parallel(t)
 
@@ -349,6 +385,75 @@ func TestTaskRunner_Restore_Running(t *testing.T) {
 	assert.Equal(t, 1, started)
 }
 
+// TestTaskRunner_Checkpoint_Migrate asserts that a task whose allocation is
+// being migrated with a checkpointing ephemeral disk is checkpointed into its
+// local dir rather than killed.
+func TestTaskRunner_Checkpoint_Migrate(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.EphemeralDisk.Sticky = true
+	tg.EphemeralDisk.Migrate = true
+	tg.EphemeralDisk.Checkpoint = true
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	tr, conf, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+	testWaitForTaskToStart(t, tr)
+
+	// Migrate the allocation
+	update := alloc.Copy()
+	update.DesiredStatus = structs.AllocDesiredStatusStop
+	update.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	tr.Update(update)
+	require.NoError(t, tr.Kill(context.Background(), structs.NewTaskEvent(structs.TaskKilling)))
+
+	checkpointed := false
+	for _, ev := range tr.TaskState().Events {
+		if ev.Type == structs.TaskCheckpointed {
+			checkpointed = true
+		}
+	}
+	require.True(t, checkpointed, "expected %q event", structs.TaskCheckpointed)
+	require.FileExists(t, filepath.Join(conf.TaskDir.CheckpointDir, "mock_checkpoint"))
+}
+
+// TestTaskRunner_Checkpoint_Restore asserts that a task is restored from a
+// checkpoint found in its local dir and that the checkpoint is then removed.
+func TestTaskRunner_Checkpoint_Restore(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+
+	// Write a checkpoint as if it had been migrated from a previous alloc
+	checkpointDir := conf.TaskDir.CheckpointDir
+	require.NoError(t, os.MkdirAll(checkpointDir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(checkpointDir, "mock_checkpoint"), nil, 0600))
+
+	tr, err := NewTaskRunner(conf)
+	require.NoError(t, err)
+	go tr.Run()
+	defer tr.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+	testWaitForTaskToStart(t, tr)
+
+	events := tr.TaskState().Events
+	require.Equal(t, structs.TaskCheckpointRestored, events[len(events)-1].Type)
+	require.NoDirExists(t, checkpointDir)
+}
+
 // setupRestoreFailureTest starts a service, shuts down the task runner, and
 // kills the task before restarting a new TaskRunner. The new TaskRunner is
 // returned once it is running and waiting in pending along with a cleanup
@@ -0,0 +1,102 @@
+package allocrunner
+
+import (
+	"fmt"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/lib/userns"
+	cstate "github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+type userNamespaceSetter interface {
+	SetUserNamespace(*drivers.UserNamespaceSpec)
+}
+
+// allocUserNamespaceSetter is a shim to allow the alloc user namespace hook
+// to set the range of host IDs on the alloc's tasks without full access to
+// the alloc runner
+type allocUserNamespaceSetter struct {
+	ar *allocRunner
+}
+
+func (a *allocUserNamespaceSetter) SetUserNamespace(s *drivers.UserNamespaceSpec) {
+	for _, tr := range a.ar.tasks {
+		tr.SetUserNamespace(s)
+	}
+}
+
+// userNamespaceHook is an alloc lifecycle hook that allocates a range of host
+// UIDs and GIDs to the alloc for remapping task users into user namespaces.
+// The range is stored in the client state so that it is kept across client
+// restarts, and is returned to the pool when the alloc is destroyed.
+type userNamespaceHook struct {
+	allocID  string
+	allocDir *allocdir.AllocDir
+	pool     *userns.Pool
+	stateDB  cstate.StateDB
+	setter   userNamespaceSetter
+	logger   hclog.Logger
+}
+
+func newUserNamespaceHook(logger hclog.Logger, allocID string, allocDir *allocdir.AllocDir,
+	pool *userns.Pool, stateDB cstate.StateDB, setter userNamespaceSetter) *userNamespaceHook {
+	h := &userNamespaceHook{
+		allocID:  allocID,
+		allocDir: allocDir,
+		pool:     pool,
+		stateDB:  stateDB,
+		setter:   setter,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *userNamespaceHook) Name() string {
+	return "user_namespace"
+}
+
+func (h *userNamespaceHook) Prerun() error {
+	// User namespaces are not enabled on this client
+	if h.pool == nil {
+		return nil
+	}
+
+	spec, err := h.stateDB.GetUserNamespace(h.allocID)
+	if err != nil {
+		return fmt.Errorf("failed to read user namespace range: %v", err)
+	}
+
+	if spec == nil {
+		hostID, err := h.pool.Claim(h.allocID)
+		if err != nil {
+			return err
+		}
+		spec = &drivers.UserNamespaceSpec{
+			HostID: hostID,
+			Size:   h.pool.Size(),
+		}
+		if err := h.stateDB.PutUserNamespace(h.allocID, spec); err != nil {
+			h.pool.Release(h.allocID)
+			return fmt.Errorf("failed to store user namespace range: %v", err)
+		}
+		h.logger.Debug("allocated user namespace range", "host_id", spec.HostID, "size", spec.Size)
+	}
+
+	// Root in the user namespace owns the shared alloc dir so tasks can
+	// manage files within it.
+	if err := h.allocDir.Chown(int(spec.HostID), int(spec.HostID)); err != nil {
+		return err
+	}
+
+	h.setter.SetUserNamespace(spec)
+	return nil
+}
+
+func (h *userNamespaceHook) Destroy() error {
+	if h.pool != nil {
+		h.pool.Release(h.allocID)
+	}
+	return nil
+}
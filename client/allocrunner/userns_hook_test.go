@@ -0,0 +1,80 @@
+package allocrunner
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/userns"
+	cstate "github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+// statically assert user namespace hook implements the expected interfaces
+var _ interfaces.RunnerPrerunHook = (*userNamespaceHook)(nil)
+var _ interfaces.RunnerDestroyHook = (*userNamespaceHook)(nil)
+
+type mockUserNamespaceSetter struct {
+	spec *drivers.UserNamespaceSpec
+}
+
+func (m *mockUserNamespaceSetter) SetUserNamespace(s *drivers.UserNamespaceSpec) {
+	m.spec = s
+}
+
+func TestUserNamespaceHook_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	setter := &mockUserNamespaceSetter{}
+	hook := newUserNamespaceHook(logger, "alloc1", nil, nil, cstate.NewMemDB(logger), setter)
+
+	require.NoError(t, hook.Prerun())
+	require.Nil(t, setter.spec)
+	require.NoError(t, hook.Destroy())
+}
+
+func TestUserNamespaceHook_PrerunDestroy(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, t.TempDir(), "alloc1")
+	require.NoError(t, allocDir.Build())
+
+	pool := userns.NewPool(1000000, 65536, 1)
+	db := cstate.NewMemDB(logger)
+	setter := &mockUserNamespaceSetter{}
+	hook := newUserNamespaceHook(logger, "alloc1", allocDir, pool, db, setter)
+
+	require.NoError(t, hook.Prerun())
+	expected := &drivers.UserNamespaceSpec{HostID: 1000000, Size: 65536}
+	require.Equal(t, expected, setter.spec)
+
+	// The range is stored so it survives client restarts
+	stored, err := db.GetUserNamespace("alloc1")
+	require.NoError(t, err)
+	require.Equal(t, expected, stored)
+
+	// Root in the namespace owns the shared alloc dir
+	fi, err := os.Stat(allocDir.SharedDir)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1000000), fi.Sys().(*syscall.Stat_t).Uid)
+
+	// Running the hook again reuses the stored range
+	require.NoError(t, hook.Prerun())
+	require.Equal(t, expected, setter.spec)
+
+	// The only range is held until the alloc is destroyed
+	_, err = pool.Claim("alloc2")
+	require.Error(t, err)
+	require.NoError(t, hook.Destroy())
+	_, err = pool.Claim("alloc2")
+	require.NoError(t, err)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/hashicorp/nomad/client/fingerprint"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
@@ -46,12 +47,14 @@ import (
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/envoy"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pool"
 	hstats "github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/tlsutil"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	nconfig "github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/csi"
 	"github.com/hashicorp/nomad/plugins/device"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -160,6 +163,7 @@ type AllocRunner interface {
 	RestartTask(taskName string, taskEvent *structs.TaskEvent) error
 	RestartAll(taskEvent *structs.TaskEvent) error
 	Reconnect(update *structs.Allocation) error
+	HandleUnhealthyDevices(unhealthy map[string]string)
 
 	GetTaskExecHandler(taskName string) drivermanager.TaskExecHandler
 	GetTaskDriverCapabilities(taskName string) (*drivers.Capabilities, error)
@@ -316,9 +320,16 @@ type Client struct {
 	// with a nomad client. Currently only used for CSI.
 	dynamicRegistry dynamicplugins.Registry
 
+	// hostVolumes creates and deletes the dynamic host volumes of the node
+	hostVolumes *hostVolumeManager
+
 	// cpusetManager configures cpusets on supported platforms
 	cpusetManager cgutil.CpusetManager
 
+	// userNamespacePool allocates host ID ranges to allocations for user
+	// namespaces. It is nil unless user namespaces are enabled.
+	userNamespacePool *userns.Pool
+
 	// EnterpriseClient is used to set and check enterprise features for clients
 	EnterpriseClient *EnterpriseClient
 
@@ -386,6 +397,10 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		EnterpriseClient:     newEnterpriseClient(logger),
 	}
 
+	if uns := cfg.UserNamespaces; uns != nil && uns.Enabled {
+		c.userNamespacePool = userns.NewPool(uint32(uns.IDStart), uint32(uns.RangeSize), uns.RangeCount)
+	}
+
 	c.batchNodeUpdates = newBatchNodeUpdates(
 		c.updateNodeFromDriver,
 		c.updateNodeFromDevices,
@@ -666,6 +681,14 @@ func (c *Client) init() error {
 
 	c.logger.Info("using alloc directory", "alloc_dir", c.config.AllocDir)
 
+	// Tasks in user namespaces run as unprivileged host IDs, which must be
+	// able to reach their task directories
+	if c.userNamespacePool != nil {
+		if err := userns.CheckTraversal(c.config.AllocDir); err != nil {
+			return fmt.Errorf("failed to check the AllocDir is reachable from user namespaces: %v", err)
+		}
+	}
+
 	reserved := "<none>"
 	if c.config.Node != nil && c.config.Node.ReservedResources != nil {
 		// Node should always be non-nil due to initialization in the
@@ -743,6 +766,19 @@ func (c *Client) Reload(newConfig *config.Config) error {
 	return nil
 }
 
+// ReloadPluginConfig sets the reloaded configuration of the given plugin on
+// the running driver or device plugin. Failing to reconfigure a plugin does not
+// affect the allocations using it.
+func (c *Client) ReloadPluginConfig(id loader.PluginID, config *base.Config) error {
+	switch id.PluginType {
+	case base.PluginTypeDriver:
+		return c.drivermanager.ReloadPluginConfig(id, config)
+	case base.PluginTypeDevice:
+		return c.devicemanager.ReloadPluginConfig(id, config)
+	}
+	return nil
+}
+
 // Leave is used to prepare the client to leave the cluster
 func (c *Client) Leave() error {
 	// TODO
@@ -1171,6 +1207,7 @@ func (c *Client) restoreState() error {
 			DynamicRegistry:     c.dynamicRegistry,
 			CSIManager:          c.csimanager,
 			CpusetManager:       c.cpusetManager,
+			UserNamespacePool:   c.userNamespacePool,
 			DeviceManager:       c.devicemanager,
 			DriverManager:       c.drivermanager,
 			ServersContactedCh:  c.serversContactedCh,
@@ -1451,6 +1488,21 @@ func (c *Client) setupNode() error {
 			}
 		}
 	}
+
+	// Fingerprint the dynamic host volumes created before a restart
+	c.hostVolumes = newHostVolumeManager(c.logger, node.ID, c.hostVolumesDir(),
+		c.config.HostVolumePluginDir, filepath.Join(c.config.StateDir, "host_volumes"),
+		c.config.HostVolumes, c.updateNodeFromHostVolume)
+	dynamicVolumes, err := c.hostVolumes.restore()
+	if err != nil {
+		return err
+	}
+	for name, vol := range dynamicVolumes {
+		if node.HostVolumes == nil {
+			node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig, len(dynamicVolumes))
+		}
+		node.HostVolumes[name] = vol
+	}
 	if node.HostNetworks == nil {
 		if l := len(c.config.HostNetworks); l != 0 {
 			node.HostNetworks = make(map[string]*structs.ClientHostNetworkConfig, l)
@@ -1616,6 +1668,9 @@ func (c *Client) registerAndHeartbeat() {
 	// Start watching for emitting node events
 	go c.watchNodeEvents()
 
+	// Start watching the images to prefetch
+	go c.watchImagePrefetches()
+
 	// Setup the heartbeat timer, for the initial registration
 	// we want to do this quickly. We want to do it extra quickly
 	// in development mode.
@@ -2510,6 +2565,7 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		DynamicRegistry:     c.dynamicRegistry,
 		CSIManager:          c.csimanager,
 		CpusetManager:       c.cpusetManager,
+		UserNamespacePool:   c.userNamespacePool,
 		DeviceManager:       c.devicemanager,
 		DriverManager:       c.drivermanager,
 		ServiceRegWrapper:   c.serviceRegWrapper,
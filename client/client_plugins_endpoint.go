@@ -0,0 +1,71 @@
+package client
+
+import (
+	"sort"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// ClientPlugins endpoint is used for retrieving the plugins loaded by a client
+type ClientPlugins struct {
+	c *Client
+}
+
+// List is used to retrieve the plugins loaded by the client along with their
+// versions and health.
+func (p *ClientPlugins) List(args *nstructs.NodeSpecificRequest, reply *structs.ClientPluginsResponse) error {
+	defer metrics.MeasureSince([]string{"client", "client_plugins", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := p.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	catalog, ok := p.c.GetConfig().PluginSingletonLoader.(loader.DetailedCatalog)
+	if !ok {
+		reply.Plugins = []*structs.ClientPlugin{}
+		return nil
+	}
+
+	node := p.c.Node()
+	details := catalog.Details()
+	plugins := make([]*structs.ClientPlugin, 0, len(details))
+	for _, d := range details {
+		plugin := &structs.ClientPlugin{
+			Name:       d.ID.Name,
+			Type:       d.ID.PluginType,
+			Version:    d.Version,
+			ApiVersion: d.ApiVersion,
+			Internal:   d.Internal,
+			Path:       d.Path,
+			SHA256:     d.SHA256,
+		}
+
+		// Only drivers report their health to the node
+		if d.ID.PluginType == base.PluginTypeDriver && node != nil {
+			if info, ok := node.Drivers[d.ID.Name]; ok {
+				plugin.Healthy = helper.BoolToPtr(info.Healthy)
+				plugin.HealthDescription = info.HealthDescription
+			}
+		}
+		plugins = append(plugins, plugin)
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		if plugins[i].Type != plugins[j].Type {
+			return plugins[i].Type < plugins[j].Type
+		}
+		return plugins[i].Name < plugins[j].Name
+	})
+
+	reply.Plugins = plugins
+	return nil
+}
@@ -1599,6 +1599,40 @@ func TestClient_updateNodeFromDriverUpdatesAll(t *testing.T) {
 		un := client.Node()
 		assert.EqualValues(t, n, un)
 	}
+
+	// prefetched image attributes a healthy driver stops fingerprinting are
+	// removed, while other attributes are kept
+	{
+		image := structs.ImagePrefetchAttribute("mock", "redis:7")
+		info := &structs.DriverInfo{
+			Detected:          true,
+			Healthy:           true,
+			HealthDescription: "healthy",
+			Attributes: map[string]string{
+				"node.mock.testattr1": "val3",
+				"node.mock.testattr2": "val4",
+				image:                 "true",
+			},
+		}
+		client.updateNodeFromDriver("mock", info)
+		require.Equal(t, "true", client.Node().Attributes[image])
+
+		info = &structs.DriverInfo{
+			Detected:          true,
+			Healthy:           true,
+			HealthDescription: "healthy",
+			Attributes: map[string]string{
+				"node.mock.testattr1": "val3",
+			},
+		}
+		client.updateNodeFromDriver("mock", info)
+		n := client.Node()
+
+		assert.Equal(t, "val3", n.Attributes["node.mock.testattr1"])
+		assert.Equal(t, "val4", n.Attributes["node.mock.testattr2"])
+		assert.NotContains(t, n.Attributes, image)
+		assert.NotContains(t, n.Drivers["mock"].Attributes, image)
+	}
 }
 
 // COMPAT(0.12): remove once upgrading from 0.9.5 is no longer supported
@@ -293,6 +293,22 @@ type Config struct {
 
 	// Artifact configuration from the agent's config file.
 	Artifact *ArtifactConfig
+
+	// UserNamespaces configures the ranges of host IDs allocated to
+	// allocations for remapping task users into user namespaces.
+	UserNamespaces *UserNamespaceConfig
+
+	// DiskQuota selects how the ephemeral disk size of allocations is
+	// enforced. It is not enforced if empty.
+	DiskQuota string
+
+	// HostVolumesDir is the directory dynamic host volumes are created in
+	// when they don't use a plugin.
+	HostVolumesDir string
+
+	// HostVolumePluginDir is the directory containing the plugins used to
+	// provision dynamic host volumes.
+	HostVolumePluginDir string
 }
 
 // ClientTemplateConfig is configuration on the client specific to template
@@ -699,6 +715,7 @@ func (c *Config) Copy() *Config {
 		copy(nc.ReservableCores, c.ReservableCores)
 	}
 	nc.Artifact = c.Artifact.Copy()
+	nc.UserNamespaces = c.UserNamespaces.Copy()
 	return nc
 }
 
@@ -744,6 +761,7 @@ func DefaultConfig() *Config {
 		CgroupParent:       cgutil.GetCgroupParent(""),
 		MaxDynamicPort:     structs.DefaultMinDynamicPort,
 		MinDynamicPort:     structs.DefaultMaxDynamicPort,
+		UserNamespaces:     DefaultUserNamespaceConfig(),
 	}
 }
 
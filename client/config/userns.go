@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultUserNamespaceIDStart is the first host UID and GID allocated to
+	// user namespaces. It is above the ranges typically handed out to login
+	// users in /etc/subuid and /etc/subgid.
+	DefaultUserNamespaceIDStart = 1000000
+
+	// DefaultUserNamespaceRangeSize is the number of IDs mapped into each
+	// user namespace, enough to cover the nobody user.
+	DefaultUserNamespaceRangeSize = 65536
+
+	// DefaultUserNamespaceRangeCount is the number of ranges available to
+	// allocations, bounding how many allocations can run with a user
+	// namespace at once.
+	DefaultUserNamespaceRangeCount = 1024
+
+	// minUserNamespaceRangeSize is the smallest range that still maps the
+	// nobody user (65534) that tasks run as by default.
+	minUserNamespaceRangeSize = 65536
+
+	// maxUserNamespaceID is the largest valid host ID. (uid_t)-1 is
+	// reserved as an invalid ID.
+	maxUserNamespaceID = math.MaxUint32 - 1
+)
+
+// UserNamespaceConfig is configuration on the client for remapping task users
+// into user namespaces. When enabled the client allocates a range of
+// subordinate UIDs and GIDs to each allocation, which drivers that support
+// user namespaces map the task's users into.
+type UserNamespaceConfig struct {
+	// Enabled allocates a range of host IDs to each allocation.
+	Enabled bool `hcl:"enabled"`
+
+	// IDStart is the first host UID and GID allocated to allocations.
+	IDStart int `hcl:"id_start"`
+
+	// RangeSize is the number of IDs in the range allocated to each
+	// allocation.
+	RangeSize int `hcl:"range_size"`
+
+	// RangeCount is the number of ranges available to allocations.
+	RangeCount int `hcl:"range_count"`
+}
+
+// DefaultUserNamespaceConfig returns the default user namespace config, which
+// is disabled.
+func DefaultUserNamespaceConfig() *UserNamespaceConfig {
+	return &UserNamespaceConfig{
+		IDStart:    DefaultUserNamespaceIDStart,
+		RangeSize:  DefaultUserNamespaceRangeSize,
+		RangeCount: DefaultUserNamespaceRangeCount,
+	}
+}
+
+// Copy returns a copy of a UserNamespaceConfig.
+func (c *UserNamespaceConfig) Copy() *UserNamespaceConfig {
+	if c == nil {
+		return nil
+	}
+
+	nc := *c
+	return &nc
+}
+
+// Merge returns a new config with the fields set in o overriding those in c.
+func (c *UserNamespaceConfig) Merge(o *UserNamespaceConfig) *UserNamespaceConfig {
+	if c == nil {
+		return o.Copy()
+	}
+	if o == nil {
+		return c.Copy()
+	}
+
+	result := c.Copy()
+	if o.Enabled {
+		result.Enabled = true
+	}
+	if o.IDStart != 0 {
+		result.IDStart = o.IDStart
+	}
+	if o.RangeSize != 0 {
+		result.RangeSize = o.RangeSize
+	}
+	if o.RangeCount != 0 {
+		result.RangeCount = o.RangeCount
+	}
+
+	return result
+}
+
+// Validate returns an error if the ranges are invalid.
+func (c *UserNamespaceConfig) Validate() error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+
+	if c.IDStart <= 0 {
+		return fmt.Errorf("id_start must be > 0")
+	}
+	if c.RangeSize < minUserNamespaceRangeSize {
+		return fmt.Errorf("range_size must be at least %d", minUserNamespaceRangeSize)
+	}
+	if c.RangeCount <= 0 {
+		return fmt.Errorf("range_count must be > 0")
+	}
+
+	last := uint64(c.IDStart) + uint64(c.RangeSize)*uint64(c.RangeCount) - 1
+	if last > maxUserNamespaceID {
+		return fmt.Errorf("user namespace ranges end at ID %d which exceeds the maximum of %d", last, uint64(maxUserNamespaceID))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserNamespaceConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	merged := DefaultUserNamespaceConfig().Merge(&UserNamespaceConfig{
+		Enabled:    true,
+		RangeCount: 16,
+	})
+	require.Equal(t, &UserNamespaceConfig{
+		Enabled:    true,
+		IDStart:    DefaultUserNamespaceIDStart,
+		RangeSize:  DefaultUserNamespaceRangeSize,
+		RangeCount: 16,
+	}, merged)
+}
+
+func TestUserNamespaceConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	valid := DefaultUserNamespaceConfig()
+	valid.Enabled = true
+	require.NoError(t, valid.Validate())
+
+	// Disabled configs are not validated
+	require.NoError(t, (&UserNamespaceConfig{}).Validate())
+
+	cases := []struct {
+		name   string
+		modify func(*UserNamespaceConfig)
+		err    string
+	}{
+		{
+			name:   "id start",
+			modify: func(c *UserNamespaceConfig) { c.IDStart = 0 },
+			err:    "id_start must be > 0",
+		},
+		{
+			name:   "range size",
+			modify: func(c *UserNamespaceConfig) { c.RangeSize = 1000 },
+			err:    "range_size must be at least 65536",
+		},
+		{
+			name:   "range count",
+			modify: func(c *UserNamespaceConfig) { c.RangeCount = -1 },
+			err:    "range_count must be > 0",
+		},
+		{
+			name:   "overflow",
+			modify: func(c *UserNamespaceConfig) { c.RangeCount = 65536 },
+			err:    "exceeds the maximum",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid.Copy()
+			tc.modify(c)
+			err := c.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
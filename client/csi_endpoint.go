@@ -220,7 +220,7 @@ func (c *CSI) ControllerCreateVolume(req *structs.ClientCSIControllerCreateVolum
 	// because it's just round-tripping the value set by the user in
 	// the server RPC call
 
-	resp.Topologies = make([]*nstructs.CSITopology, len(cresp.Volume.AccessibleTopology))
+	resp.Topologies = make([]*nstructs.CSITopology, 0, len(cresp.Volume.AccessibleTopology))
 	for _, topo := range cresp.Volume.AccessibleTopology {
 		resp.Topologies = append(resp.Topologies,
 			&nstructs.CSITopology{Segments: topo.Segments})
@@ -264,6 +264,48 @@ func (c *CSI) ControllerDeleteVolume(req *structs.ClientCSIControllerDeleteVolum
 	return err
 }
 
+// ControllerExpandVolume is used to grow a volume in the external storage
+// provider. The response tells the server whether the node plugins must also
+// expand the volume where it's in use.
+func (c *CSI) ControllerExpandVolume(req *structs.ClientCSIControllerExpandVolumeRequest, resp *structs.ClientCSIControllerExpandVolumeResponse) error {
+	defer metrics.MeasureSince([]string{"client", "csi_controller", "expand_volume"}, time.Now())
+
+	plugin, err := c.findControllerPlugin(req.PluginID)
+	if err != nil {
+		// the server's view of the plugin health is stale, so let it know it
+		// should retry with another controller instance
+		return fmt.Errorf("CSI.ControllerExpandVolume: %w: %v",
+			nstructs.ErrCSIClientRPCRetryable, err)
+	}
+	defer plugin.Close()
+
+	csiReq, err := req.ToCSIRequest()
+	if err != nil {
+		return fmt.Errorf("CSI.ControllerExpandVolume: %v", err)
+	}
+
+	ctx, cancelFn := c.requestContext()
+	defer cancelFn()
+
+	// CSI ControllerExpandVolume errors for timeout, codes.Unavailable and
+	// codes.ResourceExhausted are retried; all other errors are fatal.
+	cresp, err := plugin.ControllerExpandVolume(ctx, csiReq,
+		grpc_retry.WithPerRetryTimeout(CSIPluginRequestTimeout),
+		grpc_retry.WithMax(3),
+		grpc_retry.WithBackoff(grpc_retry.BackoffExponential(100*time.Millisecond)))
+	if err != nil {
+		return fmt.Errorf("CSI.ControllerExpandVolume: %v", err)
+	}
+	if cresp == nil {
+		c.c.logger.Warn("plugin did not return error or response; this is a bug in the plugin and should be reported to the plugin author")
+		return fmt.Errorf("CSI.ControllerExpandVolume: plugin did not return error or response")
+	}
+
+	resp.CapacityBytes = cresp.CapacityBytes
+	resp.NodeExpansionRequired = cresp.NodeExpansionRequired
+	return nil
+}
+
 func (c *CSI) ControllerListVolumes(req *structs.ClientCSIControllerListVolumesRequest, resp *structs.ClientCSIControllerListVolumesResponse) error {
 	defer metrics.MeasureSince([]string{"client", "csi_controller", "list_volumes"}, time.Now())
 
@@ -492,6 +534,52 @@ func (c *CSI) NodeDetachVolume(req *structs.ClientCSINodeDetachVolumeRequest, re
 	return nil
 }
 
+// NodeExpandVolume is used to expand a volume on the node where it's
+// published for the allocation, after the controller expanded the volume.
+func (c *CSI) NodeExpandVolume(req *structs.ClientCSINodeExpandVolumeRequest, resp *structs.ClientCSINodeExpandVolumeResponse) error {
+	defer metrics.MeasureSince([]string{"client", "csi_node", "expand_volume"}, time.Now())
+
+	// The following block of validation checks should not be reached on a
+	// real Nomad cluster. They serve as a defensive check before forwarding
+	// requests to plugins, and to aid with development.
+	if req.PluginID == "" {
+		return errors.New("CSI.NodeExpandVolume: PluginID is required")
+	}
+	if req.VolumeID == "" {
+		return errors.New("CSI.NodeExpandVolume: VolumeID is required")
+	}
+	if req.AllocID == "" {
+		return errors.New("CSI.NodeExpandVolume: AllocID is required")
+	}
+
+	ctx, cancelFn := c.requestContext()
+	defer cancelFn()
+
+	mounter, err := c.c.csimanager.MounterForPlugin(ctx, req.PluginID)
+	if err != nil {
+		return fmt.Errorf("CSI.NodeExpandVolume: %v", err)
+	}
+
+	usageOpts := &csimanager.UsageOptions{
+		ReadOnly:       req.ReadOnly,
+		AttachmentMode: req.AttachmentMode,
+		AccessMode:     req.AccessMode,
+		MountOptions:   req.MountOptions,
+	}
+	capacity := &csi.CapacityRange{
+		RequiredBytes: req.CapacityMin,
+		LimitBytes:    req.CapacityMax,
+	}
+
+	capacityBytes, err := mounter.ExpandVolume(ctx,
+		req.VolumeID, req.ExternalID, req.AllocID, usageOpts, capacity)
+	if err != nil {
+		return fmt.Errorf("CSI.NodeExpandVolume: %v", err)
+	}
+	resp.CapacityBytes = capacityBytes
+	return nil
+}
+
 func (c *CSI) findControllerPlugin(name string) (csi.CSIPlugin, error) {
 	return c.findPlugin(dynamicplugins.PluginTypeCSIController, name)
 }
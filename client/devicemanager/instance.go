@@ -8,6 +8,7 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -207,7 +208,15 @@ func (i *instanceManager) Reserve(d *structs.AllocatedDeviceResource) (*device.C
 	}
 
 	// Send the reserve request
-	return devicePlugin.Reserve(d.DeviceIDs)
+	if d.Shares == 0 {
+		return devicePlugin.Reserve(d.DeviceIDs)
+	}
+
+	sharedPlugin, ok := devicePlugin.(device.SharedDevicePlugin)
+	if !ok {
+		return nil, fmt.Errorf("device plugin %q does not support shared devices", i.id.Name)
+	}
+	return sharedPlugin.ReserveShares(d.DeviceIDs, d.Shares)
 }
 
 // Devices returns the detected devices.
@@ -310,6 +319,28 @@ func (i *instanceManager) dispense() (plugin device.DevicePlugin, err error) {
 	return device, nil
 }
 
+// reloadConfig sets the plugin's reloaded configuration on the running device
+// plugin if it supports being reconfigured. If the plugin isn't running, the
+// configuration is set when it is next dispensed.
+func (i *instanceManager) reloadConfig(config *base.Config) error {
+	c := *config
+	c.AgentConfig = i.pluginConfig
+
+	i.pluginLock.Lock()
+	defer i.pluginLock.Unlock()
+
+	if i.plugin == nil || i.plugin.Exited() {
+		return nil
+	}
+
+	if err := pluginmanager.ReloadConfig(i.device, &c); err != nil {
+		return err
+	}
+
+	i.logger.Info("reloaded device plugin configuration")
+	return nil
+}
+
 // cleanup shutsdown the plugin
 func (i *instanceManager) cleanup() {
 	i.shutdownLock.Lock()
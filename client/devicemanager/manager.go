@@ -31,6 +31,10 @@ type Manager interface {
 
 	// DeviceStats returns the device statistics for the given device.
 	DeviceStats(d *structs.AllocatedDeviceResource) (*device.DeviceGroupStats, error)
+
+	// ReloadPluginConfig sets the reloaded configuration of the given plugin
+	// on the running device plugin.
+	ReloadPluginConfig(id loader.PluginID, config *base.Config) error
 }
 
 // StateStorage is used to persist the device managers state across
@@ -236,6 +240,22 @@ func (m *manager) Reserve(d *structs.AllocatedDeviceResource) (*device.Container
 	return nil, UnknownDeviceErrFromAllocated("failed to reserve devices", d)
 }
 
+// ReloadPluginConfig sets the reloaded configuration of the given plugin on
+// the running device plugin. A plugin that fails to be reconfigured keeps
+// running with its previous configuration.
+func (m *manager) ReloadPluginConfig(id loader.PluginID, config *base.Config) error {
+	instance, ok := m.instances[id]
+	if !ok {
+		return nil
+	}
+
+	if err := instance.reloadConfig(config); err != nil {
+		m.logger.Error("failed to reload device plugin configuration", "plugin", id.Name, "error", err)
+		return fmt.Errorf("device plugin %q: %v", id.Name, err)
+	}
+	return nil
+}
+
 // AllStats returns statistics for all the devices
 func (m *manager) AllStats() []*device.DeviceGroupStats {
 	// Go through each plugin and collect stats
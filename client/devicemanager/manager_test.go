@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -427,6 +428,69 @@ func TestManager_Reserve(t *testing.T) {
 	}
 }
 
+// Test that reloaded configurations are only set on plugins supporting it
+func TestManager_ReloadPluginConfig(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	config, _, catalog := baseTestConfig(t)
+
+	info := pluginInfoResponse("nvidia")
+	var lock sync.Mutex
+	var setConfigs []*base.Config
+	dev := &device.MockDevicePlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF:   base.StaticInfo(info),
+			ConfigSchemaF: base.TestConfigSchema(),
+			SetConfigF: func(c *base.Config) error {
+				lock.Lock()
+				defer lock.Unlock()
+				setConfigs = append(setConfigs, c)
+				return nil
+			},
+		},
+		FingerprintF: device.StaticFingerprinter([]*device.DeviceGroup{nvidiaDeviceGroup}),
+		ReserveF:     deviceReserveFn,
+		StatsF:       device.StaticStats([]*device.DeviceGroupStats{nvidiaDeviceGroupStats}),
+	}
+	configureCatalogWith(catalog, map[*base.PluginInfoResponse]loader.PluginInstance{
+		info: loader.MockBasicExternalPlugin(dev, device.ApiVersion010),
+	})
+
+	m := New(config)
+	m.Run()
+	defer m.Shutdown()
+
+	// Wait till we get a fingerprint result
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	<-m.WaitForFirstFingerprint(ctx)
+	require.NoError(ctx.Err())
+
+	lock.Lock()
+	setConfigs = nil
+	lock.Unlock()
+
+	id := loader.PluginID{Name: "nvidia", PluginType: base.PluginTypeDevice}
+	c := &base.Config{PluginConfig: []byte("new")}
+
+	// Plugins that don't support being reconfigured keep their configuration
+	err := m.ReloadPluginConfig(id, c)
+	require.Error(err)
+	require.Contains(err.Error(), "does not support reloading")
+
+	lock.Lock()
+	require.Empty(setConfigs)
+	lock.Unlock()
+
+	info.ReloadConfig = true
+	require.NoError(m.ReloadPluginConfig(id, c))
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal([]*base.Config{{PluginConfig: []byte("new"), AgentConfig: config.PluginConfig}}, setConfigs)
+}
+
 // Test that shutdown shutsdown the plugins
 func TestManager_Shutdown(t *testing.T) {
 	ci.Parallel(t)
@@ -1,6 +1,7 @@
 package devicemanager
 
 import (
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/device"
@@ -48,3 +49,7 @@ func (m *MockManager) Reserve(d *structs.AllocatedDeviceResource) (*device.Conta
 func (m *MockManager) DeviceStats(d *structs.AllocatedDeviceResource) (*device.DeviceGroupStats, error) {
 	return m.DeviceStatsF(d)
 }
+
+func (m *MockManager) ReloadPluginConfig(id loader.PluginID, config *base.Config) error {
+	return nil
+}
@@ -3,6 +3,7 @@ package fingerprint
 import (
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	log "github.com/hashicorp/go-hclog"
@@ -22,6 +23,10 @@ const (
 	// local addresses.
 	networkDisallowLinkLocalOption  = "fingerprint.network.disallow_link_local"
 	networkDisallowLinkLocalDefault = false
+
+	// networkLinkSpeedAttr is the attribute holding the speed in Mb/s of the
+	// fingerprinted interface, when it was detected or configured
+	networkLinkSpeedAttr = "network.link_speed"
 )
 
 // NetworkFingerprint is used to fingerprint the Network capabilities of a node
@@ -120,6 +125,12 @@ func (f *NetworkFingerprint) Fingerprint(req *FingerprintRequest, resp *Fingerpr
 		resp.AddAttribute("unique.network.ip-address", nwResources[0].IP)
 	}
 
+	// Only report a known link speed, so bandwidth shaping is not skipped
+	// based on the default speed
+	if len(nwResources) > 0 && (cfg.NetworkSpeed != 0 || throughput != 0) {
+		resp.AddAttribute(networkLinkSpeedAttr, strconv.Itoa(mbits))
+	}
+
 	ifaces, err := f.interfaceDetector.Interfaces()
 	if err != nil {
 		return err
@@ -237,6 +237,7 @@ func TestNetworkFingerprint_basic(t *testing.T) {
 	if net.MBits != 101 {
 		t.Fatalf("Expected Network Resource to have bandwidth %d; got %d", 101, net.MBits)
 	}
+	assertNodeAttributeEquals(t, attributes, "network.link_speed", "101")
 }
 
 func TestNetworkFingerprint_default_device_absent(t *testing.T) {
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/client/structs"
+)
+
+// HostVolume endpoint is used for creating and deleting the dynamic host
+// volumes of a client.
+type HostVolume struct {
+	c *Client
+}
+
+// Create creates a dynamic host volume and adds it to the node fingerprint
+func (v *HostVolume) Create(req *structs.ClientHostVolumeCreateRequest, resp *structs.ClientHostVolumeCreateResponse) error {
+	defer metrics.MeasureSince([]string{"client", "host_volume", "create"}, time.Now())
+
+	if req.ID == "" {
+		return errors.New("HostVolume.Create: ID is required")
+	}
+	if req.Name == "" {
+		return errors.New("HostVolume.Create: Name is required")
+	}
+
+	if err := v.c.hostVolumes.create(req, resp); err != nil {
+		return fmt.Errorf("HostVolume.Create: %v", err)
+	}
+	return nil
+}
+
+// Delete removes a dynamic host volume from the node fingerprint and deletes
+// it
+func (v *HostVolume) Delete(req *structs.ClientHostVolumeDeleteRequest, resp *structs.ClientHostVolumeDeleteResponse) error {
+	defer metrics.MeasureSince([]string{"client", "host_volume", "delete"}, time.Now())
+
+	if req.ID == "" {
+		return errors.New("HostVolume.Delete: ID is required")
+	}
+
+	if err := v.c.hostVolumes.delete(req); err != nil {
+		return fmt.Errorf("HostVolume.Delete: %v", err)
+	}
+	return nil
+}
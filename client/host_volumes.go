@@ -0,0 +1,343 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// hostVolumePluginTimeout is the timeout for host volume plugins to
+	// create or delete a volume.
+	hostVolumePluginTimeout = 2 * time.Minute
+
+	// hostVolumePluginCreate and hostVolumePluginDelete are the operations
+	// passed as the first argument to host volume plugins
+	hostVolumePluginCreate = "create"
+	hostVolumePluginDelete = "delete"
+)
+
+// hostVolume is the state of a dynamic host volume persisted by the client
+// so it's fingerprinted again after a restart.
+type hostVolume struct {
+	ID            string
+	Name          string
+	PluginID      string
+	HostPath      string
+	CapacityBytes int64
+	Parameters    map[string]string
+}
+
+// hostVolumePluginOutput is what host volume plugins write to stdout when
+// creating a volume
+type hostVolumePluginOutput struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// hostVolumeManager creates and deletes the dynamic host volumes of the
+// client, either as directories in volumesDir or with the plugins in
+// pluginDir.
+type hostVolumeManager struct {
+	logger     hclog.Logger
+	nodeID     string
+	volumesDir string
+	pluginDir  string
+
+	// stateDir holds a JSON file per volume
+	stateDir string
+
+	// configVolumes are the host volumes from the client configuration,
+	// whose names cannot be used by dynamic host volumes
+	configVolumes map[string]*structs.ClientHostVolumeConfig
+
+	// updateNode is called to add a volume to the node fingerprint, or to
+	// remove it if vol is nil
+	updateNode func(name string, vol *structs.ClientHostVolumeConfig)
+
+	volumes     map[string]*hostVolume
+	volumesLock sync.Mutex
+}
+
+func newHostVolumeManager(logger hclog.Logger, nodeID, volumesDir, pluginDir, stateDir string,
+	configVolumes map[string]*structs.ClientHostVolumeConfig,
+	updateNode func(string, *structs.ClientHostVolumeConfig)) *hostVolumeManager {
+
+	return &hostVolumeManager{
+		logger:        logger.Named("host_volumes"),
+		nodeID:        nodeID,
+		volumesDir:    volumesDir,
+		pluginDir:     pluginDir,
+		stateDir:      stateDir,
+		configVolumes: configVolumes,
+		updateNode:    updateNode,
+		volumes:       map[string]*hostVolume{},
+	}
+}
+
+// restore loads the volumes persisted in the state directory and returns
+// their fingerprint
+func (m *hostVolumeManager) restore() (map[string]*structs.ClientHostVolumeConfig, error) {
+	m.volumesLock.Lock()
+	defer m.volumesLock.Unlock()
+
+	files, err := ioutil.ReadDir(m.stateDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read host volumes state: %v", err)
+	}
+
+	fingerprint := map[string]*structs.ClientHostVolumeConfig{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		buf, err := ioutil.ReadFile(filepath.Join(m.stateDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read host volume state: %v", err)
+		}
+		var vol hostVolume
+		if err := json.Unmarshal(buf, &vol); err != nil {
+			return nil, fmt.Errorf("failed to decode host volume state %q: %v", file.Name(), err)
+		}
+
+		if _, err := os.Stat(vol.HostPath); err != nil {
+			// The volume is kept so it can still be deleted, but it's not
+			// fingerprinted so no allocation is placed on it
+			m.logger.Warn("host volume is missing", "volume_id", vol.ID, "name", vol.Name, "error", err)
+		} else {
+			fingerprint[vol.Name] = vol.fingerprint()
+		}
+		m.volumes[vol.ID] = &vol
+	}
+
+	return fingerprint, nil
+}
+
+// create creates a volume and adds it to the node fingerprint. Creating an
+// existing volume returns it, so that servers can retry.
+func (m *hostVolumeManager) create(req *cstructs.ClientHostVolumeCreateRequest,
+	resp *cstructs.ClientHostVolumeCreateResponse) error {
+
+	m.volumesLock.Lock()
+	defer m.volumesLock.Unlock()
+
+	if vol, ok := m.volumes[req.ID]; ok {
+		resp.HostPath = vol.HostPath
+		resp.CapacityBytes = vol.CapacityBytes
+		return nil
+	}
+
+	if !isHostVolumeFileName(req.ID) {
+		return fmt.Errorf("invalid host volume ID %q", req.ID)
+	}
+	if _, ok := m.configVolumes[req.Name]; ok {
+		return fmt.Errorf("host volume %q is already defined in the client configuration", req.Name)
+	}
+	for _, vol := range m.volumes {
+		if vol.Name == req.Name {
+			return fmt.Errorf("host volume %q already exists", req.Name)
+		}
+	}
+
+	vol := &hostVolume{
+		ID:         req.ID,
+		Name:       req.Name,
+		PluginID:   req.PluginID,
+		HostPath:   filepath.Join(m.volumesDir, req.ID),
+		Parameters: req.Parameters,
+	}
+
+	if req.PluginID == "" {
+		if err := os.MkdirAll(vol.HostPath, 0755); err != nil {
+			return fmt.Errorf("failed to create host volume directory: %v", err)
+		}
+	} else {
+		out, err := m.runPlugin(hostVolumePluginCreate, vol,
+			req.RequestedCapacityMinBytes, req.RequestedCapacityMaxBytes)
+		if err != nil {
+			return err
+		}
+
+		var output hostVolumePluginOutput
+		if err := json.Unmarshal(out, &output); err != nil {
+			return fmt.Errorf("failed to decode output of host volume plugin %q: %v", req.PluginID, err)
+		}
+		if output.Path != "" {
+			vol.HostPath = output.Path
+		}
+		vol.CapacityBytes = output.Bytes
+	}
+
+	if err := m.persist(vol); err != nil {
+		return err
+	}
+	m.volumes[vol.ID] = vol
+	m.updateNode(vol.Name, vol.fingerprint())
+
+	resp.HostPath = vol.HostPath
+	resp.CapacityBytes = vol.CapacityBytes
+	return nil
+}
+
+// delete removes a volume from the node fingerprint and deletes it.
+// Deleting a missing volume is a no-op, so that servers can retry.
+func (m *hostVolumeManager) delete(req *cstructs.ClientHostVolumeDeleteRequest) error {
+	m.volumesLock.Lock()
+	defer m.volumesLock.Unlock()
+
+	vol, ok := m.volumes[req.ID]
+	if !ok {
+		return nil
+	}
+
+	// Stop placing allocations on the volume before deleting it
+	m.updateNode(vol.Name, nil)
+
+	if vol.PluginID == "" {
+		if err := os.RemoveAll(vol.HostPath); err != nil {
+			return fmt.Errorf("failed to delete host volume directory: %v", err)
+		}
+	} else {
+		if _, err := m.runPlugin(hostVolumePluginDelete, vol, 0, 0); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Remove(m.statePath(vol.ID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete host volume state: %v", err)
+	}
+	delete(m.volumes, vol.ID)
+	return nil
+}
+
+// runPlugin runs the plugin of a volume for an operation and returns its
+// stdout
+func (m *hostVolumeManager) runPlugin(op string, vol *hostVolume, capacityMin, capacityMax int64) ([]byte, error) {
+	if m.pluginDir == "" {
+		return nil, fmt.Errorf("host volume plugin %q not found: host_volume_plugin_dir is not set", vol.PluginID)
+	}
+
+	// Only run executables in the plugin directory
+	if !isHostVolumeFileName(vol.PluginID) {
+		return nil, fmt.Errorf("invalid host volume plugin %q", vol.PluginID)
+	}
+	path := filepath.Join(m.pluginDir, vol.PluginID)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("host volume plugin %q not found: %v", vol.PluginID, err)
+	}
+
+	params, err := json.Marshal(vol.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode host volume parameters: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hostVolumePluginTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, op)
+	cmd.Env = append(os.Environ(),
+		"NOMAD_HOST_VOLUME_ID="+vol.ID,
+		"NOMAD_HOST_VOLUME_NAME="+vol.Name,
+		"NOMAD_HOST_VOLUME_NODE_ID="+m.nodeID,
+		"NOMAD_HOST_VOLUME_PATH="+vol.HostPath,
+		"NOMAD_HOST_VOLUME_CAPACITY_MIN_BYTES="+strconv.FormatInt(capacityMin, 10),
+		"NOMAD_HOST_VOLUME_CAPACITY_MAX_BYTES="+strconv.FormatInt(capacityMax, 10),
+		"NOMAD_HOST_VOLUME_PARAMETERS="+string(params),
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	m.logger.Debug("running host volume plugin", "plugin_id", vol.PluginID, "operation", op, "volume_id", vol.ID)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("host volume plugin %q failed to %s volume: %v: %s",
+			vol.PluginID, op, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// persist writes the state of a volume so it's restored after a restart
+func (m *hostVolumeManager) persist(vol *hostVolume) error {
+	if err := os.MkdirAll(m.stateDir, 0700); err != nil {
+		return fmt.Errorf("failed to create host volumes state directory: %v", err)
+	}
+
+	buf, err := json.Marshal(vol)
+	if err != nil {
+		return fmt.Errorf("failed to encode host volume state: %v", err)
+	}
+
+	// Write to a temporary file first so a crash doesn't leave a partial
+	// state behind
+	path := m.statePath(vol.ID)
+	if err := ioutil.WriteFile(path+".tmp", buf, 0600); err != nil {
+		return fmt.Errorf("failed to write host volume state: %v", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write host volume state: %v", err)
+	}
+	return nil
+}
+
+func (m *hostVolumeManager) statePath(id string) string {
+	return filepath.Join(m.stateDir, id+".json")
+}
+
+// isHostVolumeFileName returns true if name can be used as a file name
+// within the volumes or plugin directories
+func isHostVolumeFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
+}
+
+// fingerprint returns the node host volume of a dynamic host volume
+func (v *hostVolume) fingerprint() *structs.ClientHostVolumeConfig {
+	return &structs.ClientHostVolumeConfig{
+		Name: v.Name,
+		Path: v.HostPath,
+	}
+}
+
+// updateNodeFromHostVolume adds a dynamic host volume to the node, or
+// removes it if vol is nil, and re-registers the node
+func (c *Client) updateNodeFromHostVolume(name string, vol *structs.ClientHostVolumeConfig) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	if vol == nil {
+		delete(c.config.Node.HostVolumes, name)
+	} else {
+		if c.config.Node.HostVolumes == nil {
+			c.config.Node.HostVolumes = map[string]*structs.ClientHostVolumeConfig{}
+		}
+		c.config.Node.HostVolumes[name] = vol
+	}
+
+	c.updateNodeLocked()
+}
+
+// hostVolumesDir returns the directory dynamic host volumes are created in.
+// Agents always set it, but it defaults to a directory next to the
+// allocations otherwise.
+func (c *Client) hostVolumesDir() string {
+	if c.config.HostVolumesDir != "" {
+		return c.config.HostVolumesDir
+	}
+	return filepath.Join(c.config.AllocDir, "host_volumes")
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHostVolumeManager(t *testing.T, pluginDir string,
+	fingerprint map[string]*structs.ClientHostVolumeConfig) *hostVolumeManager {
+
+	dir := t.TempDir()
+	configVolumes := map[string]*structs.ClientHostVolumeConfig{
+		"tmp": {Name: "tmp", Path: "/tmp"},
+	}
+	return newHostVolumeManager(testlog.HCLogger(t), uuid.Generate(),
+		filepath.Join(dir, "volumes"), pluginDir, filepath.Join(dir, "state"), configVolumes,
+		func(name string, vol *structs.ClientHostVolumeConfig) {
+			if vol == nil {
+				delete(fingerprint, name)
+			} else {
+				fingerprint[name] = vol
+			}
+		})
+}
+
+func TestHostVolumeManager_Directory(t *testing.T) {
+	ci.Parallel(t)
+
+	fingerprint := map[string]*structs.ClientHostVolumeConfig{}
+	m := newTestHostVolumeManager(t, "", fingerprint)
+
+	req := &cstructs.ClientHostVolumeCreateRequest{ID: uuid.Generate(), Name: "data"}
+	var resp cstructs.ClientHostVolumeCreateResponse
+	require.NoError(t, m.create(req, &resp))
+	require.Equal(t, filepath.Join(m.volumesDir, req.ID), resp.HostPath)
+	require.DirExists(t, resp.HostPath)
+	require.Equal(t, resp.HostPath, fingerprint["data"].Path)
+
+	// Creating the volume again returns it
+	var again cstructs.ClientHostVolumeCreateResponse
+	require.NoError(t, m.create(req, &again))
+	require.Equal(t, resp, again)
+
+	// Names must be unique among the configured and dynamic volumes
+	err := m.create(&cstructs.ClientHostVolumeCreateRequest{ID: uuid.Generate(), Name: "data"}, &again)
+	require.EqualError(t, err, `host volume "data" already exists`)
+	err = m.create(&cstructs.ClientHostVolumeCreateRequest{ID: uuid.Generate(), Name: "tmp"}, &again)
+	require.EqualError(t, err, `host volume "tmp" is already defined in the client configuration`)
+
+	// The volume is fingerprinted again after a restart
+	restored := newHostVolumeManager(testlog.HCLogger(t), m.nodeID, m.volumesDir, "", m.stateDir, nil, m.updateNode)
+	out, err := restored.restore()
+	require.NoError(t, err)
+	require.Equal(t, fingerprint, out)
+
+	require.NoError(t, restored.delete(&cstructs.ClientHostVolumeDeleteRequest{ID: req.ID}))
+	require.NoDirExists(t, resp.HostPath)
+	require.Empty(t, fingerprint)
+
+	// Deleting a missing volume is a no-op
+	require.NoError(t, restored.delete(&cstructs.ClientHostVolumeDeleteRequest{ID: req.ID}))
+
+	out, err = newHostVolumeManager(testlog.HCLogger(t), m.nodeID, m.volumesDir, "", m.stateDir, nil, m.updateNode).restore()
+	require.NoError(t, err)
+	require.Empty(t, out)
+}
+
+func TestHostVolumeManager_Plugin(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("host volume plugin test uses a shell script")
+	}
+
+	pluginDir := t.TempDir()
+	logPath := filepath.Join(pluginDir, "ops.log")
+	script := `#!/bin/sh
+echo "$1 $NOMAD_HOST_VOLUME_NAME $NOMAD_HOST_VOLUME_CAPACITY_MIN_BYTES $NOMAD_HOST_VOLUME_PARAMETERS" >> ` + logPath + `
+if [ "$1" = "create" ]; then
+  mkdir -p "$NOMAD_HOST_VOLUME_PATH"
+  echo "{\"path\": \"$NOMAD_HOST_VOLUME_PATH\", \"bytes\": 2048}"
+fi
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(pluginDir, "lvm"), []byte(script), 0755))
+
+	fingerprint := map[string]*structs.ClientHostVolumeConfig{}
+	m := newTestHostVolumeManager(t, pluginDir, fingerprint)
+
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        uuid.Generate(),
+		Name:                      "data",
+		PluginID:                  "lvm",
+		RequestedCapacityMinBytes: 1024,
+		Parameters:                map[string]string{"vg": "nomad"},
+	}
+	var resp cstructs.ClientHostVolumeCreateResponse
+	require.NoError(t, m.create(req, &resp))
+	require.Equal(t, filepath.Join(m.volumesDir, req.ID), resp.HostPath)
+	require.Equal(t, int64(2048), resp.CapacityBytes)
+	require.Contains(t, fingerprint, "data")
+
+	require.NoError(t, m.delete(&cstructs.ClientHostVolumeDeleteRequest{ID: req.ID}))
+	require.Empty(t, fingerprint)
+
+	ops, err := ioutil.ReadFile(logPath)
+	require.NoError(t, err)
+	require.Equal(t, "create data 1024 {\"vg\":\"nomad\"}\ndelete data 0 {\"vg\":\"nomad\"}\n", string(ops))
+
+	// Failing plugins fail the operation
+	err = m.create(&cstructs.ClientHostVolumeCreateRequest{
+		ID: uuid.Generate(), Name: "other", PluginID: "missing"}, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `host volume plugin "missing" not found`)
+
+	_, err = os.Stat(filepath.Join(m.stateDir, req.ID+".json"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// watchImagePrefetches is a long lived goroutine that watches the image
+// prefetches matching this node and hands their images to the task drivers
+// supporting prefetching.
+func (c *Client) watchImagePrefetches() {
+	prefetcher := newImagePrefetcher(c.logger, c.drivermanager.Dispense)
+
+	req := structs.NodeSpecificRequest{
+		NodeID:   c.NodeID(),
+		SecretID: c.secretNodeID(),
+		QueryOptions: structs.QueryOptions{
+			Region:     c.Region(),
+			AllowStale: true,
+		},
+	}
+
+	for {
+		var resp structs.ImagePrefetchListResponse
+		err := c.RPC(structs.ImagePrefetchListForNodeRPCMethod, &req, &resp)
+		if err != nil {
+			// Shutdown often causes EOF errors, so check for shutdown first
+			select {
+			case <-c.shutdownCh:
+				return
+			default:
+			}
+
+			if err != noServersErr {
+				c.logger.Error("error querying image prefetches", "error", err)
+			}
+			retry := c.retryIntv(getAllocRetryIntv)
+			select {
+			case <-c.rpcRetryWatcher():
+				continue
+			case <-time.After(retry):
+				continue
+			case <-c.shutdownCh:
+				return
+			}
+		}
+
+		// The images are handed to the drivers whenever the query returns,
+		// even if unchanged, so drivers restarted since the last call pull
+		// them again
+		if err := prefetcher.apply(resp.ImagePrefetches); err != nil {
+			c.logger.Warn("failed to prefetch images", "error", err)
+
+			// Retry without blocking on the next change
+			select {
+			case <-time.After(c.retryIntv(getAllocRetryIntv)):
+				continue
+			case <-c.shutdownCh:
+				return
+			}
+		}
+
+		req.MinQueryIndex = resp.Index
+	}
+}
+
+// imagePrefetcher hands the images of a set of image prefetches to the task
+// drivers they are meant for.
+type imagePrefetcher struct {
+	logger   hclog.Logger
+	dispense func(driver string) (drivers.DriverPlugin, error)
+
+	// prefetching is the set of drivers last handed any images, which are
+	// told to release them once no prefetch lists images for them
+	prefetching map[string]bool
+}
+
+func newImagePrefetcher(logger hclog.Logger, dispense func(string) (drivers.DriverPlugin, error)) *imagePrefetcher {
+	return &imagePrefetcher{
+		logger:      logger.Named("image_prefetch"),
+		dispense:    dispense,
+		prefetching: make(map[string]bool),
+	}
+}
+
+// apply hands each driver the images listed for it by the prefetches.
+// Drivers that are not running or do not support prefetching are skipped.
+func (p *imagePrefetcher) apply(prefetches []*structs.ImagePrefetch) error {
+	byDriver := make(map[string][]string)
+	for _, prefetch := range prefetches {
+		for _, image := range prefetch.Images {
+			if !helper.SliceStringContains(byDriver[prefetch.Driver], image) {
+				byDriver[prefetch.Driver] = append(byDriver[prefetch.Driver], image)
+			}
+		}
+	}
+	for name := range p.prefetching {
+		if _, ok := byDriver[name]; !ok {
+			byDriver[name] = nil
+		}
+	}
+
+	var mErr multierror.Error
+	for name, images := range byDriver {
+		sort.Strings(images)
+
+		driver, err := p.prefetchDriver(name)
+		if err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("driver %q: %v", name, err))
+			continue
+		}
+		if driver == nil {
+			p.logger.Trace("driver not found or does not support image prefetching", "driver", name)
+			delete(p.prefetching, name)
+			continue
+		}
+
+		if err := driver.PrefetchImages(images); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("driver %q: %v", name, err))
+			continue
+		}
+		p.logger.Trace("set prefetched images", "driver", name, "images", strings.Join(images, ","))
+
+		if len(images) == 0 {
+			delete(p.prefetching, name)
+		} else {
+			p.prefetching[name] = true
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// prefetchDriver returns the named driver if it is running on this node and
+// supports image prefetching, or nil otherwise.
+func (p *imagePrefetcher) prefetchDriver(name string) (drivers.ImagePrefetchDriver, error) {
+	plugin, err := p.dispense(name)
+	if err == drivermanager.ErrDriverNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	caps, err := plugin.Capabilities()
+	if err != nil {
+		return nil, err
+	}
+	if !caps.ImagePrefetch {
+		return nil, nil
+	}
+
+	driver, ok := plugin.(drivers.ImagePrefetchDriver)
+	if !ok {
+		return nil, nil
+	}
+	return driver, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtu "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImagePrefetcher_Apply(t *testing.T) {
+	ci.Parallel(t)
+
+	var prefetched [][]string
+	docker := &dtu.MockDriver{
+		CapabilitiesF: func() (*drivers.Capabilities, error) {
+			return &drivers.Capabilities{ImagePrefetch: true}, nil
+		},
+		PrefetchImagesF: func(images []string) error {
+			prefetched = append(prefetched, images)
+			return nil
+		},
+	}
+	exec := &dtu.MockDriver{
+		CapabilitiesF: func() (*drivers.Capabilities, error) {
+			return &drivers.Capabilities{}, nil
+		},
+		PrefetchImagesF: func([]string) error {
+			t.Fatal("driver without the capability must not be called")
+			return nil
+		},
+	}
+	dispense := func(name string) (drivers.DriverPlugin, error) {
+		switch name {
+		case "docker":
+			return docker, nil
+		case "exec":
+			return exec, nil
+		}
+		return nil, drivermanager.ErrDriverNotFound
+	}
+
+	p := newImagePrefetcher(testlog.HCLogger(t), dispense)
+
+	// Images are merged across prefetches for the same driver, and drivers
+	// that are missing or cannot prefetch are skipped
+	require.NoError(t, p.apply([]*structs.ImagePrefetch{
+		{Name: "a", Driver: "docker", Images: []string{"redis:7", "nvidia/cuda:11"}},
+		{Name: "b", Driver: "docker", Images: []string{"redis:7"}},
+		{Name: "c", Driver: "exec", Images: []string{"ignored"}},
+		{Name: "d", Driver: "podman", Images: []string{"ignored"}},
+	}))
+	require.Equal(t, [][]string{{"nvidia/cuda:11", "redis:7"}}, prefetched)
+
+	// Drivers no longer listed are told to release their images once
+	prefetched = nil
+	require.NoError(t, p.apply(nil))
+	require.Equal(t, [][]string{nil}, prefetched)
+
+	prefetched = nil
+	require.NoError(t, p.apply(nil))
+	require.Empty(t, prefetched)
+}
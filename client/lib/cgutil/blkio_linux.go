@@ -0,0 +1,95 @@
+//go:build linux
+
+package cgutil
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	lcc "github.com/opencontainers/runc/libcontainer/configs"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// blkio is the v1 controller of block I/O
+	blkio = "blkio"
+)
+
+// ConfigureBlkio sets the block I/O weight and device throttles of a task on
+// the cgroup resources. The same resources configure the v1 blkio and the v2
+// io controllers. Devices are identified by their major and minor numbers,
+// so their paths must exist on the client.
+func ConfigureBlkio(res *lcc.Resources, io *structs.IOResources) error {
+	if io == nil {
+		return nil
+	}
+
+	res.BlkioWeight = uint16(io.Weight)
+
+	for _, d := range io.Devices {
+		major, minor, err := blockDeviceNumbers(d.Path)
+		if err != nil {
+			return err
+		}
+		if d.ReadBps > 0 {
+			res.BlkioThrottleReadBpsDevice = append(res.BlkioThrottleReadBpsDevice,
+				lcc.NewThrottleDevice(major, minor, d.ReadBps))
+		}
+		if d.WriteBps > 0 {
+			res.BlkioThrottleWriteBpsDevice = append(res.BlkioThrottleWriteBpsDevice,
+				lcc.NewThrottleDevice(major, minor, d.WriteBps))
+		}
+		if d.ReadIOPS > 0 {
+			res.BlkioThrottleReadIOPSDevice = append(res.BlkioThrottleReadIOPSDevice,
+				lcc.NewThrottleDevice(major, minor, d.ReadIOPS))
+		}
+		if d.WriteIOPS > 0 {
+			res.BlkioThrottleWriteIOPSDevice = append(res.BlkioThrottleWriteIOPSDevice,
+				lcc.NewThrottleDevice(major, minor, d.WriteIOPS))
+		}
+	}
+
+	return nil
+}
+
+// blkioConfigured returns whether the resources set any block I/O weight or
+// device throttle.
+func blkioConfigured(res *lcc.Resources) bool {
+	return res != nil && (res.BlkioWeight != 0 ||
+		len(res.BlkioThrottleReadBpsDevice) > 0 ||
+		len(res.BlkioThrottleWriteBpsDevice) > 0 ||
+		len(res.BlkioThrottleReadIOPSDevice) > 0 ||
+		len(res.BlkioThrottleWriteIOPSDevice) > 0)
+}
+
+// configureBlkioV1 creates the v1 blkio cgroup of the given id and applies
+// the block I/O resources to it.
+func configureBlkioV1(config *lcc.Config, id string) error {
+	path, err := GetCgroupPathHelperV1(blkio, id)
+	if err != nil {
+		return fmt.Errorf("failed to find %s cgroup mountpoint: %v", blkio, err)
+	}
+	if err = os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+	if err = new(fs.BlkioGroup).Set(path, config.Cgroups.Resources); err != nil {
+		return fmt.Errorf("failed to set block I/O limits: %v", err)
+	}
+	config.Cgroups.Paths[blkio] = path
+	return nil
+}
+
+// blockDeviceNumbers returns the major and minor numbers of the block device
+// at path.
+func blockDeviceNumbers(path string) (int64, int64, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return 0, 0, fmt.Errorf("failed to find io device %q: %v", path, err)
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return 0, 0, fmt.Errorf("io device %q is not a block device", path)
+	}
+	return int64(unix.Major(st.Rdev)), int64(unix.Minor(st.Rdev)), nil
+}
@@ -0,0 +1,106 @@
+//go:build linux
+
+package cgutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	lcc "github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestUtil_ConfigureBlkio(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("unset", func(t *testing.T) {
+		res := new(lcc.Resources)
+		require.NoError(t, ConfigureBlkio(res, nil))
+		require.False(t, blkioConfigured(res))
+	})
+
+	t.Run("weight", func(t *testing.T) {
+		res := new(lcc.Resources)
+		require.NoError(t, ConfigureBlkio(res, &structs.IOResources{Weight: 500}))
+		require.Equal(t, uint16(500), res.BlkioWeight)
+		require.True(t, blkioConfigured(res))
+	})
+
+	t.Run("missing device", func(t *testing.T) {
+		res := new(lcc.Resources)
+		err := ConfigureBlkio(res, &structs.IOResources{
+			Devices: []*structs.IODeviceLimit{{Path: "/dev/does-not-exist", ReadBps: 1}},
+		})
+		require.EqualError(t, err, `failed to find io device "/dev/does-not-exist": no such file or directory`)
+	})
+
+	t.Run("not a block device", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(path, nil, 0644))
+
+		res := new(lcc.Resources)
+		err := ConfigureBlkio(res, &structs.IOResources{
+			Devices: []*structs.IODeviceLimit{{Path: path, ReadBps: 1}},
+		})
+		require.EqualError(t, err, `io device "`+path+`" is not a block device`)
+	})
+
+	t.Run("device limits", func(t *testing.T) {
+		testutil.RequireRoot(t)
+
+		path := filepath.Join(t.TempDir(), "sdb")
+		require.NoError(t, unix.Mknod(path, unix.S_IFBLK|0600, int(unix.Mkdev(8, 16))))
+
+		res := new(lcc.Resources)
+		require.NoError(t, ConfigureBlkio(res, &structs.IOResources{
+			Devices: []*structs.IODeviceLimit{{Path: path, ReadBps: 1024, WriteIOPS: 10}},
+		}))
+		require.Equal(t, []*lcc.ThrottleDevice{lcc.NewThrottleDevice(8, 16, 1024)}, res.BlkioThrottleReadBpsDevice)
+		require.Equal(t, []*lcc.ThrottleDevice{lcc.NewThrottleDevice(8, 16, 10)}, res.BlkioThrottleWriteIOPSDevice)
+		require.Empty(t, res.BlkioThrottleWriteBpsDevice)
+		require.Empty(t, res.BlkioThrottleReadIOPSDevice)
+		require.True(t, blkioConfigured(res))
+	})
+}
+
+func TestUtil_ConfigureBasicCgroups_Blkio(t *testing.T) {
+	ci.Parallel(t)
+	testutil.CgroupsCompatibleV1(t)
+	testutil.RequireRoot(t)
+
+	// throttle a loop device, which every test host has
+	major, minor, err := blockDeviceNumbers("/dev/loop0")
+	if err != nil {
+		t.Skipf("no loop device: %v", err)
+	}
+
+	config := &lcc.Config{
+		Cgroups: &lcc.Cgroup{
+			Resources: new(lcc.Resources),
+		},
+	}
+	require.NoError(t, ConfigureBlkio(config.Cgroups.Resources, &structs.IOResources{
+		Devices: []*structs.IODeviceLimit{{Path: "/dev/loop0", ReadBps: 1048576}},
+	}))
+	require.NoError(t, ConfigureBasicCgroups(config))
+	defer func() {
+		require.NoError(t, cgroups.RemovePaths(config.Cgroups.Paths))
+	}()
+
+	require.Contains(t, config.Cgroups.Paths, "freezer")
+	require.Contains(t, config.Cgroups.Paths, blkio)
+	require.Equal(t,
+		filepath.Base(config.Cgroups.Paths["freezer"]),
+		filepath.Base(config.Cgroups.Paths[blkio]))
+
+	limit, err := cgroups.ReadFile(config.Cgroups.Paths[blkio], "blkio.throttle.read_bps_device")
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%d:%d 1048576\n", major, minor), limit)
+}
@@ -61,7 +61,8 @@ func CgroupScope(allocID, task string) string {
 // ConfigureBasicCgroups will initialize a cgroup and modify config to contain
 // a reference to its path.
 //
-// v1: creates a random "freezer" cgroup which can later be used for cleanup of processes.
+// v1: creates a random "freezer" cgroup which can later be used for cleanup of processes,
+// and a "blkio" cgroup of the same name if the config limits block I/O.
 // v2: does nothing.
 func ConfigureBasicCgroups(config *lcc.Config) error {
 	if UseV2 {
@@ -81,6 +82,10 @@ func ConfigureBasicCgroups(config *lcc.Config) error {
 	config.Cgroups.Paths = map[string]string{
 		subsystem: path,
 	}
+
+	if blkioConfigured(config.Cgroups.Resources) {
+		return configureBlkioV1(config, filepath.Join(DefaultCgroupV1Parent, id))
+	}
 	return nil
 }
 
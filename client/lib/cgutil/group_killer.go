@@ -91,8 +91,15 @@ func (d *killer) v1(cgroup *configs.Cgroup) error {
 		return err
 	}
 
-	// remove the cgroup from disk
-	return cgroups.RemovePath(path)
+	// remove the cgroup from disk, along with the blkio cgroup made for
+	// limiting block I/O
+	if err = cgroups.RemovePath(path); err != nil {
+		return err
+	}
+	if blkioPath, ok := cgroup.Paths[blkio]; ok {
+		return cgroups.RemovePath(blkioPath)
+	}
+	return nil
 }
 
 func (d *killer) v2(cgroup *configs.Cgroup) error {
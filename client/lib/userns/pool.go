@@ -0,0 +1,101 @@
+// Package userns allocates ranges of subordinate host UIDs and GIDs to
+// allocations for mapping into user namespaces.
+package userns
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Pool hands out fixed size ranges of host IDs to allocations. Each range is
+// used for both UIDs and GIDs. A Pool is safe for concurrent use.
+type Pool struct {
+	start uint32
+	size  uint32
+	count int
+
+	// claims maps an allocation ID to the index of its range and owners maps
+	// a range index back to its allocation ID.
+	claims map[string]int
+	owners map[int]string
+
+	lock sync.Mutex
+}
+
+// NewPool returns a pool of count ranges of size IDs, the first of which
+// begins at start.
+func NewPool(start, size uint32, count int) *Pool {
+	return &Pool{
+		start:  start,
+		size:   size,
+		count:  count,
+		claims: make(map[string]int),
+		owners: make(map[int]string),
+	}
+}
+
+// Size returns the number of IDs in each range.
+func (p *Pool) Size() uint32 {
+	return p.size
+}
+
+// Claim returns the first host ID of the range allocated to the allocation,
+// allocating a free range if it does not hold one yet.
+func (p *Pool) Claim(allocID string) (uint32, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if i, ok := p.claims[allocID]; ok {
+		return p.hostID(i), nil
+	}
+
+	for i := 0; i < p.count; i++ {
+		if _, ok := p.owners[i]; !ok {
+			p.claims[allocID] = i
+			p.owners[i] = allocID
+			return p.hostID(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("all %d user namespace ranges are in use", p.count)
+}
+
+// Reserve marks the range beginning at hostID as held by the allocation. It
+// is used to restore the claims of allocations across client restarts, and
+// returns an error if the range is not part of the pool or is held by another
+// allocation.
+func (p *Pool) Reserve(allocID string, hostID uint32) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if hostID < p.start || (hostID-p.start)%p.size != 0 || int((hostID-p.start)/p.size) >= p.count {
+		return fmt.Errorf("user namespace range starting at %d is not part of the configured ranges", hostID)
+	}
+	i := int((hostID - p.start) / p.size)
+
+	if owner, ok := p.owners[i]; ok && owner != allocID {
+		return fmt.Errorf("user namespace range starting at %d is held by allocation %s", hostID, owner)
+	}
+	if prev, ok := p.claims[allocID]; ok && prev != i {
+		delete(p.owners, prev)
+	}
+
+	p.claims[allocID] = i
+	p.owners[i] = allocID
+	return nil
+}
+
+// Release returns the range held by the allocation to the pool.
+func (p *Pool) Release(allocID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if i, ok := p.claims[allocID]; ok {
+		delete(p.claims, allocID)
+		delete(p.owners, i)
+	}
+}
+
+func (p *Pool) hostID(i int) uint32 {
+	return p.start + uint32(i)*p.size
+}
@@ -0,0 +1,59 @@
+package userns
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_Claim(t *testing.T) {
+	ci.Parallel(t)
+
+	p := NewPool(100000, 65536, 2)
+
+	id1, err := p.Claim("alloc1")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000), id1)
+
+	// Claims are idempotent.
+	again, err := p.Claim("alloc1")
+	require.NoError(t, err)
+	require.Equal(t, id1, again)
+
+	id2, err := p.Claim("alloc2")
+	require.NoError(t, err)
+	require.Equal(t, uint32(165536), id2)
+
+	_, err = p.Claim("alloc3")
+	require.EqualError(t, err, "all 2 user namespace ranges are in use")
+
+	// Released ranges are handed out again.
+	p.Release("alloc1")
+	id3, err := p.Claim("alloc3")
+	require.NoError(t, err)
+	require.Equal(t, id1, id3)
+}
+
+func TestPool_Reserve(t *testing.T) {
+	ci.Parallel(t)
+
+	p := NewPool(100000, 65536, 2)
+
+	require.NoError(t, p.Reserve("alloc1", 165536))
+	require.NoError(t, p.Reserve("alloc1", 165536))
+
+	// New claims skip reserved ranges.
+	id, err := p.Claim("alloc2")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000), id)
+
+	err = p.Reserve("alloc3", 165536)
+	require.EqualError(t, err, "user namespace range starting at 165536 is held by allocation alloc1")
+
+	for _, hostID := range []uint32{0, 99999, 100001, 231072} {
+		err = p.Reserve("alloc3", hostID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not part of the configured ranges")
+	}
+}
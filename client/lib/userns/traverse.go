@@ -0,0 +1,34 @@
+package userns
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CheckTraversal returns an error naming the first of dir and its parents
+// that other users can't traverse, as processes running as the unprivileged
+// host IDs of a user namespace must be able to reach paths within dir.
+// Permissions are never changed.
+func CheckTraversal(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	for {
+		fi, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if fi.Mode().Perm()&0001 == 0 {
+			return fmt.Errorf("directory %q can't be traversed by user namespaces: it must have the execute permission for others (o+x)", dir)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,35 @@
+package userns
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTraversal(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	data := filepath.Join(root, "data")
+	dir := filepath.Join(data, "alloc")
+	require.NoError(t, os.MkdirAll(dir, 0711))
+	for _, path := range []string{filepath.Dir(root), root, data} {
+		require.NoError(t, os.Chmod(path, 0711))
+	}
+
+	require.NoError(t, CheckTraversal(dir))
+
+	// The first directory that can't be traversed is named, and permissions
+	// are left untouched
+	require.NoError(t, os.Chmod(data, 0700))
+	err := CheckTraversal(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), data)
+
+	fi, err := os.Stat(data)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), fi.Mode().Perm())
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -259,6 +260,22 @@ func (c *Client) updateNodeFromDriverLocked(name string, info *structs.DriverInf
 				c.config.Node.Attributes[attrName] = newVal
 			}
 		}
+
+		// Remove the attributes of prefetched images a healthy driver no
+		// longer holds. Unhealthy drivers may not fingerprint any attributes,
+		// so their last known attributes are kept.
+		if info.Healthy {
+			prefix := structs.ImagePrefetchAttributePrefix(name)
+			for attrName := range oldVal.Attributes {
+				if !strings.HasPrefix(attrName, prefix) {
+					continue
+				}
+				if _, ok := info.Attributes[attrName]; !ok {
+					hasChanged = true
+					delete(c.config.Node.Attributes, attrName)
+				}
+			}
+		}
 	}
 
 	// COMPAT Remove in Nomad 0.10
@@ -295,12 +312,38 @@ func (c *Client) updateNodeFromDevicesLocked(devices []*structs.NodeDeviceResour
 	if !structs.DevicesEquals(c.config.Node.NodeResources.Devices, devices) {
 		c.logger.Debug("new devices detected", "devices", len(devices))
 		c.config.Node.NodeResources.Devices = devices
+
+		// Allocations react to their devices' health without blocking the
+		// node update
+		go c.handleUnhealthyDevices(unhealthyDeviceInstances(devices))
 		return true
 	}
 
 	return false
 }
 
+// handleUnhealthyDevices lets every allocation react to the unhealthy device
+// instances of the node
+func (c *Client) handleUnhealthyDevices(unhealthy map[string]string) {
+	for _, ar := range c.getAllocRunners() {
+		ar.HandleUnhealthyDevices(unhealthy)
+	}
+}
+
+// unhealthyDeviceInstances returns the IDs of the unhealthy device instances
+// mapped to their health description
+func unhealthyDeviceInstances(devices []*structs.NodeDeviceResource) map[string]string {
+	unhealthy := make(map[string]string)
+	for _, dev := range devices {
+		for _, instance := range dev.Instances {
+			if !instance.Healthy {
+				unhealthy[instance.ID] = instance.HealthDescription
+			}
+		}
+	}
+	return unhealthy
+}
+
 // batchNodeUpdates allows for batching multiple Node updates from fingerprinting.
 // Once ready, the batches can be flushed and toggled to stop batching and forward
 // all updates to a configured callback to be performed incrementally
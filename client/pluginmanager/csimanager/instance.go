@@ -4,13 +4,21 @@ import (
 	"context"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/csi"
 )
 
 const managerFingerprintInterval = 30 * time.Second
 
+// managerVolumeStatsInterval is how often the usage and condition of the
+// volumes are collected. The stats are part of the fingerprint, and any change
+// updates the node on the servers, so they're collected less often than the
+// fingerprint.
+const managerVolumeStatsInterval = 5 * time.Minute
+
 // instanceManager is used to manage the fingerprinting and supervision of a
 // single CSI Plugin.
 type instanceManager struct {
@@ -40,6 +48,11 @@ type instanceManager struct {
 	volumeManager        *volumeManager
 	volumeManagerSetupCh chan struct{}
 
+	// volumeStats are the last collected volume stats, reported with every
+	// fingerprint until they're collected again
+	volumeStats          map[string]*structs.CSIVolumeStats
+	volumeStatsCollected time.Time
+
 	client csi.CSIPlugin
 }
 
@@ -139,6 +152,9 @@ func (i *instanceManager) runLoop() {
 		case <-timer.C:
 			ctx, cancelFn := i.requestCtxWithTimeout(managerFingerprintInterval)
 			info := i.fp.fingerprint(ctx)
+			if info != nil {
+				i.collectVolumeStats(ctx, info)
+			}
 			cancelFn()
 			if info != nil {
 				i.updater(i.info.Name, info)
@@ -148,6 +164,50 @@ func (i *instanceManager) runLoop() {
 	}
 }
 
+// collectVolumeStats adds the usage and condition of the volumes mounted by a
+// healthy node plugin to its fingerprint, so that they're reported to the
+// servers along with the plugin health, and emits them as metrics. The stats
+// are only collected every managerVolumeStatsInterval, and the last ones are
+// reported in between.
+func (i *instanceManager) collectVolumeStats(ctx context.Context, info *structs.CSIInfo) {
+	if !info.Healthy || info.NodeInfo == nil || !info.NodeInfo.SupportsStats {
+		return
+	}
+
+	select {
+	case <-i.volumeManagerSetupCh:
+	default:
+		return
+	}
+
+	if time.Since(i.volumeStatsCollected) < managerVolumeStatsInterval {
+		info.NodeInfo.VolumeStats = i.volumeStats
+		return
+	}
+
+	i.volumeStats = i.volumeManager.VolumeStats(ctx)
+	i.volumeStatsCollected = time.Now()
+	info.NodeInfo.VolumeStats = i.volumeStats
+
+	for volID, stats := range i.volumeStats {
+		labels := []metrics.Label{
+			{Name: "plugin_id", Value: i.info.Name},
+			{Name: "volume_id", Value: volID},
+		}
+		metrics.SetGaugeWithLabels([]string{"client", "csi", "volume", "total_bytes"}, float32(stats.TotalBytes), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "csi", "volume", "used_bytes"}, float32(stats.UsedBytes), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "csi", "volume", "available_bytes"}, float32(stats.AvailableBytes), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "csi", "volume", "total_inodes"}, float32(stats.TotalInodes), labels)
+		metrics.SetGaugeWithLabels([]string{"client", "csi", "volume", "used_inodes"}, float32(stats.UsedInodes), labels)
+
+		var abnormal float32
+		if stats.Abnormal {
+			abnormal = 1
+		}
+		metrics.SetGaugeWithLabels([]string{"client", "csi", "volume", "abnormal"}, abnormal, labels)
+	}
+}
+
 func (i *instanceManager) shutdown() {
 	i.shutdownCtxCancelFn()
 	<-i.shutdownCh
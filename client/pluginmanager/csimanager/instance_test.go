@@ -78,3 +78,45 @@ func TestInstanceManager_Shutdown(t *testing.T) {
 	}, 1*time.Second, 10*time.Millisecond)
 
 }
+
+func TestInstanceManager_CollectVolumeStats(t *testing.T) {
+	client, im := setupTestNodeInstanceManager(t)
+	im.volumeManager = newVolumeManager(im.logger, func(*structs.NodeEvent) {}, client, t.TempDir(), t.TempDir(), true)
+	im.volumeManagerSetupCh = make(chan struct{})
+	close(im.volumeManagerSetupCh)
+
+	usage := &UsageOptions{
+		AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		AccessMode:     structs.CSIVolumeAccessModeSingleNodeWriter,
+	}
+	im.volumeManager.usageTracker.Claim("alloc", "foo", usage)
+	im.volumeManager.externalIDs["foo"] = "vol-12345"
+
+	client.NextNodeGetVolumeStatsResponse = &csi.NodeGetVolumeStatsResponse{
+		Bytes: &csi.VolumeUsage{Total: 1024, Used: 256, Available: 768},
+	}
+	fingerprint := func() *structs.CSIInfo {
+		info := &structs.CSIInfo{
+			Healthy:  true,
+			NodeInfo: &structs.CSINodeInfo{SupportsStats: true},
+		}
+		im.collectVolumeStats(context.Background(), info)
+		return info
+	}
+
+	info := fingerprint()
+	require.Equal(t, int64(256), info.NodeInfo.VolumeStats["foo"].UsedBytes)
+	require.Equal(t, int64(1), client.NodeGetVolumeStatsCallCount)
+
+	// the last stats are reported until the interval elapses, so that usage
+	// changes don't update the node on every fingerprint
+	client.NextNodeGetVolumeStatsResponse.Bytes.Used = 512
+	next := fingerprint()
+	require.True(t, info.Equal(next))
+	require.Equal(t, int64(1), client.NodeGetVolumeStatsCallCount)
+
+	im.volumeStatsCollected = time.Now().Add(-managerVolumeStatsInterval)
+	info = fingerprint()
+	require.Equal(t, int64(512), info.NodeInfo.VolumeStats["foo"].UsedBytes)
+	require.Equal(t, int64(2), client.NodeGetVolumeStatsCallCount)
+}
@@ -67,3 +67,25 @@ func (v *volumeUsageTracker) Free(allocID, volID string, usage *UsageOptions) bo
 	allocs := v.allocsForKey(key)
 	return len(allocs) == 0
 }
+
+// volumeUsage is an allocation and the usage options it claims a volume with
+type volumeUsage struct {
+	allocID   string
+	usageOpts UsageOptions
+}
+
+// Volumes returns one of the current usages of each volume in use, keyed by
+// volume ID.
+func (v *volumeUsageTracker) Volumes() map[string]volumeUsage {
+	v.stateMu.Lock()
+	defer v.stateMu.Unlock()
+
+	out := make(map[string]volumeUsage)
+	for key, allocs := range v.state {
+		if _, ok := out[key.id]; ok || len(allocs) == 0 {
+			continue
+		}
+		out[key.id] = volumeUsage{allocID: allocs[0], usageOpts: key.usageOpts}
+	}
+	return out
+}
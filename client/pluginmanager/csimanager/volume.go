@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
@@ -50,6 +51,15 @@ type volumeManager struct {
 	// requiresStaging shows whether the plugin requires that the volume manager
 	// calls NodeStageVolume and NodeUnstageVolume RPCs during setup and teardown
 	requiresStaging bool
+
+	// externalIDs maps the ID of each volume mounted by the volume manager
+	// to its external ID, so that its stats can be collected
+	externalIDs   map[string]string
+	externalIDsMu sync.Mutex
+
+	// abnormal is the set of volumes the plugin last reported as abnormal.
+	// It's only accessed by VolumeStats.
+	abnormal map[string]bool
 }
 
 func newVolumeManager(logger hclog.Logger, eventer TriggerNodeEvent, plugin csi.CSIPlugin, rootDir, containerRootDir string, requiresStaging bool) *volumeManager {
@@ -61,6 +71,8 @@ func newVolumeManager(logger hclog.Logger, eventer TriggerNodeEvent, plugin csi.
 		containerMountPoint: containerRootDir,
 		requiresStaging:     requiresStaging,
 		usageTracker:        newVolumeUsageTracker(),
+		externalIDs:         make(map[string]string),
+		abnormal:            make(map[string]bool),
 	}
 }
 
@@ -253,6 +265,10 @@ func (v *volumeManager) MountVolume(ctx context.Context, vol *structs.CSIVolume,
 
 	if err == nil {
 		v.usageTracker.Claim(alloc.ID, vol.ID, usage)
+
+		v.externalIDsMu.Lock()
+		v.externalIDs[vol.ID] = vol.RemoteID()
+		v.externalIDsMu.Unlock()
 	}
 
 	event := structs.NewNodeEvent().
@@ -373,3 +389,152 @@ func (v *volumeManager) UnmountVolume(ctx context.Context, volID, remoteID, allo
 
 	return err
 }
+
+// ExpandVolume grows the filesystem of a volume published for the allocation
+// after the controller has expanded the volume. The plugin is given both the
+// published path and, for plugins that stage volumes, the staging path.
+func (v *volumeManager) ExpandVolume(ctx context.Context, volID, remoteID, allocID string, usage *UsageOptions, capacity *csi.CapacityRange) (int64, error) {
+	logger := v.logger.With("volume_id", volID, "alloc_id", allocID)
+	ctx = hclog.WithContext(ctx, logger)
+
+	req := &csi.NodeExpandVolumeRequest{
+		ExternalID:    remoteID,
+		VolumePath:    v.targetForVolume(v.containerMountPoint, volID, allocID, usage),
+		CapacityRange: capacity,
+	}
+	if v.requiresStaging {
+		req.StagingTargetPath = v.stagingDirForVolume(v.containerMountPoint, volID, usage)
+	}
+
+	var capacityBytes int64
+	capability, err := csi.VolumeCapabilityFromStructs(usage.AttachmentMode, usage.AccessMode, usage.MountOptions)
+	if err == nil {
+		req.VolumeCapability = capability
+
+		// CSI NodeExpandVolume errors for timeout, codes.Unavailable and
+		// codes.ResourceExhausted are retried; all other errors are fatal.
+		var resp *csi.NodeExpandVolumeResponse
+		resp, err = v.plugin.NodeExpandVolume(ctx, req,
+			grpc_retry.WithPerRetryTimeout(DefaultMountActionTimeout),
+			grpc_retry.WithMax(3),
+			grpc_retry.WithBackoff(grpc_retry.BackoffExponential(100*time.Millisecond)),
+		)
+		if resp != nil {
+			capacityBytes = resp.CapacityBytes
+		}
+	}
+
+	event := structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemStorage).
+		SetMessage("Expand volume").
+		AddDetail("volume_id", volID)
+	if err == nil {
+		event.AddDetail("success", "true")
+	} else {
+		event.AddDetail("success", "false")
+		event.AddDetail("error", err.Error())
+	}
+
+	v.eventer(event)
+
+	return capacityBytes, err
+}
+
+// VolumeStats returns the usage and condition of each volume mounted on this
+// node, keyed by volume ID. Volumes the plugin fails to report on are left
+// out. A node event is emitted when a volume becomes abnormal or recovers.
+func (v *volumeManager) VolumeStats(ctx context.Context) map[string]*structs.CSIVolumeStats {
+	usages := v.usageTracker.Volumes()
+
+	v.externalIDsMu.Lock()
+	externalIDs := make(map[string]string, len(usages))
+	for volID, externalID := range v.externalIDs {
+		if _, ok := usages[volID]; !ok {
+			// the volume has been unmounted for all allocations
+			delete(v.externalIDs, volID)
+			continue
+		}
+		externalIDs[volID] = externalID
+	}
+	v.externalIDsMu.Unlock()
+
+	for volID := range v.abnormal {
+		if _, ok := usages[volID]; !ok {
+			delete(v.abnormal, volID)
+		}
+	}
+
+	var out map[string]*structs.CSIVolumeStats
+	for volID, usage := range usages {
+		externalID, ok := externalIDs[volID]
+		if !ok {
+			continue
+		}
+
+		req := &csi.NodeGetVolumeStatsRequest{
+			ExternalID: externalID,
+			VolumePath: v.targetForVolume(v.containerMountPoint, volID, usage.allocID, &usage.usageOpts),
+		}
+		if v.requiresStaging {
+			req.StagingTargetPath = v.stagingDirForVolume(v.containerMountPoint, volID, &usage.usageOpts)
+		}
+
+		resp, err := v.plugin.NodeGetVolumeStats(ctx, req)
+		if err != nil {
+			v.logger.Warn("failed to collect volume stats", "volume_id", volID, "error", err)
+			continue
+		}
+
+		stats := csiVolumeStatsFromResponse(resp)
+		v.updateVolumeCondition(volID, stats)
+		if out == nil {
+			out = make(map[string]*structs.CSIVolumeStats)
+		}
+		out[volID] = stats
+	}
+
+	return out
+}
+
+// updateVolumeCondition emits a node event when the condition of a volume
+// changes between abnormal and normal.
+func (v *volumeManager) updateVolumeCondition(volID string, stats *structs.CSIVolumeStats) {
+	if stats.Abnormal == v.abnormal[volID] {
+		return
+	}
+
+	event := structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemStorage).
+		AddDetail("volume_id", volID)
+	if stats.Abnormal {
+		v.abnormal[volID] = true
+		event.SetMessage("Volume abnormal").AddDetail("condition", stats.Message)
+	} else {
+		delete(v.abnormal, volID)
+		event.SetMessage("Volume recovered")
+	}
+
+	v.eventer(event)
+}
+
+func csiVolumeStatsFromResponse(resp *csi.NodeGetVolumeStatsResponse) *structs.CSIVolumeStats {
+	stats := &structs.CSIVolumeStats{}
+	if resp == nil {
+		return stats
+	}
+	if resp.Bytes != nil {
+		stats.TotalBytes = resp.Bytes.Total
+		stats.UsedBytes = resp.Bytes.Used
+		stats.AvailableBytes = resp.Bytes.Available
+	}
+	if resp.Inodes != nil {
+		stats.TotalInodes = resp.Inodes.Total
+		stats.UsedInodes = resp.Inodes.Used
+		stats.AvailableInodes = resp.Inodes.Available
+	}
+	if resp.Condition != nil {
+		stats.Abnormal = resp.Condition.Abnormal
+		stats.Message = resp.Condition.Message
+	}
+	return stats
+}
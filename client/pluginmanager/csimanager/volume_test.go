@@ -515,3 +515,149 @@ func TestVolumeManager_MountVolumeEvents(t *testing.T) {
 	require.Equal(t, "vol", e.Details["volume_id"])
 	require.Equal(t, "true", e.Details["success"])
 }
+
+func TestVolumeManager_ExpandVolume(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		Name                 string
+		UsageOptions         *UsageOptions
+		PluginErr            error
+		ExpectedErr          error
+		ExpectedCapacity     int64
+		ExpectedCSICallCount int64
+	}{
+		{
+			Name:                 "Returns an error when the attachment mode is unknown",
+			UsageOptions:         &UsageOptions{},
+			ExpectedErr:          errors.New("unknown volume attachment mode: "),
+			ExpectedCSICallCount: 0,
+		},
+		{
+			Name: "Returns an error when the plugin returns an error",
+			UsageOptions: &UsageOptions{
+				AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+				AccessMode:     structs.CSIVolumeAccessModeSingleNodeWriter,
+			},
+			PluginErr:            errors.New("Some Unknown Error"),
+			ExpectedErr:          errors.New("Some Unknown Error"),
+			ExpectedCSICallCount: 1,
+		},
+		{
+			Name: "Happy Path",
+			UsageOptions: &UsageOptions{
+				AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+				AccessMode:     structs.CSIVolumeAccessModeSingleNodeWriter,
+			},
+			ExpectedCapacity:     4096,
+			ExpectedCSICallCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tmpPath := t.TempDir()
+
+			csiFake := &csifake.Client{}
+			csiFake.NextNodeExpandVolumeErr = tc.PluginErr
+			if tc.PluginErr == nil {
+				csiFake.NextNodeExpandVolumeResponse = &csi.NodeExpandVolumeResponse{
+					CapacityBytes: 4096,
+				}
+			}
+
+			var events []*structs.NodeEvent
+			eventer := func(e *structs.NodeEvent) {
+				events = append(events, e)
+			}
+			manager := newVolumeManager(testlog.HCLogger(t), eventer, csiFake, tmpPath, tmpPath, true)
+			ctx := context.Background()
+
+			capacity, err := manager.ExpandVolume(ctx, "foo", "foo", "alloc",
+				tc.UsageOptions, &csi.CapacityRange{RequiredBytes: 2048})
+
+			require.Len(t, events, 1)
+			require.Equal(t, "Expand volume", events[0].Message)
+			require.Equal(t, "foo", events[0].Details["volume_id"])
+
+			if tc.ExpectedErr != nil {
+				require.EqualError(t, err, tc.ExpectedErr.Error())
+				require.Equal(t, "false", events[0].Details["success"])
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "true", events[0].Details["success"])
+			}
+			require.Equal(t, tc.ExpectedCapacity, capacity)
+			require.Equal(t, tc.ExpectedCSICallCount, csiFake.NodeExpandVolumeCallCount)
+		})
+	}
+}
+
+func TestVolumeManager_VolumeStats(t *testing.T) {
+	ci.Parallel(t)
+
+	tmpPath := t.TempDir()
+	csiFake := &csifake.Client{}
+
+	var events []*structs.NodeEvent
+	eventer := func(e *structs.NodeEvent) {
+		events = append(events, e)
+	}
+	manager := newVolumeManager(testlog.HCLogger(t), eventer, csiFake, tmpPath, tmpPath, true)
+	ctx := context.Background()
+
+	// no volumes are mounted
+	require.Nil(t, manager.VolumeStats(ctx))
+	require.Equal(t, int64(0), csiFake.NodeGetVolumeStatsCallCount)
+
+	usage := &UsageOptions{
+		AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		AccessMode:     structs.CSIVolumeAccessModeSingleNodeWriter,
+	}
+	manager.usageTracker.Claim("alloc", "foo", usage)
+	manager.externalIDs["foo"] = "vol-12345"
+
+	csiFake.NextNodeGetVolumeStatsResponse = &csi.NodeGetVolumeStatsResponse{
+		Bytes:     &csi.VolumeUsage{Total: 1024, Used: 256, Available: 768},
+		Inodes:    &csi.VolumeUsage{Total: 100, Used: 10, Available: 90},
+		Condition: &csi.VolumeCondition{Abnormal: true, Message: "disk failure"},
+	}
+	stats := manager.VolumeStats(ctx)
+	require.Equal(t, map[string]*structs.CSIVolumeStats{
+		"foo": {
+			TotalBytes:      1024,
+			UsedBytes:       256,
+			AvailableBytes:  768,
+			TotalInodes:     100,
+			UsedInodes:      10,
+			AvailableInodes: 90,
+			Abnormal:        true,
+			Message:         "disk failure",
+		},
+	}, stats)
+	require.Len(t, events, 1)
+	require.Equal(t, "Volume abnormal", events[0].Message)
+	require.Equal(t, "foo", events[0].Details["volume_id"])
+	require.Equal(t, "disk failure", events[0].Details["condition"])
+
+	// the event is only emitted when the condition changes
+	manager.VolumeStats(ctx)
+	require.Len(t, events, 1)
+
+	csiFake.NextNodeGetVolumeStatsResponse.Condition = &csi.VolumeCondition{}
+	manager.VolumeStats(ctx)
+	require.Len(t, events, 2)
+	require.Equal(t, "Volume recovered", events[1].Message)
+
+	// volumes the plugin fails to report on are left out
+	csiFake.NextNodeGetVolumeStatsErr = errors.New("plugin error")
+	require.Nil(t, manager.VolumeStats(ctx))
+
+	// unmounted volumes are no longer reported
+	manager.usageTracker.Free("alloc", "foo", usage)
+	csiFake.NextNodeGetVolumeStatsErr = nil
+	callCount := csiFake.NodeGetVolumeStatsCallCount
+	require.Nil(t, manager.VolumeStats(ctx))
+	require.Equal(t, callCount, csiFake.NodeGetVolumeStatsCallCount)
+	require.Empty(t, manager.externalIDs)
+}
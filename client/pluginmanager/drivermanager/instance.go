@@ -7,6 +7,7 @@ import (
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -237,6 +238,28 @@ func (i *instanceManager) dispense() (plugin drivers.DriverPlugin, err error) {
 	return driver, nil
 }
 
+// reloadConfig sets the plugin's reloaded configuration on the running driver
+// plugin if it supports being reconfigured. If the plugin isn't running, the
+// configuration is set when it is next dispensed.
+func (i *instanceManager) reloadConfig(config *base.Config) error {
+	c := *config
+	c.AgentConfig = i.pluginConfig
+
+	i.pluginLock.Lock()
+	defer i.pluginLock.Unlock()
+
+	if i.plugin == nil || i.plugin.Exited() {
+		return nil
+	}
+
+	if err := pluginmanager.ReloadConfig(i.driver, &c); err != nil {
+		return err
+	}
+
+	i.logger.Info("reloaded driver plugin configuration")
+	return nil
+}
+
 // cleanup shutsdown the plugin
 func (i *instanceManager) cleanup() {
 	i.shutdownLock.Lock()
@@ -7,6 +7,7 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
 	"github.com/hashicorp/nomad/helper/testlog"
@@ -121,3 +122,39 @@ func TestInstanceManager_dispense(t *testing.T) {
 	require.Same(plug, plug2)
 
 }
+
+func TestInstanceManager_reloadConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	info := &base.PluginInfoResponse{Type: base.PluginTypeDriver, Name: "mock"}
+	var setConfigs []*base.Config
+	drv := &dtu.MockDriver{
+		MockPlugin: base.MockPlugin{
+			PluginInfoF: base.StaticInfo(info),
+			SetConfigF: func(c *base.Config) error {
+				setConfigs = append(setConfigs, c)
+				return nil
+			},
+		},
+	}
+	agentConfig := &base.AgentConfig{Driver: &base.ClientDriverConfig{ClientMinPort: 1000}}
+	i := &instanceManager{
+		logger:       testlog.HCLogger(t),
+		id:           &loader.PluginID{Name: "mock", PluginType: base.PluginTypeDriver},
+		pluginConfig: agentConfig,
+		plugin:       loader.MockBasicExternalPlugin(drv, "0.1.0"),
+		driver:       drv,
+	}
+	c := &base.Config{PluginConfig: []byte("new")}
+
+	// Drivers that don't support being reconfigured keep their configuration
+	err := i.reloadConfig(c)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not support reloading")
+	require.Empty(t, setConfigs)
+
+	// The agent config of the instance is set along with the plugin config
+	info.ReloadConfig = true
+	require.NoError(t, i.reloadConfig(c))
+	require.Equal(t, []*base.Config{{PluginConfig: []byte("new"), AgentConfig: agentConfig}}, setConfigs)
+}
@@ -27,6 +27,10 @@ type Manager interface {
 	// Dispense returns a drivers.DriverPlugin for the given driver plugin name
 	// handling reattaching to an existing driver if available
 	Dispense(driver string) (drivers.DriverPlugin, error)
+
+	// ReloadPluginConfig sets the reloaded configuration of the given plugin
+	// on the running driver plugin.
+	ReloadPluginConfig(id loader.PluginID, config *base.Config) error
 }
 
 // TaskExecHandler is function to be called for executing commands in a task
@@ -229,6 +233,29 @@ func (m *manager) Shutdown() {
 	}
 }
 
+// ReloadPluginConfig sets the reloaded configuration of the given plugin on the
+// running driver plugin. Tasks keep running on the reconfigured driver and a
+// driver that fails to be reconfigured keeps running with its previous
+// configuration.
+func (m *manager) ReloadPluginConfig(id loader.PluginID, config *base.Config) error {
+	if id.PluginType != base.PluginTypeDriver {
+		return nil
+	}
+
+	m.instancesMu.RLock()
+	instance, ok := m.instances[id.Name]
+	m.instancesMu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	if err := instance.reloadConfig(config); err != nil {
+		m.logger.Error("failed to reload driver plugin configuration", "driver", id.Name, "error", err)
+		return fmt.Errorf("driver %q: %v", id.Name, err)
+	}
+	return nil
+}
+
 func (m *manager) WaitForFirstFingerprint(ctx context.Context) <-chan struct{} {
 	ctx, cancel := context.WithCancel(ctx)
 	go m.waitForFirstFingerprint(ctx, cancel)
@@ -48,5 +48,7 @@ func (m *testManager) Dispense(driver string) (drivers.DriverPlugin, error) {
 	return d, nil
 }
 
+func (m *testManager) ReloadPluginConfig(loader.PluginID, *base.Config) error { return nil }
+
 func (m *testManager) RegisterEventHandler(driver, taskID string, handler EventHandler) {}
 func (m *testManager) DeregisterEventHandler(driver, taskID string)                     {}
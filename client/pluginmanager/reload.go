@@ -0,0 +1,26 @@
+package pluginmanager
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// ReloadConfig sets a reloaded configuration on a running plugin. The
+// configuration is only set if the plugin supports being reconfigured while
+// running, otherwise an error is returned and the plugin keeps its previous
+// configuration.
+func ReloadConfig(p base.BasePlugin, c *base.Config) error {
+	info, err := p.PluginInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get plugin info: %v", err)
+	}
+	if !info.ReloadConfig {
+		return fmt.Errorf("plugin does not support reloading its configuration, restart the agent to apply it")
+	}
+
+	if err := p.SetConfig(c); err != nil {
+		return fmt.Errorf("setting config failed: %v", err)
+	}
+	return nil
+}
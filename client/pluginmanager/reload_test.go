@@ -0,0 +1,42 @@
+package pluginmanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	info := &base.PluginInfoResponse{Type: base.PluginTypeDevice, Name: "mock"}
+	var setConfigs []*base.Config
+	var setErr error
+	p := &base.MockPlugin{
+		PluginInfoF: base.StaticInfo(info),
+		SetConfigF: func(c *base.Config) error {
+			setConfigs = append(setConfigs, c)
+			return setErr
+		},
+	}
+	c := &base.Config{PluginConfig: []byte("new")}
+
+	// Plugins that don't support being reconfigured aren't called
+	err := ReloadConfig(p, c)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not support reloading")
+	require.Empty(t, setConfigs)
+
+	info.ReloadConfig = true
+	require.NoError(t, ReloadConfig(p, c))
+	require.Equal(t, []*base.Config{c}, setConfigs)
+
+	// Errors setting the config are returned
+	setErr = fmt.Errorf("invalid config")
+	err = ReloadConfig(p, c)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid config")
+}
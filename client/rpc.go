@@ -19,11 +19,13 @@ import (
 
 // rpcEndpoints holds the RPC endpoints
 type rpcEndpoints struct {
-	ClientStats *ClientStats
-	CSI         *CSI
-	FileSystem  *FileSystem
-	Allocations *Allocations
-	Agent       *Agent
+	ClientStats   *ClientStats
+	ClientPlugins *ClientPlugins
+	CSI           *CSI
+	FileSystem    *FileSystem
+	Allocations   *Allocations
+	Agent         *Agent
+	HostVolume    *HostVolume
 }
 
 // ClientRPC is used to make a local, client only RPC call
@@ -259,10 +261,12 @@ func (c *Client) setupClientRpc(rpcs map[string]interface{}) {
 		}
 	} else {
 		c.endpoints.ClientStats = &ClientStats{c}
+		c.endpoints.ClientPlugins = &ClientPlugins{c}
 		c.endpoints.CSI = &CSI{c}
 		c.endpoints.FileSystem = NewFileSystemEndpoint(c)
 		c.endpoints.Allocations = NewAllocationsEndpoint(c)
 		c.endpoints.Agent = NewAgentEndpoint(c)
+		c.endpoints.HostVolume = &HostVolume{c}
 		c.setupClientRpcServer(c.rpcServer)
 	}
 
@@ -273,10 +277,12 @@ func (c *Client) setupClientRpc(rpcs map[string]interface{}) {
 func (c *Client) setupClientRpcServer(server *rpc.Server) {
 	// Register the endpoints
 	server.Register(c.endpoints.ClientStats)
+	server.Register(c.endpoints.ClientPlugins)
 	server.Register(c.endpoints.CSI)
 	server.Register(c.endpoints.FileSystem)
 	server.Register(c.endpoints.Allocations)
 	server.Register(c.endpoints.Agent)
+	server.Register(c.endpoints.HostVolume)
 }
 
 // rpcConnListener is a long lived function that listens for new connections
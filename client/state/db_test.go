@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/kr/pretty"
 	"github.com/stretchr/testify/require"
 )
@@ -299,6 +300,35 @@ func TestStateDB_TaskState(t *testing.T) {
 	})
 }
 
+// TestStateDB_UserNamespace asserts the behavior of GetUserNamespace and
+// PutUserNamespace for all operational StateDB implementations.
+func TestStateDB_UserNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		require := require.New(t)
+
+		// Getting nonexistent state should return nil
+		spec, err := db.GetUserNamespace("allocid")
+		require.NoError(err)
+		require.Nil(spec)
+
+		// Putting a spec without first putting the allocation should work
+		orig := &drivers.UserNamespaceSpec{HostID: 1000000, Size: 65536}
+		require.NoError(db.PutUserNamespace("allocid", orig))
+
+		spec, err = db.GetUserNamespace("allocid")
+		require.NoError(err)
+		require.Equal(orig, spec)
+
+		// Deleting the allocation should remove the spec
+		require.NoError(db.DeleteAllocationBucket("allocid"))
+		spec, err = db.GetUserNamespace("allocid")
+		require.NoError(err)
+		require.Nil(spec)
+	})
+}
+
 // TestStateDB_DeviceManager asserts the behavior of device manager state related StateDB
 // methods.
 func TestStateDB_DeviceManager(t *testing.T) {
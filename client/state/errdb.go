@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 // ErrDB implements a StateDB that returns errors on restore methods, used for testing
@@ -48,6 +49,14 @@ func (m *ErrDB) PutNetworkStatus(allocID string, ns *structs.AllocNetworkStatus,
 	return fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) GetUserNamespace(allocID string) (*drivers.UserNamespaceSpec, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) PutUserNamespace(allocID string, spec *drivers.UserNamespaceSpec) error {
+	return fmt.Errorf("Error!")
+}
+
 func (m *ErrDB) GetTaskRunnerState(allocID string, taskName string) (*state.LocalState, *structs.TaskState, error) {
 	return nil, nil, fmt.Errorf("Error!")
 }
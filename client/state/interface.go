@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 // StateDB implementations store and load Nomad client state.
@@ -38,6 +39,11 @@ type StateDB interface {
 	GetNetworkStatus(allocID string) (*structs.AllocNetworkStatus, error)
 	PutNetworkStatus(allocID string, ns *structs.AllocNetworkStatus, opts ...WriteOption) error
 
+	// Get/Put UserNamespace get and put the range of host IDs allocated to
+	// the allocation for user namespaces. It may be nil.
+	GetUserNamespace(allocID string) (*drivers.UserNamespaceSpec, error)
+	PutUserNamespace(allocID string, spec *drivers.UserNamespaceSpec) error
+
 	// GetTaskRunnerState returns the LocalState and TaskState for a
 	// TaskRunner. Either state may be nil if it is not found, but if an
 	// error is encountered only the error will be non-nil.
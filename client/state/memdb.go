@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 // MemDB implements a StateDB that stores data in memory and should only be
@@ -23,6 +24,9 @@ type MemDB struct {
 	// alloc_id -> value
 	networkStatus map[string]*structs.AllocNetworkStatus
 
+	// alloc_id -> value
+	userNamespace map[string]*drivers.UserNamespaceSpec
+
 	// alloc_id -> task_name -> value
 	localTaskState map[string]map[string]*state.LocalState
 	taskState      map[string]map[string]*structs.TaskState
@@ -47,6 +51,7 @@ func NewMemDB(logger hclog.Logger) *MemDB {
 		allocs:         make(map[string]*structs.Allocation),
 		deployStatus:   make(map[string]*structs.AllocDeploymentStatus),
 		networkStatus:  make(map[string]*structs.AllocNetworkStatus),
+		userNamespace:  make(map[string]*drivers.UserNamespaceSpec),
 		localTaskState: make(map[string]map[string]*state.LocalState),
 		taskState:      make(map[string]map[string]*structs.TaskState),
 		logger:         logger,
@@ -106,6 +111,19 @@ func (m *MemDB) PutNetworkStatus(allocID string, ns *structs.AllocNetworkStatus,
 	return nil
 }
 
+func (m *MemDB) GetUserNamespace(allocID string) (*drivers.UserNamespaceSpec, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.userNamespace[allocID], nil
+}
+
+func (m *MemDB) PutUserNamespace(allocID string, spec *drivers.UserNamespaceSpec) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.userNamespace[allocID] = spec
+	return nil
+}
+
 func (m *MemDB) GetTaskRunnerState(allocID string, taskName string) (*state.LocalState, *structs.TaskState, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -182,6 +200,7 @@ func (m *MemDB) DeleteAllocationBucket(allocID string, opts ...WriteOption) erro
 	delete(m.allocs, allocID)
 	delete(m.taskState, allocID)
 	delete(m.localTaskState, allocID)
+	delete(m.userNamespace, allocID)
 
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 // NoopDB implements a StateDB that does not persist any data.
@@ -43,6 +44,14 @@ func (n NoopDB) PutNetworkStatus(allocID string, ds *structs.AllocNetworkStatus,
 	return nil
 }
 
+func (n NoopDB) GetUserNamespace(allocID string) (*drivers.UserNamespaceSpec, error) {
+	return nil, nil
+}
+
+func (n NoopDB) PutUserNamespace(allocID string, spec *drivers.UserNamespaceSpec) error {
+	return nil
+}
+
 func (n NoopDB) GetTaskRunnerState(allocID string, taskName string) (*state.LocalState, *structs.TaskState, error) {
 	return nil, nil, nil
 }
@@ -13,6 +13,7 @@ import (
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
 	"github.com/hashicorp/nomad/helper/boltdd"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"go.etcd.io/bbolt"
 )
 
@@ -73,6 +74,10 @@ var (
 	// stored under
 	allocNetworkStatusKey = []byte("network_status")
 
+	// allocUserNamespaceKey is the key *drivers.UserNamespaceSpec is
+	// stored under
+	allocUserNamespaceKey = []byte("user_namespace")
+
 	// allocations -> $allocid -> task-$taskname -> the keys below
 	taskLocalStateKey = []byte("local_state")
 	taskStateKey      = []byte("task_state")
@@ -371,6 +376,60 @@ func (s *BoltStateDB) GetNetworkStatus(allocID string) (*structs.AllocNetworkSta
 	return entry.NetworkStatus, nil
 }
 
+// userNamespaceEntry wraps values for UserNamespace keys.
+type userNamespaceEntry struct {
+	UserNamespace *drivers.UserNamespaceSpec
+}
+
+// PutUserNamespace stores the range of host IDs allocated to an
+// allocation's user namespaces or returns an error.
+func (s *BoltStateDB) PutUserNamespace(allocID string, spec *drivers.UserNamespaceSpec) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		allocBkt, err := getAllocationBucket(tx, allocID)
+		if err != nil {
+			return err
+		}
+
+		entry := userNamespaceEntry{
+			UserNamespace: spec,
+		}
+		return allocBkt.Put(allocUserNamespaceKey, &entry)
+	})
+}
+
+// GetUserNamespace retrieves the range of host IDs allocated to an
+// allocation's user namespaces or returns an error.
+func (s *BoltStateDB) GetUserNamespace(allocID string) (*drivers.UserNamespaceSpec, error) {
+	var entry userNamespaceEntry
+
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		allAllocsBkt := tx.Bucket(allocationsBucketName)
+		if allAllocsBkt == nil {
+			// No state, return
+			return nil
+		}
+
+		allocBkt := allAllocsBkt.Bucket([]byte(allocID))
+		if allocBkt == nil {
+			// No state for alloc, return
+			return nil
+		}
+
+		return allocBkt.Get(allocUserNamespaceKey, &entry)
+	})
+
+	// It's valid for this field to be nil/missing
+	if boltdd.IsErrNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.UserNamespace, nil
+}
+
 // GetTaskRunnerState returns the LocalState and TaskState for a
 // TaskRunner. LocalState or TaskState will be nil if they do not exist.
 //
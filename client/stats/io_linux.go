@@ -0,0 +1,43 @@
+package stats
+
+import (
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// IOCounters holds the cumulative block I/O of a task across all devices
+type IOCounters struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+}
+
+// CgroupIOCounters sums the block I/O stats of a cgroup across devices. The
+// v1 blkio and v2 io controllers report the stats in the same layout, with
+// the v1 sync, async and total counters being ignored.
+func CgroupIOCounters(blkio *cgroups.BlkioStats) *IOCounters {
+	c := &IOCounters{}
+	if blkio == nil {
+		return c
+	}
+
+	for _, e := range blkio.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			c.ReadBytes += e.Value
+		case "write":
+			c.WriteBytes += e.Value
+		}
+	}
+	for _, e := range blkio.IoServicedRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			c.ReadOps += e.Value
+		case "write":
+			c.WriteOps += e.Value
+		}
+	}
+	return c
+}
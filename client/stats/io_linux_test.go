@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCgroupIOCounters(t *testing.T) {
+	ci.Parallel(t)
+
+	require.Equal(t, &IOCounters{}, CgroupIOCounters(nil))
+
+	// v1 reports sync, async and total counters next to read and write
+	blkio := &cgroups.BlkioStats{
+		IoServiceBytesRecursive: []cgroups.BlkioStatEntry{
+			{Major: 8, Minor: 0, Op: "Read", Value: 4096},
+			{Major: 8, Minor: 0, Op: "Write", Value: 8192},
+			{Major: 8, Minor: 0, Op: "Sync", Value: 8192},
+			{Major: 8, Minor: 0, Op: "Total", Value: 12288},
+			{Major: 8, Minor: 16, Op: "Read", Value: 1024},
+		},
+		IoServicedRecursive: []cgroups.BlkioStatEntry{
+			{Major: 8, Minor: 0, Op: "Read", Value: 1},
+			{Major: 8, Minor: 0, Op: "Write", Value: 2},
+			{Major: 8, Minor: 0, Op: "Total", Value: 3},
+			{Major: 8, Minor: 16, Op: "write", Value: 4},
+		},
+	}
+	require.Equal(t, &IOCounters{
+		ReadBytes:  5120,
+		WriteBytes: 8192,
+		ReadOps:    1,
+		WriteOps:   6,
+	}, CgroupIOCounters(blkio))
+}
@@ -236,10 +236,6 @@ func (req *ClientCSIControllerCreateVolumeRequest) ToCSIRequest() (*csi.Controll
 			CloneID:    req.CloneID,
 			SnapshotID: req.SnapshotID,
 		},
-		AccessibilityRequirements: &csi.TopologyRequirement{
-			Requisite: []*csi.Topology{},
-			Preferred: []*csi.Topology{},
-		},
 	}
 
 	// The CSI spec requires that at least one of the fields in CapacityRange
@@ -260,7 +256,13 @@ func (req *ClientCSIControllerCreateVolumeRequest) ToCSIRequest() (*csi.Controll
 		creq.VolumeCapabilities = append(creq.VolumeCapabilities, ccap)
 	}
 
+	// Accessibility requirements are only sent if requested, as plugins
+	// supporting topologies may otherwise reject an empty requisite list
 	if req.RequestedTopologies != nil {
+		creq.AccessibilityRequirements = &csi.TopologyRequirement{
+			Requisite: []*csi.Topology{},
+			Preferred: []*csi.Topology{},
+		}
 		for _, topo := range req.RequestedTopologies.Required {
 			creq.AccessibilityRequirements.Requisite = append(
 				creq.AccessibilityRequirements.Requisite, &csi.Topology{
@@ -303,6 +305,51 @@ func (req *ClientCSIControllerDeleteVolumeRequest) ToCSIRequest() *csi.Controlle
 
 type ClientCSIControllerDeleteVolumeResponse struct{}
 
+// ClientCSIControllerExpandVolumeRequest the RPC made from the server to a
+// Nomad client to tell a CSI controller plugin on that client to perform
+// ControllerExpandVolume
+type ClientCSIControllerExpandVolumeRequest struct {
+	ExternalVolumeID string
+	CapacityMin      int64
+	CapacityMax      int64
+	Secrets          structs.CSISecrets
+
+	// VolumeCapability is the capability the volume is in use with, if
+	// any, so the plugin can tell mounted and block volumes apart
+	VolumeCapability *structs.CSIVolumeCapability
+	MountOptions     *structs.CSIMountOptions
+
+	CSIControllerQuery
+}
+
+func (req *ClientCSIControllerExpandVolumeRequest) ToCSIRequest() (*csi.ControllerExpandVolumeRequest, error) {
+	creq := &csi.ControllerExpandVolumeRequest{
+		ExternalVolumeID: req.ExternalVolumeID,
+		Secrets:          req.Secrets,
+		CapacityRange: &csi.CapacityRange{
+			RequiredBytes: req.CapacityMin,
+			LimitBytes:    req.CapacityMax,
+		},
+	}
+
+	if req.VolumeCapability != nil {
+		ccap, err := csi.VolumeCapabilityFromStructs(
+			req.VolumeCapability.AttachmentMode,
+			req.VolumeCapability.AccessMode,
+			req.MountOptions)
+		if err != nil {
+			return nil, err
+		}
+		creq.VolumeCapability = ccap
+	}
+	return creq, nil
+}
+
+type ClientCSIControllerExpandVolumeResponse struct {
+	CapacityBytes         int64
+	NodeExpansionRequired bool
+}
+
 // ClientCSIControllerListVolumesVolumeRequest the RPC made from the server to
 // a Nomad client to tell a CSI controller plugin on that client to perform
 // ListVolumes
@@ -419,3 +466,30 @@ type ClientCSINodeDetachVolumeRequest struct {
 }
 
 type ClientCSINodeDetachVolumeResponse struct{}
+
+// ClientCSINodeExpandVolumeRequest is the RPC made from the server to a Nomad
+// client to tell a CSI node plugin on that client to perform NodeExpandVolume
+// once the controller has expanded the volume.
+type ClientCSINodeExpandVolumeRequest struct {
+	PluginID   string // ID of the plugin that manages the volume (required)
+	VolumeID   string // ID of the volume to be expanded (required)
+	AllocID    string // ID of the allocation the volume is published for (required)
+	NodeID     string // ID of the Nomad client targeted
+	ExternalID string // External ID of the volume to be expanded (required)
+
+	// These fields should match the original volume request so that
+	// we can find the mount points on the client
+	AttachmentMode structs.CSIVolumeAttachmentMode
+	AccessMode     structs.CSIVolumeAccessMode
+	ReadOnly       bool
+	MountOptions   *structs.CSIMountOptions
+
+	// CapacityMin and CapacityMax are the capacity range the controller
+	// expanded the volume to
+	CapacityMin int64
+	CapacityMax int64
+}
+
+type ClientCSINodeExpandVolumeResponse struct {
+	CapacityBytes int64
+}
@@ -0,0 +1,33 @@
+package structs
+
+// ClientHostVolumeCreateRequest is the RPC made from the server to a Nomad
+// client to create a dynamic host volume and fingerprint it.
+type ClientHostVolumeCreateRequest struct {
+	ID       string // ID of the volume to be created (required)
+	Name     string // Name the volume is fingerprinted under (required)
+	NodeID   string // ID of the Nomad client targeted
+	PluginID string // Plugin that provisions the volume, or empty for a directory
+
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+	Parameters                map[string]string
+}
+
+type ClientHostVolumeCreateResponse struct {
+	HostPath      string
+	CapacityBytes int64
+}
+
+// ClientHostVolumeDeleteRequest is the RPC made from the server to a Nomad
+// client to remove a dynamic host volume from its fingerprint and delete it.
+type ClientHostVolumeDeleteRequest struct {
+	ID       string // ID of the volume to be deleted (required)
+	Name     string // Name the volume is fingerprinted under
+	NodeID   string // ID of the Nomad client targeted
+	PluginID string // Plugin that provisioned the volume, or empty for a directory
+	HostPath string // Path of the volume on the client
+
+	Parameters map[string]string
+}
+
+type ClientHostVolumeDeleteResponse struct{}
@@ -34,6 +34,35 @@ type ClientStatsResponse struct {
 	structs.QueryMeta
 }
 
+// ClientPluginsResponse is used to return the plugins loaded by a node.
+type ClientPluginsResponse struct {
+	Plugins []*ClientPlugin
+	structs.QueryMeta
+}
+
+// ClientPlugin describes a plugin loaded by a node.
+type ClientPlugin struct {
+	// Name and Type identify the plugin
+	Name string
+	Type string
+
+	// Version is the plugin's version and ApiVersion the negotiated plugin
+	// API version
+	Version    string
+	ApiVersion string
+
+	// Internal marks plugins built into the Nomad binary
+	Internal bool
+
+	// Path and SHA256 are the binary and its checksum for external plugins
+	Path   string
+	SHA256 string
+
+	// Healthy is nil for plugins that don't report their health
+	Healthy           *bool
+	HealthDescription string
+}
+
 // MonitorRequest is used to request and stream logs from a client node.
 type MonitorRequest struct {
 	// LogLevel is the log level filter we want to stream logs on
@@ -259,17 +288,86 @@ func (cs *CpuStats) Add(other *CpuStats) {
 	cs.Measured = joinStringSet(cs.Measured, other.Measured)
 }
 
-// ResourceUsage holds information related to cpu and memory stats
+// IOStats holds block I/O usage related stats
+type IOStats struct {
+	// ReadBytes and WriteBytes are the bytes read from and written to block
+	// devices
+	ReadBytes  uint64
+	WriteBytes uint64
+
+	// ReadOps and WriteOps are the read and write operations serviced by
+	// block devices
+	ReadOps  uint64
+	WriteOps uint64
+
+	// A list of fields whose values were actually sampled
+	Measured []string
+}
+
+func (is *IOStats) Add(other *IOStats) {
+	if other == nil {
+		return
+	}
+
+	is.ReadBytes += other.ReadBytes
+	is.WriteBytes += other.WriteBytes
+	is.ReadOps += other.ReadOps
+	is.WriteOps += other.WriteOps
+	is.Measured = joinStringSet(is.Measured, other.Measured)
+}
+
+// NetworkStats holds the usage of an allocation's shared network
+type NetworkStats struct {
+	// RxBytes and TxBytes are the bytes received and transmitted by the
+	// allocation
+	RxBytes uint64
+	TxBytes uint64
+
+	// RxMBits and TxMBits are the throughput in Mb/s measured since the
+	// previous sample
+	RxMBits float64
+	TxMBits float64
+
+	// LimitMBits is the bandwidth the allocation is shaped to in each
+	// direction, or zero if unlimited
+	LimitMBits int
+
+	// A list of fields whose values were actually sampled
+	Measured []string
+}
+
+// DiskStats holds the usage of an allocation's ephemeral disk
+type DiskStats struct {
+	// Used is the bytes written to the ephemeral disk
+	Used uint64
+
+	// Size is the ephemeral disk size in bytes
+	Size uint64
+
+	// A list of fields whose values were actually sampled
+	Measured []string
+}
+
+// ResourceUsage holds information related to cpu, memory and block I/O stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
 	DeviceStats []*device.DeviceGroupStats
+
+	// IOStats is only set by drivers that measure block I/O
+	IOStats *IOStats
 }
 
 func (ru *ResourceUsage) Add(other *ResourceUsage) {
 	ru.MemoryStats.Add(other.MemoryStats)
 	ru.CpuStats.Add(other.CpuStats)
 	ru.DeviceStats = append(ru.DeviceStats, other.DeviceStats...)
+	if other.IOStats != nil {
+		if ru.IOStats == nil {
+			ru.IOStats = &IOStats{}
+		}
+		ru.IOStats.Add(other.IOStats)
+	}
 }
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -289,6 +387,14 @@ type AllocResourceUsage struct {
 	// Tasks contains the resource usage of each task
 	Tasks map[string]*TaskResourceUsage
 
+	// NetworkStats is the usage of the allocation's shared network, if it
+	// can be measured
+	NetworkStats *NetworkStats
+
+	// DiskStats is the usage of the allocation's ephemeral disk, if its
+	// size is enforced
+	DiskStats *DiskStats
+
 	// The max timestamp of all the Tasks
 	Timestamp int64
 }
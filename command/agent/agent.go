@@ -19,6 +19,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	uuidparse "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/allocdir"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/state"
@@ -573,6 +574,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if agentConfig.DataDir != "" {
 		conf.StateDir = filepath.Join(agentConfig.DataDir, "client")
 		conf.AllocDir = filepath.Join(agentConfig.DataDir, "alloc")
+		conf.HostVolumesDir = filepath.Join(agentConfig.DataDir, "host_volumes")
 	}
 	if agentConfig.Client.StateDir != "" {
 		conf.StateDir = agentConfig.Client.StateDir
@@ -580,6 +582,10 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if agentConfig.Client.AllocDir != "" {
 		conf.AllocDir = agentConfig.Client.AllocDir
 	}
+	if agentConfig.Client.HostVolumesDir != "" {
+		conf.HostVolumesDir = agentConfig.Client.HostVolumesDir
+	}
+	conf.HostVolumePluginDir = agentConfig.Client.HostVolumePluginDir
 	if agentConfig.Client.NetworkInterface != "" {
 		conf.NetworkInterface = agentConfig.Client.NetworkInterface
 	}
@@ -623,6 +629,22 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Node.Name = agentConfig.NodeName
 	conf.Node.Meta = agentConfig.Client.Meta
 	conf.Node.NodeClass = agentConfig.Client.NodeClass
+	for i, mw := range agentConfig.Client.MaintenanceWindows {
+		dur, err := time.ParseDuration(mw.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing maintenance window %d duration: %s", i+1, err)
+		}
+		window := &structs.NodeMaintenanceWindow{
+			Cron:     mw.Cron,
+			Duration: dur,
+			TimeZone: mw.TimeZone,
+			Source:   structs.NodeMaintenanceWindowSourceConfig,
+		}
+		if err := window.Validate(); err != nil {
+			return nil, fmt.Errorf("Invalid maintenance window %d: %s", i+1, err)
+		}
+		conf.Node.MaintenanceWindows = append(conf.Node.MaintenanceWindows, window)
+	}
 
 	// Set up the HTTP advertise address
 	conf.Node.HTTPAddr = agentConfig.AdvertiseAddrs.HTTP
@@ -724,6 +746,15 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.Artifact = artifactConfig
 
+	if agentConfig.Client.UserNamespaces != nil {
+		conf.UserNamespaces = agentConfig.Client.UserNamespaces.Copy()
+	}
+
+	if err := allocdir.DiskQuotaMode(agentConfig.Client.DiskQuota).Validate(); err != nil {
+		return nil, fmt.Errorf("invalid 'disk_quota': %v", err)
+	}
+	conf.DiskQuota = agentConfig.Client.DiskQuota
+
 	return conf, nil
 }
 
@@ -563,6 +563,27 @@ func TestAgent_ClientConfig_ReservedCores(t *testing.T) {
 	require.Exactly(t, []uint16{0, 2, 3}, c.Node.ReservedResources.Cpu.ReservedCpuCores)
 }
 
+func TestAgent_ClientConfig_DiskQuota(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	a := &Agent{config: conf}
+
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Empty(t, c.DiskQuota)
+
+	conf.Client.DiskQuota = "loopback"
+	c, err = a.clientConfig()
+	require.NoError(t, err)
+	require.Equal(t, "loopback", c.DiskQuota)
+
+	conf.Client.DiskQuota = "xfs"
+	_, err = a.clientConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid 'disk_quota'")
+}
+
 // Clients should inherit telemetry configuration
 func TestAgent_Client_TelemetryConfiguration(t *testing.T) {
 	ci.Parallel(t)
@@ -411,6 +411,11 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		return false
 	}
 
+	if err := config.Client.UserNamespaces.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("client.user_namespaces stanza invalid: %v", err))
+		return false
+	}
+
 	if !config.DevMode {
 		// Ensure that we have the directories we need to run.
 		if config.Server.Enabled && config.DataDir == "" {
@@ -1025,6 +1030,13 @@ func (c *Command) handleReload() {
 			c.agent.logger.Error("reloading client config failed", "error", err)
 			return
 		}
+
+		// Plugins that fail to reload keep running with their previous
+		// configuration, so don't abort the rest of the reload
+		c.agent.logger.Debug("starting reload of plugin configs")
+		if err := c.agent.reloadPlugins(newConf.Plugins); err != nil {
+			c.agent.logger.Error("reloading plugin configs failed", "error", err)
+		}
 	}
 
 	// reload HTTP server after we have reloaded both client and server, in case
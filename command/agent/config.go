@@ -327,10 +327,51 @@ type ClientConfig struct {
 	// Artifact contains the configuration for artifacts.
 	Artifact *config.ArtifactConfig `hcl:"artifact"`
 
+	// UserNamespaces configures the ranges of host IDs allocated to
+	// allocations for remapping task users into user namespaces.
+	UserNamespaces *client.UserNamespaceConfig `hcl:"user_namespaces"`
+
+	// DiskQuota selects how the ephemeral disk size of allocations is
+	// enforced: "auto", "project" or "loopback". It is not enforced if
+	// unset.
+	DiskQuota string `hcl:"disk_quota"`
+
+	// HostVolumesDir is the directory dynamic host volumes are created in
+	// when they don't use a plugin. Defaults to <data_dir>/host_volumes.
+	HostVolumesDir string `hcl:"host_volumes_dir"`
+
+	// HostVolumePluginDir is the directory containing the plugins used to
+	// provision dynamic host volumes.
+	HostVolumePluginDir string `hcl:"host_volume_plugin_dir"`
+
+	// PluginManifest lists the external plugins allowed to run along with
+	// their expected version and checksum. If set, external plugins that are
+	// not listed or don't match are not launched.
+	PluginManifest []*config.PluginManifestEntry `hcl:"plugin_manifest"`
+
+	// MaintenanceWindows are the recurring windows during which the node
+	// doesn't accept new placements of service jobs.
+	MaintenanceWindows []*MaintenanceWindowConfig `hcl:"maintenance_window"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
 
+// MaintenanceWindowConfig declares a recurring maintenance window of the node
+type MaintenanceWindowConfig struct {
+	// Name is used to identify the window in the configuration
+	Name string `hcl:",key"`
+
+	// Cron is the cron expression of the start of the window
+	Cron string `hcl:"cron"`
+
+	// Duration is how long the window stays open
+	Duration string `hcl:"duration"`
+
+	// TimeZone is the time zone the cron expression is evaluated in
+	TimeZone string `hcl:"time_zone"`
+}
+
 // ACLConfig is configuration specific to the ACL system
 type ACLConfig struct {
 	// Enabled controls if we are enforce and manage ACLs
@@ -978,6 +1019,7 @@ func DefaultConfig() *Config {
 			CNIConfigDir:                   "/opt/cni/config",
 			NomadServiceDiscovery:          helper.BoolToPtr(true),
 			Artifact:                       config.DefaultArtifactConfig(),
+			UserNamespaces:                 client.DefaultUserNamespaceConfig(),
 		},
 		Server: &ServerConfig{
 			Enabled:           false,
@@ -1783,7 +1825,29 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.CgroupParent = b.CgroupParent
 	}
 
+	if b.DiskQuota != "" {
+		result.DiskQuota = b.DiskQuota
+	}
+
+	if b.HostVolumesDir != "" {
+		result.HostVolumesDir = b.HostVolumesDir
+	}
+	if b.HostVolumePluginDir != "" {
+		result.HostVolumePluginDir = b.HostVolumePluginDir
+	}
+
+	result.PluginManifest = a.PluginManifest
+	if len(b.PluginManifest) != 0 {
+		result.PluginManifest = append(result.PluginManifest, b.PluginManifest...)
+	}
+
+	result.MaintenanceWindows = a.MaintenanceWindows
+	if len(b.MaintenanceWindows) != 0 {
+		result.MaintenanceWindows = append(result.MaintenanceWindows, b.MaintenanceWindows...)
+	}
+
 	result.Artifact = a.Artifact.Merge(b.Artifact)
+	result.UserNamespaces = a.UserNamespaces.Merge(b.UserNamespaces)
 
 	return &result
 }
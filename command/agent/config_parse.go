@@ -215,6 +215,18 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_network")
 	}
 
+	// Remove PluginManifest extra keys
+	for _, pm := range c.Client.PluginManifest {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, pm.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "plugin_manifest")
+	}
+
+	// Remove MaintenanceWindows extra keys
+	for _, mw := range c.Client.MaintenanceWindows {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, mw.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "maintenance_window")
+	}
+
 	// Remove AuditConfig extra keys
 	for _, f := range c.Audit.Filters {
 		helper.RemoveEqualFold(&c.Audit.ExtraKeysHCL, f.Name)
@@ -90,6 +90,24 @@ var basicConfig = &Config{
 		CNIPath:             "/tmp/cni_path",
 		BridgeNetworkName:   "custom_bridge_name",
 		BridgeNetworkSubnet: "custom_bridge_subnet",
+		DiskQuota:           "auto",
+		HostVolumesDir:      "/tmp/host_volumes",
+		HostVolumePluginDir: "/tmp/host_volume_plugins",
+		PluginManifest: []*config.PluginManifestEntry{
+			{
+				Name:    "nomad-driver-example",
+				Version: "0.1.0",
+				SHA256:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			},
+		},
+		MaintenanceWindows: []*MaintenanceWindowConfig{
+			{
+				Name:     "weekly",
+				Cron:     "0 2 * * 0",
+				Duration: "2h",
+				TimeZone: "Europe/Paris",
+			},
+		},
 	},
 	Server: &ServerConfig{
 		Enabled:                   true,
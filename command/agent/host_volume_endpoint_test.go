@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_HostVolumeCRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Wait for the client to be ready to create volumes
+		testutil.WaitForResult(func() (bool, error) {
+			node, err := s.server.State().NodeByID(nil, s.client.NodeID())
+			if err != nil {
+				return false, err
+			}
+			if node == nil {
+				return false, fmt.Errorf("unknown node")
+			}
+			return node.Status == structs.NodeStatusReady, fmt.Errorf("bad node status")
+		}, func(err error) {
+			t.Fatal(err)
+		})
+
+		args := &structs.HostVolumeCreateRequest{
+			Volume: &structs.HostVolume{Name: "data"},
+		}
+		req, err := http.NewRequest("PUT", "/v1/volume/host/create", encodeReq(args))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.HostVolumeSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+		vol := obj.(structs.HostVolumeCreateResponse).Volume
+		require.Equal(t, s.client.NodeID(), vol.NodeID)
+		require.DirExists(t, vol.HostPath)
+
+		req, err = http.NewRequest("GET", "/v1/volumes?type=host", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.CSIVolumesRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.HostVolume), 1)
+
+		req, err = http.NewRequest("GET", "/v1/volume/host/"+vol.ID, nil)
+		require.NoError(t, err)
+		obj, err = s.Server.HostVolumeSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, vol.HostPath, obj.(*structs.HostVolume).HostPath)
+
+		req, err = http.NewRequest("DELETE", "/v1/volume/host/"+vol.ID, nil)
+		require.NoError(t, err)
+		_, err = s.Server.HostVolumeSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.NoDirExists(t, vol.HostPath)
+
+		req, err = http.NewRequest("GET", "/v1/volume/host/"+vol.ID, nil)
+		require.NoError(t, err)
+		_, err = s.Server.HostVolumeSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "volume not found")
+	})
+}
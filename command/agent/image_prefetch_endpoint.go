@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) ImagePrefetchesRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.ImagePrefetchListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.ImagePrefetchListResponse
+	if err := s.agent.RPC(structs.ImagePrefetchListRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.ImagePrefetches == nil {
+		out.ImagePrefetches = make([]*structs.ImagePrefetch, 0)
+	}
+	return out.ImagePrefetches, nil
+}
+
+func (s *HTTPServer) ImagePrefetchSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	name := strings.TrimPrefix(req.URL.Path, "/v1/image-prefetch/")
+	if len(name) == 0 {
+		return nil, CodedError(400, "Missing Image Prefetch Name")
+	}
+	switch req.Method {
+	case "GET":
+		return s.imagePrefetchQuery(resp, req, name)
+	case "PUT", "POST":
+		return s.imagePrefetchUpdate(resp, req, name)
+	case "DELETE":
+		return s.imagePrefetchDelete(resp, req, name)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) imagePrefetchQuery(resp http.ResponseWriter, req *http.Request,
+	name string) (interface{}, error) {
+	args := structs.ImagePrefetchSpecificRequest{
+		Name: name,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleImagePrefetchResponse
+	if err := s.agent.RPC(structs.ImagePrefetchGetRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.ImagePrefetch == nil {
+		return nil, CodedError(404, "Image prefetch not found")
+	}
+	return out.ImagePrefetch, nil
+}
+
+func (s *HTTPServer) imagePrefetchUpdate(resp http.ResponseWriter, req *http.Request,
+	name string) (interface{}, error) {
+	var prefetch structs.ImagePrefetch
+	if err := decodeBody(req, &prefetch); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// The name in the body defaults to the name in the path
+	if prefetch.Name == "" {
+		prefetch.Name = name
+	} else if prefetch.Name != name {
+		return nil, CodedError(400, "Image prefetch name does not match request path")
+	}
+
+	args := structs.ImagePrefetchUpsertRequest{
+		ImagePrefetches: []*structs.ImagePrefetch{&prefetch},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC(structs.ImagePrefetchUpsertRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) imagePrefetchDelete(resp http.ResponseWriter, req *http.Request,
+	name string) (interface{}, error) {
+
+	args := structs.ImagePrefetchDeleteRequest{
+		Names: []string{name},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC(structs.ImagePrefetchDeleteRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return nil, nil
+}
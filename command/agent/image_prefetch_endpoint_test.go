@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_ImagePrefetchCRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// The name defaults to the one in the path
+		prefetch := &structs.ImagePrefetch{
+			Driver: "docker",
+			Images: []string{"nvidia/cuda:11.7.0-base-ubuntu22.04"},
+		}
+		req, err := http.NewRequest("PUT", "/v1/image-prefetch/cuda", encodeReq(prefetch))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.ImagePrefetchSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Mismatched names are rejected
+		prefetch.Name = "other"
+		req, err = http.NewRequest("PUT", "/v1/image-prefetch/cuda", encodeReq(prefetch))
+		require.NoError(t, err)
+		_, err = s.Server.ImagePrefetchSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "Image prefetch name does not match request path")
+
+		req, err = http.NewRequest("GET", "/v1/image-prefetches", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.ImagePrefetchesRequest(respW, req)
+		require.NoError(t, err)
+		require.NotZero(t, respW.HeaderMap.Get("X-Nomad-Index"))
+		list := obj.([]*structs.ImagePrefetch)
+		require.Len(t, list, 1)
+		require.Equal(t, "cuda", list[0].Name)
+
+		req, err = http.NewRequest("DELETE", "/v1/image-prefetch/cuda", nil)
+		require.NoError(t, err)
+		_, err = s.Server.ImagePrefetchSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+
+		req, err = http.NewRequest("GET", "/v1/image-prefetch/cuda", nil)
+		require.NoError(t, err)
+		_, err = s.Server.ImagePrefetchSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "Image prefetch not found")
+	})
+}
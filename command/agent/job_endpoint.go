@@ -72,6 +72,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/dispatch"):
 		jobName := strings.TrimSuffix(path, "/dispatch")
 		return s.jobDispatchRequest(resp, req, jobName)
+	case strings.HasSuffix(path, "/dispatch-queue"):
+		jobName := strings.TrimSuffix(path, "/dispatch-queue")
+		return s.jobDispatchQueue(resp, req, jobName)
 	case strings.HasSuffix(path, "/versions"):
 		jobName := strings.TrimSuffix(path, "/versions")
 		return s.jobVersions(resp, req, jobName)
@@ -506,6 +509,32 @@ func (s *HTTPServer) jobScale(resp http.ResponseWriter, req *http.Request,
 	}
 }
 
+func (s *HTTPServer) jobDispatchQueue(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobDispatchQueueRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobDispatchQueueResponse
+	if err := s.agent.RPC("Job.DispatchQueue", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Queue == nil {
+		return nil, CodedError(404, "job not found")
+	}
+
+	return out.Queue, nil
+}
+
 func (s *HTTPServer) jobScaleStatus(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 
@@ -952,9 +981,28 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 
 	if job.ParameterizedJob != nil {
 		j.ParameterizedJob = &structs.ParameterizedJobConfig{
-			Payload:      job.ParameterizedJob.Payload,
-			MetaRequired: job.ParameterizedJob.MetaRequired,
-			MetaOptional: job.ParameterizedJob.MetaOptional,
+			Payload:       job.ParameterizedJob.Payload,
+			MetaRequired:  job.ParameterizedJob.MetaRequired,
+			MetaOptional:  job.ParameterizedJob.MetaOptional,
+			MaxConcurrent: job.ParameterizedJob.MaxConcurrent,
+		}
+
+		if retry := job.ParameterizedJob.Retry; retry != nil {
+			j.ParameterizedJob.Retry = &structs.DispatchRetryPolicy{
+				Attempts:      *retry.Attempts,
+				Delay:         *retry.Delay,
+				DelayFunction: *retry.DelayFunction,
+				MaxDelay:      *retry.MaxDelay,
+				ExitCodes:     retry.ExitCodes,
+			}
+		}
+	}
+
+	if job.GC != nil {
+		j.GC = &structs.JobGCPolicy{
+			KeepSuccessful: *job.GC.KeepSuccessful,
+			KeepFailed:     *job.GC.KeepFailed,
+			MaxAge:         *job.GC.MaxAge,
 		}
 	}
 
@@ -975,6 +1023,16 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	if len(job.DependsOn) > 0 {
+		j.DependsOn = make([]*structs.JobDependency, len(job.DependsOn))
+		for i, d := range job.DependsOn {
+			j.DependsOn[i] = &structs.JobDependency{
+				JobID:     *d.JobID,
+				Condition: *d.Condition,
+			}
+		}
+	}
+
 	if len(job.TaskGroups) > 0 {
 		j.TaskGroups = []*structs.TaskGroup{}
 		for _, taskGroup := range job.TaskGroups {
@@ -1016,6 +1074,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.MaxClientDisconnect = taskGroup.MaxClientDisconnect
 	}
 
+	if taskGroup.ExpectedDuration != nil {
+		tg.ExpectedDuration = taskGroup.ExpectedDuration
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -1041,9 +1103,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	}
 
 	tg.EphemeralDisk = &structs.EphemeralDisk{
-		Sticky:  *taskGroup.EphemeralDisk.Sticky,
-		SizeMB:  *taskGroup.EphemeralDisk.SizeMB,
-		Migrate: *taskGroup.EphemeralDisk.Migrate,
+		Sticky:     *taskGroup.EphemeralDisk.Sticky,
+		SizeMB:     *taskGroup.EphemeralDisk.SizeMB,
+		Migrate:    *taskGroup.EphemeralDisk.Migrate,
+		Checkpoint: *taskGroup.EphemeralDisk.Checkpoint,
 	}
 
 	if len(taskGroup.Spreads) > 0 {
@@ -1102,6 +1165,17 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		if a := taskGroup.Update.Analysis; a != nil {
+			tg.Update.Analysis = &structs.DeploymentAnalysis{
+				Address:      *a.Address,
+				Query:        *a.Query,
+				Interval:     *a.Interval,
+				Operator:     *a.Operator,
+				Threshold:    *a.Threshold,
+				FailureLimit: *a.FailureLimit,
+			}
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1302,6 +1376,24 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 				Count:       *d.Count,
 				Constraints: ApiConstraintsToStructs(d.Constraints),
 				Affinities:  ApiAffinitiesToStructs(d.Affinities),
+				OnUnhealthy: d.OnUnhealthy,
+				Shares:      d.Shares,
+			})
+		}
+	}
+
+	if in.IO != nil {
+		out.IO = &structs.IOResources{}
+		if in.IO.Weight != nil {
+			out.IO.Weight = *in.IO.Weight
+		}
+		for _, d := range in.IO.Devices {
+			out.IO.Devices = append(out.IO.Devices, &structs.IODeviceLimit{
+				Path:      d.Path,
+				ReadBps:   d.ReadBps,
+				WriteBps:  d.WriteBps,
+				ReadIOPS:  d.ReadIOPS,
+				WriteIOPS: d.WriteIOPS,
 			})
 		}
 	}
@@ -2420,6 +2420,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			MetaRequired: []string{"a", "b"},
 			MetaOptional: []string{"c", "d"},
 		},
+		GC: &api.JobGCPolicy{
+			KeepSuccessful: helper.IntToPtr(5),
+			KeepFailed:     helper.IntToPtr(10),
+			MaxAge:         helper.TimeToPtr(24 * time.Hour),
+		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
 			"foo": "bar",
@@ -2490,9 +2495,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					},
 				},
 				EphemeralDisk: &api.EphemeralDisk{
-					SizeMB:  helper.IntToPtr(100),
-					Sticky:  helper.BoolToPtr(true),
-					Migrate: helper.BoolToPtr(true),
+					SizeMB:     helper.IntToPtr(100),
+					Sticky:     helper.BoolToPtr(true),
+					Migrate:    helper.BoolToPtr(true),
+					Checkpoint: helper.BoolToPtr(true),
 				},
 				Update: &api.UpdateStrategy{
 					HealthCheck:      helper.StringToPtr(structs.UpdateStrategyHealthCheck_Checks),
@@ -2697,6 +2703,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Count: nil,
 								},
 							},
+							IO: &api.IOResources{
+								Weight: helper.IntToPtr(500),
+								Devices: []*api.IODeviceLimit{
+									{Path: "/dev/sda", ReadBps: 1024, WriteIOPS: 10},
+								},
+							},
 						},
 						Meta: map[string]string{
 							"lol": "code",
@@ -2814,6 +2826,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			MetaRequired: []string{"a", "b"},
 			MetaOptional: []string{"c", "d"},
 		},
+		GC: &structs.JobGCPolicy{
+			KeepSuccessful: 5,
+			KeepFailed:     10,
+			MaxAge:         24 * time.Hour,
+		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
 			"foo": "bar",
@@ -2884,9 +2901,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					HealthyDeadline: 12 * time.Hour,
 				},
 				EphemeralDisk: &structs.EphemeralDisk{
-					SizeMB:  100,
-					Sticky:  true,
-					Migrate: true,
+					SizeMB:     100,
+					Sticky:     true,
+					Migrate:    true,
+					Checkpoint: true,
 				},
 				Update: &structs.UpdateStrategy{
 					Stagger:          1 * time.Second,
@@ -3102,6 +3120,12 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 									Count: 1,
 								},
 							},
+							IO: &structs.IOResources{
+								Weight: 500,
+								Devices: []*structs.IODeviceLimit{
+									{Path: "/dev/sda", ReadBps: 1024, WriteIOPS: 10},
+								},
+							},
 						},
 						Meta: map[string]string{
 							"lol": "code",
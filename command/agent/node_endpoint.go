@@ -66,6 +66,9 @@ func (s *HTTPServer) NodeSpecificRequest(resp http.ResponseWriter, req *http.Req
 	case strings.HasSuffix(path, "/eligibility"):
 		nodeName := strings.TrimSuffix(path, "/eligibility")
 		return s.nodeToggleEligibility(resp, req, nodeName)
+	case strings.HasSuffix(path, "/maintenance"):
+		nodeName := strings.TrimSuffix(path, "/maintenance")
+		return s.nodeUpdateMaintenance(resp, req, nodeName)
 	case strings.HasSuffix(path, "/purge"):
 		nodeName := strings.TrimSuffix(path, "/purge")
 		return s.nodePurge(resp, req, nodeName)
@@ -179,6 +182,30 @@ func (s *HTTPServer) nodeToggleEligibility(resp http.ResponseWriter, req *http.R
 	return out, nil
 }
 
+func (s *HTTPServer) nodeUpdateMaintenance(resp http.ResponseWriter, req *http.Request,
+	nodeID string) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var maintenanceRequest structs.NodeUpdateMaintenanceRequest
+	if err := decodeBody(req, &maintenanceRequest); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if maintenanceRequest.NodeID == "" {
+		maintenanceRequest.NodeID = nodeID
+	}
+
+	s.parseWriteRequest(req, &maintenanceRequest.WriteRequest)
+
+	var out structs.NodeMaintenanceUpdateResponse
+	if err := s.agent.RPC("Node.UpdateMaintenance", &maintenanceRequest, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) nodeQuery(resp http.ResponseWriter, req *http.Request,
 	nodeID string) (interface{}, error) {
 	if req.Method != "GET" {
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/nomad/helper/pluginutils/catalog"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 // setupPlugins is used to setup the plugin loaders.
@@ -24,6 +25,9 @@ func (a *Agent) setupPlugins() error {
 		InternalPlugins:   internal,
 		SupportedVersions: loader.AgentSupportedApiVersions,
 	}
+	if a.config.Client != nil {
+		config.Manifest = a.config.Client.PluginManifest
+	}
 	l, err := loader.NewPluginLoader(config)
 	if err != nil {
 		return fmt.Errorf("failed to create plugin loader: %v", err)
@@ -42,6 +46,30 @@ func (a *Agent) setupPlugins() error {
 	return nil
 }
 
+// reloadPlugins reloads the plugin configurations and sets the changed
+// configurations on the running driver and device plugins. Plugins whose
+// configuration can't be reloaded keep running with their previous
+// configuration.
+func (a *Agent) reloadPlugins(configs []*config.PluginConfig) error {
+	catalog, ok := a.pluginLoader.(loader.ReloadableCatalog)
+	if !ok {
+		return loader.ErrReloadUnsupported
+	}
+
+	var apply loader.ApplyConfigFunc
+	if a.client != nil {
+		apply = a.client.ReloadPluginConfig
+	}
+	if _, err := catalog.ReloadConfigs(configs, apply); err != nil {
+		return err
+	}
+
+	a.configLock.Lock()
+	a.config.Plugins = configs
+	a.configLock.Unlock()
+	return nil
+}
+
 func (a *Agent) internalPluginConfigs() (map[loader.PluginID]*loader.InternalPluginConfig, error) {
 	// Get the registered plugins
 	catalog := catalog.Catalog()
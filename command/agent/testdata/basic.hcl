@@ -96,9 +96,23 @@ client {
     path = "/tmp"
   }
 
-  cni_path              = "/tmp/cni_path"
-  bridge_network_name   = "custom_bridge_name"
-  bridge_network_subnet = "custom_bridge_subnet"
+  cni_path               = "/tmp/cni_path"
+  bridge_network_name    = "custom_bridge_name"
+  bridge_network_subnet  = "custom_bridge_subnet"
+  disk_quota             = "auto"
+  host_volumes_dir       = "/tmp/host_volumes"
+  host_volume_plugin_dir = "/tmp/host_volume_plugins"
+
+  plugin_manifest "nomad-driver-example" {
+    version = "0.1.0"
+    sha256  = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+  }
+
+  maintenance_window "weekly" {
+    cron      = "0 2 * * 0"
+    duration  = "2h"
+    time_zone = "Europe/Paris"
+  }
 }
 
 server {
@@ -221,6 +221,14 @@ func (c *AllocStatusCommand) Run(args []string) int {
 				c.Ui.Output("Omitting resource statistics since the node is down.")
 			}
 		}
+		if displayStats && stats != nil && stats.NetworkStats != nil {
+			c.Ui.Output("")
+			c.Ui.Output(formatAllocNetworkStats(stats.NetworkStats))
+		}
+		if displayStats && stats != nil && stats.DiskStats != nil {
+			c.Ui.Output("")
+			c.Ui.Output(formatAllocDiskStats(stats.DiskStats))
+		}
 		c.outputTaskDetails(alloc, stats, displayStats, verbose)
 	}
 
@@ -360,6 +368,44 @@ func formatAllocNetworkInfo(alloc *api.Allocation) string {
 	return fmt.Sprintf("Allocation Addresses%s\n%s", mode, formatList(addrs))
 }
 
+// formatAllocNetworkStats formats the usage of the allocation's shared network
+func formatAllocNetworkStats(stats *api.NetworkStats) string {
+	limit := "-"
+	if stats.LimitMBits > 0 {
+		limit = fmt.Sprintf("%d Mb/s", stats.LimitMBits)
+	}
+
+	throughput := func(mbits float64) string {
+		for _, measured := range stats.Measured {
+			if measured == "RxMBits" {
+				return fmt.Sprintf("%.2f Mb/s", mbits)
+			}
+		}
+		return "-"
+	}
+
+	out := []string{
+		"Direction|Bytes|Throughput|Limit",
+		fmt.Sprintf("Received|%s|%s|%s", humanize.IBytes(stats.RxBytes), throughput(stats.RxMBits), limit),
+		fmt.Sprintf("Transmitted|%s|%s|%s", humanize.IBytes(stats.TxBytes), throughput(stats.TxMBits), limit),
+	}
+	return fmt.Sprintf("Allocation Network Usage\n%s", formatList(out))
+}
+
+// formatAllocDiskStats formats the usage of the allocation's ephemeral disk
+func formatAllocDiskStats(stats *api.DiskStats) string {
+	percent := "-"
+	if stats.Size > 0 {
+		percent = fmt.Sprintf("%.1f%%", float64(stats.Used)/float64(stats.Size)*100)
+	}
+
+	out := []string{
+		"Used|Size|Percent",
+		fmt.Sprintf("%s|%s|%s", humanize.IBytes(stats.Used), humanize.IBytes(stats.Size), percent),
+	}
+	return fmt.Sprintf("Ephemeral Disk Usage\n%s", formatList(out))
+}
+
 // futureEvalTimePretty returns when the eval is eligible to reschedule
 // relative to current time, based on the WaitUntil field
 func futureEvalTimePretty(evalID string, client *api.Client) string {
@@ -509,6 +555,8 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		} else {
 			desc = "Task exceeded restart policy"
 		}
+	case api.TaskDiskExceeded:
+		desc = fmt.Sprintf("Ephemeral disk usage exceeded the %d MB limit", event.DiskLimit)
 	case api.TaskSiblingFailed:
 		if event.FailedSibling != "" {
 			desc = fmt.Sprintf("Task's sibling %q failed", event.FailedSibling)
@@ -540,6 +588,10 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		desc = "Leader Task in Group dead"
 	case api.TaskClientReconnected:
 		desc = "Client reconnected"
+	case api.TaskCheckpointed:
+		desc = "Task checkpointed for migration"
+	case api.TaskCheckpointRestored:
+		desc = "Task restored from checkpoint"
 	default:
 		desc = event.Message
 	}
@@ -637,6 +689,7 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsage *api.ResourceUsage) {
 	memoryStats := resourceUsage.MemoryStats
 	cpuStats := resourceUsage.CpuStats
+	ioStats := resourceUsage.IOStats
 	deviceStats := resourceUsage.DeviceStats
 
 	if memoryStats != nil && len(memoryStats.Measured) > 0 {
@@ -703,6 +756,33 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 		c.Ui.Output(formatList(out))
 	}
 
+	if ioStats != nil && len(ioStats.Measured) > 0 {
+		c.Ui.Output("")
+		c.Ui.Output("IO Stats")
+
+		// Sort the measured stats
+		sort.Strings(ioStats.Measured)
+
+		var measuredStats []string
+		for _, measured := range ioStats.Measured {
+			switch measured {
+			case "Read Bytes":
+				measuredStats = append(measuredStats, humanize.IBytes(ioStats.ReadBytes))
+			case "Write Bytes":
+				measuredStats = append(measuredStats, humanize.IBytes(ioStats.WriteBytes))
+			case "Read Ops":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", ioStats.ReadOps))
+			case "Write Ops":
+				measuredStats = append(measuredStats, fmt.Sprintf("%v", ioStats.WriteOps))
+			}
+		}
+
+		out := make([]string, 2)
+		out[0] = strings.Join(ioStats.Measured, "|")
+		out[1] = strings.Join(measuredStats, "|")
+		c.Ui.Output(formatList(out))
+	}
+
 	if len(deviceStats) > 0 {
 		c.Ui.Output("")
 		c.Ui.Output("Device Stats")
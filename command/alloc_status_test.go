@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -381,6 +382,63 @@ func TestAllocStatusCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(a.ID, res[0])
 }
 
+func TestAllocStatusCommand_formatAllocNetworkStats(t *testing.T) {
+	ci.Parallel(t)
+
+	// The throughput is only shown once measured
+	out := formatAllocNetworkStats(&api.NetworkStats{
+		RxBytes:  2048,
+		TxBytes:  1024,
+		Measured: []string{"RxBytes", "TxBytes"},
+	})
+	require.Regexp(t, `Received\s+2.0 KiB\s+-\s+-`, out)
+	require.Regexp(t, `Transmitted\s+1.0 KiB\s+-\s+-`, out)
+
+	out = formatAllocNetworkStats(&api.NetworkStats{
+		RxBytes:    2048,
+		TxBytes:    1024,
+		RxMBits:    12.5,
+		TxMBits:    0.25,
+		LimitMBits: 100,
+		Measured:   []string{"RxBytes", "TxBytes", "RxMBits", "TxMBits"},
+	})
+	require.Regexp(t, `Received\s+2.0 KiB\s+12.50 Mb/s\s+100 Mb/s`, out)
+	require.Regexp(t, `Transmitted\s+1.0 KiB\s+0.25 Mb/s\s+100 Mb/s`, out)
+}
+
+func TestAllocStatusCommand_formatAllocDiskStats(t *testing.T) {
+	ci.Parallel(t)
+
+	out := formatAllocDiskStats(&api.DiskStats{
+		Used:     75 * 1024 * 1024,
+		Size:     300 * 1024 * 1024,
+		Measured: []string{"Used", "Size"},
+	})
+	require.Contains(t, out, "Ephemeral Disk Usage")
+	require.Regexp(t, `75 MiB\s+300 MiB\s+25.0%`, out)
+}
+
+func TestAllocStatusCommand_outputVerboseResourceUsage_IO(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocStatusCommand{Meta: Meta{Ui: ui}}
+	cmd.outputVerboseResourceUsage("web", &api.ResourceUsage{
+		IOStats: &api.IOStats{
+			ReadBytes:  2048,
+			WriteBytes: 1024,
+			ReadOps:    3,
+			WriteOps:   4,
+			Measured:   []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"},
+		},
+	})
+
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "IO Stats")
+	require.Regexp(t, `Read Bytes\s+Read Ops\s+Write Bytes\s+Write Ops`, out)
+	require.Regexp(t, `2.0 KiB\s+3\s+1.0 KiB\s+4`, out)
+}
+
 func TestAllocStatusCommand_HostVolumes(t *testing.T) {
 	ci.Parallel(t)
 	// We have to create a tempdir for the host volume even though we're
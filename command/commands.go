@@ -330,6 +330,16 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job dispatch-queue": func() (cli.Command, error) {
+			return &JobDispatchQueueCommand{
+				Meta: meta,
+			}, nil
+		},
+		"job dispatch-queue status": func() (cli.Command, error) {
+			return &JobDispatchQueueStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job eval": func() (cli.Command, error) {
 			return &JobEvalCommand{
 				Meta: meta,
@@ -485,6 +495,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node plugins": func() (cli.Command, error) {
+			return &NodePluginsCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node status": func() (cli.Command, error) {
 			return &NodeStatusCommand{
 				Meta: meta,
@@ -48,6 +48,18 @@ Dispatch Options:
     once to inject multiple metadata key/value pairs. Arbitrary keys are not
     allowed. The parameterized job must allow the key to be merged.
 
+  -depends-on <job>[:<condition>]
+    Depends-on declares that the dispatched job must wait for the given
+    upstream job to complete before it is scheduled. The condition may be one
+    of "success" (the default), "failure" or "complete". The flag can be
+    provided more than once to wait on multiple upstream jobs, such as other
+    dispatched jobs.
+
+  -priority <priority>
+    Overrides the priority of the dispatched job. When the parameterized job
+    sets max_concurrent, dispatched jobs with a higher priority are released
+    from the dispatch queue first.
+
   -detach
     Return immediately instead of entering monitor mode. After job dispatch,
     the evaluation ID will be printed to the screen, which can be used to
@@ -71,6 +83,8 @@ func (c *JobDispatchCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-meta":              complete.PredictAnything,
+			"-depends-on":        complete.PredictAnything,
+			"-priority":          complete.PredictAnything,
 			"-detach":            complete.PredictNothing,
 			"-idempotency-token": complete.PredictAnything,
 			"-verbose":           complete.PredictNothing,
@@ -106,7 +120,8 @@ func (c *JobDispatchCommand) Name() string { return "job dispatch" }
 func (c *JobDispatchCommand) Run(args []string) int {
 	var detach, verbose bool
 	var idempotencyToken string
-	var meta []string
+	var priority int
+	var meta, dependsOn []string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -114,6 +129,8 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.StringVar(&idempotencyToken, "idempotency-token", "", "")
 	flags.Var((*flaghelper.StringFlag)(&meta), "meta", "")
+	flags.Var((*flaghelper.StringFlag)(&dependsOn), "depends-on", "")
+	flags.IntVar(&priority, "priority", 0, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -163,6 +180,13 @@ func (c *JobDispatchCommand) Run(args []string) int {
 		metaMap[split[0]] = split[1]
 	}
 
+	// Build the dependencies
+	deps, err := parseDispatchDependencies(dependsOn)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -174,7 +198,14 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	w := &api.WriteOptions{
 		IdempotencyToken: idempotencyToken,
 	}
-	resp, _, err := client.Jobs().Dispatch(job, metaMap, payload, w)
+	req := &api.JobDispatchRequest{
+		JobID:     job,
+		Meta:      metaMap,
+		Payload:   payload,
+		DependsOn: deps,
+		Priority:  priority,
+	}
+	resp, _, err := client.Jobs().DispatchOpts(req, w)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to dispatch job: %s", err))
 		return 1
@@ -201,3 +232,30 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	mon := newMonitor(c.Ui, client, length)
 	return mon.monitor(resp.EvalID)
 }
+
+// parseDispatchDependencies parses the -depends-on flag values of the form
+// <job>[:<condition>] into job dependencies.
+func parseDispatchDependencies(values []string) ([]*api.JobDependency, error) {
+	var deps []*api.JobDependency
+	for _, v := range values {
+		jobID, condition := v, api.JobDependencyConditionSuccess
+		if idx := strings.LastIndex(v, ":"); idx != -1 {
+			jobID, condition = v[:idx], v[idx+1:]
+		}
+
+		if jobID == "" {
+			return nil, fmt.Errorf("Error parsing depends-on value %q: missing job ID", v)
+		}
+		switch condition {
+		case api.JobDependencyConditionSuccess, api.JobDependencyConditionFailure, api.JobDependencyConditionComplete:
+		default:
+			return nil, fmt.Errorf("Error parsing depends-on value %q: invalid condition %q", v, condition)
+		}
+
+		deps = append(deps, &api.JobDependency{
+			JobID:     &jobID,
+			Condition: &condition,
+		})
+	}
+	return deps, nil
+}
@@ -0,0 +1,37 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type JobDispatchQueueCommand struct {
+	Meta
+}
+
+func (f *JobDispatchQueueCommand) Name() string { return "dispatch-queue" }
+
+func (f *JobDispatchQueueCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (f *JobDispatchQueueCommand) Synopsis() string {
+	return "Interact with the dispatch queue of parameterized jobs"
+}
+
+func (f *JobDispatchQueueCommand) Help() string {
+	helpText := `
+Usage: nomad job dispatch-queue <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with the dispatch queue of
+  parameterized jobs that set max_concurrent.
+
+  Show the dispatch queue of a parameterized job:
+
+      $ nomad job dispatch-queue status <job_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
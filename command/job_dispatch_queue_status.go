@@ -0,0 +1,195 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type JobDispatchQueueStatusCommand struct {
+	Meta
+}
+
+func (c *JobDispatchQueueStatusCommand) Help() string {
+	helpText := `
+Usage: nomad job dispatch-queue status [options] <job id>
+
+  Display the dispatch queue of a parameterized job that sets max_concurrent.
+  Dispatched jobs are listed in the order they are released from the queue.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  and 'list-jobs' capabilities for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Dispatch Queue Status Options:
+
+  -json
+    Output the dispatch queue in its JSON format.
+
+  -t
+    Format and display the dispatch queue using a Go template.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobDispatchQueueStatusCommand) Synopsis() string {
+	return "Display the dispatch queue of a parameterized job"
+}
+
+func (c *JobDispatchQueueStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *JobDispatchQueueStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Jobs().PrefixList(a.Last)
+		if err != nil {
+			return []string{}
+		}
+
+		// filter this by parameterized jobs
+		matches := make([]string, 0, len(resp))
+		for _, job := range resp {
+			if job.ParameterizedJob {
+				matches = append(matches, job.ID)
+			}
+		}
+		return matches
+	})
+}
+
+func (c *JobDispatchQueueStatusCommand) Name() string { return "job dispatch-queue status" }
+
+func (c *JobDispatchQueueStatusCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := args[0]
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying job: %s", err))
+		return 1
+	}
+	// filter non-parameterized jobs
+	parameterizedJobs := make([]*api.JobListStub, 0, len(jobs))
+	for _, j := range jobs {
+		if j.ParameterizedJob {
+			parameterizedJobs = append(parameterizedJobs, j)
+		}
+	}
+	if len(parameterizedJobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No parameterized job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(parameterizedJobs) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple parameterized jobs\n\n%s", createStatusListOutput(parameterizedJobs, c.allNamespaces())))
+		return 1
+	}
+	jobID = parameterizedJobs[0].ID
+	q := &api.QueryOptions{Namespace: parameterizedJobs[0].JobSummary.Namespace}
+
+	queue, _, err := client.Jobs().DispatchQueue(jobID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying dispatch queue: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, queue)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	maxConcurrent := "unlimited"
+	if queue.MaxConcurrent > 0 {
+		maxConcurrent = fmt.Sprintf("%d", queue.MaxConcurrent)
+	}
+	basic := []string{
+		fmt.Sprintf("ID|%s", queue.JobID),
+		fmt.Sprintf("Namespace|%s", queue.Namespace),
+		fmt.Sprintf("Max Concurrent|%s", maxConcurrent),
+		fmt.Sprintf("Running|%d", queue.Running),
+		fmt.Sprintf("Queued|%d", len(queue.Queued)),
+	}
+	c.Ui.Output(formatKV(basic))
+
+	if len(queue.Queued) == 0 {
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Queued Jobs[reset]"))
+	c.Ui.Output(formatDispatchQueue(queue.Queued, length))
+	return 0
+}
+
+// formatDispatchQueue formats the queued dispatched jobs in release order.
+func formatDispatchQueue(entries []*api.JobDispatchQueueEntry, length int) string {
+	out := make([]string, len(entries)+1)
+	out[0] = "Position|ID|Eval ID|Priority|Submit Date"
+	for i, e := range entries {
+		out[i+1] = fmt.Sprintf("%d|%s|%s|%d|%s",
+			i+1,
+			e.JobID,
+			limit(e.EvalID, length),
+			e.Priority,
+			formatTime(time.Unix(0, e.SubmitTime)),
+		)
+	}
+	return formatList(out)
+}
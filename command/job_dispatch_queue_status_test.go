@@ -0,0 +1,63 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobDispatchQueueStatusCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobDispatchQueueStatusCommand{}
+}
+
+func TestJobDispatchQueueStatusCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobDispatchQueueStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "foo"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error querying job")
+}
+
+func TestJobDispatchQueueStatusCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobDispatchQueueStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Not a parameterized job
+	state := srv.Agent.Server().State()
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	code := cmd.Run([]string{"-address=" + url, job.ID})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "No parameterized job(s)")
+	ui.ErrorWriter.Reset()
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 3}
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, parent))
+
+	code = cmd.Run([]string{"-address=" + url, parent.ID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, parent.ID)
+	require.Contains(t, out, "Max Concurrent = 3")
+	require.Contains(t, out, "Queued         = 0")
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -193,6 +194,13 @@ func (c *JobStatusCommand) Run(args []string) int {
 		basic = append(basic, fmt.Sprintf("Idempotency Token|%v", *job.DispatchIdempotencyToken))
 	}
 
+	if t := job.DispatchRetryTracker; t != nil && len(t.Events) != 0 {
+		last := t.Events[len(t.Events)-1]
+		basic = append(basic,
+			fmt.Sprintf("Dispatch Attempt|%d", len(t.Events)+1),
+			fmt.Sprintf("Retry Of|%s", last.PrevJobID))
+	}
+
 	if periodic && !parameterized {
 		if *job.Stop {
 			basic = append(basic, "Next Periodic Launch|none (job stopped)")
@@ -286,6 +294,21 @@ func (c *JobStatusCommand) outputParameterizedInfo(client *api.Client, job *api.
 	parameterizedJob[0] = fmt.Sprintf("Payload|%s", job.ParameterizedJob.Payload)
 	parameterizedJob[1] = fmt.Sprintf("Required Metadata|%v", strings.Join(job.ParameterizedJob.MetaRequired, ", "))
 	parameterizedJob[2] = fmt.Sprintf("Optional Metadata|%v", strings.Join(job.ParameterizedJob.MetaOptional, ", "))
+	if job.ParameterizedJob.MaxConcurrent > 0 {
+		parameterizedJob = append(parameterizedJob, fmt.Sprintf("Max Concurrent|%d", job.ParameterizedJob.MaxConcurrent))
+	}
+	if retry := job.ParameterizedJob.Retry; retry != nil {
+		parameterizedJob = append(parameterizedJob,
+			fmt.Sprintf("Retry Attempts|%d", *retry.Attempts),
+			fmt.Sprintf("Retry Delay|%s (%s, max %s)", *retry.Delay, *retry.DelayFunction, *retry.MaxDelay))
+		if len(retry.ExitCodes) != 0 {
+			codes := make([]string, len(retry.ExitCodes))
+			for i, c := range retry.ExitCodes {
+				codes[i] = strconv.Itoa(c)
+			}
+			parameterizedJob = append(parameterizedJob, fmt.Sprintf("Retry Exit Codes|%s", strings.Join(codes, ", ")))
+		}
+	}
 	c.Ui.Output(formatKV(parameterizedJob))
 
 	// Output the summary
@@ -354,6 +377,11 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 		return err
 	}
 
+	// Output the upstream jobs this job depends on
+	if err := c.outputJobDependencies(client, job, q); err != nil {
+		return err
+	}
+
 	// Determine latest evaluation with failures whose follow up hasn't
 	// completed, this is done while formatting
 	var latestFailedPlacement *api.Evaluation
@@ -408,6 +436,34 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 	return nil
 }
 
+// outputJobDependencies prints the upstream jobs the passed job depends on
+// along with their current status. If a request fails, an error is returned.
+func (c *JobStatusCommand) outputJobDependencies(client *api.Client, job *api.Job, q *api.QueryOptions) error {
+	if len(job.DependsOn) == 0 {
+		return nil
+	}
+
+	out := make([]string, len(job.DependsOn)+1)
+	out[0] = "Job ID|Condition|Status"
+	for i, d := range job.DependsOn {
+		status := "not registered"
+		upstream, _, err := client.Jobs().Info(*d.JobID, q)
+		if err != nil {
+			if !strings.Contains(err.Error(), "job not found") {
+				return fmt.Errorf("Error querying upstream job %q: %s", *d.JobID, err)
+			}
+		} else {
+			status = getStatusString(*upstream.Status, upstream.Stop)
+		}
+
+		out[i+1] = fmt.Sprintf("%s|%s|%s", *d.JobID, *d.Condition, status)
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Dependencies[reset]"))
+	c.Ui.Output(formatList(out))
+	return nil
+}
+
 func (c *JobStatusCommand) formatDeployment(client *api.Client, d *api.Deployment) string {
 	// Format the high-level elements
 	high := []string{
@@ -261,6 +261,12 @@ func (m *monitor) monitor(evalID string) int {
 						formatTime(time.Now()), limit(eval.BlockedEval, m.length)))
 				}
 			}
+		case structs.EvalStatusWaiting:
+			// The evaluation may wait on its job dependencies or in the
+			// dispatch queue indefinitely so there is nothing more to monitor
+			m.ui.Info(fmt.Sprintf("%s: Evaluation %q waiting on job dependencies or dispatch queue",
+				formatTime(time.Now()), limit(eval.ID, m.length)))
+			return 0
 		default:
 			// Wait for the next update
 			time.Sleep(updateWait)
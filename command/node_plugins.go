@@ -0,0 +1,208 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type NodePluginsCommand struct {
+	Meta
+}
+
+func (c *NodePluginsCommand) Help() string {
+	helpText := `
+Usage: nomad node plugins [options] <node>
+
+  List the task driver and device plugins loaded by a node along with their
+  versions, checksums and health. The -self flag is useful to list the plugins
+  of the local node.
+
+  If ACLs are enabled, this option requires a token with the 'node:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Plugins Options:
+
+  -self
+    List the plugins of the local node.
+
+  -verbose
+    Display full checksums and the path of external plugins.
+
+  -json
+    Output the plugins in their JSON format.
+
+  -t
+    Format and display the plugins using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodePluginsCommand) Synopsis() string {
+	return "List the plugins loaded by a node"
+}
+
+func (c *NodePluginsCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-self":    complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *NodePluginsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Nodes, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Nodes]
+	})
+}
+
+func (c *NodePluginsCommand) Name() string { return "node plugins" }
+
+func (c *NodePluginsCommand) Run(args []string) int {
+	var self, verbose, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&self, "self", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got a node ID
+	args = flags.Args()
+	if l := len(args); self && l != 0 || !self && l != 1 {
+		c.Ui.Error("Node ID must be specified if -self isn't being used")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// If -self flag is set then determine the current node.
+	var nodeID string
+	if !self {
+		nodeID = args[0]
+	} else {
+		var err error
+		if nodeID, err = getLocalNodeID(client); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	if len(nodeID) == 1 {
+		c.Ui.Error("Identifier must contain at least two characters.")
+		return 1
+	}
+
+	nodeID = sanitizeUUIDPrefix(nodeID)
+	nodes, _, err := client.Nodes().PrefixList(nodeID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying node plugins: %s", err))
+		return 1
+	}
+	// Return error if no nodes are found
+	if len(nodes) == 0 {
+		c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", nodeID))
+		return 1
+	}
+	if len(nodes) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple nodes\n\n%s",
+			formatNodeStubList(nodes, true)))
+		return 1
+	}
+
+	plugins, err := client.Nodes().Plugins(nodes[0].ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying node plugins: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, plugins)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(plugins) == 0 {
+		c.Ui.Output("No plugins loaded")
+		return 0
+	}
+
+	c.Ui.Output(formatNodePlugins(plugins, verbose))
+	return 0
+}
+
+// formatNodePlugins returns a table of the plugins loaded by a node
+func formatNodePlugins(plugins []*api.NodePlugin, verbose bool) string {
+	header := "Name|Type|Version|Source|SHA256|Healthy"
+	if verbose {
+		header += "|Path"
+	}
+
+	rows := make([]string, 0, len(plugins)+1)
+	rows = append(rows, header)
+	for _, p := range plugins {
+		source := "external"
+		if p.Internal {
+			source = "internal"
+		}
+
+		checksum := "<none>"
+		if p.SHA256 != "" {
+			checksum = p.SHA256
+			if !verbose {
+				checksum = limit(checksum, shortId)
+			}
+		}
+
+		healthy := "n/a"
+		if p.Healthy != nil {
+			healthy = fmt.Sprintf("%v", *p.Healthy)
+		}
+
+		row := fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			p.Name, p.Type, p.Version, source, checksum, healthy)
+		if verbose {
+			path := p.Path
+			if path == "" {
+				path = "<none>"
+			}
+			row += "|" + path
+		}
+		rows = append(rows, row)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePluginsCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodePluginsCommand{}
+}
+
+func TestNodePluginsCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NodePluginsCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "12345678-abcd-efab-cdef-123456789abc"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error querying node plugins")
+	ui.ErrorWriter.Reset()
+
+	// Fails on non-existent node
+	code = cmd.Run([]string{"-address=" + url, "12345678-abcd-efab-cdef-123456789abc"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "No node(s) with prefix or id")
+	ui.ErrorWriter.Reset()
+}
+
+func TestNodePluginsCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to be ready
+	var nodeID string
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		for _, node := range nodes {
+			if node.Status == structs.NodeStatusReady {
+				nodeID = node.ID
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("no ready nodes")
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	ui := cli.NewMockUi()
+	cmd := &NodePluginsCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, "-json", nodeID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.NotEmpty(t, ui.OutputWriter.String())
+}
+
+func TestNodePluginsCommand_Format(t *testing.T) {
+	ci.Parallel(t)
+
+	plugins := []*api.NodePlugin{
+		{
+			Name:     "mock_driver",
+			Type:     "driver",
+			Version:  "0.1.0",
+			Internal: true,
+			Healthy:  helper.BoolToPtr(true),
+		},
+		{
+			Name:    "example",
+			Type:    "device",
+			Version: "0.2.0",
+			Path:    "/opt/nomad/plugins/example",
+			SHA256:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		},
+	}
+
+	out := formatNodePlugins(plugins, false)
+	lines := strings.Split(out, "\n")
+	require.Len(t, lines, 3)
+	require.Regexp(t, `mock_driver\s+driver\s+0.1.0\s+internal\s+<none>\s+true`, lines[1])
+	require.Regexp(t, `example\s+device\s+0.2.0\s+external\s+9f86d081\s+n/a`, lines[2])
+
+	out = formatNodePlugins(plugins, true)
+	require.Contains(t, out, "/opt/nomad/plugins/example")
+	require.Contains(t, out, plugins[1].SHA256)
+}
@@ -18,12 +18,15 @@ func (c *VolumeCreateCommand) Help() string {
 Usage: nomad volume create [options] <input>
 
   Creates a volume in an external storage provider and registers it in Nomad.
+  Volumes of type "host" are instead created by a Nomad client, which adds
+  them to its host volumes.
 
   If the supplied path is "-" the volume file is read from stdin. Otherwise, it
   is read from the file at the supplied path.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' capability for the volume's namespace.
+  'csi-write-volume' capability for the volume's namespace, or with the
+  'node:write' policy for host volumes.
 
 General Options:
 
@@ -98,6 +101,9 @@ func (c *VolumeCreateCommand) Run(args []string) int {
 	case "csi":
 		code := c.csiCreate(client, ast)
 		return code
+	case "host":
+		code := c.hostCreate(client, ast)
+		return code
 	default:
 		c.Ui.Error(fmt.Sprintf("Error unknown volume type: %s", volType))
 		return 1
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/mitchellh/mapstructure"
+)
+
+func (c *VolumeCreateCommand) hostCreate(client *api.Client, ast *ast.File) int {
+	vol, err := hostDecodeVolume(ast)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error decoding the volume definition: %s", err))
+		return 1
+	}
+
+	vol, _, err = client.HostVolumes().Create(vol, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating volume: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf(
+		"Created host volume %s with ID %s on node %s", vol.Name, vol.ID, limit(vol.NodeID, shortId)))
+	return 0
+}
+
+func hostDecodeVolume(input *ast.File) (*api.HostVolume, error) {
+	vol := &api.HostVolume{}
+
+	list, ok := input.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing: root should be an object")
+	}
+
+	valid := []string{
+		"id", "name", "namespace", "type", "plugin_id", "node_id",
+		"constraint", "capacity_min", "capacity_max", "parameters",
+	}
+	if err := helper.CheckHCLKeys(list, valid); err != nil {
+		return nil, err
+	}
+
+	// Decode the full thing into a map[string]interface for ease
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, list); err != nil {
+		return nil, err
+	}
+
+	// Need to manually parse these fields
+	delete(m, "constraint")
+	delete(m, "capacity_max")
+	delete(m, "capacity_min")
+	delete(m, "type")
+
+	// Decode the rest
+	if err := mapstructure.WeakDecode(m, vol); err != nil {
+		return nil, err
+	}
+
+	capacityMin, err := parseCapacityBytes(list.Filter("capacity_min"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity_min: %v", err)
+	}
+	vol.RequestedCapacityMinBytes = capacityMin
+	capacityMax, err := parseCapacityBytes(list.Filter("capacity_max"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity_max: %v", err)
+	}
+	vol.RequestedCapacityMaxBytes = capacityMax
+
+	for _, o := range list.Filter("constraint").Elem().Items {
+		if err := helper.CheckHCLKeys(o.Val, []string{"attribute", "operator", "value"}); err != nil {
+			return nil, err
+		}
+
+		ot, ok := o.Val.(*ast.ObjectType)
+		if !ok {
+			return nil, fmt.Errorf("constraint should be a block")
+		}
+
+		var m map[string]string
+		if err := hcl.DecodeObject(&m, ot.List); err != nil {
+			return nil, err
+		}
+
+		operator := m["operator"]
+		if operator == "" {
+			operator = "="
+		}
+		vol.Constraints = append(vol.Constraints,
+			api.NewConstraint(m["attribute"], operator, m["value"]))
+	}
+
+	return vol, nil
+}
@@ -203,3 +203,55 @@ topology_request {
 
 	}
 }
+
+func TestHostVolumeDecode(t *testing.T) {
+	ci.Parallel(t)
+
+	ast, err := hcl.ParseString(`
+name         = "data"
+type         = "host"
+plugin_id    = "lvm"
+capacity_min = "10GiB"
+capacity_max = "20G"
+
+constraint {
+  attribute = "${meta.rack}"
+  value     = "r1"
+}
+
+constraint {
+  attribute = "${attr.kernel.name}"
+  operator  = "!="
+  value     = "windows"
+}
+
+parameters {
+  vg = "nomad"
+}
+`)
+	require.NoError(t, err)
+
+	vol, err := hostDecodeVolume(ast)
+	require.NoError(t, err)
+	require.Equal(t, &api.HostVolume{
+		Name:                      "data",
+		PluginID:                  "lvm",
+		RequestedCapacityMinBytes: 10737418240,
+		RequestedCapacityMaxBytes: 20000000000,
+		Constraints: []*api.Constraint{
+			{LTarget: "${meta.rack}", Operand: "=", RTarget: "r1"},
+			{LTarget: "${attr.kernel.name}", Operand: "!=", RTarget: "windows"},
+		},
+		Parameters: map[string]string{"vg": "nomad"},
+	}, vol)
+
+	ast, err = hcl.ParseString(`
+name  = "data"
+type  = "host"
+rando = "bar"
+`)
+	require.NoError(t, err)
+	_, err = hostDecodeVolume(ast)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid key: rando")
+}
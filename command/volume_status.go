@@ -21,12 +21,13 @@ func (c *VolumeStatusCommand) Help() string {
 	helpText := `
 Usage: nomad volume status [options] <id>
 
-  Display status information about a CSI volume. If no volume id is given, a
-  list of all volumes will be displayed.
+  Display status information about a CSI volume, or about a dynamic host
+  volume with -type host. If no volume id is given, a list of all volumes will
+  be displayed.
 
   When ACLs are enabled, this command requires a token with the
   'csi-read-volume' and 'csi-list-volumes' capability for the volume's
-  namespace.
+  namespace, or with the 'node:read' policy for host volumes.
 
 General Options:
 
@@ -35,7 +36,7 @@ General Options:
 Status Options:
 
   -type <type>
-    List only volumes of type <type>.
+    List only volumes of type <type>, either "csi" or "host".
 
   -short
     Display short output. Used only when a single volume is being
@@ -60,7 +61,7 @@ func (c *VolumeStatusCommand) Synopsis() string {
 func (c *VolumeStatusCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-type":    predictVolumeType,
+			"-type":    complete.PredictSet("csi", "host"),
 			"-short":   complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 			"-json":    complete.PredictNothing,
@@ -127,12 +128,25 @@ func (c *VolumeStatusCommand) Run(args []string) int {
 		id = args[0]
 	}
 
+	switch typeArg {
+	case "host":
+		return c.hostStatus(client, id)
+	case "", "csi":
+	default:
+		c.Ui.Error(fmt.Sprintf("Error unknown volume type: %s", typeArg))
+		return 1
+	}
+
 	code := c.csiStatus(client, id)
 	if code != 0 {
 		return code
 	}
 
-	// Extend this section with other volume implementations
+	// List the dynamic host volumes along with the CSI volumes, unless the
+	// output is formatted as a single document
+	if typeArg == "" && id == "" && !(c.json || len(c.template) > 0) {
+		return c.listHostVolumes(client, false)
+	}
 
 	return 0
 }
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -240,6 +241,12 @@ func (c *VolumeStatusCommand) formatBasic(vol *api.CSIVolume) (string, error) {
 		full = append(full, topo)
 	}
 
+	if len(vol.NodeStats) > 0 {
+		statsBanner := c.Colorize().Color("\n[bold]Node Stats[reset]")
+		full = append(full, statsBanner)
+		full = append(full, c.formatNodeStats(vol))
+	}
+
 	// Format the allocs
 	banner := c.Colorize().Color("\n[bold]Allocations[reset]")
 	allocs := formatAllocListStubs(vol.Allocations, c.verbose, c.length)
@@ -272,6 +279,35 @@ func (c *VolumeStatusCommand) formatTopology(vol *api.CSIVolume) string {
 	return formatList(rows)
 }
 
+func (c *VolumeStatusCommand) formatNodeStats(vol *api.CSIVolume) string {
+	nodeIDs := make([]string, 0, len(vol.NodeStats))
+	for nodeID := range vol.NodeStats {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	rows := []string{"Node ID|Capacity|Used|Available|Inodes Used|Condition"}
+	for _, nodeID := range nodeIDs {
+		stats := vol.NodeStats[nodeID]
+		if stats == nil {
+			continue
+		}
+		condition := "healthy"
+		if stats.Abnormal {
+			condition = fmt.Sprintf("abnormal: %s", stats.Message)
+		}
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%d/%d|%s",
+			limit(nodeID, c.length),
+			humanize.IBytes(uint64(stats.TotalBytes)),
+			humanize.IBytes(uint64(stats.UsedBytes)),
+			humanize.IBytes(uint64(stats.AvailableBytes)),
+			stats.UsedInodes, stats.TotalInodes,
+			condition,
+		))
+	}
+	return formatList(rows)
+}
+
 func csiVolMountOption(volume, request *api.CSIMountOptions) string {
 	var req, opts *structs.CSIMountOptions
 
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/api"
+)
+
+func (c *VolumeStatusCommand) hostBanner() {
+	if !(c.json || len(c.template) > 0) {
+		c.Ui.Output(c.Colorize().Color("[bold]Dynamic Host Volumes[reset]"))
+	}
+}
+
+func (c *VolumeStatusCommand) hostStatus(client *api.Client, id string) int {
+	// Invoke list mode if no volume id
+	if id == "" {
+		return c.listHostVolumes(client, true)
+	}
+
+	// Prefix search for the volume
+	vols, _, err := client.HostVolumes().List(&api.QueryOptions{Prefix: id})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying volumes: %s", err))
+		return 1
+	}
+	if len(vols) == 0 {
+		c.Ui.Error(fmt.Sprintf("No volumes(s) with prefix or ID %q found", id))
+		return 1
+	}
+
+	var vol *api.HostVolume
+	if len(vols) == 1 {
+		vol = vols[0]
+	} else {
+		for _, v := range vols {
+			if v.ID == id {
+				vol = v
+				break
+			}
+		}
+		if vol == nil {
+			out, err := c.hostFormatVolumes(vols)
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+				return 1
+			}
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple volumes\n\n%s", out))
+			return 1
+		}
+	}
+
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, vol)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting volume: %s", err))
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatHostVolume(vol))
+	return 0
+}
+
+// listHostVolumes lists the dynamic host volumes. If always is false, they're
+// listed after the CSI volumes and nothing is output when there are none.
+func (c *VolumeStatusCommand) listHostVolumes(client *api.Client, always bool) int {
+	vols, _, err := client.HostVolumes().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying host volumes: %s", err))
+		return 1
+	}
+
+	if len(vols) == 0 {
+		if always {
+			c.Ui.Error("No dynamic host volumes")
+		}
+		return 0
+	}
+
+	if !always {
+		c.Ui.Output("")
+	}
+	c.hostBanner()
+	str, err := c.hostFormatVolumes(vols)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+		return 1
+	}
+	c.Ui.Output(str)
+	return 0
+}
+
+func (c *VolumeStatusCommand) hostFormatVolumes(vols []*api.HostVolume) (string, error) {
+	// Sort the output by volume id
+	sort.Slice(vols, func(i, j int) bool { return vols[i].ID < vols[j].ID })
+
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, vols)
+		if err != nil {
+			return "", fmt.Errorf("format error: %v", err)
+		}
+		return out, nil
+	}
+
+	rows := make([]string, len(vols)+1)
+	rows[0] = "ID|Name|Namespace|Plugin ID|Node ID|Capacity"
+	for i, v := range vols {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			limit(v.ID, c.length),
+			v.Name,
+			v.Namespace,
+			v.PluginID,
+			limit(v.NodeID, c.length),
+			formatHostVolumeCapacity(v),
+		)
+	}
+	return formatList(rows), nil
+}
+
+func formatHostVolume(vol *api.HostVolume) string {
+	output := []string{
+		fmt.Sprintf("ID|%s", vol.ID),
+		fmt.Sprintf("Name|%s", vol.Name),
+		fmt.Sprintf("Namespace|%s", vol.Namespace),
+		fmt.Sprintf("Plugin ID|%s", vol.PluginID),
+		fmt.Sprintf("Node ID|%s", vol.NodeID),
+		fmt.Sprintf("Host Path|%s", vol.HostPath),
+		fmt.Sprintf("Capacity|%s", formatHostVolumeCapacity(vol)),
+	}
+	return formatKV(output)
+}
+
+// formatHostVolumeCapacity returns the capacity reported by the volume's
+// plugin, if any
+func formatHostVolumeCapacity(vol *api.HostVolume) string {
+	if vol.CapacityBytes == 0 {
+		return "<none>"
+	}
+	return humanize.IBytes(uint64(vol.CapacityBytes))
+}
@@ -1,8 +1,10 @@
 package command
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -57,3 +59,38 @@ func TestCSIVolumeStatusCommand_AutocompleteArgs(t *testing.T) {
 	require.Equal(t, 1, len(res))
 	require.Equal(t, vol.ID, res[0])
 }
+
+func TestCSIVolumeStatusCommand_FormatNodeStats(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VolumeStatusCommand{Meta: Meta{Ui: ui}, length: fullId}
+
+	vol := &api.CSIVolume{
+		NodeStats: map[string]*api.CSIVolumeStats{
+			"node-b": {
+				TotalBytes:     2048,
+				UsedBytes:      1024,
+				AvailableBytes: 1024,
+				TotalInodes:    100,
+				UsedInodes:     10,
+				Abnormal:       true,
+				Message:        "disk failure",
+			},
+			"node-a": {
+				TotalBytes:     1024,
+				AvailableBytes: 1024,
+			},
+		},
+	}
+
+	out := cmd.formatNodeStats(vol)
+	lines := strings.Split(out, "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[0], "Condition")
+	require.True(t, strings.HasPrefix(lines[1], "node-a"))
+	require.Contains(t, lines[1], "healthy")
+	require.True(t, strings.HasPrefix(lines[2], "node-b"))
+	require.Contains(t, lines[2], "2.0 KiB")
+	require.Contains(t, lines[2], "10/100")
+	require.Contains(t, lines[2], "abnormal: disk failure")
+}
@@ -132,6 +132,7 @@ var (
 		PluginApiVersions: []string{drivers.ApiVersion010},
 		PluginVersion:     "0.1.0",
 		Name:              pluginName,
+		ReloadConfig:      true,
 	}
 
 	danglingContainersBlock = hclspec.NewObject(map[string]*hclspec.Spec{
@@ -153,6 +154,26 @@ var (
 		),
 	})
 
+	diskPressureBlock = hclspec.NewObject(map[string]*hclspec.Spec{
+		"enabled": hclspec.NewDefault(
+			hclspec.NewAttr("enabled", "bool", false),
+			hclspec.NewLiteral(`true`),
+		),
+		"period": hclspec.NewDefault(
+			hclspec.NewAttr("period", "string", false),
+			hclspec.NewLiteral(`"1m"`),
+		),
+		"min_free_percent": hclspec.NewDefault(
+			hclspec.NewAttr("min_free_percent", "number", false),
+			hclspec.NewLiteral(`10`),
+		),
+		"target_free_percent": hclspec.NewDefault(
+			hclspec.NewAttr("target_free_percent", "number", false),
+			hclspec.NewLiteral(`20`),
+		),
+		"pinned_images": hclspec.NewAttr("pinned_images", "list(string)", false),
+	})
+
 	// configSpec is the hcl specification returned by the ConfigSchema RPC
 	// and is used to parse the contents of the 'plugin "docker" {...}' block.
 	// Example:
@@ -172,6 +193,11 @@ var (
 	//			image = true
 	//			image_delay = "5m"
 	//			container = false
+	//			disk_pressure {
+	//				min_free_percent = 10
+	//				target_free_percent = 20
+	//				pinned_images = ["redis:*"]
+	//			}
 	//		}
 	//		volumes {
 	//			enabled = true
@@ -237,6 +263,7 @@ var (
 					creation_grace = "5m"
 				}`),
 			),
+			"disk_pressure": hclspec.NewBlock("disk_pressure", false, diskPressureBlock),
 		})), hclspec.NewLiteral(`{
 			image = true
 			image_delay = "3m"
@@ -354,14 +381,18 @@ var (
 		"entrypoint":         hclspec.NewAttr("entrypoint", "list(string)", false),
 		"extra_hosts":        hclspec.NewAttr("extra_hosts", "list(string)", false),
 		"force_pull":         hclspec.NewAttr("force_pull", "bool", false),
-		"hostname":           hclspec.NewAttr("hostname", "string", false),
-		"init":               hclspec.NewAttr("init", "bool", false),
-		"interactive":        hclspec.NewAttr("interactive", "bool", false),
-		"ipc_mode":           hclspec.NewAttr("ipc_mode", "string", false),
-		"ipv4_address":       hclspec.NewAttr("ipv4_address", "string", false),
-		"ipv6_address":       hclspec.NewAttr("ipv6_address", "string", false),
-		"labels":             hclspec.NewAttr("labels", "list(map(string))", false),
-		"load":               hclspec.NewAttr("load", "string", false),
+		"healthchecks": hclspec.NewBlock("healthchecks", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"disable":              hclspec.NewAttr("disable", "bool", false),
+			"restart_on_unhealthy": hclspec.NewAttr("restart_on_unhealthy", "bool", false),
+		})),
+		"hostname":     hclspec.NewAttr("hostname", "string", false),
+		"init":         hclspec.NewAttr("init", "bool", false),
+		"interactive":  hclspec.NewAttr("interactive", "bool", false),
+		"ipc_mode":     hclspec.NewAttr("ipc_mode", "string", false),
+		"ipv4_address": hclspec.NewAttr("ipv4_address", "string", false),
+		"ipv6_address": hclspec.NewAttr("ipv6_address", "string", false),
+		"labels":       hclspec.NewAttr("labels", "list(map(string))", false),
+		"load":         hclspec.NewAttr("load", "string", false),
 		"logging": hclspec.NewBlock("logging", false, hclspec.NewObject(map[string]*hclspec.Spec{
 			"type":   hclspec.NewAttr("type", "string", false),
 			"driver": hclspec.NewAttr("driver", "string", false),
@@ -413,6 +444,7 @@ var (
 		},
 		MustInitiateNetwork: true,
 		MountConfigs:        drivers.MountConfigSupportAll,
+		ImagePrefetch:       true,
 	}
 )
 
@@ -435,6 +467,7 @@ type TaskConfig struct {
 	Entrypoint        []string           `codec:"entrypoint"`
 	ExtraHosts        []string           `codec:"extra_hosts"`
 	ForcePull         bool               `codec:"force_pull"`
+	Healthchecks      DockerHealthchecks `codec:"healthchecks"`
 	Hostname          string             `codec:"hostname"`
 	Init              bool               `codec:"init"`
 	Interactive       bool               `codec:"interactive"`
@@ -508,6 +541,17 @@ func (d DockerDevice) toDockerDevice() (docker.Device, error) {
 	return dd, nil
 }
 
+// DockerHealthchecks controls the health check defined by the image's
+// HEALTHCHECK instruction.
+type DockerHealthchecks struct {
+	// Disable disables the image's health check
+	Disable bool `codec:"disable"`
+
+	// RestartOnUnhealthy stops the container when it becomes unhealthy so
+	// the task's restart policy applies
+	RestartOnUnhealthy bool `codec:"restart_on_unhealthy"`
+}
+
 type DockerLogging struct {
 	Type   string             `codec:"type"`
 	Driver string             `codec:"driver"`
@@ -651,6 +695,31 @@ type GCConfig struct {
 	Container          bool          `codec:"container"`
 
 	DanglingContainers ContainerGCConfig `codec:"dangling_containers"`
+
+	DiskPressure DiskPressureGCConfig `codec:"disk_pressure"`
+}
+
+// DiskPressureGCConfig controls removing the least recently used images
+// when the disk holding the Docker data root runs low on free space.
+type DiskPressureGCConfig struct {
+	// Enabled controls whether disk pressure image GC is enabled
+	Enabled bool `codec:"enabled"`
+
+	// PeriodStr controls how often the free space is checked
+	PeriodStr string        `codec:"period"`
+	period    time.Duration `codec:"-"`
+
+	// MinFreePercent is the percentage of free space below which images
+	// are removed
+	MinFreePercent int `codec:"min_free_percent"`
+
+	// TargetFreePercent is the percentage of free space images are removed
+	// until reaching
+	TargetFreePercent int `codec:"target_free_percent"`
+
+	// PinnedImages are globs matched against image names, tags, digests and
+	// IDs of images that are never removed
+	PinnedImages []string `codec:"pinned_images"`
 }
 
 type VolumeConfig struct {
@@ -682,63 +751,90 @@ func (d *Driver) SetConfig(c *base.Config) error {
 		}
 	}
 
-	d.config = &config
-	d.config.InfraImage = strings.TrimPrefix(d.config.InfraImage, "https://")
+	config.InfraImage = strings.TrimPrefix(config.InfraImage, "https://")
 
-	if len(d.config.GC.ImageDelay) > 0 {
-		dur, err := time.ParseDuration(d.config.GC.ImageDelay)
+	if len(config.GC.ImageDelay) > 0 {
+		dur, err := time.ParseDuration(config.GC.ImageDelay)
 		if err != nil {
 			return fmt.Errorf("failed to parse 'image_delay' duration: %v", err)
 		}
-		d.config.GC.imageDelayDuration = dur
+		config.GC.imageDelayDuration = dur
 	}
 
-	if len(d.config.GC.DanglingContainers.PeriodStr) > 0 {
-		dur, err := time.ParseDuration(d.config.GC.DanglingContainers.PeriodStr)
+	if len(config.GC.DanglingContainers.PeriodStr) > 0 {
+		dur, err := time.ParseDuration(config.GC.DanglingContainers.PeriodStr)
 		if err != nil {
 			return fmt.Errorf("failed to parse 'period' duration: %v", err)
 		}
-		d.config.GC.DanglingContainers.period = dur
+		config.GC.DanglingContainers.period = dur
 	}
 
-	if len(d.config.GC.DanglingContainers.CreationGraceStr) > 0 {
-		dur, err := time.ParseDuration(d.config.GC.DanglingContainers.CreationGraceStr)
+	if len(config.GC.DanglingContainers.CreationGraceStr) > 0 {
+		dur, err := time.ParseDuration(config.GC.DanglingContainers.CreationGraceStr)
 		if err != nil {
 			return fmt.Errorf("failed to parse 'creation_grace' duration: %v", err)
 		}
 		if dur < danglingContainersCreationGraceMinimum {
 			return fmt.Errorf("creation_grace is less than minimum, %v", danglingContainersCreationGraceMinimum)
 		}
-		d.config.GC.DanglingContainers.CreationGrace = dur
+		config.GC.DanglingContainers.CreationGrace = dur
 	}
 
-	if len(d.config.PullActivityTimeout) > 0 {
-		dur, err := time.ParseDuration(d.config.PullActivityTimeout)
+	if gc := &config.GC.DiskPressure; gc.Enabled {
+		dur, err := time.ParseDuration(gc.PeriodStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse disk_pressure 'period' duration: %v", err)
+		}
+		if dur <= 0 {
+			return fmt.Errorf("disk_pressure period must be positive")
+		}
+		gc.period = dur
+
+		if gc.MinFreePercent <= 0 || gc.MinFreePercent > gc.TargetFreePercent || gc.TargetFreePercent > 100 {
+			return fmt.Errorf("disk_pressure requires 0 < min_free_percent <= target_free_percent <= 100")
+		}
+	}
+
+	if len(config.PullActivityTimeout) > 0 {
+		dur, err := time.ParseDuration(config.PullActivityTimeout)
 		if err != nil {
 			return fmt.Errorf("failed to parse 'pull_activity_timeout' duaration: %v", err)
 		}
 		if dur < pullActivityTimeoutMinimum {
 			return fmt.Errorf("pull_activity_timeout is less than minimum, %v", pullActivityTimeoutMinimum)
 		}
-		d.config.pullActivityTimeoutDuration = dur
+		config.pullActivityTimeoutDuration = dur
 	}
 
-	if d.config.InfraImagePullTimeout != "" {
-		dur, err := time.ParseDuration(d.config.InfraImagePullTimeout)
+	if config.InfraImagePullTimeout != "" {
+		dur, err := time.ParseDuration(config.InfraImagePullTimeout)
 		if err != nil {
 			return fmt.Errorf("failed to parse 'infra_image_pull_timeout' duaration: %v", err)
 		}
-		d.config.infraImagePullTimeoutDuration = dur
+		config.infraImagePullTimeoutDuration = dur
 	}
 
-	d.config.allowRuntimes = make(map[string]struct{}, len(d.config.AllowRuntimesList))
-	for _, r := range d.config.AllowRuntimesList {
-		d.config.allowRuntimes[r] = struct{}{}
+	config.allowRuntimes = make(map[string]struct{}, len(config.AllowRuntimesList))
+	for _, r := range config.AllowRuntimesList {
+		config.allowRuntimes[r] = struct{}{}
 	}
 
+	// The configuration is only swapped once it's fully validated, so that a
+	// driver failing to be reconfigured keeps its previous configuration
+	d.configLock.Lock()
+	reload := d.coordinator != nil
+	d.config = &config
 	if c.AgentConfig != nil {
 		d.clientConfig = c.AgentConfig.Driver
 	}
+	d.configLock.Unlock()
+
+	// When reconfigured, the image coordinator keeps tracking the images of
+	// the running tasks and the reconcilers and image GC keep running with the
+	// configuration the driver was started with
+	if reload {
+		return nil
+	}
 
 	dockerClient, _, err := d.dockerClients()
 	if err != nil {
@@ -747,15 +843,19 @@ func (d *Driver) SetConfig(c *base.Config) error {
 	coordinatorConfig := &dockerCoordinatorConfig{
 		ctx:         d.ctx,
 		client:      dockerClient,
-		cleanup:     d.config.GC.Image,
+		cleanup:     config.GC.Image,
 		logger:      d.logger,
-		removeDelay: d.config.GC.imageDelayDuration,
+		removeDelay: config.GC.imageDelayDuration,
 	}
 
 	d.coordinator = newDockerCoordinator(coordinatorConfig)
 
 	d.danglingReconciler = newReconciler(d)
 
+	d.imageGC = newImageGC(d)
+
+	d.prefetcher = newImagePrefetcher(d)
+
 	d.cpusetFixer = newCpusetFixer(d)
 
 	return nil
@@ -775,6 +875,6 @@ var _ drivers.InternalCapabilitiesDriver = (*Driver)(nil)
 
 func (d *Driver) InternalCapabilities() drivers.InternalCapabilities {
 	return drivers.InternalCapabilities{
-		DisableLogCollection: d.config != nil && d.config.DisableLogCollection,
+		DisableLogCollection: d.getConfig().DisableLogCollection,
 	}
 }
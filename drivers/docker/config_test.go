@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/stretchr/testify/require"
 )
@@ -223,6 +224,9 @@ config {
   entrypoint = ["/bin/bash", "-c"]
   extra_hosts = ["127.0.0.1  localhost.example.com"]
   force_pull = true
+  healthchecks {
+    restart_on_unhealthy = true
+  }
   hostname = "self.example.com"
   interactive = true
   ipc_mode = "host"
@@ -376,6 +380,7 @@ config {
 		Entrypoint:       []string{"/bin/bash", "-c"},
 		ExtraHosts:       []string{"127.0.0.1  localhost.example.com"},
 		ForcePull:        true,
+		Healthchecks:     DockerHealthchecks{RestartOnUnhealthy: true},
 		Hostname:         "self.example.com",
 		Interactive:      true,
 		IPCMode:          "host",
@@ -596,6 +601,38 @@ func TestConfig_DriverConfig_GC(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "default disk_pressure",
+			config: `{ gc { disk_pressure { } } }`,
+			expected: GCConfig{
+				Image: true, ImageDelay: "3m", Container: true,
+				DanglingContainers: ContainerGCConfig{
+					Enabled: true, PeriodStr: "5m", CreationGraceStr: "5m"},
+				DiskPressure: DiskPressureGCConfig{
+					Enabled: true, PeriodStr: "1m", MinFreePercent: 10, TargetFreePercent: 20},
+			},
+		},
+		{
+			name: "full disk_pressure",
+			config: `{ gc { disk_pressure {
+			     period = "30s"
+			     min_free_percent = 5
+			     target_free_percent = 15
+			     pinned_images = ["redis:*"]
+			}}}`,
+			expected: GCConfig{
+				Image: true, ImageDelay: "3m", Container: true,
+				DanglingContainers: ContainerGCConfig{
+					Enabled: true, PeriodStr: "5m", CreationGraceStr: "5m"},
+				DiskPressure: DiskPressureGCConfig{
+					Enabled:           true,
+					PeriodStr:         "30s",
+					MinFreePercent:    5,
+					TargetFreePercent: 15,
+					PinnedImages:      []string{"redis:*"},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -733,3 +770,38 @@ func TestConfig_DriverConfig_AllowRuntimes(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_DriverConfig_Reload(t *testing.T) {
+	ci.Parallel(t)
+
+	var tc map[string]interface{}
+	hclutils.NewConfigParser(configSpec).ParseHCL(t, `config { pids_limit = 100 }`, &tc)
+	dh := dockerDriverHarness(t, tc)
+	d := dh.Impl().(*Driver)
+	coordinator, imageGC := d.coordinator, d.imageGC
+	require.NotNil(t, coordinator)
+
+	setConfig := func(hcl string) error {
+		var c map[string]interface{}
+		hclutils.NewConfigParser(configSpec).ParseHCL(t, "config "+hcl, &c)
+		var data []byte
+		require.NoError(t, base.MsgPackEncode(&data, c))
+		return d.SetConfig(&base.Config{PluginConfig: data})
+	}
+
+	// The new configuration is applied while the image coordinator and
+	// reconcilers of the running tasks are kept
+	require.NoError(t, setConfig(`{ pids_limit = 200 }`))
+	require.Equal(t, int64(200), d.getConfig().PidsLimit)
+	require.Same(t, coordinator, d.coordinator)
+	require.Same(t, imageGC, d.imageGC)
+
+	// An invalid configuration leaves the previous one in place
+	err := setConfig(`{
+  pids_limit = 300
+  gc { dangling_containers { creation_grace = "1s" } }
+}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "creation_grace is less than minimum")
+	require.Equal(t, int64(200), d.getConfig().PidsLimit)
+}
@@ -112,6 +112,10 @@ type dockerCoordinator struct {
 
 	// deleteFuture is indexed by image ID and has a cancelable delete future
 	deleteFuture map[string]context.CancelFunc
+
+	// imageLastUsed is the last time a task started or stopped using an
+	// image, indexed by image ID
+	imageLastUsed map[string]time.Time
 }
 
 // newDockerCoordinator returns a new Docker coordinator
@@ -126,6 +130,7 @@ func newDockerCoordinator(config *dockerCoordinatorConfig) *dockerCoordinator {
 		pullLoggers:             make(map[string][]LogEventFn),
 		imageRefCount:           make(map[string]map[string]struct{}),
 		deleteFuture:            make(map[string]context.CancelFunc),
+		imageLastUsed:           make(map[string]time.Time),
 	}
 }
 
@@ -156,6 +161,10 @@ func (d *dockerCoordinator) PullImage(image string, authOptions *docker.AuthConf
 	// Nomad).
 	delete(d.pullFutures, image)
 
+	if err == nil {
+		d.imageLastUsed[id] = time.Now()
+	}
+
 	// If we are cleaning up, we increment the reference count on the image
 	if err == nil && d.cleanup {
 		d.incrementImageReferenceImpl(id, image, callerID)
@@ -224,6 +233,7 @@ func (d *dockerCoordinator) pullImageImpl(image string, authOptions *docker.Auth
 func (d *dockerCoordinator) IncrementImageReference(imageID, imageName, callerID string) {
 	d.imageLock.Lock()
 	defer d.imageLock.Unlock()
+	d.imageLastUsed[imageID] = time.Now()
 	if d.cleanup {
 		d.incrementImageReferenceImpl(imageID, imageName, callerID)
 	}
@@ -256,6 +266,7 @@ func (d *dockerCoordinator) incrementImageReferenceImpl(imageID, imageName, call
 func (d *dockerCoordinator) RemoveImage(imageID, callerID string) {
 	d.imageLock.Lock()
 	defer d.imageLock.Unlock()
+	d.imageLastUsed[imageID] = time.Now()
 
 	if !d.cleanup {
 		return
@@ -353,9 +364,45 @@ func (d *dockerCoordinator) removeImageImpl(id string, ctx context.Context) {
 		delete(d.deleteFuture, id)
 		cancel()
 	}
+	delete(d.imageLastUsed, id)
 	d.imageLock.Unlock()
 }
 
+// lastUsed returns the last time a task started or stopped using each image
+// that is not currently referenced.
+func (d *dockerCoordinator) lastUsed() map[string]time.Time {
+	d.imageLock.Lock()
+	defer d.imageLock.Unlock()
+
+	used := make(map[string]time.Time, len(d.imageLastUsed))
+	for id, t := range d.imageLastUsed {
+		if len(d.imageRefCount[id]) == 0 {
+			used[id] = t
+		}
+	}
+	return used
+}
+
+// isReferenced returns whether any task holds a reference to the image.
+func (d *dockerCoordinator) isReferenced(imageID string) bool {
+	d.imageLock.Lock()
+	defer d.imageLock.Unlock()
+	return len(d.imageRefCount[imageID]) != 0
+}
+
+// imageRemoved forgets an image removed outside of the reference counting,
+// cancelling any pending delete of it.
+func (d *dockerCoordinator) imageRemoved(imageID string) {
+	d.imageLock.Lock()
+	defer d.imageLock.Unlock()
+
+	if cancel, ok := d.deleteFuture[imageID]; ok {
+		cancel()
+		delete(d.deleteFuture, imageID)
+	}
+	delete(d.imageLastUsed, imageID)
+}
+
 func (d *dockerCoordinator) registerPullLogger(image string, logger LogEventFn) {
 	d.pullLoggerLock.Lock()
 	defer d.pullLoggerLock.Unlock()
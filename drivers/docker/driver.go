@@ -90,8 +90,10 @@ type Driver struct {
 	eventer *eventer.Eventer
 
 	// config contains the runtime configuration for the driver set by the
-	// SetConfig RPC
-	config *DriverConfig
+	// SetConfig RPC. It's replaced when the driver is reconfigured, so it's
+	// accessed with getConfig.
+	config     *DriverConfig
+	configLock sync.RWMutex
 
 	// clientConfig contains a driver specific subset of the Nomad client
 	// configuration
@@ -125,6 +127,8 @@ type Driver struct {
 
 	danglingReconciler *containerReconciler
 	cpusetFixer        CpusetFixer
+	imageGC            *imageGC
+	prefetcher         *imagePrefetcher
 }
 
 // NewDockerDriver returns a docker implementation of a driver plugin
@@ -139,6 +143,13 @@ func NewDockerDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlu
 	}
 }
 
+// getConfig returns the current configuration of the driver
+func (d *Driver) getConfig() *DriverConfig {
+	d.configLock.RLock()
+	defer d.configLock.RUnlock()
+	return d.config
+}
+
 func (d *Driver) reattachToDockerLogger(reattachConfig *pstructs.ReattachConfig) (docklog.DockerLogger, *plugin.Client, error) {
 	reattach, err := pstructs.ReattachConfigToGoPlugin(reattachConfig)
 	if err != nil {
@@ -163,14 +174,14 @@ func (d *Driver) setupNewDockerLogger(container *docker.Container, cfg *drivers.
 	}
 
 	if err := dlogger.Start(&docklog.StartOpts{
-		Endpoint:    d.config.Endpoint,
+		Endpoint:    d.getConfig().Endpoint,
 		ContainerID: container.ID,
 		TTY:         container.Config.Tty,
 		Stdout:      cfg.StdoutPath,
 		Stderr:      cfg.StderrPath,
-		TLSCert:     d.config.TLS.Cert,
-		TLSKey:      d.config.TLS.Key,
-		TLSCA:       d.config.TLS.CA,
+		TLSCert:     d.getConfig().TLS.Cert,
+		TLSKey:      d.getConfig().TLS.Key,
+		TLSCA:       d.getConfig().TLS.CA,
 		StartTime:   startTime.Unix(),
 	}); err != nil {
 		pluginClient.Kill()
@@ -190,6 +201,11 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		return fmt.Errorf("failed to decode driver task state: %v", err)
 	}
 
+	var driverConfig TaskConfig
+	if err := handle.Config.DecodeDriverConfig(&driverConfig); err != nil {
+		return fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
 	client, _, err := d.dockerClients()
 	if err != nil {
 		return fmt.Errorf("failed to get docker client: %v", err)
@@ -211,11 +227,14 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		containerImage:        container.Image,
 		doneCh:                make(chan bool),
 		waitCh:                make(chan struct{}),
-		removeContainerOnExit: d.config.GC.Container,
+		removeContainerOnExit: d.getConfig().GC.Container,
 		net:                   handleState.DriverNetwork,
+		emitEvent:             d.emitEventFunc(handle.Config),
+		restartOnUnhealthy:    driverConfig.Healthchecks.RestartOnUnhealthy,
+		healthPollInterval:    defaultHealthPollInterval,
 	}
 
-	if !d.config.DisableLogCollection {
+	if !d.getConfig().DisableLogCollection {
 		h.dlogger, h.dloggerPluginClient, err = d.reattachToDockerLogger(handleState.ReattachConfig)
 		if err != nil {
 			d.logger.Warn("failed to reattach to docker logger process", "error", err)
@@ -239,6 +258,7 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 
 	d.tasks.Set(handle.Config.ID, h)
 	go h.run()
+	go h.watchHealth()
 
 	return nil
 }
@@ -358,7 +378,7 @@ CREATE:
 		}
 	}
 
-	collectingLogs := !d.config.DisableLogCollection
+	collectingLogs := !d.getConfig().DisableLogCollection
 
 	var dlogger docklog.DockerLogger
 	var pluginClient *plugin.Client
@@ -393,8 +413,11 @@ CREATE:
 		containerImage:        container.Image,
 		doneCh:                make(chan bool),
 		waitCh:                make(chan struct{}),
-		removeContainerOnExit: d.config.GC.Container,
+		removeContainerOnExit: d.getConfig().GC.Container,
 		net:                   net,
+		emitEvent:             d.emitEventFunc(cfg),
+		restartOnUnhealthy:    driverConfig.Healthchecks.RestartOnUnhealthy,
+		healthPollInterval:    defaultHealthPollInterval,
 	}
 
 	if err := handle.SetDriverState(h.buildState()); err != nil {
@@ -409,6 +432,7 @@ CREATE:
 
 	d.tasks.Set(cfg.ID, h)
 	go h.run()
+	go h.watchHealth()
 
 	return handle, net, nil
 }
@@ -584,7 +608,7 @@ func (d *Driver) pullImage(task *drivers.TaskConfig, driverConfig *TaskConfig, c
 		return "", fmt.Errorf("Failed to parse image_pull_timeout: %v", err)
 	}
 
-	return d.coordinator.PullImage(driverConfig.Image, authOptions, task.ID, d.emitEventFunc(task), pullDur, d.config.pullActivityTimeoutDuration)
+	return d.coordinator.PullImage(driverConfig.Image, authOptions, task.ID, d.emitEventFunc(task), pullDur, d.getConfig().pullActivityTimeoutDuration)
 }
 
 func (d *Driver) emitEventFunc(task *drivers.TaskConfig) LogEventFn {
@@ -608,8 +632,8 @@ type authBackend func(string) (*docker.AuthConfiguration, error)
 func (d *Driver) resolveRegistryAuthentication(driverConfig *TaskConfig, repo string) (*docker.AuthConfiguration, error) {
 	return firstValidAuth(repo, []authBackend{
 		authFromTaskConfig(driverConfig),
-		authFromDockerConfig(d.config.Auth.Config),
-		authFromHelper(d.config.Auth.Helper),
+		authFromDockerConfig(d.getConfig().Auth.Config),
+		authFromHelper(d.getConfig().Auth.Helper),
 	})
 }
 
@@ -663,7 +687,7 @@ func (d *Driver) containerBinds(task *drivers.TaskConfig, driverConfig *TaskConf
 
 	taskLocalBindVolume := driverConfig.VolumeDriver == ""
 
-	if !d.config.Volumes.Enabled && !taskLocalBindVolume {
+	if !d.getConfig().Volumes.Enabled && !taskLocalBindVolume {
 		return nil, fmt.Errorf("volumes are not enabled; cannot use volume driver %q", driverConfig.VolumeDriver)
 	}
 
@@ -688,7 +712,7 @@ func (d *Driver) containerBinds(task *drivers.TaskConfig, driverConfig *TaskConf
 			src = filepath.Clean(src)
 		}
 
-		if !d.config.Volumes.Enabled && !isParentPath(task.AllocDir, src) {
+		if !d.getConfig().Volumes.Enabled && !isParentPath(task.AllocDir, src) {
 			return nil, fmt.Errorf("volumes are not enabled; cannot mount host paths: %+q", userbind)
 		}
 
@@ -699,7 +723,7 @@ func (d *Driver) containerBinds(task *drivers.TaskConfig, driverConfig *TaskConf
 		binds = append(binds, bind)
 	}
 
-	if selinuxLabel := d.config.Volumes.SelinuxLabel; selinuxLabel != "" {
+	if selinuxLabel := d.getConfig().Volumes.SelinuxLabel; selinuxLabel != "" {
 		// Apply SELinux Label to each volume
 		for i := range binds {
 			binds[i] = fmt.Sprintf("%s:%s", binds[i], selinuxLabel)
@@ -821,17 +845,21 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		config.WorkingDir = driverConfig.WorkDir
 	}
 
+	if driverConfig.Healthchecks.Disable {
+		config.Healthcheck = &docker.HealthConfig{Test: []string{"NONE"}}
+	}
+
 	containerRuntime := driverConfig.Runtime
 	if _, ok := task.DeviceEnv[nvidiaVisibleDevices]; ok {
 		if !d.gpuRuntime {
-			return c, fmt.Errorf("requested docker runtime %q was not found", d.config.GPURuntimeName)
+			return c, fmt.Errorf("requested docker runtime %q was not found", d.getConfig().GPURuntimeName)
 		}
-		if containerRuntime != "" && containerRuntime != d.config.GPURuntimeName {
-			return c, fmt.Errorf("conflicting runtime requests: gpu runtime %q conflicts with task runtime %q", d.config.GPURuntimeName, containerRuntime)
+		if containerRuntime != "" && containerRuntime != d.getConfig().GPURuntimeName {
+			return c, fmt.Errorf("conflicting runtime requests: gpu runtime %q conflicts with task runtime %q", d.getConfig().GPURuntimeName, containerRuntime)
 		}
-		containerRuntime = d.config.GPURuntimeName
+		containerRuntime = d.getConfig().GPURuntimeName
 	}
-	if _, ok := d.config.allowRuntimes[containerRuntime]; !ok && containerRuntime != "" {
+	if _, ok := d.getConfig().allowRuntimes[containerRuntime]; !ok && containerRuntime != "" {
 		return c, fmt.Errorf("requested runtime %q is not allowed", containerRuntime)
 	}
 
@@ -840,14 +868,14 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 	var pidsLimit int64
 
 	// Pids limit defined in Nomad plugin config. Defaults to 0 (Unlimited).
-	if d.config.PidsLimit > 0 {
-		pidsLimit = d.config.PidsLimit
+	if d.getConfig().PidsLimit > 0 {
+		pidsLimit = d.getConfig().PidsLimit
 	}
 
 	// Override Nomad plugin config pids limit, by user defined pids limit.
 	if driverConfig.PidsLimit > 0 {
-		if d.config.PidsLimit > 0 && driverConfig.PidsLimit > d.config.PidsLimit {
-			return c, fmt.Errorf("pids_limit cannot be greater than nomad plugin config pids_limit: %d", d.config.PidsLimit)
+		if d.getConfig().PidsLimit > 0 && driverConfig.PidsLimit > d.getConfig().PidsLimit {
+			return c, fmt.Errorf("pids_limit cannot be greater than nomad plugin config pids_limit: %d", d.getConfig().PidsLimit)
 		}
 		pidsLimit = driverConfig.PidsLimit
 	}
@@ -902,6 +930,30 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		hostConfig.CPUQuota = int64(task.Resources.LinuxResources.PercentTicks*float64(driverConfig.CPUCFSPeriod)) * int64(numCores)
 	}
 
+	// Limit the block I/O of the container, which docker applies through the
+	// cgroup blkio or io controller
+	if io := task.Resources.LinuxResources.IO; io != nil {
+		hostConfig.BlkioWeight = int64(io.Weight)
+		for _, device := range io.Devices {
+			if device.ReadBps > 0 {
+				hostConfig.BlkioDeviceReadBps = append(hostConfig.BlkioDeviceReadBps,
+					docker.BlockLimit{Path: device.Path, Rate: int64(device.ReadBps)})
+			}
+			if device.WriteBps > 0 {
+				hostConfig.BlkioDeviceWriteBps = append(hostConfig.BlkioDeviceWriteBps,
+					docker.BlockLimit{Path: device.Path, Rate: int64(device.WriteBps)})
+			}
+			if device.ReadIOPS > 0 {
+				hostConfig.BlkioDeviceReadIOps = append(hostConfig.BlkioDeviceReadIOps,
+					docker.BlockLimit{Path: device.Path, Rate: int64(device.ReadIOPS)})
+			}
+			if device.WriteIOPS > 0 {
+				hostConfig.BlkioDeviceWriteIOps = append(hostConfig.BlkioDeviceWriteIOps,
+					docker.BlockLimit{Path: device.Path, Rate: int64(device.WriteIOPS)})
+			}
+		}
+	}
+
 	// Windows does not support MemorySwap/MemorySwappiness #2193
 	if runtime.GOOS == "windows" {
 		hostConfig.MemorySwap = 0
@@ -927,8 +979,8 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 
 	if hostConfig.LogConfig.Type == "" && hostConfig.LogConfig.Config == nil {
 		logger.Trace("no docker log driver provided, defaulting to plugin config")
-		hostConfig.LogConfig.Type = d.config.Logging.Type
-		hostConfig.LogConfig.Config = d.config.Logging.Config
+		hostConfig.LogConfig.Type = d.getConfig().Logging.Type
+		hostConfig.LogConfig.Config = d.getConfig().Logging.Config
 	}
 
 	logger.Debug("configured resources",
@@ -939,14 +991,14 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 	logger.Debug("binding directories", "binds", hclog.Fmt("%#v", hostConfig.Binds))
 
 	//  set privileged mode
-	if driverConfig.Privileged && !d.config.AllowPrivileged {
+	if driverConfig.Privileged && !d.getConfig().AllowPrivileged {
 		return c, fmt.Errorf(`Docker privileged mode is disabled on this Nomad agent`)
 	}
 	hostConfig.Privileged = driverConfig.Privileged
 
 	// set add/drop capabilities
 	if hostConfig.CapAdd, hostConfig.CapDrop, err = capabilities.Delta(
-		capabilities.DockerDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
+		capabilities.DockerDefaults(), d.getConfig().AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
 	); err != nil {
 		return c, err
 	}
@@ -1175,7 +1227,7 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 	labels[dockerLabelAllocID] = task.AllocID
 
 	//optional labels, as configured in plugin configuration
-	for _, configurationExtraLabel := range d.config.ExtraLabels {
+	for _, configurationExtraLabel := range d.getConfig().ExtraLabels {
 		if glob.Glob(configurationExtraLabel, "job_name") {
 			labels[dockerLabelJobName] = task.JobName
 		}
@@ -1256,7 +1308,7 @@ func (d *Driver) toDockerMount(m *DockerMount, task *drivers.TaskConfig) (*docke
 
 		// paths inside alloc dir are always allowed as they mount within
 		// a container, and treated as relative to task dir
-		if !d.config.Volumes.Enabled && !isParentPath(task.AllocDir, hm.Source) {
+		if !d.getConfig().Volumes.Enabled && !isParentPath(task.AllocDir, hm.Source) {
 			return nil, fmt.Errorf(
 				"volumes are not enabled; cannot mount host path: %q %q",
 				hm.Source, task.AllocDir)
@@ -1264,7 +1316,7 @@ func (d *Driver) toDockerMount(m *DockerMount, task *drivers.TaskConfig) (*docke
 	case "tmpfs":
 		// no source, so no sandbox check required
 	default: // "volume", but also any new thing that comes along
-		if !d.config.Volumes.Enabled {
+		if !d.getConfig().Volumes.Enabled {
 			return nil, fmt.Errorf(
 				"volumes are not enabled; cannot mount volume: %q", hm.Source)
 		}
@@ -1470,7 +1522,7 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 // doesn't exist or is still in use. Requires the global client to already be
 // initialized.
 func (d *Driver) cleanupImage(handle *taskHandle) error {
-	if !d.config.GC.Image {
+	if !d.getConfig().GC.Image {
 		return nil
 	}
 
@@ -1503,6 +1555,10 @@ func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 		ExitResult:      h.ExitResult(),
 	}
 
+	if health := container.State.Health.Status; health != "" {
+		status.DriverAttributes["health"] = health
+	}
+
 	status.State = drivers.TaskStateUnknown
 	if container.State.Running {
 		status.State = drivers.TaskStateRunning
@@ -1693,11 +1749,11 @@ func (d *Driver) newDockerClient(timeout time.Duration) (*docker.Client, error)
 	// the DOCKER_* environment variables DOCKER_HOST, DOCKER_TLS_VERIFY, and
 	// DOCKER_CERT_PATH. This allows us to lock down the config in production
 	// but also accept the standard ENV configs for dev and test.
-	dockerEndpoint := d.config.Endpoint
+	dockerEndpoint := d.getConfig().Endpoint
 	if dockerEndpoint != "" {
-		cert := d.config.TLS.Cert
-		key := d.config.TLS.Key
-		ca := d.config.TLS.CA
+		cert := d.getConfig().TLS.Cert
+		key := d.getConfig().TLS.Key
+		ca := d.getConfig().TLS.CA
 
 		if cert+key+ca != "" {
 			d.logger.Debug("using TLS client connection", "endpoint", dockerEndpoint)
@@ -2788,6 +2788,30 @@ func TestDockerDriver_CreateContainerConfig_CPUHardLimit(t *testing.T) {
 	require.NotZero(t, c.HostConfig.CPUPeriod)
 }
 
+func TestDockerDriver_CreateContainerConfig_IO(t *testing.T) {
+	ci.Parallel(t)
+
+	task, cfg, ports := dockerTask(t)
+	defer freeport.Return(ports)
+	task.Resources.LinuxResources.IO = &structs.IOResources{
+		Weight: 500,
+		Devices: []*structs.IODeviceLimit{
+			{Path: "/dev/sda", ReadBps: 1024, WriteIOPS: 10},
+		},
+	}
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+
+	require.Equal(t, int64(500), c.HostConfig.BlkioWeight)
+	require.Equal(t, []docker.BlockLimit{{Path: "/dev/sda", Rate: 1024}}, c.HostConfig.BlkioDeviceReadBps)
+	require.Equal(t, []docker.BlockLimit{{Path: "/dev/sda", Rate: 10}}, c.HostConfig.BlkioDeviceWriteIOps)
+	require.Empty(t, c.HostConfig.BlkioDeviceWriteBps)
+	require.Empty(t, c.HostConfig.BlkioDeviceReadIOps)
+}
+
 func TestDockerDriver_memoryLimits(t *testing.T) {
 	ci.Parallel(t)
 
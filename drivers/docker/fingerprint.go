@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 )
@@ -17,6 +18,7 @@ func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint,
 	// task drivers not having a kind of post-setup hook.
 	d.danglingReconciler.Start()
 	d.cpusetFixer.Start()
+	d.imageGC.Start()
 
 	ch := make(chan *drivers.Fingerprint)
 	go d.handleFingerprint(ctx, ch)
@@ -117,15 +119,15 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	d.setDetected(true)
 	fp.Attributes["driver.docker"] = pstructs.NewBoolAttribute(true)
 	fp.Attributes["driver.docker.version"] = pstructs.NewStringAttribute(env.Get("Version"))
-	if d.config.AllowPrivileged {
+	if d.getConfig().AllowPrivileged {
 		fp.Attributes["driver.docker.privileged.enabled"] = pstructs.NewBoolAttribute(true)
 	}
 
-	if d.config.PidsLimit > 0 {
-		fp.Attributes["driver.docker.pids.limit"] = pstructs.NewIntAttribute(d.config.PidsLimit, "")
+	if d.getConfig().PidsLimit > 0 {
+		fp.Attributes["driver.docker.pids.limit"] = pstructs.NewIntAttribute(d.getConfig().PidsLimit, "")
 	}
 
-	if d.config.Volumes.Enabled {
+	if d.getConfig().Volumes.Enabled {
 		fp.Attributes["driver.docker.volumes.enabled"] = pstructs.NewBoolAttribute(true)
 	}
 
@@ -158,7 +160,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	} else {
 		runtimeNames := make([]string, 0, len(dockerInfo.Runtimes))
 		for name := range dockerInfo.Runtimes {
-			if d.config.GPURuntimeName == name {
+			if d.getConfig().GPURuntimeName == name {
 				// Nvidia runtime is detected by Docker.
 				// It makes possible to run GPU workloads using Docker driver on this host.
 				d.gpuRuntime = true
@@ -187,5 +189,15 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 
 	d.setFingerprintSuccess()
 
+	for _, image := range d.prefetcher.images() {
+		fp.Attributes[structs.ImagePrefetchAttribute(pluginName, image)] = pstructs.NewBoolAttribute(true)
+	}
+
+	// Failing to free enough disk space leaves the driver usable, but
+	// changing the description surfaces the condition as a node event
+	if desc := d.imageGC.pressure(); desc != "" {
+		fp.HealthDescription = desc
+	}
+
 	return fp
 }
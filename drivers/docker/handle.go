@@ -15,6 +15,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/drivers/docker/docklog"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	"golang.org/x/net/context"
@@ -34,10 +35,34 @@ type taskHandle struct {
 	removeContainerOnExit bool
 	net                   *drivers.DriverNetwork
 
+	// emitEvent emits task events for the task
+	emitEvent LogEventFn
+
+	// restartOnUnhealthy stops the container once its health check fails
+	restartOnUnhealthy bool
+
+	// healthPollInterval is the interval between inspections of the
+	// container's health
+	healthPollInterval time.Duration
+
+	// health is the last health status of the container's health check and
+	// stoppedUnhealthy is set when the container is stopped for failing it
+	health           string
+	stoppedUnhealthy bool
+	healthLock       sync.Mutex
+
 	exitResult     *drivers.ExitResult
 	exitResultLock sync.Mutex
 }
 
+// defaultHealthPollInterval is the interval between inspections of the health
+// of containers with a health check
+const defaultHealthPollInterval = 5 * time.Second
+
+// unhealthyStopTimeout is the time an unhealthy container is given to stop
+// before it is killed
+const unhealthyStopTimeout = 10 * time.Second
+
 func (h *taskHandle) ExitResult() *drivers.ExitResult {
 	h.exitResultLock.Lock()
 	defer h.exitResultLock.Unlock()
@@ -223,6 +248,67 @@ func (h *taskHandle) shutdownLogger() {
 	h.dloggerPluginClient.Kill()
 }
 
+// watchHealth watches the status of the container's health check, emitting a
+// task event whenever it changes. It exits immediately if the container has
+// no health check.
+func (h *taskHandle) watchHealth() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-h.doneCh:
+			return
+		case <-timer.C:
+			timer.Reset(h.healthPollInterval)
+		}
+
+		container, err := h.client.InspectContainerWithOptions(docker.InspectContainerOptions{
+			ID: h.containerID,
+		})
+		if err != nil {
+			h.logger.Debug("failed to inspect container health", "error", err)
+			continue
+		}
+
+		status := container.State.Health.Status
+		switch status {
+		case structs.TaskHealthStarting, structs.TaskHealthHealthy, structs.TaskHealthUnhealthy:
+		default:
+			// The container has no health check
+			return
+		}
+
+		h.healthLock.Lock()
+		changed := status != h.health
+		h.health = status
+		stop := status == structs.TaskHealthUnhealthy && h.restartOnUnhealthy
+		h.stoppedUnhealthy = stop
+		h.healthLock.Unlock()
+
+		if changed {
+			msg := fmt.Sprintf("Container health status is %s", status)
+			if logs := container.State.Health.Log; status == structs.TaskHealthUnhealthy && len(logs) > 0 {
+				msg = fmt.Sprintf("%s: %s", msg, strings.TrimSpace(logs[len(logs)-1].Output))
+			}
+			h.emitEvent(msg, map[string]string{drivers.TaskEventHealthAnnotation: status})
+		}
+
+		if stop {
+			h.logger.Info("stopping unhealthy container")
+			if err := h.client.StopContainer(h.containerID, uint(unhealthyStopTimeout.Seconds())); err != nil {
+				_, noSuchContainer := err.(*docker.NoSuchContainer)
+				_, containerNotRunning := err.(*docker.ContainerNotRunning)
+				if !containerNotRunning && !noSuchContainer {
+					h.logger.Error("failed to stop unhealthy container", "error", err)
+					continue
+				}
+			}
+			return
+		}
+	}
+}
+
 func (h *taskHandle) run() {
 	defer h.shutdownLogger()
 
@@ -249,6 +335,12 @@ func (h *taskHandle) run() {
 		werr = fmt.Errorf("OOM Killed")
 	}
 
+	h.healthLock.Lock()
+	if h.stoppedUnhealthy {
+		werr = fmt.Errorf("Docker container was stopped because it was unhealthy")
+	}
+	h.healthLock.Unlock()
+
 	// Shutdown stats collection
 	close(h.doneCh)
 
@@ -0,0 +1,156 @@
+package docker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHealthDocker serves the container inspect and stop endpoints of the
+// Docker API, reporting the given health statuses in order and repeating the
+// last one.
+type fakeHealthDocker struct {
+	statuses []string
+	stopped  bool
+	lock     sync.Mutex
+}
+
+func (f *fakeHealthDocker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	switch r.URL.Path {
+	case "/containers/abc/json":
+		status := f.statuses[0]
+		if len(f.statuses) > 1 {
+			f.statuses = f.statuses[1:]
+		}
+		json.NewEncoder(w).Encode(docker.Container{
+			ID: "abc",
+			State: docker.State{
+				Running: !f.stopped,
+				Health: docker.Health{
+					Status: status,
+					Log:    []docker.HealthCheck{{ExitCode: 1, Output: "connection refused\n"}},
+				},
+			},
+		})
+	case "/containers/abc/stop":
+		f.stopped = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testHealthHandle(t *testing.T, fake *fakeHealthDocker) (*taskHandle, func() []map[string]string) {
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client, err := docker.NewClient(srv.URL)
+	require.NoError(t, err)
+
+	var lock sync.Mutex
+	var events []map[string]string
+	h := &taskHandle{
+		client:             client,
+		logger:             testlog.HCLogger(t),
+		containerID:        "abc",
+		doneCh:             make(chan bool),
+		healthPollInterval: 10 * time.Millisecond,
+		emitEvent: func(msg string, annotations map[string]string) {
+			lock.Lock()
+			defer lock.Unlock()
+			events = append(events, map[string]string{
+				"message": msg,
+				"health":  annotations[drivers.TaskEventHealthAnnotation],
+			})
+		},
+	}
+	return h, func() []map[string]string {
+		lock.Lock()
+		defer lock.Unlock()
+		return events
+	}
+}
+
+func TestTaskHandle_WatchHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	fake := &fakeHealthDocker{statuses: []string{"starting", "starting", "healthy", "unhealthy"}}
+	h, events := testHealthHandle(t, fake)
+	h.restartOnUnhealthy = true
+
+	done := make(chan struct{})
+	go func() {
+		h.watchHealth()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		close(h.doneCh)
+		t.Fatal("timed out waiting for unhealthy container to be stopped")
+	}
+
+	require.Equal(t, []map[string]string{
+		{"message": "Container health status is starting", "health": structs.TaskHealthStarting},
+		{"message": "Container health status is healthy", "health": structs.TaskHealthHealthy},
+		{"message": "Container health status is unhealthy: connection refused", "health": structs.TaskHealthUnhealthy},
+	}, events())
+	require.True(t, fake.stopped)
+	require.True(t, h.stoppedUnhealthy)
+}
+
+func TestTaskHandle_WatchHealth_NoHealthcheck(t *testing.T) {
+	ci.Parallel(t)
+
+	fake := &fakeHealthDocker{statuses: []string{""}}
+	h, events := testHealthHandle(t, fake)
+
+	done := make(chan struct{})
+	go func() {
+		h.watchHealth()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		close(h.doneCh)
+		t.Fatal("expected watcher to exit for container without a health check")
+	}
+	require.Empty(t, events())
+}
+
+func TestTaskHandle_WatchHealth_NoRestart(t *testing.T) {
+	ci.Parallel(t)
+
+	fake := &fakeHealthDocker{statuses: []string{"healthy", "unhealthy"}}
+	h, events := testHealthHandle(t, fake)
+
+	go h.watchHealth()
+	defer close(h.doneCh)
+
+	require.Eventually(t, func() bool {
+		return len(events()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Unhealthy containers keep running unless restart_on_unhealthy is set
+	time.Sleep(50 * time.Millisecond)
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+	require.False(t, fake.stopped)
+	require.Len(t, events(), 2)
+}
@@ -0,0 +1,313 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	docker "github.com/fsouza/go-dockerclient"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/ryanuber/go-glob"
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// imageGCClient is the subset of the Docker client used by imageGC
+type imageGCClient interface {
+	ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error)
+	ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error)
+	RemoveImageExtended(name string, opts docker.RemoveImageOptions) error
+	Info() (*docker.DockerInfo, error)
+}
+
+// imageGC removes the least recently used images when the free space on the
+// disk holding the Docker data root drops below a threshold.
+//
+// Reference counted image removal only cleans up images some time after the
+// last task using them stops, so nodes running many distinct images, or
+// configured with a long image_delay, can still run out of disk space.
+type imageGC struct {
+	ctx         context.Context
+	config      *DiskPressureGCConfig
+	client      imageGCClient
+	coordinator *dockerCoordinator
+	logger      hclog.Logger
+
+	// infraImage is the pause container image, which is always pinned
+	infraImage string
+
+	isDriverHealthy func() bool
+	trackedImages   func() map[string]bool
+
+	// diskUsage returns the total and free bytes of the disk holding path
+	diskUsage func(path string) (total, free uint64, err error)
+
+	// pressureDesc is set while GC could not free enough space
+	pressureDesc string
+	pressureLock sync.Mutex
+
+	once sync.Once
+}
+
+func newImageGC(d *Driver) *imageGC {
+	return &imageGC{
+		ctx:         d.ctx,
+		config:      &d.getConfig().GC.DiskPressure,
+		client:      client,
+		coordinator: d.coordinator,
+		logger:      d.logger.Named("image_gc"),
+		infraImage:  d.getConfig().InfraImage,
+
+		isDriverHealthy: func() bool { return d.previouslyDetected() && d.fingerprintSuccessful() },
+		trackedImages:   d.trackedImages,
+		diskUsage:       diskUsage,
+	}
+}
+
+func (g *imageGC) Start() {
+	if !g.config.Enabled {
+		g.logger.Trace("skipping disk pressure image gc; is disabled")
+		return
+	}
+
+	g.once.Do(func() {
+		go g.run()
+	})
+}
+
+func (g *imageGC) run() {
+	lastIterSucceeded := true
+
+	timer := time.NewTimer(g.config.period)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if g.isDriverHealthy() {
+				err := g.collect()
+				if err != nil && lastIterSucceeded {
+					g.logger.Warn("failed to garbage collect images", "error", err)
+				}
+				lastIterSucceeded = (err == nil)
+			}
+
+			timer.Reset(g.config.period)
+		case <-g.ctx.Done():
+			return
+		}
+	}
+}
+
+// pressure returns a description of the disk pressure if the last collection
+// could not free enough space, or the empty string otherwise.
+func (g *imageGC) pressure() string {
+	g.pressureLock.Lock()
+	defer g.pressureLock.Unlock()
+	return g.pressureDesc
+}
+
+func (g *imageGC) setPressure(desc string) {
+	g.pressureLock.Lock()
+	defer g.pressureLock.Unlock()
+	g.pressureDesc = desc
+}
+
+// collect removes unused images, least recently used first, until the free
+// space on the Docker data root reaches the target.
+func (g *imageGC) collect() error {
+	info, err := g.client.Info()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker system info: %v", err)
+	}
+	root := info.DockerRootDir
+
+	total, free, err := g.diskUsage(root)
+	if err != nil {
+		return fmt.Errorf("failed to determine disk space for %s: %v", root, err)
+	}
+	if total == 0 || percent(free, total) >= float64(g.config.MinFreePercent) {
+		g.setPressure("")
+		return nil
+	}
+
+	candidates, err := g.candidates()
+	if err != nil {
+		return err
+	}
+
+	g.logger.Info("free disk space below threshold, removing images",
+		"path", root, "free_percent", percent(free, total), "candidates", len(candidates))
+
+	initialFree := free
+	removed := 0
+	for _, img := range candidates {
+		if percent(free, total) >= float64(g.config.TargetFreePercent) {
+			break
+		}
+
+		// Skip images a task has started using since listing them
+		if g.coordinator.isReferenced(img.ID) {
+			continue
+		}
+
+		ctx, cancel := g.dockerAPIQueryContext()
+		err := g.client.RemoveImageExtended(img.ID, docker.RemoveImageOptions{
+			Context: ctx,
+			Force:   true,
+		})
+		cancel()
+		if err == docker.ErrNoSuchImage {
+			g.coordinator.imageRemoved(img.ID)
+			continue
+		}
+		if err != nil {
+			g.logger.Warn("failed to remove image", "image_id", img.ID, "error", err)
+			continue
+		}
+
+		g.logger.Debug("removed image", "image_id", img.ID, "tags", img.RepoTags)
+		g.coordinator.imageRemoved(img.ID)
+		removed++
+
+		total, free, err = g.diskUsage(root)
+		if err != nil {
+			return fmt.Errorf("failed to determine disk space for %s: %v", root, err)
+		}
+	}
+
+	if free > initialFree {
+		metrics.IncrCounter([]string{"client", "driver", "docker", "image_gc", "reclaimed_bytes"}, float32(free-initialFree))
+	}
+	metrics.IncrCounter([]string{"client", "driver", "docker", "image_gc", "removed_images"}, float32(removed))
+
+	if percent(free, total) < float64(g.config.MinFreePercent) {
+		g.logger.Warn("image garbage collection could not free enough disk space",
+			"path", root, "free_percent", percent(free, total), "min_free_percent", g.config.MinFreePercent)
+		metrics.IncrCounter([]string{"client", "driver", "docker", "image_gc", "insufficient"}, 1)
+		g.setPressure(fmt.Sprintf("Image garbage collection could not free enough disk space on %s", root))
+		return nil
+	}
+
+	g.setPressure("")
+	return nil
+}
+
+// candidates returns the images that may be removed, least recently used
+// first. Images not used by a task since the driver started are ordered by
+// their creation time.
+func (g *imageGC) candidates() ([]docker.APIImages, error) {
+	ctx, cancel := g.dockerAPIQueryContext()
+	defer cancel()
+
+	images, err := g.client.ListImages(docker.ListImagesOptions{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %v", err)
+	}
+
+	containers, err := g.client.ListContainers(docker.ListContainersOptions{
+		Context: ctx,
+		All:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	// Containers reference their image by the name or ID they were created
+	// with
+	inUse := g.trackedImages()
+	for _, c := range containers {
+		inUse[c.Image] = true
+	}
+
+	lastUsed := g.coordinator.lastUsed()
+
+	result := make([]docker.APIImages, 0, len(images))
+	used := make(map[string]time.Time, len(images))
+	for _, img := range images {
+		if g.pinned(img) || isImageInUse(img, inUse) || g.coordinator.isReferenced(img.ID) {
+			continue
+		}
+
+		if t, ok := lastUsed[img.ID]; ok {
+			used[img.ID] = t
+		} else {
+			used[img.ID] = time.Unix(img.Created, 0)
+		}
+		result = append(result, img)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return used[result[i].ID].Before(used[result[j].ID])
+	})
+	return result, nil
+}
+
+// pinned returns whether the image matches the infra image or one of the
+// pinned image globs.
+func (g *imageGC) pinned(img docker.APIImages) bool {
+	names := imageNames(img)
+	for _, name := range names {
+		if g.infraImage != "" && name == g.infraImage {
+			return true
+		}
+		for _, pattern := range g.config.PinnedImages {
+			if glob.Glob(pattern, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dockerAPIQueryContext returns a context for docker API response with an
+// appropriate timeout to protect against wedged locked-up API call.
+func (g *imageGC) dockerAPIQueryContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(g.ctx, 30*time.Second)
+}
+
+// imageNames returns the ID, tags and digests an image can be referred to by
+func imageNames(img docker.APIImages) []string {
+	names := []string{img.ID, strings.TrimPrefix(img.ID, "sha256:")}
+	names = append(names, img.RepoTags...)
+	names = append(names, img.RepoDigests...)
+	return names
+}
+
+// isImageInUse returns whether any name of the image is in the set of names
+// referenced by containers
+func isImageInUse(img docker.APIImages, inUse map[string]bool) bool {
+	for _, name := range imageNames(img) {
+		if inUse[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func percent(part, total uint64) float64 {
+	return float64(part) / float64(total) * 100
+}
+
+func diskUsage(path string) (uint64, uint64, error) {
+	usage, err := disk.Usage(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	return usage.Total, usage.Free, nil
+}
+
+func (d *Driver) trackedImages() map[string]bool {
+	d.tasks.lock.RLock()
+	defer d.tasks.lock.RUnlock()
+
+	r := make(map[string]bool, len(d.tasks.store))
+	for _, h := range d.tasks.store {
+		r[h.containerImage] = true
+	}
+
+	return r
+}
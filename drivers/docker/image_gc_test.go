@@ -0,0 +1,181 @@
+package docker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/stretchr/testify/require"
+)
+
+// mockImageGCClient frees the size of each removed image from a disk of
+// 1000 bytes
+type mockImageGCClient struct {
+	images     []docker.APIImages
+	containers []docker.APIContainers
+	free       uint64
+	removed    []string
+	lock       sync.Mutex
+}
+
+func (m *mockImageGCClient) ListImages(docker.ListImagesOptions) ([]docker.APIImages, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]docker.APIImages(nil), m.images...), nil
+}
+
+func (m *mockImageGCClient) ListContainers(docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	return m.containers, nil
+}
+
+func (m *mockImageGCClient) RemoveImageExtended(id string, _ docker.RemoveImageOptions) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i, img := range m.images {
+		if img.ID == id {
+			m.images = append(m.images[:i], m.images[i+1:]...)
+			m.removed = append(m.removed, id)
+			m.free += uint64(img.Size)
+			return nil
+		}
+	}
+	return docker.ErrNoSuchImage
+}
+
+func (m *mockImageGCClient) Info() (*docker.DockerInfo, error) {
+	return &docker.DockerInfo{DockerRootDir: "/var/lib/docker"}, nil
+}
+
+func (m *mockImageGCClient) diskUsage(string) (uint64, uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return 1000, m.free, nil
+}
+
+func newTestImageGC(t *testing.T, mock *mockImageGCClient) *imageGC {
+	logger := testlog.HCLogger(t)
+	coordinator := newDockerCoordinator(&dockerCoordinatorConfig{
+		ctx:     context.Background(),
+		logger:  logger,
+		cleanup: true,
+		client:  newMockImageClient(nil, 0),
+	})
+
+	return &imageGC{
+		ctx: context.Background(),
+		config: &DiskPressureGCConfig{
+			Enabled:           true,
+			MinFreePercent:    10,
+			TargetFreePercent: 20,
+			PinnedImages:      []string{"redis:*"},
+		},
+		client:          mock,
+		coordinator:     coordinator,
+		logger:          logger,
+		infraImage:      "gcr.io/google_containers/pause-amd64:3.1",
+		isDriverHealthy: func() bool { return true },
+		trackedImages:   func() map[string]bool { return map[string]bool{"sha256:tracked": true} },
+		diskUsage:       mock.diskUsage,
+	}
+}
+
+func TestImageGC_Collect(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := &mockImageGCClient{
+		images: []docker.APIImages{
+			{ID: "sha256:old", RepoTags: []string{"app:1"}, Created: 100, Size: 40},
+			{ID: "sha256:recent", RepoTags: []string{"app:2"}, Created: 50, Size: 40},
+			{ID: "sha256:newer", RepoTags: []string{"app:3"}, Created: 200, Size: 40},
+			{ID: "sha256:pinned", RepoTags: []string{"redis:7"}, Created: 1, Size: 500},
+			{ID: "sha256:infra", RepoTags: []string{"gcr.io/google_containers/pause-amd64:3.1"}, Created: 1, Size: 500},
+			{ID: "sha256:container", RepoTags: []string{"stopped:1"}, Created: 1, Size: 500},
+			{ID: "sha256:tracked", Created: 1, Size: 500},
+			{ID: "sha256:referenced", Created: 1, Size: 500},
+		},
+		containers: []docker.APIContainers{{Image: "stopped:1"}},
+		free:       50,
+	}
+	gc := newTestImageGC(t, mock)
+
+	// A recently used image is removed after images only known by their
+	// creation time, and referenced images are never removed
+	gc.coordinator.IncrementImageReference("sha256:recent", "app:2", "task")
+	gc.coordinator.RemoveImage("sha256:recent", "task")
+	gc.coordinator.IncrementImageReference("sha256:referenced", "ref", "task")
+
+	require.NoError(t, gc.collect())
+	require.Equal(t, []string{"sha256:old", "sha256:newer", "sha256:recent"}, mock.removed)
+	require.Empty(t, gc.pressure())
+
+	// Nothing is removed while free space is above the minimum
+	mock.removed = nil
+	mock.free = 150
+	require.NoError(t, gc.collect())
+	require.Empty(t, mock.removed)
+}
+
+func TestImageGC_Collect_Insufficient(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := &mockImageGCClient{
+		images: []docker.APIImages{
+			{ID: "sha256:a", Created: 1, Size: 10},
+			{ID: "sha256:pinned", RepoTags: []string{"redis:7"}, Created: 1, Size: 500},
+		},
+		free: 50,
+	}
+	gc := newTestImageGC(t, mock)
+
+	require.NoError(t, gc.collect())
+	require.Equal(t, []string{"sha256:a"}, mock.removed)
+	require.Contains(t, gc.pressure(), "could not free enough disk space on /var/lib/docker")
+
+	// Pressure clears once enough space is free
+	mock.free = 500
+	require.NoError(t, gc.collect())
+	require.Empty(t, gc.pressure())
+}
+
+func TestImageGC_Pinned(t *testing.T) {
+	ci.Parallel(t)
+
+	gc := newTestImageGC(t, &mockImageGCClient{})
+	gc.config.PinnedImages = []string{"redis:*", "*/team/*", "sha256:abc*", "app@sha256:def"}
+
+	cases := []struct {
+		img    docker.APIImages
+		pinned bool
+	}{
+		{docker.APIImages{ID: "sha256:1", RepoTags: []string{"redis:7"}}, true},
+		{docker.APIImages{ID: "sha256:1", RepoTags: []string{"registry.example.com/team/app:1"}}, true},
+		{docker.APIImages{ID: "sha256:abcdef"}, true},
+		{docker.APIImages{ID: "sha256:1", RepoDigests: []string{"app@sha256:def"}}, true},
+		{docker.APIImages{ID: "sha256:1", RepoTags: []string{"gcr.io/google_containers/pause-amd64:3.1"}}, true},
+		{docker.APIImages{ID: "sha256:1", RepoTags: []string{"memcached:1"}}, false},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.pinned, gc.pinned(tc.img), "%v", tc.img)
+	}
+}
+
+func TestImageGC_Collect_LastUsedOnRemove(t *testing.T) {
+	ci.Parallel(t)
+
+	gc := newTestImageGC(t, &mockImageGCClient{})
+	gc.coordinator.IncrementImageReference("sha256:a", "a", "task")
+	require.Empty(t, gc.coordinator.lastUsed())
+
+	before := time.Now()
+	gc.coordinator.RemoveImage("sha256:a", "task")
+	used := gc.coordinator.lastUsed()
+	require.Contains(t, used, "sha256:a")
+	require.False(t, used["sha256:a"].Before(before))
+
+	gc.coordinator.imageRemoved("sha256:a")
+	require.Empty(t, gc.coordinator.lastUsed())
+}
@@ -28,15 +28,15 @@ func (d *Driver) CreateNetwork(allocID string, createSpec *drivers.NetworkCreate
 		return nil, false, fmt.Errorf("failed to connect to docker daemon: %s", err)
 	}
 
-	repo, _ := parseDockerImage(d.config.InfraImage)
+	repo, _ := parseDockerImage(d.getConfig().InfraImage)
 	authOptions, err := firstValidAuth(repo, []authBackend{
-		authFromDockerConfig(d.config.Auth.Config),
-		authFromHelper(d.config.Auth.Helper),
+		authFromDockerConfig(d.getConfig().Auth.Config),
+		authFromHelper(d.getConfig().Auth.Helper),
 	})
 	if err != nil {
-		d.logger.Debug("auth failed for infra container image pull", "image", d.config.InfraImage, "error", err)
+		d.logger.Debug("auth failed for infra container image pull", "image", d.getConfig().InfraImage, "error", err)
 	}
-	_, err = d.coordinator.PullImage(d.config.InfraImage, authOptions, allocID, noopLogEventFn, d.config.infraImagePullTimeoutDuration, d.config.pullActivityTimeoutDuration)
+	_, err = d.coordinator.PullImage(d.getConfig().InfraImage, authOptions, allocID, noopLogEventFn, d.getConfig().infraImagePullTimeoutDuration, d.getConfig().pullActivityTimeoutDuration)
 	if err != nil {
 		return nil, false, err
 	}
@@ -74,7 +74,7 @@ func (d *Driver) CreateNetwork(allocID string, createSpec *drivers.NetworkCreate
 		return specFromContainer(container, createSpec.Hostname), false, nil
 	}
 
-	container, err = d.createContainer(client, *config, d.config.InfraImage)
+	container, err = d.createContainer(client, *config, d.getConfig().InfraImage)
 	if err != nil {
 		return nil, false, err
 	}
@@ -114,7 +114,7 @@ func (d *Driver) createSandboxContainerConfig(allocID string, createSpec *driver
 	return &docker.CreateContainerOptions{
 		Name: fmt.Sprintf("nomad_init_%s", allocID),
 		Config: &docker.Config{
-			Image:    d.config.InfraImage,
+			Image:    d.getConfig().InfraImage,
 			Hostname: createSpec.Hostname,
 		},
 		HostConfig: &docker.HostConfig{
@@ -0,0 +1,187 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// imagePrefetchCallerID is the caller prefetched images are referenced
+	// by, which keeps them from being removed while they are prefetched
+	imagePrefetchCallerID = "image-prefetch"
+
+	// imagePrefetchPullTimeout bounds how long prefetching a single image may
+	// take. Stalled pulls are cancelled sooner by the pull activity timeout.
+	imagePrefetchPullTimeout = 30 * time.Minute
+)
+
+var _ drivers.ImagePrefetchDriver = (*Driver)(nil)
+
+// PrefetchImages sets the images to pull ahead of the tasks using them. Images
+// missing from the set are released and removed once no task uses them.
+func (d *Driver) PrefetchImages(images []string) error {
+	if d.prefetcher == nil {
+		return fmt.Errorf("driver is not configured")
+	}
+
+	d.prefetcher.set(images)
+	return nil
+}
+
+// imagePrefetcher pulls the images set by the client in the background and
+// keeps a reference on them so neither the reference counted image cleanup
+// nor the disk pressure image GC removes them.
+type imagePrefetcher struct {
+	logger hclog.Logger
+
+	// pull pulls the image, referencing it, and returns its ID
+	pull func(image string) (string, error)
+
+	// release drops the reference on the image ID
+	release func(imageID string)
+
+	// exists returns false if the image ID is known to have been removed
+	exists func(imageID string) bool
+
+	// desired is the set of images last set by the client
+	desired map[string]bool
+
+	// present maps the prefetched images to their image IDs
+	present map[string]string
+
+	// pulling is the set of images being pulled
+	pulling map[string]bool
+
+	lock sync.Mutex
+}
+
+func newImagePrefetcher(d *Driver) *imagePrefetcher {
+	return &imagePrefetcher{
+		logger: d.logger.Named("image_prefetch"),
+		pull:   d.prefetchImage,
+		release: func(imageID string) {
+			d.coordinator.RemoveImage(imageID, imagePrefetchCallerID)
+		},
+		exists: func(imageID string) bool {
+			_, err := client.InspectImage(imageID)
+			return err != docker.ErrNoSuchImage
+		},
+		desired: make(map[string]bool),
+		present: make(map[string]string),
+		pulling: make(map[string]bool),
+	}
+}
+
+// set replaces the images to prefetch, pulling the missing ones in the
+// background and releasing the ones no longer listed.
+func (p *imagePrefetcher) set(images []string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.desired = make(map[string]bool, len(images))
+	for _, image := range images {
+		p.desired[image] = true
+	}
+
+	for image, id := range p.present {
+		if !p.desired[image] {
+			delete(p.present, image)
+			p.releaseLocked(id)
+			continue
+		}
+
+		// Pull images removed outside of Nomad again
+		if !p.exists(id) {
+			p.logger.Debug("prefetched image was removed", "image", image, "image_id", id)
+			delete(p.present, image)
+		}
+	}
+
+	for image := range p.desired {
+		if _, ok := p.present[image]; ok || p.pulling[image] {
+			continue
+		}
+
+		p.pulling[image] = true
+		go p.fetch(image)
+	}
+}
+
+// fetch pulls the image and records it as present if it is still desired
+func (p *imagePrefetcher) fetch(image string) {
+	p.logger.Debug("prefetching image", "image", image)
+	id, err := p.pull(image)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.pulling, image)
+
+	if err != nil {
+		// The client sets the images again periodically, which retries the
+		// pull
+		p.logger.Warn("failed to prefetch image", "image", image, "error", err)
+		return
+	}
+
+	if !p.desired[image] {
+		p.releaseLocked(id)
+		return
+	}
+
+	p.logger.Debug("prefetched image", "image", image, "image_id", id)
+	p.present[image] = id
+}
+
+// releaseLocked releases the image ID unless another prefetched image
+// resolves to it. The lock must be held.
+func (p *imagePrefetcher) releaseLocked(id string) {
+	for _, other := range p.present {
+		if other == id {
+			return
+		}
+	}
+	p.release(id)
+}
+
+// images returns the prefetched images present on the node
+func (p *imagePrefetcher) images() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	images := make([]string, 0, len(p.present))
+	for image := range p.present {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+// prefetchImage pulls the image, unless it is already present and not
+// tagged latest, and references it for prefetching.
+func (d *Driver) prefetchImage(image string) (string, error) {
+	repo, tag := parseDockerImage(image)
+	if tag != "latest" {
+		if dockerImage, _ := client.InspectImage(image); dockerImage != nil {
+			d.coordinator.IncrementImageReference(dockerImage.ID, image, imagePrefetchCallerID)
+			return dockerImage.ID, nil
+		}
+	}
+
+	config := d.getConfig()
+	authOptions, err := firstValidAuth(repo, []authBackend{
+		authFromDockerConfig(config.Auth.Config),
+		authFromHelper(config.Auth.Helper),
+	})
+	if err != nil {
+		d.logger.Debug("auth failed for prefetched image pull", "image", image, "error", err)
+	}
+
+	return d.coordinator.PullImage(image, authOptions, imagePrefetchCallerID, noopLogEventFn,
+		imagePrefetchPullTimeout, config.pullActivityTimeoutDuration)
+}
@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/stretchr/testify/require"
+)
+
+// testPrefetcher is an image prefetcher resolving images to IDs prefixed
+// with "id-", failing to pull images in fail, and recording released IDs.
+type testPrefetcher struct {
+	*imagePrefetcher
+
+	fail     map[string]bool
+	removed  map[string]bool
+	released []string
+	pulls    int
+	lock     sync.Mutex
+}
+
+func newTestPrefetcher(t *testing.T) *testPrefetcher {
+	tp := &testPrefetcher{
+		fail:    make(map[string]bool),
+		removed: make(map[string]bool),
+	}
+	tp.imagePrefetcher = &imagePrefetcher{
+		logger: testlog.HCLogger(t),
+		pull: func(image string) (string, error) {
+			tp.lock.Lock()
+			defer tp.lock.Unlock()
+			tp.pulls++
+			if tp.fail[image] {
+				return "", fmt.Errorf("pull failed")
+			}
+			return "id-" + image, nil
+		},
+		release: func(id string) {
+			tp.lock.Lock()
+			defer tp.lock.Unlock()
+			tp.released = append(tp.released, id)
+		},
+		exists: func(id string) bool {
+			tp.lock.Lock()
+			defer tp.lock.Unlock()
+			return !tp.removed[id]
+		},
+		desired: make(map[string]bool),
+		present: make(map[string]string),
+		pulling: make(map[string]bool),
+	}
+	return tp
+}
+
+func (tp *testPrefetcher) waitImages(t *testing.T, expected []string) {
+	require.Eventually(t, func() bool {
+		images := tp.images()
+		tp.lock.Lock()
+		defer tp.lock.Unlock()
+		return fmt.Sprint(images) == fmt.Sprint(expected)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestImagePrefetcher_Set(t *testing.T) {
+	ci.Parallel(t)
+
+	tp := newTestPrefetcher(t)
+	tp.fail["broken:1"] = true
+
+	tp.set([]string{"redis:7", "nvidia/cuda:11", "broken:1"})
+	tp.waitImages(t, []string{"nvidia/cuda:11", "redis:7"})
+
+	// Failed pulls are retried when the images are set again, while present
+	// images are not pulled again
+	tp.fail["broken:1"] = false
+	tp.set([]string{"redis:7", "nvidia/cuda:11", "broken:1"})
+	tp.waitImages(t, []string{"broken:1", "nvidia/cuda:11", "redis:7"})
+	require.Equal(t, 4, tp.pulls)
+
+	// Images no longer listed are released, and removed images pulled again
+	tp.removed["id-redis:7"] = true
+	tp.set([]string{"redis:7"})
+	tp.waitImages(t, []string{"redis:7"})
+	require.ElementsMatch(t, []string{"id-nvidia/cuda:11", "id-broken:1"}, tp.released)
+	require.Equal(t, 5, tp.pulls)
+}
+
+func TestImagePrefetcher_SharedImageID(t *testing.T) {
+	ci.Parallel(t)
+
+	tp := newTestPrefetcher(t)
+	tp.pull = func(string) (string, error) { return "sha256:same", nil }
+
+	tp.set([]string{"app:1", "app:stable"})
+	tp.waitImages(t, []string{"app:1", "app:stable"})
+
+	// The image is only released once no prefetched image resolves to it
+	tp.set([]string{"app:stable"})
+	require.Empty(t, tp.released)
+
+	tp.set(nil)
+	require.Equal(t, []string{"sha256:same"}, tp.released)
+	require.Empty(t, tp.images())
+}
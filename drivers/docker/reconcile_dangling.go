@@ -33,7 +33,7 @@ type containerReconciler struct {
 func newReconciler(d *Driver) *containerReconciler {
 	return &containerReconciler{
 		ctx:    d.ctx,
-		config: &d.config.GC.DanglingContainers,
+		config: &d.getConfig().GC.DanglingContainers,
 		client: client,
 		logger: d.logger,
 
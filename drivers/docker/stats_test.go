@@ -35,6 +35,16 @@ func TestDriver_DockerStatsCollector(t *testing.T) {
 	stats.MemoryStats.CommitPeak = 321323
 	stats.MemoryStats.PrivateWorkingSet = 62222
 
+	stats.BlkioStats.IOServiceBytesRecursive = []docker.BlkioStatsEntry{
+		{Major: 8, Op: "Read", Value: 4096},
+		{Major: 8, Op: "Write", Value: 8192},
+		{Major: 8, Op: "Total", Value: 12288},
+	}
+	stats.BlkioStats.IOServicedRecursive = []docker.BlkioStatsEntry{
+		{Major: 8, Op: "read", Value: 1},
+		{Major: 8, Op: "write", Value: 2},
+	}
+
 	go dockerStatsCollector(dst, src, time.Second)
 
 	select {
@@ -54,6 +64,10 @@ func TestDriver_DockerStatsCollector(t *testing.T) {
 			require.Equal(stats.MemoryStats.MaxUsage, ru.ResourceUsage.MemoryStats.MaxUsage)
 			require.Equal(stats.CPUStats.ThrottlingData.ThrottledPeriods, ru.ResourceUsage.CpuStats.ThrottledPeriods)
 			require.Equal(stats.CPUStats.ThrottlingData.ThrottledTime, ru.ResourceUsage.CpuStats.ThrottledTime)
+			require.Equal(uint64(4096), ru.ResourceUsage.IOStats.ReadBytes)
+			require.Equal(uint64(8192), ru.ResourceUsage.IOStats.WriteBytes)
+			require.Equal(uint64(1), ru.ResourceUsage.IOStats.ReadOps)
+			require.Equal(uint64(2), ru.ResourceUsage.IOStats.WriteOps)
 		} else {
 			require.Equal(stats.MemoryStats.PrivateWorkingSet, ru.ResourceUsage.MemoryStats.RSS)
 			require.Equal(stats.MemoryStats.Commit, ru.ResourceUsage.MemoryStats.Usage)
@@ -4,6 +4,7 @@ package util
 
 import (
 	"runtime"
+	"strings"
 
 	docker "github.com/fsouza/go-dockerclient"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -16,6 +17,8 @@ var (
 	// cgroup-v2 only exposes a subset of memory stats
 	DockerCgroupV1MeasuredMemStats = []string{"RSS", "Cache", "Swap", "Usage", "Max Usage"}
 	DockerCgroupV2MeasuredMemStats = []string{"Cache", "Swap", "Usage"}
+
+	DockerMeasuredIOStats = []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"}
 )
 
 func DockerStatsToTaskResourceUsage(s *docker.Stats) *cstructs.TaskResourceUsage {
@@ -55,10 +58,33 @@ func DockerStatsToTaskResourceUsage(s *docker.Stats) *cstructs.TaskResourceUsage
 		s.CPUStats.CPUUsage.TotalUsage, s.PreCPUStats.CPUUsage.TotalUsage, runtime.NumCPU())
 	cs.TotalTicks = (cs.Percent / 100) * stats.TotalTicksAvailable() / float64(runtime.NumCPU())
 
+	// Sum the block I/O across devices. Docker reports the cgroup v1 sync,
+	// async and total counters alongside reads and writes.
+	is := &cstructs.IOStats{
+		Measured: DockerMeasuredIOStats,
+	}
+	for _, e := range s.BlkioStats.IOServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			is.ReadBytes += e.Value
+		case "write":
+			is.WriteBytes += e.Value
+		}
+	}
+	for _, e := range s.BlkioStats.IOServicedRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			is.ReadOps += e.Value
+		case "write":
+			is.WriteOps += e.Value
+		}
+	}
+
 	return &cstructs.TaskResourceUsage{
 		ResourceUsage: &cstructs.ResourceUsage{
 			MemoryStats: ms,
 			CpuStats:    cs,
+			IOStats:     is,
 		},
 		Timestamp: s.Read.UTC().UnixNano(),
 	}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,7 +18,9 @@ import (
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/plugins/base"
@@ -23,6 +28,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/drivers/utils"
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+	digest "github.com/opencontainers/go-digest"
 )
 
 const (
@@ -78,17 +84,36 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"default_seccomp_profile": hclspec.NewAttr("default_seccomp_profile", "string", false),
+		"default_fs_allow_read":   hclspec.NewAttr("default_fs_allow_read", "list(string)", false),
+		"default_fs_allow_write":  hclspec.NewAttr("default_fs_allow_write", "list(string)", false),
+		"image_cache_dir":         hclspec.NewAttr("image_cache_dir", "string", false),
+		"image_gc_delay": hclspec.NewDefault(
+			hclspec.NewAttr("image_gc_delay", "string", false),
+			hclspec.NewLiteral(`"3m"`),
+		),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
+		"command":  hclspec.NewAttr("command", "string", false),
 		"args":     hclspec.NewAttr("args", "list(string)", false),
 		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
 		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
 		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
 		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+
+		"seccomp_profile": hclspec.NewAttr("seccomp_profile", "string", false),
+		"fs_allow_read":   hclspec.NewAttr("fs_allow_read", "list(string)", false),
+		"fs_allow_write":  hclspec.NewAttr("fs_allow_write", "list(string)", false),
+
+		"image":      hclspec.NewAttr("image", "string", false),
+		"force_pull": hclspec.NewAttr("force_pull", "bool", false),
+		"auth": hclspec.NewBlock("auth", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"username": hclspec.NewAttr("username", "string", false),
+			"password": hclspec.NewAttr("password", "string", false),
+		})),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -121,6 +146,10 @@ type Driver struct {
 	// tasks is the in memory datastore mapping taskIDs to driverHandles
 	tasks *taskStore
 
+	// images is the cache of images used as task root filesystems. It is
+	// nil unless image_cache_dir is configured.
+	images *ociimage.Store
+
 	// ctx is the context for the driver. It is passed to other subsystems to
 	// coordinate shutdown
 	ctx context.Context
@@ -151,6 +180,35 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// DefaultSeccompProfile is the seccomp profile applied to tasks that do
+	// not set one: "default", "unconfined" or the absolute path of a JSON
+	// profile on the host.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
+
+	// DefaultFSAllowRead is the list of paths inside the task's chroot that
+	// tasks which do not set fs_allow_read may read.
+	DefaultFSAllowRead []string `codec:"default_fs_allow_read"`
+
+	// DefaultFSAllowWrite is the list of paths inside the task's chroot that
+	// tasks which do not set fs_allow_write may write.
+	DefaultFSAllowWrite []string `codec:"default_fs_allow_write"`
+
+	// ImageCacheDir is the directory in which the layers of task images are
+	// cached. Tasks can only use images if it is set.
+	ImageCacheDir string `codec:"image_cache_dir"`
+
+	// ImageGCDelay is how long an image is kept in the cache after the last
+	// task using it is destroyed.
+	ImageGCDelay string `codec:"image_gc_delay"`
+}
+
+func (c *Config) sandboxPolicy() *sandbox.Policy {
+	return &sandbox.Policy{
+		SeccompProfile: c.DefaultSeccompProfile,
+		FSAllowRead:    c.DefaultFSAllowRead,
+		FSAllowWrite:   c.DefaultFSAllowWrite,
+	}
 }
 
 func (c *Config) validate() error {
@@ -171,17 +229,45 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if err := c.sandboxPolicy().ValidateDefaults(); err != nil {
+		return err
+	}
+
+	if c.ImageCacheDir != "" {
+		if !filepath.IsAbs(c.ImageCacheDir) {
+			return fmt.Errorf("image_cache_dir must be an absolute path, got %q", c.ImageCacheDir)
+		}
+		if strings.ContainsAny(c.ImageCacheDir, ",:") {
+			return fmt.Errorf("image_cache_dir must not contain ',' or ':', got %q", c.ImageCacheDir)
+		}
+		if _, err := time.ParseDuration(c.ImageGCDelay); err != nil {
+			return fmt.Errorf("image_gc_delay must be a duration: %v", err)
+		}
+	}
+
 	return nil
 }
 
 // TaskConfig is the driver configuration of a task within a job
 type TaskConfig struct {
-	// Command is the thing to exec.
+	// Command is the thing to exec. It may only be omitted if Image has an
+	// entrypoint or cmd.
 	Command string `codec:"command"`
 
 	// Args are passed along to Command.
 	Args []string `codec:"args"`
 
+	// Image is the reference of an OCI image used as the root filesystem
+	// of the task instead of the chroot.
+	Image string `codec:"image"`
+
+	// ForcePull resolves the image's tag with its registry even if the
+	// image is already cached.
+	ForcePull bool `codec:"force_pull"`
+
+	// Auth is the credentials used to pull the image.
+	Auth ImageAuth `codec:"auth"`
+
 	// ModePID indicates whether PID namespace isolation is enabled for the task.
 	// Must be "private" or "host" if set.
 	ModePID string `codec:"pid_mode"`
@@ -195,9 +281,35 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SeccompProfile is the seccomp profile applied to the task: "default",
+	// "unconfined" or the path of a JSON profile relative to the task dir.
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// FSAllowRead is the list of paths inside the task's chroot that the
+	// task may read.
+	FSAllowRead []string `codec:"fs_allow_read"`
+
+	// FSAllowWrite is the list of paths inside the task's chroot that the
+	// task may write.
+	FSAllowWrite []string `codec:"fs_allow_write"`
+}
+
+func (tc *TaskConfig) sandboxPolicy() *sandbox.Policy {
+	return &sandbox.Policy{
+		SeccompProfile: tc.SeccompProfile,
+		FSAllowRead:    tc.FSAllowRead,
+		FSAllowWrite:   tc.FSAllowWrite,
+	}
 }
 
 func (tc *TaskConfig) validate() error {
+	if tc.Image != "" {
+		if _, err := ociimage.ParseReference(tc.Image); err != nil {
+			return err
+		}
+	}
+
 	switch tc.ModePID {
 	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
 	default:
@@ -220,6 +332,10 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
 	}
 
+	if err := tc.sandboxPolicy().Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -231,6 +347,10 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+
+	// ImageDigest is the digest of the image mounted as the task's root
+	// filesystem, if any
+	ImageDigest string
 }
 
 // NewExecDriver returns a new DrivePlugin implementation
@@ -287,6 +407,15 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 	}
 	d.config = config
 
+	if config.ImageCacheDir != "" {
+		delay, _ := time.ParseDuration(config.ImageGCDelay)
+		images, err := ociimage.NewStore(d.ctx, d.logger, config.ImageCacheDir, delay)
+		if err != nil {
+			return err
+		}
+		d.images = images
+	}
+
 	if cfg != nil && cfg.AgentConfig != nil {
 		d.nomadConfig = cfg.AgentConfig.Driver
 	}
@@ -300,7 +429,41 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	return driverCapabilities, nil
+	if !criuAvailable() {
+		return driverCapabilities, nil
+	}
+
+	caps := *driverCapabilities
+	caps.Checkpoint = true
+	return &caps, nil
+}
+
+// criuAvailable returns whether the criu(8) binary used to checkpoint and
+// restore tasks can be found on the client.
+func criuAvailable() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	_, err := osexec.LookPath("criu")
+	return err == nil
+}
+
+// userNamespacesSupported returns true if the kernel supports user namespaces
+// and they have not been disabled.
+func userNamespacesSupported() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if _, err := os.Stat("/proc/self/ns/user"); err != nil {
+		return false
+	}
+	max, err := os.ReadFile("/proc/sys/user/max_user_namespaces")
+	if err != nil {
+		// older kernels don't limit the number of user namespaces
+		return true
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(max)))
+	return err == nil && n > 0
 }
 
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
@@ -366,6 +529,21 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	if criuAvailable() {
+		fp.Attributes["driver.exec.checkpoint"] = pstructs.NewBoolAttribute(true)
+	}
+	if sandbox.SeccompSupported() {
+		fp.Attributes["driver.exec.seccomp"] = pstructs.NewBoolAttribute(true)
+	}
+	if sandbox.LandlockSupported() {
+		fp.Attributes["driver.exec.landlock"] = pstructs.NewBoolAttribute(true)
+	}
+	if userNamespacesSupported() {
+		fp.Attributes["driver.exec.user_namespaces"] = pstructs.NewBoolAttribute(true)
+	}
+	if d.images != nil {
+		fp.Attributes["driver.exec.images"] = pstructs.NewBoolAttribute(true)
+	}
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -405,11 +583,19 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		return fmt.Errorf("failed to reattach to executor: %v", err)
 	}
 
+	// Keep the task's image in the cache while it runs
+	if taskState.ImageDigest != "" && d.images != nil {
+		if _, err := d.images.AcquireDigest(digest.Digest(taskState.ImageDigest), handle.Config.ID); err != nil {
+			d.logger.Warn("failed to find image of recovered task", "error", err, "task_id", handle.Config.ID)
+		}
+	}
+
 	h := &taskHandle{
 		exec:         exec,
 		pid:          taskState.Pid,
 		pluginClient: pluginClient,
 		taskConfig:   taskState.TaskConfig,
+		imageDigest:  taskState.ImageDigest,
 		procState:    drivers.TaskStateRunning,
 		startedAt:    taskState.StartedAt,
 		exitResult:   &drivers.ExitResult{},
@@ -423,6 +609,21 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// RestoreTask starts the task from the checkpoint written to path by a
+// previous call to CheckpointTask.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, path string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if !criuAvailable() {
+		return nil, nil, fmt.Errorf("criu not found, cannot restore task")
+	}
+	return d.startTask(cfg, path)
+}
+
+// startTask launches the task, restoring it from the checkpoint at
+// restorePath if set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, restorePath string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -435,11 +636,59 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	if err := driverConfig.validate(); err != nil {
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
+	if driverConfig.Command == "" && driverConfig.Image == "" {
+		return nil, nil, fmt.Errorf("command must be set unless an image is used")
+	}
+
+	sandboxConfig, err := d.config.sandboxPolicy().Resolve(driverConfig.sandboxPolicy(), cfg.TaskDir().Dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to configure sandbox: %v", err)
+	}
+	if err := sandboxConfig.CheckSupported(); err != nil {
+		return nil, nil, err
+	}
+	if cfg.UserNamespace != nil && !userNamespacesSupported() {
+		return nil, nil, fmt.Errorf("user namespaces are enabled on the client but not supported by the kernel")
+	}
 
 	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
 
+	execCmd := &executor.ExecCommand{
+		Cmd:  driverConfig.Command,
+		Args: driverConfig.Args,
+		Env:  cfg.EnvList(),
+		User: cfg.User,
+	}
+
+	var imageDigest string
+	started := false
+	if driverConfig.Image != "" {
+		img, err := d.mountImage(cfg, &driverConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to mount image: %v", err)
+		}
+		imageDigest = img.Digest.String()
+
+		defer func() {
+			if !started {
+				d.unmountImage(cfg, imageDigest)
+			}
+		}()
+
+		execCmd.Cmd, execCmd.Args, err = imageCommand(img.Config, driverConfig.Command, driverConfig.Args)
+		if err != nil {
+			return nil, nil, err
+		}
+		execCmd.Env = imageEnv(img.Config.Env, cfg.Env)
+		execCmd.Rootfs = imageRootfs(cfg)
+		execCmd.WorkDir = img.Config.WorkingDir
+		if execCmd.User == "" {
+			execCmd.User = img.Config.User
+		}
+	}
+
 	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, "executor.out")
 	executorConfig := &executor.ExecutorConfig{
 		LogFile:     pluginLogFile,
@@ -454,12 +703,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed to create executor: %v", err)
 	}
 
-	user := cfg.User
-	if user == "" {
-		user = "nobody"
+	if execCmd.User == "" {
+		execCmd.User = "nobody"
 	}
 
-	if cfg.DNS != nil {
+	// Images don't have the client's resolv.conf embedded like the chroot,
+	// so the client's is mounted in when the task doesn't configure DNS
+	if cfg.DNS != nil || imageDigest != "" {
 		dnsMount, err := resolvconf.GenerateDNSMount(cfg.TaskDir().Dir, cfg.DNS)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to build mount for resolv.conf: %v", err)
@@ -475,24 +725,21 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 	d.logger.Debug("task capabilities", "capabilities", caps)
 
-	execCmd := &executor.ExecCommand{
-		Cmd:              driverConfig.Command,
-		Args:             driverConfig.Args,
-		Env:              cfg.EnvList(),
-		User:             user,
-		ResourceLimits:   true,
-		NoPivotRoot:      d.config.NoPivotRoot,
-		Resources:        cfg.Resources,
-		TaskDir:          cfg.TaskDir().Dir,
-		StdoutPath:       cfg.StdoutPath,
-		StderrPath:       cfg.StderrPath,
-		Mounts:           cfg.Mounts,
-		Devices:          cfg.Devices,
-		NetworkIsolation: cfg.NetworkIsolation,
-		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
-		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
-		Capabilities:     caps,
-	}
+	execCmd.ResourceLimits = true
+	execCmd.NoPivotRoot = d.config.NoPivotRoot
+	execCmd.Resources = cfg.Resources
+	execCmd.TaskDir = cfg.TaskDir().Dir
+	execCmd.StdoutPath = cfg.StdoutPath
+	execCmd.StderrPath = cfg.StderrPath
+	execCmd.Mounts = cfg.Mounts
+	execCmd.Devices = cfg.Devices
+	execCmd.NetworkIsolation = cfg.NetworkIsolation
+	execCmd.ModePID = executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID)
+	execCmd.ModeIPC = executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC)
+	execCmd.Capabilities = caps
+	execCmd.RestorePath = restorePath
+	execCmd.Sandbox = sandboxConfig
+	execCmd.UserNamespace = cfg.UserNamespace
 
 	ps, err := exec.Launch(execCmd)
 	if err != nil {
@@ -505,6 +752,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		pid:          ps.Pid,
 		pluginClient: pluginClient,
 		taskConfig:   cfg,
+		imageDigest:  imageDigest,
 		procState:    drivers.TaskStateRunning,
 		startedAt:    time.Now().Round(time.Millisecond),
 		logger:       d.logger,
@@ -515,6 +763,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
+		ImageDigest:    imageDigest,
 	}
 
 	if err := handle.SetDriverState(&driverState); err != nil {
@@ -526,9 +775,29 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 
 	d.tasks.Set(cfg.ID, h)
 	go h.run()
+	started = true
 	return handle, nil, nil
 }
 
+// CheckpointTask writes a checkpoint of the running task to path. The task
+// is stopped once the checkpoint is complete.
+func (d *Driver) CheckpointTask(taskID string, path string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if !criuAvailable() {
+		return fmt.Errorf("criu not found, cannot checkpoint task")
+	}
+
+	if err := handle.exec.Checkpoint(path); err != nil {
+		return fmt.Errorf("executor Checkpoint failed: %v", err)
+	}
+
+	return nil
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -618,6 +887,10 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 		handle.pluginClient.Kill()
 	}
 
+	if handle.imageDigest != "" {
+		d.unmountImage(handle.taskConfig, handle.imageDigest)
+	}
+
 	// workaround for the case where DestroyTask was issued on task restart
 	d.resetCgroup(handle)
 
@@ -691,6 +964,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingRawDriver = (*Driver)(nil)
+var _ drivers.CheckpointDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
 	taskID string,
@@ -813,6 +813,34 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("sandbox", func(t *testing.T) {
+		for _, tc := range []struct {
+			profile string
+			read    []string
+			exp     string
+		}{
+			{profile: "", exp: ""},
+			{profile: "default", read: []string{"/bin", "/lib"}, exp: ""},
+			{profile: "unconfined", exp: ""},
+			{profile: "profile.json", exp: `seccomp profile path "profile.json" must be absolute`},
+			{profile: "/does/not/exist.json", exp: "failed to read seccomp profile"},
+			{read: []string{"bin"}, exp: `fs_allow_read path "bin" must be absolute`},
+		} {
+			err := (&Config{
+				DefaultModePID:        "private",
+				DefaultModeIPC:        "private",
+				DefaultSeccompProfile: tc.profile,
+				DefaultFSAllowRead:    tc.read,
+			}).validate()
+			if tc.exp == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.exp)
+			}
+		}
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -872,4 +900,19 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("fs_allow", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{
+			FSAllowRead:  []string{"/bin", "/lib"},
+			FSAllowWrite: []string{"/alloc/data"},
+		}).validate())
+
+		err := (&TaskConfig{
+			FSAllowRead:  []string{"bin"},
+			FSAllowWrite: []string{"alloc/data"},
+		}).validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `fs_allow_read path "bin" must be absolute`)
+		require.Contains(t, err.Error(), `fs_allow_write path "alloc/data" must be absolute`)
+	})
 }
@@ -5,21 +5,29 @@ package exec
 import (
 	"context"
 	"fmt"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/userns"
 	ctestutils "github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
 	basePlug "github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
 	"github.com/hashicorp/nomad/testutil"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sys/unix"
 )
@@ -319,3 +327,280 @@ func TestExecDriver_Capabilities(t *testing.T) {
 		})
 	}
 }
+
+func TestExecDriver_Sandbox(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	if !sandbox.LandlockSupported() {
+		t.Skip("landlock is not supported")
+	}
+
+	profile := `{"defaultAction":"SCMP_ACT_ALLOW","syscalls":[{"names":["mkdir","mkdirat"],"action":"SCMP_ACT_ERRNO"}]}`
+	libs := []string{"/bin", "/lib", "/lib64", "/usr", "/etc/ld.so.cache"}
+
+	for _, tc := range []struct {
+		Name       string
+		Config     *TaskConfig
+		Successful bool
+		Stderr     string
+	}{
+		{
+			Name: "seccomp",
+			Config: &TaskConfig{
+				Command:        "/usr/bin/mktemp",
+				Args:           []string{"-d"},
+				SeccompProfile: "local/profile.json",
+			},
+			Stderr: "Operation not permitted",
+		},
+		{
+			Name: "fs allowed",
+			Config: &TaskConfig{
+				Command:     "/bin/bash",
+				Args:        []string{"-c", "echo ok > /alloc/ok.txt && cat /local/profile.json"},
+				FSAllowRead: libs,
+			},
+			Successful: true,
+		},
+		{
+			// cat is dynamically linked, so it needs the loader and
+			// shared libraries even if the task allows nothing
+			Name: "fs allows nothing",
+			Config: &TaskConfig{
+				Command:     "/bin/cat",
+				Args:        []string{"/local/profile.json"},
+				FSAllowRead: []string{},
+			},
+			Successful: true,
+		},
+		{
+			Name: "fs denied",
+			Config: &TaskConfig{
+				Command:     "/bin/cat",
+				Args:        []string{"/etc/passwd"},
+				FSAllowRead: []string{},
+			},
+			Stderr: "Permission denied",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			d := NewExecDriver(ctx, testlog.HCLogger(t))
+			harness := dtestutil.NewDriverHarness(t, d)
+			defer harness.Kill()
+
+			tmpDir := t.TempDir()
+			allocID := uuid.Generate()
+			task := &drivers.TaskConfig{
+				AllocID:    allocID,
+				ID:         uuid.Generate(),
+				Name:       "sandbox",
+				StdoutPath: filepath.Join(tmpDir, "stdout"),
+				StderrPath: filepath.Join(tmpDir, "stderr"),
+				Resources:  testResources(allocID, "sandbox"),
+			}
+			cleanup := harness.MkAllocDir(task, false)
+
+			require.NoError(t, os.WriteFile(task.StdoutPath, []byte{}, 0660))
+			require.NoError(t, os.WriteFile(task.StderrPath, []byte{}, 0660))
+			defer cleanup()
+
+			require.NoError(t, os.WriteFile(
+				filepath.Join(task.TaskDir().LocalDir, "profile.json"), []byte(profile), 0644))
+			require.NoError(t, task.EncodeConcreteDriverConfig(tc.Config))
+
+			_, _, err := harness.StartTask(task)
+			require.NoError(t, err)
+			defer d.DestroyTask(task.ID, true)
+
+			waitCh, err := harness.WaitTask(context.Background(), task.ID)
+			require.NoError(t, err)
+
+			var res *drivers.ExitResult
+			select {
+			case res = <-waitCh:
+			case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+				require.Fail(t, "timeout waiting for task")
+			}
+
+			stderr, err := os.ReadFile(task.StderrPath)
+			require.NoError(t, err)
+			require.Equal(t, tc.Successful, res.Successful(), "stderr: %s", stderr)
+			require.Contains(t, string(stderr), tc.Stderr)
+		})
+	}
+}
+
+func TestExecDriver_UserNamespace(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	if !userNamespacesSupported() {
+		t.Skip("user namespaces are not supported")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	tmpDir := t.TempDir()
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:       allocID,
+		ID:            uuid.Generate(),
+		Name:          "userns",
+		StdoutPath:    filepath.Join(tmpDir, "stdout"),
+		StderrPath:    filepath.Join(tmpDir, "stderr"),
+		Resources:     testResources(allocID, "userns"),
+		UserNamespace: &drivers.UserNamespaceSpec{HostID: 1000000, Size: 65536},
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// the alloc dir must be reachable by the mapped IDs
+	require.NoError(t, os.Chmod(filepath.Dir(task.AllocDir), 0711))
+	require.NoError(t, userns.CheckTraversal(task.AllocDir))
+
+	require.NoError(t, os.WriteFile(task.StdoutPath, []byte{}, 0660))
+	require.NoError(t, os.WriteFile(task.StderrPath, []byte{}, 0660))
+
+	require.NoError(t, task.EncodeConcreteDriverConfig(&TaskConfig{
+		Command: "/bin/bash",
+		Args:    []string{"-c", "cat /proc/self/uid_map && touch /local/created"},
+		ModePID: executor.IsolationModePrivate,
+		ModeIPC: executor.IsolationModePrivate,
+	}))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	defer d.DestroyTask(task.ID, true)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+
+	select {
+	case res := <-waitCh:
+		stderr, _ := os.ReadFile(task.StderrPath)
+		require.True(t, res.Successful(), "stderr: %s", stderr)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+
+	stdout, err := os.ReadFile(task.StdoutPath)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0", "1000000", "65536"}, strings.Fields(string(stdout)))
+
+	// the task runs as nobody in the namespace, which is mapped into the
+	// range on the host
+	fi, err := os.Stat(filepath.Join(task.TaskDir().LocalDir, "created"))
+	require.NoError(t, err)
+	require.Equal(t, uint32(1000000+65534), fi.Sys().(*syscall.Stat_t).Uid)
+}
+
+// hostBinaryFiles returns the layer entries for the host binary at path and
+// the shared libraries it loads.
+func hostBinaryFiles(t *testing.T, path string) []ociimage.TestFile {
+	out, err := osexec.Command("ldd", path).Output()
+	require.NoError(t, err)
+
+	files := []ociimage.TestFile{{Path: path, HostPath: path}}
+	for _, line := range strings.Split(string(out), "\n") {
+		for _, field := range strings.Fields(line) {
+			if filepath.IsAbs(field) {
+				files = append(files, ociimage.TestFile{Path: field, HostPath: field})
+			}
+		}
+	}
+	return files
+}
+
+func TestExecDriver_Image(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	config := &Config{
+		DefaultModePID: executor.IsolationModePrivate,
+		DefaultModeIPC: executor.IsolationModePrivate,
+		AllowCaps:      capabilities.NomadDefaults().Slice(true),
+		ImageCacheDir:  t.TempDir(),
+		ImageGCDelay:   "1h",
+	}
+	var data []byte
+	require.NoError(t, basePlug.MsgPackEncode(&data, config))
+	require.NoError(t, harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	layout := t.TempDir()
+	base := append(hostBinaryFiles(t, "/bin/sh"),
+		ociimage.TestFile{Path: "etc/passwd", Contents: "root:x:0:0::/:/bin/sh\nnobody:x:65534:65534::/:/bin/sh\n"},
+		ociimage.TestFile{Path: "srv", Dir: true, Mode: 0777},
+	)
+	ociimage.WriteTestLayout(t, layout, "v1", v1.ImageConfig{
+		Entrypoint: []string{"/bin/sh", "-c"},
+		Cmd:        []string{`echo "$FOO $PATH $PWD"; read line < /local/hello; echo $line; echo written > /srv/out`},
+		Env:        []string{"FOO=image", "PATH=/bin"},
+		WorkingDir: "/srv",
+	}, base)
+
+	tmpDir := t.TempDir()
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:    allocID,
+		ID:         uuid.Generate(),
+		Name:       "image",
+		StdoutPath: filepath.Join(tmpDir, "stdout"),
+		StderrPath: filepath.Join(tmpDir, "stderr"),
+		Resources:  testResources(allocID, "image"),
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	require.NoError(t, os.WriteFile(task.StdoutPath, []byte{}, 0660))
+	require.NoError(t, os.WriteFile(task.StderrPath, []byte{}, 0660))
+	require.NoError(t, os.WriteFile(filepath.Join(task.TaskDir().LocalDir, "hello"), []byte("hello\n"), 0644))
+	require.NoError(t, task.EncodeConcreteDriverConfig(&TaskConfig{
+		Image: "oci:" + layout + ":v1",
+	}))
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+
+	select {
+	case res := <-waitCh:
+		stderr, _ := os.ReadFile(task.StderrPath)
+		require.True(t, res.Successful(), "stderr: %s", stderr)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+
+	stdout, err := os.ReadFile(task.StdoutPath)
+	require.NoError(t, err)
+	require.Equal(t, "image /bin /srv\nhello\n", string(stdout))
+
+	// Writes are kept in the task directory rather than the cached image
+	rootfs := imageRootfs(task)
+	out, err := os.ReadFile(filepath.Join(rootfs, "srv", "out"))
+	require.NoError(t, err)
+	require.Equal(t, "written\n", string(out))
+	require.FileExists(t, filepath.Join(task.TaskDir().Dir, imageDir, "upper", "srv", "out"))
+
+	// The image is unmounted when the task is destroyed
+	require.NoError(t, harness.DestroyTask(task.ID, true))
+	_, err = os.Stat(filepath.Join(rootfs, "srv"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -22,6 +22,7 @@ type taskHandle struct {
 	stateLock sync.RWMutex
 
 	taskConfig  *drivers.TaskConfig
+	imageDigest string
 	procState   drivers.TaskState
 	startedAt   time.Time
 	completedAt time.Time
@@ -0,0 +1,133 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	digest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// imageDir is the directory within the task directory holding the root
+// filesystem mounted from the task's image and the writable layer over it
+const imageDir = "image"
+
+// ImageAuth is the credentials used to pull a task's image.
+type ImageAuth struct {
+	Username string `codec:"username"`
+	Password string `codec:"password"`
+}
+
+// imageRootfs returns the path the task's image is mounted at.
+func imageRootfs(cfg *drivers.TaskConfig) string {
+	return filepath.Join(cfg.TaskDir().Dir, imageDir, "rootfs")
+}
+
+// mountImage pulls the task's image into the image cache, if needed, and
+// mounts it at the task's root filesystem. Writes by the task are kept in
+// the task directory and the cached layers are left untouched.
+func (d *Driver) mountImage(cfg *drivers.TaskConfig, tc *TaskConfig) (*ociimage.Image, error) {
+	if d.images == nil {
+		return nil, fmt.Errorf("image_cache_dir must be set in the exec plugin config to run images")
+	}
+
+	ref, err := ociimage.ParseReference(tc.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	// Local layouts, such as those downloaded by artifacts, are found relative
+	// to the task directory
+	if ref.IsLayout() && !filepath.IsAbs(ref.Layout) {
+		ref.Layout = filepath.Join(cfg.TaskDir().Dir, ref.Layout)
+	}
+
+	var auth *ociimage.Auth
+	if tc.Auth.Username != "" {
+		auth = &ociimage.Auth{
+			Username: tc.Auth.Username,
+			Password: tc.Auth.Password,
+		}
+	}
+
+	img, err := d.images.Acquire(d.ctx, ref, auth, tc.ForcePull, cfg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(cfg.TaskDir().Dir, imageDir)
+	err = ociimage.Mount(img, filepath.Join(dir, "upper"), filepath.Join(dir, "work"), imageRootfs(cfg))
+	if err != nil {
+		d.images.Release(img.Digest, cfg.ID)
+		return nil, err
+	}
+
+	d.logger.Debug("mounted task image", "image", tc.Image, "image_digest", img.Digest, "task_id", cfg.ID)
+	return img, nil
+}
+
+// unmountImage unmounts the task's root filesystem and releases its image
+// so that it can be removed from the cache once unused.
+func (d *Driver) unmountImage(cfg *drivers.TaskConfig, imageDigest string) {
+	if err := ociimage.Unmount(imageRootfs(cfg)); err != nil {
+		d.logger.Warn("failed to unmount task image", "error", err, "task_id", cfg.ID)
+	}
+	if d.images != nil {
+		d.images.Release(digest.Digest(imageDigest), cfg.ID)
+	}
+}
+
+// imageCommand returns the command and arguments of a task run from an
+// image. The task's command replaces the image's entrypoint and cmd, and
+// the task's args replace the image's cmd.
+func imageCommand(config v1.ImageConfig, command string, args []string) (string, []string, error) {
+	var argv []string
+	if command != "" {
+		argv = append([]string{command}, args...)
+	} else {
+		argv = append(argv, config.Entrypoint...)
+		if len(args) > 0 {
+			argv = append(argv, args...)
+		} else {
+			argv = append(argv, config.Cmd...)
+		}
+	}
+
+	if len(argv) == 0 {
+		return "", nil, fmt.Errorf("image has no entrypoint or cmd, the task must set a command")
+	}
+	return argv[0], argv[1:], nil
+}
+
+// imageEnv merges the environment of the image into the task's. Variables
+// set for the task take precedence, apart from those the task inherited
+// from the client's environment such as PATH, which the image's values
+// replace.
+func imageEnv(image []string, task map[string]string) []string {
+	env := make(map[string]string, len(image)+len(task))
+	for k, v := range task {
+		env[k] = v
+	}
+
+	for _, kv := range image {
+		k, v, _ := strings.Cut(kv, "=")
+		if taskValue, ok := task[k]; ok {
+			if hostValue, ok := os.LookupEnv(k); !ok || hostValue != taskValue {
+				continue
+			}
+		}
+		env[k] = v
+	}
+
+	list := make([]string, 0, len(env))
+	for k, v := range env {
+		list = append(list, k+"="+v)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,88 @@
+package exec
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	config := v1.ImageConfig{
+		Entrypoint: []string{"/entrypoint.sh", "--verbose"},
+		Cmd:        []string{"serve"},
+	}
+
+	cases := []struct {
+		name    string
+		config  v1.ImageConfig
+		command string
+		args    []string
+		cmd     string
+		argv    []string
+	}{
+		{
+			name:   "image defaults",
+			config: config,
+			cmd:    "/entrypoint.sh",
+			argv:   []string{"--verbose", "serve"},
+		},
+		{
+			name:   "args replace cmd",
+			config: config,
+			args:   []string{"migrate"},
+			cmd:    "/entrypoint.sh",
+			argv:   []string{"--verbose", "migrate"},
+		},
+		{
+			name:    "command replaces entrypoint",
+			config:  config,
+			command: "/bin/sh",
+			cmd:     "/bin/sh",
+			argv:    []string{},
+		},
+		{
+			name:   "cmd only",
+			config: v1.ImageConfig{Cmd: []string{"/bin/app", "run"}},
+			cmd:    "/bin/app",
+			argv:   []string{"run"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, argv, err := imageCommand(tc.config, tc.command, tc.args)
+			require.NoError(t, err)
+			require.Equal(t, tc.cmd, cmd)
+			require.Equal(t, tc.argv, argv)
+		})
+	}
+
+	_, _, err := imageCommand(v1.ImageConfig{}, "", nil)
+	require.EqualError(t, err, "image has no entrypoint or cmd, the task must set a command")
+}
+
+func TestImageEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	image := []string{"PATH=/opt/app/bin:/bin", "APP_MODE=image", "LANG=C.UTF-8"}
+	task := map[string]string{
+		// inherited from the client's environment
+		"PATH": os.Getenv("PATH"),
+
+		// set for the task
+		"APP_MODE":   "job",
+		"NOMAD_TASK": "web",
+	}
+
+	require.Equal(t, []string{
+		"APP_MODE=job",
+		"LANG=C.UTF-8",
+		"NOMAD_TASK=web",
+		"PATH=/opt/app/bin:/bin",
+	}, imageEnv(image, task))
+}
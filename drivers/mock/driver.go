@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -31,6 +33,10 @@ const (
 	// taskHandleVersion is the version of task handle which this driver sets
 	// and understands how to decode driver state
 	taskHandleVersion = 1
+
+	// checkpointFile is the file written to the checkpoint directory by
+	// CheckpointTask and required by RestoreTask
+	checkpointFile = "mock_checkpoint"
 )
 
 var (
@@ -153,6 +159,7 @@ func NewMockDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugi
 		Exec:         true,
 		FSIsolation:  drivers.FSIsolationNone,
 		MountConfigs: drivers.MountConfigSupportNone,
+		Checkpoint:   true,
 	}
 
 	return &Driver{
@@ -493,6 +500,34 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 
 }
 
+// CheckpointTask writes a marker file to path and stops the task.
+func (d *Driver) CheckpointTask(taskID string, path string) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, checkpointFile), []byte(taskID), 0600); err != nil {
+		return err
+	}
+
+	d.logger.Debug("checkpointed task", "task_name", h.taskConfig.Name, "path", path)
+	h.kill()
+	return nil
+}
+
+// RestoreTask starts the task after verifying the checkpoint written by
+// CheckpointTask exists at path.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, path string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, err := os.Stat(filepath.Join(path, checkpointFile)); err != nil {
+		return nil, nil, fmt.Errorf("invalid checkpoint: %v", err)
+	}
+	return d.StartTask(cfg)
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -623,6 +658,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
+var _ drivers.CheckpointDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreaming(ctx context.Context, taskID string, execOpts *drivers.ExecOptions) (*drivers.ExitResult, error) {
 	h, ok := d.tasks.Get(taskID)
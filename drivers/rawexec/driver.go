@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -82,6 +83,9 @@ var (
 			hclspec.NewAttr("no_cgroups", "bool", false),
 			hclspec.NewLiteral("false"),
 		),
+		"default_seccomp_profile": hclspec.NewAttr("default_seccomp_profile", "string", false),
+		"default_fs_allow_read":   hclspec.NewAttr("default_fs_allow_read", "list(string)", false),
+		"default_fs_allow_write":  hclspec.NewAttr("default_fs_allow_write", "list(string)", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
@@ -89,6 +93,10 @@ var (
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
 		"command": hclspec.NewAttr("command", "string", true),
 		"args":    hclspec.NewAttr("args", "list(string)", false),
+
+		"seccomp_profile": hclspec.NewAttr("seccomp_profile", "string", false),
+		"fs_allow_read":   hclspec.NewAttr("fs_allow_read", "list(string)", false),
+		"fs_allow_write":  hclspec.NewAttr("fs_allow_write", "list(string)", false),
 	})
 
 	// capabilities is returned by the Capabilities RPC and indicates what
@@ -138,12 +146,51 @@ type Config struct {
 
 	// Enabled is set to true to enable the raw_exec driver
 	Enabled bool `codec:"enabled"`
+
+	// DefaultSeccompProfile is the seccomp profile applied to tasks that do
+	// not set one: "default", "unconfined" or the absolute path of a JSON
+	// profile.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
+
+	// DefaultFSAllowRead is the list of host paths that tasks which do not
+	// set fs_allow_read may read.
+	DefaultFSAllowRead []string `codec:"default_fs_allow_read"`
+
+	// DefaultFSAllowWrite is the list of host paths that tasks which do not
+	// set fs_allow_write may write.
+	DefaultFSAllowWrite []string `codec:"default_fs_allow_write"`
+}
+
+func (c *Config) sandboxPolicy() *sandbox.Policy {
+	return &sandbox.Policy{
+		SeccompProfile: c.DefaultSeccompProfile,
+		FSAllowRead:    c.DefaultFSAllowRead,
+		FSAllowWrite:   c.DefaultFSAllowWrite,
+	}
 }
 
 // TaskConfig is the driver configuration of a task within a job
 type TaskConfig struct {
 	Command string   `codec:"command"`
 	Args    []string `codec:"args"`
+
+	// SeccompProfile is the seccomp profile applied to the task: "default",
+	// "unconfined" or the path of a JSON profile relative to the task dir.
+	SeccompProfile string `codec:"seccomp_profile"`
+
+	// FSAllowRead is the list of host paths that the task may read.
+	FSAllowRead []string `codec:"fs_allow_read"`
+
+	// FSAllowWrite is the list of host paths that the task may write.
+	FSAllowWrite []string `codec:"fs_allow_write"`
+}
+
+func (tc *TaskConfig) sandboxPolicy() *sandbox.Policy {
+	return &sandbox.Policy{
+		SeccompProfile: tc.SeccompProfile,
+		FSAllowRead:    tc.FSAllowRead,
+		FSAllowWrite:   tc.FSAllowWrite,
+	}
 }
 
 // TaskState is the state which is encoded in the handle returned in
@@ -184,6 +231,10 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 		}
 	}
 
+	if err := config.sandboxPolicy().ValidateDefaults(); err != nil {
+		return err
+	}
+
 	d.config = &config
 	if cfg.AgentConfig != nil {
 		d.nomadConfig = cfg.AgentConfig.Driver
@@ -229,6 +280,12 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		health = drivers.HealthStateHealthy
 		desc = drivers.DriverHealthy
 		attrs["driver.raw_exec"] = pstructs.NewBoolAttribute(true)
+		if sandbox.SeccompSupported() {
+			attrs["driver.raw_exec.seccomp"] = pstructs.NewBoolAttribute(true)
+		}
+		if sandbox.LandlockSupported() {
+			attrs["driver.raw_exec.landlock"] = pstructs.NewBoolAttribute(true)
+		}
 	} else {
 		health = drivers.HealthStateUndetected
 		desc = "disabled"
@@ -308,6 +365,14 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
 	}
 
+	sandboxConfig, err := d.config.sandboxPolicy().Resolve(driverConfig.sandboxPolicy(), cfg.TaskDir().Dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to configure sandbox: %v", err)
+	}
+	if err := sandboxConfig.CheckSupported(); err != nil {
+		return nil, nil, err
+	}
+
 	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
@@ -338,6 +403,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		StdoutPath:         cfg.StdoutPath,
 		StderrPath:         cfg.StderrPath,
 		NetworkIsolation:   cfg.NetworkIsolation,
+		Sandbox:            sandboxConfig,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	ctestutil "github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/testtask"
@@ -98,6 +99,26 @@ func TestRawExecDriver_SetConfig(t *testing.T) {
 	bconfig.PluginConfig = data
 	require.NoError(harness.SetConfig(bconfig))
 	require.Exactly(config, d.(*Driver).config)
+
+	// Set sandbox defaults.
+	config.DefaultSeccompProfile = "default"
+	config.DefaultFSAllowRead = []string{"/usr", "/lib"}
+	data = []byte{}
+	require.NoError(basePlug.MsgPackEncode(&data, config))
+	bconfig.PluginConfig = data
+	require.NoError(harness.SetConfig(bconfig))
+	require.Exactly(config, d.(*Driver).config)
+
+	// Reject a relative default seccomp profile.
+	invalid := *config
+	invalid.DefaultSeccompProfile = "profile.json"
+	data = []byte{}
+	require.NoError(basePlug.MsgPackEncode(&data, &invalid))
+	bconfig.PluginConfig = data
+	err := harness.SetConfig(bconfig)
+	require.Error(err)
+	require.Contains(err.Error(), "must be absolute")
+	require.Exactly(config, d.(*Driver).config)
 }
 
 func TestRawExecDriver_Fingerprint(t *testing.T) {
@@ -128,6 +149,14 @@ func TestRawExecDriver_Fingerprint(t *testing.T) {
 		}
 	}
 
+	enabledAttrs := map[string]*pstructs.Attribute{"driver.raw_exec": pstructs.NewBoolAttribute(true)}
+	if sandbox.SeccompSupported() {
+		enabledAttrs["driver.raw_exec.seccomp"] = pstructs.NewBoolAttribute(true)
+	}
+	if sandbox.LandlockSupported() {
+		enabledAttrs["driver.raw_exec.landlock"] = pstructs.NewBoolAttribute(true)
+	}
+
 	cases := []struct {
 		Name     string
 		Conf     Config
@@ -150,7 +179,7 @@ func TestRawExecDriver_Fingerprint(t *testing.T) {
 				Enabled: true,
 			},
 			Expected: drivers.Fingerprint{
-				Attributes:        map[string]*pstructs.Attribute{"driver.raw_exec": pstructs.NewBoolAttribute(true)},
+				Attributes:        enabledAttrs,
 				Health:            drivers.HealthStateHealthy,
 				HealthDescription: drivers.DriverHealthy,
 			},
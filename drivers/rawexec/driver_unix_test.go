@@ -18,6 +18,7 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	clienttestutil "github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	"github.com/hashicorp/nomad/helper/testtask"
 	"github.com/hashicorp/nomad/helper/uuid"
 	basePlug "github.com/hashicorp/nomad/plugins/base"
@@ -442,3 +443,100 @@ func TestRawExecDriver_NoCgroup(t *testing.T) {
 		return true, nil
 	}, func(err error) { require.NoError(t, err) })
 }
+
+func TestRawExecDriver_Sandbox(t *testing.T) {
+	ci.Parallel(t)
+
+	if !sandbox.LandlockSupported() {
+		t.Skip("landlock is not supported")
+	}
+
+	libs := []string{"/bin", "/lib", "/lib64", "/usr", "/etc/ld.so.cache"}
+
+	for _, tc := range []struct {
+		Name       string
+		Config     *TaskConfig
+		Successful bool
+		Stderr     string
+	}{
+		{
+			Name: "seccomp default",
+			Config: &TaskConfig{
+				Command:        "/bin/sh",
+				Args:           []string{"-c", "echo ok"},
+				SeccompProfile: "default",
+			},
+			Successful: true,
+		},
+		{
+			Name: "fs allowed",
+			Config: &TaskConfig{
+				Command:     "/bin/sh",
+				Args:        []string{"-c", "echo ok > ../alloc/ok.txt && echo ok > local/ok.txt"},
+				FSAllowRead: libs,
+			},
+			Successful: true,
+		},
+		{
+			// cat is dynamically linked, so it needs the loader and
+			// shared libraries even if the task allows nothing
+			Name: "fs allows nothing",
+			Config: &TaskConfig{
+				Command:     "/bin/cat",
+				Args:        []string{"/dev/null"},
+				FSAllowRead: []string{},
+			},
+			Successful: true,
+		},
+		{
+			Name: "fs denied",
+			Config: &TaskConfig{
+				Command:     "/bin/cat",
+				Args:        []string{"/etc/passwd"},
+				FSAllowRead: []string{},
+			},
+			Stderr: "Permission denied",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			d := newEnabledRawExecDriver(t)
+			harness := dtestutil.NewDriverHarness(t, d)
+			defer harness.Kill()
+
+			tmpDir := t.TempDir()
+			task := &drivers.TaskConfig{
+				AllocID:    uuid.Generate(),
+				ID:         uuid.Generate(),
+				Name:       "sandbox",
+				StdoutPath: filepath.Join(tmpDir, "stdout"),
+				StderrPath: filepath.Join(tmpDir, "stderr"),
+			}
+			cleanup := harness.MkAllocDir(task, false)
+
+			require.NoError(t, os.WriteFile(task.StdoutPath, []byte{}, 0660))
+			require.NoError(t, os.WriteFile(task.StderrPath, []byte{}, 0660))
+			defer cleanup()
+
+			require.NoError(t, task.EncodeConcreteDriverConfig(tc.Config))
+
+			_, _, err := harness.StartTask(task)
+			require.NoError(t, err)
+			defer harness.DestroyTask(task.ID, true)
+
+			waitCh, err := harness.WaitTask(context.Background(), task.ID)
+			require.NoError(t, err)
+
+			var res *drivers.ExitResult
+			select {
+			case res = <-waitCh:
+			case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+				require.Fail(t, "timeout waiting for task")
+			}
+
+			stderr, err := os.ReadFile(task.StderrPath)
+			require.NoError(t, err)
+			require.Equal(t, tc.Successful, res.Successful(), "stderr: %s", stderr)
+			require.Contains(t, string(stderr), tc.Stderr)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/nomad/client/lib/resources"
 	"github.com/hashicorp/nomad/client/stats"
 	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	shelpers "github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/syndtr/gocapability/capability"
@@ -47,6 +48,7 @@ var (
 	// The statistics the basic executor exposes
 	ExecutorBasicMeasuredMemStats = []string{"RSS", "Swap"}
 	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent"}
+	ExecutorBasicMeasuredIOStats  = []string{"Read Bytes", "Write Bytes"}
 )
 
 // Executor is the interface which allows a driver to launch and supervise
@@ -88,6 +90,12 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Checkpoint dumps the state of the user process to the given directory
+	// and stops it. The process may later be resumed by launching a command
+	// with RestorePath set to the same directory. Executors that cannot
+	// checkpoint processes return an error.
+	Checkpoint(path string) error
 }
 
 // ExecCommand holds the user command, args, and other isolation related
@@ -154,6 +162,29 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// RestorePath is the directory of a checkpoint previously taken with
+	// Executor.Checkpoint. When set, the process is restored from the
+	// checkpoint instead of being started from Cmd.
+	RestorePath string
+
+	// Sandbox restricts the syscalls and filesystem paths available to the
+	// task. The task directory, command binary and standard device nodes are
+	// added to the filesystem allow-lists by the executor.
+	Sandbox *sandbox.Config
+
+	// UserNamespace is the range of host IDs to map into a user namespace
+	// for the task. It is only supported by the isolating executor.
+	UserNamespace *drivers.UserNamespaceSpec
+
+	// Rootfs is the host path of the root filesystem of the task, used by
+	// the isolating executor in place of the task directory. The task's
+	// alloc, local and secrets directories are mounted into it.
+	Rootfs string
+
+	// WorkDir is the working directory of the task process within its root
+	// filesystem. It is only supported by the isolating executor.
+	WorkDir string
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -283,6 +314,10 @@ func (e *UniversalExecutor) Version() (*ExecutorVersion, error) {
 func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
 	e.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
+	if command.RestorePath != "" {
+		return nil, fmt.Errorf("restoring from a checkpoint is not supported by the universal executor")
+	}
+
 	e.commandCfg = command
 
 	// setting the user of the process
@@ -339,6 +374,13 @@ func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error)
 	e.childCmd.Args = append([]string{e.childCmd.Path}, command.Args...)
 	e.childCmd.Env = e.commandCfg.Env
 
+	// Run the command through the sandbox shim if it is restricted
+	if !command.Sandbox.Empty() {
+		if err := e.configureSandbox(absPath); err != nil {
+			return nil, err
+		}
+	}
+
 	// Start the process
 	if err = withNetworkIsolation(e.childCmd.Start, command.NetworkIsolation); err != nil {
 		return nil, fmt.Errorf("failed to start command path=%q --- args=%q: %v", path, e.childCmd.Args, err)
@@ -611,6 +653,11 @@ func (e *UniversalExecutor) Signal(s os.Signal) error {
 	return nil
 }
 
+// Checkpoint is not supported by the universal executor
+func (e *UniversalExecutor) Checkpoint(path string) error {
+	return fmt.Errorf("checkpoint not supported by the universal executor")
+}
+
 func (e *UniversalExecutor) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	ch := make(chan *cstructs.TaskResourceUsage)
 	go e.handleStats(ch, ctx, interval)
@@ -3,7 +3,9 @@
 package executor
 
 import (
+	"fmt"
 	"os/exec"
+	"runtime"
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/resources"
@@ -18,6 +20,10 @@ func NewExecutorWithIsolation(logger hclog.Logger) Executor {
 
 func (e *UniversalExecutor) configureResourceContainer(_ int) error { return nil }
 
+func (e *UniversalExecutor) configureSandbox(_ string) error {
+	return fmt.Errorf("sandboxing is not supported on %s", runtime.GOOS)
+}
+
 func (e *UniversalExecutor) getAllPids() (resources.PIDs, error) {
 	return getAllPidsByScanning()
 }
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/armon/circbuf"
+	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocdir"
@@ -48,6 +49,9 @@ var (
 
 	// ExecutorCgroupMeasuredCpuStats is the list of CPU stats captures by the executor
 	ExecutorCgroupMeasuredCpuStats = []string{"System Mode", "User Mode", "Throttled Periods", "Throttled Time", "Percent"}
+
+	// ExecutorCgroupMeasuredIOStats is the list of block I/O stats captured by the executor
+	ExecutorCgroupMeasuredIOStats = []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"}
 )
 
 // LibcontainerExecutor implements an Executor with the runc/libcontainer api
@@ -121,7 +125,12 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.container = container
 
 	// Look up the binary path and make it executable
-	absPath, err := lookupTaskBin(command)
+	var absPath, path string
+	if command.Rootfs != "" {
+		absPath, path, err = lookupRootfsBin(command)
+	} else {
+		absPath, err = lookupTaskBin(command)
+	}
 
 	if err != nil {
 		return nil, err
@@ -131,22 +140,39 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, err
 	}
 
-	path := absPath
+	if command.Rootfs == "" {
+		// Ensure that the path is contained in the chroot, and find it relative to the container
+		rel, err := filepath.Rel(command.TaskDir, absPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine relative path base=%q target=%q: %v", command.TaskDir, absPath, err)
+		}
 
-	// Ensure that the path is contained in the chroot, and find it relative to the container
-	rel, err := filepath.Rel(command.TaskDir, path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine relative path base=%q target=%q: %v", command.TaskDir, path, err)
+		// Turn relative-to-chroot path into absolute path to avoid
+		// libcontainer trying to resolve the binary using $PATH.
+		// Do *not* use filepath.Join as it will translate ".."s returned by
+		// filepath.Rel. Prepending "/" will cause the path to be rooted in the
+		// chroot which is the desired behavior.
+		path = "/" + rel
 	}
 
-	// Turn relative-to-chroot path into absolute path to avoid
-	// libcontainer trying to resolve the binary using $PATH.
-	// Do *not* use filepath.Join as it will translate ".."s returned by
-	// filepath.Rel. Prepending "/" will cause the path to be rooted in the
-	// chroot which is the desired behavior.
-	path = "/" + rel
-
 	combined := append([]string{path}, command.Args...)
+
+	// Run the command through the sandbox shim, mounted into the chroot by
+	// configureIsolation, if it is restricted
+	if !command.Sandbox.Empty() {
+		taskDirs := []string{
+			allocdir.SharedAllocContainerPath,
+			allocdir.TaskLocalContainerPath,
+			allocdir.TaskSecretsContainerPath,
+			"/tmp",
+		}
+		combined, err = sandboxShimArgs(sandboxShimPath, command.Sandbox, path, command.Args, taskDirs)
+		if err != nil {
+			container.Destroy()
+			return nil, err
+		}
+	}
+
 	stdout, err := command.Stdout()
 	if err != nil {
 		return nil, err
@@ -164,6 +190,7 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		Env:    command.Env,
 		Stdout: stdout,
 		Stderr: stderr,
+		Cwd:    command.WorkDir,
 		Init:   true,
 	}
 
@@ -176,8 +203,14 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.userCpuStats = stats.NewCpuStats()
 	l.systemCpuStats = stats.NewCpuStats()
 
-	// Starts the task
-	if err := container.Run(process); err != nil {
+	// Starts the task, resuming it from a checkpoint if one was given
+	if command.RestorePath != "" {
+		l.logger.Debug("restoring from checkpoint", "path", command.RestorePath)
+		err = container.Restore(process, criuOpts(command.RestorePath))
+	} else {
+		err = container.Run(process)
+	}
+	if err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -369,6 +402,7 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 		}
 
 		ts := time.Now()
+		io := stats.CgroupIOCounters(&lstats.CgroupStats.BlkioStats)
 		stats := lstats.CgroupStats
 
 		// Memory Related Stats
@@ -404,10 +438,21 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 			TotalTicks:       l.systemCpuStats.TicksConsumed(totalPercent),
 			Measured:         ExecutorCgroupMeasuredCpuStats,
 		}
+
+		// Block I/O Related Stats
+		is := &cstructs.IOStats{
+			ReadBytes:  io.ReadBytes,
+			WriteBytes: io.WriteBytes,
+			ReadOps:    io.ReadOps,
+			WriteOps:   io.WriteOps,
+			Measured:   ExecutorCgroupMeasuredIOStats,
+		}
+
 		taskResUsage := cstructs.TaskResourceUsage{
 			ResourceUsage: &cstructs.ResourceUsage{
 				MemoryStats: ms,
 				CpuStats:    cs,
+				IOStats:     is,
 			},
 			Timestamp: ts.UTC().UnixNano(),
 			Pids:      pidStats,
@@ -422,6 +467,32 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 	}
 }
 
+// Checkpoint dumps the container's processes to path using criu(8). The
+// processes are stopped once the checkpoint has been written, which causes
+// any pending Wait calls to return.
+func (l *LibcontainerExecutor) Checkpoint(path string) error {
+	if l.container == nil {
+		return fmt.Errorf("container not started")
+	}
+
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint dir: %v", err)
+	}
+
+	l.logger.Debug("checkpointing container", "path", path)
+	return l.container.Checkpoint(criuOpts(path))
+}
+
+// criuOpts returns the options used for both checkpointing to and restoring
+// from the criu images in the given directory.
+func criuOpts(path string) *libcontainer.CriuOpts {
+	return &libcontainer.CriuOpts{
+		ImagesDirectory: path,
+		WorkDirectory:   path,
+		FileLocks:       true,
+	}
+}
+
 // Signal sends a signal to the process managed by the executor
 func (l *LibcontainerExecutor) Signal(s os.Signal) error {
 	return l.userProc.Signal(s)
@@ -486,12 +557,17 @@ func (l *LibcontainerExecutor) ExecStreaming(ctx context.Context, cmd []string,
 	stream drivers.ExecTaskStream) error {
 
 	// the task process will be started by the container
+	cwd := "/"
+	if l.command.WorkDir != "" {
+		cwd = l.command.WorkDir
+	}
+
 	process := &libcontainer.Process{
 		Args: cmd,
 		Env:  l.userProc.Env,
 		User: l.userProc.User,
 		Init: false,
-		Cwd:  "/",
+		Cwd:  cwd,
 	}
 
 	execHelper := &execHelper{
@@ -573,6 +649,9 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 
 	// set the new root directory for the container
 	cfg.Rootfs = command.TaskDir
+	if command.Rootfs != "" {
+		cfg.Rootfs = command.Rootfs
+	}
 
 	// disable pivot_root if set in the driver's configuration
 	cfg.NoPivotRoot = command.NoPivotRoot
@@ -587,6 +666,19 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		})
 	}
 
+	// map the task's users into the range of host IDs allocated to the alloc
+	if uns := command.UserNamespace; uns != nil {
+		// proc and mqueue can only be mounted in a user namespace that owns
+		// the PID and IPC namespaces
+		if command.ModePID != IsolationModePrivate || command.ModeIPC != IsolationModePrivate {
+			return fmt.Errorf("user namespaces require private PID and IPC modes")
+		}
+		cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+		mappings := []lconfigs.IDMap{{ContainerID: 0, HostID: int(uns.HostID), Size: int(uns.Size)}}
+		cfg.UidMappings = mappings
+		cfg.GidMappings = mappings
+	}
+
 	// paths to mask using a bind mount to /dev/null to prevent reading
 	cfg.MaskPaths = []string{
 		"/proc/kcore",
@@ -649,10 +741,53 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		},
 	}
 
+	// sysfs can only be mounted in a user namespace that owns the network
+	// namespace, so bind mount the host's instead
+	if command.UserNamespace != nil {
+		for _, m := range cfg.Mounts {
+			if m.Device == "sysfs" {
+				m.Source = "/sys"
+				m.Device = "bind"
+				m.Flags |= syscall.MS_BIND | syscall.MS_REC
+			}
+		}
+	}
+
+	// the task's directories are only found in the task directory, so
+	// mount them into a separate root filesystem
+	if command.Rootfs != "" {
+		taskDirs := [][2]string{
+			{filepath.Join(filepath.Dir(command.TaskDir), allocdir.SharedAllocName), allocdir.SharedAllocContainerPath},
+			{filepath.Join(command.TaskDir, allocdir.TaskLocal), allocdir.TaskLocalContainerPath},
+			{filepath.Join(command.TaskDir, allocdir.TaskSecrets), allocdir.TaskSecretsContainerPath},
+		}
+		for _, dir := range taskDirs {
+			cfg.Mounts = append(cfg.Mounts, &lconfigs.Mount{
+				Source:      dir[0],
+				Destination: dir[1],
+				Device:      "bind",
+				Flags:       unix.MS_BIND | unix.MS_REC,
+			})
+		}
+	}
+
 	if len(command.Mounts) > 0 {
 		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
 	}
 
+	if !command.Sandbox.Empty() {
+		shim, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to find sandbox shim: %v", err)
+		}
+		cfg.Mounts = append(cfg.Mounts, &lconfigs.Mount{
+			Source:      shim,
+			Destination: sandboxShimPath,
+			Device:      "bind",
+			Flags:       unix.MS_BIND | unix.MS_RDONLY,
+		})
+	}
+
 	return nil
 }
 
@@ -709,6 +844,13 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
 	cfg.Cgroups.Resources.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
 
+	// Set the block I/O weight and device throttles
+	if command.Resources.LinuxResources != nil {
+		if err := cgutil.ConfigureBlkio(cfg.Cgroups.Resources, command.Resources.LinuxResources.IO); err != nil {
+			return err
+		}
+	}
+
 	if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
 		cfg.Hooks = lconfigs.Hooks{
 			lconfigs.CreateRuntime: lconfigs.HookList{
@@ -834,6 +976,55 @@ func lookupTaskBin(command *ExecCommand) (string, error) {
 	return lookPathIn(path, taskDir, bin)
 }
 
+// lookupRootfsBin finds the file `bin` in taskDir/local, then at its path
+// within the task's root filesystem or by searching the PATH of the task's
+// environment inside it. Symlinks are resolved within the root filesystem.
+// It returns the absolute path of the file on the host and its path within
+// the container.
+func lookupRootfsBin(command *ExecCommand) (string, string, error) {
+	bin := command.Cmd
+
+	// Check in the local directory, which is mounted into the root filesystem
+	localDir := filepath.Join(command.TaskDir, allocdir.TaskLocal)
+	if local, err := securejoin.SecureJoin(localDir, bin); err == nil {
+		if fi, err := os.Stat(local); err == nil && !fi.IsDir() {
+			rel, err := filepath.Rel(localDir, local)
+			if err != nil {
+				return "", "", err
+			}
+			return local, filepath.Join(allocdir.TaskLocalContainerPath, rel), nil
+		}
+	}
+
+	var paths []string
+	if strings.Contains(bin, "/") {
+		paths = []string{filepath.Join("/", bin)}
+	} else {
+		path := "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+		for _, env := range command.Env {
+			if strings.HasPrefix(env, "PATH=") {
+				path = strings.TrimPrefix(env, "PATH=")
+			}
+		}
+		for _, dir := range filepath.SplitList(path) {
+			if filepath.IsAbs(dir) {
+				paths = append(paths, filepath.Join(dir, bin))
+			}
+		}
+	}
+
+	for _, p := range paths {
+		host, err := securejoin.SecureJoin(command.Rootfs, p)
+		if err != nil {
+			continue
+		}
+		if fi, err := os.Stat(host); err == nil && !fi.IsDir() {
+			return host, p, nil
+		}
+	}
+	return "", "", fmt.Errorf("file %s not found in the task's root filesystem", bin)
+}
+
 // lookPathIn looks for a file with PATH inside the directory root. Like exec.LookPath
 func lookPathIn(path string, root string, bin string) (string, error) {
 	// exec.LookPath(file string)
@@ -5,21 +5,26 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
+	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	tu "github.com/hashicorp/nomad/testutil"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
@@ -323,6 +328,62 @@ func TestExecutor_CgroupPaths(t *testing.T) {
 	}, func(err error) { t.Error(err) })
 }
 
+func TestExecutor_BlkioLimits(t *testing.T) {
+	ci.Parallel(t)
+	testutil.ExecCompatible(t)
+	testutil.CgroupsCompatibleV1(t)
+
+	require := require.New(t)
+
+	// throttle a loop device, which every test host has
+	var st unix.Stat_t
+	if err := unix.Stat("/dev/loop0", &st); err != nil {
+		t.Skipf("no loop device: %v", err)
+	}
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/bash"
+	execCmd.Args = []string{"-c", "sleep 9000"}
+	defer allocDir.Destroy()
+
+	execCmd.ResourceLimits = true
+	execCmd.Resources.LinuxResources = &drivers.LinuxResources{
+		IO: &structs.IOResources{
+			Devices: []*structs.IODeviceLimit{{Path: "/dev/loop0", WriteBps: 1048576}},
+		},
+	}
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	ps, err := executor.Launch(execCmd)
+	require.NoError(err)
+	require.NotZero(ps.Pid)
+
+	subsystems, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", ps.Pid))
+	require.NoError(err)
+	path, err := cgutil.GetCgroupPathHelperV1("blkio", subsystems["blkio"])
+	require.NoError(err)
+
+	limit, err := cgroups.ReadFile(path, "blkio.throttle.write_bps_device")
+	require.NoError(err)
+	require.Equal(fmt.Sprintf("%d:%d 1048576\n", unix.Major(st.Rdev), unix.Minor(st.Rdev)), limit)
+
+	// block I/O is reported with the task stats
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := executor.Stats(ctx, time.Second)
+	require.NoError(err)
+	select {
+	case ru := <-ch:
+		require.NotNil(ru.ResourceUsage.IOStats)
+		require.Equal(ExecutorCgroupMeasuredIOStats, ru.ResourceUsage.IOStats.Measured)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for stats")
+	}
+}
+
 // TestExecutor_CgroupPaths asserts that all cgroups created for a task
 // are destroyed on shutdown
 func TestExecutor_CgroupPathsAreDestroyed(t *testing.T) {
@@ -451,6 +512,47 @@ func TestUniversalExecutor_LookupTaskBin(t *testing.T) {
 	require.Error(err)
 }
 
+func TestExecutor_LookupRootfsBin(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	rootfs := t.TempDir()
+	cmd := &ExecCommand{Env: []string{"PATH=/usr/bin:/bin"}, TaskDir: taskDir, Rootfs: rootfs}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(rootfs, "bin"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(rootfs, "usr/bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootfs, "bin/busybox"), []byte{1, 2}, 0755))
+
+	// Absolute symlinks are resolved within the root filesystem
+	require.NoError(t, os.Symlink("/bin/busybox", filepath.Join(rootfs, "usr/bin/app")))
+
+	cmd.Cmd = "app"
+	host, path, err := lookupRootfsBin(cmd)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(rootfs, "bin/busybox"), host)
+	require.Equal(t, "/usr/bin/app", path)
+
+	cmd.Cmd = "/bin/busybox"
+	host, path, err = lookupRootfsBin(cmd)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(rootfs, "bin/busybox"), host)
+	require.Equal(t, "/bin/busybox", path)
+
+	// Files in the local directory are found at their mount point
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "local"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "local/app"), []byte{1, 2}, 0755))
+	cmd.Cmd = "app"
+	host, path, err = lookupRootfsBin(cmd)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(taskDir, "local/app"), host)
+	require.Equal(t, "/local/app", path)
+
+	// Host binaries outside the root filesystem are not found
+	cmd.Cmd = "sh"
+	_, _, err = lookupRootfsBin(cmd)
+	require.Error(t, err)
+}
+
 // Exec Launch looks for the binary only inside the chroot
 func TestExecutor_EscapeContainer(t *testing.T) {
 	ci.Parallel(t)
@@ -782,3 +884,219 @@ func TestUniversalExecutor_NoCgroup(t *testing.T) {
 	})
 
 }
+
+func TestUniversalExecutor_Checkpoint_Unsupported(t *testing.T) {
+	ci.Parallel(t)
+
+	testExecCmd := testExecutorCommand(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	defer allocDir.Destroy()
+	execCmd.Cmd = "/bin/sleep"
+	execCmd.Args = []string{"1"}
+	execCmd.RestorePath = filepath.Join(execCmd.TaskDir, "checkpoint")
+
+	executor := NewExecutor(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+
+	require.Error(t, executor.Checkpoint(execCmd.RestorePath))
+}
+
+func TestExecutor_Checkpoint_Restore(t *testing.T) {
+	ci.Parallel(t)
+	testutil.ExecCompatible(t)
+	if _, err := exec.LookPath("criu"); err != nil {
+		t.Skip("criu not found")
+	}
+
+	testExecCmd := testExecutorCommand(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	defer allocDir.Destroy()
+	execCmd.Cmd = "/bin/sleep"
+	execCmd.Args = []string{"30"}
+	libcontainerFactory.configureExecCmd(t, execCmd)
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	require.NoError(t, err)
+
+	// Checkpointing stops the process
+	checkpointDir := filepath.Join(execCmd.TaskDir, allocdir.TaskLocal, allocdir.TaskCheckpoint)
+	require.NoError(t, executor.Checkpoint(checkpointDir))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = executor.Wait(ctx)
+	require.NoError(t, err)
+
+	// Restore the process into a new container
+	restored := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer restored.Shutdown("SIGKILL", 0)
+
+	execCmd.RestorePath = checkpointDir
+	ps, err := restored.Launch(execCmd)
+	require.NoError(t, err)
+	require.NotZero(t, ps.Pid)
+	require.NoError(t, restored.Shutdown("SIGKILL", 0))
+}
+
+func TestExecutor_Sandbox(t *testing.T) {
+	ci.Parallel(t)
+	testutil.ExecCompatible(t)
+
+	if !sandbox.LandlockSupported() {
+		t.Skip("landlock is not supported")
+	}
+
+	denyMkdir := &sandbox.SeccompProfile{
+		DefaultAction: sandbox.ActAllow,
+		Syscalls: []*sandbox.SeccompSyscall{{
+			Names:  []string{"mkdir", "mkdirat"},
+			Action: sandbox.ActErrno,
+		}},
+	}
+	cases := map[string]struct {
+		command     func(t *testing.T) *testExecCmd
+		newExecutor func(logger hclog.Logger) Executor
+		local       func(cmd *ExecCommand) string // task local dir as seen by the task
+	}{
+		"UniversalExecutor": {
+			command:     testExecutorCommand,
+			newExecutor: NewExecutor,
+			local: func(cmd *ExecCommand) string {
+				return filepath.Join(cmd.TaskDir, allocdir.TaskLocal)
+			},
+		},
+		"LibcontainerExecutor": {
+			command: func(t *testing.T) *testExecCmd {
+				testExecCmd := testExecutorCommandWithChroot(t)
+				setupRootfsBinary(t, testExecCmd.command.TaskDir, "/bin/mkdir")
+				return testExecCmd
+			},
+			newExecutor: NewExecutorWithIsolation,
+			local: func(*ExecCommand) string {
+				return allocdir.TaskLocalContainerPath
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// run executes cmd with file, a path in the task local dir
+			// containing "hello", appended to its args
+			run := func(t *testing.T, c *sandbox.Config, cmd, file string) (int, string, string) {
+				testExecCmd := tc.command(t)
+				execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+				defer allocDir.Destroy()
+				executor := tc.newExecutor(testlog.HCLogger(t))
+				defer executor.Shutdown("SIGKILL", 0)
+
+				require.NoError(t, ioutil.WriteFile(
+					filepath.Join(execCmd.TaskDir, allocdir.TaskLocal, "hello.txt"), []byte("hello"), 0644))
+
+				if !filepath.IsAbs(file) {
+					file = filepath.Join(tc.local(execCmd), file)
+				}
+				execCmd.Cmd = cmd
+				execCmd.Args = []string{file}
+				execCmd.Sandbox = c
+
+				_, err := executor.Launch(execCmd)
+				require.NoError(t, err)
+				ps, err := executor.Wait(context.Background())
+				require.NoError(t, err)
+				require.NoError(t, executor.Shutdown("", 0))
+				testExecCmd.outputCopyDone.Wait()
+
+				return ps.ExitCode, testExecCmd.stdout.String(), testExecCmd.stderr.String()
+			}
+
+			t.Run("seccomp", func(t *testing.T) {
+				code, _, stderr := run(t, &sandbox.Config{Seccomp: denyMkdir},
+					"/bin/mkdir", "foo")
+				require.NotZero(t, code)
+				require.Contains(t, stderr, "Operation not permitted")
+			})
+
+			// cat is dynamically linked, so it only runs if the loader
+			// and shared libraries are allowed by default
+			t.Run("allowed path", func(t *testing.T) {
+				code, stdout, stderr := run(t, &sandbox.Config{FSRestricted: true},
+					"/bin/cat", "hello.txt")
+				require.Zero(t, code, "stderr: %s", stderr)
+				require.Equal(t, "hello", stdout)
+			})
+
+			t.Run("denied path", func(t *testing.T) {
+				code, _, stderr := run(t, &sandbox.Config{FSRestricted: true},
+					"/bin/cat", "/etc/passwd")
+				require.NotZero(t, code)
+				require.Contains(t, stderr, "Permission denied")
+			})
+		})
+	}
+}
+
+func TestExecutor_UserNamespace(t *testing.T) {
+	ci.Parallel(t)
+	testutil.ExecCompatible(t)
+	r := require.New(t)
+
+	uns := &drivers.UserNamespaceSpec{HostID: 1000000, Size: 65536}
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	defer allocDir.Destroy()
+
+	// the client gives root in the namespace ownership of the task dirs
+	for _, td := range allocDir.TaskDirs {
+		r.NoError(td.Chown(int(uns.HostID), int(uns.HostID)))
+	}
+
+	execCmd.Cmd = "/bin/bash"
+	execCmd.Args = []string{"-c", "cat /proc/self/uid_map /proc/self/gid_map && echo hello > /local/hello.txt"}
+	execCmd.UserNamespace = uns
+	execCmd.ModePID = "private"
+	execCmd.ModeIPC = "private"
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	r.NoError(err)
+	ps, err := executor.Wait(context.Background())
+	r.NoError(err)
+	r.NoError(executor.Shutdown("", 0))
+	testExecCmd.outputCopyDone.Wait()
+	r.Zero(ps.ExitCode, "stderr: %s", testExecCmd.stderr.String())
+
+	fields := strings.Fields(testExecCmd.stdout.String())
+	r.Equal([]string{"0", "1000000", "65536", "0", "1000000", "65536"}, fields)
+
+	// files created by root in the namespace are owned by the mapped ID
+	fi, err := os.Stat(filepath.Join(execCmd.TaskDir, allocdir.TaskLocal, "hello.txt"))
+	r.NoError(err)
+	stat := fi.Sys().(*syscall.Stat_t)
+	r.Equal(uns.HostID, stat.Uid)
+	r.Equal(uns.HostID, stat.Gid)
+}
+
+func TestExecutor_UserNamespace_HostModes(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, mode := range []struct{ pid, ipc string }{{"host", "private"}, {"private", "host"}} {
+		cfg := &lconfigs.Config{}
+		err := configureIsolation(cfg, &ExecCommand{
+			TaskDir:       t.TempDir(),
+			ModePID:       mode.pid,
+			ModeIPC:       mode.ipc,
+			UserNamespace: &drivers.UserNamespaceSpec{HostID: 1000000, Size: 65536},
+		})
+		require.EqualError(t, err, "user namespaces require private PID and IPC modes")
+	}
+}
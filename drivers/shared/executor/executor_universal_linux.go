@@ -2,6 +2,7 @@ package executor
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"syscall"
 
 	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/lib/resources"
 	"github.com/hashicorp/nomad/client/taskenv"
@@ -66,6 +68,29 @@ func setCmdUser(cmd *exec.Cmd, userid string) error {
 	return nil
 }
 
+// configureSandbox runs the task command bin through the sandbox shim, which
+// applies the task's sandbox config before executing it. The task may always
+// write to its own task directory and the shared alloc directory.
+func (e *UniversalExecutor) configureSandbox(bin string) error {
+	shim, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find sandbox shim: %v", err)
+	}
+
+	taskDirs := []string{
+		e.commandCfg.TaskDir,
+		filepath.Join(filepath.Dir(e.commandCfg.TaskDir), allocdir.SharedAllocName),
+	}
+	args, err := sandboxShimArgs(shim, e.commandCfg.Sandbox, bin, e.commandCfg.Args, taskDirs)
+	if err != nil {
+		return err
+	}
+
+	e.childCmd.Path = shim
+	e.childCmd.Args = args
+	return nil
+}
+
 // configureResourceContainer configured the cgroups to be used to track pids
 // created by the executor
 func (e *UniversalExecutor) configureResourceContainer(pid int) error {
@@ -80,6 +105,13 @@ func (e *UniversalExecutor) configureResourceContainer(pid int) error {
 		cfg.Cgroups.Resources.Devices = append(cfg.Cgroups.Resources.Devices, &device.Rule)
 	}
 
+	// block I/O is limited whenever the task is placed in a cgroup
+	if res := e.commandCfg.Resources; res != nil && res.LinuxResources != nil {
+		if err := cgutil.ConfigureBlkio(cfg.Cgroups.Resources, res.LinuxResources.IO); err != nil {
+			return err
+		}
+	}
+
 	lookup := func(env []string, name string) (result string) {
 		for _, s := range env {
 			if strings.HasPrefix(s, name+"=") {
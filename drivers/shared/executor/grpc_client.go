@@ -50,6 +50,13 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultPidMode:     cmd.ModePID,
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
+		RestorePath:        cmd.RestorePath,
+		UserNamespace:      drivers.UserNamespaceSpecToProto(cmd.UserNamespace),
+		Rootfs:             cmd.Rootfs,
+		WorkDir:            cmd.WorkDir,
+	}
+	if err := sandboxToProto(req, cmd.Sandbox); err != nil {
+		return nil, err
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
@@ -93,6 +100,16 @@ func (c *grpcExecutorClient) Shutdown(signal string, gracePeriod time.Duration)
 	return nil
 }
 
+func (c *grpcExecutorClient) Checkpoint(path string) error {
+	ctx := context.Background()
+	req := &proto.CheckpointRequest{Path: path}
+	if _, err := c.client.Checkpoint(ctx, req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c *grpcExecutorClient) UpdateResources(r *drivers.Resources) error {
 	ctx := context.Background()
 	req := &proto.UpdateResourcesRequest{Resources: drivers.ResourcesToProto(r)}
@@ -20,6 +20,11 @@ type grpcExecutorServer struct {
 }
 
 func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchRequest) (*proto.LaunchResponse, error) {
+	sandboxConfig, err := sandboxFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+
 	ps, err := s.impl.Launch(&ExecCommand{
 		Cmd:                req.Cmd,
 		Args:               req.Args,
@@ -38,6 +43,11 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
+		RestorePath:        req.RestorePath,
+		Sandbox:            sandboxConfig,
+		UserNamespace:      drivers.UserNamespaceSpecFromProto(req.UserNamespace),
+		Rootfs:             req.Rootfs,
+		WorkDir:            req.WorkDir,
 	})
 
 	if err != nil {
@@ -144,6 +154,13 @@ func (s *grpcExecutorServer) Signal(ctx context.Context, req *proto.SignalReques
 	return &proto.SignalResponse{}, nil
 }
 
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.Path); err != nil {
+		return nil, err
+	}
+	return &proto.CheckpointResponse{}, nil
+}
+
 func (s *grpcExecutorServer) Exec(ctx context.Context, req *proto.ExecRequest) (*proto.ExecResponse, error) {
 	deadline, err := ptypes.Timestamp(req.Deadline)
 	if err != nil {
@@ -153,7 +153,18 @@ func (c *pidCollector) pidStats() (map[string]*drivers.ResourceUsage, error) {
 			// calculate cpu usage percent
 			cs.Percent = np.StatsTotalCPU.Percent(cpuStats.Total() * float64(time.Second))
 		}
-		stats[strconv.Itoa(pid)] = &drivers.ResourceUsage{MemoryStats: ms, CpuStats: cs}
+
+		// the bytes a process read from and wrote to storage are only
+		// available on Linux
+		var is *drivers.IOStats
+		if ioCounters, err := p.IOCounters(); err == nil {
+			is = &drivers.IOStats{
+				ReadBytes:  ioCounters.ReadBytes,
+				WriteBytes: ioCounters.WriteBytes,
+				Measured:   ExecutorBasicMeasuredIOStats,
+			}
+		}
+		stats[strconv.Itoa(pid)] = &drivers.ResourceUsage{MemoryStats: ms, CpuStats: cs, IOStats: is}
 	}
 
 	return stats, nil
@@ -166,6 +177,7 @@ func aggregatedResourceUsage(systemCpuStats *stats.CpuStats, pidStats map[string
 	var (
 		systemModeCPU, userModeCPU, percent float64
 		totalRSS, totalSwap                 uint64
+		totalIO                             *drivers.IOStats
 	)
 
 	for _, pidStat := range pidStats {
@@ -175,6 +187,13 @@ func aggregatedResourceUsage(systemCpuStats *stats.CpuStats, pidStats map[string
 
 		totalRSS += pidStat.MemoryStats.RSS
 		totalSwap += pidStat.MemoryStats.Swap
+
+		if pidStat.IOStats != nil {
+			if totalIO == nil {
+				totalIO = &drivers.IOStats{}
+			}
+			totalIO.Add(pidStat.IOStats)
+		}
 	}
 
 	totalCPU := &drivers.CpuStats{
@@ -194,6 +213,7 @@ func aggregatedResourceUsage(systemCpuStats *stats.CpuStats, pidStats map[string
 	resourceUsage := drivers.ResourceUsage{
 		MemoryStats: totalMemory,
 		CpuStats:    totalCPU,
+		IOStats:     totalIO,
 	}
 	return &drivers.TaskResourceUsage{
 		ResourceUsage: &resourceUsage,
@@ -46,6 +46,14 @@ type LaunchRequest struct {
 	CpusetCgroup         string                       `protobuf:"bytes,17,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	AllowCaps            []string                     `protobuf:"bytes,18,rep,name=allow_caps,json=allowCaps,proto3" json:"allow_caps,omitempty"`
 	Capabilities         []string                     `protobuf:"bytes,19,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	RestorePath          string                       `protobuf:"bytes,20,opt,name=restore_path,json=restorePath,proto3" json:"restore_path,omitempty"`
+	SeccompProfile       []byte                       `protobuf:"bytes,21,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`
+	FsAllowRead          []string                     `protobuf:"bytes,22,rep,name=fs_allow_read,json=fsAllowRead,proto3" json:"fs_allow_read,omitempty"`
+	FsAllowWrite         []string                     `protobuf:"bytes,23,rep,name=fs_allow_write,json=fsAllowWrite,proto3" json:"fs_allow_write,omitempty"`
+	UserNamespace        *proto1.UserNamespaceSpec    `protobuf:"bytes,24,opt,name=user_namespace,json=userNamespace,proto3" json:"user_namespace,omitempty"`
+	Rootfs               string                       `protobuf:"bytes,25,opt,name=rootfs,proto3" json:"rootfs,omitempty"`
+	WorkDir              string                       `protobuf:"bytes,26,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	FsRestricted         bool                         `protobuf:"varint,27,opt,name=fs_restricted,json=fsRestricted,proto3" json:"fs_restricted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -209,6 +217,62 @@ func (m *LaunchRequest) GetCapabilities() []string {
 	return nil
 }
 
+func (m *LaunchRequest) GetRestorePath() string {
+	if m != nil {
+		return m.RestorePath
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetSeccompProfile() []byte {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetFsAllowRead() []string {
+	if m != nil {
+		return m.FsAllowRead
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetFsAllowWrite() []string {
+	if m != nil {
+		return m.FsAllowWrite
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetUserNamespace() *proto1.UserNamespaceSpec {
+	if m != nil {
+		return m.UserNamespace
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetRootfs() string {
+	if m != nil {
+		return m.Rootfs
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetWorkDir() string {
+	if m != nil {
+		return m.WorkDir
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetFsRestricted() bool {
+	if m != nil {
+		return m.FsRestricted
+	}
+	return false
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -786,6 +850,76 @@ func (m *ExecResponse) GetExitCode() int32 {
 	return 0
 }
 
+type CheckpointRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{16}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{17}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
 type ProcessState struct {
 	Pid                  int32                `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
 	ExitCode             int32                `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
@@ -800,7 +934,7 @@ func (m *ProcessState) Reset()         { *m = ProcessState{} }
 func (m *ProcessState) String() string { return proto.CompactTextString(m) }
 func (*ProcessState) ProtoMessage()    {}
 func (*ProcessState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{16}
+	return fileDescriptor_66b85426380683f3, []int{18}
 }
 
 func (m *ProcessState) XXX_Unmarshal(b []byte) error {
@@ -866,6 +1000,8 @@ func init() {
 	proto.RegisterType((*SignalResponse)(nil), "hashicorp.nomad.plugins.executor.proto.SignalResponse")
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
 }
 
@@ -874,74 +1010,86 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1058 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xee, 0xc5, 0x89, 0x5f, 0xc6, 0x76, 0xe2, 0x2e, 0xa8, 0x5c, 0x8d, 0x50, 0xcd, 0x21, 0x51,
-	0x0b, 0xca, 0x25, 0x4a, 0xdf, 0x90, 0x90, 0x28, 0x22, 0x2d, 0xa8, 0x52, 0x1a, 0x45, 0x97, 0x42,
-	0x25, 0x3e, 0x70, 0x6c, 0xee, 0xb6, 0xf6, 0x2a, 0xf6, 0xed, 0xb2, 0xbb, 0xe7, 0x04, 0x09, 0x89,
-	0x4f, 0xfc, 0x03, 0x90, 0xf8, 0x31, 0xfc, 0x38, 0xb4, 0x6f, 0x17, 0x3b, 0x2d, 0xd5, 0xb9, 0x88,
-	0x4f, 0xbe, 0x1d, 0x3f, 0xcf, 0xcc, 0xec, 0xce, 0xcc, 0x33, 0x70, 0x27, 0x17, 0x74, 0x41, 0x84,
-	0xdc, 0x95, 0x53, 0x2c, 0x48, 0xbe, 0x4b, 0x2e, 0x48, 0x56, 0x2a, 0x26, 0x76, 0xb9, 0x60, 0x8a,
-	0x55, 0xc7, 0xd8, 0x1c, 0xd1, 0xc7, 0x53, 0x2c, 0xa7, 0x34, 0x63, 0x82, 0xc7, 0x05, 0x9b, 0xe3,
-	0x3c, 0xe6, 0xb3, 0x72, 0x42, 0x0b, 0x19, 0xaf, 0xe2, 0x86, 0xb7, 0x26, 0x8c, 0x4d, 0x66, 0xc4,
-	0x3a, 0x39, 0x2d, 0x5f, 0xee, 0x2a, 0x3a, 0x27, 0x52, 0xe1, 0x39, 0x77, 0x80, 0xc8, 0x11, 0x77,
-	0x7d, 0x78, 0x1b, 0xce, 0x9e, 0x2c, 0x26, 0xfa, 0xbb, 0x09, 0xfd, 0x43, 0x5c, 0x16, 0xd9, 0x34,
-	0x21, 0x3f, 0x97, 0x44, 0x2a, 0x34, 0x80, 0x46, 0x36, 0xcf, 0xc3, 0x60, 0x14, 0x8c, 0x3b, 0x89,
-	0xfe, 0x44, 0x08, 0x36, 0xb1, 0x98, 0xc8, 0x70, 0x63, 0xd4, 0x18, 0x77, 0x12, 0xf3, 0x8d, 0x8e,
-	0xa0, 0x23, 0x88, 0x64, 0xa5, 0xc8, 0x88, 0x0c, 0x1b, 0xa3, 0x60, 0xdc, 0xdd, 0xdf, 0x8b, 0xff,
-	0x2d, 0x71, 0x17, 0xdf, 0x86, 0x8c, 0x13, 0xcf, 0x4b, 0x2e, 0x5d, 0xa0, 0x5b, 0xd0, 0x95, 0x2a,
-	0x67, 0xa5, 0x4a, 0x39, 0x56, 0xd3, 0x70, 0xd3, 0x44, 0x07, 0x6b, 0x3a, 0xc6, 0x6a, 0xea, 0x00,
-	0x44, 0x08, 0x0b, 0xd8, 0xaa, 0x00, 0x44, 0x08, 0x03, 0x18, 0x40, 0x83, 0x14, 0x8b, 0xb0, 0x69,
-	0x92, 0xd4, 0x9f, 0x3a, 0xef, 0x52, 0x12, 0x11, 0xb6, 0x0c, 0xd6, 0x7c, 0xa3, 0x9b, 0xd0, 0x56,
-	0x58, 0x9e, 0xa5, 0x39, 0x15, 0x61, 0xdb, 0xd8, 0x5b, 0xfa, 0xfc, 0x98, 0x0a, 0x74, 0x1b, 0x76,
-	0x7c, 0x3e, 0xe9, 0x8c, 0xce, 0xa9, 0x92, 0x61, 0x67, 0x14, 0x8c, 0xdb, 0xc9, 0xb6, 0x37, 0x1f,
-	0x1a, 0x2b, 0xda, 0x83, 0x77, 0x4f, 0xb1, 0xa4, 0x59, 0xca, 0x05, 0xcb, 0x88, 0x94, 0x69, 0x36,
-	0x11, 0xac, 0xe4, 0x21, 0x18, 0x34, 0x32, 0xff, 0x1d, 0xdb, 0xbf, 0x0e, 0xcc, 0x3f, 0xe8, 0x31,
-	0x34, 0xe7, 0xac, 0x2c, 0x94, 0x0c, 0xbb, 0xa3, 0xc6, 0xb8, 0xbb, 0x7f, 0xa7, 0xe6, 0x53, 0x3d,
-	0xd3, 0xa4, 0xc4, 0x71, 0xd1, 0xb7, 0xd0, 0xca, 0xc9, 0x82, 0xea, 0x17, 0xef, 0x19, 0x37, 0x9f,
-	0xd5, 0x74, 0xf3, 0xd8, 0xb0, 0x12, 0xcf, 0x46, 0x53, 0xb8, 0x5e, 0x10, 0x75, 0xce, 0xc4, 0x59,
-	0x4a, 0x25, 0x9b, 0x61, 0x45, 0x59, 0x11, 0xf6, 0x4d, 0x11, 0xbf, 0xa8, 0xe9, 0xf2, 0xc8, 0xf2,
-	0x9f, 0x7a, 0xfa, 0x09, 0x27, 0x59, 0x32, 0x28, 0xae, 0x58, 0x51, 0x04, 0xfd, 0x82, 0xa5, 0x9c,
-	0x2e, 0x98, 0x4a, 0x05, 0x63, 0x2a, 0xdc, 0x36, 0x6f, 0xd4, 0x2d, 0xd8, 0xb1, 0xb6, 0x25, 0x8c,
-	0x29, 0x34, 0x86, 0x41, 0x4e, 0x5e, 0xe2, 0x72, 0xa6, 0x52, 0x4e, 0xf3, 0x74, 0xce, 0x72, 0x12,
-	0xee, 0x98, 0xd2, 0x6c, 0x3b, 0xfb, 0x31, 0xcd, 0x9f, 0xb1, 0x9c, 0x2c, 0x23, 0x29, 0xcf, 0x2c,
-	0x72, 0xb0, 0x82, 0x7c, 0xca, 0x33, 0x83, 0xfc, 0x08, 0xfa, 0x19, 0x2f, 0x25, 0x51, 0xbe, 0x36,
-	0xd7, 0x0d, 0xac, 0x67, 0x8d, 0xae, 0x2a, 0x1f, 0x00, 0xe0, 0xd9, 0x8c, 0x9d, 0xa7, 0x19, 0xe6,
-	0x32, 0x44, 0xa6, 0x71, 0x3a, 0xc6, 0x72, 0x80, 0xb9, 0x44, 0x11, 0xf4, 0x32, 0xcc, 0xf1, 0x29,
-	0x9d, 0x51, 0x45, 0x89, 0x0c, 0xdf, 0x31, 0x80, 0x15, 0x5b, 0xf4, 0x13, 0x6c, 0xfb, 0xe9, 0x91,
-	0x9c, 0x15, 0x92, 0xa0, 0x23, 0x68, 0xb9, 0xb6, 0x30, 0x23, 0xd4, 0xdd, 0xbf, 0x17, 0xd7, 0x9b,
-	0xe7, 0xd8, 0xb5, 0xcc, 0x89, 0xc2, 0x8a, 0x24, 0xde, 0x49, 0xd4, 0x87, 0xee, 0x0b, 0x4c, 0x95,
-	0x9b, 0xce, 0xe8, 0x47, 0xe8, 0xd9, 0xe3, 0xff, 0x14, 0xee, 0x10, 0x76, 0x4e, 0xa6, 0xa5, 0xca,
-	0xd9, 0x79, 0xe1, 0x05, 0xe1, 0x06, 0x34, 0x25, 0x9d, 0x14, 0x78, 0xe6, 0x34, 0xc1, 0x9d, 0xd0,
-	0x87, 0xd0, 0x9b, 0x08, 0x9c, 0x91, 0x94, 0x13, 0x41, 0x59, 0x1e, 0x6e, 0x8c, 0x82, 0x71, 0x23,
-	0xe9, 0x1a, 0xdb, 0xb1, 0x31, 0x45, 0x08, 0x06, 0x97, 0xde, 0x6c, 0xc6, 0xd1, 0x14, 0x6e, 0x7c,
-	0xc7, 0x73, 0x1d, 0xb4, 0xd2, 0x01, 0x17, 0x68, 0x45, 0x53, 0x82, 0xff, 0xac, 0x29, 0xd1, 0x4d,
-	0x78, 0xef, 0x95, 0x48, 0x2e, 0x89, 0x01, 0x6c, 0x7f, 0x4f, 0x84, 0xa4, 0xcc, 0xdf, 0x32, 0xfa,
-	0x14, 0x76, 0x2a, 0x8b, 0x7b, 0xdb, 0x10, 0x5a, 0x0b, 0x6b, 0x72, 0x37, 0xf7, 0xc7, 0xe8, 0x13,
-	0xe8, 0xe9, 0x77, 0xab, 0x32, 0x1f, 0x42, 0x9b, 0x16, 0x8a, 0x88, 0x85, 0x7b, 0xa4, 0x46, 0x52,
-	0x9d, 0xa3, 0x17, 0xd0, 0x77, 0x58, 0xe7, 0xf6, 0x1b, 0xd8, 0x92, 0xda, 0xb0, 0xe6, 0x15, 0x9f,
-	0x63, 0x79, 0x66, 0x1d, 0x59, 0x7a, 0x74, 0x1b, 0xfa, 0x27, 0xa6, 0x12, 0xaf, 0x2f, 0xd4, 0x96,
-	0x2f, 0x94, 0xbe, 0xac, 0x07, 0xba, 0xeb, 0x9f, 0x41, 0xf7, 0xc9, 0x05, 0xc9, 0x3c, 0xf1, 0x01,
-	0xb4, 0x73, 0x82, 0xf3, 0x19, 0x2d, 0x88, 0x4b, 0x6a, 0x18, 0xdb, 0xe5, 0x12, 0xfb, 0xe5, 0x12,
-	0x3f, 0xf7, 0xcb, 0x25, 0xa9, 0xb0, 0x7e, 0x55, 0x6c, 0xbc, 0xba, 0x2a, 0x1a, 0x97, 0xab, 0x22,
-	0x3a, 0x80, 0x9e, 0x0d, 0xe6, 0xee, 0x7f, 0x03, 0x9a, 0xac, 0x54, 0xbc, 0x54, 0x26, 0x56, 0x2f,
-	0x71, 0x27, 0xf4, 0x3e, 0x74, 0xc8, 0x05, 0x55, 0x69, 0xa6, 0xc7, 0x7a, 0xc3, 0xdc, 0xa0, 0xad,
-	0x0d, 0x07, 0x2c, 0x27, 0xd1, 0xef, 0x01, 0xf4, 0x96, 0x3b, 0x56, 0xc7, 0xe6, 0x34, 0x77, 0x37,
-	0xd5, 0x9f, 0x6f, 0xe4, 0x2f, 0xbd, 0x4d, 0x63, 0xf9, 0x6d, 0x50, 0x0c, 0x9b, 0x7a, 0x6d, 0x9a,
-	0x85, 0xf3, 0xe6, 0x6b, 0x1b, 0xdc, 0xfe, 0x9f, 0x1d, 0x68, 0x3f, 0x71, 0x83, 0x84, 0x7e, 0x81,
-	0xa6, 0x9d, 0x7e, 0x74, 0xbf, 0xee, 0xd4, 0xad, 0xec, 0xda, 0xe1, 0x83, 0x75, 0x69, 0xae, 0x7e,
-	0xd7, 0x90, 0x84, 0x4d, 0xad, 0x03, 0xe8, 0x6e, 0x5d, 0x0f, 0x4b, 0x22, 0x32, 0xbc, 0xb7, 0x1e,
-	0xa9, 0x0a, 0xfa, 0x1b, 0xb4, 0xfd, 0x38, 0xa3, 0x87, 0x75, 0x7d, 0x5c, 0x91, 0x93, 0xe1, 0xe7,
-	0xeb, 0x13, 0xab, 0x04, 0xfe, 0x08, 0x60, 0xe7, 0xca, 0x48, 0xa3, 0x2f, 0xeb, 0xfa, 0x7b, 0xbd,
-	0xea, 0x0c, 0x1f, 0xbd, 0x35, 0xbf, 0x4a, 0xeb, 0x57, 0x68, 0x39, 0xed, 0x40, 0xb5, 0x2b, 0xba,
-	0x2a, 0x3f, 0xc3, 0x87, 0x6b, 0xf3, 0xaa, 0xe8, 0x17, 0xb0, 0x65, 0x74, 0x01, 0xd5, 0x2e, 0xeb,
-	0xb2, 0x76, 0x0d, 0xef, 0xaf, 0xc9, 0xf2, 0x71, 0xf7, 0x02, 0xdd, 0xff, 0x56, 0x58, 0xea, 0xf7,
-	0xff, 0x8a, 0x62, 0xd5, 0xef, 0xff, 0x2b, 0xfa, 0x65, 0xfa, 0x5f, 0x8f, 0x61, 0xfd, 0xfe, 0x5f,
-	0xd2, 0xbb, 0xfa, 0xfd, 0xbf, 0xac, 0x5b, 0xd1, 0x35, 0xf4, 0x57, 0x00, 0x7d, 0x6d, 0x3a, 0x51,
-	0x82, 0xe0, 0x39, 0x2d, 0x26, 0xe8, 0x51, 0x4d, 0xf1, 0xd6, 0x2c, 0x2b, 0xe0, 0x8e, 0xe9, 0x53,
-	0xf9, 0xea, 0xed, 0x1d, 0xf8, 0xb4, 0xc6, 0xc1, 0x5e, 0xf0, 0x75, 0xeb, 0x87, 0x2d, 0xab, 0x59,
-	0x4d, 0xf3, 0x73, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x63, 0xc4, 0xd3, 0x74, 0x0c,
-	0x00, 0x00,
+	// 1251 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdb, 0x6f, 0x1b, 0xc5,
+	0x17, 0xfe, 0x6d, 0x9c, 0xf8, 0x72, 0x7c, 0x89, 0x3b, 0xbf, 0x92, 0x4e, 0xb7, 0x42, 0x35, 0x5b,
+	0x44, 0x2c, 0x28, 0x4e, 0x94, 0x5e, 0x01, 0x89, 0x02, 0x69, 0x41, 0x95, 0xda, 0x28, 0xda, 0xb4,
+	0x54, 0xe2, 0x81, 0x65, 0xba, 0x3b, 0xb6, 0x47, 0xb1, 0x77, 0x96, 0x99, 0xd9, 0x24, 0x48, 0x48,
+	0x3c, 0xf5, 0x89, 0x57, 0x1e, 0x78, 0xe1, 0x7f, 0x45, 0x73, 0xd9, 0x8d, 0xdd, 0x16, 0x58, 0x07,
+	0xf1, 0xe4, 0x99, 0x6f, 0xcf, 0x77, 0xce, 0x99, 0x73, 0xce, 0x7c, 0x63, 0xb8, 0x99, 0x08, 0x76,
+	0x42, 0x85, 0xdc, 0x91, 0x53, 0x22, 0x68, 0xb2, 0x43, 0xcf, 0x68, 0x9c, 0x2b, 0x2e, 0x76, 0x32,
+	0xc1, 0x15, 0x2f, 0xb7, 0x23, 0xb3, 0x45, 0x1f, 0x4c, 0x89, 0x9c, 0xb2, 0x98, 0x8b, 0x6c, 0x94,
+	0xf2, 0x39, 0x49, 0x46, 0xd9, 0x2c, 0x9f, 0xb0, 0x54, 0x8e, 0x96, 0xed, 0xfc, 0xeb, 0x13, 0xce,
+	0x27, 0x33, 0x6a, 0x9d, 0xbc, 0xcc, 0xc7, 0x3b, 0x8a, 0xcd, 0xa9, 0x54, 0x64, 0x9e, 0x39, 0x83,
+	0xc0, 0x11, 0x77, 0x8a, 0xf0, 0x36, 0x9c, 0xdd, 0x59, 0x9b, 0xe0, 0xd7, 0x16, 0x74, 0x9f, 0x90,
+	0x3c, 0x8d, 0xa7, 0x21, 0xfd, 0x31, 0xa7, 0x52, 0xa1, 0x3e, 0xd4, 0xe2, 0x79, 0x82, 0xbd, 0x81,
+	0x37, 0x6c, 0x85, 0x7a, 0x89, 0x10, 0xac, 0x13, 0x31, 0x91, 0x78, 0x6d, 0x50, 0x1b, 0xb6, 0x42,
+	0xb3, 0x46, 0x07, 0xd0, 0x12, 0x54, 0xf2, 0x5c, 0xc4, 0x54, 0xe2, 0xda, 0xc0, 0x1b, 0xb6, 0xf7,
+	0x76, 0x47, 0x7f, 0x95, 0xb8, 0x8b, 0x6f, 0x43, 0x8e, 0xc2, 0x82, 0x17, 0x9e, 0xbb, 0x40, 0xd7,
+	0xa1, 0x2d, 0x55, 0xc2, 0x73, 0x15, 0x65, 0x44, 0x4d, 0xf1, 0xba, 0x89, 0x0e, 0x16, 0x3a, 0x24,
+	0x6a, 0xea, 0x0c, 0xa8, 0x10, 0xd6, 0x60, 0xa3, 0x34, 0xa0, 0x42, 0x18, 0x83, 0x3e, 0xd4, 0x68,
+	0x7a, 0x82, 0xeb, 0x26, 0x49, 0xbd, 0xd4, 0x79, 0xe7, 0x92, 0x0a, 0xdc, 0x30, 0xb6, 0x66, 0x8d,
+	0xae, 0x42, 0x53, 0x11, 0x79, 0x1c, 0x25, 0x4c, 0xe0, 0xa6, 0xc1, 0x1b, 0x7a, 0xff, 0x90, 0x09,
+	0xb4, 0x0d, 0x9b, 0x45, 0x3e, 0xd1, 0x8c, 0xcd, 0x99, 0x92, 0xb8, 0x35, 0xf0, 0x86, 0xcd, 0xb0,
+	0x57, 0xc0, 0x4f, 0x0c, 0x8a, 0x76, 0xe1, 0xf2, 0x4b, 0x22, 0x59, 0x1c, 0x65, 0x82, 0xc7, 0x54,
+	0xca, 0x28, 0x9e, 0x08, 0x9e, 0x67, 0x18, 0x8c, 0x35, 0x32, 0xdf, 0x0e, 0xed, 0xa7, 0x7d, 0xf3,
+	0x05, 0x3d, 0x84, 0xfa, 0x9c, 0xe7, 0xa9, 0x92, 0xb8, 0x3d, 0xa8, 0x0d, 0xdb, 0x7b, 0x37, 0x2b,
+	0x96, 0xea, 0xa9, 0x26, 0x85, 0x8e, 0x8b, 0xbe, 0x81, 0x46, 0x42, 0x4f, 0x98, 0xae, 0x78, 0xc7,
+	0xb8, 0xf9, 0xb8, 0xa2, 0x9b, 0x87, 0x86, 0x15, 0x16, 0x6c, 0x34, 0x85, 0x4b, 0x29, 0x55, 0xa7,
+	0x5c, 0x1c, 0x47, 0x4c, 0xf2, 0x19, 0x51, 0x8c, 0xa7, 0xb8, 0x6b, 0x9a, 0xf8, 0x59, 0x45, 0x97,
+	0x07, 0x96, 0xff, 0xb8, 0xa0, 0x1f, 0x65, 0x34, 0x0e, 0xfb, 0xe9, 0x6b, 0x28, 0x0a, 0xa0, 0x9b,
+	0xf2, 0x28, 0x63, 0x27, 0x5c, 0x45, 0x82, 0x73, 0x85, 0x7b, 0xa6, 0x46, 0xed, 0x94, 0x1f, 0x6a,
+	0x2c, 0xe4, 0x5c, 0xa1, 0x21, 0xf4, 0x13, 0x3a, 0x26, 0xf9, 0x4c, 0x45, 0x19, 0x4b, 0xa2, 0x39,
+	0x4f, 0x28, 0xde, 0x34, 0xad, 0xe9, 0x39, 0xfc, 0x90, 0x25, 0x4f, 0x79, 0x42, 0x17, 0x2d, 0x59,
+	0x16, 0x5b, 0xcb, 0xfe, 0x92, 0xe5, 0xe3, 0x2c, 0x36, 0x96, 0x37, 0xa0, 0x1b, 0x67, 0xb9, 0xa4,
+	0xaa, 0xe8, 0xcd, 0x25, 0x63, 0xd6, 0xb1, 0xa0, 0xeb, 0xca, 0xbb, 0x00, 0x64, 0x36, 0xe3, 0xa7,
+	0x51, 0x4c, 0x32, 0x89, 0x91, 0x19, 0x9c, 0x96, 0x41, 0xf6, 0x49, 0x26, 0x51, 0x00, 0x9d, 0x98,
+	0x64, 0xe4, 0x25, 0x9b, 0x31, 0xc5, 0xa8, 0xc4, 0xff, 0x37, 0x06, 0x4b, 0x18, 0x7a, 0x0f, 0x3a,
+	0x82, 0x4a, 0xc5, 0x05, 0xb5, 0x63, 0x79, 0xd9, 0x84, 0x69, 0x3b, 0xcc, 0xcc, 0xe5, 0x36, 0x6c,
+	0x4a, 0x1a, 0xc7, 0x7c, 0x9e, 0xe9, 0x79, 0x19, 0xb3, 0x19, 0xc5, 0xef, 0x0c, 0xbc, 0x61, 0x27,
+	0xec, 0x39, 0xf8, 0xd0, 0xa2, 0xba, 0x56, 0x63, 0x19, 0xd9, 0x8c, 0x04, 0x25, 0x09, 0xde, 0x32,
+	0x01, 0xdb, 0x63, 0xf9, 0xa5, 0xc6, 0x42, 0x4a, 0x12, 0xf4, 0x3e, 0xf4, 0x4a, 0x9b, 0x53, 0xc1,
+	0x14, 0xc5, 0x57, 0x6c, 0x56, 0xce, 0xe8, 0x85, 0xc6, 0x50, 0x04, 0x3d, 0x3d, 0xec, 0x51, 0x4a,
+	0xe6, 0x54, 0x66, 0x24, 0xa6, 0x18, 0x9b, 0xe6, 0xde, 0xaf, 0xd8, 0xdc, 0xe7, 0x92, 0x8a, 0x83,
+	0x82, 0x6b, 0x3a, 0xdb, 0xcd, 0x17, 0x21, 0xb4, 0x05, 0x75, 0xdd, 0xcd, 0xb1, 0xc4, 0x57, 0xcd,
+	0x81, 0xdd, 0x4e, 0xdf, 0x2e, 0x33, 0x55, 0xfa, 0x76, 0xf9, 0xf6, 0x76, 0xe9, 0xbd, 0xbe, 0x5d,
+	0x37, 0xcc, 0xe9, 0x74, 0x61, 0x04, 0x8b, 0x15, 0x4d, 0xf0, 0x35, 0x33, 0x09, 0x9d, 0xb1, 0x0c,
+	0x4b, 0x2c, 0xf8, 0x01, 0x7a, 0x85, 0x18, 0xc9, 0x8c, 0xa7, 0x92, 0xa2, 0x03, 0x68, 0xb8, 0x5b,
+	0x66, 0x14, 0xa9, 0xbd, 0x77, 0x7b, 0x54, 0x4d, 0x1e, 0x47, 0xee, 0x06, 0x1e, 0x29, 0xa2, 0x68,
+	0x58, 0x38, 0x09, 0xba, 0xd0, 0x7e, 0x41, 0x98, 0x72, 0x62, 0x17, 0x7c, 0x0f, 0x1d, 0xbb, 0xfd,
+	0x8f, 0xc2, 0x3d, 0x81, 0xcd, 0xa3, 0x69, 0xae, 0x12, 0x7e, 0x9a, 0x16, 0xfa, 0xba, 0x05, 0x75,
+	0xc9, 0x26, 0x29, 0x99, 0x39, 0x89, 0x75, 0x3b, 0x3d, 0x4a, 0x13, 0x41, 0x62, 0x1a, 0x65, 0x54,
+	0x30, 0x9e, 0xe0, 0xb5, 0x81, 0x37, 0xac, 0x85, 0x6d, 0x83, 0x1d, 0x1a, 0x28, 0x40, 0xd0, 0x3f,
+	0xf7, 0x66, 0x33, 0x0e, 0xa6, 0xb0, 0xf5, 0x3c, 0x4b, 0x74, 0xd0, 0x52, 0x56, 0x5d, 0xa0, 0x25,
+	0x89, 0xf6, 0xfe, 0xb5, 0x44, 0x07, 0x57, 0xe1, 0xca, 0x1b, 0x91, 0x5c, 0x12, 0x7d, 0xe8, 0x7d,
+	0x4b, 0x85, 0x64, 0xbc, 0x38, 0x65, 0xf0, 0x11, 0x6c, 0x96, 0x88, 0xab, 0x2d, 0x86, 0xc6, 0x89,
+	0x85, 0xdc, 0xc9, 0x8b, 0x6d, 0xf0, 0x21, 0x74, 0x74, 0xdd, 0xca, 0xcc, 0x7d, 0x68, 0xb2, 0x54,
+	0x51, 0x71, 0xe2, 0x8a, 0x54, 0x0b, 0xcb, 0x7d, 0xf0, 0x02, 0xba, 0xce, 0xd6, 0xb9, 0xfd, 0x1a,
+	0x36, 0xa4, 0x06, 0x56, 0x3c, 0xe2, 0x33, 0x22, 0x8f, 0xad, 0x23, 0x4b, 0x0f, 0xb6, 0xa1, 0x7b,
+	0x64, 0x3a, 0xf1, 0xf6, 0x46, 0x6d, 0x14, 0x8d, 0xd2, 0x87, 0x2d, 0x0c, 0xdd, 0xf1, 0x8f, 0xa1,
+	0xfd, 0xe8, 0x8c, 0xc6, 0x05, 0xf1, 0x2e, 0x34, 0x13, 0x4a, 0x92, 0x19, 0x4b, 0xa9, 0x4b, 0xca,
+	0x1f, 0xd9, 0xb7, 0x7a, 0x54, 0xbc, 0xd5, 0xa3, 0x67, 0xc5, 0x5b, 0x1d, 0x96, 0xb6, 0xc5, 0xcb,
+	0xbb, 0xf6, 0xe6, 0xcb, 0x5b, 0x3b, 0x7f, 0x79, 0x83, 0x7d, 0xe8, 0xd8, 0x60, 0xee, 0xfc, 0x5b,
+	0x50, 0xe7, 0xb9, 0xca, 0x72, 0x65, 0x62, 0x75, 0x42, 0xb7, 0x43, 0xd7, 0xa0, 0x45, 0xcf, 0x98,
+	0x8a, 0x62, 0xad, 0x92, 0x6b, 0xe6, 0x04, 0x4d, 0x0d, 0xec, 0xf3, 0x84, 0x06, 0xdb, 0x70, 0x69,
+	0x7f, 0x4a, 0xe3, 0xe3, 0x8c, 0xb3, 0xb4, 0xb8, 0x0c, 0x3a, 0x9a, 0x11, 0x31, 0xdb, 0x1d, 0xb3,
+	0x0e, 0x2e, 0x03, 0x5a, 0x34, 0x74, 0x07, 0x7e, 0xe5, 0x41, 0x67, 0x71, 0xe0, 0x75, 0xea, 0x19,
+	0x4b, 0x5c, 0xa1, 0xf4, 0xf2, 0x6f, 0xc3, 0x2f, 0x94, 0xb6, 0xb6, 0x58, 0x5a, 0x34, 0x82, 0x75,
+	0xfd, 0x27, 0x06, 0xaf, 0xff, 0x63, 0xd5, 0x8c, 0xdd, 0xde, 0x1f, 0x00, 0xcd, 0x47, 0xee, 0x1e,
+	0xa2, 0x9f, 0xa0, 0x6e, 0xc5, 0x03, 0xdd, 0xa9, 0x7a, 0x69, 0x97, 0xfe, 0xf9, 0xf8, 0x77, 0x57,
+	0xa5, 0xb9, 0x6a, 0xfc, 0x0f, 0x49, 0x58, 0xd7, 0x32, 0x82, 0x6e, 0x55, 0xf5, 0xb0, 0xa0, 0x41,
+	0xfe, 0xed, 0xd5, 0x48, 0x65, 0xd0, 0x5f, 0xa0, 0x59, 0xa8, 0x01, 0xba, 0x57, 0xd5, 0xc7, 0x6b,
+	0x6a, 0xe4, 0xdf, 0x5f, 0x9d, 0x58, 0x26, 0xf0, 0x9b, 0x07, 0x9b, 0xaf, 0x29, 0x02, 0xfa, 0xbc,
+	0xaa, 0xbf, 0xb7, 0x8b, 0x96, 0xff, 0xe0, 0xc2, 0xfc, 0x32, 0xad, 0x9f, 0xa1, 0xe1, 0xa4, 0x07,
+	0x55, 0xee, 0xe8, 0xb2, 0x7a, 0xf9, 0xf7, 0x56, 0xe6, 0x95, 0xd1, 0xcf, 0x60, 0xc3, 0xc8, 0x0a,
+	0xaa, 0xdc, 0xd6, 0x45, 0xe9, 0xf3, 0xef, 0xac, 0xc8, 0x2a, 0xe2, 0xee, 0x7a, 0x7a, 0xfe, 0xad,
+	0x2e, 0x55, 0x9f, 0xff, 0x25, 0xc1, 0xf3, 0xef, 0xae, 0x4a, 0x5b, 0x9c, 0x7f, 0x7d, 0x0d, 0xab,
+	0xcf, 0xff, 0x82, 0x5c, 0xfa, 0xb7, 0x57, 0x23, 0x95, 0x41, 0x5f, 0x79, 0x00, 0xe7, 0xda, 0x84,
+	0x3e, 0xa9, 0xea, 0xe6, 0x0d, 0xe1, 0xf3, 0x3f, 0xbd, 0x08, 0xb5, 0xcc, 0xe3, 0x77, 0x0f, 0xba,
+	0x3a, 0xb5, 0x23, 0x25, 0x28, 0x99, 0xb3, 0x74, 0x82, 0x1e, 0x54, 0x7c, 0x83, 0x34, 0xcb, 0xbe,
+	0x43, 0x8e, 0x59, 0x24, 0xf4, 0xc5, 0xc5, 0x1d, 0x14, 0x69, 0x0d, 0xbd, 0x5d, 0xef, 0xab, 0xc6,
+	0x77, 0x1b, 0x56, 0x3b, 0xeb, 0xe6, 0xe7, 0xd6, 0x9f, 0x03, 0x00, 0x1c, 0x12, 0x04, 0x36, 0x8a,
+	0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -964,6 +1112,7 @@ type ExecutorClient interface {
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (Executor_StatsClient, error)
 	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
 }
@@ -1071,6 +1220,15 @@ func (c *executorClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *executorClient) ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Executor_serviceDesc.Streams[1], "/hashicorp.nomad.plugins.executor.proto.Executor/ExecStreaming", opts...)
 	if err != nil {
@@ -1112,6 +1270,7 @@ type ExecutorServer interface {
 	Stats(*StatsRequest, Executor_StatsServer) error
 	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
 }
@@ -1144,6 +1303,9 @@ func (*UnimplementedExecutorServer) Signal(ctx context.Context, req *SignalReque
 func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
@@ -1299,6 +1461,24 @@ func _Executor_Exec_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Executor_ExecStreaming_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ExecutorServer).ExecStreaming(&executorExecStreamingServer{stream})
 }
@@ -1357,6 +1537,10 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
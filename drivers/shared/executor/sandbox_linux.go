@@ -0,0 +1,92 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
+)
+
+const (
+	// sandboxShimArg is the subcommand that applies a sandbox config to its
+	// own process before executing the task command.
+	sandboxShimArg = "sandbox-shim"
+
+	// sandboxShimPath is where the shim binary is mounted inside the chroot
+	// of the LibcontainerExecutor.
+	sandboxShimPath = "/dev/.nomad-sandbox-shim"
+)
+
+// sandboxLibraryPaths are the paths of the dynamic loader and the shared
+// libraries, which every dynamically linked command needs to read. Paths that
+// do not exist are skipped when the sandbox is applied.
+var sandboxLibraryPaths = []string{
+	"/etc/ld.so.cache",
+	"/lib",
+	"/lib32",
+	"/lib64",
+	"/libx32",
+	"/usr/lib",
+	"/usr/lib32",
+	"/usr/lib64",
+	"/usr/libx32",
+}
+
+// init is used when an executor starts a sandboxed task. The sandbox shim is
+// invoked as `sandbox-shim <config> <command> [args...]`, restricts itself
+// according to the JSON encoded sandbox.Config and then executes the task
+// command, which inherits the restrictions.
+//
+// Like the libcontainer shim, this is implemented as an `init` so that it is
+// handled anywhere this package is used, including tests.
+func init() {
+	if len(os.Args) > 1 && os.Args[1] == sandboxShimArg {
+		// Landlock restrictions apply to the calling thread, so the thread
+		// that applies them must be the one calling execve
+		runtime.LockOSThread()
+		if err := runSandboxShim(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to start sandboxed task: %v\n", err)
+			os.Exit(1)
+		}
+		panic("--this line should have never been executed, congratulations--")
+	}
+}
+
+func runSandboxShim(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("expected a sandbox config and command")
+	}
+
+	var c sandbox.Config
+	if err := json.Unmarshal([]byte(args[0]), &c); err != nil {
+		return fmt.Errorf("failed to decode sandbox config: %v", err)
+	}
+	if err := sandbox.Apply(&c); err != nil {
+		return err
+	}
+
+	return syscall.Exec(args[1], args[1:], os.Environ())
+}
+
+// sandboxShimArgs returns the arguments that run the command bin through the
+// sandbox shim at the path shim. The config is extended with the paths that
+// every task needs: bin itself, the loader and shared libraries, the standard
+// device nodes and the task's writable directories.
+func sandboxShimArgs(shim string, c *sandbox.Config, bin string, args []string, taskDirs []string) ([]string, error) {
+	c = c.Copy()
+	if c.RestrictsFS() {
+		c.FSAllowRead = append(c.FSAllowRead, bin, "/dev/random", "/dev/urandom")
+		c.FSAllowRead = append(c.FSAllowRead, sandboxLibraryPaths...)
+		c.FSAllowWrite = append(c.FSAllowWrite, "/dev/null", "/dev/zero")
+		c.FSAllowWrite = append(c.FSAllowWrite, taskDirs...)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sandbox config: %v", err)
+	}
+	return append([]string{shim, sandboxShimArg, string(data), bin}, args...), nil
+}
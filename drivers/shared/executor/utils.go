@@ -10,6 +10,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/drivers/shared/executor/proto"
+	"github.com/hashicorp/nomad/drivers/shared/sandbox"
 	"github.com/hashicorp/nomad/plugins/base"
 )
 
@@ -127,6 +128,44 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 	}, nil
 }
 
+// sandboxToProto sets the sandbox fields of a launch request. The seccomp
+// profile is sent in its JSON form.
+func sandboxToProto(req *proto.LaunchRequest, c *sandbox.Config) error {
+	if c == nil {
+		return nil
+	}
+	if c.Seccomp != nil {
+		profile, err := json.Marshal(c.Seccomp)
+		if err != nil {
+			return fmt.Errorf("failed to encode seccomp profile: %v", err)
+		}
+		req.SeccompProfile = profile
+	}
+	req.FsAllowRead = c.FSAllowRead
+	req.FsAllowWrite = c.FSAllowWrite
+	req.FsRestricted = c.FSRestricted
+	return nil
+}
+
+func sandboxFromProto(req *proto.LaunchRequest) (*sandbox.Config, error) {
+	if len(req.SeccompProfile) == 0 && !req.FsRestricted {
+		return nil, nil
+	}
+	c := &sandbox.Config{
+		FSAllowRead:  req.FsAllowRead,
+		FSAllowWrite: req.FsAllowWrite,
+		FSRestricted: req.FsRestricted,
+	}
+	if len(req.SeccompProfile) != 0 {
+		profile, err := sandbox.ParseSeccompProfile(req.SeccompProfile)
+		if err != nil {
+			return nil, err
+		}
+		c.Seccomp = profile
+	}
+	return c, nil
+}
+
 // IsolationMode returns the namespace isolation mode as determined from agent
 // plugin configuration and task driver configuration. The task configuration
 // takes precedence, if it is configured.
@@ -0,0 +1,81 @@
+package ociimage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// fetcher retrieves the manifests and blobs of an image from its source.
+type fetcher interface {
+	// resolve returns the descriptor of the manifest or index the reference
+	// points to. The media type of the descriptor may be empty.
+	resolve(ctx context.Context) (v1.Descriptor, error)
+
+	// fetch returns the content of the manifest or blob described by desc.
+	// The content is not verified against the descriptor's digest.
+	fetch(ctx context.Context, desc v1.Descriptor) (io.ReadCloser, error)
+}
+
+// layoutFetcher reads images from a local OCI image layout directory.
+type layoutFetcher struct {
+	dir string
+	ref *Reference
+}
+
+func newLayoutFetcher(ref *Reference) *layoutFetcher {
+	return &layoutFetcher{
+		dir: ref.Layout,
+		ref: ref,
+	}
+}
+
+func (f *layoutFetcher) resolve(ctx context.Context) (v1.Descriptor, error) {
+	raw, err := os.ReadFile(filepath.Join(f.dir, "index.json"))
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("failed to read image layout index: %v", err)
+	}
+
+	var index v1.Index
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return v1.Descriptor{}, fmt.Errorf("failed to parse image layout index: %v", err)
+	}
+
+	// Digests may refer to manifests nested in an index that are not listed
+	// in the layout's top level index
+	if f.ref.Digest != "" {
+		for _, desc := range index.Manifests {
+			if desc.Digest == f.ref.Digest {
+				return desc, nil
+			}
+		}
+		return v1.Descriptor{Digest: f.ref.Digest}, nil
+	}
+
+	if f.ref.Tag == "" {
+		if len(index.Manifests) != 1 {
+			return v1.Descriptor{}, fmt.Errorf("image layout %q holds %d images, a tag or digest must be given",
+				f.dir, len(index.Manifests))
+		}
+		return index.Manifests[0], nil
+	}
+
+	for _, desc := range index.Manifests {
+		if desc.Annotations[v1.AnnotationRefName] == f.ref.Tag {
+			return desc, nil
+		}
+	}
+	return v1.Descriptor{}, fmt.Errorf("tag %q not found in image layout %q", f.ref.Tag, f.dir)
+}
+
+func (f *layoutFetcher) fetch(ctx context.Context, desc v1.Descriptor) (io.ReadCloser, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, err
+	}
+	return os.Open(filepath.Join(f.dir, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded()))
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package ociimage
+
+import "fmt"
+
+// Mount mounts an overlay of the image's layers at target, which is only
+// supported on linux.
+func Mount(img *Image, upper, work, target string) error {
+	return fmt.Errorf("mounting images is only supported on linux")
+}
+
+// Unmount removes the overlay mounted at target, if any.
+func Unmount(target string) error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package ociimage
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Mount mounts an overlay of the image's layers at target. Writes to the
+// overlay are stored in upper, and work is the overlay's work directory,
+// which must be on the same filesystem as upper. Any overlay already
+// mounted at target is replaced.
+func Mount(img *Image, upper, work, target string) error {
+	if len(img.Layers) == 0 {
+		return fmt.Errorf("image %s has no layers", img.Digest)
+	}
+
+	for _, dir := range []string{upper, work, target} {
+		if strings.ContainsAny(dir, ",:") {
+			return fmt.Errorf("path %q cannot be used in an overlay mount", dir)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	// Overlay lower directories are listed from the top layer down
+	lower := make([]string, len(img.Layers))
+	for i, layer := range img.Layers {
+		lower[len(lower)-1-i] = layer
+	}
+
+	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", strings.Join(lower, ":"), upper, work)
+	if len(opts) >= os.Getpagesize() {
+		return fmt.Errorf("image %s has too many layers to mount", img.Digest)
+	}
+
+	if err := Unmount(target); err != nil {
+		return err
+	}
+	if err := unix.Mount("overlay", target, "overlay", 0, opts); err != nil {
+		return fmt.Errorf("failed to mount image: %v", err)
+	}
+	return nil
+}
+
+// Unmount removes the overlay mounted at target, if any.
+func Unmount(target string) error {
+	err := unix.Unmount(target, unix.MNT_DETACH)
+	if err != nil && err != unix.EINVAL && err != unix.ENOENT {
+		return fmt.Errorf("failed to unmount image: %v", err)
+	}
+	return nil
+}
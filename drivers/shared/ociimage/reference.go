@@ -0,0 +1,103 @@
+// Package ociimage pulls OCI images from registries or local OCI image layout
+// directories and unpacks their layers into a content-addressed cache, from
+// which an overlay root filesystem can be mounted for a task.
+package ociimage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// layoutPrefix is the prefix of references to local OCI image layouts
+const layoutPrefix = "oci:"
+
+// Reference identifies an image in a registry or in a local OCI image
+// layout directory.
+type Reference struct {
+	// Layout is the path of a local OCI image layout directory. It is set for
+	// references of the form oci:<path>[:<tag>|@<digest>].
+	Layout string
+
+	// Named is the registry repository of the image. It is set for all
+	// references that are not local layouts.
+	Named reference.Named
+
+	// Tag is the tag of the image. Registry references without a tag or
+	// digest are given the "latest" tag.
+	Tag string
+
+	// Digest is the digest of the image's manifest or index, if the
+	// reference pins one.
+	Digest digest.Digest
+}
+
+// ParseReference parses an image reference. References starting with "oci:"
+// refer to a local OCI image layout directory, all others are parsed as
+// registry references, e.g. "docker.io/library/alpine:3.16".
+func ParseReference(s string) (*Reference, error) {
+	if strings.HasPrefix(s, layoutPrefix) {
+		return parseLayoutReference(strings.TrimPrefix(s, layoutPrefix))
+	}
+
+	named, err := reference.ParseNormalizedNamed(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %q: %v", s, err)
+	}
+
+	ref := &Reference{}
+	if canonical, ok := named.(reference.Canonical); ok {
+		ref.Digest = canonical.Digest()
+	} else {
+		named = reference.TagNameOnly(named)
+	}
+	if tagged, ok := named.(reference.Tagged); ok {
+		ref.Tag = tagged.Tag()
+	}
+	ref.Named = reference.TrimNamed(named)
+	return ref, nil
+}
+
+func parseLayoutReference(s string) (*Reference, error) {
+	ref := &Reference{}
+	if i := strings.LastIndex(s, "@"); i != -1 {
+		d, err := digest.Parse(s[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid image reference %q: %v", layoutPrefix+s, err)
+		}
+		ref.Digest = d
+		s = s[:i]
+	} else if i := strings.LastIndex(s, ":"); i > strings.LastIndex(s, "/") {
+		ref.Tag = s[i+1:]
+		s = s[:i]
+	}
+
+	if s == "" {
+		return nil, fmt.Errorf("invalid image reference %q: missing layout path", layoutPrefix)
+	}
+	ref.Layout = s
+	return ref, nil
+}
+
+// IsLayout returns true if the reference is to a local OCI image layout.
+func (r *Reference) IsLayout() bool {
+	return r.Layout != ""
+}
+
+func (r *Reference) String() string {
+	name := layoutPrefix + r.Layout
+	if !r.IsLayout() {
+		name = r.Named.String()
+	}
+
+	switch {
+	case r.Digest != "":
+		return name + "@" + r.Digest.String()
+	case r.Tag != "":
+		return name + ":" + r.Tag
+	default:
+		return name
+	}
+}
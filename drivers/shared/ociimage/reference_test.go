@@ -0,0 +1,79 @@
+package ociimage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	ci.Parallel(t)
+
+	const sha = "sha256:d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26"
+
+	cases := []struct {
+		ref    string
+		layout string
+		name   string
+		tag    string
+		digest string
+		str    string
+	}{
+		{
+			ref:  "alpine",
+			name: "docker.io/library/alpine",
+			tag:  "latest",
+			str:  "docker.io/library/alpine:latest",
+		},
+		{
+			ref:  "registry.example.com:5000/team/app:1.2",
+			name: "registry.example.com:5000/team/app",
+			tag:  "1.2",
+			str:  "registry.example.com:5000/team/app:1.2",
+		},
+		{
+			ref:    "alpine@" + sha,
+			name:   "docker.io/library/alpine",
+			digest: sha,
+			str:    "docker.io/library/alpine@" + sha,
+		},
+		{
+			ref:    "oci:/srv/images/app",
+			layout: "/srv/images/app",
+			str:    "oci:/srv/images/app",
+		},
+		{
+			ref:    "oci:local/app:v2",
+			layout: "local/app",
+			tag:    "v2",
+			str:    "oci:local/app:v2",
+		},
+		{
+			ref:    "oci:/srv/images/app@" + sha,
+			layout: "/srv/images/app",
+			digest: sha,
+			str:    "oci:/srv/images/app@" + sha,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.ref, func(t *testing.T) {
+			ref, err := ParseReference(tc.ref)
+			require.NoError(t, err)
+			require.Equal(t, tc.layout, ref.Layout)
+			require.Equal(t, tc.layout != "", ref.IsLayout())
+			if tc.name != "" {
+				require.Equal(t, tc.name, ref.Named.String())
+			}
+			require.Equal(t, tc.tag, ref.Tag)
+			require.Equal(t, tc.digest, ref.Digest.String())
+			require.Equal(t, tc.str, ref.String())
+		})
+	}
+
+	for _, bad := range []string{"oci:", "oci:/srv/app@sha256:nope", "UPPER/case", ""} {
+		_, err := ParseReference(bad)
+		require.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,255 @@
+package ociimage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/distribution/registry/client/auth/challenge"
+	digest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// dockerHubDomain is the domain of normalized Docker Hub references and
+	// dockerHubRegistry is the host serving its registry API
+	dockerHubDomain   = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+
+	// Docker image manifest media types, which are accepted alongside the
+	// OCI equivalents
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// manifestMediaTypes are the media types accepted when requesting manifests
+var manifestMediaTypes = []string{
+	v1.MediaTypeImageManifest,
+	v1.MediaTypeImageIndex,
+	mediaTypeDockerManifest,
+	mediaTypeDockerManifestList,
+}
+
+// Auth is the credentials used to authenticate with a registry.
+type Auth struct {
+	Username string
+	Password string
+}
+
+// registryFetcher retrieves images using the OCI distribution API. Bearer
+// tokens are requested from the registry's token service when it challenges
+// a request, and reused for the following requests.
+type registryFetcher struct {
+	client *http.Client
+	ref    *Reference
+	auth   *Auth
+
+	// base is the URL of the repository on the registry
+	base *url.URL
+
+	tokenLock sync.Mutex
+	token     string
+}
+
+func newRegistryFetcher(client *http.Client, ref *Reference, auth *Auth) *registryFetcher {
+	host := reference.Domain(ref.Named)
+	if host == dockerHubDomain {
+		host = dockerHubRegistry
+	}
+
+	return &registryFetcher{
+		client: client,
+		ref:    ref,
+		auth:   auth,
+		base: &url.URL{
+			Scheme: "https",
+			Host:   host,
+			Path:   "/v2/" + reference.Path(ref.Named),
+		},
+	}
+}
+
+func (f *registryFetcher) resolve(ctx context.Context) (v1.Descriptor, error) {
+	ref := f.ref.Tag
+	if f.ref.Digest != "" {
+		ref = f.ref.Digest.String()
+	}
+
+	resp, err := f.get(ctx, "manifests/"+ref)
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("failed to read manifest of %s: %v", f.ref, err)
+	}
+	if len(raw) > maxManifestSize {
+		return v1.Descriptor{}, fmt.Errorf("manifest of %s exceeds %d bytes", f.ref, maxManifestSize)
+	}
+
+	mediaType := resp.Header.Get("Content-Type")
+	if !isManifestMediaType(mediaType) {
+		mediaType = sniffMediaType(raw)
+	}
+
+	return v1.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(raw),
+		Size:      int64(len(raw)),
+	}, nil
+}
+
+func (f *registryFetcher) fetch(ctx context.Context, desc v1.Descriptor) (io.ReadCloser, error) {
+	kind := "blobs/"
+	if isManifestMediaType(desc.MediaType) {
+		kind = "manifests/"
+	}
+
+	resp, err := f.get(ctx, kind+desc.Digest.String())
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// get requests the path relative to the repository, authenticating and
+// retrying the request once if the registry challenges it.
+func (f *registryFetcher) get(ctx context.Context, path string) (*http.Response, error) {
+	u := *f.base
+	u.Path += "/" + path
+
+	resp, err := f.do(ctx, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenges := challenge.ResponseChallenges(resp)
+		resp.Body.Close()
+		if err := f.authenticate(ctx, challenges); err != nil {
+			return nil, fmt.Errorf("failed to authenticate with registry %s: %v", f.base.Host, err)
+		}
+		if resp, err = f.do(ctx, u.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to fetch %s of %s: unexpected response %q", path, f.ref, resp.Status)
+	}
+	return resp, nil
+}
+
+func (f *registryFetcher) do(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+
+	f.tokenLock.Lock()
+	token := f.token
+	f.tokenLock.Unlock()
+
+	switch {
+	case token != "":
+		req.Header.Set("Authorization", "Bearer "+token)
+	case f.auth != nil && f.auth.Username != "":
+		req.SetBasicAuth(f.auth.Username, f.auth.Password)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach registry %s: %v", f.base.Host, err)
+	}
+	return resp, nil
+}
+
+// authenticate requests a bearer token for pulling the repository from the
+// token service named in the registry's challenge.
+func (f *registryFetcher) authenticate(ctx context.Context, challenges []challenge.Challenge) error {
+	for _, c := range challenges {
+		if c.Scheme != "bearer" {
+			continue
+		}
+
+		realm := c.Parameters["realm"]
+		if realm == "" {
+			return fmt.Errorf("bearer challenge is missing a realm")
+		}
+		u, err := url.Parse(realm)
+		if err != nil {
+			return fmt.Errorf("invalid token realm %q: %v", realm, err)
+		}
+		q := u.Query()
+		if service := c.Parameters["service"]; service != "" {
+			q.Set("service", service)
+		}
+		q.Set("scope", "repository:"+reference.Path(f.ref.Named)+":pull")
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return err
+		}
+		if f.auth != nil && f.auth.Username != "" {
+			req.SetBasicAuth(f.auth.Username, f.auth.Password)
+		}
+
+		resp, err := f.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("token request failed: %s", resp.Status)
+		}
+
+		var body struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return fmt.Errorf("failed to decode token response: %v", err)
+		}
+
+		token := body.Token
+		if token == "" {
+			token = body.AccessToken
+		}
+		if token == "" {
+			return fmt.Errorf("token response did not include a token")
+		}
+
+		f.tokenLock.Lock()
+		f.token = token
+		f.tokenLock.Unlock()
+		return nil
+	}
+
+	// Registries using basic authentication only need the credentials,
+	// which are sent with every request when given.
+	if f.auth == nil || f.auth.Username == "" {
+		return fmt.Errorf("registry requires credentials")
+	}
+	return fmt.Errorf("credentials were rejected")
+}
+
+func isManifestMediaType(mediaType string) bool {
+	for _, mt := range manifestMediaTypes {
+		if mt == mediaType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,682 @@
+package ociimage
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	digest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// maxManifestSize is the size limit of manifests, indexes and image
+	// configs, which are read into memory
+	maxManifestSize = 4 << 20
+
+	// maxIndexDepth is the number of nested indexes followed when resolving
+	// the manifest of an image
+	maxIndexDepth = 4
+
+	// tmpPrefix is the prefix of files and directories being written into
+	// the store, which are removed by garbage collection if left behind
+	tmpPrefix = ".tmp-"
+)
+
+// ErrImageNotFound is returned when an image is not present in the store.
+var ErrImageNotFound = errors.New("image not found in cache")
+
+// Image is an image whose layers have been unpacked into the store.
+type Image struct {
+	// Digest is the digest of the image's manifest
+	Digest digest.Digest
+
+	// Config is the execution configuration of the image
+	Config v1.ImageConfig
+
+	// Layers is the paths of the image's unpacked layers, from the base
+	// layer up.
+	Layers []string
+}
+
+// Store is a content-addressed cache of images. Each layer is unpacked once
+// into a directory named by its digest and shared by all images containing
+// it. The store counts references to images and removes images, and the
+// layers no other image uses, once they have been unreferenced for the
+// removal delay.
+//
+// The store is laid out as:
+//
+//	blobs/<alg>/<hex>   image configs
+//	images/<alg>/<hex>  manifests of images whose layers are unpacked
+//	layers/<alg>/<hex>  unpacked layers
+//	tags.json           registry references resolved to manifest digests
+type Store struct {
+	ctx         context.Context
+	logger      hclog.Logger
+	dir         string
+	removeDelay time.Duration
+
+	// client is used to reach registries
+	client *http.Client
+
+	// lock syncs access to the fields below and to the store directory
+	// outside of layer unpacking
+	lock sync.Mutex
+
+	// refs is the set of callers referencing each image
+	refs map[digest.Digest]map[string]struct{}
+
+	// released is the time each unreferenced image was last released
+	released map[digest.Digest]time.Time
+
+	// pulls is the number of pulls in progress, during which garbage
+	// collection is deferred so it does not remove their layers
+	pulls int
+}
+
+// NewStore returns a store rooted at dir, creating it if needed. Images left
+// in the store by a previous process are removed after the removal delay
+// unless they are referenced again.
+func NewStore(ctx context.Context, logger hclog.Logger, dir string, removeDelay time.Duration) (*Store, error) {
+	for _, d := range []string{"blobs", "images", "layers"} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0700); err != nil {
+			return nil, fmt.Errorf("failed to create image store: %v", err)
+		}
+	}
+
+	s := &Store{
+		ctx:         ctx,
+		logger:      logger.Named("image_store"),
+		dir:         dir,
+		removeDelay: removeDelay,
+		client:      http.DefaultClient,
+		refs:        make(map[digest.Digest]map[string]struct{}),
+		released:    make(map[digest.Digest]time.Time),
+	}
+
+	images, err := s.images()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, d := range images {
+		s.released[d] = now
+	}
+	s.scheduleCollect()
+
+	return s, nil
+}
+
+// Acquire returns the referenced image, pulling it into the store unless it
+// is already cached, and adds a reference to it for callerID. Registry tags
+// are only resolved again when forcePull is set; images in local layouts are
+// always resolved.
+func (s *Store) Acquire(ctx context.Context, ref *Reference, auth *Auth, forcePull bool, callerID string) (*Image, error) {
+	if !forcePull && !ref.IsLayout() {
+		s.lock.Lock()
+		img, err := s.cachedImageLocked(ref)
+		if err == nil {
+			s.incrementLocked(img.Digest, callerID)
+		}
+		s.lock.Unlock()
+
+		if err == nil {
+			return img, nil
+		}
+		if err != ErrImageNotFound {
+			return nil, err
+		}
+	}
+
+	var f fetcher
+	if ref.IsLayout() {
+		f = newLayoutFetcher(ref)
+	} else {
+		f = newRegistryFetcher(s.client, ref, auth)
+	}
+
+	s.lock.Lock()
+	s.pulls++
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		s.pulls--
+		s.lock.Unlock()
+	}()
+
+	d, err := s.pull(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull image %s: %v", ref, err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !ref.IsLayout() {
+		if err := s.setTagLocked(ref, d); err != nil {
+			return nil, err
+		}
+	}
+
+	img, err := s.imageLocked(d)
+	if err != nil {
+		return nil, err
+	}
+	s.incrementLocked(d, callerID)
+	return img, nil
+}
+
+// AcquireDigest returns the image with the given manifest digest and adds
+// a reference to it for callerID. It returns ErrImageNotFound if the image
+// is not in the store.
+func (s *Store) AcquireDigest(d digest.Digest, callerID string) (*Image, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	img, err := s.imageLocked(d)
+	if err != nil {
+		return nil, err
+	}
+	s.incrementLocked(d, callerID)
+	return img, nil
+}
+
+// Release removes callerID's reference to the image. The image is removed
+// from the store once it has been unreferenced for the removal delay.
+func (s *Store) Release(d digest.Digest, callerID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	references, ok := s.refs[d]
+	if !ok {
+		return
+	}
+	delete(references, callerID)
+	s.logger.Debug("image reference count decremented", "image_digest", d, "references", len(references))
+	if len(references) != 0 {
+		return
+	}
+
+	delete(s.refs, d)
+	s.released[d] = time.Now()
+	s.scheduleCollect()
+}
+
+func (s *Store) incrementLocked(d digest.Digest, callerID string) {
+	references, ok := s.refs[d]
+	if !ok {
+		references = make(map[string]struct{})
+		s.refs[d] = references
+	}
+	delete(s.released, d)
+
+	if _, ok := references[callerID]; !ok {
+		references[callerID] = struct{}{}
+		s.logger.Debug("image reference count incremented", "image_digest", d, "references", len(references))
+	}
+}
+
+// cachedImageLocked returns the image the registry reference resolves to
+// without contacting the registry.
+func (s *Store) cachedImageLocked(ref *Reference) (*Image, error) {
+	tags, err := s.tagsLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	// Digest references are recorded as they may name an index rather than
+	// the manifest of the image
+	d := digest.Digest(tags[ref.String()])
+	if d == "" {
+		d = ref.Digest
+	}
+	if d == "" {
+		return nil, ErrImageNotFound
+	}
+	return s.imageLocked(d)
+}
+
+// imageLocked loads the image with the manifest digest d from the store.
+func (s *Store) imageLocked(d digest.Digest) (*Image, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(s.path("images", d))
+	if os.IsNotExist(err) {
+		return nil, ErrImageNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read image manifest: %v", err)
+	}
+
+	var manifest v1.Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse image manifest: %v", err)
+	}
+
+	raw, err = os.ReadFile(s.path("blobs", manifest.Config.Digest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image config: %v", err)
+	}
+	var config v1.Image
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse image config: %v", err)
+	}
+
+	img := &Image{
+		Digest: d,
+		Config: config.Config,
+	}
+	for _, layer := range manifest.Layers {
+		p := s.path("layers", layer.Digest)
+		if _, err := os.Stat(p); err != nil {
+			return nil, fmt.Errorf("image layer %s is missing from the cache: %v", layer.Digest, err)
+		}
+		img.Layers = append(img.Layers, p)
+	}
+	return img, nil
+}
+
+// pull fetches the image's manifest and config and unpacks any layers not
+// already in the store. It returns the digest of the image's manifest.
+func (s *Store) pull(ctx context.Context, f fetcher) (digest.Digest, error) {
+	desc, err := f.resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var raw []byte
+	for depth := 0; ; depth++ {
+		if depth > maxIndexDepth {
+			return "", fmt.Errorf("image indexes are nested more than %d deep", maxIndexDepth)
+		}
+
+		raw, err = fetchBytes(ctx, f, desc)
+		if err != nil {
+			return "", err
+		}
+		if !isManifestMediaType(desc.MediaType) {
+			desc.MediaType = sniffMediaType(raw)
+		}
+
+		if desc.MediaType != v1.MediaTypeImageIndex && desc.MediaType != mediaTypeDockerManifestList {
+			break
+		}
+
+		var index v1.Index
+		if err := json.Unmarshal(raw, &index); err != nil {
+			return "", fmt.Errorf("failed to parse image index: %v", err)
+		}
+		if desc, err = selectPlatform(index); err != nil {
+			return "", err
+		}
+	}
+
+	var manifest v1.Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return "", fmt.Errorf("failed to parse image manifest: %v", err)
+	}
+
+	// Fail early rather than after downloading the layers
+	for _, layer := range manifest.Layers {
+		if _, err := layerDecompressor(layer.MediaType); err != nil {
+			return "", err
+		}
+	}
+
+	config, err := fetchBytes(ctx, f, manifest.Config)
+	if err != nil {
+		return "", err
+	}
+	if err := s.writeFile(s.path("blobs", manifest.Config.Digest), config); err != nil {
+		return "", err
+	}
+
+	for _, layer := range manifest.Layers {
+		if err := s.unpackLayer(ctx, f, layer); err != nil {
+			return "", fmt.Errorf("failed to unpack layer %s: %v", layer.Digest, err)
+		}
+	}
+
+	// The image is only recorded once all of its layers are in place
+	if err := s.writeFile(s.path("images", desc.Digest), raw); err != nil {
+		return "", err
+	}
+	s.logger.Debug("pulled image", "image_digest", desc.Digest, "layers", len(manifest.Layers))
+	return desc.Digest, nil
+}
+
+// unpackLayer extracts the layer into a temporary directory, verifying its
+// digest, and then moves it into place.
+func (s *Store) unpackLayer(ctx context.Context, f fetcher, desc v1.Descriptor) error {
+	dest := s.path("layers", desc.Digest)
+	if _, err := os.Stat(dest); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dest), tmpPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	blob, err := f.fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	verifier := desc.Digest.Verifier()
+	r := io.TeeReader(blob, verifier)
+
+	decompress, err := layerDecompressor(desc.MediaType)
+	if err != nil {
+		return err
+	}
+	tr, err := decompress(r)
+	if err != nil {
+		return err
+	}
+	if err := unpack(tmp, tr); err != nil {
+		return err
+	}
+
+	// Read any trailing data so the whole blob is verified
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("digest mismatch")
+	}
+
+	// The layer may have been unpacked concurrently by another pull
+	if err := os.Rename(tmp, dest); err != nil {
+		if _, serr := os.Stat(dest); serr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// layerDecompressor returns the function used to read the tar stream of a
+// layer with the given media type.
+func layerDecompressor(mediaType string) (func(io.Reader) (io.Reader, error), error) {
+	switch {
+	case strings.HasSuffix(mediaType, "tar+gzip"), strings.HasSuffix(mediaType, "tar.gzip"):
+		return func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }, nil
+	case strings.HasSuffix(mediaType, "tar"):
+		return func(r io.Reader) (io.Reader, error) { return r, nil }, nil
+	default:
+		return nil, fmt.Errorf("unsupported layer media type %q", mediaType)
+	}
+}
+
+// collect removes images that have been unreferenced for the removal delay,
+// followed by the configs, tags and layers no longer used by any image.
+func (s *Store) collect() {
+	select {
+	case <-s.ctx.Done():
+		return
+	default:
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Pulls in progress may be unpacking layers not yet referenced by an
+	// image
+	if s.pulls > 0 {
+		s.scheduleCollect()
+		return
+	}
+
+	images, err := s.images()
+	if err != nil {
+		s.logger.Warn("failed to list cached images", "error", err)
+		return
+	}
+
+	now := time.Now()
+	live := map[digest.Digest]struct{}{}
+	for _, d := range images {
+		if _, ok := s.refs[d]; !ok {
+			released, ok := s.released[d]
+			if !ok {
+				// Guard against images added to the directory out of band
+				s.released[d] = now
+				s.scheduleCollect()
+			}
+			if ok && now.Sub(released) >= s.removeDelay {
+				if err := os.Remove(s.path("images", d)); err != nil {
+					s.logger.Warn("failed to remove image", "image_digest", d, "error", err)
+				} else {
+					s.logger.Debug("removed unused image", "image_digest", d)
+					delete(s.released, d)
+					continue
+				}
+			}
+		}
+
+		raw, err := os.ReadFile(s.path("images", d))
+		if err != nil {
+			s.logger.Warn("failed to read image manifest", "image_digest", d, "error", err)
+			return
+		}
+		var manifest v1.Manifest
+		if err := json.Unmarshal(raw, &manifest); err != nil {
+			s.logger.Warn("failed to parse image manifest", "image_digest", d, "error", err)
+			return
+		}
+		live[d] = struct{}{}
+		live[manifest.Config.Digest] = struct{}{}
+		for _, layer := range manifest.Layers {
+			live[layer.Digest] = struct{}{}
+		}
+	}
+
+	if tags, err := s.tagsLocked(); err == nil {
+		changed := false
+		for tag, d := range tags {
+			if _, ok := live[digest.Digest(d)]; !ok {
+				delete(tags, tag)
+				changed = true
+			}
+		}
+		if changed {
+			if err := s.writeTagsLocked(tags); err != nil {
+				s.logger.Warn("failed to write image tags", "error", err)
+			}
+		}
+	}
+
+	for _, kind := range []string{"blobs", "layers"} {
+		s.removeUnused(kind, live)
+	}
+}
+
+// removeUnused removes the entries of the content-addressed directory that
+// are not in the live set.
+func (s *Store) removeUnused(kind string, live map[digest.Digest]struct{}) {
+	algs, err := os.ReadDir(filepath.Join(s.dir, kind))
+	if err != nil {
+		s.logger.Warn("failed to read image cache", "error", err)
+		return
+	}
+	for _, alg := range algs {
+		entries, err := os.ReadDir(filepath.Join(s.dir, kind, alg.Name()))
+		if err != nil {
+			s.logger.Warn("failed to read image cache", "error", err)
+			continue
+		}
+		for _, entry := range entries {
+			d := digest.NewDigestFromEncoded(digest.Algorithm(alg.Name()), entry.Name())
+			if _, ok := live[d]; ok {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(s.dir, kind, alg.Name(), entry.Name())); err != nil {
+				s.logger.Warn("failed to remove unused image data", "path", entry.Name(), "error", err)
+				continue
+			}
+			s.logger.Trace("removed unused image data", "kind", kind, "digest", d)
+		}
+	}
+}
+
+// scheduleCollect runs garbage collection once the removal delay has passed.
+func (s *Store) scheduleCollect() {
+	time.AfterFunc(s.removeDelay, s.collect)
+}
+
+// images returns the digests of the images in the store.
+func (s *Store) images() ([]digest.Digest, error) {
+	algs, err := os.ReadDir(filepath.Join(s.dir, "images"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached images: %v", err)
+	}
+
+	var images []digest.Digest
+	for _, alg := range algs {
+		entries, err := os.ReadDir(filepath.Join(s.dir, "images", alg.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cached images: %v", err)
+		}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), tmpPrefix) {
+				continue
+			}
+			images = append(images, digest.NewDigestFromEncoded(digest.Algorithm(alg.Name()), entry.Name()))
+		}
+	}
+	return images, nil
+}
+
+func (s *Store) tagsLocked() (map[string]string, error) {
+	tags := map[string]string{}
+	raw, err := os.ReadFile(filepath.Join(s.dir, "tags.json"))
+	if os.IsNotExist(err) {
+		return tags, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read image tags: %v", err)
+	}
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		return nil, fmt.Errorf("failed to parse image tags: %v", err)
+	}
+	return tags, nil
+}
+
+func (s *Store) setTagLocked(ref *Reference, d digest.Digest) error {
+	tags, err := s.tagsLocked()
+	if err != nil {
+		return err
+	}
+	tags[ref.String()] = d.String()
+	return s.writeTagsLocked(tags)
+}
+
+func (s *Store) writeTagsLocked(tags map[string]string) error {
+	raw, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+	return s.writeFile(filepath.Join(s.dir, "tags.json"), raw)
+}
+
+// path returns the path of the content with digest d in the given directory
+// of the store.
+func (s *Store) path(kind string, d digest.Digest) string {
+	return filepath.Join(s.dir, kind, d.Algorithm().String(), d.Encoded())
+}
+
+// writeFile atomically writes the file, creating its parent directory.
+func (s *Store) writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), tmpPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// fetchBytes reads the manifest or config described by desc and verifies it
+// against the descriptor's digest.
+func fetchBytes(ctx context.Context, f fetcher, desc v1.Descriptor) ([]byte, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, err
+	}
+
+	r, err := f.fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(r, maxManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxManifestSize {
+		return nil, fmt.Errorf("%s exceeds %d bytes", desc.Digest, maxManifestSize)
+	}
+	if desc.Digest.Algorithm().FromBytes(raw) != desc.Digest {
+		return nil, fmt.Errorf("digest mismatch for %s", desc.Digest)
+	}
+	return raw, nil
+}
+
+// selectPlatform returns the manifest in the index for the client's
+// platform.
+func selectPlatform(index v1.Index) (v1.Descriptor, error) {
+	for _, desc := range index.Manifests {
+		if desc.Platform == nil ||
+			(desc.Platform.OS == runtime.GOOS && desc.Platform.Architecture == runtime.GOARCH) {
+			return desc, nil
+		}
+	}
+	return v1.Descriptor{}, fmt.Errorf("image has no manifest for %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// sniffMediaType returns the media type of a manifest or index from its
+// content, for sources that do not report it.
+func sniffMediaType(raw []byte) string {
+	var probe struct {
+		MediaType string            `json:"mediaType"`
+		Manifests []json.RawMessage `json:"manifests"`
+	}
+	if err := json.Unmarshal(raw, &probe); err == nil {
+		if probe.MediaType != "" {
+			return probe.MediaType
+		}
+		if probe.Manifests != nil {
+			return v1.MediaTypeImageIndex
+		}
+	}
+	return v1.MediaTypeImageManifest
+}
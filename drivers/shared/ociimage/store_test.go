@@ -0,0 +1,334 @@
+package ociimage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	digest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+var (
+	testBaseLayer = []TestFile{
+		{Path: "bin/app", Contents: "v1", Mode: 0755},
+		{Path: "etc/app.conf", Contents: "conf"},
+		{Path: "etc/old.conf", Contents: "old"},
+		{Path: "data", Dir: true},
+		{Path: "data/a", Contents: "a"},
+		{Path: "conf", Link: "/etc/app.conf"},
+	}
+
+	testAppLayer = []TestFile{
+		{Path: "bin/app", Contents: "v2", Mode: 0755},
+		{Path: "etc/.wh.old.conf"},
+		{Path: "data/.wh..wh..opq"},
+		{Path: "data/b", Contents: "b"},
+	}
+
+	testImageConfig = v1.ImageConfig{
+		Entrypoint: []string{"/bin/app"},
+		Cmd:        []string{"serve"},
+		Env:        []string{"PATH=/bin"},
+		WorkingDir: "/data",
+	}
+)
+
+func testStore(t *testing.T, removeDelay time.Duration) *Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	s, err := NewStore(ctx, testlog.HCLogger(t), t.TempDir(), removeDelay)
+	require.NoError(t, err)
+	return s
+}
+
+func mustParse(t *testing.T, ref string) *Reference {
+	r, err := ParseReference(ref)
+	require.NoError(t, err)
+	return r
+}
+
+func TestStore_AcquireLayout(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	layout := t.TempDir()
+	d := WriteTestLayout(t, layout, "v1", testImageConfig, testBaseLayer, testAppLayer)
+
+	s := testStore(t, time.Hour)
+	img, err := s.Acquire(context.Background(), mustParse(t, "oci:"+layout+":v1"), nil, false, "task1")
+	require.NoError(t, err)
+	require.Equal(t, d, img.Digest)
+	require.Equal(t, testImageConfig, img.Config)
+	require.Len(t, img.Layers, 2)
+
+	// Whiteouts are converted for overlayfs
+	fi, err := os.Lstat(filepath.Join(img.Layers[1], "etc/old.conf"))
+	require.NoError(t, err)
+	require.Equal(t, os.ModeCharDevice|os.ModeDevice, fi.Mode().Type())
+	require.Zero(t, fi.Sys().(*syscall.Stat_t).Rdev)
+
+	buf := make([]byte, 1)
+	_, err = unix.Getxattr(filepath.Join(img.Layers[1], "data"), "trusted.overlay.opaque", buf)
+	require.NoError(t, err)
+	require.Equal(t, "y", string(buf))
+
+	// The image is found by digest
+	again, err := s.AcquireDigest(d, "task2")
+	require.NoError(t, err)
+	require.Equal(t, img, again)
+
+	_, err = s.AcquireDigest(digest.FromString("missing"), "task3")
+	require.Equal(t, ErrImageNotFound, err)
+
+	// The mounted image shows the merged layers
+	dir := t.TempDir()
+	rootfs := filepath.Join(dir, "rootfs")
+	require.NoError(t, Mount(img, filepath.Join(dir, "upper"), filepath.Join(dir, "work"), rootfs))
+	defer Unmount(rootfs)
+
+	app, err := os.ReadFile(filepath.Join(rootfs, "bin/app"))
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(app))
+
+	_, err = os.Stat(filepath.Join(rootfs, "etc/old.conf"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(rootfs, "data/a"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(rootfs, "data/b"))
+	require.NoError(t, err)
+
+	link, err := os.Readlink(filepath.Join(rootfs, "conf"))
+	require.NoError(t, err)
+	require.Equal(t, "/etc/app.conf", link)
+
+	// Writes go to the upper directory and leave the cached layers untouched
+	require.NoError(t, os.WriteFile(filepath.Join(rootfs, "bin/app"), []byte("v3"), 0755))
+	app, err = os.ReadFile(filepath.Join(img.Layers[1], "bin/app"))
+	require.NoError(t, err)
+	require.Equal(t, "v2", string(app))
+
+	require.NoError(t, Unmount(rootfs))
+	require.NoError(t, Unmount(rootfs))
+}
+
+func TestStore_UnpackEscape(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	outside := t.TempDir()
+	layout := t.TempDir()
+	WriteTestLayout(t, layout, "v1", v1.ImageConfig{}, []TestFile{
+		{Path: "escape", Link: outside},
+		{Path: "escape/pwned", Contents: "pwned"},
+		{Path: "../../up", Contents: "up"},
+	})
+
+	s := testStore(t, time.Hour)
+	img, err := s.Acquire(context.Background(), mustParse(t, "oci:"+layout+":v1"), nil, false, "task1")
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(outside)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = os.Stat(filepath.Join(img.Layers[0], outside, "pwned"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(img.Layers[0], "up"))
+	require.NoError(t, err)
+}
+
+func TestStore_DigestMismatch(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	layout := t.TempDir()
+	WriteTestLayout(t, layout, "v1", v1.ImageConfig{}, []TestFile{{Path: "file", Contents: "ok"}})
+
+	// Swap the layer's content for another layer
+	var manifest v1.Manifest
+	raw, err := os.ReadFile(filepath.Join(layout, "index.json"))
+	require.NoError(t, err)
+	var index v1.Index
+	require.NoError(t, json.Unmarshal(raw, &index))
+	raw, err = os.ReadFile(filepath.Join(layout, "blobs", "sha256", index.Manifests[0].Digest.Encoded()))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &manifest))
+
+	other := t.TempDir()
+	WriteTestLayout(t, other, "v1", v1.ImageConfig{}, []TestFile{{Path: "file", Contents: "evil"}})
+	var otherIndex v1.Index
+	raw, err = os.ReadFile(filepath.Join(other, "index.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &otherIndex))
+	var otherManifest v1.Manifest
+	raw, err = os.ReadFile(filepath.Join(other, "blobs", "sha256", otherIndex.Manifests[0].Digest.Encoded()))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &otherManifest))
+
+	evil, err := os.ReadFile(filepath.Join(other, "blobs", "sha256", otherManifest.Layers[0].Digest.Encoded()))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(layout, "blobs", "sha256", manifest.Layers[0].Digest.Encoded()), evil, 0644))
+
+	s := testStore(t, time.Hour)
+	_, err = s.Acquire(context.Background(), mustParse(t, "oci:"+layout+":v1"), nil, false, "task1")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "digest mismatch")
+
+	// Nothing is left in the cache
+	entries, err := os.ReadDir(filepath.Join(s.dir, "layers", "sha256"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestStore_GC(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	layout := t.TempDir()
+	WriteTestLayout(t, layout, "base", v1.ImageConfig{}, testBaseLayer)
+	WriteTestLayout(t, layout, "app", testImageConfig, testBaseLayer, testAppLayer)
+
+	s := testStore(t, 100*time.Millisecond)
+	base, err := s.Acquire(context.Background(), mustParse(t, "oci:"+layout+":base"), nil, false, "task1")
+	require.NoError(t, err)
+	app, err := s.Acquire(context.Background(), mustParse(t, "oci:"+layout+":app"), nil, false, "task2")
+	require.NoError(t, err)
+
+	// The base layer is shared
+	require.Equal(t, base.Layers[0], app.Layers[0])
+
+	// Released images are kept for the delay and can be reacquired
+	s.Release(app.Digest, "task2")
+	_, err = s.AcquireDigest(app.Digest, "task3")
+	require.NoError(t, err)
+	time.Sleep(200 * time.Millisecond)
+	require.DirExists(t, app.Layers[1])
+
+	// Once the delay passes only the unused layer is removed
+	s.Release(app.Digest, "task3")
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(app.Layers[1])
+		return os.IsNotExist(err)
+	}, 5*time.Second, 50*time.Millisecond)
+	require.DirExists(t, base.Layers[0])
+	_, err = s.AcquireDigest(app.Digest, "task3")
+	require.Equal(t, ErrImageNotFound, err)
+
+	s.Release(base.Digest, "task1")
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(base.Layers[0])
+		return os.IsNotExist(err)
+	}, 5*time.Second, 50*time.Millisecond)
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, "blobs", "sha256"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+// testRegistry serves the images of an OCI layout with the distribution API,
+// requiring a bearer token obtained with the given credentials.
+func testRegistry(t *testing.T, layout, repo string, auth *Auth) (*httptest.Server, *int32) {
+	var manifestRequests int32
+	const token = "secret-token"
+
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, pass, _ := r.BasicAuth()
+			if user != auth.Username || pass != auth.Password ||
+				r.URL.Query().Get("scope") != "repository:"+repo+":pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w, `{"token": %q}`, token)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		prefix := "/v2/" + repo + "/"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		kind, ref := filepath.Split(strings.TrimPrefix(r.URL.Path, prefix))
+
+		if kind == "manifests/" {
+			atomic.AddInt32(&manifestRequests, 1)
+			if _, err := digest.Parse(ref); err != nil {
+				desc, err := newLayoutFetcher(&Reference{Layout: layout, Tag: ref}).resolve(r.Context())
+				if err != nil {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				ref = desc.Digest.String()
+			}
+			w.Header().Set("Content-Type", v1.MediaTypeImageManifest)
+		}
+
+		d, err := digest.Parse(ref)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join(layout, "blobs", d.Algorithm().String(), d.Encoded()))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &manifestRequests
+}
+
+func TestStore_AcquireRegistry(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	layout := t.TempDir()
+	d := WriteTestLayout(t, layout, "v1", testImageConfig, testBaseLayer, testAppLayer)
+
+	auth := &Auth{Username: "user", Password: "pass"}
+	srv, manifestRequests := testRegistry(t, layout, "team/app", auth)
+
+	s := testStore(t, time.Hour)
+	s.client = srv.Client()
+	ref := mustParse(t, strings.TrimPrefix(srv.URL, "https://")+"/team/app:v1")
+
+	// Bad credentials are rejected
+	_, err := s.Acquire(context.Background(), ref, &Auth{Username: "user", Password: "wrong"}, false, "task1")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to authenticate")
+
+	img, err := s.Acquire(context.Background(), ref, auth, false, "task1")
+	require.NoError(t, err)
+	require.Equal(t, d, img.Digest)
+	require.Equal(t, testImageConfig, img.Config)
+	require.Len(t, img.Layers, 2)
+
+	// Cached tags are not resolved again unless forced
+	requests := atomic.LoadInt32(manifestRequests)
+	_, err = s.Acquire(context.Background(), ref, auth, false, "task2")
+	require.NoError(t, err)
+	require.Equal(t, requests, atomic.LoadInt32(manifestRequests))
+
+	_, err = s.Acquire(context.Background(), ref, auth, true, "task3")
+	require.NoError(t, err)
+	require.Greater(t, atomic.LoadInt32(manifestRequests), requests)
+}
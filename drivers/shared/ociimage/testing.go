@@ -0,0 +1,170 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	testing "github.com/mitchellh/go-testing-interface"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// TestFile is an entry in a layer of an image written by WriteTestLayout.
+type TestFile struct {
+	// Path is the path of the entry in the image
+	Path string
+
+	// Contents is the contents of a regular file
+	Contents string
+
+	// HostPath is the path of a host file copied into the layer as a
+	// regular file, following symlinks
+	HostPath string
+
+	// Link is the target of a symlink
+	Link string
+
+	// Dir marks the entry as a directory
+	Dir bool
+
+	// Mode is the permission bits of the entry, which default to 0755 for
+	// directories and host files and 0644 for other regular files
+	Mode int64
+}
+
+// WriteTestLayout writes an image with the given config and layers into
+// the OCI image layout at dir, creating the layout if needed, and tags it.
+// Layers are listed from the base layer up. It returns the digest of the
+// image's manifest.
+func WriteTestLayout(t testing.T, dir, tag string, config v1.ImageConfig, layers ...[]TestFile) digest.Digest {
+	image := v1.Image{
+		Architecture: runtime.GOARCH,
+		OS:           runtime.GOOS,
+		Config:       config,
+		RootFS:       v1.RootFS{Type: "layers"},
+	}
+
+	manifest := v1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: v1.MediaTypeImageManifest,
+	}
+	for _, files := range layers {
+		raw := testLayer(t, files)
+		image.RootFS.DiffIDs = append(image.RootFS.DiffIDs, digest.FromBytes(raw))
+
+		var gz bytes.Buffer
+		w := gzip.NewWriter(&gz)
+		if _, err := w.Write(raw); err != nil {
+			t.Fatalf("failed to compress layer: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed to compress layer: %v", err)
+		}
+		manifest.Layers = append(manifest.Layers, writeTestBlob(t, dir, v1.MediaTypeImageLayerGzip, gz.Bytes()))
+	}
+
+	manifest.Config = writeTestBlob(t, dir, v1.MediaTypeImageConfig, testJSON(t, image))
+	desc := writeTestBlob(t, dir, v1.MediaTypeImageManifest, testJSON(t, manifest))
+	desc.Annotations = map[string]string{v1.AnnotationRefName: tag}
+
+	index := v1.Index{Versioned: specs.Versioned{SchemaVersion: 2}}
+	if raw, err := os.ReadFile(filepath.Join(dir, "index.json")); err == nil {
+		if err := json.Unmarshal(raw, &index); err != nil {
+			t.Fatalf("failed to parse layout index: %v", err)
+		}
+	}
+	manifests := []v1.Descriptor{desc}
+	for _, m := range index.Manifests {
+		if m.Annotations[v1.AnnotationRefName] != tag {
+			manifests = append(manifests, m)
+		}
+	}
+	index.Manifests = manifests
+
+	layout := v1.ImageLayout{Version: v1.ImageLayoutVersion}
+	if err := os.WriteFile(filepath.Join(dir, v1.ImageLayoutFile), testJSON(t, layout), 0644); err != nil {
+		t.Fatalf("failed to write layout: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.json"), testJSON(t, index), 0644); err != nil {
+		t.Fatalf("failed to write layout index: %v", err)
+	}
+	return desc.Digest
+}
+
+// testLayer returns an uncompressed layer holding the files.
+func testLayer(t testing.T, files []TestFile) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:     f.Path,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+		}
+		contents := []byte(f.Contents)
+
+		switch {
+		case f.Dir:
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0755
+		case f.Link != "":
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = f.Link
+			hdr.Mode = 0777
+		case f.HostPath != "":
+			raw, err := os.ReadFile(f.HostPath)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", f.HostPath, err)
+			}
+			contents = raw
+			hdr.Mode = 0755
+		}
+		if f.Mode != 0 {
+			hdr.Mode = f.Mode
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Size = int64(len(contents))
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("failed to write layer: %v", err)
+		}
+		if _, err := tw.Write(contents[:hdr.Size]); err != nil {
+			t.Fatalf("failed to write layer: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to write layer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func writeTestBlob(t testing.T, dir, mediaType string, data []byte) v1.Descriptor {
+	d := digest.FromBytes(data)
+	path := filepath.Join(dir, "blobs", d.Algorithm().String(), d.Encoded())
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create blobs dir: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write blob: %v", err)
+	}
+	return v1.Descriptor{
+		MediaType: mediaType,
+		Digest:    d,
+		Size:      int64(len(data)),
+	}
+}
+
+func testJSON(t testing.T, v interface{}) []byte {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", v, err)
+	}
+	return raw
+}
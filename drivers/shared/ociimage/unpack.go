@@ -0,0 +1,148 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+)
+
+const (
+	// whiteoutPrefix marks an entry removing the named path from the layers
+	// below and whiteoutOpaque marks a directory whose contents in the layers
+	// below are hidden
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+
+	// paxXattrPrefix is the prefix of PAX records holding extended
+	// attributes
+	paxXattrPrefix = "SCHILY.xattr."
+)
+
+// unpack extracts the tar stream of a layer into root, converting OCI
+// whiteouts into their overlayfs equivalents so the layer can be used as a
+// lower directory of an overlay mount. Entries are never written outside of
+// root, even when following symlinks created by earlier entries.
+func unpack(root string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read layer: %v", err)
+		}
+
+		name := filepath.Clean(string(filepath.Separator) + hdr.Name)
+		if name == string(filepath.Separator) {
+			continue
+		}
+
+		dir, base := filepath.Split(name)
+		parent, err := securejoin.SecureJoin(root, dir)
+		if err != nil {
+			return fmt.Errorf("failed to unpack %s: %v", hdr.Name, err)
+		}
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return fmt.Errorf("failed to unpack %s: %v", hdr.Name, err)
+		}
+
+		switch {
+		case base == whiteoutOpaque:
+			err = setOpaque(parent)
+		case strings.HasPrefix(base, whiteoutPrefix):
+			target := filepath.Join(parent, strings.TrimPrefix(base, whiteoutPrefix))
+			if err = os.RemoveAll(target); err == nil {
+				err = whiteout(target)
+			}
+		default:
+			err = unpackEntry(root, filepath.Join(parent, base), hdr, tr)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to unpack %s: %v", hdr.Name, err)
+		}
+	}
+}
+
+// unpackEntry creates the file, directory, link or device described by hdr
+// at target.
+func unpackEntry(root, target string, hdr *tar.Header, r io.Reader) error {
+	// Entries replace anything an earlier entry left at the same path, other
+	// than directories which are merged
+	if fi, err := os.Lstat(target); err == nil && !(fi.IsDir() && hdr.Typeflag == tar.TypeDir) {
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := os.Mkdir(target, 0700); err != nil && !os.IsExist(err) {
+			return err
+		}
+
+	case tar.TypeReg, tar.TypeRegA:
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, r); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+
+	case tar.TypeSymlink:
+		if err := os.Symlink(hdr.Linkname, target); err != nil {
+			return err
+		}
+
+	case tar.TypeLink:
+		// Hard links share the metadata of their target so there is nothing
+		// else to set
+		dir, base := filepath.Split(filepath.Clean(string(filepath.Separator) + hdr.Linkname))
+		parent, err := securejoin.SecureJoin(root, dir)
+		if err != nil {
+			return err
+		}
+		if err := os.Link(filepath.Join(parent, base), target); err != nil {
+			return fmt.Errorf("hard link target must be in the same layer: %v", err)
+		}
+		return nil
+
+	case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+		if err := mknod(target, hdr); err != nil {
+			return err
+		}
+
+	default:
+		// Other entry types carry no content
+		return nil
+	}
+
+	if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+		return err
+	}
+	if err := setXattrs(target, hdr.PAXRecords); err != nil {
+		return err
+	}
+	if hdr.Typeflag == tar.TypeSymlink {
+		return nil
+	}
+
+	// The mode is set after changing the owner, which clears setuid bits
+	if err := os.Chmod(target, hdr.FileInfo().Mode()); err != nil {
+		return err
+	}
+	if hdr.Typeflag != tar.TypeDir {
+		return os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+//go:build !linux
+
+package ociimage
+
+import (
+	"archive/tar"
+	"fmt"
+)
+
+func mknod(path string, hdr *tar.Header) error {
+	return fmt.Errorf("device files are only supported on linux")
+}
+
+func whiteout(path string) error {
+	return fmt.Errorf("whiteouts are only supported on linux")
+}
+
+func setOpaque(dir string) error {
+	return fmt.Errorf("opaque directories are only supported on linux")
+}
+
+func setXattrs(path string, records map[string]string) error {
+	return nil
+}
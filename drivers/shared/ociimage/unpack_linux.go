@@ -0,0 +1,70 @@
+package ociimage
+
+import (
+	"archive/tar"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// xattrAllowedPrefixes are the namespaces of extended attributes kept when
+// unpacking layers. Trusted attributes in particular must not be set, as
+// overlayfs stores its own metadata in them.
+var xattrAllowedPrefixes = []string{
+	"security.capability",
+	"user.",
+}
+
+// mknod creates the character device, block device or fifo described by hdr.
+func mknod(path string, hdr *tar.Header) error {
+	mode := uint32(hdr.Mode & 07777)
+	switch hdr.Typeflag {
+	case tar.TypeChar:
+		mode |= unix.S_IFCHR
+	case tar.TypeBlock:
+		mode |= unix.S_IFBLK
+	case tar.TypeFifo:
+		mode |= unix.S_IFIFO
+	}
+	return unix.Mknod(path, mode, int(unix.Mkdev(uint32(hdr.Devmajor), uint32(hdr.Devminor))))
+}
+
+// whiteout creates an overlayfs whiteout at path, a 0/0 character device
+// hiding the path in the layers below.
+func whiteout(path string) error {
+	return unix.Mknod(path, unix.S_IFCHR, 0)
+}
+
+// setOpaque marks the directory as opaque to overlayfs, hiding its contents
+// in the layers below.
+func setOpaque(dir string) error {
+	return unix.Setxattr(dir, "trusted.overlay.opaque", []byte("y"), 0)
+}
+
+// setXattrs sets the extended attributes recorded in the PAX records of a
+// tar entry on path.
+func setXattrs(path string, records map[string]string) error {
+	for key, value := range records {
+		if !strings.HasPrefix(key, paxXattrPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, paxXattrPrefix)
+		if !xattrAllowed(name) {
+			continue
+		}
+		err := unix.Lsetxattr(path, name, []byte(value), 0)
+		if err != nil && err != unix.ENOTSUP {
+			return err
+		}
+	}
+	return nil
+}
+
+func xattrAllowed(name string) bool {
+	for _, prefix := range xattrAllowedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
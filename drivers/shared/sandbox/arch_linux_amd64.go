@@ -0,0 +1,17 @@
+package sandbox
+
+import "golang.org/x/net/bpf"
+
+// auditArch is AUDIT_ARCH_X86_64.
+const auditArch = 0xc000003e
+
+// x32SyscallBit marks syscalls made through the x32 ABI, which shares
+// AUDIT_ARCH_X86_64 but uses different syscall numbers.
+const x32SyscallBit = 0x40000000
+
+// archFilter rejects x32 syscalls, which would otherwise bypass the filter.
+// It runs with the syscall number loaded.
+var archFilter = []bpf.Instruction{
+	bpf.JumpIf{Cond: bpf.JumpGreaterOrEqual, Val: x32SyscallBit, SkipFalse: 1},
+	bpf.RetConstant{Val: seccompRetErrno | defaultErrno},
+}
@@ -0,0 +1,9 @@
+package sandbox
+
+import "golang.org/x/net/bpf"
+
+// auditArch is AUDIT_ARCH_AARCH64.
+const auditArch = 0xc00000b7
+
+// archFilter needs no extra checks on arm64.
+var archFilter []bpf.Instruction
@@ -0,0 +1,13 @@
+//go:build linux && !amd64 && !arm64
+
+package sandbox
+
+import "golang.org/x/net/bpf"
+
+// auditArch is unset on architectures without a syscall table, which causes
+// seccomp profiles to be rejected.
+const auditArch = 0
+
+var archFilter []bpf.Instruction
+
+var syscallNumbers = map[string]uint32{}
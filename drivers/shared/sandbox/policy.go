@@ -0,0 +1,113 @@
+package sandbox
+
+import (
+	"fmt"
+	"path/filepath"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+)
+
+// Policy is the sandbox configuration accepted by task drivers, either as
+// operator defaults in the plugin config or in the driver config of a task.
+type Policy struct {
+	// SeccompProfile is "default", "unconfined" or the path of a JSON
+	// seccomp profile.
+	SeccompProfile string
+
+	// FSAllowRead and FSAllowWrite are the filesystem allow-lists. A nil
+	// list is unset, while an empty list is set but allows nothing. The
+	// filesystem is restricted if either list is set.
+	FSAllowRead  []string
+	FSAllowWrite []string
+}
+
+// Validate checks that the allow-lists only contain absolute paths.
+func (p *Policy) Validate() error {
+	var mErr multierror.Error
+	for _, path := range p.FSAllowRead {
+		if !filepath.IsAbs(path) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("fs_allow_read path %q must be absolute", path))
+		}
+	}
+	for _, path := range p.FSAllowWrite {
+		if !filepath.IsAbs(path) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("fs_allow_write path %q must be absolute", path))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ValidateDefaults validates a policy set in the plugin config. A custom
+// seccomp profile must be given as an absolute path on the host and is loaded
+// to check that it is valid.
+func (p *Policy) ValidateDefaults() error {
+	var mErr multierror.Error
+	if err := p.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if !builtinProfile(p.SeccompProfile) {
+		if !filepath.IsAbs(p.SeccompProfile) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("seccomp profile path %q must be absolute", p.SeccompProfile))
+		} else if _, err := LoadSeccompProfile(p.SeccompProfile); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Resolve returns the sandbox config for a task, using the task's policy
+// where it is set and the defaults otherwise. A custom seccomp profile set by
+// the task is read relative to taskDir, the task directory on the host, and
+// may not escape it.
+func (p *Policy) Resolve(task *Policy, taskDir string) (*Config, error) {
+	if err := task.Validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Config{
+		FSAllowRead:  p.FSAllowRead,
+		FSAllowWrite: p.FSAllowWrite,
+	}
+	if task.FSAllowRead != nil {
+		c.FSAllowRead = task.FSAllowRead
+	}
+	if task.FSAllowWrite != nil {
+		c.FSAllowWrite = task.FSAllowWrite
+	}
+	c.FSRestricted = c.FSAllowRead != nil || c.FSAllowWrite != nil
+
+	profile := p.SeccompProfile
+	if task.SeccompProfile != "" {
+		profile = task.SeccompProfile
+		if !builtinProfile(profile) {
+			if filepath.IsAbs(profile) {
+				return nil, fmt.Errorf("seccomp profile path %q must be relative to the task directory", profile)
+			}
+			escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", profile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check seccomp profile path: %v", err)
+			}
+			if escapes {
+				return nil, fmt.Errorf("seccomp profile path %q escapes the task directory", profile)
+			}
+			profile = filepath.Join(taskDir, profile)
+		}
+	}
+
+	seccomp, err := LoadSeccompProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	c.Seccomp = seccomp
+
+	return c.Copy(), nil
+}
+
+func builtinProfile(name string) bool {
+	switch name {
+	case "", SeccompProfileDefault, SeccompProfileUnconfined:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_ValidateDefaults(t *testing.T) {
+	ci.Parallel(t)
+
+	profile := filepath.Join(t.TempDir(), "profile.json")
+	require.NoError(t, os.WriteFile(profile, []byte(`{"defaultAction":"SCMP_ACT_ALLOW"}`), 0644))
+
+	valid := []*Policy{
+		{},
+		{SeccompProfile: SeccompProfileDefault},
+		{SeccompProfile: SeccompProfileUnconfined},
+		{SeccompProfile: profile, FSAllowRead: []string{"/usr"}, FSAllowWrite: []string{"/var/lib/app"}},
+	}
+	for _, p := range valid {
+		require.NoError(t, p.ValidateDefaults())
+	}
+
+	err := (&Policy{SeccompProfile: "profile.json"}).ValidateDefaults()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be absolute")
+
+	err = (&Policy{SeccompProfile: filepath.Join(t.TempDir(), "missing.json")}).ValidateDefaults()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read seccomp profile")
+
+	err = (&Policy{FSAllowRead: []string{"usr"}, FSAllowWrite: []string{"tmp"}}).ValidateDefaults()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `fs_allow_read path "usr" must be absolute`)
+	require.Contains(t, err.Error(), `fs_allow_write path "tmp" must be absolute`)
+}
+
+func TestPolicy_Resolve(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(taskDir, "local"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(taskDir, "local", "profile.json"),
+		[]byte(`{"defaultAction":"SCMP_ACT_LOG"}`), 0644))
+
+	defaults := &Policy{
+		SeccompProfile: SeccompProfileDefault,
+		FSAllowRead:    []string{"/usr", "/lib"},
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		c, err := defaults.Resolve(&Policy{}, taskDir)
+		require.NoError(t, err)
+		require.Equal(t, DefaultSeccompProfile(), c.Seccomp)
+		require.Equal(t, []string{"/usr", "/lib"}, c.FSAllowRead)
+		require.Nil(t, c.FSAllowWrite)
+		require.True(t, c.RestrictsFS())
+	})
+
+	t.Run("unrestricted", func(t *testing.T) {
+		c, err := (&Policy{}).Resolve(&Policy{}, taskDir)
+		require.NoError(t, err)
+		require.False(t, c.RestrictsFS())
+		require.True(t, c.Empty())
+	})
+
+	t.Run("empty allow-lists", func(t *testing.T) {
+		c, err := (&Policy{}).Resolve(&Policy{
+			FSAllowRead:  []string{},
+			FSAllowWrite: []string{},
+		}, taskDir)
+		require.NoError(t, err)
+		require.Empty(t, c.FSAllowRead)
+		require.Empty(t, c.FSAllowWrite)
+		require.True(t, c.RestrictsFS())
+		require.False(t, c.Empty())
+	})
+
+	t.Run("task overrides", func(t *testing.T) {
+		c, err := defaults.Resolve(&Policy{
+			SeccompProfile: "local/profile.json",
+			FSAllowRead:    []string{},
+			FSAllowWrite:   []string{"/data"},
+		}, taskDir)
+		require.NoError(t, err)
+		require.Equal(t, ActLog, c.Seccomp.DefaultAction)
+		require.Empty(t, c.FSAllowRead)
+		require.Equal(t, []string{"/data"}, c.FSAllowWrite)
+	})
+
+	t.Run("unconfined", func(t *testing.T) {
+		c, err := defaults.Resolve(&Policy{SeccompProfile: SeccompProfileUnconfined}, taskDir)
+		require.NoError(t, err)
+		require.Nil(t, c.Seccomp)
+	})
+
+	t.Run("absolute profile", func(t *testing.T) {
+		_, err := defaults.Resolve(&Policy{SeccompProfile: "/etc/profile.json"}, taskDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "must be relative to the task directory")
+	})
+
+	t.Run("escaping profile", func(t *testing.T) {
+		_, err := defaults.Resolve(&Policy{SeccompProfile: "../../profile.json"}, taskDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "escapes the task directory")
+	})
+
+	t.Run("relative allow-list", func(t *testing.T) {
+		_, err := defaults.Resolve(&Policy{FSAllowWrite: []string{"local"}}, taskDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "must be absolute")
+	})
+}
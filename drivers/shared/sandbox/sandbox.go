@@ -0,0 +1,66 @@
+// Package sandbox restricts the syscalls and filesystem paths available to a
+// task process. Syscalls are filtered with seccomp and filesystem access is
+// restricted with Landlock, both of which are only available on Linux.
+package sandbox
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Config describes the restrictions applied to a task process before it
+// executes the task command.
+type Config struct {
+	// Seccomp is the seccomp profile used to filter syscalls. A nil profile
+	// leaves syscalls unfiltered.
+	Seccomp *SeccompProfile `json:",omitempty"`
+
+	// FSAllowRead is the list of paths, and everything beneath them, that
+	// the task may read and execute.
+	FSAllowRead []string `json:",omitempty"`
+
+	// FSAllowWrite is the list of paths, and everything beneath them, that
+	// the task may read, execute, create, modify and remove.
+	FSAllowWrite []string `json:",omitempty"`
+
+	// FSRestricted is set if filesystem access is restricted to the
+	// allow-lists. Both lists may be empty, in which case the task may not
+	// access any path.
+	FSRestricted bool `json:",omitempty"`
+}
+
+// Empty returns true if the config does not restrict the task in any way.
+func (c *Config) Empty() bool {
+	return c == nil || (c.Seccomp == nil && !c.RestrictsFS())
+}
+
+// RestrictsFS returns true if filesystem access should be restricted to the
+// allow-listed paths.
+func (c *Config) RestrictsFS() bool {
+	return c != nil && c.FSRestricted
+}
+
+// CheckSupported returns an error if the host cannot enforce the config.
+func (c *Config) CheckSupported() error {
+	if c.RestrictsFS() && !LandlockSupported() {
+		return fmt.Errorf("filesystem allow-lists require Landlock support in the kernel")
+	}
+	if c != nil && c.Seccomp != nil && !SeccompSupported() {
+		return fmt.Errorf("seccomp profiles are not supported on %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the config.
+func (c *Config) Copy() *Config {
+	if c == nil {
+		return nil
+	}
+	nc := &Config{
+		Seccomp:      c.Seccomp.Copy(),
+		FSAllowRead:  append([]string(nil), c.FSAllowRead...),
+		FSAllowWrite: append([]string(nil), c.FSAllowWrite...),
+		FSRestricted: c.FSRestricted,
+	}
+	return nc
+}
@@ -0,0 +1,27 @@
+//go:build !linux
+
+package sandbox
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Apply returns an error unless the config is empty, since sandboxing is only
+// supported on Linux.
+func Apply(c *Config) error {
+	if c.Empty() {
+		return nil
+	}
+	return fmt.Errorf("sandboxing is not supported on %s", runtime.GOOS)
+}
+
+// LandlockSupported returns false on non-Linux platforms.
+func LandlockSupported() bool {
+	return false
+}
+
+// SeccompSupported returns false on non-Linux platforms.
+func SeccompSupported() bool {
+	return false
+}
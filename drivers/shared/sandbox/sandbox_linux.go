@@ -0,0 +1,38 @@
+package sandbox
+
+import (
+	"fmt"
+)
+
+// Apply restricts the calling process according to the config. Landlock
+// rules only apply to the calling thread, so the caller must lock the OS
+// thread and execve the task command from it. The seccomp filter is installed
+// last so that it cannot block the syscalls used to set up Landlock.
+func Apply(c *Config) error {
+	if c.Empty() {
+		return nil
+	}
+	if c.RestrictsFS() {
+		if err := installLandlock(c.FSAllowRead, c.FSAllowWrite); err != nil {
+			return err
+		}
+	}
+	if c.Seccomp != nil {
+		if err := installSeccomp(c.Seccomp); err != nil {
+			return fmt.Errorf("failed to apply seccomp profile: %v", err)
+		}
+	}
+	return nil
+}
+
+// LandlockSupported returns true if the kernel supports Landlock.
+func LandlockSupported() bool {
+	_, err := LandlockABI()
+	return err == nil
+}
+
+// SeccompSupported returns true if seccomp profiles can be compiled for this
+// architecture.
+func SeccompSupported() bool {
+	return auditArch != 0
+}
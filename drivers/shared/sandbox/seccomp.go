@@ -0,0 +1,213 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// SeccompProfileDefault is the name of the built-in profile returned by
+	// DefaultSeccompProfile.
+	SeccompProfileDefault = "default"
+
+	// SeccompProfileUnconfined disables syscall filtering.
+	SeccompProfileUnconfined = "unconfined"
+)
+
+// Seccomp actions, named after their OCI runtime spec equivalents.
+const (
+	ActAllow       = "SCMP_ACT_ALLOW"
+	ActErrno       = "SCMP_ACT_ERRNO"
+	ActKill        = "SCMP_ACT_KILL"
+	ActKillThread  = "SCMP_ACT_KILL_THREAD"
+	ActKillProcess = "SCMP_ACT_KILL_PROCESS"
+	ActLog         = "SCMP_ACT_LOG"
+	ActTrap        = "SCMP_ACT_TRAP"
+)
+
+// defaultErrno is EPERM, the errno returned by SCMP_ACT_ERRNO when the
+// profile does not set one.
+const defaultErrno = 1
+
+// SeccompProfile is a seccomp filter in the JSON format used by the OCI
+// runtime spec and Docker. Only the subset of the format that can be applied
+// without argument inspection is supported.
+type SeccompProfile struct {
+	DefaultAction   string            `json:"defaultAction"`
+	DefaultErrnoRet *uint             `json:"defaultErrnoRet,omitempty"`
+	Syscalls        []*SeccompSyscall `json:"syscalls,omitempty"`
+}
+
+// SeccompSyscall applies an action to a group of syscalls.
+type SeccompSyscall struct {
+	Names    []string          `json:"names"`
+	Action   string            `json:"action"`
+	ErrnoRet *uint             `json:"errnoRet,omitempty"`
+	Args     []json.RawMessage `json:"args,omitempty"`
+}
+
+// Copy returns a deep copy of the profile.
+func (p *SeccompProfile) Copy() *SeccompProfile {
+	if p == nil {
+		return nil
+	}
+	np := &SeccompProfile{
+		DefaultAction:   p.DefaultAction,
+		DefaultErrnoRet: copyUint(p.DefaultErrnoRet),
+		Syscalls:        make([]*SeccompSyscall, len(p.Syscalls)),
+	}
+	for i, s := range p.Syscalls {
+		np.Syscalls[i] = &SeccompSyscall{
+			Names:    append([]string(nil), s.Names...),
+			Action:   s.Action,
+			ErrnoRet: copyUint(s.ErrnoRet),
+			Args:     append([]json.RawMessage(nil), s.Args...),
+		}
+	}
+	return np
+}
+
+// Validate returns an error if the profile uses actions or features that
+// cannot be applied.
+func (p *SeccompProfile) Validate() error {
+	var mErr multierror.Error
+	if err := validateAction(p.DefaultAction); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("defaultAction: %v", err))
+	}
+	for i, s := range p.Syscalls {
+		if len(s.Names) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("syscalls[%d]: names must not be empty", i))
+		}
+		if err := validateAction(s.Action); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("syscalls[%d]: %v", i, err))
+		}
+		if len(s.Args) > 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("syscalls[%d]: argument conditions are not supported", i))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+func validateAction(action string) error {
+	switch action {
+	case ActAllow, ActErrno, ActKill, ActKillThread, ActKillProcess, ActLog, ActTrap:
+		return nil
+	case "":
+		return fmt.Errorf("action must be set")
+	default:
+		return fmt.Errorf("unsupported action %q", action)
+	}
+}
+
+// ParseSeccompProfile decodes and validates a JSON seccomp profile.
+func ParseSeccompProfile(data []byte) (*SeccompProfile, error) {
+	var p SeccompProfile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode seccomp profile: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid seccomp profile: %v", err)
+	}
+	return &p, nil
+}
+
+// LoadSeccompProfile resolves a seccomp_profile value. The names "default"
+// and "unconfined" select the built-in profile and no filtering respectively,
+// and anything else is read as the path of a JSON profile. An empty value
+// returns a nil profile.
+func LoadSeccompProfile(value string) (*SeccompProfile, error) {
+	switch value {
+	case "", SeccompProfileUnconfined:
+		return nil, nil
+	case SeccompProfileDefault:
+		return DefaultSeccompProfile(), nil
+	}
+
+	data, err := ioutil.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seccomp profile: %v", err)
+	}
+	return ParseSeccompProfile(data)
+}
+
+// defaultDeniedSyscalls are the syscalls rejected by the default profile.
+// They either administer the host, escape or modify namespaces, or inspect
+// other processes, none of which a task needs to do.
+var defaultDeniedSyscalls = []string{
+	"acct",
+	"add_key",
+	"bpf",
+	"clock_adjtime",
+	"clock_settime",
+	"create_module",
+	"delete_module",
+	"finit_module",
+	"fsconfig",
+	"fsmount",
+	"fsopen",
+	"fspick",
+	"get_kernel_syms",
+	"init_module",
+	"ioperm",
+	"iopl",
+	"kcmp",
+	"kexec_file_load",
+	"kexec_load",
+	"keyctl",
+	"lookup_dcookie",
+	"mount",
+	"move_mount",
+	"name_to_handle_at",
+	"nfsservctl",
+	"open_by_handle_at",
+	"open_tree",
+	"perf_event_open",
+	"pivot_root",
+	"process_vm_readv",
+	"process_vm_writev",
+	"ptrace",
+	"query_module",
+	"quotactl",
+	"reboot",
+	"request_key",
+	"setns",
+	"settimeofday",
+	"stime",
+	"swapoff",
+	"swapon",
+	"syslog",
+	"_sysctl",
+	"sysfs",
+	"umount",
+	"umount2",
+	"unshare",
+	"uselib",
+	"userfaultfd",
+	"ustat",
+	"vm86",
+	"vm86old",
+}
+
+// DefaultSeccompProfile returns the built-in profile, which allows every
+// syscall except those used to administer the host or escape the task's
+// isolation. Denied syscalls fail with EPERM.
+func DefaultSeccompProfile() *SeccompProfile {
+	return &SeccompProfile{
+		DefaultAction: ActAllow,
+		Syscalls: []*SeccompSyscall{{
+			Names:  append([]string(nil), defaultDeniedSyscalls...),
+			Action: ActErrno,
+		}},
+	}
+}
+
+func copyUint(u *uint) *uint {
+	if u == nil {
+		return nil
+	}
+	nu := *u
+	return &nu
+}
@@ -0,0 +1,133 @@
+package sandbox
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"unsafe"
+
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+)
+
+// Values from linux/seccomp.h that are not exported by x/sys/unix.
+const (
+	seccompRetKillProcess = 0x80000000
+	seccompRetKillThread  = 0x00000000
+	seccompRetTrap        = 0x00030000
+	seccompRetErrno       = 0x00050000
+	seccompRetLog         = 0x7ffc0000
+	seccompRetAllow       = 0x7fff0000
+
+	seccompSetModeFilter   = 1
+	seccompFilterFlagTsync = 1
+)
+
+// Offsets into struct seccomp_data.
+const (
+	seccompDataNr   = 0
+	seccompDataArch = 4
+)
+
+// actionValue returns the seccomp return value for an action.
+func actionValue(action string, errnoRet *uint) uint32 {
+	switch action {
+	case ActAllow:
+		return seccompRetAllow
+	case ActErrno:
+		errno := uint32(defaultErrno)
+		if errnoRet != nil {
+			errno = uint32(*errnoRet) & 0xffff
+		}
+		return seccompRetErrno | errno
+	case ActKill, ActKillThread:
+		return seccompRetKillThread
+	case ActKillProcess:
+		return seccompRetKillProcess
+	case ActLog:
+		return seccompRetLog
+	case ActTrap:
+		return seccompRetTrap
+	}
+	return seccompRetKillProcess
+}
+
+// assemble compiles the profile into a classic BPF program. Syscalls that are
+// unknown on this architecture are skipped, so a single profile can list
+// syscalls for several architectures.
+func (p *SeccompProfile) assemble() ([]bpf.RawInstruction, error) {
+	if auditArch == 0 {
+		return nil, fmt.Errorf("seccomp profiles are not supported on %s", runtime.GOARCH)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	defaultAction := actionValue(p.DefaultAction, p.DefaultErrnoRet)
+
+	// Later rules take precedence over earlier ones for the same syscall
+	actions := make(map[uint32]uint32)
+	for _, s := range p.Syscalls {
+		action := actionValue(s.Action, s.ErrnoRet)
+		for _, name := range s.Names {
+			if nr, ok := syscallNumbers[name]; ok {
+				actions[nr] = action
+			}
+		}
+	}
+	nrs := make([]uint32, 0, len(actions))
+	for nr, action := range actions {
+		if action != defaultAction {
+			nrs = append(nrs, nr)
+		}
+	}
+	sort.Slice(nrs, func(i, j int) bool { return nrs[i] < nrs[j] })
+
+	prog := []bpf.Instruction{
+		// Kill processes using a syscall ABI other than the one the
+		// syscall numbers were resolved for
+		bpf.LoadAbsolute{Off: seccompDataArch, Size: 4},
+		bpf.JumpIf{Cond: bpf.JumpEqual, Val: auditArch, SkipTrue: 1},
+		bpf.RetConstant{Val: seccompRetKillProcess},
+		bpf.LoadAbsolute{Off: seccompDataNr, Size: 4},
+	}
+	prog = append(prog, archFilter...)
+	for _, nr := range nrs {
+		prog = append(prog,
+			bpf.JumpIf{Cond: bpf.JumpNotEqual, Val: nr, SkipTrue: 1},
+			bpf.RetConstant{Val: actions[nr]},
+		)
+	}
+	prog = append(prog, bpf.RetConstant{Val: defaultAction})
+
+	return bpf.Assemble(prog)
+}
+
+// installSeccomp applies the profile to every thread of the calling process.
+// The filter is inherited by child processes and survives execve.
+func installSeccomp(p *SeccompProfile) error {
+	raw, err := p.assemble()
+	if err != nil {
+		return err
+	}
+
+	filter := make([]unix.SockFilter, len(raw))
+	for i, ins := range raw {
+		filter[i] = unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+	}
+	prog := unix.SockFprog{
+		Len:    uint16(len(filter)),
+		Filter: &filter[0],
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %v", err)
+	}
+	_, _, errno := unix.RawSyscall(unix.SYS_SECCOMP,
+		seccompSetModeFilter, seccompFilterFlagTsync, uintptr(unsafe.Pointer(&prog)))
+	runtime.KeepAlive(filter)
+	if errno != 0 {
+		return fmt.Errorf("failed to install seccomp filter: %v", errno)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+)
+
+// runFilter runs the assembled profile against a syscall number for the
+// native architecture and returns the seccomp action.
+func runFilter(t *testing.T, p *SeccompProfile, nr uint32) uint32 {
+	raw, err := p.assemble()
+	require.NoError(t, err)
+
+	insns, ok := bpf.Disassemble(raw)
+	require.True(t, ok)
+	vm, err := bpf.NewVM(insns)
+	require.NoError(t, err)
+
+	// struct seccomp_data is in native byte order, but the BPF VM loads
+	// words as big endian
+	data := make([]byte, 64)
+	putUint32BE(data[seccompDataNr:], nr)
+	putUint32BE(data[seccompDataArch:], auditArch)
+
+	action, err := vm.Run(data)
+	require.NoError(t, err)
+	return uint32(action)
+}
+
+func putUint32BE(b []byte, v uint32) {
+	b[0] = byte(v >> 24)
+	b[1] = byte(v >> 16)
+	b[2] = byte(v >> 8)
+	b[3] = byte(v)
+}
+
+func TestSeccompProfile_Assemble_Default(t *testing.T) {
+	ci.Parallel(t)
+
+	p := DefaultSeccompProfile()
+	require.Equal(t, uint32(seccompRetErrno|unix.EPERM), runFilter(t, p, syscallNumbers["mount"]))
+	require.Equal(t, uint32(seccompRetErrno|unix.EPERM), runFilter(t, p, syscallNumbers["ptrace"]))
+	require.Equal(t, uint32(seccompRetAllow), runFilter(t, p, syscallNumbers["read"]))
+	require.Equal(t, uint32(seccompRetAllow), runFilter(t, p, syscallNumbers["mkdirat"]))
+}
+
+func TestSeccompProfile_Assemble_Custom(t *testing.T) {
+	ci.Parallel(t)
+
+	enosys := uint(unix.ENOSYS)
+	p, err := ParseSeccompProfile([]byte(`{
+  "defaultAction": "SCMP_ACT_ERRNO",
+  "defaultErrnoRet": 38,
+  "syscalls": [
+    {"names": ["read", "write", "not_a_syscall"], "action": "SCMP_ACT_ALLOW"},
+    {"names": ["getpid"], "action": "SCMP_ACT_KILL_PROCESS"},
+    {"names": ["write"], "action": "SCMP_ACT_ERRNO", "errnoRet": 5}
+  ]
+}`))
+	require.NoError(t, err)
+	require.EqualValues(t, enosys, *p.DefaultErrnoRet)
+
+	require.Equal(t, uint32(seccompRetAllow), runFilter(t, p, syscallNumbers["read"]))
+	require.Equal(t, uint32(seccompRetKillProcess), runFilter(t, p, syscallNumbers["getpid"]))
+	require.Equal(t, uint32(seccompRetErrno|unix.EIO), runFilter(t, p, syscallNumbers["write"]))
+	require.Equal(t, uint32(seccompRetErrno|unix.ENOSYS), runFilter(t, p, syscallNumbers["close"]))
+}
+
+func TestSeccompProfile_Assemble_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &SeccompProfile{DefaultAction: "SCMP_ACT_NOTIFY"}
+	_, err := p.assemble()
+	require.Error(t, err)
+}
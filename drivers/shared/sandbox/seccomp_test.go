@@ -0,0 +1,108 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeccompProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "valid",
+			data: `{"defaultAction":"SCMP_ACT_ERRNO","defaultErrnoRet":38,"syscalls":[{"names":["read","write"],"action":"SCMP_ACT_ALLOW"}]}`,
+		},
+		{
+			name: "bad json",
+			data: `{"defaultAction":`,
+			err:  "failed to decode",
+		},
+		{
+			name: "missing default action",
+			data: `{"syscalls":[{"names":["read"],"action":"SCMP_ACT_ALLOW"}]}`,
+			err:  "defaultAction: action must be set",
+		},
+		{
+			name: "unsupported action",
+			data: `{"defaultAction":"SCMP_ACT_ALLOW","syscalls":[{"names":["read"],"action":"SCMP_ACT_NOTIFY"}]}`,
+			err:  `unsupported action "SCMP_ACT_NOTIFY"`,
+		},
+		{
+			name: "empty names",
+			data: `{"defaultAction":"SCMP_ACT_ALLOW","syscalls":[{"names":[],"action":"SCMP_ACT_ERRNO"}]}`,
+			err:  "names must not be empty",
+		},
+		{
+			name: "args",
+			data: `{"defaultAction":"SCMP_ACT_ALLOW","syscalls":[{"names":["personality"],"action":"SCMP_ACT_ERRNO","args":[{"index":0,"value":8,"op":"SCMP_CMP_EQ"}]}]}`,
+			err:  "argument conditions are not supported",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParseSeccompProfile([]byte(tc.data))
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, ActErrno, p.DefaultAction)
+			require.EqualValues(t, 38, *p.DefaultErrnoRet)
+			require.Equal(t, []string{"read", "write"}, p.Syscalls[0].Names)
+		})
+	}
+}
+
+func TestLoadSeccompProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	p, err := LoadSeccompProfile("")
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	p, err = LoadSeccompProfile(SeccompProfileUnconfined)
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	p, err = LoadSeccompProfile(SeccompProfileDefault)
+	require.NoError(t, err)
+	require.Equal(t, DefaultSeccompProfile(), p)
+	require.NoError(t, p.Validate())
+
+	path := filepath.Join(t.TempDir(), "profile.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"defaultAction":"SCMP_ACT_LOG"}`), 0644))
+	p, err = LoadSeccompProfile(path)
+	require.NoError(t, err)
+	require.Equal(t, ActLog, p.DefaultAction)
+
+	_, err = LoadSeccompProfile(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read seccomp profile")
+}
+
+func TestSeccompProfile_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	errno := uint(13)
+	p := DefaultSeccompProfile()
+	p.Syscalls[0].ErrnoRet = &errno
+
+	c := p.Copy()
+	require.Equal(t, p, c)
+
+	c.Syscalls[0].Names[0] = "read"
+	*c.Syscalls[0].ErrnoRet = 1
+	require.Equal(t, "acct", p.Syscalls[0].Names[0])
+	require.EqualValues(t, 13, *p.Syscalls[0].ErrnoRet)
+}
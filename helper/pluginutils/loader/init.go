@@ -2,11 +2,14 @@ package loader
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	multierror "github.com/hashicorp/go-multierror"
 	plugin "github.com/hashicorp/go-plugin"
@@ -34,6 +37,15 @@ func validateConfig(config *PluginLoaderConfig) error {
 		}
 	}
 
+	// Validate that all manifest entries have a binary name and checksum
+	for _, m := range config.Manifest {
+		if m.Name == "" {
+			_ = multierror.Append(&mErr, fmt.Errorf("plugin manifest entry passed without binary name"))
+		} else if len(m.SHA256) != hex.EncodedLen(sha256.Size) {
+			_ = multierror.Append(&mErr, fmt.Errorf("plugin manifest entry for %q must have a hex encoded sha256 checksum", m.Name))
+		}
+	}
+
 	// Validate internal plugins
 	for k, config := range config.InternalPlugins {
 		// Validate config
@@ -101,8 +113,10 @@ func (l *PluginLoader) initInternal(plugins map[PluginID]*InternalPluginConfig,
 		}
 
 		info := &pluginInfo{
-			factory: config.Factory,
-			config:  config.Config,
+			factory:       config.Factory,
+			config:        config.Config,
+			configName:    k.Name,
+			defaultConfig: config.Config,
 		}
 
 		// Try to retrieve a user specified config
@@ -261,6 +275,30 @@ func (l *PluginLoader) fingerprintPlugins(plugins []os.FileInfo, configs map[str
 	fingerprinted := make(map[PluginID]*pluginInfo, len(plugins))
 	for _, p := range plugins {
 		name := cleanPluginExecutable(p.Name())
+
+		// Verify the binary against the manifest before launching it
+		sum, err := fileSHA256(filepath.Join(l.pluginDir, p.Name()))
+		if err != nil {
+			l.logger.Error("failed to checksum plugin", "plugin", name, "error", err)
+			_ = multierror.Append(&mErr, err)
+			continue
+		}
+
+		var entry *config.PluginManifestEntry
+		if l.manifest != nil {
+			var ok bool
+			entry, ok = l.manifest[name]
+			if !ok {
+				l.logger.Warn("skipping plugin not listed in the plugin manifest", "plugin", name)
+				continue
+			}
+			if !strings.EqualFold(sum, entry.SHA256) {
+				l.logger.Error("plugin checksum does not match the plugin manifest", "plugin", name, "sha256", sum)
+				_ = multierror.Append(&mErr, fmt.Errorf("plugin %q has sha256 %s but the manifest requires %s", name, sum, entry.SHA256))
+				continue
+			}
+		}
+
 		c := configs[name]
 		info, err := l.fingerprintPlugin(p, c)
 		if err != nil {
@@ -272,6 +310,21 @@ func (l *PluginLoader) fingerprintPlugins(plugins []os.FileInfo, configs map[str
 			// Plugin was skipped for validation reasons
 			continue
 		}
+		info.configName = name
+		info.sha256 = sum
+
+		if entry != nil && entry.Version != "" {
+			expected, err := version.NewVersion(entry.Version)
+			if err != nil {
+				_ = multierror.Append(&mErr, fmt.Errorf("plugin %q has invalid manifest version %q: %v", name, entry.Version, err))
+				continue
+			}
+			if !expected.Equal(info.version) {
+				l.logger.Error("plugin failed verification", "plugin", name, "version", info.version, "expected_version", expected)
+				_ = multierror.Append(&mErr, fmt.Errorf("plugin %q has version %s but the manifest requires %s", name, info.version, expected))
+				continue
+			}
+		}
 
 		id := PluginID{
 			Name:       info.baseInfo.Name,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"sync"
 
 	log "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
@@ -29,6 +30,57 @@ type PluginCatalog interface {
 	Catalog() map[string][]*base.PluginInfoResponse
 }
 
+// ReloadableCatalog is a PluginCatalog whose plugin configurations can be
+// reloaded while the agent is running.
+type ReloadableCatalog interface {
+	PluginCatalog
+
+	// ReloadConfigs validates the passed plugin configurations, applies the
+	// changed ones to the running plugins with apply and stores them. It
+	// returns the IDs of the plugins whose configuration changed.
+	ReloadConfigs(configs []*config.PluginConfig, apply ApplyConfigFunc) ([]PluginID, error)
+}
+
+// ApplyConfigFunc sets the reloaded configuration of a plugin on its running
+// instances. The AgentConfig of the passed configuration is unset and must be
+// filled in by the caller. If an error is returned, the plugin keeps its
+// previous configuration.
+type ApplyConfigFunc func(id PluginID, config *base.Config) error
+
+// DetailedCatalog is a PluginCatalog that can describe how its plugins are
+// launched.
+type DetailedCatalog interface {
+	PluginCatalog
+
+	// Details returns the details of all plugins in the catalog
+	Details() []*PluginDetails
+}
+
+// PluginDetails describes a plugin in the catalog.
+type PluginDetails struct {
+	// ID is the name and type of the plugin
+	ID PluginID
+
+	// Version is the plugin's version
+	Version string
+
+	// ApiVersion is the negotiated plugin API version
+	ApiVersion string
+
+	// Internal marks plugins built into the Nomad binary
+	Internal bool
+
+	// Path is the binary of an external plugin
+	Path string
+
+	// SHA256 is the hex encoded checksum of an external plugin's binary
+	SHA256 string
+}
+
+// ErrReloadUnsupported is returned when the plugin catalog does not support
+// reloading plugin configurations.
+var ErrReloadUnsupported = fmt.Errorf("plugin catalog does not support reloading plugin configurations")
+
 // InternalPluginConfig is used to configure launching an internal plugin.
 type InternalPluginConfig struct {
 	Config  map[string]interface{}
@@ -67,6 +119,11 @@ type PluginLoaderConfig struct {
 	// Configs is an optional set of configs for plugins
 	Configs []*config.PluginConfig
 
+	// Manifest is an optional set of external plugins allowed to be launched
+	// along with their expected version and checksum. If set, any external
+	// plugin not listed is skipped.
+	Manifest []*config.PluginManifestEntry
+
 	// InternalPlugins allows registering internal plugins.
 	InternalPlugins map[PluginID]*InternalPluginConfig
 
@@ -86,8 +143,14 @@ type PluginLoader struct {
 	// pluginDir is the directory containing plugin binaries
 	pluginDir string
 
-	// plugins maps a plugin to information required to launch it
-	plugins map[PluginID]*pluginInfo
+	// manifest maps the executable names of the external plugins allowed to
+	// be launched to their expected version and checksum
+	manifest map[string]*config.PluginManifestEntry
+
+	// plugins maps a plugin to information required to launch it. The
+	// entries are replaced when plugin configurations are reloaded.
+	plugins     map[PluginID]*pluginInfo
+	pluginsLock sync.RWMutex
 }
 
 // pluginInfo captures the necessary information to launch and configure a
@@ -98,6 +161,9 @@ type pluginInfo struct {
 	exePath string
 	args    []string
 
+	// sha256 is the hex encoded checksum of the external plugin binary
+	sha256 string
+
 	baseInfo   *base.PluginInfoResponse
 	version    *version.Version
 	apiVersion string
@@ -105,6 +171,14 @@ type pluginInfo struct {
 	configSchema  *hclspec.Spec
 	config        map[string]interface{}
 	msgpackConfig []byte
+
+	// configName is the name of the plugin's entry in the agent's plugin
+	// configuration
+	configName string
+
+	// defaultConfig is the config of an internal plugin used when the agent
+	// does not configure the plugin
+	defaultConfig map[string]interface{}
 }
 
 // NewPluginLoader returns an instance of a plugin loader or an error if the
@@ -132,6 +206,10 @@ func NewPluginLoader(config *PluginLoaderConfig) (*PluginLoader, error) {
 		plugins:           make(map[PluginID]*pluginInfo),
 	}
 
+	if len(config.Manifest) != 0 {
+		l.manifest = manifestByName(config.Manifest)
+	}
+
 	if err := l.init(config); err != nil {
 		return nil, fmt.Errorf("failed to initialize plugin loader: %v", err)
 	}
@@ -139,6 +217,15 @@ func NewPluginLoader(config *PluginLoaderConfig) (*PluginLoader, error) {
 	return l, nil
 }
 
+// manifestByName indexes the plugin manifest entries by plugin name.
+func manifestByName(entries []*config.PluginManifestEntry) map[string]*config.PluginManifestEntry {
+	out := make(map[string]*config.PluginManifestEntry, len(entries))
+	for _, entry := range entries {
+		out[entry.Name] = entry
+	}
+	return out
+}
+
 // Dispense returns a plugin instance, loading it either internally or by
 // launching an external plugin.
 func (l *PluginLoader) Dispense(name, pluginType string, config *base.AgentConfig, logger log.Logger) (PluginInstance, error) {
@@ -146,7 +233,7 @@ func (l *PluginLoader) Dispense(name, pluginType string, config *base.AgentConfi
 		Name:       name,
 		PluginType: pluginType,
 	}
-	pinfo, ok := l.plugins[id]
+	pinfo, ok := l.lookupPlugin(id)
 	if !ok {
 		return nil, fmt.Errorf("unknown plugin with name %q and type %q", name, pluginType)
 	}
@@ -161,6 +248,14 @@ func (l *PluginLoader) Dispense(name, pluginType string, config *base.AgentConfi
 			killFn:     cancel,
 		}
 	} else {
+		// Ensure a verified binary hasn't been replaced since it was
+		// fingerprinted
+		if l.manifest != nil {
+			if err := verifyChecksum(pinfo.exePath, pinfo.sha256); err != nil {
+				return nil, fmt.Errorf("refusing to launch plugin %s: %v", id, err)
+			}
+		}
+
 		var err error
 		instance, err = l.dispensePlugin(pinfo.baseInfo.Type, pinfo.apiVersion, pinfo.exePath, pinfo.args, nil, logger)
 		if err != nil {
@@ -279,9 +374,39 @@ func getPluginMap(pluginType string, logger log.Logger) map[string]plugin.Plugin
 
 // Catalog returns the catalog of all plugins
 func (l *PluginLoader) Catalog() map[string][]*base.PluginInfoResponse {
+	l.pluginsLock.RLock()
+	defer l.pluginsLock.RUnlock()
+
 	c := make(map[string][]*base.PluginInfoResponse, 3)
 	for id, info := range l.plugins {
 		c[id.PluginType] = append(c[id.PluginType], info.baseInfo)
 	}
 	return c
 }
+
+// Details returns the details of all plugins in the catalog
+func (l *PluginLoader) Details() []*PluginDetails {
+	l.pluginsLock.RLock()
+	defer l.pluginsLock.RUnlock()
+
+	details := make([]*PluginDetails, 0, len(l.plugins))
+	for id, info := range l.plugins {
+		details = append(details, &PluginDetails{
+			ID:         id,
+			Version:    info.baseInfo.PluginVersion,
+			ApiVersion: info.apiVersion,
+			Internal:   info.factory != nil,
+			Path:       info.exePath,
+			SHA256:     info.sha256,
+		})
+	}
+	return details
+}
+
+// lookupPlugin returns the launch information of the given plugin.
+func (l *PluginLoader) lookupPlugin(id PluginID) (*pluginInfo, bool) {
+	l.pluginsLock.RLock()
+	defer l.pluginsLock.RUnlock()
+	pinfo, ok := l.plugins[id]
+	return pinfo, ok
+}
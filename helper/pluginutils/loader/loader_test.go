@@ -1,6 +1,7 @@
 package loader
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -155,6 +156,122 @@ func TestPluginLoader_External(t *testing.T) {
 	require.EqualValues(expected, detected)
 }
 
+func TestPluginLoader_External_Manifest(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// Create two plugins, only one of which is in the manifest
+	plugins := []string{"mock-device", "mock-device-2"}
+	h := newHarness(t, plugins)
+
+	exeSuffix := ""
+	if runtime.GOOS == "windows" {
+		exeSuffix = ".exe"
+	}
+	sum, err := fileSHA256(filepath.Join(h.pluginDir(), plugins[0]+exeSuffix))
+	require.NoError(err)
+
+	logger := testlog.HCLogger(t)
+	logger.SetLevel(log.Trace)
+	lconfig := &PluginLoaderConfig{
+		Logger:            logger,
+		PluginDir:         h.pluginDir(),
+		SupportedVersions: supportedApiVersions,
+		Configs: []*config.PluginConfig{
+			{
+				Name: plugins[0],
+				Args: []string{"-plugin", "-name", plugins[0],
+					"-type", base.PluginTypeDevice, "-version", "v0.0.1",
+					"-api-version", device.ApiVersion010},
+			},
+			{
+				Name: plugins[1],
+				Args: []string{"-plugin", "-name", plugins[1],
+					"-type", base.PluginTypeDevice, "-version", "v0.0.2",
+					"-api-version", device.ApiVersion010},
+			},
+		},
+		Manifest: []*config.PluginManifestEntry{
+			{
+				Name:    plugins[0],
+				Version: "0.0.1",
+				SHA256:  strings.ToUpper(sum),
+			},
+		},
+	}
+
+	l, err := NewPluginLoader(lconfig)
+	require.NoError(err)
+
+	// Only the plugin listed in the manifest is loaded
+	c := l.Catalog()
+	require.Len(c[base.PluginTypeDevice], 1)
+	require.Equal(plugins[0], c[base.PluginTypeDevice][0].Name)
+
+	details := l.Details()
+	require.Len(details, 1)
+	require.Equal(sum, details[0].SHA256)
+	require.False(details[0].Internal)
+	require.Equal("v0.0.1", details[0].Version)
+
+	// The plugin can be dispensed while its binary matches the manifest
+	p, err := l.Dispense(plugins[0], base.PluginTypeDevice, nil, logger)
+	require.NoError(err)
+	p.Kill()
+
+	// A modified binary is refused
+	f, err := os.OpenFile(filepath.Join(h.pluginDir(), plugins[0]+exeSuffix), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(err)
+	_, err = f.Write([]byte("tampered"))
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	_, err = l.Dispense(plugins[0], base.PluginTypeDevice, nil, logger)
+	require.Error(err)
+	require.Contains(err.Error(), "refusing to launch plugin")
+}
+
+func TestPluginLoader_External_Manifest_Bad(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	plugins := []string{"mock-device"}
+	h := newHarness(t, plugins)
+
+	logger := testlog.HCLogger(t)
+	logger.SetLevel(log.Trace)
+	lconfig := &PluginLoaderConfig{
+		Logger:            logger,
+		PluginDir:         h.pluginDir(),
+		SupportedVersions: supportedApiVersions,
+		Configs: []*config.PluginConfig{
+			{
+				Name: plugins[0],
+				Args: []string{"-plugin", "-name", plugins[0],
+					"-type", base.PluginTypeDevice, "-version", "v0.0.1",
+					"-api-version", device.ApiVersion010},
+			},
+		},
+		Manifest: []*config.PluginManifestEntry{
+			{
+				Name:   plugins[0],
+				SHA256: strings.Repeat("0", 64),
+			},
+		},
+	}
+
+	// A checksum mismatch fails loading
+	_, err := NewPluginLoader(lconfig)
+	require.Error(err)
+	require.Contains(err.Error(), "the manifest requires")
+
+	// A malformed checksum fails validation
+	lconfig.Manifest[0].SHA256 = "abc"
+	_, err = NewPluginLoader(lconfig)
+	require.Error(err)
+	require.Contains(err.Error(), "hex encoded sha256 checksum")
+}
+
 func TestPluginLoader_External_ApiVersions(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -1003,6 +1120,116 @@ func TestPluginLoader_Dispense_Internal(t *testing.T) {
 	require.Equal(device.ApiVersion010, mock.negotiatedApiVersion)
 }
 
+func TestPluginLoader_ReloadConfigs_Internal(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	plugin := "mock-device"
+	pluginVersion := "v0.0.1"
+	pluginApiVersions := []string{device.ApiVersion010}
+	h := newHarness(t, nil)
+
+	id := PluginID{
+		Name:       plugin,
+		PluginType: base.PluginTypeDevice,
+	}
+
+	logger := testlog.HCLogger(t)
+	logger.SetLevel(log.Trace)
+	lconfig := &PluginLoaderConfig{
+		Logger:            logger,
+		PluginDir:         h.pluginDir(),
+		SupportedVersions: supportedApiVersions,
+		InternalPlugins: map[PluginID]*InternalPluginConfig{
+			id: {
+				Factory: mockFactory(plugin, base.PluginTypeDevice, pluginVersion, pluginApiVersions, true),
+				Config: map[string]interface{}{
+					"res_key": "default",
+				},
+			},
+		},
+	}
+
+	l, err := NewPluginLoader(lconfig)
+	require.NoError(err)
+
+	// reserveKeys dispenses the plugin and returns the env keys it reserves
+	reserveKeys := func() map[string]string {
+		p, err := l.Dispense(plugin, base.PluginTypeDevice, nil, logger)
+		require.NoError(err)
+		defer p.Kill()
+
+		res, err := p.Plugin().(device.DevicePlugin).Reserve([]string{"fake"})
+		require.NoError(err)
+		return res.Envs
+	}
+	require.Contains(reserveKeys(), "default")
+
+	// Reload with a changed config
+	configs := []*config.PluginConfig{
+		{
+			Name: plugin,
+			Config: map[string]interface{}{
+				"res_key": "reloaded",
+			},
+		},
+	}
+	var applied []*base.Config
+	var applyErr error
+	apply := func(applyID PluginID, c *base.Config) error {
+		require.Equal(id, applyID)
+		applied = append(applied, c)
+		return applyErr
+	}
+
+	// A config rejected by the running plugins isn't stored
+	applyErr = fmt.Errorf("rejected")
+	changed, err := l.ReloadConfigs(configs, apply)
+	require.Error(err)
+	require.Contains(err.Error(), "rejected")
+	require.Empty(changed)
+	require.Len(applied, 1)
+	require.Contains(reserveKeys(), "default")
+
+	// An accepted config is stored once it is set on the running plugins
+	applyErr = nil
+	applied = nil
+	changed, err = l.ReloadConfigs(configs, apply)
+	require.NoError(err)
+	require.Equal([]PluginID{id}, changed)
+	require.Contains(reserveKeys(), "reloaded")
+	require.Len(applied, 1)
+	require.Equal(l.plugins[id].msgpackConfig, applied[0].PluginConfig)
+	require.Equal(device.ApiVersion010, applied[0].ApiVersion)
+
+	// Reloading the same config changes nothing
+	applied = nil
+	changed, err = l.ReloadConfigs(configs, apply)
+	require.NoError(err)
+	require.Empty(changed)
+	require.Empty(applied)
+
+	// An invalid config is rejected and the previous config is kept
+	changed, err = l.ReloadConfigs([]*config.PluginConfig{
+		{
+			Name: plugin,
+			Config: map[string]interface{}{
+				"non-existent": "3",
+			},
+		},
+	}, apply)
+	require.Error(err)
+	require.Empty(changed)
+	require.Empty(applied)
+	require.Contains(reserveKeys(), "reloaded")
+
+	// Removing the config reverts to the default
+	changed, err = l.ReloadConfigs(nil, nil)
+	require.NoError(err)
+	require.Equal([]PluginID{id}, changed)
+	require.Contains(reserveKeys(), "default")
+}
+
 func TestPluginLoader_Dispense_NoConfigSchema_External(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -0,0 +1,96 @@
+package loader
+
+import (
+	"fmt"
+	"reflect"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// ReloadConfigs validates and stores the passed plugin configurations. Plugins
+// whose configuration is unchanged are left untouched. A changed configuration
+// is first set on the plugin's running instances with apply, which may be nil
+// if there are none, and only stored once they accept it, so that plugins
+// dispensed later use it. A plugin whose new configuration is invalid or
+// rejected keeps its previous configuration and the error is returned along
+// with the IDs of the plugins that were successfully reloaded.
+func (l *PluginLoader) ReloadConfigs(configs []*config.PluginConfig, apply ApplyConfigFunc) ([]PluginID, error) {
+	configMap := configMap(configs)
+
+	// Copy the plugins so validation doesn't hold the lock while dispensing
+	l.pluginsLock.RLock()
+	plugins := make(map[PluginID]*pluginInfo, len(l.plugins))
+	for id, info := range l.plugins {
+		plugins[id] = info
+	}
+	l.pluginsLock.RUnlock()
+
+	var mErr multierror.Error
+	var changed []PluginID
+	for id, info := range plugins {
+		var newConfig map[string]interface{}
+		var newArgs []string
+		if c, ok := configMap[info.configName]; ok {
+			newConfig = c.Config
+			newArgs = c.Args
+		}
+
+		// Internal plugins fall back to their default configuration
+		if info.factory != nil && newConfig == nil {
+			newConfig = info.defaultConfig
+		}
+
+		// Match the empty configuration set when validating the plugin
+		if newConfig == nil && info.configSchema != nil {
+			newConfig = map[string]interface{}{}
+		}
+
+		if info.factory == nil && !argsEqual(info.args, newArgs) {
+			_ = multierror.Append(&mErr, fmt.Errorf("plugin %s: changing plugin args requires an agent restart", id))
+			continue
+		}
+
+		if reflect.DeepEqual(info.config, newConfig) {
+			continue
+		}
+
+		updated := *info
+		updated.config = newConfig
+		updated.msgpackConfig = nil
+		if err := l.validatePluginConfig(id, &updated); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("plugin %s:", id)))
+			continue
+		}
+
+		if apply != nil {
+			c := &base.Config{
+				PluginConfig: updated.msgpackConfig,
+				ApiVersion:   updated.apiVersion,
+			}
+			if err := apply(id, c); err != nil {
+				_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("plugin %s:", id)))
+				continue
+			}
+		}
+
+		l.pluginsLock.Lock()
+		l.plugins[id] = &updated
+		l.pluginsLock.Unlock()
+
+		l.logger.Info("reloaded plugin configuration", "plugin", id.Name, "type", id.PluginType)
+		changed = append(changed, id)
+	}
+
+	return changed, mErr.ErrorOrNil()
+}
+
+// argsEqual returns whether two sets of plugin arguments are equal, treating
+// nil and empty arguments as equal.
+func argsEqual(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
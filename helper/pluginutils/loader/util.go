@@ -1,6 +1,11 @@
 package loader
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/nomad/nomad/structs/config"
@@ -24,3 +29,31 @@ func cleanPluginExecutable(name string) string {
 		return name
 	}
 }
+
+// fileSHA256 returns the hex encoded SHA-256 checksum of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyChecksum returns an error if the SHA-256 checksum of the file at path
+// doesn't match the expected hex encoded checksum.
+func verifyChecksum(path, expected string) error {
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(sum, expected) {
+		return fmt.Errorf("sha256 %s does not match expected %s", sum, expected)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/plugins/base"
 )
 
@@ -59,6 +60,26 @@ func (s *SingletonLoader) Reattach(name, pluginType string, config *plugin.Reatt
 	return s.getPlugin(true, name, pluginType, nil, nil, config)
 }
 
+// Details returns the details of all plugins in the wrapped catalog, or nil if
+// the catalog can't describe its plugins.
+func (s *SingletonLoader) Details() []*loader.PluginDetails {
+	catalog, ok := s.loader.(loader.DetailedCatalog)
+	if !ok {
+		return nil
+	}
+	return catalog.Details()
+}
+
+// ReloadConfigs reloads the plugin configurations of the wrapped catalog.
+// Running instances are reconfigured by apply.
+func (s *SingletonLoader) ReloadConfigs(configs []*config.PluginConfig, apply loader.ApplyConfigFunc) ([]loader.PluginID, error) {
+	catalog, ok := s.loader.(loader.ReloadableCatalog)
+	if !ok {
+		return nil, loader.ErrReloadUnsupported
+	}
+	return catalog.ReloadConfigs(configs, apply)
+}
+
 // getPlugin is a helper that either dispenses or reattaches to a plugin using
 // futures to ensure only a single instance is retrieved
 func (s *SingletonLoader) getPlugin(reattach bool, name, pluginType string, logger log.Logger,
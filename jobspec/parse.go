@@ -415,10 +415,19 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 	// Get our resource object
 	o := list.Items[0]
 
+	// We need this later
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("update: should be an object")
+	}
+
 	var m map[string]interface{}
 	if err := hcl.DecodeObject(&m, o.Val); err != nil {
 		return err
 	}
+	delete(m, "analysis")
 
 	// Check for invalid keys
 	valid := []string{
@@ -431,11 +440,58 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 		"auto_revert",
 		"auto_promote",
 		"canary",
+		"analysis",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
 	}
 
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	// If we have an analysis block, then parse that
+	if o := listVal.Filter("analysis"); len(o.Items) > 0 {
+		if len(o.Elem().Items) > 1 {
+			return fmt.Errorf("only one 'analysis' block allowed per 'update' block")
+		}
+		if *result == nil {
+			*result = &api.UpdateStrategy{}
+		}
+		if err := parseDeploymentAnalysis(&(*result).Analysis, o.Elem().Items[0]); err != nil {
+			return multierror.Prefix(err, "analysis ->")
+		}
+	}
+
+	return nil
+}
+
+func parseDeploymentAnalysis(result **api.DeploymentAnalysis, item *ast.ObjectItem) error {
+	valid := []string{
+		"address",
+		"query",
+		"interval",
+		"operator",
+		"threshold",
+		"failure_limit",
+	}
+	if err := checkHCLKeys(item.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, item.Val); err != nil {
+		return err
+	}
+
 	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
 		WeaklyTypedInput: true,
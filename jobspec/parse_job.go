@@ -38,6 +38,8 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 	delete(m, "vault")
 	delete(m, "spread")
 	delete(m, "multiregion")
+	delete(m, "depends_on")
+	delete(m, "gc")
 
 	// Set the ID and name to the object key
 	result.ID = stringToPtr(obj.Keys[0].Token.Value().(string))
@@ -63,6 +65,8 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"affinity",
 		"spread",
 		"datacenters",
+		"depends_on",
+		"gc",
 		"group",
 		"id",
 		"meta",
@@ -128,6 +132,13 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		}
 	}
 
+	// If we have a gc block, then parse that
+	if o := listVal.Filter("gc"); len(o.Items) > 0 {
+		if err := parseJobGCPolicy(&result.GC, o); err != nil {
+			return multierror.Prefix(err, "gc ->")
+		}
+	}
+
 	// If we have a reschedule stanza, then parse that
 	if o := listVal.Filter("reschedule"); len(o.Items) > 0 {
 		if err := parseReschedulePolicy(&result.Reschedule, o); err != nil {
@@ -151,6 +162,13 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		result.Multiregion = &mr
 	}
 
+	// Parse dependencies
+	if o := listVal.Filter("depends_on"); len(o.Items) > 0 {
+		if err := parseDependencies(&result.DependsOn, o); err != nil {
+			return multierror.Prefix(err, "depends_on ->")
+		}
+	}
+
 	// Parse out meta fields. These are in HCL as a list so we need
 	// to iterate over them and merge them.
 	if metaO := listVal.Filter("meta"); len(metaO.Items) > 0 {
@@ -260,6 +278,32 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 	return nil
 }
 
+func parseDependencies(result *[]*api.JobDependency, list *ast.ObjectList) error {
+	for _, o := range list.Elem().Items {
+		// Check for invalid keys
+		valid := []string{
+			"job_id",
+			"condition",
+		}
+		if err := checkHCLKeys(o.Val, valid); err != nil {
+			return err
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		var d api.JobDependency
+		if err := mapstructure.WeakDecode(m, &d); err != nil {
+			return err
+		}
+		*result = append(*result, &d)
+	}
+
+	return nil
+}
+
 func parseParameterizedJob(result **api.ParameterizedJobConfig, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) > 1 {
@@ -273,12 +317,15 @@ func parseParameterizedJob(result **api.ParameterizedJobConfig, list *ast.Object
 	if err := hcl.DecodeObject(&m, o.Val); err != nil {
 		return err
 	}
+	delete(m, "retry")
 
 	// Check for invalid keys
 	valid := []string{
 		"payload",
 		"meta_required",
 		"meta_optional",
+		"retry",
+		"max_concurrent",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -290,6 +337,104 @@ func parseParameterizedJob(result **api.ParameterizedJobConfig, list *ast.Object
 		return err
 	}
 
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("parameterized: should be an object")
+	}
+
+	// If we have a retry block, then parse that
+	if o := listVal.Filter("retry"); len(o.Items) > 0 {
+		if err := parseDispatchRetryPolicy(&d.Retry, o); err != nil {
+			return multierror.Prefix(err, "retry ->")
+		}
+	}
+
 	*result = &d
 	return nil
 }
+
+func parseDispatchRetryPolicy(final **api.DispatchRetryPolicy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'retry' block allowed")
+	}
+
+	// Get our retry object
+	obj := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{
+		"attempts",
+		"delay",
+		"delay_function",
+		"max_delay",
+		"exit_codes",
+	}
+	if err := checkHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+
+	var result api.DispatchRetryPolicy
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &result,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	*final = &result
+	return nil
+}
+
+func parseJobGCPolicy(final **api.JobGCPolicy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'gc' block allowed per job")
+	}
+
+	// Get our gc object
+	obj := list.Items[0]
+
+	// Check for invalid keys
+	valid := []string{
+		"keep_successful",
+		"keep_failed",
+		"max_age",
+	}
+	if err := checkHCLKeys(obj.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, obj.Val); err != nil {
+		return err
+	}
+
+	var result api.JobGCPolicy
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &result,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	*final = &result
+	return nil
+}
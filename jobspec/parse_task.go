@@ -560,6 +560,7 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 		"network",
 		"device",
 		"cores",
+		"io",
 	}
 	if err := checkHCLKeys(listVal, valid); err != nil {
 		return multierror.Prefix(err, "resources ->")
@@ -571,6 +572,7 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 	}
 	delete(m, "network")
 	delete(m, "device")
+	delete(m, "io")
 
 	if err := mapstructure.WeakDecode(m, result); err != nil {
 		return err
@@ -610,6 +612,8 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 				"count",
 				"affinity",
 				"constraint",
+				"on_unhealthy",
+				"shares",
 			}
 			if err := checkHCLKeys(do.Val, valid); err != nil {
 				return multierror.Prefix(err, fmt.Sprintf("resources, device[%d]->", idx))
@@ -649,6 +653,84 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 		}
 	}
 
+	// Parse the io resources
+	if o := listVal.Filter("io"); len(o.Items) > 0 {
+		if err := parseIOResources(&result.IO, o); err != nil {
+			return multierror.Prefix(err, "resources ->")
+		}
+	}
+
+	return nil
+}
+
+func parseIOResources(result **api.IOResources, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'io' block allowed per resources")
+	}
+
+	// Get our io object
+	o := list.Items[0]
+
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("io: should be an object")
+	}
+
+	valid := []string{
+		"weight",
+		"device",
+	}
+	if err := checkHCLKeys(listVal, valid); err != nil {
+		return multierror.Prefix(err, "io ->")
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+	delete(m, "device")
+
+	var io api.IOResources
+	if err := mapstructure.WeakDecode(m, &io); err != nil {
+		return err
+	}
+
+	if o := listVal.Filter("device"); len(o.Items) > 0 {
+		io.Devices = make([]*api.IODeviceLimit, len(o.Items))
+		for idx, do := range o.Items {
+			if l := len(do.Keys); l == 0 {
+				return multierror.Prefix(fmt.Errorf("missing device path"), fmt.Sprintf("io, device[%d]->", idx))
+			} else if l > 1 {
+				return multierror.Prefix(fmt.Errorf("only one path may be specified"), fmt.Sprintf("io, device[%d]->", idx))
+			}
+
+			valid := []string{
+				"read_bps",
+				"write_bps",
+				"read_iops",
+				"write_iops",
+			}
+			if err := checkHCLKeys(do.Val, valid); err != nil {
+				return multierror.Prefix(err, fmt.Sprintf("io, device[%d]->", idx))
+			}
+
+			var m map[string]interface{}
+			if err := hcl.DecodeObject(&m, do.Val); err != nil {
+				return err
+			}
+
+			d := api.IODeviceLimit{Path: do.Keys[0].Token.Value().(string)}
+			if err := mapstructure.WeakDecode(m, &d); err != nil {
+				return err
+			}
+			io.Devices[idx] = &d
+		}
+	}
+
+	*result = &io
 	return nil
 }
 
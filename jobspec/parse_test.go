@@ -303,8 +303,9 @@ func TestParse(t *testing.T) {
 									},
 									Devices: []*api.RequestedDevice{
 										{
-											Name:  "nvidia/gpu",
-											Count: uint64ToPtr(10),
+											Name:        "nvidia/gpu",
+											Count:       uint64ToPtr(10),
+											OnUnhealthy: "reschedule",
 											Constraints: []*api.Constraint{
 												{
 													LTarget: "${device.attr.memory}",
@@ -326,6 +327,16 @@ func TestParse(t *testing.T) {
 											Count: nil,
 										},
 									},
+									IO: &api.IOResources{
+										Weight: intToPtr(500),
+										Devices: []*api.IODeviceLimit{
+											{
+												Path:      "/dev/sda",
+												ReadBps:   10485760,
+												WriteIOPS: 100,
+											},
+										},
+									},
 								},
 								KillTimeout:   timeToPtr(22 * time.Second),
 								ShutdownDelay: 11 * time.Second,
@@ -534,6 +545,49 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"periodic-gc.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				Periodic: &api.PeriodicConfig{
+					SpecType: stringToPtr(api.PeriodicSpecCron),
+					Spec:     stringToPtr("*/5 * * *"),
+				},
+				GC: &api.JobGCPolicy{
+					KeepSuccessful: intToPtr(5),
+					KeepFailed:     intToPtr(20),
+					MaxAge:         timeToPtr(168 * time.Hour),
+				},
+			},
+			false,
+		},
+
+		{
+			"ephemeral-disk-checkpoint.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("bar"),
+						EphemeralDisk: &api.EphemeralDisk{
+							Sticky:     boolToPtr(true),
+							Migrate:    boolToPtr(true),
+							Checkpoint: boolToPtr(true),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "baz",
+								Driver: "exec",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"specify-job.hcl",
 			&api.Job{
@@ -824,6 +878,64 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"parameterized-job-retry.hcl",
+			&api.Job{
+				ID:   stringToPtr("parameterized_job"),
+				Name: stringToPtr("parameterized_job"),
+				Type: stringToPtr("batch"),
+
+				ParameterizedJob: &api.ParameterizedJobConfig{
+					Payload: "optional",
+					Retry: &api.DispatchRetryPolicy{
+						Attempts:      intToPtr(5),
+						Delay:         timeToPtr(10 * time.Second),
+						DelayFunction: stringToPtr("constant"),
+						MaxDelay:      timeToPtr(5 * time.Minute),
+						ExitCodes:     []int{1, 75},
+					},
+				},
+
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("foo"),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"parameterized-job-max-concurrent.hcl",
+			&api.Job{
+				ID:   stringToPtr("parameterized_job"),
+				Name: stringToPtr("parameterized_job"),
+				Type: stringToPtr("batch"),
+
+				ParameterizedJob: &api.ParameterizedJobConfig{
+					Payload:       "forbidden",
+					MaxConcurrent: 10,
+				},
+
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("foo"),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"job-with-kill-signal.hcl",
 			&api.Job{
@@ -1740,6 +1852,59 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"job-dependencies.hcl",
+			&api.Job{
+				ID:   stringToPtr("transform"),
+				Name: stringToPtr("transform"),
+				Type: stringToPtr("batch"),
+				DependsOn: []*api.JobDependency{
+					{
+						JobID: stringToPtr("extract"),
+					},
+					{
+						JobID:     stringToPtr("cleanup"),
+						Condition: stringToPtr("complete"),
+					},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("transform"),
+						Tasks: []*api.Task{
+							{
+								Name:   "transform",
+								Driver: "exec",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"update-analysis.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("bar"),
+						Update: &api.UpdateStrategy{
+							MaxParallel: intToPtr(2),
+							Analysis: &api.DeploymentAnalysis{
+								Address:      stringToPtr("http://prometheus.service.consul:9090"),
+								Query:        stringToPtr(`sum(rate(http_errors{alloc="{{.AllocID}}"}[1m]))`),
+								Interval:     timeToPtr(30 * time.Second),
+								Operator:     stringToPtr("<"),
+								Threshold:    float64ToPtr(0.05),
+								FailureLimit: intToPtr(2),
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"multiregion.hcl",
 			&api.Job{
@@ -259,7 +259,8 @@ job "binstore-storagelocker" {
         }
 
         device "nvidia/gpu" {
-          count = 10
+          count        = 10
+          on_unhealthy = "reschedule"
 
           constraint {
             attribute = "${device.attr.memory}"
@@ -275,6 +276,15 @@ job "binstore-storagelocker" {
         }
 
         device "intel/gpu" {}
+
+        io {
+          weight = 500
+
+          device "/dev/sda" {
+            read_bps   = 10485760
+            write_iops = 100
+          }
+        }
       }
 
       kill_timeout = "22s"
@@ -0,0 +1,13 @@
+job "foo" {
+  group "bar" {
+    ephemeral_disk {
+      sticky     = true
+      migrate    = true
+      checkpoint = true
+    }
+
+    task "baz" {
+      driver = "exec"
+    }
+  }
+}
@@ -0,0 +1,18 @@
+job "transform" {
+  type = "batch"
+
+  depends_on {
+    job_id = "extract"
+  }
+
+  depends_on {
+    job_id    = "cleanup"
+    condition = "complete"
+  }
+
+  group "transform" {
+    task "transform" {
+      driver = "exec"
+    }
+  }
+}
@@ -0,0 +1,14 @@
+job "parameterized_job" {
+  type = "batch"
+
+  parameterized {
+    payload        = "forbidden"
+    max_concurrent = 10
+  }
+
+  group "foo" {
+    task "bar" {
+      driver = "docker"
+    }
+  }
+}
@@ -0,0 +1,21 @@
+job "parameterized_job" {
+  type = "batch"
+
+  parameterized {
+    payload = "optional"
+
+    retry {
+      attempts       = 5
+      delay          = "10s"
+      delay_function = "constant"
+      max_delay      = "5m"
+      exit_codes     = [1, 75]
+    }
+  }
+
+  group "foo" {
+    task "bar" {
+      driver = "docker"
+    }
+  }
+}
@@ -0,0 +1,11 @@
+job "foo" {
+  periodic {
+    cron = "*/5 * * *"
+  }
+
+  gc {
+    keep_successful = 5
+    keep_failed     = 20
+    max_age         = "168h"
+  }
+}
@@ -0,0 +1,16 @@
+job "foo" {
+  group "bar" {
+    update {
+      max_parallel = 2
+
+      analysis {
+        address       = "http://prometheus.service.consul:9090"
+        query         = "sum(rate(http_errors{alloc=\"{{.AllocID}}\"}[1m]))"
+        interval      = "30s"
+        operator      = "<"
+        threshold     = 0.05
+        failure_limit = 2
+      }
+    }
+  }
+}
@@ -31,6 +31,7 @@ type MockClientCSI struct {
 	NextDetachError                   error
 	NextCreateError                   error
 	NextCreateResponse                *cstructs.ClientCSIControllerCreateVolumeResponse
+	LastCreateRequest                 *cstructs.ClientCSIControllerCreateVolumeRequest
 	NextDeleteError                   error
 	NextListExternalError             error
 	NextListExternalResponse          *cstructs.ClientCSIControllerListVolumesResponse
@@ -40,6 +41,10 @@ type MockClientCSI struct {
 	NextListExternalSnapshotsError    error
 	NextListExternalSnapshotsResponse *cstructs.ClientCSIControllerListSnapshotsResponse
 	NextNodeDetachError               error
+	NextExpandError                   error
+	NextExpandResponse                *cstructs.ClientCSIControllerExpandVolumeResponse
+	NextNodeExpandError               error
+	LastNodeExpandRequest             *cstructs.ClientCSINodeExpandVolumeRequest
 }
 
 func newMockClientCSI() *MockClientCSI {
@@ -49,6 +54,7 @@ func newMockClientCSI() *MockClientCSI {
 		NextListExternalResponse:          &cstructs.ClientCSIControllerListVolumesResponse{},
 		NextCreateSnapshotResponse:        &cstructs.ClientCSIControllerCreateSnapshotResponse{},
 		NextListExternalSnapshotsResponse: &cstructs.ClientCSIControllerListSnapshotsResponse{},
+		NextExpandResponse:                &cstructs.ClientCSIControllerExpandVolumeResponse{},
 	}
 }
 
@@ -66,6 +72,7 @@ func (c *MockClientCSI) ControllerDetachVolume(req *cstructs.ClientCSIController
 }
 
 func (c *MockClientCSI) ControllerCreateVolume(req *cstructs.ClientCSIControllerCreateVolumeRequest, resp *cstructs.ClientCSIControllerCreateVolumeResponse) error {
+	c.LastCreateRequest = req
 	*resp = *c.NextCreateResponse
 	return c.NextCreateError
 }
@@ -74,6 +81,11 @@ func (c *MockClientCSI) ControllerDeleteVolume(req *cstructs.ClientCSIController
 	return c.NextDeleteError
 }
 
+func (c *MockClientCSI) ControllerExpandVolume(req *cstructs.ClientCSIControllerExpandVolumeRequest, resp *cstructs.ClientCSIControllerExpandVolumeResponse) error {
+	*resp = *c.NextExpandResponse
+	return c.NextExpandError
+}
+
 func (c *MockClientCSI) ControllerListVolumes(req *cstructs.ClientCSIControllerListVolumesRequest, resp *cstructs.ClientCSIControllerListVolumesResponse) error {
 	*resp = *c.NextListExternalResponse
 	return c.NextListExternalError
@@ -97,6 +109,11 @@ func (c *MockClientCSI) NodeDetachVolume(req *cstructs.ClientCSINodeDetachVolume
 	return c.NextNodeDetachError
 }
 
+func (c *MockClientCSI) NodeExpandVolume(req *cstructs.ClientCSINodeExpandVolumeRequest, resp *cstructs.ClientCSINodeExpandVolumeResponse) error {
+	c.LastNodeExpandRequest = req
+	return c.NextNodeExpandError
+}
+
 func TestClientCSIController_AttachVolume_Local(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -227,6 +244,38 @@ func TestClientCSIController_CreateVolume_Forwarded(t *testing.T) {
 	require.Contains(err.Error(), "no plugins registered for type")
 }
 
+func TestClientCSIController_ExpandVolume_Local(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	codec, cleanup := setupLocal(t)
+	defer cleanup()
+
+	req := &cstructs.ClientCSIControllerExpandVolumeRequest{
+		CSIControllerQuery: cstructs.CSIControllerQuery{PluginID: "minnie"},
+	}
+
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "ClientCSI.ControllerExpandVolume", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "no plugins registered for type")
+}
+
+func TestClientCSIController_ExpandVolume_Forwarded(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	codec, cleanup := setupForward(t)
+	defer cleanup()
+
+	req := &cstructs.ClientCSIControllerExpandVolumeRequest{
+		CSIControllerQuery: cstructs.CSIControllerQuery{PluginID: "minnie"},
+	}
+
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "ClientCSI.ControllerExpandVolume", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "no plugins registered for type")
+}
+
 func TestClientCSIController_DeleteVolume_Local(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -523,6 +572,7 @@ func setupLocal(t *testing.T) (rpc.ClientCodec, func()) {
 	mockCSI.NextDetachError = fmt.Errorf("no plugins registered for type")
 	mockCSI.NextCreateError = fmt.Errorf("no plugins registered for type")
 	mockCSI.NextDeleteError = fmt.Errorf("no plugins registered for type")
+	mockCSI.NextExpandError = fmt.Errorf("no plugins registered for type")
 	mockCSI.NextListExternalError = fmt.Errorf("no plugins registered for type")
 	mockCSI.NextCreateSnapshotError = fmt.Errorf("no plugins registered for type")
 	mockCSI.NextDeleteSnapshotError = fmt.Errorf("no plugins registered for type")
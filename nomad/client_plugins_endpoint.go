@@ -0,0 +1,76 @@
+package nomad
+
+import (
+	"errors"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+
+	"github.com/hashicorp/nomad/client/structs"
+)
+
+// ClientPlugins is used to forward RPC requests to the targeted Nomad client's
+// ClientPlugins endpoint.
+type ClientPlugins struct {
+	srv    *Server
+	logger log.Logger
+}
+
+func (s *ClientPlugins) List(args *nstructs.NodeSpecificRequest, reply *structs.ClientPluginsResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hop
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := s.srv.forward("ClientPlugins.List", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_plugins", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := s.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	// Verify the arguments.
+	if args.NodeID == "" {
+		return errors.New("missing NodeID")
+	}
+
+	// Check if the node even exists and is compatible with NodeRpc
+	snap, err := s.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Make sure Node is new enough to support RPC
+	_, err = getNodeForRpc(snap, args.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := s.srv.getNodeConn(args.NodeID)
+	if !ok {
+
+		// Determine the Server that has a connection to the node.
+		srv, err := s.srv.serverWithNodeConn(args.NodeID, s.srv.Region())
+		if err != nil {
+			return err
+		}
+
+		if srv == nil {
+			return nstructs.ErrNoNodeConn
+		}
+
+		return s.srv.forwardServer(srv, "ClientPlugins.List", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "ClientPlugins.List", args, reply)
+}
@@ -3,6 +3,7 @@ package nomad
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -106,44 +107,67 @@ func (c *CoreScheduler) jobGC(eval *structs.Evaluation) error {
 	var gcAlloc, gcEval []string
 	var gcJob []*structs.Job
 
-OUTER:
+	// Dead children of jobs with a GC policy are collected according to the
+	// policy of their parent instead of the threshold
+	parents := make(map[structs.NamespacedID]*structs.Job)
+	children := make(map[structs.NamespacedID][]*structs.Job)
+
 	for i := iter.Next(); i != nil; i = iter.Next() {
 		job := i.(*structs.Job)
 
+		if job.ParentID != "" {
+			parent, err := c.gcPolicyParent(parents, job)
+			if err != nil {
+				c.logger.Error("job GC failed to get parent of job", "job", job.ID, "error", err)
+				continue
+			}
+			if parent != nil {
+				id := structs.NewNamespacedID(parent.ID, parent.Namespace)
+				children[id] = append(children[id], job)
+				continue
+			}
+		}
+
 		// Ignore new jobs.
 		if job.CreateIndex > oldThreshold {
 			continue
 		}
 
-		ws := memdb.NewWatchSet()
-		evals, err := c.snap.EvalsByJob(ws, job.Namespace, job.ID)
+		// Job is eligible for garbage collection
+		if jobEval, jobAlloc, ok := c.jobEvalsGC(job, oldThreshold); ok {
+			gcJob = append(gcJob, job)
+			gcAlloc = append(gcAlloc, jobAlloc...)
+			gcEval = append(gcEval, jobEval...)
+		}
+	}
+
+	for id, jobs := range children {
+		expired, unlimited, err := c.childJobsToGC(parents[id], jobs)
 		if err != nil {
-			c.logger.Error("job GC failed to get evals for job", "job", job.ID, "error", err)
+			c.logger.Error("job GC failed to apply GC policy of job", "job", id.ID, "error", err)
 			continue
 		}
 
-		allEvalsGC := true
-		var jobAlloc, jobEval []string
-		for _, eval := range evals {
-			gc, allocs, err := c.gcEval(eval, oldThreshold, true)
-			if err != nil {
-				continue OUTER
-			} else if gc {
-				jobEval = append(jobEval, eval.ID)
-				jobAlloc = append(jobAlloc, allocs...)
-			} else {
-				allEvalsGC = false
-				break
+		// The policy overrides the threshold
+		for _, job := range expired {
+			if jobEval, jobAlloc, ok := c.jobEvalsGC(job, math.MaxUint64); ok {
+				gcJob = append(gcJob, job)
+				gcAlloc = append(gcAlloc, jobAlloc...)
+				gcEval = append(gcEval, jobEval...)
 			}
 		}
 
-		// Job is eligible for garbage collection
-		if allEvalsGC {
-			gcJob = append(gcJob, job)
-			gcAlloc = append(gcAlloc, jobAlloc...)
-			gcEval = append(gcEval, jobEval...)
+		// Children the policy doesn't limit are subject to the threshold
+		for _, job := range unlimited {
+			if job.CreateIndex > oldThreshold {
+				continue
+			}
+			if jobEval, jobAlloc, ok := c.jobEvalsGC(job, oldThreshold); ok {
+				gcJob = append(gcJob, job)
+				gcAlloc = append(gcAlloc, jobAlloc...)
+				gcEval = append(gcEval, jobEval...)
+			}
 		}
-
 	}
 
 	// Fast-path the nothing case
@@ -163,6 +187,88 @@ OUTER:
 	return c.jobReap(gcJob, eval.LeaderACL)
 }
 
+// jobEvalsGC returns the evaluations and allocations of the job to garbage
+// collect given a threshold index. The job is only eligible for garbage
+// collection if all of its evaluations are.
+func (c *CoreScheduler) jobEvalsGC(job *structs.Job, thresholdIndex uint64) ([]string, []string, bool) {
+	ws := memdb.NewWatchSet()
+	evals, err := c.snap.EvalsByJob(ws, job.Namespace, job.ID)
+	if err != nil {
+		c.logger.Error("job GC failed to get evals for job", "job", job.ID, "error", err)
+		return nil, nil, false
+	}
+
+	var jobAlloc, jobEval []string
+	for _, eval := range evals {
+		gc, allocs, err := c.gcEval(eval, thresholdIndex, true)
+		if err != nil || !gc {
+			return nil, nil, false
+		}
+		jobEval = append(jobEval, eval.ID)
+		jobAlloc = append(jobAlloc, allocs...)
+	}
+	return jobEval, jobAlloc, true
+}
+
+// gcPolicyParent returns the parent of the job if the parent has a GC policy.
+// Parents are cached in the given map, including those without a policy.
+func (c *CoreScheduler) gcPolicyParent(parents map[structs.NamespacedID]*structs.Job, job *structs.Job) (*structs.Job, error) {
+	id := structs.NewNamespacedID(job.ParentID, job.Namespace)
+	if parent, ok := parents[id]; ok {
+		return parent, nil
+	}
+
+	parent, err := c.snap.JobByID(nil, job.Namespace, job.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil && parent.GC == nil {
+		parent = nil
+	}
+	parents[id] = parent
+	return parent, nil
+}
+
+// childJobsToGC returns the dead children of the parent job that are no
+// longer kept by its GC policy. Successful and failed children are counted
+// separately, keeping the most recently launched ones. Children of a category
+// the policy limits neither by count nor by age are returned separately, to be
+// garbage collected according to the threshold.
+func (c *CoreScheduler) childJobsToGC(parent *structs.Job, children []*structs.Job) ([]*structs.Job, []*structs.Job, error) {
+	policy := parent.GC
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].CreateIndex > children[j].CreateIndex
+	})
+
+	// Children that finished before the cutoff index exceed the max age
+	var cutoff uint64
+	if policy.MaxAge > 0 {
+		cutoff = c.srv.fsm.TimeTable().NearestIndex(time.Now().UTC().Add(-policy.MaxAge))
+	}
+
+	var succeeded, failed int
+	var expired, unlimited []*structs.Job
+	for _, child := range children {
+		summary, err := c.snap.JobSummaryByID(nil, child.Namespace, child.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		keep, count := policy.KeepFailed, &failed
+		if !child.Stopped() && summary.Succeeded() {
+			keep, count = policy.KeepSuccessful, &succeeded
+		}
+		*count++
+
+		if keep == 0 && policy.MaxAge == 0 {
+			unlimited = append(unlimited, child)
+		} else if (keep > 0 && *count > keep) || child.ModifyIndex <= cutoff {
+			expired = append(expired, child)
+		}
+	}
+	return expired, unlimited, nil
+}
+
 // jobReap contacts the leader and issues a reap on the passed jobs
 func (c *CoreScheduler) jobReap(jobs []*structs.Job, leaderACL string) error {
 	// Call to the leader to issue the reap
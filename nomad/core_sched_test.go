@@ -1737,6 +1737,192 @@ func TestCoreScheduler_JobGC_Periodic(t *testing.T) {
 	}
 }
 
+// upsertDeadChildJob inserts a dead child of the parent job whose single
+// allocation finished with the given client status.
+func upsertDeadChildJob(t *testing.T, store *state.StateStore, index uint64, parent *structs.Job, clientStatus string) *structs.Job {
+	child := parent.Copy()
+	child.ID = fmt.Sprintf("%s%s%d", parent.ID, structs.PeriodicLaunchSuffix, index)
+	if parent.IsParameterized() {
+		child.ID = fmt.Sprintf("%s%s%d", parent.ID, structs.DispatchLaunchSuffix, index)
+		child.Dispatched = true
+	}
+	child.ParentID = parent.ID
+	child.Periodic = nil
+	child.ParameterizedJob = nil
+	child.GC = nil
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, child))
+
+	eval := mock.Eval()
+	eval.JobID = child.ID
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, index+1, []*structs.Evaluation{eval}))
+
+	alloc := mock.Alloc()
+	alloc.Job = child
+	alloc.JobID = child.ID
+	alloc.EvalID = eval.ID
+	alloc.TaskGroup = child.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusPending
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index+2, []*structs.Allocation{alloc}))
+
+	update := alloc.Copy()
+	update.ClientStatus = clientStatus
+	require.NoError(t, store.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index+3, []*structs.Allocation{update}))
+
+	out, err := store.JobByID(nil, child.Namespace, child.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusDead, out.Status)
+	return out
+}
+
+func TestCoreScheduler_JobGC_Policy(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	store := s1.fsm.State()
+	parent := mock.PeriodicJob()
+	parent.GC = &structs.JobGCPolicy{
+		KeepSuccessful: 1,
+		KeepFailed:     2,
+	}
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, parent))
+
+	// Children are kept by the policy even though they are past the threshold
+	var successful, failed []*structs.Job
+	for i := uint64(0); i < 3; i++ {
+		index := 1100 + i*100
+		successful = append(successful, upsertDeadChildJob(t, store, index, parent, structs.AllocClientStatusComplete))
+		failed = append(failed, upsertDeadChildJob(t, store, index+50, parent, structs.AllocClientStatusFailed))
+	}
+
+	// A dead batch job without a parent is not affected
+	other := mock.Job()
+	other.Type = structs.JobTypeBatch
+	other.Status = structs.JobStatusDead
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1500, other))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	gc := s1.coreJobEval(structs.CoreJobJobGC, 2000)
+	require.NoError(t, core.Process(gc))
+
+	exists := func(job *structs.Job) bool {
+		out, err := store.JobByID(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		return out != nil
+	}
+
+	// The most recent successful child and two failed children are kept
+	require.False(t, exists(successful[0]))
+	require.False(t, exists(successful[1]))
+	require.True(t, exists(successful[2]))
+	require.False(t, exists(failed[0]))
+	require.True(t, exists(failed[1]))
+	require.True(t, exists(failed[2]))
+	require.True(t, exists(other))
+	require.True(t, exists(parent))
+}
+
+func TestCoreScheduler_JobGC_Policy_MaxAge(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	store := s1.fsm.State()
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	parent.GC = &structs.JobGCPolicy{
+		KeepFailed: 5,
+		MaxAge:     time.Hour,
+	}
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, parent))
+
+	oldSuccess := upsertDeadChildJob(t, store, 1100, parent, structs.AllocClientStatusComplete)
+	oldFailed := upsertDeadChildJob(t, store, 1200, parent, structs.AllocClientStatusFailed)
+
+	// Children that finished before the cutoff exceed the max age
+	tt := s1.fsm.TimeTable()
+	tt.Witness(1500, time.Now().UTC().Add(-2*time.Hour))
+
+	newSuccess := upsertDeadChildJob(t, store, 2100, parent, structs.AllocClientStatusComplete)
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	gc := s1.coreJobEval(structs.CoreJobJobGC, 3000)
+	require.NoError(t, core.Process(gc))
+
+	for _, job := range []*structs.Job{oldSuccess, oldFailed} {
+		out, err := store.JobByID(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	}
+	out, err := store.JobByID(nil, newSuccess.Namespace, newSuccess.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestCoreScheduler_JobGC_Policy_Threshold(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	store := s1.fsm.State()
+	parent := mock.PeriodicJob()
+	parent.GC = &structs.JobGCPolicy{
+		KeepFailed: 1,
+	}
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, parent))
+
+	oldSuccess := upsertDeadChildJob(t, store, 1100, parent, structs.AllocClientStatusComplete)
+	oldFailed := upsertDeadChildJob(t, store, 1200, parent, structs.AllocClientStatusFailed)
+	newFailed := upsertDeadChildJob(t, store, 1300, parent, structs.AllocClientStatusFailed)
+
+	// Successful children aren't limited by the policy, so they're collected
+	// once they're past the threshold
+	tt := s1.fsm.TimeTable()
+	tt.Witness(1500, time.Now().UTC().Add(-1*s1.config.JobGCThreshold))
+
+	newSuccess := upsertDeadChildJob(t, store, 2100, parent, structs.AllocClientStatusComplete)
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	gc := s1.coreJobEval(structs.CoreJobJobGC, 3000)
+	require.NoError(t, core.Process(gc))
+
+	exists := func(job *structs.Job) bool {
+		out, err := store.JobByID(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		return out != nil
+	}
+
+	require.False(t, exists(oldSuccess))
+	require.True(t, exists(newSuccess))
+	require.False(t, exists(oldFailed))
+	require.True(t, exists(newFailed))
+}
+
 func TestCoreScheduler_DeploymentGC(t *testing.T) {
 	ci.Parallel(t)
 
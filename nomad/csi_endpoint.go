@@ -247,6 +247,12 @@ func (v *CSIVolume) Get(args *structs.CSIVolumeGetRequest, reply *structs.CSIVol
 			if err != nil {
 				return err
 			}
+			if vol != nil {
+				vol, err = snap.CSIVolumeDenormalizeNodeStats(ws, vol)
+			}
+			if err != nil {
+				return err
+			}
 
 			reply.Volume = vol
 			return v.srv.replySetIndex(csiVolumeTable, &reply.QueryMeta)
@@ -932,9 +938,17 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 	type validated struct {
 		vol    *structs.CSIVolume
 		plugin *structs.CSIPlugin
+		// current is the volume already in the state store, if the
+		// request asks to expand an existing volume
+		current *structs.CSIVolume
 	}
 	validatedVols := []validated{}
 
+	snap, err := v.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
 	// This is the only namespace we ACL checked, force all the volumes to use it.
 	// We also validate that the plugin exists for each plugin, and validate the
 	// capabilities when the plugin has a controller.
@@ -955,12 +969,30 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 		if !plugin.HasControllerCapability(structs.CSIControllerSupportsCreateDelete) {
 			return fmt.Errorf("plugin does not support creating volumes")
 		}
+		if err := validateVolumeContentSource(vol, plugin); err != nil {
+			return err
+		}
+
+		current, err := snap.CSIVolumeByID(nil, vol.Namespace, vol.ID)
+		if err != nil {
+			return err
+		}
+		if current != nil && current.ExternalID != "" &&
+			vol.RequestedCapacityMin > current.Capacity {
+			if !plugin.HasControllerCapability(structs.CSIControllerSupportsExpand) {
+				return fmt.Errorf("plugin does not support expanding volumes")
+			}
+		} else {
+			current = nil
+		}
 
-		validatedVols = append(validatedVols, validated{vol, plugin})
+		validatedVols = append(validatedVols, validated{vol, plugin, current})
 	}
 
 	// Attempt to create all the validated volumes and write only successfully
-	// created volumes to raft. And we'll report errors for any failed volumes
+	// created volumes to raft. And we'll report errors for any failed volumes.
+	// Existing volumes that request a larger capacity are expanded instead,
+	// and their new capacity is written to raft separately.
 	//
 	// NOTE: creating the volume in the external storage provider can't be
 	// made atomic with the registration, and creating the volume provides
@@ -971,8 +1003,21 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 	// eval" that can do the plugin RPCs async.
 
 	var mErr multierror.Error
+	var expanded []*structs.CSIVolume
+	var index uint64
 
 	for _, valid := range validatedVols {
+		if valid.current != nil {
+			vol, expandIndex, err := v.expandVolume(valid.current, valid.plugin,
+				valid.vol.RequestedCapacityMin, valid.vol.RequestedCapacityMax)
+			if err != nil {
+				multierror.Append(&mErr, err)
+			} else {
+				expanded = append(expanded, vol)
+				index = expandIndex
+			}
+			continue
+		}
 		err = v.createVolume(valid.vol, valid.plugin)
 		if err != nil {
 			multierror.Append(&mErr, err)
@@ -981,13 +1026,16 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 		}
 	}
 
-	resp, index, err := v.srv.raftApply(structs.CSIVolumeRegisterRequestType, regArgs)
-	if err != nil {
-		v.logger.Error("csi raft apply failed", "error", err, "method", "register")
-		return err
-	}
-	if respErr, ok := resp.(error); ok {
-		multierror.Append(&mErr, respErr)
+	if len(regArgs.Volumes) > 0 || len(expanded) == 0 {
+		resp, regIndex, err := v.srv.raftApply(structs.CSIVolumeRegisterRequestType, regArgs)
+		if err != nil {
+			v.logger.Error("csi raft apply failed", "error", err, "method", "register")
+			return err
+		}
+		if respErr, ok := resp.(error); ok {
+			multierror.Append(&mErr, respErr)
+		}
+		index = regIndex
 	}
 
 	err = mErr.ErrorOrNil()
@@ -995,12 +1043,28 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 		return err
 	}
 
-	reply.Volumes = regArgs.Volumes
+	reply.Volumes = append(regArgs.Volumes, expanded...)
 	reply.Index = index
 	v.srv.setQueryMeta(&reply.QueryMeta)
 	return nil
 }
 
+// validateVolumeContentSource ensures the controller plugin advertises the
+// capabilities required to create a volume from a snapshot or another volume.
+// Per the CSI spec, restoring a snapshot requires CREATE_DELETE_SNAPSHOT and
+// cloning a volume requires CLONE_VOLUME.
+func validateVolumeContentSource(vol *structs.CSIVolume, plugin *structs.CSIPlugin) error {
+	if vol.SnapshotID != "" &&
+		!plugin.HasControllerCapability(structs.CSIControllerSupportsCreateDeleteSnapshot) {
+		return fmt.Errorf("plugin does not support creating volumes from snapshots")
+	}
+	if vol.CloneID != "" &&
+		!plugin.HasControllerCapability(structs.CSIControllerSupportsClone) {
+		return fmt.Errorf("plugin does not support cloning volumes")
+	}
+	return nil
+}
+
 func (v *CSIVolume) createVolume(vol *structs.CSIVolume, plugin *structs.CSIPlugin) error {
 
 	method := "ClientCSI.ControllerCreateVolume"
@@ -1030,6 +1094,97 @@ func (v *CSIVolume) createVolume(vol *structs.CSIVolume, plugin *structs.CSIPlug
 	return nil
 }
 
+// expandVolume grows an existing volume in the external storage provider,
+// then expands it on each node where it's claimed if the plugin requires it,
+// and finally writes the new capacity to raft. Node expansion errors are
+// returned after the new capacity is written, as the controller expansion
+// can't be rolled back.
+func (v *CSIVolume) expandVolume(vol *structs.CSIVolume, plugin *structs.CSIPlugin, capacityMin, capacityMax int64) (*structs.CSIVolume, uint64, error) {
+
+	cReq := &cstructs.ClientCSIControllerExpandVolumeRequest{
+		ExternalVolumeID: vol.ExternalID,
+		CapacityMin:      capacityMin,
+		CapacityMax:      capacityMax,
+		Secrets:          vol.Secrets,
+		MountOptions:     vol.MountOptions,
+	}
+	if vol.AccessMode != structs.CSIVolumeAccessModeUnknown {
+		cReq.VolumeCapability = &structs.CSIVolumeCapability{
+			AccessMode:     vol.AccessMode,
+			AttachmentMode: vol.AttachmentMode,
+		}
+	}
+	cReq.PluginID = plugin.ID
+	cResp := &cstructs.ClientCSIControllerExpandVolumeResponse{}
+	err := v.srv.RPC("ClientCSI.ControllerExpandVolume", cReq, cResp)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not expand volume %q: %v", vol.ID, err)
+	}
+
+	var mErr multierror.Error
+	if cResp.NodeExpansionRequired && plugin.HasNodeCapability(structs.CSINodeSupportsExpand) {
+		for _, claim := range vol.ReadClaims {
+			multierror.Append(&mErr, v.nodeExpandVolume(vol, claim, capacityMin, capacityMax))
+		}
+		for _, claim := range vol.WriteClaims {
+			multierror.Append(&mErr, v.nodeExpandVolume(vol, claim, capacityMin, capacityMax))
+		}
+	}
+
+	req := &structs.CSIVolumeExpandRequest{
+		VolumeID:             vol.ID,
+		Capacity:             cResp.CapacityBytes,
+		RequestedCapacityMin: capacityMin,
+		RequestedCapacityMax: capacityMax,
+		WriteRequest: structs.WriteRequest{
+			Namespace: vol.Namespace,
+		},
+	}
+	resp, index, err := v.srv.raftApply(structs.CSIVolumeExpandRequestType, req)
+	if err != nil {
+		v.logger.Error("csi raft apply failed", "error", err, "method", "expand")
+		return nil, 0, err
+	}
+	if respErr, ok := resp.(error); ok {
+		return nil, 0, respErr
+	}
+
+	vol = vol.Copy()
+	vol.Capacity = cResp.CapacityBytes
+	vol.RequestedCapacityMin = capacityMin
+	vol.RequestedCapacityMax = capacityMax
+	vol.ModifyIndex = index
+	return vol, index, mErr.ErrorOrNil()
+}
+
+func (v *CSIVolume) nodeExpandVolume(vol *structs.CSIVolume, claim *structs.CSIVolumeClaim, capacityMin, capacityMax int64) error {
+	if claim.AccessMode == structs.CSIVolumeAccessModeUnknown {
+		// claim has already been released client-side
+		return nil
+	}
+
+	req := &cstructs.ClientCSINodeExpandVolumeRequest{
+		PluginID:       vol.PluginID,
+		VolumeID:       vol.ID,
+		ExternalID:     vol.RemoteID(),
+		AllocID:        claim.AllocationID,
+		NodeID:         claim.NodeID,
+		AttachmentMode: claim.AttachmentMode,
+		AccessMode:     claim.AccessMode,
+		ReadOnly:       claim.Mode == structs.CSIVolumeClaimRead,
+		MountOptions:   vol.MountOptions,
+		CapacityMin:    capacityMin,
+		CapacityMax:    capacityMax,
+	}
+	err := v.srv.RPC("ClientCSI.NodeExpandVolume",
+		req, &cstructs.ClientCSINodeExpandVolumeResponse{})
+	if err != nil {
+		return fmt.Errorf("could not expand volume %q on node %q: %v",
+			vol.ID, claim.NodeID, err)
+	}
+	return nil
+}
+
 func (v *CSIVolume) Delete(args *structs.CSIVolumeDeleteRequest, reply *structs.CSIVolumeDeleteResponse) error {
 	if done, err := v.srv.forward("CSIVolume.Delete", args, args, reply); done {
 		return err
@@ -1106,6 +1106,180 @@ func TestCSIVolumeEndpoint_Create(t *testing.T) {
 	require.Equal(t, "bar", vol.Context["plugincontext"])
 	require.Equal(t, "", vol.Context["mycontext"])
 	require.Equal(t, map[string]string{"rack": "R1"}, vol.Topologies[0].Segments)
+
+	// Restoring snapshots and cloning volumes require the matching controller
+	// capabilities
+	restoreReq := &structs.CSIVolumeCreateRequest{
+		Volumes: []*structs.CSIVolume{{
+			ID:                    uuid.Generate(),
+			Name:                  "restored",
+			PluginID:              "minnie",
+			SnapshotID:            "snap-12345",
+			RequestedCapabilities: vols[0].RequestedCapabilities,
+		}},
+		WriteRequest: structs.WriteRequest{Region: "global", Namespace: ns},
+	}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Create", restoreReq,
+		&structs.CSIVolumeCreateResponse{})
+	require.EqualError(t, err, "plugin does not support creating volumes from snapshots")
+
+	node = node.Copy()
+	node.CSIControllerPlugins["minnie"].ControllerInfo.SupportsCreateDeleteSnapshot = true
+	index++
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, index, node))
+
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Create", restoreReq,
+		&structs.CSIVolumeCreateResponse{})
+	require.NoError(t, err)
+	require.Equal(t, "snap-12345", fake.LastCreateRequest.SnapshotID)
+	require.Empty(t, fake.LastCreateRequest.CloneID)
+
+	cloneReq := &structs.CSIVolumeCreateRequest{
+		Volumes: []*structs.CSIVolume{{
+			ID:                    uuid.Generate(),
+			Name:                  "cloned",
+			PluginID:              "minnie",
+			CloneID:               "vol-12345",
+			RequestedCapabilities: vols[0].RequestedCapabilities,
+		}},
+		WriteRequest: structs.WriteRequest{Region: "global", Namespace: ns},
+	}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Create", cloneReq,
+		&structs.CSIVolumeCreateResponse{})
+	require.EqualError(t, err, "plugin does not support cloning volumes")
+}
+
+func TestCSIVolumeEndpoint_Create_Expand(t *testing.T) {
+	ci.Parallel(t)
+	var err error
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+
+	testutil.WaitForLeader(t, srv.RPC)
+
+	fake := newMockClientCSI()
+	fake.NextCreateResponse = &cstructs.ClientCSIControllerCreateVolumeResponse{
+		ExternalVolumeID: "vol-12345",
+		CapacityBytes:    42,
+	}
+	fake.NextExpandResponse = &cstructs.ClientCSIControllerExpandVolumeResponse{
+		CapacityBytes: 100,
+	}
+
+	client, cleanup := client.TestClientWithRPCs(t,
+		func(c *cconfig.Config) {
+			c.Servers = []string{srv.config.RPCAddr.String()}
+		},
+		map[string]interface{}{"CSI": fake},
+	)
+	defer cleanup()
+
+	node := client.Node()
+	node.Attributes["nomad.version"] = "0.11.0" // client RPCs not supported on early versions
+
+	req0 := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp0 structs.NodeUpdateResponse
+	err = client.RPC("Node.Register", req0, &resp0)
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := srv.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a client")
+	})
+
+	ns := structs.DefaultNamespace
+	state := srv.fsm.State()
+	codec := rpcClient(t, srv)
+	index := uint64(1000)
+
+	node.CSIControllerPlugins = map[string]*structs.CSIInfo{
+		"minnie": {
+			PluginID: "minnie",
+			Healthy:  true,
+			ControllerInfo: &structs.CSIControllerInfo{
+				SupportsCreateDelete: true,
+				SupportsExpand:       true,
+			},
+			RequiresControllerPlugin: true,
+		},
+	}
+	node.CSINodePlugins = map[string]*structs.CSIInfo{
+		"minnie": {
+			PluginID: "minnie",
+			Healthy:  true,
+			NodeInfo: &structs.CSINodeInfo{},
+		},
+	}
+	index++
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, index, node))
+
+	volID := uuid.Generate()
+	newVolume := func(capacityMin int64) []*structs.CSIVolume {
+		return []*structs.CSIVolume{{
+			ID:                   volID,
+			Name:                 "vol",
+			PluginID:             "minnie",
+			RequestedCapacityMin: capacityMin,
+			RequestedCapabilities: []*structs.CSIVolumeCapability{{
+				AccessMode:     structs.CSIVolumeAccessModeSingleNodeWriter,
+				AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+			}},
+		}}
+	}
+
+	req1 := &structs.CSIVolumeCreateRequest{
+		Volumes: newVolume(10),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns,
+		},
+	}
+	resp1 := &structs.CSIVolumeCreateResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Create", req1, resp1)
+	require.NoError(t, err)
+
+	vol, err := state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), vol.Capacity)
+
+	// re-running the create with a larger minimum capacity expands the
+	// existing volume instead of creating a new one
+	req2 := &structs.CSIVolumeCreateRequest{
+		Volumes: newVolume(100),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns,
+		},
+	}
+	resp2 := &structs.CSIVolumeCreateResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Create", req2, resp2)
+	require.NoError(t, err)
+	require.Len(t, resp2.Volumes, 1)
+	require.Equal(t, int64(100), resp2.Volumes[0].Capacity)
+
+	vol, err = state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	require.Equal(t, "vol-12345", vol.ExternalID)
+	require.Equal(t, int64(100), vol.Capacity)
+	require.Equal(t, int64(100), vol.RequestedCapacityMin)
+
+	// controller failures are returned and leave the volume untouched
+	fake.NextExpandError = fmt.Errorf("no space left")
+	req2.Volumes = newVolume(200)
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Create", req2, resp2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no space left")
+
+	vol, err = state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), vol.Capacity)
 }
 
 func TestCSIVolumeEndpoint_Delete(t *testing.T) {
@@ -0,0 +1,207 @@
+package nomad
+
+import (
+	"fmt"
+
+	metrics "github.com/armon/go-metrics"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// waitingEvals returns all the evaluations waiting to be scheduled. A job
+// may have several of them, for instance if it's registered again while
+// waiting on its dependencies.
+func waitingEvals(ws memdb.WatchSet, snap *state.StateStore) ([]*structs.Evaluation, error) {
+	iter, err := snap.EvalsByStatus(ws, structs.EvalStatusWaiting)
+	if err != nil {
+		return nil, err
+	}
+
+	var waiting []*structs.Evaluation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		waiting = append(waiting, raw.(*structs.Evaluation))
+	}
+	return waiting, nil
+}
+
+// dispatchQueue returns the dispatch queue of the parameterized job. Dispatched
+// jobs with a waiting evaluation are queued once, by their oldest waiting
+// evaluation, while the remaining dispatched jobs that are not dead have been
+// released and count towards the limit.
+func dispatchQueue(ws memdb.WatchSet, snap *state.StateStore, parent *structs.Job,
+	waiting []*structs.Evaluation) (*structs.JobDispatchQueue, error) {
+
+	oldest := make(map[structs.NamespacedID]*structs.Evaluation)
+	for _, eval := range waiting {
+		id := structs.NewNamespacedID(eval.JobID, eval.Namespace)
+		if e, ok := oldest[id]; !ok || eval.CreateIndex < e.CreateIndex {
+			oldest[id] = eval
+		}
+	}
+
+	queue := &structs.JobDispatchQueue{
+		JobID:         parent.ID,
+		Namespace:     parent.Namespace,
+		MaxConcurrent: parent.ParameterizedJob.MaxConcurrent,
+		Queued:        []*structs.JobDispatchQueueEntry{},
+	}
+
+	iter, err := snap.JobsByIDPrefix(ws, parent.Namespace, parent.ID+structs.DispatchLaunchSuffix)
+	if err != nil {
+		return nil, err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if job.ParentID != parent.ID {
+			continue
+		}
+
+		eval, ok := oldest[structs.NewNamespacedID(job.ID, job.Namespace)]
+		if !ok {
+			if job.Status != structs.JobStatusDead {
+				queue.Running++
+			}
+			continue
+		}
+
+		queue.Queued = append(queue.Queued, &structs.JobDispatchQueueEntry{
+			JobID:       job.ID,
+			EvalID:      eval.ID,
+			Priority:    job.Priority,
+			SubmitTime:  job.SubmitTime,
+			CreateIndex: eval.CreateIndex,
+		})
+	}
+
+	structs.SortDispatchQueue(queue.Queued)
+	return queue, nil
+}
+
+// limitDispatchConcurrency filters the updates releasing waiting evaluations
+// so that dispatched jobs of parameterized jobs with MaxConcurrent set are
+// only released while there are free slots, in the order of the dispatch
+// queue. Each dispatched job takes a single slot, so all its ready evaluations
+// are released or held back together. It returns the remaining updates along
+// with the dispatch queues as they are once the updates are applied.
+func limitDispatchConcurrency(ws memdb.WatchSet, snap *state.StateStore,
+	waiting []*structs.Evaluation, updates []*structs.Evaluation) (
+	[]*structs.Evaluation, []*structs.JobDispatchQueue, error) {
+
+	// Find the parameterized jobs with a limit and queued dispatched jobs
+	parents := make(map[structs.NamespacedID]*structs.Job)
+	for _, eval := range waiting {
+		job, err := snap.JobByID(ws, eval.Namespace, eval.JobID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if job == nil || !job.Dispatched {
+			continue
+		}
+
+		id := structs.NewNamespacedID(job.ParentID, job.Namespace)
+		if _, ok := parents[id]; ok {
+			continue
+		}
+		parent, err := snap.JobByID(ws, job.Namespace, job.ParentID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if parent != nil && parent.IsParameterized() && parent.ParameterizedJob.MaxConcurrent > 0 {
+			parents[id] = parent
+		}
+	}
+	if len(parents) == 0 {
+		return updates, nil, nil
+	}
+
+	// Index the evaluations that are ready to be released by job
+	ready := make(map[structs.NamespacedID][]*structs.Evaluation)
+	for _, update := range updates {
+		if update.Status == structs.EvalStatusPending {
+			id := structs.NewNamespacedID(update.JobID, update.Namespace)
+			ready[id] = append(ready[id], update)
+		}
+	}
+
+	held := make(map[string]struct{})
+
+	queues := make([]*structs.JobDispatchQueue, 0, len(parents))
+	for _, parent := range parents {
+		queue, err := dispatchQueue(ws, snap, parent, waiting)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Release the ready evaluations in queue order while there are free
+		// slots, holding back the rest
+		var remaining []*structs.JobDispatchQueueEntry
+		for _, entry := range queue.Queued {
+			evals, ok := ready[structs.NewNamespacedID(entry.JobID, queue.Namespace)]
+			if !ok {
+				remaining = append(remaining, entry)
+				continue
+			}
+
+			if queue.Running < queue.MaxConcurrent {
+				queue.Running++
+				for _, update := range evals {
+					if update.StatusDescription == "" {
+						update.StatusDescription = "released from dispatch queue"
+					} else {
+						update.StatusDescription = fmt.Sprintf("%s; released from dispatch queue", update.StatusDescription)
+					}
+				}
+				continue
+			}
+
+			for _, update := range evals {
+				held[update.ID] = struct{}{}
+			}
+			remaining = append(remaining, entry)
+		}
+		queue.Queued = remaining
+		queues = append(queues, queue)
+	}
+
+	// Drop the updates that were held back. Cancelled evaluations are always
+	// applied.
+	filtered := updates[:0]
+	for _, update := range updates {
+		if _, ok := held[update.ID]; ok {
+			continue
+		}
+		filtered = append(filtered, update)
+	}
+
+	return filtered, queues, nil
+}
+
+// emitDispatchQueueMetrics emits the depth and running gauges of the dispatch
+// queues. Queues that were emitted previously but are no longer present are
+// reset to zero. It returns the set of queues emitted.
+func emitDispatchQueueMetrics(queues []*structs.JobDispatchQueue,
+	previous map[structs.NamespacedID]struct{}) map[structs.NamespacedID]struct{} {
+
+	emitted := make(map[structs.NamespacedID]struct{}, len(queues))
+	emit := func(id structs.NamespacedID, depth, running int) {
+		labels := []metrics.Label{
+			{Name: "job", Value: id.ID},
+			{Name: "namespace", Value: id.Namespace},
+		}
+		metrics.SetGaugeWithLabels([]string{"nomad", "dispatch_queue", "depth"}, float32(depth), labels)
+		metrics.SetGaugeWithLabels([]string{"nomad", "dispatch_queue", "running"}, float32(running), labels)
+	}
+
+	for _, queue := range queues {
+		id := structs.NewNamespacedID(queue.JobID, queue.Namespace)
+		emit(id, len(queue.Queued), queue.Running)
+		emitted[id] = struct{}{}
+	}
+	for id := range previous {
+		if _, ok := emitted[id]; !ok {
+			emit(id, 0, 0)
+		}
+	}
+	return emitted
+}
@@ -0,0 +1,220 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// upsertQueuedDispatchedJob upserts a dispatched job of the parent with the
+// given priority along with its waiting evaluation.
+func upsertQueuedDispatchedJob(t *testing.T, s *state.StateStore, index uint64, parent *structs.Job, priority int) (*structs.Job, *structs.Evaluation) {
+	job := mockDispatchedJob(parent)
+	job.Priority = priority
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, index, job))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	eval.Priority = priority
+	eval.Status = job.RegisterEvalStatus()
+	require.Equal(t, structs.EvalStatusWaiting, eval.Status)
+	require.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, index+1, []*structs.Evaluation{eval}))
+	return job, eval
+}
+
+func TestDispatchQueue_WaitingEvalUpdates(t *testing.T) {
+	ci.Parallel(t)
+
+	s := state.TestStateStore(t)
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 2}
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 100, parent))
+
+	// One dispatched job is already running
+	running, _ := upsertQueuedDispatchedJob(t, s, 200, parent, 50)
+	runningEval := mock.Eval()
+	runningEval.JobID = running.ID
+	require.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, 210, []*structs.Evaluation{runningEval}))
+	released, err := s.EvalsByJob(nil, running.Namespace, running.ID)
+	require.NoError(t, err)
+	for _, e := range released {
+		if e.Status == structs.EvalStatusWaiting {
+			update := e.Copy()
+			update.Status = structs.EvalStatusComplete
+			require.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, 220, []*structs.Evaluation{update}))
+		}
+	}
+
+	_, low := upsertQueuedDispatchedJob(t, s, 300, parent, 20)
+	_, high := upsertQueuedDispatchedJob(t, s, 400, parent, 80)
+	_, mid := upsertQueuedDispatchedJob(t, s, 500, parent, 50)
+
+	raw, _, err := waitingEvalUpdates(memdb.NewWatchSet(), s)
+	require.NoError(t, err)
+	result := raw.(*waitingEvalsResult)
+
+	// Only the highest priority dispatched job fits in the free slot
+	require.Len(t, result.updates, 1)
+	require.Equal(t, high.ID, result.updates[0].ID)
+	require.Equal(t, structs.EvalStatusPending, result.updates[0].Status)
+
+	require.Len(t, result.queues, 1)
+	queue := result.queues[0]
+	require.Equal(t, 2, queue.MaxConcurrent)
+	require.Equal(t, 2, queue.Running)
+	require.Len(t, queue.Queued, 2)
+	require.Equal(t, mid.ID, queue.Queued[0].EvalID)
+	require.Equal(t, low.ID, queue.Queued[1].EvalID)
+
+	// Removing the limit releases all the queued dispatched jobs
+	unlimited := parent.Copy()
+	unlimited.ParameterizedJob.MaxConcurrent = 0
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 600, unlimited))
+
+	raw, _, err = waitingEvalUpdates(memdb.NewWatchSet(), s)
+	require.NoError(t, err)
+	result = raw.(*waitingEvalsResult)
+	require.Len(t, result.updates, 3)
+	require.Empty(t, result.queues)
+}
+
+func TestDispatchQueue_WaitingEvalUpdates_SameJob(t *testing.T) {
+	ci.Parallel(t)
+
+	s := state.TestStateStore(t)
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 1}
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 100, parent))
+
+	// The first dispatched job has two waiting evaluations
+	first, eval := upsertQueuedDispatchedJob(t, s, 200, parent, 50)
+	second := eval.Copy()
+	second.ID = uuid.Generate()
+	require.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, 210, []*structs.Evaluation{second}))
+	_, other := upsertQueuedDispatchedJob(t, s, 300, parent, 50)
+
+	raw, _, err := waitingEvalUpdates(memdb.NewWatchSet(), s)
+	require.NoError(t, err)
+	result := raw.(*waitingEvalsResult)
+
+	// The dispatched job takes a single slot, and both its evaluations are
+	// released
+	require.Len(t, result.updates, 2)
+	for _, update := range result.updates {
+		require.Equal(t, first.ID, update.JobID)
+		require.Equal(t, structs.EvalStatusPending, update.Status)
+	}
+
+	require.Len(t, result.queues, 1)
+	queue := result.queues[0]
+	require.Equal(t, 1, queue.Running)
+	require.Len(t, queue.Queued, 1)
+	require.Equal(t, other.ID, queue.Queued[0].EvalID)
+}
+
+func TestDispatchQueue_Dispatch(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 1}
+	regReq := &structs.JobRegisterRequest{
+		Job: parent,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: parent.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	// Dispatch three jobs, which are all queued until released
+	var evalIDs []string
+	for _, priority := range []int{0, 10, 90} {
+		req := &structs.JobDispatchRequest{
+			JobID:    parent.ID,
+			Priority: priority,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: parent.Namespace,
+			},
+		}
+		var resp structs.JobDispatchResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp))
+		evalIDs = append(evalIDs, resp.EvalID)
+	}
+
+	// Only one dispatched job is released at a time
+	state := s1.fsm.State()
+	var queue *structs.JobDispatchQueue
+	testutil.WaitForResult(func() (bool, error) {
+		req := &structs.JobDispatchQueueRequest{
+			JobID: parent.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: parent.Namespace,
+			},
+		}
+		var resp structs.JobDispatchQueueResponse
+		if err := msgpackrpc.CallWithCodec(codec, "Job.DispatchQueue", req, &resp); err != nil {
+			return false, err
+		}
+		queue = resp.Queue
+		if queue.Running != 1 || len(queue.Queued) != 2 {
+			return false, fmt.Errorf("expected 1 running and 2 queued, got %d and %d", queue.Running, len(queue.Queued))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+	require.GreaterOrEqual(t, queue.Queued[0].Priority, queue.Queued[1].Priority)
+
+	// Completing the released dispatched job releases the next in the queue
+	var releasedID string
+	for _, id := range evalIDs {
+		eval, err := state.EvalByID(nil, id)
+		require.NoError(t, err)
+		if eval.Status == structs.EvalStatusPending {
+			releasedID = id
+			complete := eval.Copy()
+			complete.Status = structs.EvalStatusComplete
+			require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 5000, []*structs.Evaluation{complete}))
+		}
+	}
+	require.NotEmpty(t, releasedID)
+
+	testutil.WaitForResult(func() (bool, error) {
+		eval, err := state.EvalByID(nil, queue.Queued[0].EvalID)
+		if err != nil {
+			return false, err
+		}
+		if eval.Status != structs.EvalStatusPending {
+			return false, fmt.Errorf("expected next eval to be released, got %q", eval.Status)
+		}
+		eval, err = state.EvalByID(nil, queue.Queued[1].EvalID)
+		if err != nil {
+			return false, err
+		}
+		if eval.Status != structs.EvalStatusWaiting {
+			return false, fmt.Errorf("expected last eval to be queued, got %q", eval.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
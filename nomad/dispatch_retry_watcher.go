@@ -0,0 +1,253 @@
+package nomad
+
+import (
+	"context"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// dispatchRetryInterval is the time the dispatch retry watcher waits
+	// before retrying after failing to query jobs or dispatch a retry.
+	dispatchRetryInterval = 5 * time.Second
+)
+
+// pendingDispatchRetry is a failed dispatched job that is retried once the
+// retry delay has passed.
+type pendingDispatchRetry struct {
+	job     *structs.Job
+	delay   time.Duration
+	retryAt time.Time
+}
+
+// watchDispatchRetries is a long lived function that watches for failed
+// dispatched jobs of parameterized jobs with a retry policy. Once the retry
+// delay of a failed job has passed, a new dispatched job is registered with
+// the same payload, meta data and idempotency token.
+func (s *Server) watchDispatchRetries(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	index := uint64(1)
+	var next time.Time
+	for {
+		// Wake up when the next retry is due even if the state doesn't change
+		queryCtx, queryCancel := context.WithCancel(ctx)
+		if !next.IsZero() {
+			queryCancel()
+			queryCtx, queryCancel = context.WithDeadline(ctx, next)
+		}
+		raw, idx, err := s.State().BlockingQuery(pendingDispatchRetries, index, queryCtx)
+		queryCancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if err == context.DeadlineExceeded {
+				// Force the query to run again now that a retry is due
+				index, next = 1, time.Time{}
+				continue
+			}
+			s.logger.Error("failed to query dispatched jobs to retry", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(dispatchRetryInterval):
+			}
+			continue
+		}
+		index, next = idx, time.Time{}
+
+		now := time.Now()
+		for _, r := range raw.([]*pendingDispatchRetry) {
+			retryAt := r.retryAt
+			if !retryAt.After(now) {
+				err := s.retryDispatchedJob(r, now)
+				if err == nil {
+					continue
+				}
+				s.logger.Error("failed to retry dispatched job", "job_id", r.job.ID, "namespace", r.job.Namespace, "error", err)
+				retryAt = now.Add(dispatchRetryInterval)
+			}
+
+			if next.IsZero() || retryAt.Before(next) {
+				next = retryAt
+			}
+		}
+	}
+}
+
+// retryDispatchedJob registers a new dispatched job retrying the failed job
+// along with its evaluation.
+func (s *Server) retryDispatchedJob(r *pendingDispatchRetry, now time.Time) error {
+	job := newDispatchRetryJob(r.job, r.delay, now)
+	eval := &structs.Evaluation{
+		ID:          uuid.Generate(),
+		Namespace:   job.Namespace,
+		Priority:    job.Priority,
+		Type:        job.Type,
+		TriggeredBy: structs.EvalTriggerDispatchRetry,
+		JobID:       job.ID,
+		Status:      job.RegisterEvalStatus(),
+		CreateTime:  now.UnixNano(),
+		ModifyTime:  now.UnixNano(),
+	}
+
+	req := structs.JobRegisterRequest{
+		Job:  job,
+		Eval: eval,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			Namespace: job.Namespace,
+		},
+	}
+	fsmErr, _, err := s.raftApply(structs.JobRegisterRequestType, req)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debug("retried dispatched job", "job_id", r.job.ID, "namespace", job.Namespace,
+		"retry_job_id", job.ID, "attempt", job.DispatchAttempt())
+	return nil
+}
+
+// newDispatchRetryJob returns a new dispatched job retrying the failed job.
+// The retry uses the failed job's version of the parameterized job so it
+// runs with the same payload and meta data. Dependencies are not carried over
+// as they were satisfied before the first attempt was scheduled.
+func newDispatchRetryJob(failed *structs.Job, delay time.Duration, now time.Time) *structs.Job {
+	job := failed.Copy()
+	job.ID = structs.DispatchedID(failed.ParentID, now)
+	job.Name = job.ID
+	job.Status = ""
+	job.StatusDescription = ""
+	job.Stable = false
+	job.Version = 0
+	job.CreateIndex = 0
+	job.ModifyIndex = 0
+	job.JobModifyIndex = 0
+	job.DependsOn = nil
+	job.SetSubmitTime()
+
+	if job.DispatchRetryTracker == nil {
+		job.DispatchRetryTracker = &structs.DispatchRetryTracker{}
+	}
+	job.DispatchRetryTracker.RetryJobID = ""
+	job.DispatchRetryTracker.Events = append(job.DispatchRetryTracker.Events, &structs.DispatchRetryEvent{
+		RetryTime: now.UnixNano(),
+		PrevJobID: failed.ID,
+		Delay:     delay,
+	})
+	return job
+}
+
+// pendingDispatchRetries returns the failed dispatched jobs that are retried.
+// The watch set covers the dead dispatched jobs that have not been retried
+// yet, along with their allocations and parameterized jobs.
+func pendingDispatchRetries(ws memdb.WatchSet, snap *state.StateStore) (interface{}, uint64, error) {
+	iter, err := snap.JobsByDispatchRetry(ws)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var retries []*pendingDispatchRetry
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+
+		// Don't retry children of parameterized jobs that are gone or stopped
+		parent, err := snap.JobByID(ws, job.Namespace, job.ParentID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if parent == nil || parent.Stopped() {
+			continue
+		}
+
+		allocs, err := snap.AllocsByJob(ws, job.Namespace, job.ID, false)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		policy := job.DispatchRetryPolicy()
+		failedAt, ok := dispatchRetryable(policy, allocs)
+		if !ok {
+			continue
+		}
+
+		delay := policy.NextDelay(job.DispatchAttempt())
+		retries = append(retries, &pendingDispatchRetry{
+			job:     job,
+			delay:   delay,
+			retryAt: failedAt.Add(delay),
+		})
+	}
+
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return retries, index, nil
+}
+
+// dispatchRetryable returns whether the allocations of a dead dispatched job
+// failed in a way the retry policy retries, along with the time of the last
+// allocation update.
+func dispatchRetryable(policy *structs.DispatchRetryPolicy, allocs []*structs.Allocation) (time.Time, bool) {
+	var failedAt int64
+	retryable := false
+	for _, alloc := range allocs {
+		if alloc.ModifyTime > failedAt {
+			failedAt = alloc.ModifyTime
+		}
+
+		switch alloc.ClientStatus {
+		case structs.AllocClientStatusFailed:
+		case structs.AllocClientStatusLost:
+			// Lost allocations have no exit code to match
+			retryable = retryable || len(policy.ExitCodes) == 0
+			continue
+		default:
+			continue
+		}
+
+		for _, ts := range alloc.TaskStates {
+			if !ts.Failed {
+				continue
+			}
+			code, ok := lastExitCode(ts)
+			if !ok {
+				// The task failed without exiting, such as failing to start
+				retryable = retryable || len(policy.ExitCodes) == 0
+				continue
+			}
+			retryable = retryable || policy.RetryableExitCode(code)
+		}
+	}
+
+	return time.Unix(0, failedAt), retryable
+}
+
+// lastExitCode returns the exit code of the last time the task terminated.
+func lastExitCode(ts *structs.TaskState) (int, bool) {
+	for i := len(ts.Events) - 1; i >= 0; i-- {
+		if e := ts.Events[i]; e.Type == structs.TaskTerminated {
+			return e.ExitCode, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,229 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRetryParameterizedJob returns a parameterized batch job that retries
+// failed dispatched jobs once.
+func mockRetryParameterizedJob() *structs.Job {
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Payload: structs.DispatchPayloadOptional,
+		Retry: &structs.DispatchRetryPolicy{
+			Attempts:      1,
+			Delay:         10 * time.Second,
+			DelayFunction: "constant",
+			MaxDelay:      10 * time.Second,
+			ExitCodes:     []int{75},
+		},
+	}
+	return job
+}
+
+// upsertDeadDispatchedJob upserts a dispatched job of the parent along with a
+// complete evaluation and an allocation whose task exited with the given exit
+// code, so the dispatched job is dead.
+func upsertDeadDispatchedJob(t *testing.T, s *state.StateStore, index uint64, job *structs.Job, exitCode int) *structs.Job {
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, index, job))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, index+1, []*structs.Evaluation{eval}))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskGroup = job.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusPending
+	require.NoError(t, s.UpsertAllocs(structs.MsgTypeTestSetup, index+2, []*structs.Allocation{alloc}))
+
+	update := alloc.Copy()
+	update.ModifyTime = time.Now().UnixNano()
+	update.ClientStatus = structs.AllocClientStatusComplete
+	if exitCode != 0 {
+		update.ClientStatus = structs.AllocClientStatusFailed
+	}
+	update.TaskStates = map[string]*structs.TaskState{
+		"web": {
+			State:  structs.TaskStateDead,
+			Failed: exitCode != 0,
+			Events: []*structs.TaskEvent{
+				structs.NewTaskEvent(structs.TaskTerminated).SetExitCode(exitCode),
+			},
+		},
+	}
+	require.NoError(t, s.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index+3, []*structs.Allocation{update}))
+
+	out, err := s.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusDead, out.Status)
+	return out
+}
+
+// mockDispatchedJob returns a dispatched job of the parameterized job.
+func mockDispatchedJob(parent *structs.Job) *structs.Job {
+	job := parent.Copy()
+	job.ID = structs.DispatchedID(parent.ID, time.Now())
+	job.Name = job.ID
+	job.ParentID = parent.ID
+	job.Dispatched = true
+	job.DispatchIdempotencyToken = "token"
+	return job
+}
+
+func TestDispatchRetryWatcher_PendingDispatchRetries(t *testing.T) {
+	ci.Parallel(t)
+
+	s := state.TestStateStore(t)
+	parent := mockRetryParameterizedJob()
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 100, parent))
+
+	pending := func() []*pendingDispatchRetry {
+		raw, _, err := pendingDispatchRetries(memdb.NewWatchSet(), s)
+		require.NoError(t, err)
+		return raw.([]*pendingDispatchRetry)
+	}
+
+	// Successful and non-retryable failures are not retried
+	upsertDeadDispatchedJob(t, s, 200, mockDispatchedJob(parent), 0)
+	upsertDeadDispatchedJob(t, s, 300, mockDispatchedJob(parent), 1)
+	require.Empty(t, pending())
+
+	// A retryable failure is retried after the delay
+	failed := upsertDeadDispatchedJob(t, s, 400, mockDispatchedJob(parent), 75)
+	retries := pending()
+	require.Len(t, retries, 1)
+	require.Equal(t, failed.ID, retries[0].job.ID)
+	require.Equal(t, 10*time.Second, retries[0].delay)
+
+	// The retry carries the failed job's payload and idempotency token
+	now := time.Now()
+	retry := newDispatchRetryJob(failed, retries[0].delay, now)
+	require.NotEqual(t, failed.ID, retry.ID)
+	require.Equal(t, parent.ID, retry.ParentID)
+	require.Equal(t, "token", retry.DispatchIdempotencyToken)
+	require.Equal(t, 2, retry.DispatchAttempt())
+	require.Equal(t, failed.ID, retry.DispatchRetryTracker.Events[0].PrevJobID)
+
+	// Once retried the failed job is not retried again, and the retry has
+	// exhausted the attempts
+	upsertDeadDispatchedJob(t, s, 500, retry, 75)
+	require.Empty(t, pending())
+
+	out, err := s.JobByID(nil, failed.Namespace, failed.ID)
+	require.NoError(t, err)
+	require.Equal(t, retry.ID, out.DispatchRetryTracker.RetryJobID)
+	require.Equal(t, failed.Version, out.Version)
+
+	// The failed job is still not retried once the retry is garbage collected
+	require.NoError(t, s.DeleteJob(550, retry.Namespace, retry.ID))
+	require.Empty(t, pending())
+
+	// Children of stopped parameterized jobs are not retried
+	upsertDeadDispatchedJob(t, s, 600, mockDispatchedJob(parent), 75)
+	require.Len(t, pending(), 1)
+	stopped := parent.Copy()
+	stopped.Stop = true
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 700, stopped))
+	require.Empty(t, pending())
+}
+
+func TestDispatchRetryWatcher_Dispatch_IdempotencyToken(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	parent := mockRetryParameterizedJob()
+	regReq := &structs.JobRegisterRequest{
+		Job: parent,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: parent.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	// Register a failed dispatched job and its retry with the same token
+	state := s1.fsm.State()
+	failed := upsertDeadDispatchedJob(t, state, 1000, mockDispatchedJob(parent), 75)
+	retry := newDispatchRetryJob(failed, 0, time.Now())
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1010, retry))
+
+	// Dispatching with the token returns the latest attempt
+	req := &structs.JobDispatchRequest{
+		JobID: parent.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:           "global",
+			Namespace:        parent.Namespace,
+			IdempotencyToken: "token",
+		},
+	}
+	var resp structs.JobDispatchResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp))
+	require.Equal(t, retry.ID, resp.DispatchedJobID)
+	require.Empty(t, resp.EvalID)
+}
+
+func TestDispatchRetryWatcher_Retry(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Retry immediately rather than waiting for the minimum delay
+	parent := mockRetryParameterizedJob()
+	parent.ParameterizedJob.Retry.Delay = 0
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, parent))
+	failed := upsertDeadDispatchedJob(t, state, 1010, mockDispatchedJob(parent), 75)
+
+	testutil.WaitForResult(func() (bool, error) {
+		iter, err := state.JobsByIDPrefix(nil, parent.Namespace, parent.ID)
+		if err != nil {
+			return false, err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			job := raw.(*structs.Job)
+			if job.DispatchRetryTracker == nil {
+				continue
+			}
+			if prev := job.DispatchRetryTracker.Events[0].PrevJobID; prev != failed.ID {
+				return false, fmt.Errorf("expected retry of %q, got %q", failed.ID, prev)
+			}
+
+			evals, err := state.EvalsByJob(nil, job.Namespace, job.ID)
+			if err != nil {
+				return false, err
+			}
+			if len(evals) != 1 || evals[0].TriggeredBy != structs.EvalTriggerDispatchRetry {
+				return false, fmt.Errorf("expected dispatch retry eval, got %v", evals)
+			}
+			return true, nil
+		}
+		return false, fmt.Errorf("expected retry of %q", failed.ID)
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
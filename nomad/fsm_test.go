@@ -3287,6 +3287,44 @@ func TestFSM_SnapshotRestore_Namespaces(t *testing.T) {
 	}
 }
 
+func TestFSM_SnapshotRestore_ImagePrefetches(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	prefetch := &structs.ImagePrefetch{
+		Name:        "cuda",
+		Driver:      "docker",
+		Images:      []string{"nvidia/cuda:11.7.0-base-ubuntu22.04"},
+		Datacenters: []string{"dc1"},
+	}
+	require.NoError(t, fsm.State().UpsertImagePrefetches(
+		structs.ImagePrefetchUpsertRequestType, 1000, []*structs.ImagePrefetch{prefetch}))
+
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().ImagePrefetchByName(nil, prefetch.Name)
+	require.NoError(t, err)
+	require.Equal(t, prefetch, out)
+}
+
+func TestFSM_SnapshotRestore_HostVolumes(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	vol := &structs.HostVolume{
+		ID:            uuid.Generate(),
+		Name:          "data",
+		Namespace:     structs.DefaultNamespace,
+		NodeID:        uuid.Generate(),
+		HostPath:      "/opt/nomad/data/host_volumes/data",
+		CapacityBytes: 1 << 30,
+	}
+	require.NoError(t, fsm.State().UpsertHostVolume(
+		structs.HostVolumeRegisterRequestType, 1000, vol))
+
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().HostVolumeByID(nil, vol.Namespace, vol.ID)
+	require.NoError(t, err)
+	require.Equal(t, vol, out)
+}
+
 func TestFSM_UpsertServiceRegistrations(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -0,0 +1,370 @@
+package nomad
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// HostVolume endpoint is used for creating and deleting dynamic host
+// volumes, which clients provision on demand and fingerprint like the host
+// volumes of their configuration.
+type HostVolume struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// Create picks a node for a host volume, has the client create it and
+// records it in the state store
+func (v *HostVolume) Create(args *structs.HostVolumeCreateRequest, reply *structs.HostVolumeCreateResponse) error {
+	if done, err := v.srv.forward("HostVolume.Create", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "create"}, time.Now())
+
+	// Host volumes are provisioned on the nodes' filesystems, so node write
+	// permissions are required
+	if aclObj, err := v.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Volume == nil {
+		return fmt.Errorf("missing volume definition")
+	}
+
+	// The ID names the volume's directory on the client, so it's always
+	// generated
+	vol := args.Volume.Copy()
+	vol.ID = uuid.Generate()
+	vol.Namespace = args.RequestNamespace()
+	if err := vol.Validate(); err != nil {
+		return fmt.Errorf("volume validation failed: %v", err)
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	node, err := v.placeVolume(snap, vol)
+	if err != nil {
+		return err
+	}
+	vol.NodeID = node.ID
+
+	// NOTE: creating the volume on the client can't be made atomic with its
+	// registration, so the client deletes the volume if registering it fails.
+	cReq := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        vol.ID,
+		Name:                      vol.Name,
+		NodeID:                    vol.NodeID,
+		PluginID:                  vol.PluginID,
+		RequestedCapacityMinBytes: vol.RequestedCapacityMinBytes,
+		RequestedCapacityMaxBytes: vol.RequestedCapacityMaxBytes,
+		Parameters:                vol.Parameters,
+	}
+	cResp := &cstructs.ClientHostVolumeCreateResponse{}
+	if err := v.srv.RPC("ClientHostVolume.Create", cReq, cResp); err != nil {
+		return err
+	}
+	vol.HostPath = cResp.HostPath
+	vol.CapacityBytes = cResp.CapacityBytes
+
+	regArgs := &structs.HostVolumeCreateRequest{
+		Volume:       vol,
+		WriteRequest: args.WriteRequest,
+	}
+	resp, index, err := v.srv.raftApply(structs.HostVolumeRegisterRequestType, regArgs)
+	if err != nil {
+		v.logger.Error("host volume raft apply failed", "error", err, "method", "register")
+		v.deleteUnregistered(vol)
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		v.deleteUnregistered(vol)
+		return respErr
+	}
+
+	vol.CreateIndex = index
+	vol.ModifyIndex = index
+	reply.Volume = vol
+	reply.Index = index
+	return nil
+}
+
+// deleteUnregistered has the client delete a volume it created but that
+// failed to be registered, since it couldn't be deleted through the API.
+func (v *HostVolume) deleteUnregistered(vol *structs.HostVolume) {
+	cReq := &cstructs.ClientHostVolumeDeleteRequest{
+		ID:         vol.ID,
+		Name:       vol.Name,
+		NodeID:     vol.NodeID,
+		PluginID:   vol.PluginID,
+		HostPath:   vol.HostPath,
+		Parameters: vol.Parameters,
+	}
+	if err := v.srv.RPC("ClientHostVolume.Delete", cReq,
+		&cstructs.ClientHostVolumeDeleteResponse{}); err != nil {
+		v.logger.Error("failed to delete unregistered host volume",
+			"volume_id", vol.ID, "node_id", vol.NodeID, "error", err)
+	}
+}
+
+// placeVolume returns the node a host volume is created on: either the node
+// it requests, or a random ready node matching its constraints. The node
+// must not have a host volume of the same name.
+func (v *HostVolume) placeVolume(snap *state.StateSnapshot, vol *structs.HostVolume) (*structs.Node, error) {
+	// Volumes being created are in the state store only once the client has
+	// created them, so two concurrent requests can still pick the same node.
+	// The client rejects the second one.
+	hasVolume := func(node *structs.Node) (bool, error) {
+		if _, ok := node.HostVolumes[vol.Name]; ok {
+			return true, nil
+		}
+		vols, err := snap.HostVolumesByNodeID(nil, node.ID)
+		if err != nil {
+			return false, err
+		}
+		for _, existing := range vols {
+			if existing.Name == vol.Name {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	ctx := scheduler.NewEvalContext(nil, snap, &structs.Plan{}, v.logger)
+	checker := scheduler.NewConstraintChecker(ctx, vol.Constraints)
+
+	if vol.NodeID != "" {
+		node, err := getNodeForRpc(snap, vol.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if !node.Ready() {
+			return nil, fmt.Errorf("node %q is not ready", node.ID)
+		}
+		if !checker.Feasible(node) {
+			return nil, fmt.Errorf("node %q does not match the volume constraints", node.ID)
+		}
+		if found, err := hasVolume(node); err != nil {
+			return nil, err
+		} else if found {
+			return nil, fmt.Errorf("node %q already has a host volume named %q", node.ID, vol.Name)
+		}
+		return node, nil
+	}
+
+	iter, err := snap.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() || nodeSupportsRpc(node) != nil || !checker.Feasible(node) {
+			continue
+		}
+		if found, err := hasVolume(node); err != nil {
+			return nil, err
+		} else if found {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no ready node matches the volume constraints")
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
+// Delete has the client delete a host volume and removes it from the state
+// store. Volumes in use by allocations cannot be deleted.
+func (v *HostVolume) Delete(args *structs.HostVolumeDeleteRequest, reply *structs.HostVolumeDeleteResponse) error {
+	if done, err := v.srv.forward("HostVolume.Delete", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "delete"}, time.Now())
+
+	if aclObj, err := v.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.VolumeID == "" {
+		return fmt.Errorf("missing volume ID")
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	vol, err := snap.HostVolumeByID(nil, args.RequestNamespace(), args.VolumeID)
+	if err != nil {
+		return err
+	}
+	if vol == nil {
+		return fmt.Errorf("host volume not found: %s", args.VolumeID)
+	}
+
+	node, err := snap.NodeByID(nil, vol.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// The volume is deregistered without contacting its client if the node
+	// was garbage collected
+	if node != nil {
+		allocs, err := snap.AllocsByNode(nil, vol.NodeID)
+		if err != nil {
+			return err
+		}
+		var users []string
+		for _, alloc := range allocs {
+			if !alloc.TerminalStatus() && allocUsesHostVolume(alloc, vol.Name) {
+				users = append(users, alloc.ID)
+			}
+		}
+		if len(users) > 0 {
+			return fmt.Errorf("host volume in use by allocations: %s", strings.Join(users, ", "))
+		}
+
+		cReq := &cstructs.ClientHostVolumeDeleteRequest{
+			ID:         vol.ID,
+			Name:       vol.Name,
+			NodeID:     vol.NodeID,
+			PluginID:   vol.PluginID,
+			HostPath:   vol.HostPath,
+			Parameters: vol.Parameters,
+		}
+		if err := v.srv.RPC("ClientHostVolume.Delete", cReq,
+			&cstructs.ClientHostVolumeDeleteResponse{}); err != nil {
+			return err
+		}
+	}
+
+	resp, index, err := v.srv.raftApply(structs.HostVolumeDeleteRequestType, args)
+	if err != nil {
+		v.logger.Error("host volume raft apply failed", "error", err, "method", "delete")
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// allocUsesHostVolume returns true if one of the volumes of the alloc's task
+// group is the host volume
+func allocUsesHostVolume(alloc *structs.Allocation, name string) bool {
+	if alloc.Job == nil {
+		return false
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return false
+	}
+	for _, req := range tg.Volumes {
+		if req.Type == structs.VolumeTypeHost && req.Source == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Get is used to get a single host volume
+func (v *HostVolume) Get(args *structs.HostVolumeGetRequest, reply *structs.HostVolumeGetResponse) error {
+	if done, err := v.srv.forward("HostVolume.Get", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "get"}, time.Now())
+
+	if aclObj, err := v.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.ID == "" {
+		return fmt.Errorf("missing volume ID")
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			vol, err := s.HostVolumeByID(ws, args.RequestNamespace(), args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Volume = vol
+			return v.srv.replySetIndex(state.TableHostVolumes, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// List is used to list the host volumes of a namespace, or of all
+// namespaces, optionally filtered by node
+func (v *HostVolume) List(args *structs.HostVolumeListRequest, reply *structs.HostVolumeListResponse) error {
+	if done, err := v.srv.forward("HostVolume.List", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "list"}, time.Now())
+
+	if aclObj, err := v.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	ns := args.RequestNamespace()
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			var iter memdb.ResultIterator
+			var err error
+			if ns == structs.AllNamespacesSentinel {
+				iter, err = s.HostVolumes(ws)
+			} else {
+				iter, err = s.HostVolumesByNamespace(ws, ns)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Volumes = []*structs.HostVolume{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				vol := raw.(*structs.HostVolume)
+				if args.NodeID != "" && vol.NodeID != args.NodeID {
+					continue
+				}
+				if args.Prefix != "" && !strings.HasPrefix(vol.ID, args.Prefix) {
+					continue
+				}
+				reply.Volumes = append(reply.Volumes, vol)
+			}
+
+			return v.srv.replySetIndex(state.TableHostVolumes, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
@@ -0,0 +1,215 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	cconfig "github.com/hashicorp/nomad/client/config"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClientHostVolume is a client HostVolume endpoint recording the
+// volumes it's asked to create and delete
+type mockClientHostVolume struct {
+	created []*cstructs.ClientHostVolumeCreateRequest
+	deleted []*cstructs.ClientHostVolumeDeleteRequest
+
+	// onCreate is called for each volume created, if set
+	onCreate func(req *cstructs.ClientHostVolumeCreateRequest)
+}
+
+func (c *mockClientHostVolume) Create(req *cstructs.ClientHostVolumeCreateRequest, resp *cstructs.ClientHostVolumeCreateResponse) error {
+	c.created = append(c.created, req)
+	if c.onCreate != nil {
+		c.onCreate(req)
+	}
+	resp.HostPath = "/srv/host_volumes/" + req.ID
+	resp.CapacityBytes = req.RequestedCapacityMinBytes
+	return nil
+}
+
+func (c *mockClientHostVolume) Delete(req *cstructs.ClientHostVolumeDeleteRequest, resp *cstructs.ClientHostVolumeDeleteResponse) error {
+	c.deleted = append(c.deleted, req)
+	return nil
+}
+
+func TestHostVolumeEndpoint_CreateDelete(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	fake := &mockClientHostVolume{}
+	c, cleanup := client.TestClientWithRPCs(t,
+		func(c *cconfig.Config) {
+			c.Servers = []string{srv.config.RPCAddr.String()}
+		},
+		map[string]interface{}{"HostVolume": fake},
+	)
+	defer cleanup()
+
+	node := c.Node()
+	node.Attributes["nomad.version"] = "1.3.0"
+	node.Meta["rack"] = "r1"
+	node.Status = structs.NodeStatusReady
+	require.NoError(t, c.RPC("Node.Register", &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}, &structs.NodeUpdateResponse{}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := srv.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a client")
+	})
+
+	state := srv.fsm.State()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	codec := rpcClient(t, srv)
+
+	// Volumes are only created on nodes matching their constraints
+	req := &structs.HostVolumeCreateRequest{
+		Volume: &structs.HostVolume{
+			Name:                      "data",
+			PluginID:                  "lvm",
+			RequestedCapacityMinBytes: 1 << 30,
+			Constraints: []*structs.Constraint{{
+				LTarget: "${meta.rack}", RTarget: "r2", Operand: "=",
+			}},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global", Namespace: structs.DefaultNamespace},
+	}
+	var resp structs.HostVolumeCreateResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	require.EqualError(t, err, "no ready node matches the volume constraints")
+
+	req.Volume.Constraints[0].RTarget = "r1"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp))
+	require.Len(t, fake.created, 1)
+	vol := resp.Volume
+	require.Equal(t, node.ID, vol.NodeID)
+	require.Equal(t, "/srv/host_volumes/"+vol.ID, vol.HostPath)
+	require.Equal(t, int64(1<<30), vol.CapacityBytes)
+
+	out, err := state.HostVolumeByID(nil, structs.DefaultNamespace, vol.ID)
+	require.NoError(t, err)
+	require.Equal(t, vol.HostPath, out.HostPath)
+
+	// Nodes only have one volume of a given name
+	req.Volume.NodeID = node.ID
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `already has a host volume named "data"`)
+
+	var list structs.HostVolumeListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", &structs.HostVolumeListRequest{
+		NodeID:       node.ID,
+		QueryOptions: structs.QueryOptions{Region: "global", Namespace: structs.AllNamespacesSentinel},
+	}, &list))
+	require.Len(t, list.Volumes, 1)
+
+	// Volumes in use cannot be deleted
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+		"data": {Name: "data", Type: structs.VolumeTypeHost, Source: "data"},
+	}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	delReq := &structs.HostVolumeDeleteRequest{
+		VolumeID:     vol.ID,
+		WriteRequest: structs.WriteRequest{Region: "global", Namespace: structs.DefaultNamespace},
+	}
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &structs.HostVolumeDeleteResponse{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "host volume in use by allocations")
+	require.Empty(t, fake.deleted)
+
+	alloc = alloc.Copy()
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc}))
+
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &structs.HostVolumeDeleteResponse{}))
+	require.Len(t, fake.deleted, 1)
+	require.Equal(t, vol.HostPath, fake.deleted[0].HostPath)
+
+	out, err = state.HostVolumeByID(nil, structs.DefaultNamespace, vol.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestHostVolumeEndpoint_Create_RegisterFailed(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	state := srv.fsm.State()
+
+	// Registering the volume fails because a volume with the same ID is
+	// registered on another node by the time the client created it
+	fake := &mockClientHostVolume{}
+	fake.onCreate = func(req *cstructs.ClientHostVolumeCreateRequest) {
+		require.NoError(t, state.UpsertHostVolume(structs.MsgTypeTestSetup, 2000, &structs.HostVolume{
+			ID:        req.ID,
+			Namespace: structs.DefaultNamespace,
+			Name:      "other",
+			NodeID:    "other-node",
+		}))
+	}
+	c, cleanup := client.TestClientWithRPCs(t,
+		func(c *cconfig.Config) {
+			c.Servers = []string{srv.config.RPCAddr.String()}
+		},
+		map[string]interface{}{"HostVolume": fake},
+	)
+	defer cleanup()
+
+	node := c.Node()
+	node.Attributes["nomad.version"] = "1.3.0"
+	node.Status = structs.NodeStatusReady
+	require.NoError(t, c.RPC("Node.Register", &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}, &structs.NodeUpdateResponse{}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := srv.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a client")
+	})
+
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	codec := rpcClient(t, srv)
+
+	req := &structs.HostVolumeCreateRequest{
+		Volume: &structs.HostVolume{
+			Name:                      "data",
+			NodeID:                    node.ID,
+			PluginID:                  "lvm",
+			RequestedCapacityMinBytes: 1 << 30,
+		},
+		WriteRequest: structs.WriteRequest{Region: "global", Namespace: structs.DefaultNamespace},
+	}
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &structs.HostVolumeCreateResponse{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot be moved to another node")
+
+	// The volume the client created is deleted
+	require.Len(t, fake.created, 1)
+	require.Len(t, fake.deleted, 1)
+	require.Equal(t, fake.created[0].ID, fake.deleted[0].ID)
+	require.Equal(t, "/srv/host_volumes/"+fake.created[0].ID, fake.deleted[0].HostPath)
+}
@@ -0,0 +1,226 @@
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ImagePrefetch endpoint is used for manipulating image prefetches, which
+// list images task drivers pull onto nodes ahead of placement.
+type ImagePrefetch struct {
+	srv *Server
+}
+
+// UpsertImagePrefetches is used to upsert a set of image prefetches
+func (p *ImagePrefetch) UpsertImagePrefetches(args *structs.ImagePrefetchUpsertRequest,
+	reply *structs.GenericResponse) error {
+	if done, err := p.srv.forward(structs.ImagePrefetchUpsertRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "image_prefetch", "upsert"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.ImagePrefetches) == 0 {
+		return fmt.Errorf("must specify at least one image prefetch")
+	}
+	for _, prefetch := range args.ImagePrefetches {
+		if err := prefetch.Validate(); err != nil {
+			return fmt.Errorf("Invalid image prefetch %q: %v", prefetch.Name, err)
+		}
+	}
+
+	out, index, err := p.srv.raftApply(structs.ImagePrefetchUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// DeleteImagePrefetches is used to delete a set of image prefetches. Drivers
+// stop keeping the images once no prefetch lists them.
+func (p *ImagePrefetch) DeleteImagePrefetches(args *structs.ImagePrefetchDeleteRequest,
+	reply *structs.GenericResponse) error {
+	if done, err := p.srv.forward(structs.ImagePrefetchDeleteRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "image_prefetch", "delete"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.Names) == 0 {
+		return fmt.Errorf("must specify at least one image prefetch to delete")
+	}
+
+	out, index, err := p.srv.raftApply(structs.ImagePrefetchDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// ListImagePrefetches is used to list the image prefetches
+func (p *ImagePrefetch) ListImagePrefetches(args *structs.ImagePrefetchListRequest,
+	reply *structs.ImagePrefetchListResponse) error {
+	if done, err := p.srv.forward(structs.ImagePrefetchListRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "image_prefetch", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = s.ImagePrefetchesByNamePrefix(ws, prefix)
+			} else {
+				iter, err = s.ImagePrefetches(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.ImagePrefetches = []*structs.ImagePrefetch{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.ImagePrefetches = append(reply.ImagePrefetches, raw.(*structs.ImagePrefetch))
+			}
+
+			return p.setIndex(s, &reply.QueryMeta)
+		}}
+	return p.srv.blockingRPC(&opts)
+}
+
+// GetImagePrefetch is used to get a specific image prefetch
+func (p *ImagePrefetch) GetImagePrefetch(args *structs.ImagePrefetchSpecificRequest,
+	reply *structs.SingleImagePrefetchResponse) error {
+	if done, err := p.srv.forward(structs.ImagePrefetchGetRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "image_prefetch", "get"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			out, err := s.ImagePrefetchByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			reply.ImagePrefetch = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+			return p.setIndex(s, &reply.QueryMeta)
+		}}
+	return p.srv.blockingRPC(&opts)
+}
+
+// ListForNode is used by clients to watch the image prefetches matching
+// their node.
+func (p *ImagePrefetch) ListForNode(args *structs.NodeSpecificRequest,
+	reply *structs.ImagePrefetchListResponse) error {
+	if done, err := p.srv.forward(structs.ImagePrefetchListForNodeRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "image_prefetch", "list_for_node"}, time.Now())
+
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID")
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// The node's datacenter and class can only change when the
+			// client restarts, so only the prefetches are watched
+			node, err := s.NodeByID(nil, args.NodeID)
+			if err != nil {
+				return err
+			}
+			if node == nil {
+				return fmt.Errorf("node %q not found", args.NodeID)
+			}
+			if args.SecretID == "" {
+				return fmt.Errorf("missing node secret ID")
+			} else if args.SecretID != node.SecretID {
+				return fmt.Errorf("node secret ID does not match")
+			}
+
+			iter, err := s.ImagePrefetches(ws)
+			if err != nil {
+				return err
+			}
+
+			reply.ImagePrefetches = []*structs.ImagePrefetch{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				prefetch := raw.(*structs.ImagePrefetch)
+				if prefetch.MatchesNode(node) {
+					reply.ImagePrefetches = append(reply.ImagePrefetches, prefetch)
+				}
+			}
+
+			return p.setIndex(s, &reply.QueryMeta)
+		}}
+	return p.srv.blockingRPC(&opts)
+}
+
+// setIndex sets the reply index to the last index that affected the image
+// prefetch table.
+func (p *ImagePrefetch) setIndex(s *state.StateStore, meta *structs.QueryMeta) error {
+	index, err := s.Index(state.TableImagePrefetches)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+	// We floor the index at one, since realistically the first write must have a higher index.
+	if index == 0 {
+		index = 1
+	}
+	meta.Index = index
+	return nil
+}
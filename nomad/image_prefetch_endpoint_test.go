@@ -0,0 +1,129 @@
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImagePrefetchEndpoint_UpsertListDelete(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	readToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "node-read",
+		mock.NodePolicy(acl.PolicyRead))
+
+	upsert := &structs.ImagePrefetchUpsertRequest{
+		ImagePrefetches: []*structs.ImagePrefetch{{
+			Name:   "cuda",
+			Driver: "docker",
+			Images: []string{"nvidia/cuda:11.7.0-base-ubuntu22.04"},
+		}},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: readToken.SecretID},
+	}
+	var upsertResp structs.GenericResponse
+
+	// Writes require node:write
+	err := msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchUpsertRPCMethod, upsert, &upsertResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Invalid prefetches are rejected
+	upsert.AuthToken = root.SecretID
+	upsert.ImagePrefetches[0].Images = nil
+	err = msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchUpsertRPCMethod, upsert, &upsertResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must list at least one image")
+
+	upsert.ImagePrefetches[0].Images = []string{"nvidia/cuda:11.7.0-base-ubuntu22.04"}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchUpsertRPCMethod, upsert, &upsertResp))
+	require.NotZero(t, upsertResp.Index)
+
+	// Reads require node:read
+	list := &structs.ImagePrefetchListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global", AuthToken: readToken.SecretID},
+	}
+	var listResp structs.ImagePrefetchListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchListRPCMethod, list, &listResp))
+	require.Len(t, listResp.ImagePrefetches, 1)
+	require.Equal(t, upsertResp.Index, listResp.Index)
+
+	get := &structs.ImagePrefetchSpecificRequest{
+		Name:         "cuda",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleImagePrefetchResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchGetRPCMethod, get, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	get.AuthToken = readToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchGetRPCMethod, get, &getResp))
+	require.Equal(t, "docker", getResp.ImagePrefetch.Driver)
+
+	del := &structs.ImagePrefetchDeleteRequest{
+		Names:        []string{"cuda"},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: root.SecretID},
+	}
+	var delResp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchDeleteRPCMethod, del, &delResp))
+
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchGetRPCMethod, get, &getResp))
+	require.Nil(t, getResp.ImagePrefetch)
+}
+
+func TestImagePrefetchEndpoint_ListForNode(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	node := mock.Node()
+	node.NodeClass = "gpu"
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	require.NoError(t, state.UpsertImagePrefetches(structs.MsgTypeTestSetup, 1001, []*structs.ImagePrefetch{
+		{Name: "all", Driver: "docker", Images: []string{"busybox:1"}},
+		{Name: "gpu", Driver: "docker", Images: []string{"nvidia/cuda:11"}, NodeClass: "gpu", Datacenters: []string{node.Datacenter}},
+		{Name: "other-dc", Driver: "docker", Images: []string{"redis:7"}, Datacenters: []string{"other"}},
+	}))
+
+	req := &structs.NodeSpecificRequest{
+		NodeID:       node.ID,
+		SecretID:     "wrong",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.ImagePrefetchListResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchListForNodeRPCMethod, req, &resp)
+	require.EqualError(t, err, "node secret ID does not match")
+
+	req.SecretID = node.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchListForNodeRPCMethod, req, &resp))
+	require.Len(t, resp.ImagePrefetches, 2)
+	require.Equal(t, "all", resp.ImagePrefetches[0].Name)
+	require.Equal(t, "gpu", resp.ImagePrefetches[1].Name)
+	require.Equal(t, uint64(1001), resp.Index)
+
+	// Blocking queries unblock when a prefetch changes
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		state.DeleteImagePrefetches(structs.MsgTypeTestSetup, 1002, []string{"all"})
+	}()
+
+	req.MinQueryIndex = resp.Index
+	start := time.Now()
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ImagePrefetchListForNodeRPCMethod, req, &resp))
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	require.Len(t, resp.ImagePrefetches, 1)
+	require.Equal(t, uint64(1002), resp.Index)
+}
@@ -0,0 +1,229 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// jobDependencyRetryInterval is the time the job dependency watcher waits
+	// before retrying after failing to query or update evaluations.
+	jobDependencyRetryInterval = 5 * time.Second
+)
+
+// waitingEvalsResult is the result of querying the waiting evaluations.
+type waitingEvalsResult struct {
+	// updates are the evaluations that are released or cancelled
+	updates []*structs.Evaluation
+
+	// queues are the dispatch queues of parameterized jobs with
+	// MaxConcurrent set
+	queues []*structs.JobDispatchQueue
+}
+
+// watchWaitingEvals is a long lived function that watches evaluations
+// waiting on job dependencies or in a dispatch queue. Once all the upstream
+// jobs of an evaluation's job have completed with the required conditions,
+// and a dispatch slot is free if the job is a queued dispatched job, the
+// evaluation is made pending so it is scheduled. If a dependency can no
+// longer be satisfied the evaluation is cancelled instead.
+func (s *Server) watchWaitingEvals(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	index := uint64(1)
+	var queues map[structs.NamespacedID]struct{}
+	for {
+		raw, idx, err := s.State().BlockingQuery(waitingEvalUpdates, index, ctx)
+		if err != nil {
+			if err == context.Canceled {
+				return
+			}
+			s.logger.Error("failed to query evaluations waiting on job dependencies", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jobDependencyRetryInterval):
+			}
+			continue
+		}
+		index = idx
+
+		result := raw.(*waitingEvalsResult)
+		queues = emitDispatchQueueMetrics(result.queues, queues)
+		if len(result.updates) == 0 {
+			continue
+		}
+
+		req := structs.EvalUpdateRequest{
+			Evals:        result.updates,
+			WriteRequest: structs.WriteRequest{Region: s.config.Region},
+		}
+		if _, _, err := s.raftApply(structs.EvalUpdateRequestType, &req); err != nil {
+			s.logger.Error("failed to update evaluations waiting on job dependencies", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jobDependencyRetryInterval):
+			}
+
+			// Force the query to run again since the state may not change
+			index = 1
+		}
+	}
+}
+
+// waitingEvalUpdates returns the updated copies of the waiting evaluations
+// whose dependencies have been resolved and that have a free dispatch slot if
+// they are queued. The watch set covers the waiting evaluations, their jobs,
+// the upstream jobs and the dispatched jobs of parameterized jobs with
+// MaxConcurrent set.
+func waitingEvalUpdates(ws memdb.WatchSet, snap *state.StateStore) (interface{}, uint64, error) {
+	waiting, err := waitingEvals(ws, snap)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var updates []*structs.Evaluation
+	for _, eval := range waiting {
+		update, err := resolveJobDependencies(ws, snap, eval)
+		if err != nil {
+			return nil, 0, err
+		}
+		if update != nil {
+			updates = append(updates, update)
+		}
+	}
+
+	updates, queues, err := limitDispatchConcurrency(ws, snap, waiting, updates)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &waitingEvalsResult{updates: updates, queues: queues}, index, nil
+}
+
+// resolveJobDependencies returns an updated copy of the waiting evaluation if
+// its job's dependencies have been resolved, or nil if it must keep waiting.
+func resolveJobDependencies(ws memdb.WatchSet, snap *state.StateStore, eval *structs.Evaluation) (*structs.Evaluation, error) {
+	job, err := snap.JobByID(ws, eval.Namespace, eval.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// There is nothing to schedule once the job is gone or stopped
+	if job == nil || job.Stopped() {
+		return updateWaitingEval(eval, structs.EvalStatusCancelled, "job stopped while waiting on dependencies"), nil
+	}
+
+	var pending []string
+	for _, d := range job.DependsOn {
+		upstream, err := snap.JobByID(ws, job.Namespace, d.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		var summary *structs.JobSummary
+		if upstream != nil {
+			summary, err = snap.JobSummaryByID(ws, job.Namespace, d.JobID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		satisfied, done := d.Evaluate(upstream, summary)
+		switch {
+		case satisfied:
+		case done:
+			desc := fmt.Sprintf("dependency on job %q can not be satisfied: condition %q not met", d.JobID, d.Condition)
+			return updateWaitingEval(eval, structs.EvalStatusCancelled, desc), nil
+		default:
+			pending = append(pending, d.JobID)
+		}
+	}
+
+	if len(pending) != 0 {
+		return nil, nil
+	}
+
+	var desc string
+	if job.HasDependencies() {
+		desc = "job dependencies satisfied"
+	}
+	return updateWaitingEval(eval, structs.EvalStatusPending, desc), nil
+}
+
+// updateWaitingEval returns a copy of the waiting evaluation with the new status.
+func updateWaitingEval(eval *structs.Evaluation, status, desc string) *structs.Evaluation {
+	update := eval.Copy()
+	update.Status = status
+	update.StatusDescription = desc
+	update.UpdateModifyTime()
+	return update
+}
+
+// validateJobDependencies checks that the job's upstream jobs will eventually
+// complete and that the dependencies don't form a cycle with the jobs already
+// registered. Upstream jobs that are not yet registered are allowed.
+func validateJobDependencies(snap *state.StateSnapshot, job *structs.Job) error {
+	if !job.HasDependencies() {
+		return nil
+	}
+
+	ws := memdb.NewWatchSet()
+	for _, d := range job.DependsOn {
+		upstream, err := snap.JobByID(ws, job.Namespace, d.JobID)
+		if err != nil {
+			return err
+		}
+		if upstream != nil && (upstream.IsPeriodic() || upstream.IsParameterized()) {
+			return fmt.Errorf("dependency on job %q is invalid: periodic and parameterized jobs never complete, depend on one of their children instead", d.JobID)
+		}
+	}
+
+	visited := make(map[string]struct{})
+	var visit func(deps []*structs.JobDependency, path []string) error
+	visit = func(deps []*structs.JobDependency, path []string) error {
+		for _, d := range deps {
+			if d.JobID == job.ID {
+				return fmt.Errorf("job dependencies form a cycle: %s", strings.Join(append(path, job.ID), " -> "))
+			}
+			if _, ok := visited[d.JobID]; ok {
+				continue
+			}
+			visited[d.JobID] = struct{}{}
+
+			upstream, err := snap.JobByID(ws, job.Namespace, d.JobID)
+			if err != nil {
+				return err
+			}
+			if upstream == nil {
+				continue
+			}
+			if err := visit(upstream.DependsOn, append(path, upstream.ID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return visit(job.DependsOn, []string{job.ID})
+}
@@ -0,0 +1,268 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// upsertFinishedBatchJob upserts a batch job along with a complete evaluation
+// and an allocation that finished with the given client status so the job
+// is dead.
+func upsertFinishedBatchJob(t *testing.T, s *state.StateStore, index uint64, clientStatus string) *structs.Job {
+	job := mock.BatchJob()
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, index, job))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, index+1, []*structs.Evaluation{eval}))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskGroup = job.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusPending
+	alloc.DesiredStatus = structs.AllocDesiredStatusRun
+	require.NoError(t, s.UpsertAllocs(structs.MsgTypeTestSetup, index+2, []*structs.Allocation{alloc}))
+
+	update := alloc.Copy()
+	update.ClientStatus = clientStatus
+	require.NoError(t, s.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index+3, []*structs.Allocation{update}))
+
+	out, err := s.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusDead, out.Status)
+	return out
+}
+
+func TestJobDependencyWatcher_ResolveJobDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	s := state.TestStateStore(t)
+	succeeded := upsertFinishedBatchJob(t, s, 100, structs.AllocClientStatusComplete)
+	failed := upsertFinishedBatchJob(t, s, 200, structs.AllocClientStatusFailed)
+
+	cases := []struct {
+		name      string
+		depends   []*structs.JobDependency
+		stop      bool
+		expStatus string
+	}{
+		{
+			name: "satisfied",
+			depends: []*structs.JobDependency{
+				{JobID: succeeded.ID, Condition: structs.JobDependencyConditionSuccess},
+				{JobID: failed.ID, Condition: structs.JobDependencyConditionFailure},
+			},
+			expStatus: structs.EvalStatusPending,
+		},
+		{
+			name: "unsatisfiable",
+			depends: []*structs.JobDependency{
+				{JobID: failed.ID, Condition: structs.JobDependencyConditionSuccess},
+			},
+			expStatus: structs.EvalStatusCancelled,
+		},
+		{
+			name: "missing upstream",
+			depends: []*structs.JobDependency{
+				{JobID: succeeded.ID, Condition: structs.JobDependencyConditionComplete},
+				{JobID: "missing", Condition: structs.JobDependencyConditionComplete},
+			},
+		},
+		{
+			name: "stopped",
+			depends: []*structs.JobDependency{
+				{JobID: "missing", Condition: structs.JobDependencyConditionComplete},
+			},
+			stop:      true,
+			expStatus: structs.EvalStatusCancelled,
+		},
+	}
+
+	index := uint64(1000)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := mock.BatchJob()
+			job.DependsOn = tc.depends
+			job.Stop = tc.stop
+			index++
+			require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, index, job))
+
+			eval := mock.Eval()
+			eval.JobID = job.ID
+			eval.Status = structs.EvalStatusWaiting
+
+			update, err := resolveJobDependencies(memdb.NewWatchSet(), s, eval)
+			require.NoError(t, err)
+			if tc.expStatus == "" {
+				require.Nil(t, update)
+				return
+			}
+			require.NotNil(t, update)
+			require.Equal(t, tc.expStatus, update.Status)
+			require.Equal(t, structs.EvalStatusWaiting, eval.Status)
+		})
+	}
+}
+
+func TestJobDependencyWatcher_WaitingEvalUpdates_SameJob(t *testing.T) {
+	ci.Parallel(t)
+
+	s := state.TestStateStore(t)
+	upstream := upsertFinishedBatchJob(t, s, 100, structs.AllocClientStatusComplete)
+
+	// The job was registered twice while waiting on a job that wasn't
+	// registered yet, so it has two waiting evaluations
+	job := mock.BatchJob()
+	job.DependsOn = []*structs.JobDependency{{JobID: "missing", Condition: structs.JobDependencyConditionSuccess}}
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	var evals []*structs.Evaluation
+	for i := 0; i < 2; i++ {
+		eval := mock.Eval()
+		eval.JobID = job.ID
+		eval.Status = structs.EvalStatusWaiting
+		evals = append(evals, eval)
+	}
+	require.NoError(t, s.UpsertEvals(structs.MsgTypeTestSetup, 1001, evals))
+
+	raw, _, err := waitingEvalUpdates(memdb.NewWatchSet(), s)
+	require.NoError(t, err)
+	require.Empty(t, raw.(*waitingEvalsResult).updates)
+
+	// Once the dependency is satisfied both evaluations are released
+	job = job.Copy()
+	job.DependsOn[0].JobID = upstream.ID
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 1002, job))
+
+	raw, _, err = waitingEvalUpdates(memdb.NewWatchSet(), s)
+	require.NoError(t, err)
+	updates := raw.(*waitingEvalsResult).updates
+	require.Len(t, updates, 2)
+	require.ElementsMatch(t, []string{evals[0].ID, evals[1].ID}, []string{updates[0].ID, updates[1].ID})
+	for _, update := range updates {
+		require.Equal(t, structs.EvalStatusPending, update.Status)
+	}
+
+	// Stopping the job cancels both evaluations
+	job = job.Copy()
+	job.DependsOn[0].JobID = "missing"
+	job.Stop = true
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 1003, job))
+
+	raw, _, err = waitingEvalUpdates(memdb.NewWatchSet(), s)
+	require.NoError(t, err)
+	updates = raw.(*waitingEvalsResult).updates
+	require.Len(t, updates, 2)
+	for _, update := range updates {
+		require.Equal(t, structs.EvalStatusCancelled, update.Status)
+	}
+}
+
+func TestJobDependencyWatcher_ValidateJobDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	s := state.TestStateStore(t)
+
+	a := mock.BatchJob()
+	a.ID = "a"
+	b := mock.BatchJob()
+	b.ID = "b"
+	b.DependsOn = []*structs.JobDependency{{JobID: "a", Condition: structs.JobDependencyConditionSuccess}}
+	periodic := mock.PeriodicJob()
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 100, a))
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 101, b))
+	require.NoError(t, s.UpsertJob(structs.MsgTypeTestSetup, 102, periodic))
+
+	snap, err := s.Snapshot()
+	require.NoError(t, err)
+
+	// Upstream jobs that are not registered yet are allowed
+	c := mock.BatchJob()
+	c.ID = "c"
+	c.DependsOn = []*structs.JobDependency{
+		{JobID: "b", Condition: structs.JobDependencyConditionSuccess},
+		{JobID: "missing", Condition: structs.JobDependencyConditionSuccess},
+	}
+	require.NoError(t, validateJobDependencies(snap, c))
+
+	// Updating a to depend on b forms a cycle
+	cycle := a.Copy()
+	cycle.DependsOn = []*structs.JobDependency{{JobID: "b", Condition: structs.JobDependencyConditionSuccess}}
+	err = validateJobDependencies(snap, cycle)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "a -> b -> a")
+
+	// Periodic jobs never complete
+	job := mock.BatchJob()
+	job.DependsOn = []*structs.JobDependency{{JobID: periodic.ID, Condition: structs.JobDependencyConditionSuccess}}
+	err = validateJobDependencies(snap, job)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "periodic and parameterized")
+}
+
+func TestJobDependencyWatcher_Register(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register a job depending on a job that isn't registered yet
+	job := mock.BatchJob()
+	job.DependsOn = []*structs.JobDependency{{JobID: "upstream"}}
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	state := s1.fsm.State()
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	require.NoError(t, err)
+	require.Equal(t, structs.EvalStatusWaiting, eval.Status)
+
+	// Depending on the dependent job forms a cycle
+	cycle := mock.BatchJob()
+	cycle.ID = "upstream"
+	cycle.DependsOn = []*structs.JobDependency{{JobID: job.ID}}
+	req.Job = cycle
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cycle")
+
+	// Finish the upstream job and wait for the eval to become pending
+	upstream := upsertFinishedBatchJob(t, state, 1000, structs.AllocClientStatusComplete)
+	job.DependsOn[0].JobID = upstream.ID
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1010, job))
+
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.EvalByID(nil, eval.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.Status != structs.EvalStatusPending {
+			return false, fmt.Errorf("expected eval to be pending, got %q", out.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/armon/go-metrics"
@@ -53,6 +54,10 @@ type Job struct {
 	// builtin admission controllers
 	mutators   []jobMutator
 	validators []jobValidator
+
+	// dispatchLock serializes dispatches using an idempotency token so
+	// concurrent retries of the same request can't both create a job
+	dispatchLock sync.Mutex
 }
 
 // NewJobEndpoints creates a new job endpoint with builtin admission controllers
@@ -213,6 +218,11 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return err
 	}
 
+	// Validate the upstream jobs of the job's dependencies
+	if err := validateJobDependencies(snap, args.Job); err != nil {
+		return err
+	}
+
 	// Ensure that all scaling policies have an appropriate ID
 	if err := propagateScalingPolicyIDs(existingJob, args.Job); err != nil {
 		return err
@@ -336,7 +346,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 			Type:        args.Job.Type,
 			TriggeredBy: structs.EvalTriggerJobRegister,
 			JobID:       args.Job.ID,
-			Status:      structs.EvalStatusPending,
+			Status:      args.Job.RegisterEvalStatus(),
 			CreateTime:  now,
 			ModifyTime:  now,
 		}
@@ -1826,6 +1836,13 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		return fmt.Errorf("missing parameterized job ID")
 	}
 
+	// Hold the lock until the dispatched job is committed so a concurrent
+	// request with the same idempotency token finds it
+	if args.IdempotencyToken != "" {
+		j.dispatchLock.Lock()
+		defer j.dispatchLock.Unlock()
+	}
+
 	snap, err := j.srv.fsm.State().Snapshot()
 	if err != nil {
 		return err
@@ -1863,6 +1880,7 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		}
 
 		// Iterate
+		var existingJob *structs.Job
 		for {
 			raw := iter.Next()
 			if raw == nil {
@@ -1870,23 +1888,30 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 			}
 
 			// Ensure the parent ID is an exact match
-			existingJob := raw.(*structs.Job)
-			if existingJob.ParentID != parameterizedJob.ID {
+			job := raw.(*structs.Job)
+			if job.ParentID != parameterizedJob.ID {
 				continue
 			}
 
-			// Idempotency tokens match
-			if existingJob.DispatchIdempotencyToken == args.IdempotencyToken {
-				// The existing job has not yet been garbage collected.
-				// Registering a new job would violate the idempotency token.
-				// Return the existing job.
-				reply.JobCreateIndex = existingJob.CreateIndex
-				reply.DispatchedJobID = existingJob.ID
-				reply.Index = existingJob.ModifyIndex
-
-				return nil
+			// Idempotency tokens match. Retries of a failed dispatched job
+			// share its token so prefer the latest attempt.
+			if job.DispatchIdempotencyToken == args.IdempotencyToken {
+				if existingJob == nil || job.DispatchAttempt() > existingJob.DispatchAttempt() {
+					existingJob = job
+				}
 			}
 		}
+
+		if existingJob != nil {
+			// The existing job has not yet been garbage collected.
+			// Registering a new job would violate the idempotency token.
+			// Return the existing job.
+			reply.JobCreateIndex = existingJob.CreateIndex
+			reply.DispatchedJobID = existingJob.ID
+			reply.Index = existingJob.ModifyIndex
+
+			return nil
+		}
 	}
 
 	// Derive the child job and commit it via Raft - with initial status
@@ -1899,6 +1924,15 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	dispatchJob.Status = ""
 	dispatchJob.StatusDescription = ""
 	dispatchJob.DispatchIdempotencyToken = args.IdempotencyToken
+	if args.Priority != 0 {
+		dispatchJob.Priority = args.Priority
+	}
+	dispatchJob.DependsOn = append(dispatchJob.DependsOn, structs.CopySliceJobDependencies(args.DependsOn)...)
+
+	// Validate the upstream jobs of the dispatched job's dependencies
+	if err := validateJobDependencies(snap, dispatchJob); err != nil {
+		return err
+	}
 
 	// Merge in the meta data
 	for k, v := range args.Meta {
@@ -1943,7 +1977,7 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 			TriggeredBy:    structs.EvalTriggerJobRegister,
 			JobID:          dispatchJob.ID,
 			JobModifyIndex: jobCreateIndex,
-			Status:         structs.EvalStatusPending,
+			Status:         dispatchJob.RegisterEvalStatus(),
 			CreateTime:     now,
 			ModifyTime:     now,
 		}
@@ -1971,6 +2005,22 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 // validateDispatchRequest returns whether the request is valid given the
 // parameterized job.
 func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job) error {
+	// Check the priority override is valid
+	if req.Priority != 0 && (req.Priority < structs.JobMinPriority || req.Priority > structs.JobMaxPriority) {
+		return fmt.Errorf("Priority must be between %d and %d", structs.JobMinPriority, structs.JobMaxPriority)
+	}
+
+	// Check the dependencies are valid
+	if len(req.DependsOn) != 0 && job.Type != structs.JobTypeBatch {
+		return fmt.Errorf("Dependencies can only be used with %q scheduler", structs.JobTypeBatch)
+	}
+	for idx, d := range req.DependsOn {
+		d.Canonicalize()
+		if err := d.Validate(); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("Dependency %d:", idx+1))
+		}
+	}
+
 	// Check the payload constraint is met
 	hasInputData := len(req.Payload) != 0
 	if job.ParameterizedJob.Payload == structs.DispatchPayloadRequired && !hasInputData {
@@ -2034,6 +2084,70 @@ func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job)
 	return nil
 }
 
+// DispatchQueue retrieves the dispatch queue of a parameterized job
+func (j *Job) DispatchQueue(args *structs.JobDispatchQueueRequest,
+	reply *structs.JobDispatchQueueResponse) error {
+
+	if done, err := j.srv.forward("Job.DispatchQueue", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "dispatch_queue"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			job, err := state.JobByID(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+
+			reply.Queue = nil
+			if job != nil {
+				if !job.IsParameterized() {
+					return fmt.Errorf("job %q is not a parameterized job", args.JobID)
+				}
+
+				waiting, err := waitingEvals(ws, state)
+				if err != nil {
+					return err
+				}
+				reply.Queue, err = dispatchQueue(ws, state, job, waiting)
+				if err != nil {
+					return err
+				}
+			}
+
+			// The queue is derived from jobs and evaluations so use the
+			// latest index of either
+			index, err := state.Index("jobs")
+			if err != nil {
+				return err
+			}
+			evalIndex, err := state.Index("evals")
+			if err != nil {
+				return err
+			}
+			if evalIndex > index {
+				index = evalIndex
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // ScaleStatus retrieves the scaling status for a job
 func (j *Job) ScaleStatus(args *structs.JobScaleStatusRequest,
 	reply *structs.JobScaleStatusResponse) error {
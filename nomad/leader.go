@@ -359,6 +359,16 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Schedule evaluations once their job dependencies are satisfied and
+	// dispatch slots are free
+	go s.watchWaitingEvals(stopCh)
+
+	// Retry failed dispatched jobs
+	go s.watchDispatchRetries(stopCh)
+
+	// Unblock evaluations when node maintenance windows close
+	go s.watchMaintenanceWindows(stopCh)
+
 	// Setup the heartbeat timers. This is done both when starting up or when
 	// a leader fail over happens. Since the timers are maintained by the leader
 	// node, effectively this means all the timers are renewed at the time of failover.
@@ -0,0 +1,129 @@
+package nomad
+
+import (
+	"context"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// maintenanceWindowRetryInterval is the time the maintenance window
+	// watcher waits before retrying after failing to query nodes.
+	maintenanceWindowRetryInterval = 5 * time.Second
+)
+
+// watchMaintenanceWindows is a long lived function that unblocks the
+// evaluations blocked on nodes in a maintenance window when the window
+// closes. Placements on these nodes don't become possible through a state
+// change, so the blocked evaluations would otherwise never be unblocked.
+func (s *Server) watchMaintenanceWindows(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	index := uint64(1)
+	var next time.Time
+	var closing []*structs.Node
+	for {
+		// Wake up when the next window closes even if the state doesn't change
+		queryCtx, queryCancel := context.WithCancel(ctx)
+		if !next.IsZero() {
+			queryCancel()
+			queryCtx, queryCancel = context.WithDeadline(ctx, next)
+		}
+		raw, idx, err := s.State().BlockingQuery(maintenanceWindowNodes, index, queryCtx)
+		queryCancel()
+		if err != nil && ctx.Err() != nil {
+			return
+		}
+
+		// Unblock the nodes whose window closed, whether the deadline was
+		// reached or the state changed at the same time
+		now := time.Now()
+		if !next.IsZero() && !now.Before(next) {
+			s.unblockMaintenanceWindowNodes(closing)
+			next, closing = time.Time{}, nil
+		}
+
+		if err != nil {
+			if err == context.DeadlineExceeded {
+				// Force the query to run again to find the next window
+				index = 1
+				continue
+			}
+			s.logger.Error("failed to query nodes with maintenance windows", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(maintenanceWindowRetryInterval):
+			}
+			continue
+		}
+		index = idx
+
+		next, closing = time.Time{}, nil
+		for _, node := range raw.([]*structs.Node) {
+			end := node.NextMaintenanceWindowEnd(now)
+			switch {
+			case end.IsZero():
+			case next.IsZero() || end.Before(next):
+				next, closing = end, []*structs.Node{node}
+			case end.Equal(next):
+				closing = append(closing, node)
+			}
+		}
+	}
+}
+
+// unblockMaintenanceWindowNodes unblocks the evaluations blocked on the nodes
+// and their computed classes.
+func (s *Server) unblockMaintenanceWindowNodes(nodes []*structs.Node) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	index, err := s.State().LatestIndex()
+	if err != nil {
+		s.logger.Error("failed to get latest index to unblock evaluations", "error", err)
+		return
+	}
+
+	for _, node := range nodes {
+		s.logger.Debug("node maintenance window closed, unblocking evaluations", "node_id", node.ID)
+		s.blockedEvals.Unblock(node.ComputedClass, index)
+		s.blockedEvals.UnblockNode(node.ID, index)
+	}
+}
+
+// maintenanceWindowNodes returns the nodes with maintenance windows. The watch
+// set covers all nodes.
+func maintenanceWindowNodes(ws memdb.WatchSet, snap *state.StateStore) (interface{}, uint64, error) {
+	iter, err := snap.Nodes(ws)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var nodes []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if len(node.MaintenanceWindows) != 0 {
+			nodes = append(nodes, node)
+		}
+	}
+
+	index, err := snap.Index("nodes")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return nodes, index, nil
+}
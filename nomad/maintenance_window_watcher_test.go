@@ -0,0 +1,78 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchMaintenanceWindows_UnblockOnClose(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// The node's only maintenance window is open for a few seconds and never
+	// opens again
+	start := time.Now().UTC().Truncate(time.Second)
+	end := start.Add(5 * time.Second)
+	node := mock.Node()
+	node.MaintenanceWindows = []*structs.NodeMaintenanceWindow{{
+		Cron: fmt.Sprintf("%d %d %d %d %d * %d",
+			start.Second(), start.Minute(), start.Hour(), start.Day(), start.Month(), start.Year()),
+		Duration: end.Sub(start),
+		Source:   structs.NodeMaintenanceWindowSourceConfig,
+	}}
+	require.True(t, node.InMaintenanceWindow(time.Now()))
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}, &structs.NodeUpdateResponse{}))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}, &structs.JobRegisterResponse{}))
+
+	// The evaluation is blocked while the window is open
+	testutil.WaitForResult(func() (bool, error) {
+		if blocked := s1.blockedEvals.Stats().TotalBlocked; blocked != 1 {
+			return false, fmt.Errorf("expected 1 blocked eval, got %d", blocked)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+	require.True(t, node.InMaintenanceWindow(time.Now()), "window closed before the eval was blocked")
+
+	// The allocation is placed once the window closes
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, err := s1.fsm.State().AllocsByJob(nil, job.Namespace, job.ID, false)
+		if err != nil {
+			return false, err
+		}
+		if len(allocs) != 1 {
+			return false, fmt.Errorf("expected 1 alloc, got %d", len(allocs))
+		}
+		if created := time.Unix(0, allocs[0].CreateTime); created.Before(end) {
+			return false, fmt.Errorf("alloc placed at %s before the window closed at %s", created, end)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
@@ -48,6 +48,10 @@ const (
 	// NodeHeartbeatEventReregistered is the message used when the node becomes
 	// reregistered by the heartbeat.
 	NodeHeartbeatEventReregistered = "Node reregistered by heartbeat"
+
+	// NodeMaintenanceEventUpdated is used when the maintenance windows of a
+	// node are updated through the Node API
+	NodeMaintenanceEventUpdated = "Node maintenance windows updated"
 )
 
 // Node endpoint is used for client interactions
@@ -768,6 +772,79 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	return nil
 }
 
+// UpdateMaintenance is used to set the maintenance windows of a node. The
+// windows replace those previously set through this endpoint but not the ones
+// declared in the client's configuration.
+func (n *Node) UpdateMaintenance(args *structs.NodeUpdateMaintenanceRequest,
+	reply *structs.NodeMaintenanceUpdateResponse) error {
+	if done, err := n.srv.forward("Node.UpdateMaintenance", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "update_maintenance"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Verify the arguments
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID for setting maintenance windows")
+	}
+	if args.NodeEvent != nil {
+		return fmt.Errorf("node event must not be set")
+	}
+	for i, w := range args.MaintenanceWindows {
+		if w == nil {
+			return fmt.Errorf("maintenance window %d is empty", i+1)
+		}
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("maintenance window %d validation failed: %v", i+1, err)
+		}
+	}
+
+	// Look for the node
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := snap.NodeByID(nil, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	// Update the timestamp of when the node status was updated
+	args.UpdatedAt = time.Now().Unix()
+
+	// Construct the node event
+	args.NodeEvent = structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemCluster).
+		SetMessage(NodeMaintenanceEventUpdated)
+
+	// Commit this update via Raft
+	outErr, index, err := n.srv.raftApply(structs.NodeUpdateMaintenanceRequestType, args)
+	if err != nil {
+		n.logger.Error("maintenance windows update failed", "error", err)
+		return err
+	}
+	if outErr != nil {
+		if err, ok := outErr.(error); ok && err != nil {
+			n.logger.Error("maintenance windows update failed", "error", err)
+			return err
+		}
+	}
+
+	// Set the reply index
+	reply.NodeModifyIndex = index
+	reply.Index = index
+	return nil
+}
+
 // Evaluate is used to force a re-evaluation of the node
 func (n *Node) Evaluate(args *structs.NodeEvaluateRequest, reply *structs.NodeUpdateResponse) error {
 	if done, err := n.srv.forward("Node.Evaluate", args, args, reply); done {
@@ -1422,6 +1422,66 @@ func TestClientEndpoint_Drain_Down(t *testing.T) {
 	})
 }
 
+func TestClientEndpoint_UpdateMaintenance(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the register request
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Fetch the response
+	var resp structs.NodeUpdateResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Invalid windows are rejected
+	req := &structs.NodeUpdateMaintenanceRequest{
+		NodeID: node.ID,
+		MaintenanceWindows: []*structs.NodeMaintenanceWindow{
+			{Cron: "0 2 * * *"},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp2 structs.NodeMaintenanceUpdateResponse
+	err := msgpackrpc.CallWithCodec(codec, "Node.UpdateMaintenance", req, &resp2)
+	require.Error(err)
+	require.Contains(err.Error(), "Duration must be greater than zero")
+
+	// Set the windows
+	req.MaintenanceWindows[0].Duration = 2 * time.Hour
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateMaintenance", req, &resp2))
+	require.NotZero(resp2.Index)
+
+	// Check for the node in the FSM
+	state := s1.fsm.State()
+	out, err := state.NodeByID(nil, node.ID)
+	require.Nil(err)
+	require.Len(out.MaintenanceWindows, 1)
+	require.Equal(structs.NodeMaintenanceWindowSourceAPI, out.MaintenanceWindows[0].Source)
+	require.Equal(2*time.Hour, out.MaintenanceWindows[0].Duration)
+	require.Len(out.Events, 2)
+	require.Equal(NodeMaintenanceEventUpdated, out.Events[1].Message)
+
+	// The windows survive the node registering again
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+	out, err = state.NodeByID(nil, node.ID)
+	require.Nil(err)
+	require.Len(out.MaintenanceWindows, 1)
+
+	// Unknown nodes are rejected
+	req.NodeID = uuid.Generate()
+	err = msgpackrpc.CallWithCodec(codec, "Node.UpdateMaintenance", req, &resp2)
+	require.EqualError(err, "node not found")
+}
+
 func TestClientEndpoint_UpdateEligibility(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
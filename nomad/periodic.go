@@ -54,7 +54,7 @@ func (s *Server) DispatchJob(job *structs.Job) (*structs.Evaluation, error) {
 		Type:        job.Type,
 		TriggeredBy: structs.EvalTriggerPeriodicJob,
 		JobID:       job.ID,
-		Status:      structs.EvalStatusPending,
+		Status:      job.RegisterEvalStatus(),
 		CreateTime:  now,
 		ModifyTime:  now,
 	}
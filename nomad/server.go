@@ -280,13 +280,17 @@ type endpoints struct {
 	Event               *Event
 	Namespace           *Namespace
 	ServiceRegistration *ServiceRegistration
+	ImagePrefetch       *ImagePrefetch
+	HostVolume          *HostVolume
 
 	// Client endpoints
 	ClientStats       *ClientStats
+	ClientPlugins     *ClientPlugins
 	FileSystem        *FileSystem
 	Agent             *Agent
 	ClientAllocations *ClientAllocations
 	ClientCSI         *ClientCSI
+	ClientHostVolume  *ClientHostVolume
 }
 
 // NewServer is used to construct a new Nomad server from the
@@ -1159,6 +1163,8 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.System = &System{srv: s, logger: s.logger.Named("system")}
 		s.staticEndpoints.Search = &Search{srv: s, logger: s.logger.Named("search")}
 		s.staticEndpoints.Namespace = &Namespace{srv: s}
+		s.staticEndpoints.ImagePrefetch = &ImagePrefetch{srv: s}
+		s.staticEndpoints.HostVolume = &HostVolume{srv: s, logger: s.logger.Named("host_volume")}
 		s.staticEndpoints.Enterprise = NewEnterpriseEndpoints(s)
 
 		// These endpoints are dynamic because they need access to the
@@ -1171,9 +1177,11 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 
 		// Client endpoints
 		s.staticEndpoints.ClientStats = &ClientStats{srv: s, logger: s.logger.Named("client_stats")}
+		s.staticEndpoints.ClientPlugins = &ClientPlugins{srv: s, logger: s.logger.Named("client_plugins")}
 		s.staticEndpoints.ClientAllocations = &ClientAllocations{srv: s, logger: s.logger.Named("client_allocs")}
 		s.staticEndpoints.ClientAllocations.register()
 		s.staticEndpoints.ClientCSI = &ClientCSI{srv: s, logger: s.logger.Named("client_csi")}
+		s.staticEndpoints.ClientHostVolume = &ClientHostVolume{srv: s, logger: s.logger.Named("client_host_volume")}
 
 		// Streaming endpoints
 		s.staticEndpoints.FileSystem = &FileSystem{srv: s, logger: s.logger.Named("client_fs")}
@@ -1201,11 +1209,15 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.Search)
 	s.staticEndpoints.Enterprise.Register(server)
 	server.Register(s.staticEndpoints.ClientStats)
+	server.Register(s.staticEndpoints.ClientPlugins)
 	server.Register(s.staticEndpoints.ClientAllocations)
 	server.Register(s.staticEndpoints.ClientCSI)
+	server.Register(s.staticEndpoints.ClientHostVolume)
 	server.Register(s.staticEndpoints.FileSystem)
 	server.Register(s.staticEndpoints.Agent)
 	server.Register(s.staticEndpoints.Namespace)
+	server.Register(s.staticEndpoints.ImagePrefetch)
+	server.Register(s.staticEndpoints.HostVolume)
 
 	// Create new dynamic endpoints and add them to the RPC server.
 	alloc := &Alloc{srv: s, ctx: ctx, logger: s.logger.Named("alloc")}
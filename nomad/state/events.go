@@ -20,6 +20,7 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.NodeUpdateEligibilityRequestType:             structs.TypeNodeDrain,
 	structs.NodeUpdateDrainRequestType:                   structs.TypeNodeDrain,
 	structs.BatchNodeUpdateDrainRequestType:              structs.TypeNodeDrain,
+	structs.NodeUpdateMaintenanceRequestType:             structs.TypeNodeMaintenance,
 	structs.DeploymentStatusUpdateRequestType:            structs.TypeDeploymentUpdate,
 	structs.DeploymentPromoteRequestType:                 structs.TypeDeploymentPromotion,
 	structs.DeploymentAllocHealthRequestType:             structs.TypeDeploymentAllocHealth,
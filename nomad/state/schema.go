@@ -14,6 +14,8 @@ const (
 
 	TableNamespaces           = "namespaces"
 	TableServiceRegistrations = "service_registrations"
+	TableImagePrefetches      = "image_prefetches"
+	TableHostVolumes          = "host_volumes"
 )
 
 const (
@@ -70,6 +72,8 @@ func init() {
 		scalingEventTableSchema,
 		namespaceTableSchema,
 		serviceRegistrationsTableSchema,
+		imagePrefetchTableSchema,
+		hostVolumeTableSchema,
 	}...)
 }
 
@@ -191,6 +195,14 @@ func jobTableSchema() *memdb.TableSchema {
 					Conditional: jobIsPeriodic,
 				},
 			},
+			"dispatch_retry": {
+				Name:         "dispatch_retry",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: jobIsDispatchRetryable,
+				},
+			},
 		},
 	}
 }
@@ -310,6 +322,26 @@ func jobIsPeriodic(obj interface{}) (bool, error) {
 	return false, nil
 }
 
+// jobIsDispatchRetryable satisfies the ConditionalIndexFunc interface and
+// creates an index on whether a job is a dead dispatched job that may still be
+// retried by its retry policy.
+func jobIsDispatchRetryable(obj interface{}) (bool, error) {
+	j, ok := obj.(*structs.Job)
+	if !ok {
+		return false, fmt.Errorf("Unexpected type: %v", obj)
+	}
+
+	policy := j.DispatchRetryPolicy()
+	if policy == nil || j.Stop || j.Status != structs.JobStatusDead {
+		return false, nil
+	}
+	if t := j.DispatchRetryTracker; t != nil && t.RetryJobID != "" {
+		return false, nil
+	}
+
+	return j.DispatchAttempt() <= policy.Attempts, nil
+}
+
 // deploymentSchema returns the MemDB schema tracking a job's deployments
 func deploymentSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -507,6 +539,17 @@ func evalTableSchema() *memdb.TableSchema {
 				},
 			},
 
+			// status is used to lookup evaluations by status, such as the
+			// evaluations waiting on job dependencies.
+			"status": {
+				Name:         "status",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Status",
+				},
+			},
+
 			// namespace_create index is used to lookup evaluations by namespace
 			// in their original chronological order based on CreateIndex.
 			//
@@ -1202,3 +1245,55 @@ func serviceRegistrationsTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// imagePrefetchTableSchema returns the MemDB schema for image prefetches.
+func imagePrefetchTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableImagePrefetches,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// hostVolumeTableSchema returns the MemDB schema for dynamic host volumes.
+func hostVolumeTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableHostVolumes,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+			// The nodeID index is used to find the volumes created on a
+			// node, whatever their namespace, as their names must be unique
+			// within the node's fingerprinted host volumes.
+			indexNodeID: {
+				Name:         indexNodeID,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "NodeID",
+				},
+			},
+		},
+	}
+}
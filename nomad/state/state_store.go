@@ -896,6 +896,11 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 		node.SchedulingEligibility = exist.SchedulingEligibility // Retain the eligibility
 		node.DrainStrategy = exist.DrainStrategy                 // Retain the drain strategy
 		node.LastDrain = exist.LastDrain                         // Retain the drain metadata
+
+		// Retain the maintenance windows set through the Node API, the
+		// client only declares the ones from its configuration
+		node.MaintenanceWindows = mergeNodeMaintenanceWindows(
+			node.MaintenanceWindows, exist.MaintenanceWindows)
 	} else {
 		// Because this is the first time the node is being registered, we should
 		// also create a node registration event
@@ -1179,6 +1184,72 @@ func (s *StateStore) UpdateNodeEligibility(msgType structs.MessageType, index ui
 	return txn.Commit()
 }
 
+// UpdateNodeMaintenanceWindows replaces the maintenance windows of a node set
+// through the Node API. The windows declared in the client's configuration
+// are left untouched.
+func (s *StateStore) UpdateNodeMaintenanceWindows(msgType structs.MessageType, index uint64, nodeID string, windows []*structs.NodeMaintenanceWindow, updatedAt int64, event *structs.NodeEvent) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Lookup the node
+	existing, err := txn.First("nodes", "id", nodeID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	// Copy the existing node
+	existingNode := existing.(*structs.Node)
+	copyNode := existingNode.Copy()
+	copyNode.StatusUpdatedAt = updatedAt
+
+	// Add the event if given
+	if event != nil {
+		appendNodeEvents(index, copyNode, []*structs.NodeEvent{event})
+	}
+
+	// Replace the windows set through the API
+	apiWindows := make([]*structs.NodeMaintenanceWindow, 0, len(windows))
+	for _, w := range windows {
+		w = w.Copy()
+		w.Source = structs.NodeMaintenanceWindowSourceAPI
+		apiWindows = append(apiWindows, w)
+	}
+	copyNode.MaintenanceWindows = mergeNodeMaintenanceWindows(
+		existingNode.MaintenanceWindows, apiWindows)
+	copyNode.ModifyIndex = index
+
+	// Insert the node
+	if err := txn.Insert("nodes", copyNode); err != nil {
+		return fmt.Errorf("node update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"nodes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// mergeNodeMaintenanceWindows returns the maintenance windows declared in the
+// client's configuration from configWindows along with the windows set through
+// the Node API from apiWindows.
+func mergeNodeMaintenanceWindows(configWindows, apiWindows []*structs.NodeMaintenanceWindow) []*structs.NodeMaintenanceWindow {
+	var merged []*structs.NodeMaintenanceWindow
+	for _, w := range configWindows {
+		if w.Source != structs.NodeMaintenanceWindowSourceAPI {
+			merged = append(merged, w)
+		}
+	}
+	for _, w := range apiWindows {
+		if w.Source == structs.NodeMaintenanceWindowSourceAPI {
+			merged = append(merged, w)
+		}
+	}
+	return merged
+}
+
 // UpsertNodeEvents adds the node events to the nodes, rotating events as
 // necessary.
 func (s *StateStore) UpsertNodeEvents(msgType structs.MessageType, index uint64, nodeEvents map[string][]*structs.NodeEvent) error {
@@ -1676,9 +1747,47 @@ func (s *StateStore) upsertJobImpl(index uint64, job *structs.Job, keepVersion b
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	if existing == nil {
+		if err := s.markDispatchRetried(index, job, txn); err != nil {
+			return fmt.Errorf("unable to mark retried dispatched job: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// markDispatchRetried records on the failed dispatched job retried by the
+// given job that it has been retried, so it isn't retried again once the retry
+// is garbage collected.
+func (s *StateStore) markDispatchRetried(index uint64, job *structs.Job, txn *txn) error {
+	tracker := job.DispatchRetryTracker
+	if !job.Dispatched || tracker == nil || len(tracker.Events) == 0 {
+		return nil
+	}
+
+	prevID := tracker.Events[len(tracker.Events)-1].PrevJobID
+	existing, err := txn.First("jobs", "id", job.Namespace, prevID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+
+	// Has already been garbage collected or marked, nothing to do
+	if existing == nil {
+		return nil
+	}
+	prev := existing.(*structs.Job)
+	if prev.DispatchRetryTracker != nil && prev.DispatchRetryTracker.RetryJobID != "" {
+		return nil
+	}
+
+	copy := prev.Copy()
+	if copy.DispatchRetryTracker == nil {
+		copy.DispatchRetryTracker = &structs.DispatchRetryTracker{}
+	}
+	copy.DispatchRetryTracker.RetryJobID = job.ID
+	return s.upsertJobImpl(index, copy, true, txn)
+}
+
 // DeleteJob is used to deregister a job
 func (s *StateStore) DeleteJob(index uint64, namespace, jobID string) error {
 	txn := s.db.WriteTxn(index)
@@ -2145,6 +2254,21 @@ func (s *StateStore) JobsByGC(ws memdb.WatchSet, gc bool) (memdb.ResultIterator,
 	return iter, nil
 }
 
+// JobsByDispatchRetry returns an iterator over the dead dispatched jobs with a
+// retry policy that have attempts left and have not been retried yet.
+func (s *StateStore) JobsByDispatchRetry(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("jobs", "dispatch_retry", true)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // JobSummaryByID returns a job summary object which matches a specific id.
 func (s *StateStore) JobSummaryByID(ws memdb.WatchSet, namespace, jobID string) (*structs.JobSummary, error) {
 	txn := s.db.ReadTxn()
@@ -2494,6 +2618,43 @@ func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *s
 	return txn.Commit()
 }
 
+// CSIVolumeExpand records the capacity of a volume after it has been
+// expanded in the storage provider. Unlike UpsertCSIVolume, it updates
+// volumes that are in use, as expansion leaves the claims untouched.
+func (s *StateStore) CSIVolumeExpand(index uint64, namespace, id string, capacity, capacityMin, capacityMax int64) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	row, err := txn.First("csi_volumes", "id", namespace, id)
+	if err != nil {
+		return fmt.Errorf("volume lookup failed: %s: %v", id, err)
+	}
+	if row == nil {
+		return fmt.Errorf("volume not found: %s", id)
+	}
+
+	orig, ok := row.(*structs.CSIVolume)
+	if !ok {
+		return fmt.Errorf("volume row conversion error")
+	}
+
+	volume := orig.Copy()
+	volume.Capacity = capacity
+	volume.RequestedCapacityMin = capacityMin
+	volume.RequestedCapacityMax = capacityMax
+	volume.ModifyIndex = index
+
+	if err = txn.Insert("csi_volumes", volume); err != nil {
+		return fmt.Errorf("volume update failed: %s: %v", id, err)
+	}
+
+	if err = txn.Insert("index", &IndexEntry{"csi_volumes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // CSIVolumeDeregister removes the volume from the server
 func (s *StateStore) CSIVolumeDeregister(index uint64, namespace string, ids []string, force bool) error {
 	txn := s.db.WriteTxn(index)
@@ -2606,6 +2767,60 @@ func (s *StateStore) csiVolumeDenormalizePluginsTxn(txn Txn, vol *structs.CSIVol
 	return vol, nil
 }
 
+// CSIVolumeDenormalizeNodeStats returns a CSIVolume with the usage and
+// condition reported by the node plugin on each node with a current claim.
+// Note that it mutates the original volume and so should always be called
+// on a Copy after reading from the state store.
+func (s *StateStore) CSIVolumeDenormalizeNodeStats(ws memdb.WatchSet, vol *structs.CSIVolume) (*structs.CSIVolume, error) {
+	if vol == nil {
+		return nil, nil
+	}
+	txn := s.db.ReadTxn()
+	defer txn.Abort()
+
+	vol.NodeStats = nil
+	addStats := func(claims map[string]*structs.CSIVolumeClaim) error {
+		for _, claim := range claims {
+			if claim == nil || claim.NodeID == "" {
+				continue
+			}
+			if _, ok := vol.NodeStats[claim.NodeID]; ok {
+				continue
+			}
+			watchCh, obj, err := txn.FirstWatch("nodes", "id", claim.NodeID)
+			if err != nil {
+				return fmt.Errorf("node lookup failed: %s %v", claim.NodeID, err)
+			}
+			ws.Add(watchCh)
+			if obj == nil {
+				continue
+			}
+			node := obj.(*structs.Node)
+			info, ok := node.CSINodePlugins[vol.PluginID]
+			if !ok || info.NodeInfo == nil {
+				continue
+			}
+			stats, ok := info.NodeInfo.VolumeStats[vol.ID]
+			if !ok {
+				continue
+			}
+			if vol.NodeStats == nil {
+				vol.NodeStats = map[string]*structs.CSIVolumeStats{}
+			}
+			vol.NodeStats[claim.NodeID] = stats.Copy()
+		}
+		return nil
+	}
+
+	if err := addStats(vol.ReadClaims); err != nil {
+		return nil, err
+	}
+	if err := addStats(vol.WriteClaims); err != nil {
+		return nil, err
+	}
+	return vol, nil
+}
+
 // CSIVolumeDenormalize returns a CSIVolume with its current
 // Allocations and Claims, including creating new PastClaims for
 // terminal or garbage collected allocations. This ensures we have a
@@ -3340,6 +3555,21 @@ func (s *StateStore) Evals(ws memdb.WatchSet, sort SortOption) (memdb.ResultIter
 	return it, nil
 }
 
+// EvalsByStatus returns an iterator over all evaluations with the given
+// status in no particular order.
+func (s *StateStore) EvalsByStatus(ws memdb.WatchSet, status string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	it, err := txn.Get("evals", "status", status)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(it.WatchCh())
+
+	return it, nil
+}
+
 // EvalsByNamespace returns an iterator over all evaluations in no particular
 // order.
 //
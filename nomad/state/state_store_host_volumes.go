@@ -0,0 +1,139 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertHostVolume is used to insert or update a dynamic host volume
+func (s *StateStore) UpsertHostVolume(
+	msgType structs.MessageType, index uint64, vol *structs.HostVolume) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableHostVolumes, indexID, vol.Namespace, vol.ID)
+	if err != nil {
+		return fmt.Errorf("host volume lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		exist := existing.(*structs.HostVolume)
+		if exist.NodeID != vol.NodeID {
+			return fmt.Errorf("host volume %q cannot be moved to another node", vol.ID)
+		}
+		vol.CreateIndex = exist.CreateIndex
+	} else {
+		vol.CreateIndex = index
+	}
+	vol.ModifyIndex = index
+
+	if err := txn.Insert(TableHostVolumes, vol); err != nil {
+		return fmt.Errorf("host volume insert failed: %v", err)
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteHostVolume is used to delete a dynamic host volume. An error is
+// returned if it does not exist.
+func (s *StateStore) DeleteHostVolume(
+	msgType structs.MessageType, index uint64, namespace, id string) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableHostVolumes, indexID, namespace, id)
+	if err != nil {
+		return fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("host volume %q not found", id)
+	}
+
+	if err := txn.Delete(TableHostVolumes, existing); err != nil {
+		return fmt.Errorf("host volume deletion failed: %v", err)
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// HostVolumeByID is used to lookup a single dynamic host volume
+func (s *StateStore) HostVolumeByID(
+	ws memdb.WatchSet, namespace, id string) (*structs.HostVolume, error) {
+
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableHostVolumes, indexID, namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.HostVolume), nil
+	}
+	return nil, nil
+}
+
+// HostVolumes returns an iterator over the dynamic host volumes of all
+// namespaces. The caller is responsible for filtering out the namespaces
+// the request is not allowed to read.
+func (s *StateStore) HostVolumes(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// HostVolumesByNamespace returns an iterator over the dynamic host volumes
+// of a namespace
+func (s *StateStore) HostVolumesByNamespace(
+	ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexID+"_prefix", namespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// HostVolumesByNodeID returns the dynamic host volumes created on a node, in
+// all namespaces
+func (s *StateStore) HostVolumesByNodeID(
+	ws memdb.WatchSet, nodeID string) ([]*structs.HostVolume, error) {
+
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexNodeID, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var result []*structs.HostVolume
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		result = append(result, raw.(*structs.HostVolume))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_HostVolumes(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	nodeID := uuid.Generate()
+	data := &structs.HostVolume{
+		ID:        uuid.Generate(),
+		Name:      "data",
+		Namespace: structs.DefaultNamespace,
+		NodeID:    nodeID,
+		HostPath:  "/srv/nomad/host_volumes/data",
+	}
+	scratch := &structs.HostVolume{
+		ID:        uuid.Generate(),
+		Name:      "scratch",
+		Namespace: "other",
+		NodeID:    nodeID,
+	}
+	require.NoError(t, testState.UpsertHostVolume(structs.MsgTypeTestSetup, 10, data))
+	require.NoError(t, testState.UpsertHostVolume(structs.MsgTypeTestSetup, 11, scratch))
+
+	index, err := testState.Index(TableHostVolumes)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), index)
+
+	// Updates keep the create index
+	ws := memdb.NewWatchSet()
+	_, err = testState.HostVolumeByID(ws, structs.DefaultNamespace, data.ID)
+	require.NoError(t, err)
+
+	update := data.Copy()
+	update.CapacityBytes = 1 << 30
+	require.NoError(t, testState.UpsertHostVolume(structs.MsgTypeTestSetup, 20, update))
+	require.True(t, watchFired(ws))
+
+	out, err := testState.HostVolumeByID(nil, structs.DefaultNamespace, data.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(20), out.ModifyIndex)
+	require.Equal(t, int64(1<<30), out.CapacityBytes)
+
+	// Volumes cannot move between nodes
+	moved := data.Copy()
+	moved.NodeID = uuid.Generate()
+	require.Error(t, testState.UpsertHostVolume(structs.MsgTypeTestSetup, 21, moved))
+
+	// The volumes are listed per namespace or per node
+	iter, err := testState.HostVolumesByNamespace(nil, "other")
+	require.NoError(t, err)
+	raw := iter.Next()
+	require.NotNil(t, raw)
+	require.Equal(t, scratch.ID, raw.(*structs.HostVolume).ID)
+	require.Nil(t, iter.Next())
+
+	vols, err := testState.HostVolumesByNodeID(nil, nodeID)
+	require.NoError(t, err)
+	require.Len(t, vols, 2)
+
+	err = testState.DeleteHostVolume(structs.MsgTypeTestSetup, 30, structs.DefaultNamespace, "missing")
+	require.EqualError(t, err, `host volume "missing" not found`)
+
+	require.NoError(t, testState.DeleteHostVolume(
+		structs.MsgTypeTestSetup, 30, structs.DefaultNamespace, data.ID))
+	out, err = testState.HostVolumeByID(nil, structs.DefaultNamespace, data.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err = testState.Index(TableHostVolumes)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), index)
+}
@@ -0,0 +1,112 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertImagePrefetches is used to insert or update a set of image
+// prefetches. Any error means none of the entries are committed.
+func (s *StateStore) UpsertImagePrefetches(
+	msgType structs.MessageType, index uint64, prefetches []*structs.ImagePrefetch) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, prefetch := range prefetches {
+		existing, err := txn.First(TableImagePrefetches, indexID, prefetch.Name)
+		if err != nil {
+			return fmt.Errorf("image prefetch lookup failed: %v", err)
+		}
+
+		if existing != nil {
+			prefetch.CreateIndex = existing.(*structs.ImagePrefetch).CreateIndex
+		} else {
+			prefetch.CreateIndex = index
+		}
+		prefetch.ModifyIndex = index
+
+		if err := txn.Insert(TableImagePrefetches, prefetch); err != nil {
+			return fmt.Errorf("image prefetch insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableImagePrefetches, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteImagePrefetches is used to delete a set of image prefetches by name.
+// An error is returned if any of them does not exist.
+func (s *StateStore) DeleteImagePrefetches(
+	msgType structs.MessageType, index uint64, names []string) error {
+
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		existing, err := txn.First(TableImagePrefetches, indexID, name)
+		if err != nil {
+			return fmt.Errorf("image prefetch lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("image prefetch %q not found", name)
+		}
+
+		if err := txn.Delete(TableImagePrefetches, existing); err != nil {
+			return fmt.Errorf("image prefetch deletion failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableImagePrefetches, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// ImagePrefetchByName is used to lookup an image prefetch by name
+func (s *StateStore) ImagePrefetchByName(ws memdb.WatchSet, name string) (*structs.ImagePrefetch, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableImagePrefetches, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("image prefetch lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ImagePrefetch), nil
+	}
+	return nil, nil
+}
+
+// ImagePrefetches returns an iterator over all the image prefetches
+func (s *StateStore) ImagePrefetches(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableImagePrefetches, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("image prefetch lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// ImagePrefetchesByNamePrefix is used to lookup image prefetches by prefix
+func (s *StateStore) ImagePrefetchesByNamePrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableImagePrefetches, indexID+"_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("image prefetch lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
@@ -0,0 +1,61 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_ImagePrefetches(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	cuda := &structs.ImagePrefetch{Name: "cuda", Driver: "docker", Images: []string{"nvidia/cuda:11"}}
+	redis := &structs.ImagePrefetch{Name: "redis", Driver: "docker", Images: []string{"redis:7"}}
+	require.NoError(t, testState.UpsertImagePrefetches(
+		structs.MsgTypeTestSetup, 10, []*structs.ImagePrefetch{cuda, redis}))
+
+	index, err := testState.Index(TableImagePrefetches)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+
+	// Updates keep the create index
+	ws := memdb.NewWatchSet()
+	_, err = testState.ImagePrefetchByName(ws, "cuda")
+	require.NoError(t, err)
+
+	update := cuda.Copy()
+	update.Images = append(update.Images, "nvidia/cuda:12")
+	require.NoError(t, testState.UpsertImagePrefetches(
+		structs.MsgTypeTestSetup, 20, []*structs.ImagePrefetch{update}))
+	require.True(t, watchFired(ws))
+
+	out, err := testState.ImagePrefetchByName(nil, "cuda")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(20), out.ModifyIndex)
+	require.Len(t, out.Images, 2)
+
+	iter, err := testState.ImagePrefetchesByNamePrefix(nil, "re")
+	require.NoError(t, err)
+	raw := iter.Next()
+	require.NotNil(t, raw)
+	require.Equal(t, "redis", raw.(*structs.ImagePrefetch).Name)
+	require.Nil(t, iter.Next())
+
+	// Deleting a missing prefetch fails without deleting the others
+	err = testState.DeleteImagePrefetches(structs.MsgTypeTestSetup, 30, []string{"redis", "missing"})
+	require.EqualError(t, err, `image prefetch "missing" not found`)
+
+	require.NoError(t, testState.DeleteImagePrefetches(structs.MsgTypeTestSetup, 30, []string{"redis"}))
+	out, err = testState.ImagePrefetchByName(nil, "redis")
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err = testState.Index(TableImagePrefetches)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), index)
+}
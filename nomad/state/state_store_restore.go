@@ -197,3 +197,19 @@ func (r *StateRestore) ServiceRegistrationRestore(service *structs.ServiceRegist
 	}
 	return nil
 }
+
+// ImagePrefetchRestore is used to restore an image prefetch
+func (r *StateRestore) ImagePrefetchRestore(prefetch *structs.ImagePrefetch) error {
+	if err := r.txn.Insert(TableImagePrefetches, prefetch); err != nil {
+		return fmt.Errorf("image prefetch insert failed: %v", err)
+	}
+	return nil
+}
+
+// HostVolumeRestore is used to restore a dynamic host volume
+func (r *StateRestore) HostVolumeRestore(vol *structs.HostVolume) error {
+	if err := r.txn.Insert(TableHostVolumes, vol); err != nil {
+		return fmt.Errorf("host volume insert failed: %v", err)
+	}
+	return nil
+}
@@ -1591,6 +1591,70 @@ func TestStateStore_UpdateNodeEligibility(t *testing.T) {
 	require.Contains(err.Error(), "while it is draining")
 }
 
+func TestStateStore_UpdateNodeMaintenanceWindows(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	state := testStateStore(t)
+	configWindow := &structs.NodeMaintenanceWindow{
+		Cron:     "0 2 * * *",
+		Duration: 2 * time.Hour,
+		Source:   structs.NodeMaintenanceWindowSourceConfig,
+	}
+	node := mock.Node()
+	node.MaintenanceWindows = []*structs.NodeMaintenanceWindow{configWindow}
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Create a watchset so we can test that updating the windows fires the watch
+	ws := memdb.NewWatchSet()
+	_, err := state.NodeByID(ws, node.ID)
+	require.NoError(err)
+
+	apiWindow := &structs.NodeMaintenanceWindow{
+		Cron:     "0 12 * * 6",
+		Duration: time.Hour,
+	}
+	event := &structs.NodeEvent{
+		Message:   "Node maintenance windows updated",
+		Subsystem: structs.NodeEventSubsystemCluster,
+		Timestamp: time.Now(),
+	}
+	require.NoError(state.UpdateNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1001, node.ID,
+		[]*structs.NodeMaintenanceWindow{apiWindow}, 7, event))
+	require.True(watchFired(ws))
+
+	// The windows set through the API are added to the configured ones
+	out, err := state.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Len(out.MaintenanceWindows, 2)
+	require.Equal(configWindow, out.MaintenanceWindows[0])
+	require.Equal(apiWindow.Cron, out.MaintenanceWindows[1].Cron)
+	require.Equal(structs.NodeMaintenanceWindowSourceAPI, out.MaintenanceWindows[1].Source)
+	require.Len(out.Events, 2)
+	require.EqualValues(1001, out.ModifyIndex)
+	require.EqualValues(7, out.StatusUpdatedAt)
+
+	// Re-registering the node replaces the configured windows but retains
+	// the ones set through the API
+	node = node.Copy()
+	node.MaintenanceWindows = nil
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1002, node))
+	out, err = state.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Len(out.MaintenanceWindows, 1)
+	require.Equal(structs.NodeMaintenanceWindowSourceAPI, out.MaintenanceWindows[0].Source)
+
+	// Clearing the windows set through the API
+	require.NoError(state.UpdateNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1003, node.ID, nil, 9, nil))
+	out, err = state.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Empty(out.MaintenanceWindows)
+
+	// Updating an unknown node fails
+	err = state.UpdateNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1004, uuid.Generate(), nil, 9, nil)
+	require.EqualError(err, "node not found")
+}
+
 func TestStateStore_Nodes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3377,6 +3441,58 @@ func TestStateStore_CSIVolume(t *testing.T) {
 	require.Equal(t, 1, len(vs))
 }
 
+func TestStateStore_CSIVolumeDenormalizeNodeStats(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+	index := uint64(1000)
+
+	plugin := mock.CSIPlugin()
+	vol := mock.CSIVolume(plugin)
+
+	node0, node1 := mock.Node(), mock.Node()
+	stats := &structs.CSIVolumeStats{
+		TotalBytes:     1024,
+		UsedBytes:      256,
+		AvailableBytes: 768,
+		Abnormal:       true,
+		Message:        "disk failure",
+	}
+	node0.CSINodePlugins = map[string]*structs.CSIInfo{
+		plugin.ID: {
+			PluginID: plugin.ID,
+			Healthy:  true,
+			NodeInfo: &structs.CSINodeInfo{
+				SupportsStats: true,
+				VolumeStats:   map[string]*structs.CSIVolumeStats{vol.ID: stats},
+			},
+		},
+	}
+	// node1 has a claim but its plugin doesn't report stats
+	node1.CSINodePlugins = map[string]*structs.CSIInfo{
+		plugin.ID: {
+			PluginID: plugin.ID,
+			Healthy:  true,
+			NodeInfo: &structs.CSINodeInfo{},
+		},
+	}
+	index++
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node0))
+	index++
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node1))
+
+	vol.WriteClaims["alloc0"] = &structs.CSIVolumeClaim{AllocationID: "alloc0", NodeID: node0.ID}
+	vol.ReadClaims["alloc1"] = &structs.CSIVolumeClaim{AllocationID: "alloc1", NodeID: node1.ID}
+
+	vol, err := store.CSIVolumeDenormalizeNodeStats(nil, vol)
+	require.NoError(t, err)
+	require.Len(t, vol.NodeStats, 1)
+	require.Equal(t, stats, vol.NodeStats[node0.ID])
+
+	// the stats are copied from the node
+	vol.NodeStats[node0.ID].UsedBytes = 0
+	require.Equal(t, int64(256), stats.UsedBytes)
+}
+
 func TestStateStore_CSIPlugin_Lifecycle(t *testing.T) {
 	ci.Parallel(t)
 
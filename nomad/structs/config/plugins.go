@@ -75,3 +75,25 @@ func PluginConfigSetMerge(first, second []*PluginConfig) []*PluginConfig {
 
 	return out
 }
+
+// PluginManifestEntry pins the version and SHA-256 checksum of an external
+// plugin. When a manifest is configured, the plugin loader only launches the
+// external plugins listed in it whose binaries match.
+type PluginManifestEntry struct {
+	// Name is the plugin's executable name in the plugin directory
+	Name string `hcl:",key"`
+
+	// Version is the expected plugin version. It isn't checked if empty.
+	Version string `hcl:"version"`
+
+	// SHA256 is the expected hex encoded SHA-256 checksum of the binary
+	SHA256 string `hcl:"sha256"`
+}
+
+func (p *PluginManifestEntry) Copy() *PluginManifestEntry {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
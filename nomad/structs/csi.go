@@ -318,6 +318,11 @@ type CSIVolume struct {
 	NodesExpected       int
 	ResourceExhausted   time.Time
 
+	// NodeStats is the usage and condition of the volume as reported by the
+	// node plugin on each node with a claim on the volume, keyed by node ID.
+	// It is denormalized from the nodes when the volume is read.
+	NodeStats map[string]*CSIVolumeStats
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -556,6 +561,13 @@ func (v *CSIVolume) Copy() *CSIVolume {
 		out.PastClaims[k] = &claim
 	}
 
+	if v.NodeStats != nil {
+		out.NodeStats = make(map[string]*CSIVolumeStats, len(v.NodeStats))
+		for k, v := range v.NodeStats {
+			out.NodeStats[k] = v.Copy()
+		}
+	}
+
 	return out
 }
 
@@ -787,9 +799,9 @@ func (v *CSIVolume) Merge(other *CSIVolume) error {
 			"volume snapshot ID cannot be updated"))
 	}
 
-	// must be compatible with capacity range
-	// TODO: when ExpandVolume is implemented we'll need to update
-	// this logic https://github.com/hashicorp/nomad/issues/10324
+	// must be compatible with capacity range. Volumes are grown by
+	// CSIVolume.Create, which expands them in the storage provider and
+	// never merges a larger capacity request here.
 	if v.Capacity != 0 {
 		if other.RequestedCapacityMax < v.Capacity ||
 			other.RequestedCapacityMin > v.Capacity {
@@ -881,6 +893,16 @@ type CSIVolumeCreateResponse struct {
 	QueryMeta
 }
 
+// CSIVolumeExpandRequest records the capacity of a volume once it has been
+// expanded by the storage provider
+type CSIVolumeExpandRequest struct {
+	VolumeID             string
+	Capacity             int64
+	RequestedCapacityMin int64
+	RequestedCapacityMax int64
+	WriteRequest
+}
+
 type CSIVolumeDeleteRequest struct {
 	VolumeIDs []string
 	Secrets   CSISecrets
@@ -17,6 +17,14 @@ type DeviceAccounterInstance struct {
 	// Instances is a mapping of the device IDs to their usage.
 	// Only a value of 0 indicates that the instance is unused.
 	Instances map[string]int
+
+	// Shares is a mapping of the device IDs of shareable instances to the
+	// number of shares they expose.
+	Shares map[string]uint64
+
+	// SharesUsed is a mapping of the device IDs to the number of shares in
+	// use. An exclusive use of a shareable instance uses all of its shares.
+	SharesUsed map[string]uint64
 }
 
 // NewDeviceAccounter returns a new device accounter. The node is used to
@@ -39,8 +47,10 @@ func NewDeviceAccounter(n *Node) *DeviceAccounter {
 	for _, dev := range devices {
 		id := *dev.ID()
 		d.Devices[id] = &DeviceAccounterInstance{
-			Device:    dev,
-			Instances: make(map[string]int, len(dev.Instances)),
+			Device:     dev,
+			Instances:  make(map[string]int, len(dev.Instances)),
+			Shares:     make(map[string]uint64),
+			SharesUsed: make(map[string]uint64),
 		}
 		for _, instance := range dev.Instances {
 			// Skip unhealthy devices as they aren't allocatable
@@ -49,6 +59,9 @@ func NewDeviceAccounter(n *Node) *DeviceAccounter {
 			}
 
 			d.Devices[id].Instances[instance.ID] = 0
+			if instance.Shares != 0 {
+				d.Devices[id].Shares[instance.ID] = instance.Shares
+			}
 		}
 	}
 
@@ -86,13 +99,8 @@ func (d *DeviceAccounter) AddAllocs(allocs []*Allocation) (collision bool) {
 					// map if the device is no longer being fingerprinted, is
 					// unhealthy, etc.
 					if devInst, ok := d.Devices[*devID]; ok {
-						if i, ok := devInst.Instances[instanceID]; ok {
-							// Mark that the device is in use
-							devInst.Instances[instanceID]++
-
-							if i != 0 {
-								collision = true
-							}
+						if devInst.use(instanceID, device.Shares) {
+							collision = true
 						}
 					}
 				}
@@ -114,22 +122,36 @@ func (d *DeviceAccounter) AddReserved(res *AllocatedDeviceResource) (collision b
 
 	// For each reserved instance, mark it as used
 	for _, id := range res.DeviceIDs {
-		cur, ok := devInst.Instances[id]
-		if !ok {
-			continue
-		}
-
-		// It has already been used, so mark that there is a collision
-		if cur != 0 {
+		if devInst.use(id, res.Shares) {
 			collision = true
 		}
-
-		devInst.Instances[id]++
 	}
 
 	return
 }
 
+// use marks the device instance as used by an allocation holding the given
+// number of shares, where zero shares is exclusive use of the instance. It
+// returns whether the use collides with the existing usage of the instance.
+func (i *DeviceAccounterInstance) use(id string, shares uint64) (collision bool) {
+	cur, ok := i.Instances[id]
+	if !ok {
+		return false
+	}
+	i.Instances[id]++
+
+	// Exclusive use, or shared use of an instance that can't be shared,
+	// collides with any other use
+	capacity := i.Shares[id]
+	if shares == 0 || capacity == 0 {
+		i.SharesUsed[id] += capacity
+		return cur != 0
+	}
+
+	i.SharesUsed[id] += shares
+	return i.SharesUsed[id] > capacity
+}
+
 // FreeCount returns the number of free device instances
 func (i *DeviceAccounterInstance) FreeCount() int {
 	count := 0
@@ -140,3 +162,14 @@ func (i *DeviceAccounterInstance) FreeCount() int {
 	}
 	return count
 }
+
+// FreeShares returns the number of unused shares of the given device instance.
+// Instances that can't be shared have no free shares.
+func (i *DeviceAccounterInstance) FreeShares(id string) uint64 {
+	capacity := i.Shares[id]
+	used := i.SharesUsed[id]
+	if used >= capacity {
+		return 0
+	}
+	return capacity - used
+}
@@ -229,3 +229,50 @@ func TestDeviceAccounter_AddReserved_Collision(t *testing.T) {
 	res.DeviceIDs = []string{nvidiaDev0ID}
 	require.True(d.AddReserved(res))
 }
+
+// Test that shared devices can be used by multiple allocations until their
+// shares are exhausted
+func TestDeviceAccounter_SharedDevices(t *testing.T) {
+	ci.Parallel(t)
+
+	require := require.New(t)
+	n := devNode()
+	n.NodeResources.Devices[0].Instances[0].Shares = 100
+	d := NewDeviceAccounter(n)
+	require.NotNil(d)
+
+	nvidiaDev0ID := n.NodeResources.Devices[0].Instances[0].ID
+	nvidiaDev1ID := n.NodeResources.Devices[0].Instances[1].ID
+	nvidiaDevice := d.Devices[*n.NodeResources.Devices[0].ID()]
+	require.EqualValues(100, nvidiaDevice.FreeShares(nvidiaDev0ID))
+	require.EqualValues(0, nvidiaDevice.FreeShares(nvidiaDev1ID))
+
+	// Create two allocations sharing the same device
+	a1, a2 := nvidiaAlloc(), nvidiaAlloc()
+	for _, a := range []*Allocation{a1, a2} {
+		a.AllocatedResources.Tasks["web"].Devices[0].DeviceIDs = []string{nvidiaDev0ID}
+		a.AllocatedResources.Tasks["web"].Devices[0].Shares = 40
+	}
+	require.False(d.AddAllocs([]*Allocation{a1, a2}))
+	require.Equal(2, nvidiaDevice.Instances[nvidiaDev0ID])
+	require.EqualValues(20, nvidiaDevice.FreeShares(nvidiaDev0ID))
+
+	// Reserving more shares than are free collides
+	res := nvidiaAllocatedDevice()
+	res.DeviceIDs = []string{nvidiaDev0ID}
+	res.Shares = 30
+	require.True(d.AddReserved(res))
+
+	// Exclusive use of a shared device collides
+	d = NewDeviceAccounter(n)
+	require.False(d.AddAllocs([]*Allocation{a1}))
+	res.Shares = 0
+	require.True(d.AddReserved(res))
+
+	// Shared use of a device that can't be shared collides
+	d = NewDeviceAccounter(n)
+	res.DeviceIDs = []string{nvidiaDev1ID}
+	require.False(d.AddReserved(res))
+	res.Shares = 10
+	require.True(d.AddReserved(res))
+}
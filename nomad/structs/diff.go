@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/helper/flatmap"
@@ -140,11 +141,27 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, cDiff)
 	}
 
+	// GC policy diff
+	if gcDiff := primitiveObjectDiff(j.GC, other.GC, nil, "GC", contextual); gcDiff != nil {
+		diff.Objects = append(diff.Objects, gcDiff)
+	}
+
 	// Multiregion diff
 	if mrDiff := multiregionDiff(j.Multiregion, other.Multiregion, contextual); mrDiff != nil {
 		diff.Objects = append(diff.Objects, mrDiff)
 	}
 
+	// Dependencies diff
+	depDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.DependsOn),
+		interfaceSlice(other.DependsOn),
+		nil,
+		"DependsOn",
+		contextual)
+	if depDiff != nil {
+		diff.Objects = append(diff.Objects, depDiff...)
+	}
+
 	// Check to see if there is a diff. We don't use reflect because we are
 	// filtering quite a few fields that will change on each diff.
 	if diff.Type == DiffTypeNone {
@@ -279,6 +296,20 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		}
 	}
 
+	// ExpectedDuration diff
+	if oldPrimitiveFlat != nil && newPrimitiveFlat != nil {
+		if tg.ExpectedDuration == nil {
+			oldPrimitiveFlat["ExpectedDuration"] = ""
+		} else {
+			oldPrimitiveFlat["ExpectedDuration"] = fmt.Sprintf("%d", *tg.ExpectedDuration)
+		}
+		if other.ExpectedDuration == nil {
+			newPrimitiveFlat["ExpectedDuration"] = ""
+		} else {
+			newPrimitiveFlat["ExpectedDuration"] = fmt.Sprintf("%d", *other.ExpectedDuration)
+		}
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, false)
 
@@ -328,8 +359,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Update diff
-	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	if uDiff := updateStrategyDiff(tg.Update, other.Update, contextual); uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -1797,6 +1827,39 @@ func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *Ob
 		diff.Objects = append(diff.Objects, requiredDiff)
 	}
 
+	// Retry diff
+	if retryDiff := dispatchRetryDiff(old.Retry, new.Retry, contextual); retryDiff != nil {
+		diff.Objects = append(diff.Objects, retryDiff)
+	}
+
+	return diff
+}
+
+func dispatchRetryDiff(old, new *DispatchRetryPolicy, contextual bool) *ObjectDiff {
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+
+	diff := primitiveObjectDiff(old, new, nil, "Retry", contextual)
+
+	var oldCodes, newCodes []string
+	if old != nil {
+		for _, c := range old.ExitCodes {
+			oldCodes = append(oldCodes, strconv.Itoa(c))
+		}
+	}
+	if new != nil {
+		for _, c := range new.ExitCodes {
+			newCodes = append(newCodes, strconv.Itoa(c))
+		}
+	}
+
+	if codesDiff := stringSetDiff(oldCodes, newCodes, "ExitCodes", contextual); codesDiff != nil {
+		if diff == nil {
+			diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Retry"}
+		}
+		diff.Objects = append(diff.Objects, codesDiff)
+	}
 	return diff
 }
 
@@ -2051,9 +2114,80 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiffs...)
 	}
 
+	// IO resources diff
+	if ioDiff := r.IO.Diff(other.IO, contextual); ioDiff != nil {
+		diff.Objects = append(diff.Objects, ioDiff)
+	}
+
 	return diff
 }
 
+// Diff returns a diff of two IO resources. If contextual diff is enabled,
+// non-changed fields will still be returned.
+func (r *IOResources) Diff(other *IOResources, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "IO"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(r, other) {
+		return nil
+	} else if r == nil {
+		r = &IOResources{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	} else if other == nil {
+		other = &IOResources{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(r, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(r, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Device limits diff
+	if dDiffs := ioDeviceLimitsDiffs(r.Devices, other.Devices, contextual); dDiffs != nil {
+		diff.Objects = append(diff.Objects, dDiffs...)
+	}
+
+	return diff
+}
+
+// ioDeviceLimitsDiffs diffs a set of IO device limits keyed by their path.
+// If contextual diff is enabled, non-changed fields will still be returned.
+func ioDeviceLimitsDiffs(old, new []*IODeviceLimit, contextual bool) []*ObjectDiff {
+	makeSet := func(limits []*IODeviceLimit) map[string]*IODeviceLimit {
+		limitMap := make(map[string]*IODeviceLimit, len(limits))
+		for _, l := range limits {
+			limitMap[l.Path] = l
+		}
+
+		return limitMap
+	}
+
+	oldSet := makeSet(old)
+	newSet := makeSet(new)
+
+	var diffs []*ObjectDiff
+	for k, oldV := range oldSet {
+		if diff := primitiveObjectDiff(oldV, newSet[k], nil, "Device", contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for k, newV := range newSet {
+		if _, ok := oldSet[k]; !ok {
+			if diff := primitiveObjectDiff(nil, newV, nil, "Device", contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
 // Diff returns a diff of two network resources. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectDiff {
@@ -2573,6 +2707,32 @@ Loop:
 	return diff
 }
 
+// updateStrategyDiff returns the diff of two update strategies, including
+// their deployment analysis.
+func updateStrategyDiff(old, new *UpdateStrategy, contextual bool) *ObjectDiff {
+	// COMPAT: Remove "Stagger" in 0.7.0.
+	diff := primitiveObjectDiff(old, new, []string{"Stagger"}, "Update", contextual)
+
+	var oldAnalysis, newAnalysis *DeploymentAnalysis
+	if old != nil {
+		oldAnalysis = old.Analysis
+	}
+	if new != nil {
+		newAnalysis = new.Analysis
+	}
+
+	aDiff := primitiveObjectDiff(oldAnalysis, newAnalysis, nil, "Analysis", contextual)
+	if aDiff == nil {
+		return diff
+	}
+
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+	}
+	diff.Objects = append(diff.Objects, aDiff)
+	return diff
+}
+
 // primitiveObjectSetDiff does a set difference of the old and new sets. The
 // filter parameter can be used to filter a set of primitive fields in the
 // passed structs. The name corresponds to the name of the passed objects. If
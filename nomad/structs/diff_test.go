@@ -935,6 +935,45 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// GC policy edited
+			Old: &Job{
+				GC: &JobGCPolicy{
+					KeepSuccessful: 5,
+					KeepFailed:     10,
+				},
+			},
+			New: &Job{
+				GC: &JobGCPolicy{
+					KeepSuccessful: 5,
+					KeepFailed:     20,
+					MaxAge:         time.Hour,
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "GC",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "KeepFailed",
+								Old:  "10",
+								New:  "20",
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxAge",
+								Old:  "0",
+								New:  "3600000000000",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Parameterized Job added
 			Old: &Job{},
@@ -952,6 +991,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxConcurrent",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Payload",
@@ -1006,6 +1051,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Payload",
@@ -1139,6 +1190,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
@@ -1176,6 +1233,68 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Parameterized Job retry edited
+			Old: &Job{
+				ParameterizedJob: &ParameterizedJobConfig{
+					Payload: DispatchPayloadOptional,
+					Retry: &DispatchRetryPolicy{
+						Attempts:      1,
+						Delay:         10 * time.Second,
+						DelayFunction: "constant",
+						ExitCodes:     []int{1},
+					},
+				},
+			},
+			New: &Job{
+				ParameterizedJob: &ParameterizedJobConfig{
+					Payload: DispatchPayloadOptional,
+					Retry: &DispatchRetryPolicy{
+						Attempts:      3,
+						Delay:         10 * time.Second,
+						DelayFunction: "constant",
+						ExitCodes:     []int{1, 75},
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "ParameterizedJob",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Retry",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Attempts",
+										Old:  "1",
+										New:  "3",
+									},
+								},
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "ExitCodes",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeAdded,
+												Name: "ExitCodes",
+												Old:  "",
+												New:  "75",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 
 		{
 			// Multiregion: region added
@@ -2486,9 +2605,10 @@ func TestTaskGroupDiff(t *testing.T) {
 			Old:      &TaskGroup{},
 			New: &TaskGroup{
 				EphemeralDisk: &EphemeralDisk{
-					Migrate: true,
-					Sticky:  true,
-					SizeMB:  100,
+					Migrate:    true,
+					Checkpoint: true,
+					Sticky:     true,
+					SizeMB:     100,
 				},
 			},
 			Expected: &TaskGroupDiff{
@@ -2498,6 +2618,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Checkpoint",
+								Old:  "",
+								New:  "true",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Migrate",
@@ -2525,9 +2651,10 @@ func TestTaskGroupDiff(t *testing.T) {
 			TestCase: "EphemeralDisk deleted",
 			Old: &TaskGroup{
 				EphemeralDisk: &EphemeralDisk{
-					Migrate: true,
-					Sticky:  true,
-					SizeMB:  100,
+					Migrate:    true,
+					Checkpoint: true,
+					Sticky:     true,
+					SizeMB:     100,
 				},
 			},
 			New: &TaskGroup{},
@@ -2538,6 +2665,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Checkpoint",
+								Old:  "true",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Migrate",
@@ -2565,9 +2698,10 @@ func TestTaskGroupDiff(t *testing.T) {
 			TestCase: "EphemeralDisk edited",
 			Old: &TaskGroup{
 				EphemeralDisk: &EphemeralDisk{
-					Migrate: true,
-					Sticky:  true,
-					SizeMB:  150,
+					Migrate:    true,
+					Checkpoint: true,
+					Sticky:     true,
+					SizeMB:     150,
 				},
 			},
 			New: &TaskGroup{
@@ -2584,6 +2718,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Checkpoint",
+								Old:  "true",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Migrate",
@@ -2632,6 +2772,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Checkpoint",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Migrate",
@@ -4004,6 +4150,28 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "ExpectedDuration added",
+			Old: &TaskGroup{
+				Name: "foo",
+			},
+			New: &TaskGroup{
+				Name:             "foo",
+				ExpectedDuration: helper.TimeToPtr(time.Hour),
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Name: "foo",
+				Fields: []*FieldDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "ExpectedDuration",
+						Old:  "",
+						New:  "3600000000000",
+					},
+				},
+			},
+		},
 	}
 
 	for i, c := range cases {
@@ -4829,6 +4997,130 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Resources edited io",
+			Old: &Task{
+				Resources: &Resources{
+					CPU:      100,
+					MemoryMB: 100,
+					IO: &IOResources{
+						Weight: 100,
+						Devices: []*IODeviceLimit{
+							{Path: "/dev/sda", ReadBps: 1024},
+						},
+					},
+				},
+			},
+			New: &Task{
+				Resources: &Resources{
+					CPU:      100,
+					MemoryMB: 100,
+					IO: &IOResources{
+						Weight: 200,
+						Devices: []*IODeviceLimit{
+							{Path: "/dev/sdb", WriteIOPS: 10},
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Resources",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "IO",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Weight",
+										Old:  "100",
+										New:  "200",
+									},
+								},
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Device",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeAdded,
+												Name: "Path",
+												Old:  "",
+												New:  "/dev/sdb",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "ReadBps",
+												Old:  "",
+												New:  "0",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "ReadIOPS",
+												Old:  "",
+												New:  "0",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "WriteBps",
+												Old:  "",
+												New:  "0",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "WriteIOPS",
+												Old:  "",
+												New:  "10",
+											},
+										},
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Device",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeDeleted,
+												Name: "Path",
+												Old:  "/dev/sda",
+												New:  "",
+											},
+											{
+												Type: DiffTypeDeleted,
+												Name: "ReadBps",
+												Old:  "1024",
+												New:  "",
+											},
+											{
+												Type: DiffTypeDeleted,
+												Name: "ReadIOPS",
+												Old:  "0",
+												New:  "",
+											},
+											{
+												Type: DiffTypeDeleted,
+												Name: "WriteBps",
+												Old:  "0",
+												New:  "",
+											},
+											{
+												Type: DiffTypeDeleted,
+												Name: "WriteIOPS",
+												Old:  "0",
+												New:  "",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:       "Resources edited memory_max with context",
 			Contextual: true,
@@ -5143,6 +5435,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "",
 										New:  "bam",
 									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Shares",
+										Old:  "",
+										New:  "0",
+									},
 								},
 							},
 							{
@@ -5161,6 +5459,12 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "baz",
 										New:  "",
 									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Shares",
+										Old:  "0",
+										New:  "",
+									},
 								},
 							},
 						},
@@ -5274,6 +5578,18 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "bar",
 										New:  "bar",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "OnUnhealthy",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "Shares",
+										Old:  "0",
+										New:  "0",
+									},
 								},
 							},
 							{
@@ -5292,6 +5608,18 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "",
 										New:  "bam",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "OnUnhealthy",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Shares",
+										Old:  "",
+										New:  "0",
+									},
 								},
 							},
 							{
@@ -5310,6 +5638,18 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "baz",
 										New:  "",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "OnUnhealthy",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Shares",
+										Old:  "0",
+										New:  "",
+									},
 								},
 							},
 						},
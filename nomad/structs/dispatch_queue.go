@@ -0,0 +1,51 @@
+package structs
+
+import "sort"
+
+// JobDispatchQueue is the dispatch queue of a parameterized job with
+// MaxConcurrent set. Dispatched jobs wait in the queue until fewer than
+// MaxConcurrent dispatched jobs are scheduled.
+type JobDispatchQueue struct {
+	// JobID and Namespace identify the parameterized job.
+	JobID     string
+	Namespace string
+
+	// MaxConcurrent is the parameterized job's concurrency limit.
+	MaxConcurrent int
+
+	// Running is the number of dispatched jobs that have been released from
+	// the queue and are not yet complete.
+	Running int
+
+	// Queued is the set of dispatched jobs waiting in the queue, in the order
+	// they are released.
+	Queued []*JobDispatchQueueEntry
+}
+
+// JobDispatchQueueEntry is a dispatched job waiting in the dispatch queue.
+type JobDispatchQueueEntry struct {
+	JobID      string
+	EvalID     string
+	Priority   int
+	SubmitTime int64
+
+	// CreateIndex is the index the evaluation was queued at.
+	CreateIndex uint64
+}
+
+// SortDispatchQueue sorts the entries in the order they are released: by
+// priority with the highest first, and then in the order they were queued.
+func SortDispatchQueue(entries []*JobDispatchQueueEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Priority != entries[j].Priority {
+			return entries[i].Priority > entries[j].Priority
+		}
+		return entries[i].CreateIndex < entries[j].CreateIndex
+	})
+}
+
+// IsDispatchQueued returns whether the job is a dispatched job that waits in
+// its parameterized job's dispatch queue before it is scheduled.
+func (j *Job) IsDispatchQueued() bool {
+	return j.Dispatched && j.ParameterizedJob != nil && j.ParameterizedJob.MaxConcurrent > 0
+}
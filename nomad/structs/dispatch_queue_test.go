@@ -0,0 +1,52 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortDispatchQueue(t *testing.T) {
+	ci.Parallel(t)
+
+	entries := []*JobDispatchQueueEntry{
+		{JobID: "a", Priority: 50, CreateIndex: 10},
+		{JobID: "b", Priority: 70, CreateIndex: 12},
+		{JobID: "c", Priority: 50, CreateIndex: 5},
+		{JobID: "d", Priority: 70, CreateIndex: 11},
+	}
+	SortDispatchQueue(entries)
+
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.JobID)
+	}
+	require.Equal(t, []string{"d", "b", "c", "a"}, ids)
+}
+
+func TestJob_IsDispatchQueued(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	j.ParameterizedJob = &ParameterizedJobConfig{MaxConcurrent: 2}
+	require.False(t, j.IsDispatchQueued())
+	require.Equal(t, EvalStatusPending, j.RegisterEvalStatus())
+
+	j.Dispatched = true
+	require.True(t, j.IsDispatchQueued())
+	require.Equal(t, EvalStatusWaiting, j.RegisterEvalStatus())
+
+	j.ParameterizedJob.MaxConcurrent = 0
+	require.False(t, j.IsDispatchQueued())
+}
+
+func TestParameterizedJobConfig_Validate_MaxConcurrent(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &ParameterizedJobConfig{Payload: DispatchPayloadOptional, MaxConcurrent: -1}
+	requireErrors(t, d.Validate(), "MaxConcurrent cannot be negative")
+
+	d.MaxConcurrent = 5
+	require.NoError(t, d.Validate())
+}
@@ -0,0 +1,188 @@
+package structs
+
+import (
+	"fmt"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+var (
+	// DefaultDispatchRetryPolicy is the retry policy defaults of a
+	// parameterized job's retry block.
+	DefaultDispatchRetryPolicy = DispatchRetryPolicy{
+		Delay:         30 * time.Second,
+		DelayFunction: "exponential",
+		MaxDelay:      1 * time.Hour,
+	}
+)
+
+// DispatchRetryPolicy configures how a dispatched job that failed is
+// dispatched again. Each retry is a new dispatched job with the same payload,
+// meta data and idempotency token as the job that failed.
+type DispatchRetryPolicy struct {
+	// Attempts is the maximum number of times a failed dispatched job is
+	// retried.
+	Attempts int
+
+	// Delay is the duration to wait before the first retry. The delay
+	// function determines how subsequent retries are delayed.
+	Delay time.Duration
+
+	// DelayFunction determines how the delay progressively changes on
+	// subsequent retries. Valid values are "exponential", "constant", and
+	// "fibonacci".
+	DelayFunction string
+
+	// MaxDelay is an upper bound on the delay.
+	MaxDelay time.Duration
+
+	// ExitCodes is the set of task exit codes that are retried. If empty,
+	// any failure is retried.
+	ExitCodes []int
+}
+
+func (r *DispatchRetryPolicy) Copy() *DispatchRetryPolicy {
+	if r == nil {
+		return nil
+	}
+	nr := new(DispatchRetryPolicy)
+	*nr = *r
+	nr.ExitCodes = helper.CopySliceInt(r.ExitCodes)
+	return nr
+}
+
+func (r *DispatchRetryPolicy) Canonicalize() {
+	if r.Delay == 0 {
+		r.Delay = DefaultDispatchRetryPolicy.Delay
+	}
+	if r.DelayFunction == "" {
+		r.DelayFunction = DefaultDispatchRetryPolicy.DelayFunction
+	}
+	if r.MaxDelay == 0 {
+		r.MaxDelay = DefaultDispatchRetryPolicy.MaxDelay
+	}
+}
+
+func (r *DispatchRetryPolicy) Validate() error {
+	var mErr multierror.Error
+	if r.Attempts < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Attempts cannot be negative (got %d)", r.Attempts))
+	}
+	if r.Delay < ReschedulePolicyMinDelay {
+		_ = multierror.Append(&mErr, fmt.Errorf("Delay cannot be less than %v (got %v)", ReschedulePolicyMinDelay, r.Delay))
+	}
+	if !isValidDelayFunction(r.DelayFunction) {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid delay function %q, must be one of %q", r.DelayFunction, RescheduleDelayFunctions))
+	}
+	if r.DelayFunction != "constant" && r.MaxDelay < r.Delay {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max Delay cannot be less than Delay %v (got %v)", r.Delay, r.MaxDelay))
+	}
+	for _, code := range r.ExitCodes {
+		if code == 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Exit code 0 is not a failure and can not be retried"))
+			break
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NextDelay returns the delay before the given retry, starting at 1.
+func (r *DispatchRetryPolicy) NextDelay(retry int) time.Duration {
+	if r.DelayFunction == "constant" {
+		return r.Delay
+	}
+
+	prev, delay := time.Duration(0), r.Delay
+	for i := 1; i < retry && delay < r.MaxDelay; i++ {
+		switch r.DelayFunction {
+		case "exponential":
+			delay *= 2
+		case "fibonacci":
+			prev, delay = delay, prev+delay
+		}
+	}
+
+	if delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	return delay
+}
+
+// RetryableExitCode returns whether a task that exited with the given exit
+// code may be retried.
+func (r *DispatchRetryPolicy) RetryableExitCode(code int) bool {
+	if len(r.ExitCodes) == 0 {
+		return true
+	}
+	for _, c := range r.ExitCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// DispatchRetryTracker tracks the previous attempts of a dispatched job that
+// was retried.
+type DispatchRetryTracker struct {
+	Events []*DispatchRetryEvent
+
+	// RetryJobID is the ID of the dispatched job retrying this one. It's set
+	// once the job is retried so it isn't retried again after the retry is
+	// garbage collected.
+	RetryJobID string
+}
+
+func (rt *DispatchRetryTracker) Copy() *DispatchRetryTracker {
+	if rt == nil {
+		return nil
+	}
+	nt := &DispatchRetryTracker{RetryJobID: rt.RetryJobID}
+	nt.Events = make([]*DispatchRetryEvent, 0, len(rt.Events))
+	for _, e := range rt.Events {
+		nt.Events = append(nt.Events, e.Copy())
+	}
+	return nt
+}
+
+// DispatchRetryEvent is used to keep track of a previous attempt of a
+// dispatched job.
+type DispatchRetryEvent struct {
+	// RetryTime is the timestamp of the retry
+	RetryTime int64
+
+	// PrevJobID is the ID of the dispatched job that failed
+	PrevJobID string
+
+	// Delay is the retry delay associated with the attempt
+	Delay time.Duration
+}
+
+func (re *DispatchRetryEvent) Copy() *DispatchRetryEvent {
+	if re == nil {
+		return nil
+	}
+	copy := new(DispatchRetryEvent)
+	*copy = *re
+	return copy
+}
+
+// DispatchAttempt returns the attempt number of a dispatched job, starting
+// at 1 for the job created by the dispatch request.
+func (j *Job) DispatchAttempt() int {
+	if j.DispatchRetryTracker == nil {
+		return 1
+	}
+	return len(j.DispatchRetryTracker.Events) + 1
+}
+
+// DispatchRetryPolicy returns the retry policy of a dispatched job or nil if
+// the job is not retried.
+func (j *Job) DispatchRetryPolicy() *DispatchRetryPolicy {
+	if !j.Dispatched || j.ParameterizedJob == nil {
+		return nil
+	}
+	return j.ParameterizedJob.Retry
+}
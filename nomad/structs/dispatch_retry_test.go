@@ -0,0 +1,92 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchRetryPolicy_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &DispatchRetryPolicy{Attempts: 2}
+	p.Canonicalize()
+	require.NoError(t, p.Validate())
+
+	p = &DispatchRetryPolicy{
+		Attempts:      -1,
+		Delay:         time.Second,
+		DelayFunction: "linear",
+		MaxDelay:      time.Millisecond,
+		ExitCodes:     []int{1, 0},
+	}
+	requireErrors(t, p.Validate(),
+		"Attempts cannot be negative",
+		"Delay cannot be less than",
+		"Invalid delay function",
+		"Max Delay cannot be less than Delay",
+		"Exit code 0",
+	)
+}
+
+func TestDispatchRetryPolicy_NextDelay(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		fn       string
+		expected []time.Duration
+	}{
+		{"constant", []time.Duration{10, 10, 10, 10, 10, 10}},
+		{"exponential", []time.Duration{10, 20, 40, 60, 60, 60}},
+		{"fibonacci", []time.Duration{10, 10, 20, 30, 50, 60}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fn, func(t *testing.T) {
+			p := &DispatchRetryPolicy{
+				Delay:         10 * time.Second,
+				DelayFunction: tc.fn,
+				MaxDelay:      60 * time.Second,
+			}
+			for i, exp := range tc.expected {
+				require.Equal(t, exp*time.Second, p.NextDelay(i+1), "retry %d", i+1)
+			}
+		})
+	}
+}
+
+func TestDispatchRetryPolicy_RetryableExitCode(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &DispatchRetryPolicy{}
+	require.True(t, p.RetryableExitCode(1))
+	require.True(t, p.RetryableExitCode(137))
+
+	p.ExitCodes = []int{75}
+	require.True(t, p.RetryableExitCode(75))
+	require.False(t, p.RetryableExitCode(1))
+}
+
+func TestJob_DispatchAttempt(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	require.Equal(t, 1, j.DispatchAttempt())
+	require.Nil(t, j.DispatchRetryPolicy())
+
+	j.ParameterizedJob = &ParameterizedJobConfig{Retry: &DispatchRetryPolicy{Attempts: 1}}
+	require.Nil(t, j.DispatchRetryPolicy())
+
+	j.Dispatched = true
+	j.DispatchRetryTracker = &DispatchRetryTracker{
+		Events: []*DispatchRetryEvent{{PrevJobID: "a"}, {PrevJobID: "b"}},
+	}
+	require.Equal(t, 3, j.DispatchAttempt())
+	require.NotNil(t, j.DispatchRetryPolicy())
+
+	c := j.Copy()
+	c.DispatchRetryTracker.Events[0].PrevJobID = "c"
+	require.Equal(t, "a", j.DispatchRetryTracker.Events[0].PrevJobID)
+}
@@ -30,6 +30,7 @@ const (
 	TypeNodeDeregistration            = "NodeDeregistration"
 	TypeNodeEligibilityUpdate         = "NodeEligibility"
 	TypeNodeDrain                     = "NodeDrain"
+	TypeNodeMaintenance               = "NodeMaintenance"
 	TypeNodeEvent                     = "NodeStreamEvent"
 	TypeDeploymentUpdate              = "DeploymentStatusUpdate"
 	TypeDeploymentPromotion           = "DeploymentPromotion"
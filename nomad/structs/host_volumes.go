@@ -0,0 +1,133 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+// validHostVolumeName matches the names of dynamic host volumes and of their
+// plugins, which are executables in the client's host_volume_plugin_dir
+var validHostVolumeName = regexp.MustCompile("^[a-zA-Z0-9-_.]{1,128}$")
+
+// HostVolume is a host volume created on demand by a Nomad client. Once
+// created, the client fingerprints it into Node.HostVolumes under its name
+// so that jobs can use it like a host volume from the client configuration.
+type HostVolume struct {
+	ID        string
+	Name      string
+	Namespace string
+
+	// PluginID is the name of the plugin the client runs to provision the
+	// volume. The client creates a plain directory if it's empty.
+	PluginID string
+
+	// NodeID is the node the volume is created on. If it's empty when
+	// creating the volume, a node is picked among the ready nodes matching
+	// the Constraints.
+	NodeID      string
+	Constraints []*Constraint
+
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+	Parameters                map[string]string
+
+	// HostPath and CapacityBytes are set by the client once the volume is
+	// created
+	HostPath      string
+	CapacityBytes int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Validate returns an error if the host volume request is invalid
+func (v *HostVolume) Validate() error {
+	var mErr multierror.Error
+
+	if !validHostVolumeName.MatchString(v.Name) {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("invalid name %q. Must match regex %s", v.Name, validHostVolumeName))
+	}
+	if v.PluginID != "" && !validHostVolumeName.MatchString(v.PluginID) {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("invalid plugin_id %q. Must match regex %s", v.PluginID, validHostVolumeName))
+	}
+	if v.RequestedCapacityMinBytes < 0 || v.RequestedCapacityMaxBytes < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("capacity cannot be negative"))
+	}
+	if v.PluginID == "" && (v.RequestedCapacityMinBytes != 0 || v.RequestedCapacityMaxBytes != 0) {
+		mErr.Errors = append(mErr.Errors, errors.New("capacity requires a plugin_id"))
+	}
+	if v.RequestedCapacityMaxBytes != 0 &&
+		v.RequestedCapacityMaxBytes < v.RequestedCapacityMinBytes {
+		mErr.Errors = append(mErr.Errors,
+			errors.New("capacity_max cannot be less than capacity_min"))
+	}
+	for _, c := range v.Constraints {
+		if err := c.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("constraint %s: %v", c, err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the host volume. It handles nil objects.
+func (v *HostVolume) Copy() *HostVolume {
+	if v == nil {
+		return nil
+	}
+
+	nv := new(HostVolume)
+	*nv = *v
+	nv.Constraints = CopySliceConstraints(v.Constraints)
+	nv.Parameters = helper.CopyMapStringString(v.Parameters)
+	return nv
+}
+
+// HostVolumeCreateRequest is used to create a host volume. It's also the
+// raft request that records the volume once the client has created it.
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+	WriteRequest
+}
+
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+	WriteMeta
+}
+
+// HostVolumeDeleteRequest is used to delete a host volume, and is also the
+// raft request that removes it from the state store.
+type HostVolumeDeleteRequest struct {
+	VolumeID string
+	WriteRequest
+}
+
+type HostVolumeDeleteResponse struct {
+	WriteMeta
+}
+
+type HostVolumeGetRequest struct {
+	ID string
+	QueryOptions
+}
+
+type HostVolumeGetResponse struct {
+	Volume *HostVolume
+	QueryMeta
+}
+
+type HostVolumeListRequest struct {
+	NodeID string
+	QueryOptions
+}
+
+type HostVolumeListResponse struct {
+	Volumes []*HostVolume
+	QueryMeta
+}
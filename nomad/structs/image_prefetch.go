@@ -0,0 +1,176 @@
+package structs
+
+import (
+	"fmt"
+
+	"github.com/docker/distribution/reference"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// ImagePrefetchUpsertRPCMethod is the RPC method for upserting image
+	// prefetches into Nomad state.
+	//
+	// Args: ImagePrefetchUpsertRequest
+	// Reply: GenericResponse
+	ImagePrefetchUpsertRPCMethod = "ImagePrefetch.UpsertImagePrefetches"
+
+	// ImagePrefetchDeleteRPCMethod is the RPC method for deleting image
+	// prefetches by name.
+	//
+	// Args: ImagePrefetchDeleteRequest
+	// Reply: GenericResponse
+	ImagePrefetchDeleteRPCMethod = "ImagePrefetch.DeleteImagePrefetches"
+
+	// ImagePrefetchListRPCMethod is the RPC method for listing image
+	// prefetches.
+	//
+	// Args: ImagePrefetchListRequest
+	// Reply: ImagePrefetchListResponse
+	ImagePrefetchListRPCMethod = "ImagePrefetch.ListImagePrefetches"
+
+	// ImagePrefetchGetRPCMethod is the RPC method for reading a single image
+	// prefetch by name.
+	//
+	// Args: ImagePrefetchSpecificRequest
+	// Reply: SingleImagePrefetchResponse
+	ImagePrefetchGetRPCMethod = "ImagePrefetch.GetImagePrefetch"
+
+	// ImagePrefetchListForNodeRPCMethod is the RPC method used by clients to
+	// watch the image prefetches matching their node.
+	//
+	// Args: NodeSpecificRequest
+	// Reply: ImagePrefetchListResponse
+	ImagePrefetchListForNodeRPCMethod = "ImagePrefetch.ListForNode"
+)
+
+// ImagePrefetch lists images that task drivers should pull onto the nodes
+// matching its selectors before any allocation needs them. Drivers that
+// support prefetching fingerprint the images they hold, which the scheduler
+// uses as a soft preference when placing tasks using those images.
+type ImagePrefetch struct {
+	// Name uniquely identifies the image prefetch
+	Name string
+
+	// Driver is the name of the task driver pulling the images
+	Driver string
+
+	// Images are the images to pull, in the format understood by the driver
+	Images []string
+
+	// Datacenters and NodeClass select the nodes pulling the images. Empty
+	// selectors match every node.
+	Datacenters []string
+	NodeClass   string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Validate returns an error if the image prefetch is invalid
+func (p *ImagePrefetch) Validate() error {
+	var mErr multierror.Error
+
+	if !validNamespaceName.MatchString(p.Name) {
+		err := fmt.Errorf("invalid name %q. Must match regex %s", p.Name, validNamespaceName)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if p.Driver == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("missing driver"))
+	}
+	if len(p.Images) == 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("must list at least one image"))
+	}
+	for i, image := range p.Images {
+		if image == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("image %d is empty", i))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the image prefetch. It handles nil objects.
+func (p *ImagePrefetch) Copy() *ImagePrefetch {
+	if p == nil {
+		return nil
+	}
+
+	np := new(ImagePrefetch)
+	*np = *p
+	np.Images = helper.CopySliceString(p.Images)
+	np.Datacenters = helper.CopySliceString(p.Datacenters)
+	return np
+}
+
+// MatchesNode returns whether the node should pull the prefetched images
+func (p *ImagePrefetch) MatchesNode(node *Node) bool {
+	if p.NodeClass != "" && p.NodeClass != node.NodeClass {
+		return false
+	}
+	if len(p.Datacenters) != 0 && !helper.SliceStringContains(p.Datacenters, node.Datacenter) {
+		return false
+	}
+	return true
+}
+
+// ImagePrefetchAttribute returns the node attribute a driver fingerprints
+// for each prefetched image it holds. The image reference is normalized so
+// that references to the same image, such as "redis" and
+// "docker.io/library/redis:latest", map to the same attribute.
+func ImagePrefetchAttribute(driver, image string) string {
+	return ImagePrefetchAttributePrefix(driver) + normalizeImageReference(image)
+}
+
+// ImagePrefetchAttributePrefix returns the prefix of the node attributes of
+// the prefetched images held by a driver.
+func ImagePrefetchAttributePrefix(driver string) string {
+	return fmt.Sprintf("driver.%s.image.", driver)
+}
+
+// normalizeImageReference returns the short form of an image reference, with
+// the default tag added if it has neither a tag nor a digest. References that
+// can't be parsed are returned unchanged.
+func normalizeImageReference(image string) string {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return image
+	}
+	return reference.FamiliarString(reference.TagNameOnly(named))
+}
+
+// ImagePrefetchUpsertRequest is used to upsert a set of image prefetches
+type ImagePrefetchUpsertRequest struct {
+	ImagePrefetches []*ImagePrefetch
+	WriteRequest
+}
+
+// ImagePrefetchDeleteRequest is used to delete a set of image prefetches
+type ImagePrefetchDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// ImagePrefetchListRequest is used to request a list of image prefetches
+type ImagePrefetchListRequest struct {
+	QueryOptions
+}
+
+// ImagePrefetchListResponse is used for a list request
+type ImagePrefetchListResponse struct {
+	ImagePrefetches []*ImagePrefetch
+	QueryMeta
+}
+
+// ImagePrefetchSpecificRequest is used to query a specific image prefetch
+type ImagePrefetchSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleImagePrefetchResponse is used to return a single image prefetch
+type SingleImagePrefetchResponse struct {
+	ImagePrefetch *ImagePrefetch
+	QueryMeta
+}
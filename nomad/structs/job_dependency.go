@@ -0,0 +1,150 @@
+package structs
+
+import (
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// JobDependencyConditionSuccess is satisfied when the upstream job
+	// completes without any failed or lost allocations.
+	JobDependencyConditionSuccess = "success"
+
+	// JobDependencyConditionFailure is satisfied when the upstream job
+	// completes with failed or lost allocations, or is stopped.
+	JobDependencyConditionFailure = "failure"
+
+	// JobDependencyConditionComplete is satisfied when the upstream job
+	// completes regardless of its outcome.
+	JobDependencyConditionComplete = "complete"
+)
+
+// JobDependency declares that a batch job may not be scheduled until another
+// job in the same namespace has completed with the given condition. The
+// upstream job may be a regular batch job or a dispatched or periodic child.
+type JobDependency struct {
+	// JobID is the ID of the upstream job.
+	JobID string
+
+	// Condition is the outcome of the upstream job required for the
+	// dependency to be satisfied.
+	Condition string
+}
+
+func (d *JobDependency) Copy() *JobDependency {
+	if d == nil {
+		return nil
+	}
+
+	nd := new(JobDependency)
+	*nd = *d
+	return nd
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Condition == "" {
+		d.Condition = JobDependencyConditionSuccess
+	}
+}
+
+func (d *JobDependency) Validate() error {
+	var mErr multierror.Error
+	if d.JobID == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Missing upstream job ID"))
+	}
+
+	switch d.Condition {
+	case JobDependencyConditionSuccess, JobDependencyConditionFailure, JobDependencyConditionComplete:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid condition %q", d.Condition))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Evaluate determines whether the dependency is satisfied given the current
+// upstream job and its summary. The done return value is true once the
+// upstream job has completed, at which point the dependency will never change
+// its outcome.
+func (d *JobDependency) Evaluate(upstream *Job, summary *JobSummary) (satisfied, done bool) {
+	if upstream == nil || upstream.Status != JobStatusDead {
+		return false, false
+	}
+
+	succeeded := !upstream.Stopped() && summary.Succeeded()
+
+	switch d.Condition {
+	case JobDependencyConditionSuccess:
+		return succeeded, true
+	case JobDependencyConditionFailure:
+		return !succeeded, true
+	default:
+		return true, true
+	}
+}
+
+func (d *JobDependency) String() string {
+	return fmt.Sprintf("%s (%s)", d.JobID, d.Condition)
+}
+
+// CopySliceJobDependencies returns a deep copy of the given dependencies.
+func CopySliceJobDependencies(s []*JobDependency) []*JobDependency {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*JobDependency, l)
+	for i, d := range s {
+		c[i] = d.Copy()
+	}
+	return c
+}
+
+// HasDependencies returns whether the job must wait for upstream jobs before
+// it is scheduled.
+func (j *Job) HasDependencies() bool {
+	return len(j.DependsOn) != 0
+}
+
+// RegisterEvalStatus returns the status of the evaluation created when the
+// job is registered, dispatched or launched. Evaluations of jobs with
+// dependencies wait for the upstream jobs before they are scheduled, and
+// evaluations of queued dispatched jobs wait for a free dispatch slot.
+func (j *Job) RegisterEvalStatus() string {
+	if j.HasDependencies() || j.IsDispatchQueued() {
+		return EvalStatusWaiting
+	}
+	return EvalStatusPending
+}
+
+// validateDependencies validates the job's dependencies. Whether the upstream
+// jobs exist and form an acyclic graph is checked at registration time.
+func (j *Job) validateDependencies() error {
+	if !j.HasDependencies() {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if j.Type != JobTypeBatch {
+		_ = multierror.Append(&mErr, fmt.Errorf("Dependencies can only be used with %q scheduler", JobTypeBatch))
+	}
+
+	seen := make(map[string]struct{}, len(j.DependsOn))
+	for idx, d := range j.DependsOn {
+		if err := d.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("Dependency %d:", idx+1)))
+			continue
+		}
+		if d.JobID == j.ID {
+			_ = multierror.Append(&mErr, fmt.Errorf("Dependency %d: job can not depend on itself", idx+1))
+		}
+		if _, ok := seen[d.JobID]; ok {
+			_ = multierror.Append(&mErr, fmt.Errorf("Dependency %d: duplicate dependency on job %q", idx+1, d.JobID))
+		}
+		seen[d.JobID] = struct{}{}
+	}
+
+	return mErr.ErrorOrNil()
+}
@@ -0,0 +1,87 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_Validate_Dependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	j.Type = JobTypeBatch
+	j.DependsOn = []*JobDependency{
+		{JobID: "upstream", Condition: JobDependencyConditionSuccess},
+	}
+	require.NoError(t, j.validateDependencies())
+
+	j.Type = JobTypeService
+	requireErrors(t, j.validateDependencies(), "can only be used with")
+
+	j.Type = JobTypeBatch
+	j.DependsOn = []*JobDependency{
+		{JobID: j.ID, Condition: JobDependencyConditionSuccess},
+		{JobID: "upstream", Condition: JobDependencyConditionComplete},
+		{JobID: "upstream", Condition: JobDependencyConditionFailure},
+		{JobID: "", Condition: "bogus"},
+	}
+	requireErrors(t, j.validateDependencies(),
+		"can not depend on itself",
+		"duplicate dependency",
+		"Missing upstream job ID",
+		"Invalid condition",
+	)
+}
+
+func TestJob_RegisterEvalStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	require.Equal(t, EvalStatusPending, j.RegisterEvalStatus())
+
+	j.DependsOn = []*JobDependency{{JobID: "upstream"}}
+	require.Equal(t, EvalStatusWaiting, j.RegisterEvalStatus())
+}
+
+func TestJobDependency_Evaluate(t *testing.T) {
+	ci.Parallel(t)
+
+	dead := &Job{Status: JobStatusDead}
+	running := &Job{Status: JobStatusRunning}
+	stopped := &Job{Status: JobStatusDead, Stop: true}
+
+	succeeded := &JobSummary{Summary: map[string]TaskGroupSummary{"web": {Complete: 1}}}
+	failed := &JobSummary{Summary: map[string]TaskGroupSummary{"web": {Complete: 1, Failed: 1}}}
+	empty := &JobSummary{Summary: map[string]TaskGroupSummary{"web": {}}}
+
+	cases := []struct {
+		name      string
+		condition string
+		upstream  *Job
+		summary   *JobSummary
+		satisfied bool
+		done      bool
+	}{
+		{"missing", JobDependencyConditionComplete, nil, nil, false, false},
+		{"running", JobDependencyConditionComplete, running, succeeded, false, false},
+		{"success/succeeded", JobDependencyConditionSuccess, dead, succeeded, true, true},
+		{"success/failed", JobDependencyConditionSuccess, dead, failed, false, true},
+		{"success/no allocs", JobDependencyConditionSuccess, dead, empty, false, true},
+		{"success/stopped", JobDependencyConditionSuccess, stopped, succeeded, false, true},
+		{"failure/succeeded", JobDependencyConditionFailure, dead, succeeded, false, true},
+		{"failure/failed", JobDependencyConditionFailure, dead, failed, true, true},
+		{"failure/stopped", JobDependencyConditionFailure, stopped, succeeded, true, true},
+		{"complete/failed", JobDependencyConditionComplete, dead, failed, true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &JobDependency{JobID: "upstream", Condition: tc.condition}
+			satisfied, done := d.Evaluate(tc.upstream, tc.summary)
+			require.Equal(t, tc.satisfied, satisfied)
+			require.Equal(t, tc.done, done)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package structs
+
+import (
+	"fmt"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// JobGCPolicy configures how the dead children of a periodic or
+// parameterized job are garbage collected. Children of a job with a policy
+// are garbage collected by the core scheduler according to the policy
+// instead of the job_gc_threshold of the servers. Children the policy limits
+// neither by count nor by age fall back to the threshold.
+type JobGCPolicy struct {
+	// KeepSuccessful is the number of most recent successful children to
+	// keep. Zero means successful children are not limited by count.
+	KeepSuccessful int
+
+	// KeepFailed is the number of most recent failed children to keep. Zero
+	// means failed children are not limited by count.
+	KeepFailed int
+
+	// MaxAge is the duration after which a dead child is garbage collected
+	// regardless of the counts. Zero means children are not limited by age.
+	MaxAge time.Duration
+}
+
+func (p *JobGCPolicy) Copy() *JobGCPolicy {
+	if p == nil {
+		return nil
+	}
+	np := new(JobGCPolicy)
+	*np = *p
+	return np
+}
+
+func (p *JobGCPolicy) Validate() error {
+	var mErr multierror.Error
+	if p.KeepSuccessful < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("KeepSuccessful cannot be negative (got %d)", p.KeepSuccessful))
+	}
+	if p.KeepFailed < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("KeepFailed cannot be negative (got %d)", p.KeepFailed))
+	}
+	if p.MaxAge < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("MaxAge cannot be negative (got %v)", p.MaxAge))
+	}
+	if p.KeepSuccessful == 0 && p.KeepFailed == 0 && p.MaxAge == 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("GC policy must set at least one of KeepSuccessful, KeepFailed or MaxAge"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateGCPolicy validates the job's GC policy.
+func (j *Job) validateGCPolicy() error {
+	if j.GC == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if !j.IsPeriodic() && !j.IsParameterized() {
+		_ = multierror.Append(&mErr, fmt.Errorf("GC policy can only be used with periodic or parameterized jobs"))
+	}
+	if err := j.GC.Validate(); err != nil {
+		_ = multierror.Append(&mErr, err)
+	}
+	return mErr.ErrorOrNil()
+}
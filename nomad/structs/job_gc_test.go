@@ -0,0 +1,90 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobGCPolicy_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		policy *JobGCPolicy
+		errors []string
+	}{
+		{
+			name:   "valid",
+			policy: &JobGCPolicy{KeepSuccessful: 5, KeepFailed: 10, MaxAge: time.Hour},
+		},
+		{
+			name:   "only max age",
+			policy: &JobGCPolicy{MaxAge: time.Hour},
+		},
+		{
+			name:   "negative",
+			policy: &JobGCPolicy{KeepSuccessful: -1, KeepFailed: -2, MaxAge: -time.Hour},
+			errors: []string{
+				"KeepSuccessful cannot be negative",
+				"KeepFailed cannot be negative",
+				"MaxAge cannot be negative",
+			},
+		},
+		{
+			name:   "empty",
+			policy: &JobGCPolicy{},
+			errors: []string{"must set at least one of"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if len(tc.errors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, e := range tc.errors {
+				require.Contains(t, err.Error(), e)
+			}
+		})
+	}
+}
+
+func TestJob_Validate_GCPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeBatch
+	job.Periodic = nil
+	job.GC = &JobGCPolicy{KeepFailed: 3}
+	err := job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "GC policy can only be used with periodic or parameterized jobs")
+
+	job.ParameterizedJob = &ParameterizedJobConfig{Payload: DispatchPayloadOptional}
+	require.NoError(t, job.Validate())
+}
+
+func TestJobSummary_Succeeded(t *testing.T) {
+	ci.Parallel(t)
+
+	var summary *JobSummary
+	require.False(t, summary.Succeeded())
+
+	summary = &JobSummary{Summary: map[string]TaskGroupSummary{
+		"web": {Complete: 2},
+		"db":  {Complete: 1},
+	}}
+	require.True(t, summary.Succeeded())
+
+	summary.Summary["db"] = TaskGroupSummary{Complete: 1, Lost: 1}
+	require.False(t, summary.Succeeded())
+
+	summary.Summary = map[string]TaskGroupSummary{"web": {}}
+	require.False(t, summary.Succeeded())
+}
@@ -1,9 +1,12 @@
 package structs
 
 import (
+	"fmt"
 	"reflect"
 	"time"
 
+	"github.com/hashicorp/cronexpr"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/helper"
 )
 
@@ -62,13 +65,33 @@ func (t *CSITopology) Equal(o *CSITopology) bool {
 	return helper.CompareMapStringString(t.Segments, o.Segments)
 }
 
+// Contains returns true if the topology has all the segments of the other
+// topology. Storage providers can report volume topologies with fewer
+// segments than node plugins, for example only the zone of a disk while the
+// node plugin reports both the zone and the hostname of the node.
+func (t *CSITopology) Contains(o *CSITopology) bool {
+	if t == nil || o == nil {
+		return false
+	}
+
+	for k, v := range o.Segments {
+		if seg, ok := t.Segments[k]; !ok || seg != v {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchFound returns true if the topology, typically the accessible topology
+// of a node plugin, is within one of the topologies a volume is accessible
+// from.
 func (t *CSITopology) MatchFound(o []*CSITopology) bool {
 	if t == nil || o == nil || len(o) == 0 {
 		return false
 	}
 
 	for _, other := range o {
-		if t.Equal(other) {
+		if t.Contains(other) {
 			return true
 		}
 	}
@@ -148,6 +171,11 @@ type CSINodeInfo struct {
 
 	// SupportsCondition indicates plugin support for VOLUME_CONDITION
 	SupportsCondition bool
+
+	// VolumeStats is the usage and condition of each volume published on
+	// the node, keyed by volume ID. It is only set when the plugin supports
+	// GET_VOLUME_STATS.
+	VolumeStats map[string]*CSIVolumeStats
 }
 
 func (n *CSINodeInfo) Copy() *CSINodeInfo {
@@ -158,10 +186,44 @@ func (n *CSINodeInfo) Copy() *CSINodeInfo {
 	nc := new(CSINodeInfo)
 	*nc = *n
 	nc.AccessibleTopology = n.AccessibleTopology.Copy()
+	if n.VolumeStats != nil {
+		nc.VolumeStats = make(map[string]*CSIVolumeStats, len(n.VolumeStats))
+		for k, v := range n.VolumeStats {
+			nc.VolumeStats[k] = v.Copy()
+		}
+	}
 
 	return nc
 }
 
+// CSIVolumeStats is the usage and condition of a volume as reported by the
+// node plugin where the volume is published. Usage fields are zero when the
+// plugin doesn't report them.
+type CSIVolumeStats struct {
+	TotalBytes     int64
+	UsedBytes      int64
+	AvailableBytes int64
+
+	TotalInodes     int64
+	UsedInodes      int64
+	AvailableInodes int64
+
+	// Abnormal and Message are only set by plugins that support
+	// VOLUME_CONDITION
+	Abnormal bool
+	Message  string
+}
+
+func (s *CSIVolumeStats) Copy() *CSIVolumeStats {
+	if s == nil {
+		return nil
+	}
+
+	ns := new(CSIVolumeStats)
+	*ns = *s
+	return ns
+}
+
 // CSIControllerInfo is the fingerprinted data from a CSI Plugin that is specific to
 // the Controller API.
 type CSIControllerInfo struct {
@@ -352,3 +414,155 @@ func (di *DriverInfo) HealthCheckEquals(other *DriverInfo) bool {
 
 	return true
 }
+
+const (
+	// NodeMaintenanceWindowSourceConfig marks maintenance windows declared
+	// in the client's configuration. They are replaced every time the client
+	// registers.
+	NodeMaintenanceWindowSourceConfig = "config"
+
+	// NodeMaintenanceWindowSourceAPI marks maintenance windows set through
+	// the Node API. They are kept when the client registers.
+	NodeMaintenanceWindowSourceAPI = "api"
+)
+
+// NodeMaintenanceWindow is a recurring window of time during which the node
+// doesn't accept new placements of service jobs. Batch work is only placed on
+// the node if it is expected to finish before the next window opens.
+type NodeMaintenanceWindow struct {
+	// Cron is the cron expression of the start of the window
+	Cron string
+
+	// Duration is how long the window stays open
+	Duration time.Duration
+
+	// TimeZone is the time zone the cron expression is evaluated in. It
+	// defaults to UTC.
+	TimeZone string
+
+	// Source is where the window was declared, either in the client's
+	// configuration or through the Node API
+	Source string
+}
+
+func (w *NodeMaintenanceWindow) Copy() *NodeMaintenanceWindow {
+	if w == nil {
+		return nil
+	}
+	nw := new(NodeMaintenanceWindow)
+	*nw = *w
+	return nw
+}
+
+func (w *NodeMaintenanceWindow) Validate() error {
+	var mErr multierror.Error
+	if w.Cron == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Must specify a cron expression"))
+	} else if _, err := cronexpr.Parse(w.Cron); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid cron expression %q: %v", w.Cron, err))
+	}
+	if w.Duration <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Duration must be greater than zero"))
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Invalid time zone %q: %v", w.TimeZone, err))
+		}
+	}
+	switch w.Source {
+	case "", NodeMaintenanceWindowSourceConfig, NodeMaintenanceWindowSourceAPI:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid source %q", w.Source))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// nextStart returns the first start of the window after the passed time, or
+// the zero time if the window never opens again.
+func (w *NodeMaintenanceWindow) nextStart(from time.Time) time.Time {
+	e, err := cronexpr.Parse(w.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+
+	loc := time.UTC
+	if w.TimeZone != "" {
+		if l, err := time.LoadLocation(w.TimeZone); err == nil {
+			loc = l
+		}
+	}
+
+	next, err := CronParseNext(e, from.In(loc), w.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return next
+}
+
+// Active returns whether the window is open at the passed time.
+func (w *NodeMaintenanceWindow) Active(now time.Time) bool {
+	// The window is open if it started within the last Duration
+	start := w.nextStart(now.Add(-w.Duration))
+	return !start.IsZero() && !start.After(now)
+}
+
+// InMaintenanceWindow returns whether any of the node's maintenance windows is
+// open at the passed time.
+func (n *Node) InMaintenanceWindow(now time.Time) bool {
+	for _, w := range n.MaintenanceWindows {
+		if w.Active(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextMaintenanceWindow returns when the next of the node's maintenance
+// windows opens after the passed time, or the zero time if none does.
+func (n *Node) NextMaintenanceWindow(now time.Time) time.Time {
+	var next time.Time
+	for _, w := range n.MaintenanceWindows {
+		start := w.nextStart(now)
+		if start.IsZero() {
+			continue
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next
+}
+
+// NextMaintenanceWindowEnd returns when the first of the node's maintenance
+// windows that is open or opens after the passed time closes, or the zero time
+// if none does.
+func (n *Node) NextMaintenanceWindowEnd(now time.Time) time.Time {
+	var next time.Time
+	for _, w := range n.MaintenanceWindows {
+		// The first window to close started within the last Duration or is
+		// the next to start
+		start := w.nextStart(now.Add(-w.Duration))
+		if start.IsZero() {
+			continue
+		}
+		end := start.Add(w.Duration)
+		if next.IsZero() || end.Before(next) {
+			next = end
+		}
+	}
+	return next
+}
+
+// copyNodeMaintenanceWindows is a helper to copy a list of maintenance windows
+func copyNodeMaintenanceWindows(windows []*NodeMaintenanceWindow) []*NodeMaintenanceWindow {
+	l := len(windows)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*NodeMaintenanceWindow, l)
+	for i, w := range windows {
+		c[i] = w.Copy()
+	}
+	return c
+}
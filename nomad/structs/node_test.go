@@ -2,6 +2,7 @@ package structs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/stretchr/testify/require"
@@ -63,3 +64,126 @@ func TestDriverInfoEquals(t *testing.T) {
 		require.Equal(testCase.expected, first.HealthCheckEquals(second), testCase.errorMsg)
 	}
 }
+
+func TestCSITopology_MatchFound(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &CSITopology{Segments: map[string]string{
+		"zone": "us-east-1a", "hostname": "node-1"}}
+
+	// Volumes can be accessible from a subset of the node's segments
+	require.True(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "us-east-1b"}},
+		{Segments: map[string]string{"zone": "us-east-1a"}},
+	}))
+	require.True(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "us-east-1a", "hostname": "node-1"}},
+	}))
+
+	require.False(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "us-east-1b"}},
+	}))
+	require.False(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "us-east-1a", "rack": "R1"}},
+	}))
+	require.False(t, node.MatchFound(nil))
+
+	var missing *CSITopology
+	require.False(t, missing.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "us-east-1a"}},
+	}))
+}
+
+func TestNodeMaintenanceWindow_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	w := &NodeMaintenanceWindow{
+		Cron:     "0 2 * * *",
+		Duration: 2 * time.Hour,
+	}
+	require.NoError(t, w.Validate())
+
+	w = &NodeMaintenanceWindow{
+		Cron:   "not a cron",
+		Source: "elsewhere",
+	}
+	err := w.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid cron expression")
+	require.Contains(t, err.Error(), "Duration must be greater than zero")
+	require.Contains(t, err.Error(), "Invalid source")
+}
+
+func TestNode_MaintenanceWindows(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		MaintenanceWindows: []*NodeMaintenanceWindow{
+			{
+				// Every day at 02:00 for two hours
+				Cron:     "0 2 * * *",
+				Duration: 2 * time.Hour,
+			},
+			{
+				// Every day at 12:00 for thirty minutes
+				Cron:     "0 12 * * *",
+				Duration: 30 * time.Minute,
+			},
+		},
+	}
+
+	day := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		now    time.Time
+		active bool
+		next   time.Time
+		end    time.Time
+	}{
+		{
+			now:    day.Add(time.Hour),
+			active: false,
+			next:   day.Add(2 * time.Hour),
+			end:    day.Add(4 * time.Hour),
+		},
+		{
+			now:    day.Add(2 * time.Hour),
+			active: true,
+			next:   day.Add(12 * time.Hour),
+			end:    day.Add(4 * time.Hour),
+		},
+		{
+			now:    day.Add(3*time.Hour + 59*time.Minute),
+			active: true,
+			next:   day.Add(12 * time.Hour),
+			end:    day.Add(4 * time.Hour),
+		},
+		{
+			now:    day.Add(4 * time.Hour),
+			active: false,
+			next:   day.Add(12 * time.Hour),
+			end:    day.Add(12*time.Hour + 30*time.Minute),
+		},
+		{
+			now:    day.Add(12*time.Hour + 15*time.Minute),
+			active: true,
+			next:   day.Add(26 * time.Hour),
+			end:    day.Add(12*time.Hour + 30*time.Minute),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.now.String(), func(t *testing.T) {
+			require.Equal(t, c.active, n.InMaintenanceWindow(c.now))
+			require.True(t, c.next.Equal(n.NextMaintenanceWindow(c.now)),
+				"expected %s, got %s", c.next, n.NextMaintenanceWindow(c.now))
+			require.True(t, c.end.Equal(n.NextMaintenanceWindowEnd(c.now)),
+				"expected %s, got %s", c.end, n.NextMaintenanceWindowEnd(c.now))
+		})
+	}
+
+	// Nodes without windows are never in maintenance
+	empty := &Node{}
+	require.False(t, empty.InMaintenanceWindow(day))
+	require.True(t, empty.NextMaintenanceWindow(day).IsZero())
+	require.True(t, empty.NextMaintenanceWindowEnd(day).IsZero())
+}
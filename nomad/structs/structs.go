@@ -16,12 +16,14 @@ import (
 	"hash/crc32"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/hashicorp/nomad/helper/escapingfs"
@@ -108,6 +110,12 @@ const (
 	ServiceRegistrationUpsertRequestType         MessageType = 47
 	ServiceRegistrationDeleteByIDRequestType     MessageType = 48
 	ServiceRegistrationDeleteByNodeIDRequestType MessageType = 49
+	ImagePrefetchUpsertRequestType               MessageType = 50
+	ImagePrefetchDeleteRequestType               MessageType = 51
+	CSIVolumeExpandRequestType                   MessageType = 52
+	HostVolumeRegisterRequestType                MessageType = 53
+	HostVolumeDeleteRequestType                  MessageType = 54
+	NodeUpdateMaintenanceRequestType             MessageType = 55
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -543,6 +551,21 @@ type DrainUpdate struct {
 	MarkEligible bool
 }
 
+// NodeUpdateMaintenanceRequest is used for updating the maintenance windows
+// of a node set through the Node API
+type NodeUpdateMaintenanceRequest struct {
+	NodeID             string
+	MaintenanceWindows []*NodeMaintenanceWindow
+
+	// NodeEvent is the event added to the node
+	NodeEvent *NodeEvent
+
+	// UpdatedAt represents server time of receiving request
+	UpdatedAt int64
+
+	WriteRequest
+}
+
 // NodeUpdateEligibilityRequest is used for updating the scheduling	eligibility
 type NodeUpdateEligibilityRequest struct {
 	NodeID      string
@@ -756,9 +779,30 @@ type JobDispatchRequest struct {
 	JobID   string
 	Payload []byte
 	Meta    map[string]string
+
+	// DependsOn is merged into the dispatched job's dependencies.
+	DependsOn []*JobDependency
+
+	// Priority overrides the priority of the dispatched job if set. It also
+	// orders the dispatch queue of parameterized jobs with MaxConcurrent set.
+	Priority int
 	WriteRequest
 }
 
+// JobDispatchQueueRequest is used to query the dispatch queue of a
+// parameterized job.
+type JobDispatchQueueRequest struct {
+	JobID string
+	QueryOptions
+}
+
+// JobDispatchQueueResponse is used to return the dispatch queue of a
+// parameterized job.
+type JobDispatchQueueResponse struct {
+	Queue *JobDispatchQueue
+	QueryMeta
+}
+
 // JobValidateRequest is used to validate a job
 type JobValidateRequest struct {
 	Job *Job
@@ -1350,6 +1394,13 @@ type NodeEligibilityUpdateResponse struct {
 	WriteMeta
 }
 
+// NodeMaintenanceUpdateResponse is used to respond to a node maintenance
+// windows update
+type NodeMaintenanceUpdateResponse struct {
+	NodeModifyIndex uint64
+	WriteMeta
+}
+
 // NodeAllocsResponse is used to return allocs for a single node
 type NodeAllocsResponse struct {
 	Allocs []*Allocation
@@ -1957,6 +2008,10 @@ type Node struct {
 	// LastDrain contains metadata about the most recent drain operation
 	LastDrain *DrainMetadata
 
+	// MaintenanceWindows are the recurring windows during which the node
+	// doesn't accept new placements of service jobs
+	MaintenanceWindows []*NodeMaintenanceWindow
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -2049,6 +2104,7 @@ func (n *Node) Copy() *Node {
 	nn.HostVolumes = copyNodeHostVolumes(n.HostVolumes)
 	nn.HostNetworks = copyNodeHostNetworks(n.HostNetworks)
 	nn.LastDrain = nn.LastDrain.Copy()
+	nn.MaintenanceWindows = copyNodeMaintenanceWindows(n.MaintenanceWindows)
 	return nn
 }
 
@@ -2276,6 +2332,7 @@ type Resources struct {
 	IOPS        int // COMPAT(0.10): Only being used to issue warnings
 	Networks    Networks
 	Devices     ResourceDevices
+	IO          *IOResources
 }
 
 const (
@@ -2338,6 +2395,12 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", r.MemoryMaxMB, r.MemoryMB))
 	}
 
+	if r.IO != nil {
+		if err := r.IO.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("io failed validation: %v", err))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -2365,6 +2428,9 @@ func (r *Resources) Merge(other *Resources) {
 	if len(other.Devices) != 0 {
 		r.Devices = other.Devices
 	}
+	if other.IO != nil {
+		r.IO = other.IO
+	}
 }
 
 // Equals Resources.
@@ -2384,7 +2450,8 @@ func (r *Resources) Equals(o *Resources) bool {
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
 		r.Networks.Equals(&o.Networks) &&
-		r.Devices.Equals(&o.Devices)
+		r.Devices.Equals(&o.Devices) &&
+		r.IO.Equals(o.IO)
 }
 
 // ResourceDevices are part of Resources.
@@ -2472,6 +2539,8 @@ func (r *Resources) Copy() *Resources {
 		}
 	}
 
+	newR.IO = r.IO.Copy()
+
 	return newR
 }
 
@@ -2516,6 +2585,119 @@ func (r *Resources) GoString() string {
 	return fmt.Sprintf("*%#v", *r)
 }
 
+const (
+	// IOWeightMin and IOWeightMax bound the relative block I/O weight of a
+	// task, matching the range of the cgroup blkio controller.
+	IOWeightMin = 10
+	IOWeightMax = 1000
+)
+
+// IOResources configures the block I/O of a task. It is enforced by drivers
+// using cgroups and is not considered when placing the task.
+type IOResources struct {
+	// Weight is the task's share of block I/O relative to other tasks, or
+	// zero to use the default weight
+	Weight int
+
+	// Devices limits the throughput of the task on specific block devices
+	Devices []*IODeviceLimit
+}
+
+// IODeviceLimit throttles the block I/O of a task on a single device. Limits
+// of zero are unlimited.
+type IODeviceLimit struct {
+	// Path is the path of the block device on the client, eg. /dev/sda
+	Path string
+
+	ReadBps   uint64
+	WriteBps  uint64
+	ReadIOPS  uint64
+	WriteIOPS uint64
+}
+
+func (r *IOResources) Validate() error {
+	var mErr multierror.Error
+
+	if r.Weight != 0 && (r.Weight < IOWeightMin || r.Weight > IOWeightMax) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("weight must be between %d and %d; got %d", IOWeightMin, IOWeightMax, r.Weight))
+	}
+
+	seen := make(map[string]struct{}, len(r.Devices))
+	for _, d := range r.Devices {
+		if d == nil {
+			continue
+		}
+		if err := d.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+			continue
+		}
+		if _, ok := seen[d.Path]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("device %q is limited more than once", d.Path))
+		}
+		seen[d.Path] = struct{}{}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+func (r *IOResources) Copy() *IOResources {
+	if r == nil {
+		return nil
+	}
+	nr := &IOResources{Weight: r.Weight}
+	if r.Devices != nil {
+		nr.Devices = make([]*IODeviceLimit, len(r.Devices))
+		for i, d := range r.Devices {
+			nr.Devices[i] = d.Copy()
+		}
+	}
+	return nr
+}
+
+func (r *IOResources) Equals(o *IOResources) bool {
+	if r == o {
+		return true
+	}
+	if r == nil || o == nil {
+		return false
+	}
+	if r.Weight != o.Weight || len(r.Devices) != len(o.Devices) {
+		return false
+	}
+	for i, d := range r.Devices {
+		if !d.Equals(o.Devices[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *IODeviceLimit) Validate() error {
+	// Block devices are only limited on Linux clients
+	if !strings.HasPrefix(d.Path, "/") {
+		return fmt.Errorf("device path %q must be absolute", d.Path)
+	}
+	if d.ReadBps == 0 && d.WriteBps == 0 && d.ReadIOPS == 0 && d.WriteIOPS == 0 {
+		return fmt.Errorf("device %q must set at least one limit", d.Path)
+	}
+	return nil
+}
+
+func (d *IODeviceLimit) Copy() *IODeviceLimit {
+	if d == nil {
+		return nil
+	}
+	nd := *d
+	return &nd
+}
+
+func (d *IODeviceLimit) Equals(o *IODeviceLimit) bool {
+	if d == nil || o == nil {
+		return d == o
+	}
+	return *d == *o
+}
+
 // NodeNetworkResource is used to describe a fingerprinted network of a node
 type NodeNetworkResource struct {
 	Mode string // host for physical networks, cni/<name> for cni networks
@@ -2805,8 +2987,30 @@ type RequestedDevice struct {
 	// Affinities are a set of affinities to apply when selecting the device
 	// to use.
 	Affinities Affinities
+
+	// OnUnhealthy is how the client reacts when an allocated instance of the
+	// device becomes unhealthy. Defaults to ignoring it.
+	OnUnhealthy string
+
+	// Shares is the number of shares requested on each device instance.
+	// When set, the instances may be shared with other allocations. Zero
+	// requests exclusive use of the instances.
+	Shares uint64
 }
 
+const (
+	// DeviceOnUnhealthyIgnore keeps tasks running on unhealthy devices
+	DeviceOnUnhealthyIgnore = "ignore"
+
+	// DeviceOnUnhealthyRestart restarts the tasks using an unhealthy device.
+	// Restarts count against the task's restart policy.
+	DeviceOnUnhealthyRestart = "restart"
+
+	// DeviceOnUnhealthyReschedule fails the tasks using an unhealthy device
+	// so the allocation is rescheduled according to its reschedule policy.
+	DeviceOnUnhealthyReschedule = "reschedule"
+)
+
 func (r *RequestedDevice) Equals(o *RequestedDevice) bool {
 	if r == o {
 		return true
@@ -2817,7 +3021,9 @@ func (r *RequestedDevice) Equals(o *RequestedDevice) bool {
 	return r.Name == o.Name &&
 		r.Count == o.Count &&
 		r.Constraints.Equals(&o.Constraints) &&
-		r.Affinities.Equals(&o.Affinities)
+		r.Affinities.Equals(&o.Affinities) &&
+		r.OnUnhealthy == o.OnUnhealthy &&
+		r.Shares == o.Shares
 }
 
 func (r *RequestedDevice) Copy() *RequestedDevice {
@@ -2887,6 +3093,13 @@ func (r *RequestedDevice) Validate() error {
 		}
 	}
 
+	switch r.OnUnhealthy {
+	case "", DeviceOnUnhealthyIgnore, DeviceOnUnhealthyRestart, DeviceOnUnhealthyReschedule:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("on_unhealthy must be one of %q, %q or %q",
+			DeviceOnUnhealthyIgnore, DeviceOnUnhealthyRestart, DeviceOnUnhealthyReschedule))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -3377,6 +3590,10 @@ type NodeDevice struct {
 	// Locality stores HW locality information for the node to optionally be
 	// used when making placement decisions.
 	Locality *NodeDeviceLocality
+
+	// Shares is the number of shares the device exposes when it can be
+	// shared between allocations. Zero means it can only be used exclusively.
+	Shares uint64
 }
 
 func (n *NodeDevice) Equals(o *NodeDevice) bool {
@@ -3396,6 +3613,8 @@ func (n *NodeDevice) Equals(o *NodeDevice) bool {
 		return false
 	} else if !n.Locality.Equals(o.Locality) {
 		return false
+	} else if n.Shares != o.Shares {
+		return false
 	}
 
 	return false
@@ -3930,6 +4149,10 @@ type AllocatedDeviceResource struct {
 
 	// DeviceIDs is the set of allocated devices
 	DeviceIDs []string
+
+	// Shares is the number of shares allocated on each of the devices when
+	// they are shared with other allocations. Zero means exclusive use.
+	Shares uint64
 }
 
 func (a *AllocatedDeviceResource) ID() *DeviceIdTuple {
@@ -4139,6 +4362,10 @@ type Job struct {
 	// for dispatching.
 	ParameterizedJob *ParameterizedJobConfig
 
+	// GC is used to configure how the dead children of a periodic or
+	// parameterized job are garbage collected.
+	GC *JobGCPolicy
+
 	// Dispatched is used to identify if the Job has been dispatched from a
 	// parameterized job.
 	Dispatched bool
@@ -4147,6 +4374,14 @@ type Job struct {
 	// non-terminal siblings which have the same token value.
 	DispatchIdempotencyToken string
 
+	// DispatchRetryTracker tracks the previous attempts of a dispatched job
+	// that is a retry of a failed dispatched job.
+	DispatchRetryTracker *DispatchRetryTracker
+
+	// DependsOn is the set of upstream jobs that must complete before this
+	// job is scheduled.
+	DependsOn []*JobDependency
+
 	// Payload is the payload supplied when the job was dispatched.
 	Payload []byte
 
@@ -4271,6 +4506,10 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+
+	for _, d := range j.DependsOn {
+		d.Canonicalize()
+	}
 }
 
 // Copy returns a deep copy of the Job. It is expected that callers use recover.
@@ -4297,6 +4536,9 @@ func (j *Job) Copy() *Job {
 	nj.Periodic = nj.Periodic.Copy()
 	nj.Meta = helper.CopyMapStringString(nj.Meta)
 	nj.ParameterizedJob = nj.ParameterizedJob.Copy()
+	nj.GC = nj.GC.Copy()
+	nj.DependsOn = CopySliceJobDependencies(nj.DependsOn)
+	nj.DispatchRetryTracker = nj.DispatchRetryTracker.Copy()
 	return nj
 }
 
@@ -4446,6 +4688,14 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if err := j.validateGCPolicy(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	if err := j.validateDependencies(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -4782,6 +5032,23 @@ func (js *JobSummary) Copy() *JobSummary {
 	return newJobSummary
 }
 
+// Succeeded returns whether the allocations of the job all completed without
+// any of them failing or being lost.
+func (js *JobSummary) Succeeded() bool {
+	if js == nil {
+		return false
+	}
+
+	complete := 0
+	for _, tg := range js.Summary {
+		if tg.Failed != 0 || tg.Lost != 0 {
+			return false
+		}
+		complete += tg.Complete
+	}
+	return complete > 0
+}
+
 // JobChildrenSummary contains the summary of children job statuses
 type JobChildrenSummary struct {
 	Pending int64
@@ -4884,6 +5151,11 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// Analysis is an optional metrics query that is evaluated while an
+	// allocation is being watched for health. Allocations whose metrics
+	// breach the configured threshold are marked unhealthy.
+	Analysis *DeploymentAnalysis
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -4893,6 +5165,7 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	copy := new(UpdateStrategy)
 	*copy = *u
+	copy.Analysis = u.Analysis.Copy()
 	return copy
 }
 
@@ -4935,6 +5208,14 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	if u.Analysis != nil {
+		if u.HealthCheck == UpdateStrategyHealthCheck_Manual {
+			_ = multierror.Append(&mErr, fmt.Errorf("Analysis can not be used with a %q health check", UpdateStrategyHealthCheck_Manual))
+		}
+		if err := u.Analysis.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, "Analysis:"))
+		}
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -4953,6 +5234,99 @@ func (u *UpdateStrategy) Rolling() bool {
 	return u.Stagger > 0 && u.MaxParallel > 0
 }
 
+const (
+	// DeploymentAnalysisOperator* are the comparisons a metric value must
+	// satisfy against the analysis threshold to be considered passing.
+	DeploymentAnalysisOperatorLess         = "<"
+	DeploymentAnalysisOperatorLessEqual    = "<="
+	DeploymentAnalysisOperatorGreater      = ">"
+	DeploymentAnalysisOperatorGreaterEqual = ">="
+)
+
+// DeploymentAnalysis configures a query against a Prometheus-compatible
+// HTTP API that is evaluated periodically while an allocation that is part
+// of a deployment is being watched for health.
+type DeploymentAnalysis struct {
+	// Address is the base URL of the Prometheus-compatible HTTP API.
+	Address string
+
+	// Query is the query to evaluate. It is interpolated as a Go template
+	// with the allocation's metadata before each evaluation.
+	Query string
+
+	// Interval is the time between query evaluations.
+	Interval time.Duration
+
+	// Operator is the comparison every returned value must satisfy against
+	// the Threshold for an evaluation to pass.
+	Operator string
+
+	// Threshold is the value the query results are compared against.
+	Threshold float64
+
+	// FailureLimit is the number of failing evaluations tolerated before the
+	// allocation is marked unhealthy.
+	FailureLimit int
+}
+
+func (a *DeploymentAnalysis) Copy() *DeploymentAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	na := new(DeploymentAnalysis)
+	*na = *a
+	return na
+}
+
+func (a *DeploymentAnalysis) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if a.Address == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Missing address"))
+	} else if u, err := url.Parse(a.Address); err != nil || u.Scheme == "" || u.Host == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid address %q", a.Address))
+	}
+	if a.Query == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Missing query"))
+	} else if _, err := template.New("query").Parse(a.Query); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid query template: %v", err))
+	}
+	if a.Interval <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Interval must be greater than zero: %v", a.Interval))
+	}
+	switch a.Operator {
+	case DeploymentAnalysisOperatorLess, DeploymentAnalysisOperatorLessEqual,
+		DeploymentAnalysisOperatorGreater, DeploymentAnalysisOperatorGreaterEqual:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid operator %q", a.Operator))
+	}
+	if a.FailureLimit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Failure limit can not be less than zero: %d < 0", a.FailureLimit))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Passes returns whether the given metric value satisfies the analysis
+// operator and threshold.
+func (a *DeploymentAnalysis) Passes(value float64) bool {
+	switch a.Operator {
+	case DeploymentAnalysisOperatorLess:
+		return value < a.Threshold
+	case DeploymentAnalysisOperatorLessEqual:
+		return value <= a.Threshold
+	case DeploymentAnalysisOperatorGreater:
+		return value > a.Threshold
+	case DeploymentAnalysisOperatorGreaterEqual:
+		return value >= a.Threshold
+	}
+	return false
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy
 	Regions  []*MultiregionRegion
@@ -5357,6 +5731,14 @@ type ParameterizedJobConfig struct {
 
 	// MetaOptional is metadata keys that may be specified by the dispatcher
 	MetaOptional []string
+
+	// Retry configures how dispatched jobs that fail are retried
+	Retry *DispatchRetryPolicy
+
+	// MaxConcurrent limits the number of dispatched jobs that are scheduled
+	// at the same time. Excess dispatched jobs are queued until running ones
+	// complete. Zero means no limit.
+	MaxConcurrent int
 }
 
 func (d *ParameterizedJobConfig) Validate() error {
@@ -5373,6 +5755,16 @@ func (d *ParameterizedJobConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
 	}
 
+	if d.MaxConcurrent < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("MaxConcurrent cannot be negative (got %d)", d.MaxConcurrent))
+	}
+
+	if d.Retry != nil {
+		if err := d.Retry.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, "Retry:"))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5380,6 +5772,9 @@ func (d *ParameterizedJobConfig) Canonicalize() {
 	if d.Payload == "" {
 		d.Payload = DispatchPayloadOptional
 	}
+	if d.Retry != nil {
+		d.Retry.Canonicalize()
+	}
 }
 
 func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
@@ -5390,6 +5785,7 @@ func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
 	*nd = *d
 	nd.MetaOptional = helper.CopySliceString(nd.MetaOptional)
 	nd.MetaRequired = helper.CopySliceString(nd.MetaRequired)
+	nd.Retry = nd.Retry.Copy()
 	return nd
 }
 
@@ -6189,6 +6585,11 @@ type TaskGroup struct {
 	// MaxClientDisconnect, if set, configures the client to allow placed
 	// allocations for tasks in this group to attempt to resume running without a restart.
 	MaxClientDisconnect *time.Duration
+
+	// ExpectedDuration, if set, is how long allocations of a batch task group
+	// are expected to run. They are only placed on nodes whose next
+	// maintenance window opens after they are expected to finish.
+	ExpectedDuration *time.Duration
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6249,6 +6650,10 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 		ntg.MaxClientDisconnect = tg.MaxClientDisconnect
 	}
 
+	if tg.ExpectedDuration != nil {
+		ntg.ExpectedDuration = tg.ExpectedDuration
+	}
+
 	return ntg
 }
 
@@ -6320,6 +6725,14 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect cannot be negative"))
 	}
 
+	if tg.ExpectedDuration != nil {
+		if j.Type != JobTypeBatch {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("expected_duration is only supported for %q jobs", JobTypeBatch))
+		} else if *tg.ExpectedDuration <= 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("expected_duration must be greater than zero"))
+		}
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -6728,9 +7141,10 @@ func (tg *TaskGroup) Warnings(j *Job) error {
 		}
 	}
 
-	// Check for mbits network field
-	if len(tg.Networks) > 0 && tg.Networks[0].MBits > 0 {
-		mErr.Errors = append(mErr.Errors, fmt.Errorf("mbits has been deprecated as of Nomad 0.12.0. Please remove mbits from the network block"))
+	// Check for mbits network field, which is only enforced by shaping the
+	// bandwidth of bridge networks
+	if len(tg.Networks) > 0 && tg.Networks[0].MBits > 0 && tg.Networks[0].Mode != "bridge" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("mbits is only enforced for networks in bridge mode. Please remove mbits from the network block"))
 	}
 
 	for _, t := range tg.Tasks {
@@ -7418,7 +7832,7 @@ func (t *Task) Warnings() error {
 
 	// Validate the resources
 	if t.Resources != nil && t.Resources.IOPS != 0 {
-		mErr.Errors = append(mErr.Errors, fmt.Errorf("IOPS has been deprecated as of Nomad 0.9.0. Please remove IOPS from resource stanza."))
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("IOPS has been deprecated as of Nomad 0.9.0. Please remove IOPS from resource stanza and use the io block to limit block I/O."))
 	}
 
 	if t.Resources != nil && len(t.Resources.Networks) != 0 {
@@ -7847,6 +8261,13 @@ const (
 	TaskStateDead    = "dead"    // Terminal state of task.
 )
 
+// Set of possible health states reported by drivers for a running task.
+const (
+	TaskHealthStarting  = "starting"  // The task's health check has not passed yet.
+	TaskHealthHealthy   = "healthy"   // The task's health check is passing.
+	TaskHealthUnhealthy = "unhealthy" // The task's health check is failing.
+)
+
 // TaskState tracks the current state of a task and events that caused state
 // transitions.
 type TaskState struct {
@@ -7871,6 +8292,11 @@ type TaskState struct {
 	// not be started again.
 	FinishedAt time.Time
 
+	// Health is the health of the running task as reported by its driver,
+	// for example from a container's health check. It is empty if the
+	// driver does not report health for the task.
+	Health string
+
 	// Series of task events that transition the state of the task.
 	Events []*TaskEvent
 
@@ -7986,6 +8412,10 @@ const (
 	// failed.
 	TaskSiblingFailed = "Sibling Task Failed"
 
+	// TaskDeviceUnhealthy indicates that a device instance allocated to the
+	// task became unhealthy.
+	TaskDeviceUnhealthy = "Device Unhealthy"
+
 	// TaskDriverMessage is an informational event message emitted by
 	// drivers such as when they're performing a long running action like
 	// downloading an image.
@@ -8012,6 +8442,14 @@ const (
 
 	// TaskClientReconnected indicates that the client running the task disconnected.
 	TaskClientReconnected = "Reconnected"
+
+	// TaskCheckpointed indicates that the task was checkpointed into its
+	// ephemeral disk before its allocation was migrated.
+	TaskCheckpointed = "Checkpointed"
+
+	// TaskCheckpointRestored indicates that the task was restored from a
+	// checkpoint migrated from a previous allocation.
+	TaskCheckpointRestored = "Restored From Checkpoint"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -8192,6 +8630,8 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		} else {
 			desc = "Task exceeded restart policy"
 		}
+	case TaskDiskExceeded:
+		desc = fmt.Sprintf("Ephemeral disk usage exceeded the %d MB limit", e.DiskLimit)
 	case TaskSiblingFailed:
 		if e.FailedSibling != "" {
 			desc = fmt.Sprintf("Task's sibling %q failed", e.FailedSibling)
@@ -8225,6 +8665,10 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		desc = "Main tasks in the group died"
 	case TaskClientReconnected:
 		desc = "Client reconnected"
+	case TaskCheckpointed:
+		desc = "Task checkpointed for migration"
+	case TaskCheckpointRestored:
+		desc = "Task restored from checkpoint"
 	default:
 		desc = e.Message
 	}
@@ -8914,6 +9358,12 @@ type EphemeralDisk struct {
 	// Migrate determines if Nomad client should migrate the allocation dir for
 	// sticky allocations
 	Migrate bool
+
+	// Checkpoint determines if tasks should be checkpointed into the
+	// allocation dir when their allocation is migrated, and restored from the
+	// checkpoint on the new node. Requires Migrate and a driver that supports
+	// checkpointing.
+	Checkpoint bool
 }
 
 // DefaultEphemeralDisk returns a EphemeralDisk with default configurations
@@ -8928,6 +9378,9 @@ func (d *EphemeralDisk) Validate() error {
 	if d.SizeMB < 10 {
 		return fmt.Errorf("minimum DiskMB value is 10; got %d", d.SizeMB)
 	}
+	if d.Checkpoint && !d.Migrate {
+		return fmt.Errorf("checkpoint requires migrate to be enabled")
+	}
 	return nil
 }
 
@@ -10668,6 +11121,7 @@ const (
 	EvalStatusComplete  = "complete"
 	EvalStatusFailed    = "failed"
 	EvalStatusCancelled = "canceled"
+	EvalStatusWaiting   = "waiting"
 )
 
 const (
@@ -10688,6 +11142,7 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerDispatchRetry        = "dispatch-retry"
 )
 
 const (
@@ -10998,7 +11453,7 @@ func (e *Evaluation) ShouldEnqueue() bool {
 	switch e.Status {
 	case EvalStatusPending:
 		return true
-	case EvalStatusComplete, EvalStatusFailed, EvalStatusBlocked, EvalStatusCancelled:
+	case EvalStatusComplete, EvalStatusFailed, EvalStatusBlocked, EvalStatusCancelled, EvalStatusWaiting:
 		return false
 	default:
 		panic(fmt.Sprintf("unhandled evaluation (%s) status %s", e.ID, e.Status))
@@ -11011,7 +11466,7 @@ func (e *Evaluation) ShouldBlock() bool {
 	switch e.Status {
 	case EvalStatusBlocked:
 		return true
-	case EvalStatusComplete, EvalStatusFailed, EvalStatusPending, EvalStatusCancelled:
+	case EvalStatusComplete, EvalStatusFailed, EvalStatusPending, EvalStatusCancelled, EvalStatusWaiting:
 		return false
 	default:
 		panic(fmt.Sprintf("unhandled evaluation (%s) status %s", e.ID, e.Status))
@@ -236,6 +236,30 @@ func TestJob_Warnings(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:     "mbits in host network",
+			Expected: []string{"mbits is only enforced for networks in bridge mode"},
+			Job: &Job{
+				Type: JobTypeService,
+				TaskGroups: []*TaskGroup{
+					{
+						Networks: []*NetworkResource{{Mode: "host", MBits: 10}},
+					},
+				},
+			},
+		},
+		{
+			Name:     "mbits in bridge network",
+			Expected: []string{},
+			Job: &Job{
+				Type: JobTypeService,
+				TaskGroups: []*TaskGroup{
+					{
+						Networks: []*NetworkResource{{Mode: "bridge", MBits: 10}},
+					},
+				},
+			},
+		},
 		{
 			Name:     "Template.VaultGrace Deprecated",
 			Expected: []string{"VaultGrace has been deprecated as of Nomad 0.11 and ignored since Vault 0.5. Please remove VaultGrace / vault_grace from template stanza."},
@@ -1685,6 +1709,85 @@ func TestTask_Validate_Resources(t *testing.T) {
 			},
 			err: "MemoryMaxMB value (10) should be larger than MemoryMB value (200",
 		},
+		{
+			name: "io",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IO: &IOResources{
+					Weight: 500,
+					Devices: []*IODeviceLimit{
+						{Path: "/dev/sda", ReadBps: 1024},
+					},
+				},
+			},
+		},
+		{
+			name: "io weight out of range",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IO:       &IOResources{Weight: 5},
+			},
+			err: "weight must be between 10 and 1000; got 5",
+		},
+		{
+			name: "io device without limits",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IO: &IOResources{
+					Devices: []*IODeviceLimit{{Path: "/dev/sda"}},
+				},
+			},
+			err: `device "/dev/sda" must set at least one limit`,
+		},
+		{
+			name: "io device relative path",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IO: &IOResources{
+					Devices: []*IODeviceLimit{{Path: "sda", WriteIOPS: 10}},
+				},
+			},
+			err: `device path "sda" must be absolute`,
+		},
+		{
+			name: "io device limited twice",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IO: &IOResources{
+					Devices: []*IODeviceLimit{
+						{Path: "/dev/sda", ReadBps: 1024},
+						{Path: "/dev/sda", WriteBps: 1024},
+					},
+				},
+			},
+			err: `device "/dev/sda" is limited more than once`,
+		},
+		{
+			name: "device restarted when unhealthy",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				Devices: []*RequestedDevice{
+					{Name: "nvidia/gpu", Count: 1, OnUnhealthy: DeviceOnUnhealthyRestart},
+				},
+			},
+		},
+		{
+			name: "device with invalid on_unhealthy",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				Devices: []*RequestedDevice{
+					{Name: "nvidia/gpu", Count: 1, OnUnhealthy: "replace"},
+				},
+			},
+			err: `on_unhealthy must be one of "ignore", "restart" or "reschedule"`,
+		},
 	}
 
 	for i := range cases {
@@ -2452,6 +2555,21 @@ func TestTask_Validate_LogConfig(t *testing.T) {
 	require.Error(t, err, "log storage")
 }
 
+func TestEphemeralDisk_Validate_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	disk := &EphemeralDisk{
+		SizeMB:     100,
+		Checkpoint: true,
+	}
+	err := disk.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checkpoint requires migrate")
+
+	disk.Migrate = true
+	require.NoError(t, disk.Validate())
+}
+
 func TestLogConfig_Equals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2963,6 +3081,81 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 	)
 }
 
+func TestUpdateStrategy_Validate_Analysis(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.HealthCheck = UpdateStrategyHealthCheck_Manual
+	u.Analysis = &DeploymentAnalysis{
+		Address:      "localhost:9090",
+		Query:        "{{.AllocID",
+		Operator:     "==",
+		FailureLimit: -1,
+	}
+
+	err := u.Validate()
+	requireErrors(t, err,
+		"Analysis can not be used with a \"manual\" health check",
+		"Invalid address",
+		"Invalid query template",
+		"Interval must be greater than zero",
+		"Invalid operator",
+		"Failure limit can not be less than zero",
+	)
+
+	u.HealthCheck = UpdateStrategyHealthCheck_TaskStates
+	u.Analysis = &DeploymentAnalysis{
+		Address:  "http://localhost:9090",
+		Query:    `error_ratio{alloc="{{.AllocID}}"}`,
+		Interval: 10 * time.Second,
+		Operator: DeploymentAnalysisOperatorLessEqual,
+	}
+	require.NoError(t, u.Validate())
+
+	// Copy must not share the analysis
+	c := u.Copy()
+	c.Analysis.Threshold = 1
+	require.Zero(t, u.Analysis.Threshold)
+}
+
+func TestDeploymentAnalysis_Passes(t *testing.T) {
+	ci.Parallel(t)
+
+	a := &DeploymentAnalysis{Threshold: 1}
+	for op, expected := range map[string][3]bool{
+		DeploymentAnalysisOperatorLess:         {true, false, false},
+		DeploymentAnalysisOperatorLessEqual:    {true, true, false},
+		DeploymentAnalysisOperatorGreater:      {false, false, true},
+		DeploymentAnalysisOperatorGreaterEqual: {false, true, true},
+	} {
+		a.Operator = op
+		require.Equal(t, expected, [3]bool{a.Passes(0), a.Passes(1), a.Passes(2)}, op)
+	}
+}
+
+func TestResource_CopyEquals_IO(t *testing.T) {
+	ci.Parallel(t)
+
+	r := &Resources{
+		CPU:      100,
+		MemoryMB: 200,
+		IO: &IOResources{
+			Weight:  500,
+			Devices: []*IODeviceLimit{{Path: "/dev/sda", ReadBps: 1024}},
+		},
+	}
+
+	c := r.Copy()
+	require.True(t, r.Equals(c))
+
+	c.IO.Devices[0].ReadBps = 2048
+	require.Equal(t, uint64(1024), r.IO.Devices[0].ReadBps)
+	require.False(t, r.Equals(c))
+
+	c.IO = nil
+	require.False(t, r.Equals(c))
+}
+
 func TestResource_NetIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5771,6 +5964,32 @@ func TestJobConfig_Validate_MaxClientDisconnect(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestJobConfig_Validate_ExpectedDuration(t *testing.T) {
+	ci.Parallel(t)
+
+	// Set up a service job with an expected duration
+	job := testJob()
+	duration := time.Hour
+	job.TaskGroups[0].ExpectedDuration = &duration
+
+	err := job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `expected_duration is only supported for "batch" jobs`)
+
+	// Modify the job to a batch job with an invalid expected duration
+	job.Type = JobTypeBatch
+	invalid := -1 * time.Minute
+	job.TaskGroups[0].ExpectedDuration = &invalid
+	err = job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected_duration must be greater than zero")
+
+	// Modify the job with a valid expected duration
+	job.TaskGroups[0].ExpectedDuration = &duration
+	err = job.Validate()
+	require.NoError(t, err)
+}
+
 func TestParameterizedJobConfig_Canonicalize(t *testing.T) {
 	ci.Parallel(t)
 
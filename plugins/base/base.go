@@ -33,6 +33,11 @@ type PluginInfoResponse struct {
 
 	// Name is the plugins name.
 	Name string
+
+	// ReloadConfig indicates SetConfig can be called again while the plugin
+	// is running to reconfigure it, such as when the agent reloads its
+	// configuration.
+	ReloadConfig bool
 }
 
 // Config contains the configuration for the plugin.
@@ -39,6 +39,7 @@ func (b *BasePluginClient) PluginInfo() (*PluginInfoResponse, error) {
 		PluginApiVersions: presp.GetPluginApiVersions(),
 		PluginVersion:     presp.GetPluginVersion(),
 		Name:              presp.GetName(),
+		ReloadConfig:      presp.GetReloadConfig(),
 	}
 
 	return resp, nil
@@ -32,6 +32,7 @@ func TestBasePlugin_PluginInfo_GRPC(t *testing.T) {
 			PluginApiVersions: apiVersions,
 			PluginVersion:     pluginVersion,
 			Name:              pluginName,
+			ReloadConfig:      true,
 		}
 		return info, nil
 	}
@@ -71,6 +72,7 @@ func TestBasePlugin_PluginInfo_GRPC(t *testing.T) {
 	require.Equal(pluginVersion, resp.PluginVersion)
 	require.Equal(pluginName, resp.Name)
 	require.Equal(PluginTypeDriver, resp.Type)
+	require.True(resp.ReloadConfig)
 
 	// Swap the implementation to return an unknown type
 	mock.PluginInfoF = unknownType
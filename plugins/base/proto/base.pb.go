@@ -98,7 +98,10 @@ type PluginInfoResponse struct {
 	// This is divorce from Nomad’s development and versioning.
 	PluginVersion string `protobuf:"bytes,3,opt,name=plugin_version,json=pluginVersion,proto3" json:"plugin_version,omitempty"`
 	// name is the name of the plugin
-	Name                 string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// reload_config indicates whether the plugin can be reconfigured while
+	// running by calling SetConfig again.
+	ReloadConfig         bool     `protobuf:"varint,5,opt,name=reload_config,json=reloadConfig,proto3" json:"reload_config,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -157,6 +160,13 @@ func (m *PluginInfoResponse) GetName() string {
 	return ""
 }
 
+func (m *PluginInfoResponse) GetReloadConfig() bool {
+	if m != nil {
+		return m.ReloadConfig
+	}
+	return false
+}
+
 // ConfigSchemaRequest is used to request the configurations schema.
 type ConfigSchemaRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -434,40 +444,41 @@ func init() {
 }
 
 var fileDescriptor_19edef855873449e = []byte{
-	// 521 bytes of a gzipped FileDescriptorProto
+	// 538 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x51, 0x6f, 0x12, 0x41,
-	0x10, 0xee, 0x01, 0xd2, 0x30, 0x40, 0x73, 0x2c, 0x9a, 0x10, 0x12, 0x13, 0x72, 0xb1, 0x09, 0x31,
-	0xcd, 0x12, 0x51, 0xd4, 0x47, 0x85, 0xf2, 0x40, 0x4c, 0xb1, 0x59, 0x14, 0x8d, 0x31, 0xb9, 0x6c,
-	0x8f, 0x2d, 0x77, 0x11, 0xf6, 0xd6, 0xdb, 0x6b, 0x63, 0x4d, 0x7c, 0xf2, 0xd9, 0x5f, 0xe4, 0xa3,
-	0x7f, 0xcc, 0xdc, 0xee, 0x5e, 0x39, 0x5a, 0x4d, 0x8f, 0xa7, 0x1b, 0x66, 0xbe, 0xef, 0x9b, 0x99,
-	0x8f, 0x59, 0x78, 0x28, 0x56, 0x17, 0xcb, 0x80, 0xcb, 0xde, 0x19, 0x95, 0xac, 0x27, 0xa2, 0x30,
-	0x0e, 0x55, 0x88, 0x55, 0x88, 0x1c, 0x9f, 0x4a, 0x3f, 0xf0, 0xc2, 0x48, 0x60, 0x1e, 0xae, 0xe9,
-	0x02, 0x1b, 0x38, 0xde, 0x60, 0xda, 0x87, 0xa9, 0x84, 0xf4, 0x69, 0xc4, 0x16, 0x3d, 0xdf, 0x5b,
-	0x49, 0xc1, 0xbc, 0xe4, 0xeb, 0x26, 0x81, 0x86, 0x39, 0x4d, 0x68, 0x9c, 0x2a, 0xe0, 0x84, 0x9f,
-	0x87, 0x84, 0x7d, 0xbd, 0x60, 0x32, 0x76, 0xfe, 0x58, 0x80, 0xb2, 0x59, 0x29, 0x42, 0x2e, 0x19,
-	0x1a, 0x42, 0x29, 0xbe, 0x12, 0xac, 0x65, 0x75, 0xac, 0xee, 0x41, 0x1f, 0xe3, 0xbb, 0xa7, 0xc0,
-	0x5a, 0xe5, 0xdd, 0x95, 0x60, 0x44, 0x71, 0x11, 0x86, 0xa6, 0x86, 0xb9, 0x54, 0x04, 0xee, 0x25,
-	0x8b, 0x64, 0x10, 0x72, 0xd9, 0x2a, 0x74, 0x8a, 0xdd, 0x0a, 0x69, 0xe8, 0xd2, 0x6b, 0x11, 0xcc,
-	0x4d, 0x01, 0x1d, 0xc2, 0x81, 0xc1, 0x1b, 0x6c, 0xab, 0xd8, 0xb1, 0xba, 0x15, 0x52, 0xd7, 0x59,
-	0x83, 0x43, 0x08, 0x4a, 0x9c, 0xae, 0x59, 0xab, 0xa4, 0x8a, 0x2a, 0x76, 0x1e, 0x40, 0x73, 0x14,
-	0xf2, 0xf3, 0x60, 0x39, 0xf3, 0x7c, 0xb6, 0xa6, 0xe9, 0x72, 0x1f, 0xe1, 0xfe, 0x76, 0xda, 0x6c,
-	0xf7, 0x0a, 0x4a, 0x89, 0x2f, 0x6a, 0xbb, 0x6a, 0xff, 0xe8, 0xbf, 0xdb, 0x69, 0x3f, 0xb1, 0xf1,
-	0x13, 0xcf, 0x04, 0xf3, 0x88, 0x62, 0x3a, 0xbf, 0x2d, 0xb0, 0x67, 0x2c, 0xd6, 0xea, 0xa6, 0x5d,
-	0xb2, 0xc0, 0x5a, 0x2e, 0x05, 0xf5, 0xbe, 0xb8, 0x9e, 0x2a, 0xa8, 0x06, 0x35, 0x52, 0x37, 0x59,
-	0x8d, 0x46, 0x04, 0x6a, 0xaa, 0x4d, 0x0a, 0x2a, 0xa8, 0x29, 0x7a, 0x79, 0x3c, 0x9e, 0x26, 0x05,
-	0xd3, 0xb4, 0xca, 0x37, 0x3f, 0xd0, 0x11, 0xa0, 0xdb, 0x5e, 0x1b, 0xff, 0xec, 0x9b, 0x56, 0x3b,
-	0x9f, 0xa1, 0x9a, 0x51, 0x42, 0x27, 0x50, 0x5e, 0x44, 0xc1, 0x25, 0x8b, 0x8c, 0x21, 0x83, 0xdc,
-	0xa3, 0x1c, 0x2b, 0x9a, 0x19, 0xc8, 0x88, 0x38, 0x2e, 0x34, 0x6e, 0x15, 0xd1, 0x23, 0xa8, 0x8f,
-	0x56, 0x01, 0xe3, 0xf1, 0x09, 0xfd, 0x76, 0x1a, 0x46, 0xb1, 0x6a, 0x55, 0x27, 0xdb, 0xc9, 0x0c,
-	0x2a, 0xe0, 0x0a, 0x55, 0xd8, 0x42, 0xe9, 0x64, 0x72, 0xc8, 0x19, 0xef, 0xf5, 0x7f, 0xfa, 0xf8,
-	0x09, 0xc0, 0xe6, 0x02, 0x51, 0x15, 0xf6, 0xdf, 0x4f, 0xdf, 0x4c, 0xdf, 0x7e, 0x98, 0xda, 0x7b,
-	0x08, 0xa0, 0x7c, 0x4c, 0x26, 0xf3, 0x31, 0xb1, 0x0b, 0x2a, 0x1e, 0xcf, 0x27, 0xa3, 0xb1, 0x5d,
-	0xec, 0xff, 0x2a, 0x02, 0x0c, 0xa9, 0x64, 0x9a, 0x87, 0x7e, 0xa4, 0x0a, 0xc9, 0x4b, 0x40, 0x83,
-	0xfc, 0x37, 0x9f, 0x79, 0x4f, 0xed, 0xe7, 0xbb, 0xd2, 0xf4, 0xf8, 0xce, 0x1e, 0xfa, 0x69, 0x41,
-	0x2d, 0x7b, 0xad, 0xe8, 0x45, 0x1e, 0xa9, 0x7f, 0x9c, 0x7d, 0xfb, 0xe5, 0xee, 0xc4, 0xeb, 0x29,
-	0xbe, 0x43, 0xe5, 0xda, 0x5b, 0xf4, 0x2c, 0x8f, 0xd0, 0xcd, 0x67, 0xd0, 0x1e, 0xec, 0xc8, 0x4a,
-	0x7b, 0x0f, 0xf7, 0x3f, 0xdd, 0x53, 0xc5, 0xb3, 0xb2, 0xfa, 0x3c, 0xfd, 0x1b, 0x00, 0x00, 0xff,
-	0xff, 0xdc, 0x0f, 0xf5, 0x51, 0x1c, 0x05, 0x00, 0x00,
+	0x10, 0xee, 0x01, 0xa5, 0x32, 0x40, 0x03, 0x83, 0x26, 0x04, 0x63, 0x42, 0x2e, 0x36, 0x21, 0xa6,
+	0x39, 0x22, 0x8a, 0xfa, 0xa8, 0x50, 0x1e, 0x88, 0x29, 0x36, 0x8b, 0xa2, 0x31, 0x26, 0x64, 0x39,
+	0xb6, 0x70, 0x11, 0x76, 0xd7, 0xdb, 0x6b, 0x63, 0x4d, 0x7c, 0xf2, 0xd9, 0x5f, 0xe4, 0x9f, 0xf1,
+	0xa7, 0x98, 0xdb, 0x5d, 0xca, 0xd1, 0x6a, 0x84, 0xa7, 0x9b, 0x9b, 0xf9, 0x66, 0xe6, 0x9b, 0x6f,
+	0x67, 0xe0, 0x81, 0x5c, 0x5c, 0xcc, 0x02, 0xae, 0x9a, 0x13, 0xaa, 0x58, 0x53, 0x86, 0x22, 0x12,
+	0xda, 0xf4, 0xb4, 0x89, 0xee, 0x9c, 0xaa, 0x79, 0xe0, 0x8b, 0x50, 0x7a, 0x5c, 0x2c, 0xe9, 0xd4,
+	0xb3, 0x70, 0x6f, 0x8d, 0xa9, 0x1d, 0xad, 0x4a, 0xa8, 0x39, 0x0d, 0xd9, 0xb4, 0x39, 0xf7, 0x17,
+	0x4a, 0x32, 0x3f, 0xfe, 0x8e, 0x63, 0xc3, 0xc0, 0xdc, 0x0a, 0x94, 0xcf, 0x34, 0xb0, 0xcf, 0xcf,
+	0x05, 0x61, 0x5f, 0x2e, 0x98, 0x8a, 0xdc, 0xdf, 0x0e, 0x60, 0xd2, 0xab, 0xa4, 0xe0, 0x8a, 0x61,
+	0x07, 0x32, 0xd1, 0x95, 0x64, 0x55, 0xa7, 0xee, 0x34, 0x0e, 0x5b, 0x9e, 0xf7, 0x7f, 0x16, 0x9e,
+	0xa9, 0xf2, 0xf6, 0x4a, 0x32, 0xa2, 0x73, 0xd1, 0x83, 0x8a, 0x81, 0x8d, 0xa9, 0x0c, 0xc6, 0x97,
+	0x2c, 0x54, 0x81, 0xe0, 0xaa, 0x9a, 0xaa, 0xa7, 0x1b, 0x39, 0x52, 0x36, 0xa1, 0x57, 0x32, 0x18,
+	0xd9, 0x00, 0x1e, 0xc1, 0xa1, 0xc5, 0x5b, 0x6c, 0x35, 0x5d, 0x77, 0x1a, 0x39, 0x52, 0x34, 0x5e,
+	0x8b, 0x43, 0x84, 0x0c, 0xa7, 0x4b, 0x56, 0xcd, 0xe8, 0xa0, 0xb6, 0xf1, 0x3e, 0x14, 0x43, 0xb6,
+	0x10, 0x74, 0x3a, 0xf6, 0x05, 0x3f, 0x0f, 0x66, 0xd5, 0xfd, 0xba, 0xd3, 0xb8, 0x43, 0x32, 0x13,
+	0x21, 0x16, 0xee, 0x3d, 0xa8, 0x74, 0xb5, 0x77, 0xe8, 0xcf, 0xd9, 0x92, 0xae, 0x26, 0xff, 0x00,
+	0x77, 0x37, 0xdd, 0x76, 0xf4, 0x97, 0x90, 0x89, 0x45, 0xd3, 0xa3, 0xe7, 0x5b, 0xc7, 0xff, 0x1c,
+	0xdd, 0x88, 0xed, 0x59, 0xb1, 0xbd, 0xa1, 0x64, 0x3e, 0xd1, 0x99, 0xee, 0x2f, 0x07, 0x4a, 0x43,
+	0x16, 0x99, 0xea, 0xb6, 0x5d, 0x3c, 0xdd, 0x52, 0xcd, 0x24, 0xf5, 0x3f, 0xaf, 0x38, 0xc6, 0x0d,
+	0x0a, 0xa4, 0x68, 0xbd, 0x06, 0x8d, 0x04, 0x0a, 0xba, 0xcd, 0x0a, 0x94, 0xd2, 0x2c, 0x9a, 0xdb,
+	0x3c, 0xc0, 0x20, 0x0e, 0xd8, 0xa6, 0x79, 0xbe, 0xfe, 0xc1, 0x63, 0xc0, 0xdb, 0x0f, 0x61, 0xc5,
+	0x2d, 0xdd, 0x7c, 0x07, 0xf7, 0x13, 0xe4, 0x13, 0x95, 0xf0, 0x14, 0xb2, 0xd3, 0x30, 0xb8, 0x64,
+	0xa1, 0x15, 0xa4, 0xbd, 0x35, 0x95, 0x13, 0x9d, 0x66, 0x09, 0xd9, 0x22, 0xee, 0x18, 0xca, 0xb7,
+	0x82, 0xf8, 0x10, 0x8a, 0xdd, 0x45, 0xc0, 0x78, 0x74, 0x4a, 0xbf, 0x9e, 0x89, 0x30, 0xd2, 0xad,
+	0x8a, 0x64, 0xd3, 0x99, 0x40, 0x05, 0x5c, 0xa3, 0x52, 0x1b, 0x28, 0xe3, 0x8c, 0xb7, 0x3c, 0xa1,
+	0xbd, 0x79, 0xd3, 0x47, 0x8f, 0x01, 0xd6, 0xeb, 0x89, 0x79, 0x38, 0x78, 0x37, 0x78, 0x3d, 0x78,
+	0xf3, 0x7e, 0x50, 0xda, 0x43, 0x80, 0xec, 0x09, 0xe9, 0x8f, 0x7a, 0xa4, 0x94, 0xd2, 0x76, 0x6f,
+	0xd4, 0xef, 0xf6, 0x4a, 0xe9, 0xd6, 0xcf, 0x34, 0x40, 0x87, 0x2a, 0x66, 0xf2, 0xf0, 0x3b, 0xc0,
+	0xfa, 0x4c, 0xb0, 0xbd, 0xfd, 0x41, 0x24, 0x8e, 0xad, 0xf6, 0x6c, 0xd7, 0x34, 0x43, 0xdf, 0xdd,
+	0xc3, 0x1f, 0x0e, 0x14, 0x92, 0xdb, 0x8a, 0xcf, 0xb7, 0x29, 0xf5, 0x97, 0xb5, 0xaf, 0xbd, 0xd8,
+	0x3d, 0xf1, 0x9a, 0xc5, 0x37, 0xc8, 0x5d, 0x6b, 0x8b, 0x4f, 0xb7, 0x29, 0x74, 0xf3, 0x0c, 0x6a,
+	0xed, 0x1d, 0xb3, 0x56, 0xbd, 0x3b, 0x07, 0x1f, 0xf7, 0x75, 0x70, 0x92, 0xd5, 0x9f, 0x27, 0x7f,
+	0x06, 0x00, 0x9c, 0x5d, 0xdb, 0xf1, 0x39, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
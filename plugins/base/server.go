@@ -35,6 +35,7 @@ func (b *basePluginServer) PluginInfo(context.Context, *proto.PluginInfoRequest)
 		PluginApiVersions: resp.PluginApiVersions,
 		PluginVersion:     resp.PluginVersion,
 		Name:              resp.Name,
+		ReloadConfig:      resp.ReloadConfig,
 	}
 
 	return presp, nil
@@ -72,6 +72,7 @@ type CSIControllerClient interface {
 	CreateVolume(ctx context.Context, in *csipbv1.CreateVolumeRequest, opts ...grpc.CallOption) (*csipbv1.CreateVolumeResponse, error)
 	ListVolumes(ctx context.Context, in *csipbv1.ListVolumesRequest, opts ...grpc.CallOption) (*csipbv1.ListVolumesResponse, error)
 	DeleteVolume(ctx context.Context, in *csipbv1.DeleteVolumeRequest, opts ...grpc.CallOption) (*csipbv1.DeleteVolumeResponse, error)
+	ControllerExpandVolume(ctx context.Context, in *csipbv1.ControllerExpandVolumeRequest, opts ...grpc.CallOption) (*csipbv1.ControllerExpandVolumeResponse, error)
 	CreateSnapshot(ctx context.Context, in *csipbv1.CreateSnapshotRequest, opts ...grpc.CallOption) (*csipbv1.CreateSnapshotResponse, error)
 	DeleteSnapshot(ctx context.Context, in *csipbv1.DeleteSnapshotRequest, opts ...grpc.CallOption) (*csipbv1.DeleteSnapshotResponse, error)
 	ListSnapshots(ctx context.Context, in *csipbv1.ListSnapshotsRequest, opts ...grpc.CallOption) (*csipbv1.ListSnapshotsResponse, error)
@@ -86,6 +87,8 @@ type CSINodeClient interface {
 	NodeUnstageVolume(ctx context.Context, in *csipbv1.NodeUnstageVolumeRequest, opts ...grpc.CallOption) (*csipbv1.NodeUnstageVolumeResponse, error)
 	NodePublishVolume(ctx context.Context, in *csipbv1.NodePublishVolumeRequest, opts ...grpc.CallOption) (*csipbv1.NodePublishVolumeResponse, error)
 	NodeUnpublishVolume(ctx context.Context, in *csipbv1.NodeUnpublishVolumeRequest, opts ...grpc.CallOption) (*csipbv1.NodeUnpublishVolumeResponse, error)
+	NodeExpandVolume(ctx context.Context, in *csipbv1.NodeExpandVolumeRequest, opts ...grpc.CallOption) (*csipbv1.NodeExpandVolumeResponse, error)
+	NodeGetVolumeStats(ctx context.Context, in *csipbv1.NodeGetVolumeStatsRequest, opts ...grpc.CallOption) (*csipbv1.NodeGetVolumeStatsResponse, error)
 }
 
 type client struct {
@@ -430,7 +433,7 @@ func (c *client) ControllerCreateVolume(ctx context.Context, req *ControllerCrea
 		switch code {
 		case codes.InvalidArgument:
 			return nil, fmt.Errorf(
-				"volume %q snapshot source %q is not compatible with these parameters: %v",
+				"volume %q content source %q is not compatible with these parameters: %v",
 				req.Name, req.ContentSource, err)
 		case codes.NotFound:
 			return nil, fmt.Errorf(
@@ -507,6 +510,45 @@ func (c *client) ControllerDeleteVolume(ctx context.Context, req *ControllerDele
 	return err
 }
 
+func (c *client) ControllerExpandVolume(ctx context.Context, req *ControllerExpandVolumeRequest, opts ...grpc.CallOption) (*ControllerExpandVolumeResponse, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+	creq := req.ToCSIRepresentation()
+	resp, err := c.controllerClient.ControllerExpandVolume(ctx, creq, opts...)
+
+	// https://github.com/container-storage-interface/spec/blob/master/spec.md#controllerexpandvolume-errors
+	if err != nil {
+		code := status.Code(err)
+		switch code {
+		case codes.NotFound:
+			return nil, fmt.Errorf("volume %q could not be found: %v",
+				req.ExternalVolumeID, err)
+		case codes.FailedPrecondition:
+			return nil, fmt.Errorf("volume %q cannot be expanded while in use: %v",
+				req.ExternalVolumeID, err)
+		case codes.OutOfRange:
+			return nil, fmt.Errorf(
+				"unsupported capacity_range for volume %q: %v",
+				req.ExternalVolumeID, err)
+		case codes.Internal:
+			return nil, fmt.Errorf(
+				"controller plugin returned an internal error, check the plugin allocation logs for more information: %v", err)
+		}
+		return nil, err
+	}
+
+	return &ControllerExpandVolumeResponse{
+		CapacityBytes:         resp.GetCapacityBytes(),
+		NodeExpansionRequired: resp.GetNodeExpansionRequired(),
+	}, nil
+}
+
 // compareCapabilities returns an error if the 'got' capabilities aren't found
 // within the 'expected' capability.
 //
@@ -880,3 +922,61 @@ func (c *client) NodeUnpublishVolume(ctx context.Context, volumeID, targetPath s
 
 	return err
 }
+
+func (c *client) NodeExpandVolume(ctx context.Context, req *NodeExpandVolumeRequest, opts ...grpc.CallOption) (*NodeExpandVolumeResponse, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.nodeClient.NodeExpandVolume(ctx, req.ToCSIRepresentation(), opts...)
+
+	// https://github.com/container-storage-interface/spec/blob/master/spec.md#nodeexpandvolume-errors
+	if err != nil {
+		code := status.Code(err)
+		switch code {
+		case codes.NotFound:
+			return nil, fmt.Errorf("volume %q could not be found: %v",
+				req.ExternalID, err)
+		case codes.FailedPrecondition:
+			return nil, fmt.Errorf("volume %q cannot be expanded in its current state: %v",
+				req.ExternalID, err)
+		case codes.OutOfRange:
+			return nil, fmt.Errorf(
+				"unsupported capacity_range for volume %q: %v", req.ExternalID, err)
+		case codes.Internal:
+			return nil, fmt.Errorf("node plugin returned an internal error, check the plugin allocation logs for more information: %v", err)
+		}
+		return nil, err
+	}
+
+	return &NodeExpandVolumeResponse{CapacityBytes: resp.GetCapacityBytes()}, nil
+}
+
+func (c *client) NodeGetVolumeStats(ctx context.Context, req *NodeGetVolumeStatsRequest, opts ...grpc.CallOption) (*NodeGetVolumeStatsResponse, error) {
+	if err := c.ensureConnected(ctx); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.nodeClient.NodeGetVolumeStats(ctx, req.ToCSIRepresentation(), opts...)
+
+	// https://github.com/container-storage-interface/spec/blob/master/spec.md#nodegetvolumestats-errors
+	if err != nil {
+		code := status.Code(err)
+		switch code {
+		case codes.NotFound:
+			return nil, fmt.Errorf("volume %q could not be found: %v",
+				req.ExternalID, err)
+		case codes.Internal:
+			return nil, fmt.Errorf("node plugin returned an internal error, check the plugin allocation logs for more information: %v", err)
+		}
+		return nil, err
+	}
+
+	return NewNodeGetVolumeStatsResponse(resp), nil
+}
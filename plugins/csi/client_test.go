@@ -894,6 +894,90 @@ func TestClient_RPC_ControllerDeleteVolume(t *testing.T) {
 	}
 }
 
+func TestClient_RPC_ControllerExpandVolume(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		Name        string
+		Request     *ControllerExpandVolumeRequest
+		ResponseErr error
+		Response    *csipbv1.ControllerExpandVolumeResponse
+		ExpectedErr error
+	}{
+		{
+			Name: "handles underlying grpc errors",
+			Request: &ControllerExpandVolumeRequest{
+				ExternalVolumeID: "vol-12345",
+				CapacityRange:    &CapacityRange{RequiredBytes: 1000},
+			},
+			ResponseErr: status.Errorf(codes.Internal, "some grpc error"),
+			ExpectedErr: fmt.Errorf("controller plugin returned an internal error, check the plugin allocation logs for more information: rpc error: code = Internal desc = some grpc error"),
+		},
+
+		{
+			Name: "handles out of range capacity",
+			Request: &ControllerExpandVolumeRequest{
+				ExternalVolumeID: "vol-12345",
+				CapacityRange:    &CapacityRange{RequiredBytes: 1000},
+			},
+			ResponseErr: status.Errorf(codes.OutOfRange, "too big"),
+			ExpectedErr: fmt.Errorf("unsupported capacity_range for volume \"vol-12345\": rpc error: code = OutOfRange desc = too big"),
+		},
+
+		{
+			Name: "handles error missing volume ID",
+			Request: &ControllerExpandVolumeRequest{
+				CapacityRange: &CapacityRange{RequiredBytes: 1000},
+			},
+			ExpectedErr: errors.New("missing ExternalVolumeID"),
+		},
+
+		{
+			Name:        "handles error missing capacity range",
+			Request:     &ControllerExpandVolumeRequest{ExternalVolumeID: "vol-12345"},
+			ExpectedErr: errors.New("missing CapacityRange"),
+		},
+
+		{
+			Name: "handles error invalid capacity range",
+			Request: &ControllerExpandVolumeRequest{
+				ExternalVolumeID: "vol-12345",
+				CapacityRange:    &CapacityRange{RequiredBytes: 1000, LimitBytes: 500},
+			},
+			ExpectedErr: errors.New("LimitBytes cannot be less than RequiredBytes"),
+		},
+
+		{
+			Name: "handles success",
+			Request: &ControllerExpandVolumeRequest{
+				ExternalVolumeID: "vol-12345",
+				CapacityRange:    &CapacityRange{RequiredBytes: 1000},
+			},
+			Response: &csipbv1.ControllerExpandVolumeResponse{
+				CapacityBytes:         1024,
+				NodeExpansionRequired: true,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, cc, _, client := newTestClient(t)
+			defer client.Close()
+
+			cc.NextErr = tc.ResponseErr
+			cc.NextExpandVolumeResponse = tc.Response
+			resp, err := client.ControllerExpandVolume(context.TODO(), tc.Request)
+			if tc.ExpectedErr != nil {
+				require.EqualError(t, err, tc.ExpectedErr.Error())
+				return
+			}
+			require.NoError(t, err, tc.Name)
+			require.Equal(t, int64(1024), resp.CapacityBytes)
+			require.True(t, resp.NodeExpansionRequired)
+		})
+	}
+}
+
 func TestClient_RPC_ControllerListVolume(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1361,3 +1445,137 @@ func TestClient_RPC_NodeUnpublishVolume(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_RPC_NodeExpandVolume(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		Name        string
+		Request     *NodeExpandVolumeRequest
+		ResponseErr error
+		Response    *csipbv1.NodeExpandVolumeResponse
+		ExpectedErr error
+	}{
+		{
+			Name: "handles underlying grpc errors",
+			Request: &NodeExpandVolumeRequest{
+				ExternalID: "vol-12345",
+				VolumePath: "/foo",
+			},
+			ResponseErr: status.Errorf(codes.Internal, "some grpc error"),
+			ExpectedErr: fmt.Errorf("node plugin returned an internal error, check the plugin allocation logs for more information: rpc error: code = Internal desc = some grpc error"),
+		},
+		{
+			Name:        "handles error missing volume ID",
+			Request:     &NodeExpandVolumeRequest{VolumePath: "/foo"},
+			ExpectedErr: errors.New("missing volume ID"),
+		},
+		{
+			Name:        "handles error missing volume path",
+			Request:     &NodeExpandVolumeRequest{ExternalID: "vol-12345"},
+			ExpectedErr: errors.New("missing VolumePath"),
+		},
+		{
+			Name: "handles success",
+			Request: &NodeExpandVolumeRequest{
+				ExternalID:        "vol-12345",
+				VolumePath:        "/foo",
+				StagingTargetPath: "/staging",
+				CapacityRange:     &CapacityRange{RequiredBytes: 1000},
+			},
+			Response: &csipbv1.NodeExpandVolumeResponse{CapacityBytes: 1024},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, _, nc, client := newTestClient(t)
+			defer client.Close()
+
+			nc.NextErr = tc.ResponseErr
+			nc.NextExpandVolumeResponse = tc.Response
+
+			resp, err := client.NodeExpandVolume(context.TODO(), tc.Request)
+			if tc.ExpectedErr != nil {
+				require.EqualError(t, err, tc.ExpectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(1024), resp.CapacityBytes)
+		})
+	}
+}
+
+func TestClient_RPC_NodeGetVolumeStats(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		Name        string
+		Request     *NodeGetVolumeStatsRequest
+		ResponseErr error
+		Response    *csipbv1.NodeGetVolumeStatsResponse
+		Expected    *NodeGetVolumeStatsResponse
+		ExpectedErr error
+	}{
+		{
+			Name: "handles underlying grpc errors",
+			Request: &NodeGetVolumeStatsRequest{
+				ExternalID: "vol-12345",
+				VolumePath: "/foo",
+			},
+			ResponseErr: status.Errorf(codes.NotFound, "no such volume"),
+			ExpectedErr: fmt.Errorf("volume \"vol-12345\" could not be found: rpc error: code = NotFound desc = no such volume"),
+		},
+		{
+			Name:        "handles error missing volume ID",
+			Request:     &NodeGetVolumeStatsRequest{VolumePath: "/foo"},
+			ExpectedErr: errors.New("missing volume ID"),
+		},
+		{
+			Name:        "handles error missing volume path",
+			Request:     &NodeGetVolumeStatsRequest{ExternalID: "vol-12345"},
+			ExpectedErr: errors.New("missing VolumePath"),
+		},
+		{
+			Name: "handles success",
+			Request: &NodeGetVolumeStatsRequest{
+				ExternalID:        "vol-12345",
+				VolumePath:        "/foo",
+				StagingTargetPath: "/staging",
+			},
+			Response: &csipbv1.NodeGetVolumeStatsResponse{
+				Usage: []*csipbv1.VolumeUsage{
+					{Unit: csipbv1.VolumeUsage_BYTES, Total: 1024, Used: 256, Available: 768},
+					{Unit: csipbv1.VolumeUsage_INODES, Total: 100, Used: 10, Available: 90},
+				},
+				VolumeCondition: &csipbv1.VolumeCondition{
+					Abnormal: true,
+					Message:  "disk failure",
+				},
+			},
+			Expected: &NodeGetVolumeStatsResponse{
+				Bytes:     &VolumeUsage{Total: 1024, Used: 256, Available: 768},
+				Inodes:    &VolumeUsage{Total: 100, Used: 10, Available: 90},
+				Condition: &VolumeCondition{Abnormal: true, Message: "disk failure"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, _, nc, client := newTestClient(t)
+			defer client.Close()
+
+			nc.NextErr = tc.ResponseErr
+			nc.NextVolumeStatsResponse = tc.Response
+
+			resp, err := client.NodeGetVolumeStats(context.TODO(), tc.Request)
+			if tc.ExpectedErr != nil {
+				require.EqualError(t, err, tc.ExpectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, resp)
+		})
+	}
+}
@@ -53,6 +53,10 @@ type CSIPlugin interface {
 	// external storage provider
 	ControllerDeleteVolume(ctx context.Context, req *ControllerDeleteVolumeRequest, opts ...grpc.CallOption) error
 
+	// ControllerExpandVolume is used to expand a remote volume in the
+	// external storage provider
+	ControllerExpandVolume(ctx context.Context, req *ControllerExpandVolumeRequest, opts ...grpc.CallOption) (*ControllerExpandVolumeResponse, error)
+
 	// ControllerListVolumes is used to list all volumes available in the
 	// external storage provider
 	ControllerListVolumes(ctx context.Context, req *ControllerListVolumesRequest, opts ...grpc.CallOption) (*ControllerListVolumesResponse, error)
@@ -98,6 +102,16 @@ type CSIPlugin interface {
 	// for the given volume.
 	NodeUnpublishVolume(ctx context.Context, volumeID, targetPath string, opts ...grpc.CallOption) error
 
+	// NodeExpandVolume is used when a plugin has the EXPAND_VOLUME node
+	// capability to grow the filesystem of a volume on the node after the
+	// controller has expanded it.
+	NodeExpandVolume(ctx context.Context, req *NodeExpandVolumeRequest, opts ...grpc.CallOption) (*NodeExpandVolumeResponse, error)
+
+	// NodeGetVolumeStats is used when a plugin has the GET_VOLUME_STATS node
+	// capability to report the usage and condition of a volume published on
+	// the node.
+	NodeGetVolumeStats(ctx context.Context, req *NodeGetVolumeStatsRequest, opts ...grpc.CallOption) (*NodeGetVolumeStatsResponse, error)
+
 	// Shutdown the client and ensure any connections are cleaned up.
 	Close() error
 }
@@ -241,6 +255,141 @@ func (r *NodeStageVolumeRequest) Validate() error {
 	return nil
 }
 
+type NodeExpandVolumeRequest struct {
+	// The external ID of the volume to expand.
+	ExternalID string
+
+	// The path where the volume is published. For block volumes this is the
+	// device path. This is a REQUIRED field.
+	VolumePath string
+
+	// The path where the volume is staged, if the plugin has the
+	// STAGE_UNSTAGE_VOLUME capability. This field is OPTIONAL.
+	StagingTargetPath string
+
+	// The capacity the volume was expanded to by the controller.
+	CapacityRange *CapacityRange
+
+	// Volume capability describing how the CO uses this volume.
+	VolumeCapability *VolumeCapability
+}
+
+func (r *NodeExpandVolumeRequest) ToCSIRepresentation() *csipbv1.NodeExpandVolumeRequest {
+	if r == nil {
+		return nil
+	}
+
+	return &csipbv1.NodeExpandVolumeRequest{
+		VolumeId:          r.ExternalID,
+		VolumePath:        r.VolumePath,
+		StagingTargetPath: r.StagingTargetPath,
+		CapacityRange:     r.CapacityRange.ToCSIRepresentation(),
+		VolumeCapability:  r.VolumeCapability.ToCSIRepresentation(),
+	}
+}
+
+func (r *NodeExpandVolumeRequest) Validate() error {
+	if r.ExternalID == "" {
+		return errors.New("missing volume ID")
+	}
+
+	if r.VolumePath == "" {
+		return errors.New("missing VolumePath")
+	}
+
+	return r.CapacityRange.Validate()
+}
+
+type NodeExpandVolumeResponse struct {
+	CapacityBytes int64
+}
+
+type NodeGetVolumeStatsRequest struct {
+	// The external ID of the volume.
+	ExternalID string
+
+	// The path where the volume is published. This is a REQUIRED field.
+	VolumePath string
+
+	// The path where the volume is staged, if the plugin has the
+	// STAGE_UNSTAGE_VOLUME capability. This field is OPTIONAL.
+	StagingTargetPath string
+}
+
+func (r *NodeGetVolumeStatsRequest) ToCSIRepresentation() *csipbv1.NodeGetVolumeStatsRequest {
+	if r == nil {
+		return nil
+	}
+
+	return &csipbv1.NodeGetVolumeStatsRequest{
+		VolumeId:          r.ExternalID,
+		VolumePath:        r.VolumePath,
+		StagingTargetPath: r.StagingTargetPath,
+	}
+}
+
+func (r *NodeGetVolumeStatsRequest) Validate() error {
+	if r.ExternalID == "" {
+		return errors.New("missing volume ID")
+	}
+
+	if r.VolumePath == "" {
+		return errors.New("missing VolumePath")
+	}
+
+	return nil
+}
+
+type NodeGetVolumeStatsResponse struct {
+	// Usage of the volume in bytes. May be nil if the plugin doesn't
+	// report it.
+	Bytes *VolumeUsage
+
+	// Usage of the volume in inodes. May be nil if the plugin doesn't
+	// report it.
+	Inodes *VolumeUsage
+
+	// Condition is only set for plugins with the VOLUME_CONDITION
+	// capability.
+	Condition *VolumeCondition
+}
+
+func NewNodeGetVolumeStatsResponse(resp *csipbv1.NodeGetVolumeStatsResponse) *NodeGetVolumeStatsResponse {
+	if resp == nil {
+		return nil
+	}
+
+	out := &NodeGetVolumeStatsResponse{}
+	for _, usage := range resp.GetUsage() {
+		u := &VolumeUsage{
+			Available: usage.GetAvailable(),
+			Total:     usage.GetTotal(),
+			Used:      usage.GetUsed(),
+		}
+		switch usage.GetUnit() {
+		case csipbv1.VolumeUsage_BYTES:
+			out.Bytes = u
+		case csipbv1.VolumeUsage_INODES:
+			out.Inodes = u
+		}
+	}
+	if cond := resp.GetVolumeCondition(); cond != nil {
+		out.Condition = &VolumeCondition{
+			Abnormal: cond.GetAbnormal(),
+			Message:  cond.GetMessage(),
+		}
+	}
+	return out
+}
+
+// VolumeUsage is the available, total, and used amount of a volume in a
+// given unit.
+type VolumeUsage struct {
+	Available int64
+	Total     int64
+	Used      int64
+}
+
 type PluginCapabilitySet struct {
 	hasControllerService bool
 	hasTopologies        bool
@@ -484,14 +633,8 @@ func (r *ControllerCreateVolumeRequest) Validate() error {
 	if r.VolumeCapabilities == nil {
 		return errors.New("missing VolumeCapabilities")
 	}
-	if r.CapacityRange != nil {
-		if r.CapacityRange.LimitBytes == 0 && r.CapacityRange.RequiredBytes == 0 {
-			return errors.New(
-				"one of LimitBytes or RequiredBytes must be set if CapacityRange is set")
-		}
-		if r.CapacityRange.LimitBytes < r.CapacityRange.RequiredBytes {
-			return errors.New("LimitBytes cannot be less than RequiredBytes")
-		}
+	if err := r.CapacityRange.Validate(); err != nil {
+		return err
 	}
 	if r.ContentSource != nil {
 		if r.ContentSource.CloneID != "" && r.ContentSource.SnapshotID != "" {
@@ -510,6 +653,18 @@ type VolumeContentSource struct {
 	CloneID    string
 }
 
+func (vcr *VolumeContentSource) String() string {
+	switch {
+	case vcr == nil:
+		return "none"
+	case vcr.CloneID != "":
+		return "volume " + vcr.CloneID
+	case vcr.SnapshotID != "":
+		return "snapshot " + vcr.SnapshotID
+	}
+	return "none"
+}
+
 func (vcr *VolumeContentSource) ToCSIRepresentation() *csipbv1.VolumeContentSource {
 	if vcr == nil {
 		return nil
@@ -622,6 +777,40 @@ func (r *ControllerDeleteVolumeRequest) Validate() error {
 	return nil
 }
 
+type ControllerExpandVolumeRequest struct {
+	ExternalVolumeID string
+	CapacityRange    *CapacityRange
+	Secrets          structs.CSISecrets
+	VolumeCapability *VolumeCapability
+}
+
+func (r *ControllerExpandVolumeRequest) ToCSIRepresentation() *csipbv1.ControllerExpandVolumeRequest {
+	if r == nil {
+		return nil
+	}
+	return &csipbv1.ControllerExpandVolumeRequest{
+		VolumeId:         r.ExternalVolumeID,
+		CapacityRange:    r.CapacityRange.ToCSIRepresentation(),
+		Secrets:          r.Secrets,
+		VolumeCapability: r.VolumeCapability.ToCSIRepresentation(),
+	}
+}
+
+func (r *ControllerExpandVolumeRequest) Validate() error {
+	if r.ExternalVolumeID == "" {
+		return errors.New("missing ExternalVolumeID")
+	}
+	if r.CapacityRange == nil {
+		return errors.New("missing CapacityRange")
+	}
+	return r.CapacityRange.Validate()
+}
+
+type ControllerExpandVolumeResponse struct {
+	CapacityBytes         int64
+	NodeExpansionRequired bool
+}
+
 type ControllerListVolumesRequest struct {
 	MaxEntries    int32
 	StartingToken string
@@ -973,3 +1162,19 @@ func (c *CapacityRange) ToCSIRepresentation() *csipbv1.CapacityRange {
 		LimitBytes:    c.LimitBytes,
 	}
 }
+
+// Validate returns an error if a set capacity range has neither bound or has
+// a limit below the required bytes. A nil capacity range is valid.
+func (c *CapacityRange) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.LimitBytes == 0 && c.RequiredBytes == 0 {
+		return errors.New(
+			"one of LimitBytes or RequiredBytes must be set if CapacityRange is set")
+	}
+	if c.LimitBytes != 0 && c.LimitBytes < c.RequiredBytes {
+		return errors.New("LimitBytes cannot be less than RequiredBytes")
+	}
+	return nil
+}
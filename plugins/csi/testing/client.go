@@ -52,6 +52,7 @@ type ControllerClient struct {
 	NextValidateVolumeCapabilitiesResponse *csipbv1.ValidateVolumeCapabilitiesResponse
 	NextCreateVolumeResponse               *csipbv1.CreateVolumeResponse
 	NextDeleteVolumeResponse               *csipbv1.DeleteVolumeResponse
+	NextExpandVolumeResponse               *csipbv1.ControllerExpandVolumeResponse
 	NextListVolumesResponse                *csipbv1.ListVolumesResponse
 	NextCreateSnapshotResponse             *csipbv1.CreateSnapshotResponse
 	NextDeleteSnapshotResponse             *csipbv1.DeleteSnapshotResponse
@@ -71,6 +72,7 @@ func (c *ControllerClient) Reset() {
 	c.NextValidateVolumeCapabilitiesResponse = nil
 	c.NextCreateVolumeResponse = nil
 	c.NextDeleteVolumeResponse = nil
+	c.NextExpandVolumeResponse = nil
 	c.NextListVolumesResponse = nil
 	c.NextCreateSnapshotResponse = nil
 	c.NextDeleteSnapshotResponse = nil
@@ -112,6 +114,10 @@ func (c *ControllerClient) DeleteVolume(ctx context.Context, in *csipbv1.DeleteV
 	return c.NextDeleteVolumeResponse, c.NextErr
 }
 
+func (c *ControllerClient) ControllerExpandVolume(ctx context.Context, in *csipbv1.ControllerExpandVolumeRequest, opts ...grpc.CallOption) (*csipbv1.ControllerExpandVolumeResponse, error) {
+	return c.NextExpandVolumeResponse, c.NextErr
+}
+
 func (c *ControllerClient) ListVolumes(ctx context.Context, in *csipbv1.ListVolumesRequest, opts ...grpc.CallOption) (*csipbv1.ListVolumesResponse, error) {
 	return c.NextListVolumesResponse, c.NextErr
 }
@@ -137,6 +143,8 @@ type NodeClient struct {
 	NextUnstageVolumeResponse   *csipbv1.NodeUnstageVolumeResponse
 	NextPublishVolumeResponse   *csipbv1.NodePublishVolumeResponse
 	NextUnpublishVolumeResponse *csipbv1.NodeUnpublishVolumeResponse
+	NextExpandVolumeResponse    *csipbv1.NodeExpandVolumeResponse
+	NextVolumeStatsResponse     *csipbv1.NodeGetVolumeStatsResponse
 }
 
 // NewNodeClient returns a new stub NodeClient
@@ -152,6 +160,8 @@ func (c *NodeClient) Reset() {
 	c.NextUnstageVolumeResponse = nil
 	c.NextPublishVolumeResponse = nil
 	c.NextUnpublishVolumeResponse = nil
+	c.NextExpandVolumeResponse = nil
+	c.NextVolumeStatsResponse = nil
 }
 
 func (c *NodeClient) NodeGetCapabilities(ctx context.Context, in *csipbv1.NodeGetCapabilitiesRequest, opts ...grpc.CallOption) (*csipbv1.NodeGetCapabilitiesResponse, error) {
@@ -177,3 +187,11 @@ func (c *NodeClient) NodePublishVolume(ctx context.Context, in *csipbv1.NodePubl
 func (c *NodeClient) NodeUnpublishVolume(ctx context.Context, in *csipbv1.NodeUnpublishVolumeRequest, opts ...grpc.CallOption) (*csipbv1.NodeUnpublishVolumeResponse, error) {
 	return c.NextUnpublishVolumeResponse, c.NextErr
 }
+
+func (c *NodeClient) NodeExpandVolume(ctx context.Context, in *csipbv1.NodeExpandVolumeRequest, opts ...grpc.CallOption) (*csipbv1.NodeExpandVolumeResponse, error) {
+	return c.NextExpandVolumeResponse, c.NextErr
+}
+
+func (c *NodeClient) NodeGetVolumeStats(ctx context.Context, in *csipbv1.NodeGetVolumeStatsRequest, opts ...grpc.CallOption) (*csipbv1.NodeGetVolumeStatsResponse, error) {
+	return c.NextVolumeStatsResponse, c.NextErr
+}
@@ -78,9 +78,20 @@ func (d *devicePluginClient) handleFingerprint(
 }
 
 func (d *devicePluginClient) Reserve(deviceIDs []string) (*ContainerReservation, error) {
+	return d.reserve(deviceIDs, 0)
+}
+
+// ReserveShares is used to reserve a number of shares on each of a set of
+// shareable devices and retrieve mount instructions.
+func (d *devicePluginClient) ReserveShares(deviceIDs []string, shares uint64) (*ContainerReservation, error) {
+	return d.reserve(deviceIDs, shares)
+}
+
+func (d *devicePluginClient) reserve(deviceIDs []string, shares uint64) (*ContainerReservation, error) {
 	// Build the request
 	req := &proto.ReserveRequest{
 		DeviceIds: deviceIDs,
+		Shares:    shares,
 	}
 
 	// Make the request
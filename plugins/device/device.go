@@ -37,6 +37,16 @@ type DevicePlugin interface {
 	Stats(ctx context.Context, interval time.Duration) (<-chan *StatsResponse, error)
 }
 
+// SharedDevicePlugin is an optional interface implemented by device plugins
+// whose device instances can be shared between multiple allocations.
+type SharedDevicePlugin interface {
+	DevicePlugin
+
+	// ReserveShares is used to reserve the given number of shares on each of
+	// the set of devices and retrieve mount instructions.
+	ReserveShares(deviceIDs []string, shares uint64) (*ContainerReservation, error)
+}
+
 // FingerprintResponse includes a set of detected devices or an error in the
 // process of fingerprinting.
 type FingerprintResponse struct {
@@ -130,6 +140,10 @@ type Device struct {
 
 	// HwLocality captures hardware locality information for the device.
 	HwLocality *DeviceLocality
+
+	// Shares is the number of shares the device exposes when it can be shared
+	// between allocations. Zero means the device can only be used exclusively.
+	Shares uint64
 }
 
 // Validate validates that the device is valid
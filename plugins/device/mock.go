@@ -2,6 +2,7 @@ package device
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/nomad/plugins/base"
@@ -9,6 +10,7 @@ import (
 
 type FingerprintFn func(context.Context) (<-chan *FingerprintResponse, error)
 type ReserveFn func([]string) (*ContainerReservation, error)
+type ReserveSharesFn func([]string, uint64) (*ContainerReservation, error)
 type StatsFn func(context.Context, time.Duration) (<-chan *StatsResponse, error)
 
 // MockDevicePlugin is used for testing.
@@ -16,9 +18,10 @@ type StatsFn func(context.Context, time.Duration) (<-chan *StatsResponse, error)
 // is passed through the base plugin layer.
 type MockDevicePlugin struct {
 	*base.MockPlugin
-	FingerprintF FingerprintFn
-	ReserveF     ReserveFn
-	StatsF       StatsFn
+	FingerprintF   FingerprintFn
+	ReserveF       ReserveFn
+	ReserveSharesF ReserveSharesFn
+	StatsF         StatsFn
 }
 
 func (p *MockDevicePlugin) Fingerprint(ctx context.Context) (<-chan *FingerprintResponse, error) {
@@ -29,6 +32,13 @@ func (p *MockDevicePlugin) Reserve(devices []string) (*ContainerReservation, err
 	return p.ReserveF(devices)
 }
 
+func (p *MockDevicePlugin) ReserveShares(devices []string, shares uint64) (*ContainerReservation, error) {
+	if p.ReserveSharesF == nil {
+		return nil, fmt.Errorf("device plugin does not support shared devices")
+	}
+	return p.ReserveSharesF(devices, shares)
+}
+
 func (p *MockDevicePlugin) Stats(ctx context.Context, interval time.Duration) (<-chan *StatsResponse, error) {
 	return p.StatsF(ctx, interval)
 }
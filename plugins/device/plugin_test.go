@@ -464,6 +464,54 @@ func TestDevicePlugin_Reserve(t *testing.T) {
 	require.EqualValues(reservation, containerRes)
 }
 
+func TestDevicePlugin_ReserveShares(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	reservation := &ContainerReservation{
+		Envs: map[string]string{
+			"foo": "bar",
+		},
+	}
+
+	var received []string
+	var receivedShares uint64
+	mock := &MockDevicePlugin{
+		ReserveF: func(devices []string) (*ContainerReservation, error) {
+			return nil, fmt.Errorf("unexpected exclusive reservation")
+		},
+		ReserveSharesF: func(devices []string, shares uint64) (*ContainerReservation, error) {
+			received = devices
+			receivedShares = shares
+			return reservation, nil
+		},
+	}
+
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		base.PluginTypeBase:   &base.PluginBase{Impl: mock},
+		base.PluginTypeDevice: &PluginDevice{Impl: mock},
+	})
+	defer server.Stop()
+	defer client.Close()
+
+	raw, err := client.Dispense(base.PluginTypeDevice)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	impl, ok := raw.(SharedDevicePlugin)
+	if !ok {
+		t.Fatalf("bad: %#v", raw)
+	}
+
+	req := []string{"a"}
+	containerRes, err := impl.ReserveShares(req, 25)
+	require.NoError(err)
+	require.EqualValues(req, received)
+	require.EqualValues(25, receivedShares)
+	require.EqualValues(reservation, containerRes)
+}
+
 func TestDevicePlugin_Stats(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
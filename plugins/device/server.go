@@ -55,7 +55,17 @@ func (d *devicePluginServer) Fingerprint(req *proto.FingerprintRequest, stream p
 }
 
 func (d *devicePluginServer) Reserve(ctx context.Context, req *proto.ReserveRequest) (*proto.ReserveResponse, error) {
-	resp, err := d.impl.Reserve(req.GetDeviceIds())
+	var resp *ContainerReservation
+	var err error
+	if shares := req.GetShares(); shares > 0 {
+		shared, ok := d.impl.(SharedDevicePlugin)
+		if !ok {
+			return nil, fmt.Errorf("device plugin does not support shared devices")
+		}
+		resp, err = shared.ReserveShares(req.GetDeviceIds(), shares)
+	} else {
+		resp, err = d.impl.Reserve(req.GetDeviceIds())
+	}
 	if err != nil {
 		return nil, err
 	}
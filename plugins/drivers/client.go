@@ -74,6 +74,8 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
+		caps.Checkpoint = resp.Capabilities.Checkpoint
+		caps.ImagePrefetch = resp.Capabilities.ImagePrefetch
 	}
 
 	return caps, nil
@@ -145,28 +147,38 @@ func (d *driverPluginClient) StartTask(c *TaskConfig) (*TaskHandle, *DriverNetwo
 
 	resp, err := d.client.StartTask(d.doneCtx, req)
 	if err != nil {
-		st := status.Convert(err)
-		if len(st.Details()) > 0 {
-			if rec, ok := st.Details()[0].(*sproto.RecoverableError); ok {
-				return nil, nil, structs.NewRecoverableError(err, rec.Recoverable)
-			}
-		}
-		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+		return nil, nil, d.startTaskErrorFromProto(err)
 	}
 
-	var net *DriverNetwork
-	if resp.NetworkOverride != nil {
-		net = &DriverNetwork{
-			PortMap:       map[string]int{},
-			IP:            resp.NetworkOverride.Addr,
-			AutoAdvertise: resp.NetworkOverride.AutoAdvertise,
-		}
-		for k, v := range resp.NetworkOverride.PortMap {
-			net.PortMap[k] = int(v)
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
+
+// startTaskErrorFromProto converts the error returned by starting a task into
+// a recoverable error if the driver marked it as such.
+func (d *driverPluginClient) startTaskErrorFromProto(err error) error {
+	st := status.Convert(err)
+	if len(st.Details()) > 0 {
+		if rec, ok := st.Details()[0].(*sproto.RecoverableError); ok {
+			return structs.NewRecoverableError(err, rec.Recoverable)
 		}
 	}
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
+
+func networkOverrideFromProto(pb *proto.NetworkOverride) *DriverNetwork {
+	if pb == nil {
+		return nil
+	}
 
-	return taskHandleFromProto(resp.Handle), net, nil
+	net := &DriverNetwork{
+		PortMap:       map[string]int{},
+		IP:            pb.Addr,
+		AutoAdvertise: pb.AutoAdvertise,
+	}
+	for k, v := range pb.PortMap {
+		net.PortMap[k] = int(v)
+	}
+	return net
 }
 
 // WaitTask returns a channel that will have an ExitResult pushed to it once when the task
@@ -497,3 +509,53 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+var _ CheckpointDriver = (*driverPluginClient)(nil)
+
+// CheckpointTask writes a checkpoint of the running task to the given
+// directory, stopping the task.
+func (d *driverPluginClient) CheckpointTask(taskID string, path string) error {
+	req := &proto.CheckpointTaskRequest{
+		TaskId: taskID,
+		Path:   path,
+	}
+
+	_, err := d.client.CheckpointTask(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
+
+// RestoreTask starts the task from the checkpoint in the given directory.
+func (d *driverPluginClient) RestoreTask(c *TaskConfig, path string) (*TaskHandle, *DriverNetwork, error) {
+	req := &proto.RestoreTaskRequest{
+		Task: taskConfigToProto(c),
+		Path: path,
+	}
+
+	resp, err := d.client.RestoreTask(d.doneCtx, req)
+	if err != nil {
+		return nil, nil, d.startTaskErrorFromProto(err)
+	}
+
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
+
+var _ ImagePrefetchDriver = (*driverPluginClient)(nil)
+
+// PrefetchImages sets the images the driver pulls ahead of the tasks using
+// them.
+func (d *driverPluginClient) PrefetchImages(images []string) error {
+	req := &proto.PrefetchImagesRequest{
+		Images: images,
+	}
+
+	_, err := d.client.PrefetchImages(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
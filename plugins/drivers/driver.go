@@ -94,6 +94,32 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// CheckpointDriver is an optional interface implemented by drivers that can
+// checkpoint the state of a running task to a directory and later restore the
+// task from it, possibly on another node. Drivers implementing it must set the
+// Checkpoint capability.
+type CheckpointDriver interface {
+	// CheckpointTask writes a checkpoint of the running task to the given
+	// directory. The task is stopped once the checkpoint is written.
+	CheckpointTask(taskID string, path string) error
+
+	// RestoreTask starts the task from the checkpoint in the given directory
+	// instead of starting it anew.
+	RestoreTask(cfg *TaskConfig, path string) (*TaskHandle, *DriverNetwork, error)
+}
+
+// ImagePrefetchDriver is an optional interface implemented by drivers that
+// can pull images ahead of the tasks using them. Drivers implementing it must
+// set the ImagePrefetch capability.
+type ImagePrefetchDriver interface {
+	// PrefetchImages sets the images the driver should pull and keep
+	// available. Each call replaces the previous set, so images missing from
+	// it may be removed once no task uses them. The driver fingerprints the
+	// attribute returned by structs.ImagePrefetchAttribute for each image it
+	// holds.
+	PrefetchImages(images []string) error
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -171,6 +197,14 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// Checkpoint indicates the driver implements the CheckpointDriver
+	// interface and can checkpoint running tasks and restore them.
+	Checkpoint bool
+
+	// ImagePrefetch indicates the driver implements the ImagePrefetchDriver
+	// interface and can pull images before tasks need them.
+	ImagePrefetch bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -211,6 +245,22 @@ type HostsConfig struct {
 	Address  string
 }
 
+// UserNamespaceSpec is the range of host UIDs and GIDs the client allocated
+// to an allocation for mapping into a user namespace. ID 0 in the namespace
+// maps to HostID on the host, and Size IDs are mapped in total.
+type UserNamespaceSpec struct {
+	HostID uint32
+	Size   uint32
+}
+
+func (s *UserNamespaceSpec) Copy() *UserNamespaceSpec {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
 // NetworkCreateRequest contains all the relevant information when creating a
 // network via DriverNetworkManager.CreateNetwork.
 type NetworkCreateRequest struct {
@@ -284,6 +334,7 @@ type TaskConfig struct {
 	AllocID          string
 	NetworkIsolation *NetworkIsolationSpec
 	DNS              *DNSConfig
+	UserNamespace    *UserNamespaceSpec
 }
 
 func (tc *TaskConfig) Copy() *TaskConfig {
@@ -296,6 +347,7 @@ func (tc *TaskConfig) Copy() *TaskConfig {
 	c.DeviceEnv = helper.CopyMapStringString(c.DeviceEnv)
 	c.Resources = tc.Resources.Copy()
 	c.DNS = tc.DNS.Copy()
+	c.UserNamespace = tc.UserNamespace.Copy()
 
 	if c.Devices != nil {
 		dc := make([]*DeviceConfig, len(c.Devices))
@@ -335,6 +387,7 @@ func (tc *TaskConfig) TaskDir() *allocdir.TaskDir {
 		SharedTaskDir:  filepath.Join(taskDir, allocdir.SharedAllocName),
 		LocalDir:       filepath.Join(taskDir, allocdir.TaskLocal),
 		SecretsDir:     filepath.Join(taskDir, allocdir.TaskSecrets),
+		CheckpointDir:  filepath.Join(taskDir, allocdir.TaskLocal, allocdir.TaskCheckpoint),
 	}
 }
 
@@ -407,11 +460,16 @@ type LinuxResources struct {
 	// specific options are deprecated in favor of exposes CPUPeriod and
 	// CPUQuota at the task resource stanza.
 	PercentTicks float64
+
+	// IO is the block I/O weight and per device limits of the task, enforced
+	// through the cgroup blkio or io controller
+	IO *structs.IOResources
 }
 
 func (r *LinuxResources) Copy() *LinuxResources {
 	res := new(LinuxResources)
 	*res = *r
+	res.IO = r.IO.Copy()
 	return res
 }
 
@@ -494,6 +552,11 @@ type TaskStatus struct {
 	NetworkOverride  *DriverNetwork
 }
 
+// TaskEventHealthAnnotation is the TaskEvent annotation drivers set to report
+// a change to the health of a running task. Its value is one of the
+// structs.TaskHealth* constants.
+const TaskEventHealthAnnotation = "health"
+
 type TaskEvent struct {
 	TaskID      string
 	TaskName    string
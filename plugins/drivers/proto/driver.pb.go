@@ -137,7 +137,7 @@ func (x DriverCapabilities_FSIsolation) String() string {
 }
 
 func (DriverCapabilities_FSIsolation) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{38, 0}
 }
 
 type DriverCapabilities_MountConfigs int32
@@ -165,7 +165,7 @@ func (x DriverCapabilities_MountConfigs) String() string {
 }
 
 func (DriverCapabilities_MountConfigs) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 1}
+	return fileDescriptor_4a8f45747846a74d, []int{38, 1}
 }
 
 type NetworkIsolationSpec_NetworkIsolationMode int32
@@ -196,7 +196,7 @@ func (x NetworkIsolationSpec_NetworkIsolationMode) String() string {
 }
 
 func (NetworkIsolationSpec_NetworkIsolationMode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{39, 0}
 }
 
 type CPUUsage_Fields int32
@@ -233,7 +233,7 @@ func (x CPUUsage_Fields) String() string {
 }
 
 func (CPUUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{63, 0}
 }
 
 type MemoryUsage_Fields int32
@@ -273,7 +273,38 @@ func (x MemoryUsage_Fields) String() string {
 }
 
 func (MemoryUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{64, 0}
+}
+
+type IOUsage_Fields int32
+
+const (
+	IOUsage_READ_BYTES  IOUsage_Fields = 0
+	IOUsage_WRITE_BYTES IOUsage_Fields = 1
+	IOUsage_READ_OPS    IOUsage_Fields = 2
+	IOUsage_WRITE_OPS   IOUsage_Fields = 3
+)
+
+var IOUsage_Fields_name = map[int32]string{
+	0: "READ_BYTES",
+	1: "WRITE_BYTES",
+	2: "READ_OPS",
+	3: "WRITE_OPS",
+}
+
+var IOUsage_Fields_value = map[string]int32{
+	"READ_BYTES":  0,
+	"WRITE_BYTES": 1,
+	"READ_OPS":    2,
+	"WRITE_OPS":   3,
+}
+
+func (x IOUsage_Fields) String() string {
+	return proto.EnumName(IOUsage_Fields_name, int32(x))
+}
+
+func (IOUsage_Fields) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{65, 0}
 }
 
 type TaskConfigSchemaRequest struct {
@@ -1833,6 +1864,276 @@ func (m *DestroyNetworkResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DestroyNetworkResponse proto.InternalMessageInfo
 
+type CheckpointTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Path is the directory the checkpoint is written to
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskRequest) Reset()         { *m = CheckpointTaskRequest{} }
+func (m *CheckpointTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskRequest) ProtoMessage()    {}
+func (*CheckpointTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{32}
+}
+
+func (m *CheckpointTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskRequest.Unmarshal(m, b)
+}
+func (m *CheckpointTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskRequest.Merge(m, src)
+}
+func (m *CheckpointTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskRequest.Size(m)
+}
+func (m *CheckpointTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskRequest proto.InternalMessageInfo
+
+func (m *CheckpointTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type CheckpointTaskResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskResponse) Reset()         { *m = CheckpointTaskResponse{} }
+func (m *CheckpointTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskResponse) ProtoMessage()    {}
+func (*CheckpointTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{33}
+}
+
+func (m *CheckpointTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskResponse.Unmarshal(m, b)
+}
+func (m *CheckpointTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskResponse.Merge(m, src)
+}
+func (m *CheckpointTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskResponse.Size(m)
+}
+func (m *CheckpointTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskResponse proto.InternalMessageInfo
+
+type PrefetchImagesRequest struct {
+	// Images are the images the driver should pull and keep available
+	Images               []string `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefetchImagesRequest) Reset()         { *m = PrefetchImagesRequest{} }
+func (m *PrefetchImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*PrefetchImagesRequest) ProtoMessage()    {}
+func (*PrefetchImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{34}
+}
+
+func (m *PrefetchImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchImagesRequest.Unmarshal(m, b)
+}
+func (m *PrefetchImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchImagesRequest.Marshal(b, m, deterministic)
+}
+func (m *PrefetchImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchImagesRequest.Merge(m, src)
+}
+func (m *PrefetchImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_PrefetchImagesRequest.Size(m)
+}
+func (m *PrefetchImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchImagesRequest proto.InternalMessageInfo
+
+func (m *PrefetchImagesRequest) GetImages() []string {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+type PrefetchImagesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefetchImagesResponse) Reset()         { *m = PrefetchImagesResponse{} }
+func (m *PrefetchImagesResponse) String() string { return proto.CompactTextString(m) }
+func (*PrefetchImagesResponse) ProtoMessage()    {}
+func (*PrefetchImagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{35}
+}
+
+func (m *PrefetchImagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchImagesResponse.Unmarshal(m, b)
+}
+func (m *PrefetchImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchImagesResponse.Marshal(b, m, deterministic)
+}
+func (m *PrefetchImagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchImagesResponse.Merge(m, src)
+}
+func (m *PrefetchImagesResponse) XXX_Size() int {
+	return xxx_messageInfo_PrefetchImagesResponse.Size(m)
+}
+func (m *PrefetchImagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchImagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchImagesResponse proto.InternalMessageInfo
+
+type RestoreTaskRequest struct {
+	// Task configuration to restore the task with
+	Task *TaskConfig `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Path is the directory containing the checkpoint to restore from
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTaskRequest) Reset()         { *m = RestoreTaskRequest{} }
+func (m *RestoreTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskRequest) ProtoMessage()    {}
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{36}
+}
+
+func (m *RestoreTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskRequest.Unmarshal(m, b)
+}
+func (m *RestoreTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskRequest.Merge(m, src)
+}
+func (m *RestoreTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskRequest.Size(m)
+}
+func (m *RestoreTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskRequest proto.InternalMessageInfo
+
+func (m *RestoreTaskRequest) GetTask() *TaskConfig {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *RestoreTaskRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	// Result is set depending on the type of error that occurred while
+	// restoring a task. See StartTaskResponse.Result for details.
+	Result StartTaskResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=hashicorp.nomad.plugins.drivers.proto.StartTaskResponse_Result" json:"result,omitempty"`
+	// DriverErrorMsg is set if an error occurred
+	DriverErrorMsg string `protobuf:"bytes,2,opt,name=driver_error_msg,json=driverErrorMsg,proto3" json:"driver_error_msg,omitempty"`
+	// Handle is opaque to the client, but must be stored in order to recover
+	// the task.
+	Handle *TaskHandle `protobuf:"bytes,3,opt,name=handle,proto3" json:"handle,omitempty"`
+	// NetworkOverride is set if the driver sets network settings and the service ip/port
+	// needs to be set differently.
+	NetworkOverride      *NetworkOverride `protobuf:"bytes,4,opt,name=network_override,json=networkOverride,proto3" json:"network_override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RestoreTaskResponse) Reset()         { *m = RestoreTaskResponse{} }
+func (m *RestoreTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskResponse) ProtoMessage()    {}
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{37}
+}
+
+func (m *RestoreTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskResponse.Unmarshal(m, b)
+}
+func (m *RestoreTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskResponse.Merge(m, src)
+}
+func (m *RestoreTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskResponse.Size(m)
+}
+func (m *RestoreTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskResponse proto.InternalMessageInfo
+
+func (m *RestoreTaskResponse) GetResult() StartTaskResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return StartTaskResponse_SUCCESS
+}
+
+func (m *RestoreTaskResponse) GetDriverErrorMsg() string {
+	if m != nil {
+		return m.DriverErrorMsg
+	}
+	return ""
+}
+
+func (m *RestoreTaskResponse) GetHandle() *TaskHandle {
+	if m != nil {
+		return m.Handle
+	}
+	return nil
+}
+
+func (m *RestoreTaskResponse) GetNetworkOverride() *NetworkOverride {
+	if m != nil {
+		return m.NetworkOverride
+	}
+	return nil
+}
+
 type DriverCapabilities struct {
 	// SendSignals indicates that the driver can send process signals (ex. SIGUSR1)
 	// to the task.
@@ -1848,7 +2149,13 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// checkpoint indicates whether the driver can checkpoint running tasks
+	// and restore them from the checkpoint.
+	Checkpoint bool `protobuf:"varint,8,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	// image_prefetch indicates whether the driver can pull images ahead of
+	// the tasks using them.
+	ImagePrefetch        bool     `protobuf:"varint,9,opt,name=image_prefetch,json=imagePrefetch,proto3" json:"image_prefetch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1858,7 +2165,7 @@ func (m *DriverCapabilities) Reset()         { *m = DriverCapabilities{} }
 func (m *DriverCapabilities) String() string { return proto.CompactTextString(m) }
 func (*DriverCapabilities) ProtoMessage()    {}
 func (*DriverCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32}
+	return fileDescriptor_4a8f45747846a74d, []int{38}
 }
 
 func (m *DriverCapabilities) XXX_Unmarshal(b []byte) error {
@@ -1928,6 +2235,20 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
+func (m *DriverCapabilities) GetImagePrefetch() bool {
+	if m != nil {
+		return m.ImagePrefetch
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -1942,7 +2263,7 @@ func (m *NetworkIsolationSpec) Reset()         { *m = NetworkIsolationSpec{} }
 func (m *NetworkIsolationSpec) String() string { return proto.CompactTextString(m) }
 func (*NetworkIsolationSpec) ProtoMessage()    {}
 func (*NetworkIsolationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33}
+	return fileDescriptor_4a8f45747846a74d, []int{39}
 }
 
 func (m *NetworkIsolationSpec) XXX_Unmarshal(b []byte) error {
@@ -2003,7 +2324,7 @@ func (m *HostsConfig) Reset()         { *m = HostsConfig{} }
 func (m *HostsConfig) String() string { return proto.CompactTextString(m) }
 func (*HostsConfig) ProtoMessage()    {}
 func (*HostsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{34}
+	return fileDescriptor_4a8f45747846a74d, []int{40}
 }
 
 func (m *HostsConfig) XXX_Unmarshal(b []byte) error {
@@ -2038,6 +2359,55 @@ func (m *HostsConfig) GetAddress() string {
 	return ""
 }
 
+type UserNamespaceSpec struct {
+	// host_id is the host UID and GID that ID 0 in the namespace maps to
+	HostId uint32 `protobuf:"varint,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	// size is the number of consecutive IDs mapped into the namespace
+	Size                 uint32   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserNamespaceSpec) Reset()         { *m = UserNamespaceSpec{} }
+func (m *UserNamespaceSpec) String() string { return proto.CompactTextString(m) }
+func (*UserNamespaceSpec) ProtoMessage()    {}
+func (*UserNamespaceSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{41}
+}
+
+func (m *UserNamespaceSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserNamespaceSpec.Unmarshal(m, b)
+}
+func (m *UserNamespaceSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserNamespaceSpec.Marshal(b, m, deterministic)
+}
+func (m *UserNamespaceSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserNamespaceSpec.Merge(m, src)
+}
+func (m *UserNamespaceSpec) XXX_Size() int {
+	return xxx_messageInfo_UserNamespaceSpec.Size(m)
+}
+func (m *UserNamespaceSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserNamespaceSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserNamespaceSpec proto.InternalMessageInfo
+
+func (m *UserNamespaceSpec) GetHostId() uint32 {
+	if m != nil {
+		return m.HostId
+	}
+	return 0
+}
+
+func (m *UserNamespaceSpec) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type DNSConfig struct {
 	Servers              []string `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
 	Searches             []string `protobuf:"bytes,2,rep,name=searches,proto3" json:"searches,omitempty"`
@@ -2051,7 +2421,7 @@ func (m *DNSConfig) Reset()         { *m = DNSConfig{} }
 func (m *DNSConfig) String() string { return proto.CompactTextString(m) }
 func (*DNSConfig) ProtoMessage()    {}
 func (*DNSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{35}
+	return fileDescriptor_4a8f45747846a74d, []int{42}
 }
 
 func (m *DNSConfig) XXX_Unmarshal(b []byte) error {
@@ -2133,17 +2503,20 @@ type TaskConfig struct {
 	// to use for the task. *Only supported on Linux
 	NetworkIsolationSpec *NetworkIsolationSpec `protobuf:"bytes,16,opt,name=network_isolation_spec,json=networkIsolationSpec,proto3" json:"network_isolation_spec,omitempty"`
 	// DNSConfig is the configuration for task DNS resolvers and other options
-	Dns                  *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Dns *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
+	// UserNamespaceSpec is the range of host IDs allocated to the task's
+	// allocation for mapping into a user namespace. *Only supported on Linux
+	UserNamespaceSpec    *UserNamespaceSpec `protobuf:"bytes,18,opt,name=user_namespace_spec,json=userNamespaceSpec,proto3" json:"user_namespace_spec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *TaskConfig) Reset()         { *m = TaskConfig{} }
 func (m *TaskConfig) String() string { return proto.CompactTextString(m) }
 func (*TaskConfig) ProtoMessage()    {}
 func (*TaskConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{36}
+	return fileDescriptor_4a8f45747846a74d, []int{43}
 }
 
 func (m *TaskConfig) XXX_Unmarshal(b []byte) error {
@@ -2283,6 +2656,13 @@ func (m *TaskConfig) GetDns() *DNSConfig {
 	return nil
 }
 
+func (m *TaskConfig) GetUserNamespaceSpec() *UserNamespaceSpec {
+	if m != nil {
+		return m.UserNamespaceSpec
+	}
+	return nil
+}
+
 type Resources struct {
 	// AllocatedResources are the resources set for the task
 	AllocatedResources *AllocatedTaskResources `protobuf:"bytes,1,opt,name=allocated_resources,json=allocatedResources,proto3" json:"allocated_resources,omitempty"`
@@ -2300,7 +2680,7 @@ func (m *Resources) Reset()         { *m = Resources{} }
 func (m *Resources) String() string { return proto.CompactTextString(m) }
 func (*Resources) ProtoMessage()    {}
 func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{37}
+	return fileDescriptor_4a8f45747846a74d, []int{44}
 }
 
 func (m *Resources) XXX_Unmarshal(b []byte) error {
@@ -2355,7 +2735,7 @@ func (m *AllocatedTaskResources) Reset()         { *m = AllocatedTaskResources{}
 func (m *AllocatedTaskResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedTaskResources) ProtoMessage()    {}
 func (*AllocatedTaskResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{38}
+	return fileDescriptor_4a8f45747846a74d, []int{45}
 }
 
 func (m *AllocatedTaskResources) XXX_Unmarshal(b []byte) error {
@@ -2408,7 +2788,7 @@ func (m *AllocatedCpuResources) Reset()         { *m = AllocatedCpuResources{} }
 func (m *AllocatedCpuResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedCpuResources) ProtoMessage()    {}
 func (*AllocatedCpuResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{39}
+	return fileDescriptor_4a8f45747846a74d, []int{46}
 }
 
 func (m *AllocatedCpuResources) XXX_Unmarshal(b []byte) error {
@@ -2448,7 +2828,7 @@ func (m *AllocatedMemoryResources) Reset()         { *m = AllocatedMemoryResourc
 func (m *AllocatedMemoryResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedMemoryResources) ProtoMessage()    {}
 func (*AllocatedMemoryResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{40}
+	return fileDescriptor_4a8f45747846a74d, []int{47}
 }
 
 func (m *AllocatedMemoryResources) XXX_Unmarshal(b []byte) error {
@@ -2499,7 +2879,7 @@ func (m *NetworkResource) Reset()         { *m = NetworkResource{} }
 func (m *NetworkResource) String() string { return proto.CompactTextString(m) }
 func (*NetworkResource) ProtoMessage()    {}
 func (*NetworkResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{41}
+	return fileDescriptor_4a8f45747846a74d, []int{48}
 }
 
 func (m *NetworkResource) XXX_Unmarshal(b []byte) error {
@@ -2574,7 +2954,7 @@ func (m *NetworkPort) Reset()         { *m = NetworkPort{} }
 func (m *NetworkPort) String() string { return proto.CompactTextString(m) }
 func (*NetworkPort) ProtoMessage()    {}
 func (*NetworkPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{42}
+	return fileDescriptor_4a8f45747846a74d, []int{49}
 }
 
 func (m *NetworkPort) XXX_Unmarshal(b []byte) error {
@@ -2623,7 +3003,7 @@ func (m *PortMapping) Reset()         { *m = PortMapping{} }
 func (m *PortMapping) String() string { return proto.CompactTextString(m) }
 func (*PortMapping) ProtoMessage()    {}
 func (*PortMapping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{43}
+	return fileDescriptor_4a8f45747846a74d, []int{50}
 }
 
 func (m *PortMapping) XXX_Unmarshal(b []byte) error {
@@ -2690,17 +3070,19 @@ type LinuxResources struct {
 	CpusetCgroup string `protobuf:"bytes,9,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	// PercentTicks is a compatibility option for docker and should not be used
 	// buf:lint:ignore FIELD_LOWER_SNAKE_CASE
-	PercentTicks         float64  `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PercentTicks float64 `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	// IO is the block I/O weight and per device limits. Default: nil (not specified)
+	Io                   *IOResources `protobuf:"bytes,10,opt,name=io,proto3" json:"io,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *LinuxResources) Reset()         { *m = LinuxResources{} }
 func (m *LinuxResources) String() string { return proto.CompactTextString(m) }
 func (*LinuxResources) ProtoMessage()    {}
 func (*LinuxResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{44}
+	return fileDescriptor_4a8f45747846a74d, []int{51}
 }
 
 func (m *LinuxResources) XXX_Unmarshal(b []byte) error {
@@ -2777,6 +3159,135 @@ func (m *LinuxResources) GetPercentTicks() float64 {
 	return 0
 }
 
+func (m *LinuxResources) GetIo() *IOResources {
+	if m != nil {
+		return m.Io
+	}
+	return nil
+}
+
+type IOResources struct {
+	// Weight is the relative block I/O weight, from 10 to 1000. Default: 0 (not specified)
+	Weight int32 `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Devices throttles the block I/O on specific devices
+	Devices              []*IODeviceLimit `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *IOResources) Reset()         { *m = IOResources{} }
+func (m *IOResources) String() string { return proto.CompactTextString(m) }
+func (*IOResources) ProtoMessage()    {}
+func (*IOResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{52}
+}
+
+func (m *IOResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IOResources.Unmarshal(m, b)
+}
+func (m *IOResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IOResources.Marshal(b, m, deterministic)
+}
+func (m *IOResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IOResources.Merge(m, src)
+}
+func (m *IOResources) XXX_Size() int {
+	return xxx_messageInfo_IOResources.Size(m)
+}
+func (m *IOResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_IOResources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IOResources proto.InternalMessageInfo
+
+func (m *IOResources) GetWeight() int32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *IOResources) GetDevices() []*IODeviceLimit {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type IODeviceLimit struct {
+	// Path is the path of the block device on the host
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Limits of zero are unlimited
+	ReadBps              uint64   `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3" json:"read_bps,omitempty"`
+	WriteBps             uint64   `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3" json:"write_bps,omitempty"`
+	ReadIops             uint64   `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3" json:"read_iops,omitempty"`
+	WriteIops            uint64   `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3" json:"write_iops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IODeviceLimit) Reset()         { *m = IODeviceLimit{} }
+func (m *IODeviceLimit) String() string { return proto.CompactTextString(m) }
+func (*IODeviceLimit) ProtoMessage()    {}
+func (*IODeviceLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{53}
+}
+
+func (m *IODeviceLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IODeviceLimit.Unmarshal(m, b)
+}
+func (m *IODeviceLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IODeviceLimit.Marshal(b, m, deterministic)
+}
+func (m *IODeviceLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IODeviceLimit.Merge(m, src)
+}
+func (m *IODeviceLimit) XXX_Size() int {
+	return xxx_messageInfo_IODeviceLimit.Size(m)
+}
+func (m *IODeviceLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_IODeviceLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IODeviceLimit proto.InternalMessageInfo
+
+func (m *IODeviceLimit) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *IODeviceLimit) GetReadBps() uint64 {
+	if m != nil {
+		return m.ReadBps
+	}
+	return 0
+}
+
+func (m *IODeviceLimit) GetWriteBps() uint64 {
+	if m != nil {
+		return m.WriteBps
+	}
+	return 0
+}
+
+func (m *IODeviceLimit) GetReadIops() uint64 {
+	if m != nil {
+		return m.ReadIops
+	}
+	return 0
+}
+
+func (m *IODeviceLimit) GetWriteIops() uint64 {
+	if m != nil {
+		return m.WriteIops
+	}
+	return 0
+}
+
 type Mount struct {
 	// TaskPath is the file path within the task directory to mount to
 	TaskPath string `protobuf:"bytes,1,opt,name=task_path,json=taskPath,proto3" json:"task_path,omitempty"`
@@ -2793,7 +3304,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{45}
+	return fileDescriptor_4a8f45747846a74d, []int{54}
 }
 
 func (m *Mount) XXX_Unmarshal(b []byte) error {
@@ -2857,7 +3368,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{46}
+	return fileDescriptor_4a8f45747846a74d, []int{55}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -2919,7 +3430,7 @@ func (m *TaskHandle) Reset()         { *m = TaskHandle{} }
 func (m *TaskHandle) String() string { return proto.CompactTextString(m) }
 func (*TaskHandle) ProtoMessage()    {}
 func (*TaskHandle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{47}
+	return fileDescriptor_4a8f45747846a74d, []int{56}
 }
 
 func (m *TaskHandle) XXX_Unmarshal(b []byte) error {
@@ -2987,7 +3498,7 @@ func (m *NetworkOverride) Reset()         { *m = NetworkOverride{} }
 func (m *NetworkOverride) String() string { return proto.CompactTextString(m) }
 func (*NetworkOverride) ProtoMessage()    {}
 func (*NetworkOverride) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{48}
+	return fileDescriptor_4a8f45747846a74d, []int{57}
 }
 
 func (m *NetworkOverride) XXX_Unmarshal(b []byte) error {
@@ -3046,7 +3557,7 @@ func (m *ExitResult) Reset()         { *m = ExitResult{} }
 func (m *ExitResult) String() string { return proto.CompactTextString(m) }
 func (*ExitResult) ProtoMessage()    {}
 func (*ExitResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{49}
+	return fileDescriptor_4a8f45747846a74d, []int{58}
 }
 
 func (m *ExitResult) XXX_Unmarshal(b []byte) error {
@@ -3110,7 +3621,7 @@ func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
 func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()    {}
 func (*TaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{50}
+	return fileDescriptor_4a8f45747846a74d, []int{59}
 }
 
 func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
@@ -3186,7 +3697,7 @@ func (m *TaskDriverStatus) Reset()         { *m = TaskDriverStatus{} }
 func (m *TaskDriverStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskDriverStatus) ProtoMessage()    {}
 func (*TaskDriverStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{51}
+	return fileDescriptor_4a8f45747846a74d, []int{60}
 }
 
 func (m *TaskDriverStatus) XXX_Unmarshal(b []byte) error {
@@ -3232,7 +3743,7 @@ func (m *TaskStats) Reset()         { *m = TaskStats{} }
 func (m *TaskStats) String() string { return proto.CompactTextString(m) }
 func (*TaskStats) ProtoMessage()    {}
 func (*TaskStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{52}
+	return fileDescriptor_4a8f45747846a74d, []int{61}
 }
 
 func (m *TaskStats) XXX_Unmarshal(b []byte) error {
@@ -3285,17 +3796,19 @@ type TaskResourceUsage struct {
 	// CPU usage stats
 	Cpu *CPUUsage `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	// Memory usage stats
-	Memory               *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Memory *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Block I/O usage stats
+	Io                   *IOUsage `protobuf:"bytes,3,opt,name=io,proto3" json:"io,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TaskResourceUsage) Reset()         { *m = TaskResourceUsage{} }
 func (m *TaskResourceUsage) String() string { return proto.CompactTextString(m) }
 func (*TaskResourceUsage) ProtoMessage()    {}
 func (*TaskResourceUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{53}
+	return fileDescriptor_4a8f45747846a74d, []int{62}
 }
 
 func (m *TaskResourceUsage) XXX_Unmarshal(b []byte) error {
@@ -3330,6 +3843,13 @@ func (m *TaskResourceUsage) GetMemory() *MemoryUsage {
 	return nil
 }
 
+func (m *TaskResourceUsage) GetIo() *IOUsage {
+	if m != nil {
+		return m.Io
+	}
+	return nil
+}
+
 type CPUUsage struct {
 	SystemMode       float64 `protobuf:"fixed64,1,opt,name=system_mode,json=systemMode,proto3" json:"system_mode,omitempty"`
 	UserMode         float64 `protobuf:"fixed64,2,opt,name=user_mode,json=userMode,proto3" json:"user_mode,omitempty"`
@@ -3348,7 +3868,7 @@ func (m *CPUUsage) Reset()         { *m = CPUUsage{} }
 func (m *CPUUsage) String() string { return proto.CompactTextString(m) }
 func (*CPUUsage) ProtoMessage()    {}
 func (*CPUUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54}
+	return fileDescriptor_4a8f45747846a74d, []int{63}
 }
 
 func (m *CPUUsage) XXX_Unmarshal(b []byte) error {
@@ -3437,7 +3957,7 @@ func (m *MemoryUsage) Reset()         { *m = MemoryUsage{} }
 func (m *MemoryUsage) String() string { return proto.CompactTextString(m) }
 func (*MemoryUsage) ProtoMessage()    {}
 func (*MemoryUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55}
+	return fileDescriptor_4a8f45747846a74d, []int{64}
 }
 
 func (m *MemoryUsage) XXX_Unmarshal(b []byte) error {
@@ -3514,6 +4034,78 @@ func (m *MemoryUsage) GetMeasuredFields() []MemoryUsage_Fields {
 	return nil
 }
 
+type IOUsage struct {
+	ReadBytes  uint64 `protobuf:"varint,1,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes uint64 `protobuf:"varint,2,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadOps    uint64 `protobuf:"varint,3,opt,name=read_ops,json=readOps,proto3" json:"read_ops,omitempty"`
+	WriteOps   uint64 `protobuf:"varint,4,opt,name=write_ops,json=writeOps,proto3" json:"write_ops,omitempty"`
+	// MeasuredFields indicates which fields were actually sampled
+	MeasuredFields       []IOUsage_Fields `protobuf:"varint,5,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.IOUsage_Fields" json:"measured_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *IOUsage) Reset()         { *m = IOUsage{} }
+func (m *IOUsage) String() string { return proto.CompactTextString(m) }
+func (*IOUsage) ProtoMessage()    {}
+func (*IOUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{65}
+}
+
+func (m *IOUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IOUsage.Unmarshal(m, b)
+}
+func (m *IOUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IOUsage.Marshal(b, m, deterministic)
+}
+func (m *IOUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IOUsage.Merge(m, src)
+}
+func (m *IOUsage) XXX_Size() int {
+	return xxx_messageInfo_IOUsage.Size(m)
+}
+func (m *IOUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_IOUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IOUsage proto.InternalMessageInfo
+
+func (m *IOUsage) GetReadBytes() uint64 {
+	if m != nil {
+		return m.ReadBytes
+	}
+	return 0
+}
+
+func (m *IOUsage) GetWriteBytes() uint64 {
+	if m != nil {
+		return m.WriteBytes
+	}
+	return 0
+}
+
+func (m *IOUsage) GetReadOps() uint64 {
+	if m != nil {
+		return m.ReadOps
+	}
+	return 0
+}
+
+func (m *IOUsage) GetWriteOps() uint64 {
+	if m != nil {
+		return m.WriteOps
+	}
+	return 0
+}
+
+func (m *IOUsage) GetMeasuredFields() []IOUsage_Fields {
+	if m != nil {
+		return m.MeasuredFields
+	}
+	return nil
+}
+
 type DriverTaskEvent struct {
 	// TaskId is the id of the task for the event
 	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -3536,7 +4128,7 @@ func (m *DriverTaskEvent) Reset()         { *m = DriverTaskEvent{} }
 func (m *DriverTaskEvent) String() string { return proto.CompactTextString(m) }
 func (*DriverTaskEvent) ProtoMessage()    {}
 func (*DriverTaskEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{56}
+	return fileDescriptor_4a8f45747846a74d, []int{66}
 }
 
 func (m *DriverTaskEvent) XXX_Unmarshal(b []byte) error {
@@ -3608,6 +4200,7 @@ func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode", NetworkIsolationSpec_NetworkIsolationMode_name, NetworkIsolationSpec_NetworkIsolationMode_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.CPUUsage_Fields", CPUUsage_Fields_name, CPUUsage_Fields_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.MemoryUsage_Fields", MemoryUsage_Fields_name, MemoryUsage_Fields_value)
+	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.IOUsage_Fields", IOUsage_Fields_name, IOUsage_Fields_value)
 	proto.RegisterType((*TaskConfigSchemaRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfigSchemaRequest")
 	proto.RegisterType((*TaskConfigSchemaResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfigSchemaResponse")
 	proto.RegisterType((*CapabilitiesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CapabilitiesRequest")
@@ -3643,10 +4236,17 @@ func init() {
 	proto.RegisterType((*CreateNetworkResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CreateNetworkResponse")
 	proto.RegisterType((*DestroyNetworkRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkRequest")
 	proto.RegisterType((*DestroyNetworkResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkResponse")
+	proto.RegisterType((*CheckpointTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskRequest")
+	proto.RegisterType((*CheckpointTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskResponse")
+	proto.RegisterType((*PrefetchImagesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.PrefetchImagesRequest")
+	proto.RegisterType((*PrefetchImagesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.PrefetchImagesResponse")
+	proto.RegisterType((*RestoreTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskRequest")
+	proto.RegisterType((*RestoreTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskResponse")
 	proto.RegisterType((*DriverCapabilities)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverCapabilities")
 	proto.RegisterType((*NetworkIsolationSpec)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec.LabelsEntry")
 	proto.RegisterType((*HostsConfig)(nil), "hashicorp.nomad.plugins.drivers.proto.HostsConfig")
+	proto.RegisterType((*UserNamespaceSpec)(nil), "hashicorp.nomad.plugins.drivers.proto.UserNamespaceSpec")
 	proto.RegisterType((*DNSConfig)(nil), "hashicorp.nomad.plugins.drivers.proto.DNSConfig")
 	proto.RegisterType((*TaskConfig)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfig")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfig.DeviceEnvEntry")
@@ -3659,6 +4259,8 @@ func init() {
 	proto.RegisterType((*NetworkPort)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkPort")
 	proto.RegisterType((*PortMapping)(nil), "hashicorp.nomad.plugins.drivers.proto.PortMapping")
 	proto.RegisterType((*LinuxResources)(nil), "hashicorp.nomad.plugins.drivers.proto.LinuxResources")
+	proto.RegisterType((*IOResources)(nil), "hashicorp.nomad.plugins.drivers.proto.IOResources")
+	proto.RegisterType((*IODeviceLimit)(nil), "hashicorp.nomad.plugins.drivers.proto.IODeviceLimit")
 	proto.RegisterType((*Mount)(nil), "hashicorp.nomad.plugins.drivers.proto.Mount")
 	proto.RegisterType((*Device)(nil), "hashicorp.nomad.plugins.drivers.proto.Device")
 	proto.RegisterType((*TaskHandle)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskHandle")
@@ -3673,6 +4275,7 @@ func init() {
 	proto.RegisterType((*TaskResourceUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskResourceUsage")
 	proto.RegisterType((*CPUUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.CPUUsage")
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
+	proto.RegisterType((*IOUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.IOUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
 }
@@ -3682,243 +4285,269 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0x9c, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd5, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xc1,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x3f, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0x07, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xce, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x6c, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xe1, 0xdb, 0xcc, 0x43, 0x9a, 0xe8,
-	0x54, 0x0e, 0x58, 0xcc, 0xff, 0x2c, 0xf7, 0xa9, 0xa2, 0x3f, 0x80, 0x6a, 0x82, 0x6f, 0x54, 0x07,
-	0x38, 0xe9, 0xed, 0x76, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xab, 0xad, 0xa1, 0x75, 0xa8, 0x9c, 0xf4,
-	0xf6, 0xba, 0xed, 0x83, 0xc1, 0xde, 0x73, 0x4d, 0x41, 0x55, 0x28, 0x45, 0x44, 0x4e, 0xbf, 0x04,
-	0x84, 0xc9, 0xd0, 0xbb, 0x20, 0x01, 0x33, 0x64, 0xa9, 0x55, 0x74, 0x1b, 0x4a, 0xa1, 0x49, 0xcf,
-	0x0d, 0xdb, 0x92, 0x3c, 0x17, 0x19, 0xb9, 0x6f, 0xa1, 0x7d, 0x28, 0x9e, 0x99, 0xae, 0x35, 0x7e,
-	0x3b, 0xdf, 0x69, 0x51, 0x33, 0xf0, 0x3d, 0x3e, 0x11, 0x4b, 0x00, 0x66, 0xdd, 0xa9, 0x95, 0x85,
-	0x02, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xec, 0x74, 0x21, 0xcf, 0xd6, 0x97, 0x16,
-	0xbd, 0xcc, 0x9a, 0x62, 0x67, 0x62, 0x3e, 0x5d, 0xff, 0xdf, 0x1c, 0x6c, 0x26, 0xb0, 0xa5, 0xa5,
-	0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x1c, 0x72, 0xf8, 0xfa, 0xce, 0xa3, 0x8c, 0xf0, 0x73, 0x48,
-	0x2d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x19, 0x06, 0x09, 0x02, 0x2f, 0x30, 0x1c,
-	0x3a, 0xe2, 0x52, 0xab, 0xe0, 0xba, 0x68, 0xef, 0xb2, 0xe6, 0x43, 0x3a, 0x4a, 0x48, 0x55, 0xbd,
-	0xa6, 0x54, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6e, 0x30, 0xd1, 0x06, 0xb6, 0x45,
-	0x1a, 0x79, 0x0e, 0xfa, 0x49, 0x46, 0xd0, 0x9e, 0x98, 0x7e, 0x24, 0x67, 0xe3, 0x0d, 0x37, 0xdd,
-	0xa0, 0xff, 0x00, 0x8a, 0xe2, 0x9f, 0x32, 0x4b, 0xea, 0x9f, 0x74, 0x3a, 0xdd, 0x7e, 0x5f, 0x5b,
-	0x43, 0x15, 0x28, 0xe0, 0xee, 0x00, 0x33, 0x0b, 0xab, 0x40, 0xe1, 0x71, 0x7b, 0xd0, 0x3e, 0xd0,
-	0x72, 0xfa, 0xf7, 0x61, 0xe3, 0x99, 0x69, 0x87, 0x59, 0x8c, 0x4b, 0xf7, 0x40, 0x9b, 0x8e, 0x95,
-	0xda, 0xd9, 0x4f, 0x69, 0x27, 0xbb, 0x68, 0xba, 0x97, 0x76, 0x38, 0xa3, 0x0f, 0x0d, 0x54, 0x12,
-	0x04, 0x52, 0x05, 0xec, 0x53, 0x7f, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0xa0,
-	0xc4, 0x4e, 0x1b, 0x6f, 0x12, 0x4a, 0xd3, 0xbf, 0xd3, 0x12, 0xa7, 0x51, 0x2b, 0x3a, 0x8d, 0x5a,
-	0xbb, 0xf2, 0xb4, 0xc2, 0xd1, 0x48, 0x74, 0x0b, 0x8a, 0xd4, 0x1e, 0xb9, 0xe6, 0x58, 0x7a, 0x0b,
-	0x49, 0xe9, 0x88, 0x19, 0x79, 0xb4, 0xb0, 0x34, 0xfc, 0x0e, 0xa0, 0x5d, 0x42, 0xc3, 0xc0, 0xbb,
-	0xca, 0xc4, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0xc1, 0x50, 0x6c, 0xc4, 0x32, 0x16, 0x04, 0xdb, 0x54,
-	0x29, 0x10, 0x89, 0xfd, 0x11, 0xa0, 0x7d, 0x97, 0x9d, 0x29, 0xd9, 0x14, 0xf1, 0x0f, 0x39, 0xb8,
-	0x91, 0x1a, 0x2f, 0x95, 0xb1, 0xfa, 0x3e, 0x64, 0x8e, 0x69, 0x42, 0xc5, 0x3e, 0x44, 0x47, 0x50,
-	0x14, 0x23, 0xa4, 0x24, 0xef, 0x2f, 0x01, 0x24, 0x8e, 0x29, 0x09, 0x27, 0x61, 0x16, 0x1a, 0xbd,
-	0xfa, 0x6e, 0x8d, 0xfe, 0x35, 0x68, 0xd1, 0xff, 0xa0, 0x6f, 0xd5, 0xcd, 0x57, 0x70, 0x63, 0xe8,
-	0x8d, 0xc7, 0x64, 0xc8, 0xac, 0xc1, 0xb0, 0xdd, 0x90, 0x04, 0x17, 0xe6, 0xf8, 0xed, 0x76, 0x83,
-	0xa6, 0xb3, 0xf6, 0xe5, 0x24, 0xfd, 0x05, 0x6c, 0x26, 0x16, 0x96, 0x8a, 0x78, 0x0c, 0x05, 0xca,
-	0x1a, 0xa4, 0x26, 0x3e, 0x5e, 0x52, 0x13, 0x14, 0x8b, 0xe9, 0xfa, 0x0d, 0x01, 0xde, 0xbd, 0x20,
-	0x6e, 0xfc, 0xb7, 0xf4, 0x5d, 0xd8, 0xec, 0x73, 0x33, 0xcd, 0x64, 0x87, 0x53, 0x13, 0xcf, 0xa5,
-	0x4c, 0x7c, 0x0b, 0x50, 0x12, 0x45, 0x1a, 0xe2, 0x15, 0x6c, 0x74, 0x2f, 0xc9, 0x30, 0x13, 0x72,
-	0x03, 0x4a, 0x43, 0xcf, 0x71, 0x4c, 0xd7, 0x6a, 0xe4, 0xee, 0xaa, 0xdb, 0x15, 0x1c, 0x91, 0xc9,
-	0xbd, 0xa8, 0x66, 0xdd, 0x8b, 0xfa, 0xdf, 0x29, 0xa0, 0x4d, 0xd7, 0x96, 0x82, 0x64, 0xdc, 0x87,
-	0x16, 0x03, 0x62, 0x6b, 0xd7, 0xb0, 0xa4, 0x64, 0x7b, 0xe4, 0x2e, 0x44, 0x3b, 0x09, 0x82, 0x84,
-	0x3b, 0x52, 0xaf, 0xe9, 0x8e, 0xf4, 0x3d, 0xf8, 0x9d, 0x88, 0x9d, 0x7e, 0x18, 0x10, 0xd3, 0xb1,
-	0xdd, 0xd1, 0xfe, 0xd1, 0x91, 0x4f, 0x04, 0xe3, 0x08, 0x41, 0xde, 0x32, 0x43, 0x53, 0x32, 0xc6,
-	0xbf, 0xd9, 0xa6, 0x1f, 0x8e, 0x3d, 0x1a, 0x6f, 0x7a, 0x4e, 0xe8, 0xff, 0xa1, 0x42, 0x63, 0x0e,
-	0x2a, 0x12, 0xef, 0x0b, 0x28, 0x50, 0x12, 0x4e, 0x7c, 0x69, 0x2a, 0xdd, 0xcc, 0x0c, 0x2f, 0xc6,
-	0x6b, 0xf5, 0x19, 0x18, 0x16, 0x98, 0x68, 0x04, 0xe5, 0x30, 0xbc, 0x32, 0xa8, 0xfd, 0xf3, 0x28,
-	0x20, 0x38, 0xb8, 0x2e, 0xfe, 0x80, 0x04, 0x8e, 0xed, 0x9a, 0xe3, 0xbe, 0xfd, 0x73, 0x82, 0x4b,
-	0x61, 0x78, 0xc5, 0x3e, 0xd0, 0x73, 0x66, 0xf0, 0x96, 0xed, 0x4a, 0xb1, 0x77, 0x56, 0x5d, 0x25,
-	0x21, 0x60, 0x2c, 0x10, 0x9b, 0x07, 0x50, 0xe0, 0xff, 0x69, 0x15, 0x43, 0xd4, 0x40, 0x0d, 0xc3,
-	0x2b, 0xce, 0x54, 0x19, 0xb3, 0xcf, 0xe6, 0x43, 0xa8, 0x25, 0xff, 0x01, 0x33, 0xa4, 0x33, 0x62,
-	0x8f, 0xce, 0x84, 0x81, 0x15, 0xb0, 0xa4, 0x98, 0x26, 0x5f, 0xdb, 0x96, 0x0c, 0x59, 0x0b, 0x58,
-	0x10, 0xfa, 0xbf, 0xe6, 0xe0, 0xce, 0x02, 0xc9, 0x48, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0x24,
-	0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xb3, 0x6d, 0x73, 0x0b, 0x8a, 0xe4, 0xd2,
-	0x0e, 0x89, 0x25, 0x45, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x77, 0x3b, 0x1d, 0xc2, 0x56, 0x27,
-	0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x0e, 0x94, 0xcd, 0xf1, 0xd8, 0x1b, 0x4e, 0xd5,
-	0x5a, 0xe2, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x3e, 0xf3, 0x68, 0xe8, 0x9a, 0x0e, 0x91, 0xce, 0x2b,
-	0xa6, 0xf5, 0x6f, 0x15, 0xb8, 0x39, 0x83, 0x27, 0xb5, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
-	0x07, 0x8d, 0xc4, 0x0d, 0xef, 0xc7, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
-	0x49, 0x92, 0x5b, 0x1c, 0x5f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xf5, 0x7f, 0x54, 0xe0, 0xa6, 0x3c,
-	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x96, 0x73, 0xef, 0x9a, 0x65, 0xbd, 0x01, 0xb7, 0x66, 0xf9, 0x92,
-	0x3e, 0xff, 0xff, 0xf2, 0x80, 0xe6, 0x6f, 0x97, 0xe8, 0x7b, 0x50, 0xa3, 0xc4, 0xb5, 0x0c, 0x71,
-	0x5e, 0x88, 0xa3, 0xac, 0x8c, 0xab, 0xac, 0x4d, 0x1c, 0x1c, 0x94, 0xb9, 0x40, 0x72, 0x29, 0xb9,
-	0x2d, 0x63, 0xfe, 0x8d, 0xce, 0xa0, 0xf6, 0x92, 0x1a, 0xf1, 0xda, 0xdc, 0xa0, 0xea, 0x99, 0xdd,
-	0xda, 0x3c, 0x1f, 0xad, 0xc7, 0xfd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8, 0x97, 0x0a,
-	0xdc, 0x8e, 0xc2, 0x8a, 0xa9, 0xf8, 0x1c, 0xcf, 0x22, 0xb4, 0x91, 0xbf, 0xab, 0x6e, 0xd7, 0x77,
-	0x8e, 0xaf, 0x21, 0xbf, 0xb9, 0xc6, 0x43, 0xcf, 0x22, 0xf8, 0xa6, 0xbb, 0xa0, 0x95, 0xa2, 0x16,
-	0xdc, 0x70, 0x26, 0x34, 0x34, 0x84, 0x15, 0x18, 0x72, 0x50, 0xa3, 0xc0, 0xe5, 0xb2, 0xc9, 0xba,
-	0x52, 0xb6, 0x8a, 0xce, 0x61, 0xdd, 0xf1, 0x26, 0x6e, 0x68, 0x0c, 0xf9, 0xfd, 0x87, 0x36, 0x8a,
-	0x4b, 0x5d, 0x8c, 0x17, 0x48, 0xe9, 0x90, 0xc1, 0x89, 0xdb, 0x14, 0xc5, 0x35, 0x27, 0x41, 0x31,
-	0x45, 0x06, 0xc4, 0xf1, 0x42, 0x62, 0x30, 0x7f, 0x49, 0x1b, 0x25, 0xa1, 0x48, 0xd1, 0xc6, 0x5c,
-	0x03, 0xd5, 0x5b, 0x50, 0x4d, 0x88, 0x19, 0x95, 0x21, 0xdf, 0x3b, 0xea, 0x75, 0xb5, 0x35, 0x04,
-	0x50, 0xec, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x35, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xb5, 0x9c,
-	0xde, 0x85, 0x5a, 0x72, 0x41, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0x33, 0x0e,
-	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0xdf, 0xa8, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
-	0x47, 0x11, 0xa9, 0x34, 0x73, 0x9a, 0xa2, 0xff, 0xbb, 0x0a, 0x5b, 0x8b, 0x64, 0x8f, 0x2c, 0xc8,
-	0x33, 0x3d, 0xca, 0x1b, 0xdf, 0xbb, 0x57, 0x23, 0x47, 0x67, 0xe6, 0xeb, 0x9b, 0xd2, 0xc5, 0x57,
-	0x30, 0xff, 0x46, 0x06, 0x14, 0xc7, 0xe6, 0x29, 0x19, 0xd3, 0x86, 0xca, 0x73, 0x22, 0x4f, 0xae,
-	0xb3, 0xf6, 0x01, 0x47, 0x12, 0x09, 0x11, 0x09, 0x8b, 0x06, 0x50, 0x65, 0x4e, 0x8c, 0x0a, 0xd1,
-	0x49, 0xbf, 0xba, 0x93, 0x71, 0x95, 0xbd, 0xe9, 0x4c, 0x9c, 0x84, 0x69, 0x3e, 0x80, 0x6a, 0x62,
-	0xb1, 0x05, 0xf9, 0x8c, 0xad, 0x64, 0x3e, 0xa3, 0x92, 0x4c, 0x4e, 0x3c, 0x9a, 0xd7, 0x01, 0x93,
-	0x11, 0x33, 0x82, 0xbd, 0xa3, 0xfe, 0x40, 0xdc, 0x1c, 0x9f, 0xe0, 0xa3, 0x93, 0x63, 0x4d, 0x61,
-	0x8d, 0x83, 0x76, 0xff, 0xa9, 0x96, 0x8b, 0x6d, 0x44, 0xd5, 0x3b, 0x50, 0x4d, 0xf0, 0x95, 0xf2,
-	0xda, 0x4a, 0xda, 0x6b, 0x33, 0xbf, 0x69, 0x5a, 0x56, 0x40, 0x28, 0x95, 0x7c, 0x44, 0xa4, 0xfe,
-	0x02, 0x2a, 0xbb, 0xbd, 0xbe, 0x84, 0x68, 0x40, 0x89, 0x92, 0x80, 0xfd, 0x6f, 0x9e, 0x99, 0xaa,
-	0xe0, 0x88, 0x64, 0xe0, 0x94, 0x98, 0xc1, 0xf0, 0x8c, 0x50, 0x79, 0xd6, 0xc7, 0x34, 0x9b, 0xe5,
-	0xf1, 0x0c, 0x8f, 0xd0, 0x5d, 0x05, 0x47, 0xa4, 0xfe, 0x3f, 0x25, 0x80, 0x69, 0xb6, 0x01, 0xd5,
-	0x21, 0x17, 0xfb, 0xe0, 0x9c, 0x6d, 0x31, 0x3b, 0x48, 0x9c, 0x31, 0xfc, 0x1b, 0xed, 0xc0, 0x4d,
-	0x87, 0x8e, 0x7c, 0x73, 0x78, 0x6e, 0xc8, 0x24, 0x81, 0xd8, 0xaa, 0xdc, 0x9f, 0xd5, 0xf0, 0x0d,
-	0xd9, 0x29, 0x77, 0xa2, 0xc0, 0x3d, 0x00, 0x95, 0xb8, 0x17, 0xdc, 0xf7, 0x54, 0x77, 0x3e, 0x5b,
-	0x3a, 0x0b, 0xd2, 0xea, 0xba, 0x17, 0xc2, 0x56, 0x18, 0x0c, 0x32, 0x00, 0x2c, 0x72, 0x61, 0x0f,
-	0x89, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
-	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x82, 0x21, 0x11, 0x0e, 0x28, 0xfb, 0x45, 0x05, 0x47,
-	0xf3, 0xf0, 0x14, 0x02, 0xed, 0x42, 0x91, 0xfb, 0x1d, 0xe6, 0x61, 0xd4, 0xef, 0x4c, 0xa9, 0xa6,
-	0xc1, 0xb8, 0x27, 0xc1, 0x72, 0x2e, 0x7a, 0x02, 0x25, 0xc1, 0x22, 0x6d, 0x94, 0x39, 0xcc, 0x47,
-	0x59, 0x9d, 0x22, 0x9f, 0x85, 0xa3, 0xd9, 0x4c, 0xab, 0x13, 0x4a, 0x82, 0x46, 0x45, 0x68, 0x95,
-	0x7d, 0xa3, 0xf7, 0xa0, 0x22, 0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda,
-	0x01, 0x7a, 0x1f, 0xaa, 0x22, 0xd6, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc,
-	0x37, 0x88, 0x01, 0x24, 0x08, 0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0,
-	0xc1, 0x23, 0xd4, 0x51, 0xe0, 0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f,
-	0xb0, 0xd6, 0x1e, 0x33, 0xae, 0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e,
-	0x79, 0xa7, 0x51, 0x57, 0x1c, 0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7,
-	0xa3, 0x05, 0xed, 0xfa, 0xd1, 0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6,
-	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
-	0xa5, 0xe6, 0x43, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
-	0x5c, 0xb8, 0xc1, 0xa5, 0xc8, 0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff,
-	0x6a, 0x47, 0x08, 0xf2, 0x86, 0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63,
-	0xdb, 0x9d, 0x5c, 0x26, 0xd6, 0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae,
-	0x51, 0x1f, 0xa7, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c,
-	0x7b, 0x41, 0x78, 0x68, 0xfa, 0x3e, 0xbb, 0xb4, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc,
-	0xc7, 0x50, 0x0f, 0xd4, 0xa1, 0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca,
-	0x3f, 0x03, 0x42, 0xcf, 0xa0, 0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79,
-	0xc8, 0x67, 0x4f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0xad,
-	0x14, 0x41, 0xe2, 0x18, 0x47, 0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe,
-	0xc4, 0xe0, 0x69, 0x7f, 0x61, 0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0,
-	0xf1, 0x26, 0x7e, 0x99, 0xf7, 0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1,
-	0xf0, 0x14, 0xe9, 0xb0, 0x1e, 0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3,
-	0xf2, 0xf0, 0x54, 0xff, 0x55, 0x0e, 0x36, 0x66, 0x58, 0x66, 0x57, 0x37, 0xe1, 0xf1, 0xa2, 0x4b,
-	0xb1, 0xa0, 0x98, 0xfb, 0x1b, 0xda, 0x56, 0x94, 0x4e, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0xaa,
-	0x33, 0x67, 0xfb, 0x6c, 0xfb, 0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf,
-	0xa1, 0x1e, 0x10, 0x7e, 0xe0, 0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6,
-	0x86, 0xd7, 0x23, 0x24, 0x46, 0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44,
-	0x2e, 0xae, 0x8c, 0x5c, 0x93, 0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c,
-	0xdc, 0x92, 0x32, 0x11, 0x44, 0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17,
-	0xcb, 0x4c, 0x65, 0xf2, 0x0c, 0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16,
-	0xea, 0x18, 0xb6, 0xcf, 0x25, 0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f,
-	0x6f, 0xe9, 0xc8, 0x8e, 0x7c, 0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85,
-	0x75, 0x7f, 0x33, 0xf1, 0x42, 0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0,
-	0x3a, 0x63, 0x83, 0xe8, 0x43, 0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48,
-	0x84, 0x8e, 0x55, 0xac, 0x89, 0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e,
-	0x41, 0x87, 0x5e, 0x40, 0x0c, 0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67,
-	0x6d, 0x6d, 0xeb, 0x15, 0x3b, 0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8,
-	0x60, 0x10, 0x4d, 0x1d, 0x7f, 0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4,
-	0xad, 0xc9, 0x21, 0xbc, 0x0d, 0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e,
-	0xb3, 0xf3, 0x5d, 0xd9, 0x56, 0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a,
-	0x43, 0x1c, 0xaa, 0xff, 0x0c, 0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65,
-	0x68, 0xc9, 0x1a, 0xf8, 0xd9, 0xfb, 0x1e, 0x54, 0xb8, 0xec, 0x13, 0x11, 0x3d, 0x8f, 0x3b, 0x79,
-	0x67, 0x13, 0xca, 0x01, 0x31, 0x2d, 0xcf, 0x1d, 0x47, 0xc9, 0xa0, 0x98, 0xd6, 0xbf, 0x81, 0xa2,
-	0x38, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3e, 0x1d, 0x9b, 0x52, 0x19, 0x85,
-	0xf2, 0x17, 0x48, 0xd1, 0x73, 0x3c, 0xed, 0xd0, 0xff, 0x53, 0x11, 0xf1, 0xa8, 0x78, 0x1b, 0x62,
-	0x81, 0x2b, 0x33, 0x72, 0x76, 0x5b, 0x16, 0x49, 0xa8, 0x88, 0x44, 0xfb, 0x50, 0x94, 0x61, 0x67,
-	0x6e, 0xd5, 0xa7, 0x35, 0x09, 0x10, 0xa5, 0xa4, 0x89, 0xbc, 0x90, 0x2f, 0x9b, 0x92, 0x26, 0x22,
-	0x25, 0x4d, 0xd8, 0x6d, 0x52, 0x06, 0xc4, 0x02, 0x2e, 0xcf, 0xe3, 0xe1, 0xaa, 0x15, 0xe7, 0xfd,
-	0x89, 0xfe, 0xdf, 0x4a, 0xec, 0xa6, 0xa2, 0xfc, 0x3c, 0xfa, 0x1a, 0xca, 0x6c, 0xc7, 0x1b, 0x8e,
-	0xe9, 0xcb, 0xd7, 0xe6, 0xce, 0x6a, 0xa9, 0xff, 0xe8, 0x10, 0x13, 0xe1, 0x6c, 0xc9, 0x17, 0x14,
-	0x73, 0x77, 0xec, 0x2a, 0x11, 0xb9, 0x3b, 0xf6, 0x8d, 0x3e, 0x80, 0xba, 0x39, 0x09, 0x3d, 0xc3,
-	0xb4, 0x2e, 0x48, 0x10, 0xda, 0x94, 0x48, 0xdd, 0xaf, 0xb3, 0xd6, 0x76, 0xd4, 0xd8, 0xfc, 0x0c,
-	0x6a, 0x49, 0xcc, 0xb7, 0x85, 0x19, 0x85, 0x64, 0x98, 0xf1, 0xa7, 0x00, 0xd3, 0x5c, 0x17, 0xb3,
-	0x11, 0x72, 0x69, 0xb3, 0x5b, 0xbd, 0xbc, 0xbb, 0x16, 0x70, 0x99, 0x35, 0x74, 0xd8, 0x7d, 0x2a,
-	0x9d, 0x88, 0x2f, 0x44, 0x89, 0x78, 0xb6, 0x99, 0xd9, 0xfe, 0x3b, 0xb7, 0xc7, 0xe3, 0x38, 0xff,
-	0x56, 0xf1, 0x3c, 0xe7, 0x29, 0x6f, 0xd0, 0x7f, 0x9b, 0x13, 0xb6, 0x22, 0x9e, 0x54, 0x32, 0xdd,
-	0x5d, 0xde, 0x95, 0xaa, 0x1f, 0x00, 0xd0, 0xd0, 0x0c, 0x58, 0xcc, 0x64, 0x46, 0x19, 0xc0, 0xe6,
-	0x5c, 0x26, 0x7f, 0x10, 0xd5, 0x78, 0xe0, 0x8a, 0x1c, 0xdd, 0x0e, 0xd1, 0xe7, 0x50, 0x1b, 0x7a,
-	0x8e, 0x3f, 0x26, 0x72, 0x72, 0xe1, 0xad, 0x93, 0xab, 0xf1, 0xf8, 0x76, 0x98, 0xc8, 0x3b, 0x16,
-	0xaf, 0x9b, 0x77, 0xfc, 0x8d, 0x22, 0x5e, 0x86, 0x92, 0x0f, 0x53, 0x68, 0xb4, 0xa0, 0xfa, 0xe1,
-	0xc9, 0x8a, 0xaf, 0x5c, 0xdf, 0x55, 0xfa, 0xd0, 0xfc, 0x3c, 0x4b, 0xad, 0xc1, 0x9b, 0xa3, 0xd8,
-	0x7f, 0x53, 0xa1, 0x12, 0x3f, 0x0a, 0xcd, 0xe9, 0xfe, 0x53, 0xa8, 0xc4, 0x05, 0x36, 0xd2, 0x41,
-	0x7c, 0xa7, 0x7a, 0xe2, 0xc1, 0xe8, 0x25, 0x20, 0x73, 0x34, 0x8a, 0xa3, 0x53, 0x63, 0x42, 0xcd,
-	0x51, 0xf4, 0x24, 0xf7, 0xe9, 0x12, 0x72, 0x88, 0x8e, 0xb3, 0x13, 0x36, 0x1f, 0x6b, 0xe6, 0x68,
-	0x94, 0x6a, 0x41, 0x7f, 0x06, 0x37, 0xd3, 0x6b, 0x18, 0xa7, 0x57, 0x86, 0x6f, 0x5b, 0xf2, 0x8e,
-	0xbc, 0xb7, 0xec, 0xbb, 0x58, 0x2b, 0x05, 0xff, 0xe5, 0xd5, 0xb1, 0x6d, 0x09, 0x99, 0xa3, 0x60,
-	0xae, 0xa3, 0xf9, 0x17, 0x70, 0xfb, 0x0d, 0xc3, 0x17, 0xe8, 0xa0, 0x97, 0xae, 0xf7, 0x58, 0x5d,
-	0x08, 0x09, 0xed, 0xfd, 0x5a, 0x11, 0xcf, 0x77, 0x69, 0x99, 0xb4, 0x93, 0x61, 0xf5, 0xbd, 0x8c,
-	0xeb, 0x74, 0x8e, 0x4f, 0x04, 0x3c, 0x8f, 0xa4, 0xbf, 0x9a, 0x89, 0xa4, 0xb3, 0xc6, 0x4f, 0x22,
-	0x20, 0x15, 0x40, 0x12, 0x41, 0xff, 0x17, 0x15, 0xca, 0x11, 0x3a, 0xbf, 0xe1, 0x5e, 0xd1, 0x90,
-	0x38, 0x46, 0x9c, 0x7e, 0x53, 0x30, 0x88, 0x26, 0x9e, 0x14, 0x7a, 0x0f, 0x2a, 0xec, 0x22, 0x2d,
-	0xba, 0x73, 0xbc, 0xbb, 0xcc, 0x1a, 0x78, 0xe7, 0xfb, 0x50, 0x0d, 0xbd, 0xd0, 0x1c, 0x1b, 0x21,
-	0x3f, 0xde, 0x55, 0x31, 0x9b, 0x37, 0xf1, 0xc3, 0x1d, 0xfd, 0x00, 0x36, 0xc3, 0xb3, 0xc0, 0x0b,
-	0xc3, 0x31, 0x0b, 0x2d, 0x79, 0xa0, 0x23, 0xe2, 0x92, 0x3c, 0xd6, 0xe2, 0x0e, 0x11, 0x00, 0x51,
-	0xe6, 0xbd, 0xa7, 0x83, 0x99, 0xe9, 0x72, 0x27, 0x92, 0xc7, 0xeb, 0x71, 0x2b, 0x33, 0x6d, 0x76,
-	0x78, 0xfa, 0x22, 0x80, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x0c, 0xd8, 0x70, 0x88, 0x49, 0x27,
-	0x01, 0xb1, 0x8c, 0x97, 0x36, 0x19, 0x5b, 0x22, 0x31, 0x51, 0xcf, 0x7c, 0x3b, 0x88, 0xc4, 0xd2,
-	0x7a, 0xcc, 0x67, 0xe3, 0x7a, 0x04, 0x27, 0x68, 0x16, 0x39, 0x88, 0x2f, 0xb4, 0x01, 0xd5, 0xfe,
-	0xf3, 0xfe, 0xa0, 0x7b, 0x68, 0x1c, 0x1e, 0xed, 0x76, 0x65, 0x49, 0x4f, 0xbf, 0x8b, 0x05, 0xa9,
-	0xb0, 0xfe, 0xc1, 0xd1, 0xa0, 0x7d, 0x60, 0x0c, 0xf6, 0x3b, 0x4f, 0xfb, 0x5a, 0x0e, 0xdd, 0x84,
-	0xcd, 0xc1, 0x1e, 0x3e, 0x1a, 0x0c, 0x0e, 0xba, 0xbb, 0xc6, 0x71, 0x17, 0xef, 0x1f, 0xed, 0xf6,
-	0x35, 0x15, 0x21, 0xa8, 0x4f, 0x9b, 0x07, 0xfb, 0x87, 0x5d, 0x2d, 0x8f, 0xaa, 0x50, 0x3a, 0xee,
-	0xe2, 0x4e, 0xb7, 0x37, 0xd0, 0x0a, 0xfa, 0xaf, 0x54, 0xa8, 0x26, 0xb4, 0xc8, 0x0c, 0x39, 0xa0,
-	0xe2, 0x1a, 0x92, 0xc7, 0xec, 0x93, 0x3f, 0x41, 0x9a, 0xc3, 0x33, 0xa1, 0x9d, 0x3c, 0x16, 0x04,
-	0xbf, 0x7a, 0x98, 0x97, 0x89, 0x7d, 0x9e, 0xc7, 0x65, 0xc7, 0xbc, 0x14, 0x20, 0xdf, 0x83, 0xda,
-	0x39, 0x09, 0x5c, 0x32, 0x96, 0xfd, 0x42, 0x23, 0x55, 0xd1, 0x26, 0x86, 0x6c, 0x83, 0x26, 0x87,
-	0x4c, 0x61, 0x84, 0x3a, 0xea, 0xa2, 0xfd, 0x30, 0x02, 0xdb, 0x82, 0x82, 0xe8, 0x2e, 0x89, 0xf5,
-	0x39, 0xc1, 0x8e, 0x29, 0xfa, 0xda, 0xf4, 0x79, 0xc8, 0x97, 0xc7, 0xfc, 0x1b, 0x9d, 0xce, 0xeb,
-	0xa7, 0xc8, 0xf5, 0xf3, 0x60, 0x79, 0x73, 0x7e, 0x93, 0x8a, 0xce, 0x62, 0x15, 0x95, 0x40, 0xc5,
-	0x51, 0x1d, 0x4c, 0xa7, 0xdd, 0xd9, 0x63, 0x6a, 0x59, 0x87, 0xca, 0x61, 0xfb, 0xa7, 0xc6, 0x49,
-	0x9f, 0x67, 0xb5, 0x91, 0x06, 0xb5, 0xa7, 0x5d, 0xdc, 0xeb, 0x1e, 0xc8, 0x16, 0x15, 0x6d, 0x81,
-	0x26, 0x5b, 0xa6, 0xe3, 0xf2, 0x0c, 0x41, 0x7c, 0x16, 0x50, 0x19, 0xf2, 0xfd, 0x67, 0xed, 0x63,
-	0xad, 0xa8, 0xff, 0x57, 0x0e, 0x36, 0xc4, 0xb1, 0x10, 0xbf, 0xd8, 0xbf, 0xf9, 0xc5, 0x32, 0x99,
-	0xe5, 0xc9, 0xa5, 0xb3, 0x3c, 0x51, 0x10, 0xca, 0x4f, 0x75, 0x75, 0x1a, 0x84, 0xf2, 0xec, 0x50,
-	0xca, 0xe3, 0xe7, 0x97, 0xf1, 0xf8, 0x0d, 0x28, 0x39, 0x84, 0xc6, 0x7a, 0xab, 0xe0, 0x88, 0x44,
-	0x36, 0x54, 0x4d, 0xd7, 0xf5, 0x42, 0x53, 0xa4, 0x4e, 0x8b, 0x4b, 0x1d, 0x86, 0x33, 0xff, 0xb8,
-	0xd5, 0x9e, 0x22, 0x09, 0xc7, 0x9c, 0xc4, 0x6e, 0xfe, 0x04, 0xb4, 0xd9, 0x01, 0xcb, 0x1c, 0x87,
-	0xdf, 0xff, 0xe1, 0xf4, 0x34, 0x24, 0x6c, 0x5f, 0xc8, 0x37, 0x07, 0x6d, 0x8d, 0x11, 0xf8, 0xa4,
-	0xd7, 0xdb, 0xef, 0x3d, 0xd1, 0x14, 0x04, 0x50, 0xec, 0xfe, 0x74, 0x7f, 0xd0, 0xdd, 0xd5, 0x72,
-	0x3b, 0xbf, 0xde, 0x84, 0xa2, 0x60, 0x12, 0x7d, 0x2b, 0x23, 0x81, 0x64, 0x35, 0x28, 0xfa, 0xc9,
-	0xd2, 0x11, 0x75, 0xaa, 0xc2, 0xb4, 0xf9, 0x68, 0xe5, 0xf9, 0xf2, 0xf5, 0x6d, 0x0d, 0xfd, 0x8d,
-	0x02, 0xb5, 0xd4, 0xcb, 0x5b, 0xd6, 0xd4, 0xf1, 0x82, 0xe2, 0xd3, 0xe6, 0x8f, 0x57, 0x9a, 0x1b,
-	0xf3, 0xf2, 0x4b, 0x05, 0xaa, 0x89, 0xb2, 0x4b, 0xf4, 0x60, 0x95, 0x52, 0x4d, 0xc1, 0xc9, 0x67,
-	0xab, 0x57, 0x79, 0xea, 0x6b, 0x1f, 0x2b, 0xe8, 0xaf, 0x15, 0xa8, 0x26, 0x0a, 0x10, 0x33, 0xb3,
-	0x32, 0x5f, 0x2e, 0x99, 0x99, 0x95, 0x45, 0xf5, 0x8e, 0x6b, 0xe8, 0x2f, 0x15, 0xa8, 0xc4, 0xc5,
-	0x84, 0xe8, 0xfe, 0xf2, 0xe5, 0x87, 0x82, 0x89, 0x4f, 0x57, 0xad, 0x5b, 0xd4, 0xd7, 0xd0, 0x9f,
-	0x43, 0x39, 0xaa, 0xbc, 0x43, 0x59, 0x4f, 0xaf, 0x99, 0xb2, 0xbe, 0xe6, 0xfd, 0xa5, 0xe7, 0x25,
-	0x97, 0x8f, 0xca, 0xe1, 0x32, 0x2f, 0x3f, 0x53, 0xb8, 0xd7, 0xbc, 0xbf, 0xf4, 0xbc, 0x78, 0x79,
-	0x66, 0x09, 0x89, 0xaa, 0xb9, 0xcc, 0x96, 0x30, 0x5f, 0xae, 0x97, 0xd9, 0x12, 0x16, 0x15, 0xe9,
-	0x09, 0x46, 0x12, 0x75, 0x77, 0x99, 0x19, 0x99, 0xaf, 0xed, 0xcb, 0xcc, 0xc8, 0x82, 0x32, 0x3f,
-	0x7d, 0x0d, 0xfd, 0x42, 0x49, 0xde, 0x0b, 0xee, 0x2f, 0x5d, 0x5e, 0xb6, 0xa4, 0x49, 0xce, 0x15,
-	0xb8, 0xf1, 0x0d, 0xfa, 0x0b, 0x99, 0xc5, 0x10, 0xd5, 0x69, 0x68, 0x19, 0xb0, 0x54, 0x41, 0x5b,
-	0xf3, 0x93, 0xd5, 0x0e, 0x1b, 0xce, 0xc4, 0x5f, 0x29, 0x00, 0xd3, 0x3a, 0xb6, 0xcc, 0x4c, 0xcc,
-	0x15, 0xd0, 0x35, 0x1f, 0xac, 0x30, 0x33, 0xb9, 0x41, 0xa2, 0x3a, 0x9b, 0xcc, 0x1b, 0x64, 0xa6,
-	0xce, 0x2e, 0xf3, 0x06, 0x99, 0xad, 0x91, 0xd3, 0xd7, 0xd0, 0x3f, 0x29, 0xb0, 0x39, 0x57, 0xe7,
-	0x83, 0x1e, 0x5d, 0xb3, 0xd4, 0xab, 0xf9, 0xc5, 0xea, 0x00, 0x11, 0x6b, 0xdb, 0xca, 0xc7, 0x0a,
-	0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0xf5, 0x0f, 0x99, 0x4f, 0xa9, 0x05, 0x15, 0x43, 0xcd, 0x87, 0xab,
-	0x4d, 0x8e, 0xa5, 0xf5, 0xf7, 0x0a, 0xd4, 0xd3, 0xa5, 0x30, 0xe8, 0xe1, 0x72, 0x6e, 0x61, 0x86,
-	0xa1, 0xcf, 0x57, 0x9c, 0x1d, 0x71, 0xf4, 0x65, 0xe9, 0x8f, 0x0b, 0x22, 0x7a, 0x2b, 0xf2, 0x9f,
-	0x1f, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x76, 0x8b, 0xb6, 0x52, 0xb4, 0x33, 0x00, 0x00,
+	// 4190 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0xcd, 0x6f, 0x1b, 0x49,
+	0x76, 0x77, 0x93, 0x22, 0x45, 0x3e, 0x8a, 0x14, 0x55, 0x92, 0x6c, 0x9a, 0x93, 0xec, 0x78, 0x3b,
+	0x98, 0xc0, 0xd8, 0x9d, 0xa1, 0x67, 0xb5, 0xd9, 0xf1, 0xc7, 0xda, 0xe3, 0xa1, 0x29, 0xda, 0xe2,
+	0x58, 0x22, 0x95, 0x22, 0x05, 0xaf, 0xe3, 0xec, 0x74, 0x5a, 0xdd, 0x65, 0xb2, 0x2d, 0xf6, 0xc7,
+	0x74, 0x37, 0x6d, 0x69, 0x83, 0x20, 0xc1, 0x06, 0x08, 0x36, 0x40, 0x82, 0xe4, 0x32, 0x99, 0x4b,
+	0xae, 0x39, 0x04, 0xf9, 0x07, 0x82, 0x04, 0x9b, 0x4b, 0x80, 0xe4, 0x98, 0x7b, 0x90, 0x4b, 0x72,
+	0xca, 0x35, 0xff, 0x40, 0x10, 0xd4, 0x57, 0xb3, 0x9b, 0xa4, 0xd7, 0x4d, 0xca, 0xc7, 0x9c, 0xd4,
+	0xef, 0x55, 0xbd, 0x5f, 0x3d, 0xbe, 0x7a, 0xf5, 0xea, 0x55, 0xd5, 0x13, 0xa8, 0xde, 0x78, 0x32,
+	0xb4, 0x9c, 0xe0, 0x96, 0xe9, 0x5b, 0xaf, 0x89, 0x1f, 0xdc, 0xf2, 0x7c, 0x37, 0x74, 0x05, 0xd5,
+	0x60, 0x04, 0xfa, 0x68, 0xa4, 0x07, 0x23, 0xcb, 0x70, 0x7d, 0xaf, 0xe1, 0xb8, 0xb6, 0x6e, 0x36,
+	0x84, 0x4c, 0x43, 0xc8, 0xf0, 0x6e, 0xf5, 0xef, 0x0c, 0x5d, 0x77, 0x38, 0x26, 0x1c, 0xe1, 0x74,
+	0xf2, 0xf2, 0x96, 0x39, 0xf1, 0xf5, 0xd0, 0x72, 0x1d, 0xd1, 0xfe, 0xe1, 0x6c, 0x7b, 0x68, 0xd9,
+	0x24, 0x08, 0x75, 0xdb, 0x13, 0x1d, 0x3e, 0x92, 0xba, 0x04, 0x23, 0xdd, 0x27, 0xe6, 0xad, 0x91,
+	0x31, 0x0e, 0x3c, 0x62, 0xd0, 0xbf, 0x1a, 0xfd, 0x10, 0xdd, 0x3e, 0x9e, 0xe9, 0x16, 0x84, 0xfe,
+	0xc4, 0x08, 0xa5, 0xe6, 0x7a, 0x18, 0xfa, 0xd6, 0xe9, 0x24, 0x24, 0xbc, 0xb7, 0x7a, 0x1d, 0xae,
+	0x0d, 0xf4, 0xe0, 0xac, 0xe5, 0x3a, 0x2f, 0xad, 0x61, 0xdf, 0x18, 0x11, 0x5b, 0xc7, 0xe4, 0xeb,
+	0x09, 0x09, 0x42, 0xf5, 0x77, 0xa1, 0x36, 0xdf, 0x14, 0x78, 0xae, 0x13, 0x10, 0xf4, 0x05, 0xac,
+	0xd1, 0x21, 0x6b, 0xca, 0x0d, 0xe5, 0x66, 0x69, 0xef, 0xe3, 0xc6, 0xdb, 0x4c, 0xc0, 0x75, 0x68,
+	0x08, 0x55, 0x1b, 0x7d, 0x8f, 0x18, 0x98, 0x49, 0xaa, 0xbb, 0xb0, 0xdd, 0xd2, 0x3d, 0xfd, 0xd4,
+	0x1a, 0x5b, 0xa1, 0x45, 0x02, 0x39, 0xe8, 0x04, 0x76, 0x92, 0x6c, 0x31, 0xe0, 0x4f, 0x61, 0xc3,
+	0x88, 0xf1, 0xc5, 0xc0, 0x77, 0x1b, 0xa9, 0x6c, 0xdf, 0xd8, 0x67, 0x54, 0x02, 0x38, 0x01, 0xa7,
+	0xee, 0x00, 0x7a, 0x6c, 0x39, 0x43, 0xe2, 0x7b, 0xbe, 0xe5, 0x84, 0x52, 0x99, 0x5f, 0x66, 0x61,
+	0x3b, 0xc1, 0x16, 0xca, 0xbc, 0x02, 0x88, 0xec, 0x48, 0x55, 0xc9, 0xde, 0x2c, 0xed, 0x7d, 0x99,
+	0x52, 0x95, 0x05, 0x78, 0x8d, 0x66, 0x04, 0xd6, 0x76, 0x42, 0xff, 0x02, 0xc7, 0xd0, 0xd1, 0x57,
+	0x90, 0x1f, 0x11, 0x7d, 0x1c, 0x8e, 0x6a, 0x99, 0x1b, 0xca, 0xcd, 0xca, 0xde, 0xe3, 0x4b, 0x8c,
+	0x73, 0xc0, 0x80, 0xfa, 0xa1, 0x1e, 0x12, 0x2c, 0x50, 0xd1, 0x27, 0x80, 0xf8, 0x97, 0x66, 0x92,
+	0xc0, 0xf0, 0x2d, 0x8f, 0xba, 0x64, 0x2d, 0x7b, 0x43, 0xb9, 0x59, 0xc4, 0x5b, 0xbc, 0x65, 0x7f,
+	0xda, 0x50, 0xf7, 0x60, 0x73, 0x46, 0x5b, 0x54, 0x85, 0xec, 0x19, 0xb9, 0x60, 0x33, 0x52, 0xc4,
+	0xf4, 0x13, 0x3d, 0x81, 0xdc, 0x6b, 0x7d, 0x3c, 0x21, 0x4c, 0xe5, 0xd2, 0xde, 0x0f, 0xde, 0xe5,
+	0x1e, 0xc2, 0x45, 0xa7, 0x76, 0xc0, 0x5c, 0xfe, 0x5e, 0xe6, 0x8e, 0xa2, 0xde, 0x85, 0x52, 0x4c,
+	0x6f, 0x54, 0x01, 0x38, 0xe9, 0xee, 0xb7, 0x07, 0xed, 0xd6, 0xa0, 0xbd, 0x5f, 0xbd, 0x82, 0xca,
+	0x50, 0x3c, 0xe9, 0x1e, 0xb4, 0x9b, 0x87, 0x83, 0x83, 0xe7, 0x55, 0x05, 0x95, 0x60, 0x5d, 0x12,
+	0x19, 0xf5, 0x1c, 0x10, 0x26, 0x86, 0xfb, 0x9a, 0xf8, 0xd4, 0x91, 0xc5, 0xac, 0xa2, 0x6b, 0xb0,
+	0x1e, 0xea, 0xc1, 0x99, 0x66, 0x99, 0x42, 0xe7, 0x3c, 0x25, 0x3b, 0x26, 0xea, 0x40, 0x7e, 0xa4,
+	0x3b, 0xe6, 0xf8, 0xdd, 0x7a, 0x27, 0x4d, 0x4d, 0xc1, 0x0f, 0x98, 0x20, 0x16, 0x00, 0xd4, 0xbb,
+	0x13, 0x23, 0xf3, 0x09, 0x50, 0x9f, 0x43, 0xb5, 0x1f, 0xea, 0x7e, 0x18, 0x57, 0xa7, 0x0d, 0x6b,
+	0x74, 0xfc, 0x9a, 0xb2, 0xf4, 0x98, 0x7c, 0x65, 0x62, 0x26, 0xae, 0xfe, 0x4f, 0x06, 0xb6, 0x62,
+	0xd8, 0xc2, 0x53, 0x9f, 0x41, 0xde, 0x27, 0xc1, 0x64, 0x1c, 0x32, 0xf8, 0xca, 0xde, 0xc3, 0x94,
+	0xf0, 0x73, 0x48, 0x0d, 0xcc, 0x60, 0xb0, 0x80, 0x43, 0x37, 0xa1, 0xca, 0x25, 0x34, 0xe2, 0xfb,
+	0xae, 0xaf, 0xd9, 0xc1, 0x90, 0x59, 0xad, 0x88, 0x2b, 0x9c, 0xdf, 0xa6, 0xec, 0xa3, 0x60, 0x18,
+	0xb3, 0x6a, 0xf6, 0x92, 0x56, 0x45, 0x3a, 0x54, 0x1d, 0x12, 0xbe, 0x71, 0xfd, 0x33, 0x8d, 0x9a,
+	0xd6, 0xb7, 0x4c, 0x52, 0x5b, 0x63, 0xa0, 0x9f, 0xa5, 0x04, 0xed, 0x72, 0xf1, 0x9e, 0x90, 0xc6,
+	0x9b, 0x4e, 0x92, 0xa1, 0x7e, 0x1f, 0xf2, 0xfc, 0x97, 0x52, 0x4f, 0xea, 0x9f, 0xb4, 0x5a, 0xed,
+	0x7e, 0xbf, 0x7a, 0x05, 0x15, 0x21, 0x87, 0xdb, 0x03, 0x4c, 0x3d, 0xac, 0x08, 0xb9, 0xc7, 0xcd,
+	0x41, 0xf3, 0xb0, 0x9a, 0x51, 0xbf, 0x07, 0x9b, 0xcf, 0x74, 0x2b, 0x4c, 0xe3, 0x5c, 0xaa, 0x0b,
+	0xd5, 0x69, 0x5f, 0x31, 0x3b, 0x9d, 0xc4, 0xec, 0xa4, 0x37, 0x4d, 0xfb, 0xdc, 0x0a, 0x67, 0xe6,
+	0xa3, 0x0a, 0x59, 0xe2, 0xfb, 0x62, 0x0a, 0xe8, 0xa7, 0xfa, 0x06, 0x36, 0xfb, 0xa1, 0xeb, 0xa5,
+	0xf2, 0xfc, 0x1f, 0xc2, 0x3a, 0xdd, 0x6d, 0xdc, 0x49, 0x28, 0x5c, 0xff, 0x7a, 0x83, 0xef, 0x46,
+	0x0d, 0xb9, 0x1b, 0x35, 0xf6, 0xc5, 0x6e, 0x85, 0x65, 0x4f, 0x74, 0x15, 0xf2, 0x81, 0x35, 0x74,
+	0xf4, 0xb1, 0x88, 0x16, 0x82, 0x52, 0x11, 0x54, 0xa7, 0x03, 0x0b, 0xc7, 0x6f, 0x01, 0xda, 0x27,
+	0x41, 0xe8, 0xbb, 0x17, 0xa9, 0xf4, 0xd9, 0x81, 0xdc, 0x4b, 0xd7, 0x37, 0xf8, 0x42, 0x2c, 0x60,
+	0x4e, 0xd0, 0x45, 0x95, 0x00, 0x11, 0xd8, 0x9f, 0x00, 0xea, 0x38, 0x74, 0x4f, 0x49, 0x37, 0x11,
+	0x7f, 0x99, 0x81, 0xed, 0x44, 0x7f, 0x31, 0x19, 0xab, 0xaf, 0x43, 0x1a, 0x98, 0x26, 0x01, 0x5f,
+	0x87, 0xa8, 0x07, 0x79, 0xde, 0x43, 0x58, 0xf2, 0xf6, 0x12, 0x40, 0x7c, 0x9b, 0x12, 0x70, 0x02,
+	0x66, 0xa1, 0xd3, 0x67, 0xdf, 0xaf, 0xd3, 0xbf, 0x81, 0xaa, 0xfc, 0x1d, 0xc1, 0x3b, 0xe7, 0xe6,
+	0x4b, 0xd8, 0x36, 0xdc, 0xf1, 0x98, 0x18, 0xd4, 0x1b, 0x34, 0xcb, 0x09, 0x89, 0xff, 0x5a, 0x1f,
+	0xbf, 0xdb, 0x6f, 0xd0, 0x54, 0xaa, 0x23, 0x84, 0xd4, 0x17, 0xb0, 0x15, 0x1b, 0x58, 0x4c, 0xc4,
+	0x63, 0xc8, 0x05, 0x94, 0x21, 0x66, 0xe2, 0xd3, 0x25, 0x67, 0x22, 0xc0, 0x5c, 0x5c, 0xdd, 0xe6,
+	0xe0, 0xed, 0xd7, 0xc4, 0x89, 0x7e, 0x96, 0xba, 0x0f, 0x5b, 0x7d, 0xe6, 0xa6, 0xa9, 0xfc, 0x70,
+	0xea, 0xe2, 0x99, 0x84, 0x8b, 0xef, 0x00, 0x8a, 0xa3, 0x08, 0x47, 0xbc, 0x80, 0xcd, 0xf6, 0x39,
+	0x31, 0x52, 0x21, 0xd7, 0x60, 0xdd, 0x70, 0x6d, 0x5b, 0x77, 0xcc, 0x5a, 0xe6, 0x46, 0xf6, 0x66,
+	0x11, 0x4b, 0x32, 0xbe, 0x16, 0xb3, 0x69, 0xd7, 0xa2, 0xfa, 0xe7, 0x0a, 0x54, 0xa7, 0x63, 0x0b,
+	0x43, 0x52, 0xed, 0x43, 0x93, 0x02, 0xd1, 0xb1, 0x37, 0xb0, 0xa0, 0x04, 0x5f, 0x86, 0x0b, 0xce,
+	0x27, 0xbe, 0x1f, 0x0b, 0x47, 0xd9, 0x4b, 0x86, 0x23, 0xf5, 0x00, 0x7e, 0x4d, 0xaa, 0xd3, 0x0f,
+	0x7d, 0xa2, 0xdb, 0x96, 0x33, 0xec, 0xf4, 0x7a, 0x1e, 0xe1, 0x8a, 0x23, 0x04, 0x6b, 0xa6, 0x1e,
+	0xea, 0x42, 0x31, 0xf6, 0x4d, 0x17, 0xbd, 0x31, 0x76, 0x83, 0x68, 0xd1, 0x33, 0x42, 0xfd, 0xd7,
+	0x2c, 0xd4, 0xe6, 0xa0, 0xa4, 0x79, 0x5f, 0x40, 0x2e, 0x20, 0xe1, 0xc4, 0x13, 0xae, 0xd2, 0x4e,
+	0xad, 0xf0, 0x62, 0xbc, 0x46, 0x9f, 0x82, 0x61, 0x8e, 0x89, 0x86, 0x50, 0x08, 0xc3, 0x0b, 0x2d,
+	0xb0, 0x7e, 0x26, 0x13, 0x82, 0xc3, 0xcb, 0xe2, 0x0f, 0x88, 0x6f, 0x5b, 0x8e, 0x3e, 0xee, 0x5b,
+	0x3f, 0x23, 0x78, 0x3d, 0x0c, 0x2f, 0xe8, 0x07, 0x7a, 0x4e, 0x1d, 0xde, 0xb4, 0x1c, 0x61, 0xf6,
+	0xd6, 0xaa, 0xa3, 0xc4, 0x0c, 0x8c, 0x39, 0x62, 0xfd, 0x10, 0x72, 0xec, 0x37, 0xad, 0xe2, 0x88,
+	0x55, 0xc8, 0x86, 0xe1, 0x05, 0x53, 0xaa, 0x80, 0xe9, 0x67, 0xfd, 0x3e, 0x6c, 0xc4, 0x7f, 0x01,
+	0x75, 0xa4, 0x11, 0xb1, 0x86, 0x23, 0xee, 0x60, 0x39, 0x2c, 0x28, 0x3a, 0x93, 0x6f, 0x2c, 0x53,
+	0xa4, 0xac, 0x39, 0xcc, 0x09, 0xf5, 0xef, 0x33, 0x70, 0x7d, 0x81, 0x65, 0x84, 0xb3, 0xbe, 0x48,
+	0x38, 0xeb, 0x7b, 0xb2, 0x82, 0xf4, 0xf8, 0x17, 0x09, 0x8f, 0x7f, 0x8f, 0xe0, 0x74, 0xd9, 0x5c,
+	0x85, 0x3c, 0x39, 0xb7, 0x42, 0x62, 0x0a, 0x53, 0x09, 0x2a, 0xb6, 0x9c, 0xd6, 0x2e, 0xbb, 0x9c,
+	0x8e, 0x60, 0xa7, 0xe5, 0x13, 0x3d, 0x24, 0x22, 0x94, 0x4b, 0xff, 0xbf, 0x0e, 0x05, 0x7d, 0x3c,
+	0x76, 0x8d, 0xe9, 0xb4, 0xae, 0x33, 0xba, 0x63, 0xa2, 0x3a, 0x14, 0x46, 0x6e, 0x10, 0x3a, 0xba,
+	0x4d, 0x44, 0xf0, 0x8a, 0x68, 0xf5, 0x1b, 0x05, 0x76, 0x67, 0xf0, 0xc4, 0x2c, 0x9c, 0x42, 0xc5,
+	0x0a, 0xdc, 0x31, 0xfb, 0x81, 0x5a, 0xec, 0x84, 0xf7, 0xe3, 0xe5, 0xb6, 0x9a, 0x8e, 0xc4, 0x60,
+	0x07, 0xbe, 0xb2, 0x15, 0x27, 0x99, 0xc7, 0xb1, 0xc1, 0x4d, 0xb1, 0xd2, 0x25, 0xa9, 0xfe, 0x95,
+	0x02, 0xbb, 0x62, 0x87, 0x4f, 0xff, 0x43, 0xe7, 0x55, 0xce, 0xbc, 0x6f, 0x95, 0xd5, 0x1a, 0x5c,
+	0x9d, 0xd5, 0x4b, 0xc4, 0xfc, 0x7d, 0xd8, 0x6d, 0x8d, 0x88, 0x71, 0xe6, 0xb9, 0x96, 0x93, 0x2a,
+	0xff, 0xa0, 0xa1, 0xcf, 0xd3, 0xc5, 0xda, 0x28, 0x62, 0xf6, 0x4d, 0xf1, 0x67, 0x51, 0x04, 0xfe,
+	0x2d, 0xd8, 0x3d, 0xf6, 0xc9, 0x4b, 0x12, 0x1a, 0xa3, 0x8e, 0xad, 0x0f, 0xa3, 0x83, 0x32, 0xf5,
+	0x3a, 0x8b, 0x31, 0xd8, 0xf9, 0xb3, 0x88, 0x05, 0x45, 0xa1, 0x66, 0x05, 0x04, 0x94, 0x4b, 0x4f,
+	0x43, 0x41, 0xe8, 0xfa, 0xe4, 0xfd, 0x1f, 0x3f, 0x16, 0xfe, 0xaa, 0x7f, 0xc9, 0xc0, 0x76, 0x62,
+	0xc4, 0xff, 0x3f, 0x94, 0xac, 0x96, 0x9f, 0xfd, 0x53, 0x0e, 0xd0, 0xfc, 0x15, 0x06, 0xfa, 0x2e,
+	0x6c, 0x04, 0xc4, 0x31, 0x35, 0x9e, 0x94, 0xf0, 0x7c, 0xa9, 0x80, 0x4b, 0x94, 0xc7, 0xb3, 0x93,
+	0x80, 0x4e, 0x0b, 0x39, 0x17, 0x4b, 0xa2, 0x80, 0xd9, 0x37, 0x1a, 0xc1, 0xc6, 0xcb, 0x40, 0x8b,
+	0x1c, 0x9c, 0x59, 0xa0, 0x92, 0x7a, 0xef, 0x9c, 0xd7, 0xa3, 0xf1, 0xb8, 0x1f, 0x2d, 0x1e, 0x5c,
+	0x7a, 0x19, 0x44, 0x04, 0xfa, 0x85, 0x02, 0xd7, 0xa4, 0x6d, 0xa6, 0x6b, 0xd4, 0x76, 0x4d, 0x12,
+	0xd4, 0xd6, 0x6e, 0x64, 0x6f, 0x56, 0xf6, 0x8e, 0x2f, 0xb1, 0x48, 0xe7, 0x98, 0x47, 0xae, 0x49,
+	0xf0, 0xae, 0xb3, 0x80, 0x1b, 0xa0, 0x06, 0x6c, 0xdb, 0x93, 0x20, 0xd4, 0x78, 0xa8, 0xd1, 0x44,
+	0xa7, 0x5a, 0x8e, 0xd9, 0x65, 0x8b, 0x36, 0x25, 0x02, 0x22, 0x3a, 0x83, 0xb2, 0xed, 0x4e, 0x9c,
+	0x50, 0x33, 0x98, 0x97, 0x07, 0xb5, 0xfc, 0x52, 0xb7, 0x2f, 0x0b, 0xac, 0x74, 0x44, 0xe1, 0xf8,
+	0x9a, 0x09, 0xf0, 0x86, 0x1d, 0xa3, 0xe8, 0x44, 0xfa, 0xc4, 0x76, 0x43, 0xa2, 0xd1, 0xb5, 0x14,
+	0xd4, 0xd6, 0xf9, 0x44, 0x72, 0x1e, 0x75, 0xb9, 0x00, 0x7d, 0x07, 0xc0, 0x88, 0x22, 0x44, 0xad,
+	0xc0, 0x3a, 0xc4, 0x38, 0xe8, 0x23, 0xa8, 0xb0, 0x00, 0xa0, 0x79, 0x62, 0xf1, 0xd7, 0x8a, 0xac,
+	0x4f, 0x99, 0x71, 0x65, 0x44, 0x50, 0x1b, 0x50, 0x8a, 0xcd, 0x16, 0x2a, 0xc0, 0x5a, 0xb7, 0xd7,
+	0x6d, 0x57, 0xaf, 0x20, 0x80, 0x7c, 0xeb, 0x00, 0xf7, 0x7a, 0x03, 0x7e, 0xc2, 0xed, 0x1c, 0x35,
+	0x9f, 0xb4, 0xab, 0x19, 0xb5, 0x0d, 0x1b, 0x71, 0xbd, 0x11, 0x82, 0xca, 0x49, 0xf7, 0x69, 0xb7,
+	0xf7, 0xac, 0xab, 0x1d, 0xf5, 0x4e, 0xba, 0x03, 0x7a, 0x36, 0xae, 0x00, 0x34, 0xbb, 0xcf, 0xa7,
+	0x74, 0x19, 0x8a, 0xdd, 0x9e, 0x24, 0x95, 0x7a, 0xa6, 0xaa, 0xa8, 0xff, 0x9c, 0x85, 0x9d, 0x45,
+	0x53, 0x88, 0x4c, 0x58, 0xa3, 0xee, 0x20, 0x02, 0xc1, 0xfb, 0xf7, 0x06, 0x86, 0xbe, 0x28, 0x38,
+	0x21, 0x0d, 0xf2, 0x63, 0xfd, 0x94, 0x8c, 0x83, 0x5a, 0x96, 0xdd, 0xdf, 0x3d, 0xb9, 0xcc, 0xd8,
+	0x87, 0x0c, 0x89, 0x5f, 0xde, 0x09, 0x58, 0x34, 0x80, 0x12, 0xdd, 0x70, 0x03, 0x6e, 0x3a, 0x11,
+	0x12, 0xf6, 0x52, 0x8e, 0x72, 0x30, 0x95, 0xc4, 0x71, 0x98, 0xfa, 0x5d, 0x28, 0xc5, 0x06, 0x5b,
+	0x70, 0xf7, 0xb6, 0x13, 0xbf, 0x7b, 0x2b, 0xc6, 0x2f, 0xd2, 0x1e, 0xc2, 0xce, 0x22, 0x1b, 0x51,
+	0x27, 0x38, 0xe8, 0xf5, 0x07, 0xfc, 0x96, 0xe3, 0x09, 0xee, 0x9d, 0x1c, 0x57, 0x15, 0xca, 0x1c,
+	0x34, 0xfb, 0x4f, 0xab, 0x99, 0xc8, 0x47, 0xb2, 0x6a, 0x0b, 0x4a, 0x31, 0xbd, 0x12, 0x19, 0x86,
+	0x92, 0xcc, 0x30, 0xe8, 0x1e, 0xaf, 0x9b, 0xa6, 0x4f, 0x82, 0x40, 0xe8, 0x21, 0x49, 0xf5, 0x0b,
+	0xd8, 0x3a, 0x09, 0x88, 0xdf, 0xd5, 0x6d, 0x12, 0x78, 0xba, 0x41, 0x98, 0x1b, 0x5c, 0x83, 0x75,
+	0x2a, 0x2a, 0x37, 0xcb, 0x32, 0xce, 0x53, 0x92, 0x6f, 0x96, 0x51, 0xfe, 0x5d, 0xc6, 0xec, 0x5b,
+	0x7d, 0x01, 0xc5, 0xfd, 0x6e, 0x5f, 0x28, 0x51, 0x83, 0xf5, 0x80, 0xf8, 0xd4, 0x72, 0x62, 0x1f,
+	0x94, 0x24, 0x55, 0x2f, 0x20, 0xba, 0x6f, 0x8c, 0x48, 0x20, 0x32, 0xdb, 0x88, 0xa6, 0x52, 0x2e,
+	0xbb, 0xcf, 0xe4, 0xb3, 0x5f, 0xc4, 0x92, 0x54, 0xff, 0xbd, 0x00, 0x30, 0xdd, 0xdc, 0x50, 0x05,
+	0x32, 0xd1, 0x06, 0x9e, 0xb1, 0x98, 0x3e, 0xb1, 0x8c, 0x8a, 0x7d, 0xa3, 0x3d, 0xd8, 0xb5, 0x83,
+	0xa1, 0xa7, 0x1b, 0x67, 0x9a, 0xd8, 0x7d, 0x78, 0xcc, 0x60, 0x81, 0x75, 0x03, 0x6f, 0x8b, 0x46,
+	0x11, 0x12, 0x38, 0xee, 0x21, 0x64, 0x89, 0xf3, 0x9a, 0x05, 0xc1, 0xd2, 0xde, 0xbd, 0xa5, 0x37,
+	0xdd, 0x46, 0xdb, 0x79, 0xcd, 0xbd, 0x8d, 0xc2, 0x20, 0x0d, 0xc0, 0x24, 0xaf, 0x2d, 0x83, 0x68,
+	0x14, 0x34, 0xc7, 0x40, 0xbf, 0x58, 0x1e, 0x74, 0x9f, 0x61, 0x44, 0xd0, 0x45, 0x53, 0xd2, 0xa8,
+	0x0b, 0x45, 0x9f, 0x04, 0xee, 0xc4, 0x37, 0x08, 0x8f, 0x84, 0xe9, 0x8f, 0xe5, 0x58, 0xca, 0xe1,
+	0x29, 0x04, 0xda, 0x87, 0x3c, 0x0b, 0x80, 0x34, 0xd4, 0x65, 0x7f, 0xe5, 0x03, 0x42, 0x12, 0x8c,
+	0xc5, 0x22, 0x2c, 0x64, 0xd1, 0x13, 0x58, 0xe7, 0x2a, 0x06, 0xb5, 0x02, 0x83, 0xf9, 0x24, 0x6d,
+	0x74, 0x66, 0x52, 0x58, 0x4a, 0xd3, 0x59, 0x9d, 0x04, 0xc4, 0x67, 0x21, 0xb3, 0x88, 0xd9, 0x37,
+	0xfa, 0x00, 0x8a, 0x3c, 0xe3, 0x34, 0x2d, 0xbf, 0x06, 0xdc, 0xbd, 0x19, 0x63, 0xdf, 0xf2, 0xd1,
+	0x87, 0x50, 0xe2, 0x27, 0x0b, 0x8d, 0xc5, 0x95, 0x12, 0x6b, 0x06, 0xce, 0x3a, 0xa6, 0xd1, 0x85,
+	0x77, 0x20, 0xbe, 0xcf, 0x3b, 0x6c, 0x44, 0x1d, 0x88, 0xef, 0xb3, 0x0e, 0xbf, 0x09, 0x9b, 0x2c,
+	0x3d, 0x1c, 0xfa, 0xee, 0xc4, 0xd3, 0x98, 0x4f, 0x95, 0x59, 0xa7, 0x32, 0x65, 0x3f, 0xa1, 0x5c,
+	0xba, 0x44, 0x68, 0xe2, 0xfb, 0xca, 0x3d, 0xe5, 0x1d, 0x2a, 0x7c, 0x25, 0xbd, 0x72, 0x4f, 0x65,
+	0x53, 0x94, 0x13, 0x6f, 0x26, 0x73, 0xe2, 0xaf, 0xe1, 0xea, 0xfc, 0xbe, 0xcb, 0x72, 0xe3, 0xea,
+	0xe5, 0x73, 0xe3, 0x1d, 0x67, 0x01, 0x17, 0x3d, 0x82, 0xac, 0xe9, 0x04, 0xb5, 0xad, 0xa5, 0x9c,
+	0x23, 0x5a, 0xc7, 0x98, 0x0a, 0xa3, 0x11, 0x6c, 0x53, 0xdb, 0x6b, 0x8e, 0x0c, 0x0e, 0x5c, 0x67,
+	0xc4, 0x30, 0xef, 0xa4, 0xc4, 0x9c, 0x8b, 0x2e, 0x78, 0x6b, 0x32, 0xcb, 0xaa, 0x7f, 0x06, 0x05,
+	0xe9, 0xe7, 0xcb, 0xc4, 0xd0, 0xfa, 0x7d, 0xa8, 0x24, 0x57, 0xc9, 0x52, 0x11, 0xf8, 0x6f, 0x32,
+	0x50, 0x8c, 0xd6, 0x03, 0x72, 0x60, 0x9b, 0xcd, 0x97, 0x1e, 0x12, 0x53, 0x9b, 0x2e, 0x2f, 0x9e,
+	0x88, 0x3f, 0x48, 0xf9, 0x6b, 0x9b, 0x12, 0x41, 0xe4, 0xc5, 0x62, 0xad, 0xa1, 0x08, 0x79, 0x3a,
+	0xde, 0x57, 0xb0, 0x39, 0xb6, 0x9c, 0xc9, 0x79, 0x6c, 0x2c, 0x7e, 0x52, 0xfa, 0x51, 0xca, 0xb1,
+	0x0e, 0xa9, 0xf4, 0x74, 0x8c, 0xca, 0x38, 0x41, 0xa3, 0x03, 0xc8, 0x79, 0xae, 0x1f, 0xca, 0x0d,
+	0x35, 0xed, 0x56, 0x77, 0xec, 0xfa, 0xe1, 0x91, 0xee, 0x79, 0xf4, 0x32, 0x80, 0x03, 0xa8, 0xdf,
+	0x64, 0xe0, 0xea, 0xe2, 0x1f, 0x86, 0xba, 0x90, 0x35, 0xbc, 0x89, 0x30, 0xd2, 0xfd, 0x65, 0x8d,
+	0xd4, 0xf2, 0x26, 0x53, 0xfd, 0x29, 0x10, 0x3d, 0x8b, 0xd8, 0xc4, 0x76, 0xfd, 0x0b, 0x61, 0x8b,
+	0x87, 0xcb, 0x42, 0x1e, 0x31, 0xe9, 0x29, 0xaa, 0x80, 0x43, 0x18, 0x0a, 0x62, 0x9d, 0x04, 0x22,
+	0x22, 0x2f, 0x79, 0x1c, 0x90, 0x90, 0x38, 0xc2, 0x51, 0x3f, 0x83, 0xdd, 0x85, 0x3f, 0x05, 0xfd,
+	0x3a, 0x80, 0xe1, 0x4d, 0x34, 0xf6, 0x9c, 0xc6, 0x3d, 0x28, 0x8b, 0x8b, 0x86, 0x37, 0xe9, 0x33,
+	0x86, 0xfa, 0x02, 0x6a, 0x6f, 0xd3, 0x97, 0xc6, 0x39, 0xae, 0xb1, 0x66, 0x9f, 0x32, 0x1b, 0x64,
+	0x71, 0x81, 0x33, 0x8e, 0x4e, 0x91, 0x0a, 0x65, 0xd9, 0xa8, 0x9f, 0xd3, 0x0e, 0x59, 0xd6, 0xa1,
+	0x24, 0x3a, 0xe8, 0xe7, 0x47, 0xa7, 0xea, 0xb7, 0x19, 0xd8, 0x9c, 0x51, 0x99, 0x1e, 0x4e, 0x79,
+	0x6c, 0x95, 0x67, 0x5f, 0x4e, 0xd1, 0x40, 0x6b, 0x58, 0xa6, 0x7c, 0xa6, 0x60, 0xdf, 0x6c, 0x8b,
+	0xf5, 0xc4, 0x13, 0x42, 0xc6, 0xf2, 0xe8, 0xf2, 0xb1, 0x4f, 0xad, 0x30, 0x60, 0x19, 0x53, 0x0e,
+	0x73, 0x02, 0x3d, 0x87, 0x8a, 0x4f, 0xd8, 0xd6, 0x6e, 0x6a, 0xdc, 0xcb, 0x72, 0x4b, 0x79, 0x99,
+	0xd0, 0x90, 0x3a, 0x1b, 0x2e, 0x4b, 0x24, 0x4a, 0x05, 0xe8, 0x19, 0x94, 0xcd, 0x0b, 0x47, 0xb7,
+	0x2d, 0x43, 0x20, 0xe7, 0x57, 0x46, 0xde, 0x10, 0x40, 0x0c, 0x98, 0xbe, 0x5c, 0xc6, 0x1a, 0xe9,
+	0x0f, 0x63, 0xa9, 0xa1, 0xb0, 0x09, 0x27, 0x92, 0xd1, 0x22, 0x27, 0xa2, 0x85, 0x7a, 0x0a, 0xa5,
+	0xd8, 0xba, 0x58, 0x46, 0x94, 0xda, 0x33, 0x74, 0x99, 0x3d, 0x73, 0x38, 0x13, 0xba, 0xd3, 0xdc,
+	0xca, 0x63, 0x16, 0x2d, 0x8a, 0xdc, 0xca, 0x53, 0xff, 0x37, 0x03, 0x95, 0xe4, 0x92, 0x96, 0x7e,
+	0xe4, 0x11, 0xdf, 0x72, 0xcd, 0x98, 0x1f, 0x1d, 0x33, 0x06, 0xf5, 0x15, 0xda, 0xfc, 0xf5, 0xc4,
+	0x0d, 0x75, 0xe9, 0x2b, 0x86, 0x37, 0xf9, 0x6d, 0x4a, 0xcf, 0xf8, 0x60, 0x76, 0xc6, 0x07, 0xd1,
+	0xc7, 0x80, 0x84, 0x2b, 0x8d, 0x2d, 0xdb, 0x0a, 0xb5, 0xd3, 0x8b, 0x90, 0xf0, 0x39, 0xce, 0xe2,
+	0x2a, 0x6f, 0x39, 0xa4, 0x0d, 0x8f, 0x28, 0x9f, 0x3a, 0x9e, 0xeb, 0xda, 0x5a, 0x60, 0xb8, 0x3e,
+	0xd1, 0x74, 0xf3, 0x15, 0x3b, 0xa8, 0x65, 0x71, 0xc9, 0x75, 0xed, 0x3e, 0xe5, 0x35, 0xcd, 0x57,
+	0x74, 0x8f, 0x35, 0xbc, 0x49, 0x40, 0x42, 0x8d, 0xfe, 0x61, 0x69, 0x49, 0x11, 0x03, 0x67, 0xb5,
+	0xbc, 0x49, 0x80, 0x7e, 0x03, 0xca, 0xb2, 0x03, 0xdb, 0x66, 0xc5, 0xfe, 0xbe, 0x21, 0xba, 0x30,
+	0x1e, 0x52, 0x61, 0xe3, 0x98, 0xf8, 0x06, 0x71, 0xc2, 0x81, 0x65, 0x9c, 0x05, 0xec, 0x68, 0xa5,
+	0xe0, 0x04, 0x0f, 0x3d, 0x82, 0x8c, 0xe5, 0xb2, 0x24, 0x20, 0xbd, 0x5b, 0x74, 0x7a, 0xd3, 0x98,
+	0x90, 0xb1, 0xdc, 0x2f, 0xd7, 0x0a, 0xeb, 0xd5, 0x02, 0x96, 0x1a, 0xdb, 0xc4, 0x0e, 0xd4, 0x09,
+	0x94, 0x62, 0xbd, 0xe8, 0xa2, 0x79, 0x93, 0xb8, 0x4d, 0xe5, 0x14, 0xea, 0x4e, 0xd3, 0x9c, 0x0c,
+	0xf3, 0xcc, 0xdf, 0x4a, 0xad, 0x02, 0xdf, 0xab, 0x98, 0x5d, 0xa3, 0x6c, 0x47, 0xfd, 0x56, 0x81,
+	0x72, 0xa2, 0x29, 0x3a, 0x1f, 0x29, 0xb1, 0xf3, 0xd1, 0x75, 0x28, 0xf8, 0x44, 0x37, 0xb5, 0x53,
+	0x8f, 0x6f, 0x13, 0x6b, 0x78, 0x9d, 0xd2, 0x8f, 0x3c, 0x16, 0x32, 0xde, 0xf8, 0x56, 0x48, 0x58,
+	0x5b, 0x96, 0xb5, 0x15, 0x18, 0x43, 0x34, 0x32, 0x39, 0xcb, 0xf5, 0xf8, 0xf4, 0xae, 0x61, 0x06,
+	0xd4, 0x71, 0x3d, 0xe6, 0x5f, 0x5c, 0x92, 0xb5, 0xe6, 0x58, 0x2b, 0xc7, 0xa2, 0xcd, 0xea, 0x4f,
+	0x21, 0xc7, 0x32, 0x3c, 0x0a, 0xc2, 0xb2, 0xa3, 0x98, 0x56, 0x05, 0xca, 0x60, 0xa9, 0xd3, 0x07,
+	0x50, 0x64, 0x0e, 0x1d, 0x3b, 0xd2, 0xb1, 0x83, 0x07, 0x6b, 0xac, 0x73, 0xb5, 0x5d, 0x67, 0x2c,
+	0x6f, 0xae, 0x23, 0x5a, 0xfd, 0x1a, 0xf2, 0xfc, 0x57, 0x5f, 0x02, 0xff, 0x13, 0x40, 0xdc, 0x99,
+	0xe8, 0x22, 0xb1, 0xad, 0x20, 0x10, 0x87, 0x08, 0x56, 0x2e, 0xc1, 0x5b, 0x8e, 0xa7, 0x0d, 0xea,
+	0x7f, 0x28, 0x00, 0xd3, 0x3b, 0x23, 0x7a, 0xee, 0xa0, 0xf3, 0x43, 0x6f, 0x5d, 0xf8, 0x1c, 0x4b,
+	0x92, 0x5e, 0x48, 0x89, 0x53, 0x43, 0x66, 0xd5, 0x8b, 0x38, 0x01, 0x20, 0xdf, 0xcf, 0x88, 0xb8,
+	0xd8, 0x59, 0xf6, 0xfd, 0x8c, 0xf0, 0xf7, 0x33, 0x42, 0x6f, 0x25, 0xc4, 0x79, 0x86, 0xc3, 0xad,
+	0xb1, 0xe3, 0x4c, 0xc9, 0x8c, 0x1e, 0x29, 0x89, 0xfa, 0xdf, 0x4a, 0x14, 0xfb, 0xe5, 0x65, 0x15,
+	0xfa, 0x0a, 0x0a, 0x34, 0x8c, 0x6a, 0xb6, 0xee, 0x89, 0xd2, 0x98, 0xd6, 0x6a, 0xf7, 0x60, 0x32,
+	0x33, 0xe0, 0xa7, 0x91, 0x75, 0x8f, 0x53, 0xd4, 0x59, 0xe9, 0x59, 0x52, 0xee, 0x21, 0xf4, 0x9b,
+	0xde, 0x7e, 0xe8, 0x93, 0xd0, 0xd5, 0x74, 0xf3, 0x35, 0xf1, 0x43, 0x2b, 0x20, 0x62, 0xee, 0xcb,
+	0x94, 0xdb, 0x94, 0xcc, 0xfa, 0x3d, 0xd8, 0x88, 0x63, 0xbe, 0x2b, 0x77, 0xcb, 0xc5, 0x73, 0xb7,
+	0xdf, 0x03, 0x98, 0x5e, 0xcc, 0x53, 0x1f, 0xa1, 0xb7, 0xfc, 0x9a, 0x21, 0x2f, 0x2f, 0x72, 0xb8,
+	0x40, 0x19, 0x2d, 0x7a, 0xa0, 0x4e, 0xbe, 0x1a, 0xe6, 0xe4, 0xab, 0x21, 0xf5, 0x7e, 0x1a, 0xd4,
+	0xce, 0xac, 0xf1, 0x38, 0x7a, 0x2c, 0x28, 0xba, 0xae, 0xfd, 0x94, 0x31, 0xd4, 0x5f, 0x66, 0xb8,
+	0xaf, 0xf0, 0xf7, 0xdf, 0x54, 0x47, 0xcf, 0xf7, 0x35, 0xd5, 0x77, 0x01, 0x82, 0x50, 0xf7, 0x69,
+	0x22, 0xaa, 0xcb, 0xe7, 0x8a, 0xfa, 0xdc, 0xb3, 0xe3, 0x40, 0x16, 0xa4, 0xe1, 0xa2, 0xe8, 0xdd,
+	0x0c, 0xd1, 0x03, 0xd8, 0x30, 0x5c, 0xdb, 0x1b, 0x13, 0x21, 0x9c, 0x7b, 0xa7, 0x70, 0x29, 0xea,
+	0xdf, 0x0c, 0x63, 0x8f, 0x24, 0xf9, 0xcb, 0x3e, 0x92, 0xfc, 0x83, 0xc2, 0x9f, 0xb1, 0xe3, 0xaf,
+	0xe8, 0x68, 0xb8, 0xa0, 0x54, 0xeb, 0xc9, 0x8a, 0x4f, 0xf2, 0xbf, 0xaa, 0x4e, 0xab, 0xfe, 0x20,
+	0x4d, 0x61, 0xd4, 0xdb, 0x8f, 0x06, 0xff, 0x98, 0x85, 0xa2, 0x9c, 0x96, 0xf9, 0xb9, 0xbf, 0x03,
+	0xc5, 0xa8, 0x1a, 0xb0, 0x96, 0x79, 0xa7, 0x85, 0xa7, 0x9d, 0xd1, 0x4b, 0x40, 0xfa, 0x70, 0x18,
+	0xa5, 0xfc, 0xda, 0x24, 0xd0, 0x87, 0xf2, 0xd2, 0xfb, 0xce, 0x12, 0x76, 0x90, 0xdb, 0xd4, 0x09,
+	0x95, 0xc7, 0x55, 0x7d, 0x38, 0x4c, 0x70, 0xd0, 0xef, 0xc3, 0x6e, 0x72, 0x0c, 0xed, 0xf4, 0x42,
+	0xf3, 0x2c, 0x53, 0x5c, 0x71, 0x1c, 0x2c, 0xfb, 0x88, 0xdf, 0x48, 0xc0, 0x3f, 0xba, 0x38, 0xb6,
+	0x4c, 0x6e, 0x73, 0xe4, 0xcf, 0x35, 0xd4, 0xff, 0x10, 0xae, 0xbd, 0xa5, 0xfb, 0x82, 0x39, 0xe8,
+	0x26, 0x8b, 0xd3, 0x56, 0x37, 0x42, 0x6c, 0xf6, 0xfe, 0x4b, 0x81, 0xad, 0xb9, 0x0e, 0xa8, 0x19,
+	0x3f, 0xab, 0xdc, 0x4a, 0x39, 0x4e, 0xeb, 0xf8, 0x84, 0xc3, 0x53, 0x59, 0xf4, 0xe5, 0xcc, 0xf1,
+	0x24, 0x6d, 0xf6, 0xc1, 0xb3, 0x7c, 0x0e, 0x24, 0x4f, 0x24, 0x9f, 0xb3, 0x2c, 0x86, 0x4f, 0x7d,
+	0x23, 0x75, 0x0a, 0xc1, 0x31, 0x32, 0x96, 0xab, 0xfe, 0x5d, 0x16, 0x0a, 0x52, 0x3b, 0x76, 0xc1,
+	0x71, 0x11, 0x84, 0xc4, 0xd6, 0xa2, 0xfb, 0x5b, 0x05, 0x03, 0x67, 0xb1, 0x5b, 0xc5, 0x0f, 0xa0,
+	0xc8, 0xce, 0xf2, 0xac, 0x39, 0xc3, 0x9a, 0x0b, 0x94, 0xc1, 0x1a, 0x3f, 0x84, 0x52, 0xe8, 0x86,
+	0xfa, 0x58, 0x0b, 0x59, 0xce, 0x95, 0xe5, 0xd2, 0x8c, 0xc5, 0x33, 0xae, 0xef, 0xc3, 0x56, 0x38,
+	0xf2, 0xdd, 0x30, 0x1c, 0xd3, 0x7c, 0x9f, 0x65, 0x9f, 0x32, 0x9b, 0xa8, 0x46, 0x0d, 0x3c, 0x2b,
+	0x0d, 0x68, 0xf4, 0x9f, 0x76, 0xa6, 0xae, 0x2f, 0x32, 0x8b, 0x72, 0xc4, 0xa5, 0x4b, 0x83, 0x6e,
+	0xbe, 0x1e, 0xcf, 0xea, 0x58, 0xac, 0x51, 0xb0, 0x24, 0x91, 0x06, 0x9b, 0x36, 0xd1, 0x83, 0x89,
+	0x4f, 0x4c, 0xed, 0xa5, 0x45, 0xc6, 0x26, 0xbf, 0x97, 0xaa, 0xa4, 0x3e, 0xb2, 0x49, 0xb3, 0x34,
+	0x1e, 0x33, 0x69, 0x5c, 0x91, 0x70, 0x9c, 0xa6, 0x99, 0x07, 0xff, 0x42, 0x9b, 0x50, 0xea, 0x3f,
+	0xef, 0x0f, 0xda, 0x47, 0xda, 0x51, 0x6f, 0xbf, 0x2d, 0xea, 0x17, 0xfb, 0x6d, 0xcc, 0x49, 0x85,
+	0xb6, 0x0f, 0x7a, 0x83, 0xe6, 0xa1, 0x36, 0xe8, 0xb4, 0x9e, 0xf6, 0xab, 0x19, 0xb4, 0x0b, 0x5b,
+	0x83, 0x03, 0xdc, 0x1b, 0x0c, 0x0e, 0xdb, 0xfb, 0xda, 0x71, 0x1b, 0x77, 0x7a, 0xfb, 0xfd, 0x6a,
+	0x96, 0x5e, 0xc4, 0x4f, 0xd9, 0x83, 0xce, 0x51, 0xbb, 0xba, 0x46, 0x2b, 0xd6, 0x8e, 0xdb, 0xb8,
+	0xd5, 0xee, 0x0e, 0xaa, 0x39, 0xf5, 0xdb, 0x2c, 0x94, 0x62, 0x5e, 0x40, 0x17, 0x82, 0x1f, 0xf0,
+	0xb3, 0xe1, 0x1a, 0xa6, 0x9f, 0xac, 0xde, 0x42, 0x37, 0x46, 0x44, 0xa4, 0x77, 0x9c, 0x60, 0xe7,
+	0x41, 0xfd, 0x3c, 0x16, 0x27, 0xd6, 0x70, 0xc1, 0xd6, 0xcf, 0x39, 0xc8, 0x77, 0x61, 0xe3, 0x8c,
+	0xf8, 0x0e, 0x19, 0x8b, 0x76, 0x3e, 0x23, 0x25, 0xce, 0xe3, 0x5d, 0x6e, 0x42, 0x55, 0x74, 0x99,
+	0xc2, 0xf0, 0xe9, 0xa8, 0x70, 0xfe, 0x91, 0x04, 0xdb, 0x81, 0x1c, 0x6f, 0x5e, 0xe7, 0xe3, 0x33,
+	0x82, 0xdd, 0xf8, 0xbe, 0xd1, 0x3d, 0x96, 0x87, 0xaf, 0x61, 0xf6, 0x8d, 0x4e, 0xe7, 0xe7, 0x27,
+	0xcf, 0xe6, 0xe7, 0xee, 0xf2, 0xcb, 0xe1, 0x6d, 0x53, 0x34, 0x8a, 0xa6, 0x68, 0x1d, 0xb2, 0x58,
+	0x16, 0xfd, 0xb5, 0x9a, 0xad, 0x03, 0x3a, 0x2d, 0x65, 0x28, 0x1e, 0x35, 0x7f, 0xa2, 0x9d, 0xf4,
+	0xd9, 0xb3, 0x08, 0xaa, 0xc2, 0xc6, 0xd3, 0x36, 0xee, 0xb6, 0x0f, 0x05, 0x27, 0x8b, 0x76, 0xa0,
+	0x2a, 0x38, 0xd3, 0x7e, 0x6b, 0x14, 0x81, 0x7f, 0xe6, 0xe8, 0x35, 0x7a, 0xff, 0x59, 0xf3, 0xb8,
+	0x9a, 0x57, 0xff, 0x36, 0x03, 0xeb, 0x62, 0x5d, 0xd1, 0x94, 0x80, 0x67, 0xd9, 0x17, 0x21, 0x91,
+	0x93, 0xc3, 0xf2, 0x67, 0x7e, 0x0c, 0xfa, 0x10, 0x4a, 0x22, 0xd3, 0x66, 0xed, 0x7c, 0xa2, 0x78,
+	0x0a, 0xcd, 0x3b, 0xc8, 0x2c, 0xdd, 0x8d, 0x32, 0x71, 0x96, 0xa5, 0xf7, 0xe2, 0x59, 0x7a, 0x2c,
+	0x11, 0x67, 0x0c, 0xda, 0xf8, 0xd5, 0xbc, 0x45, 0x73, 0xcc, 0xa2, 0x3f, 0x5a, 0x2e, 0x30, 0xbc,
+	0xcd, 0x9a, 0x8f, 0x23, 0x6b, 0x56, 0x00, 0x70, 0xbb, 0xb9, 0xaf, 0x3d, 0x7a, 0x3e, 0x68, 0x53,
+	0xa3, 0x6e, 0x42, 0xe9, 0x19, 0xee, 0x0c, 0xda, 0x82, 0xa1, 0xa0, 0x0d, 0x28, 0xb0, 0x0e, 0xbd,
+	0x63, 0xea, 0xee, 0x65, 0x28, 0xf2, 0x66, 0x4a, 0x66, 0xd5, 0xff, 0xcc, 0xc0, 0x26, 0xdf, 0x82,
+	0xa3, 0x52, 0xae, 0xb7, 0xbf, 0xac, 0xc7, 0x2f, 0x44, 0x33, 0xc9, 0x0b, 0x51, 0x99, 0xf0, 0xb3,
+	0x0c, 0x2a, 0x3b, 0x4d, 0xf8, 0xd9, 0x45, 0x6a, 0x62, 0x77, 0x5d, 0x5b, 0x66, 0x77, 0xad, 0xc1,
+	0xba, 0x4d, 0x82, 0xc8, 0xc7, 0x8b, 0x58, 0x92, 0xc8, 0x82, 0x92, 0xee, 0x38, 0x6e, 0xa8, 0xf3,
+	0x57, 0x86, 0xfc, 0x52, 0x89, 0xc7, 0xcc, 0x2f, 0x6e, 0x34, 0xa7, 0x48, 0x7c, 0x13, 0x8c, 0x63,
+	0xd7, 0x3f, 0x87, 0xea, 0x6c, 0x87, 0x65, 0x52, 0x8f, 0xef, 0xfd, 0x60, 0x9a, 0x79, 0x10, 0x1a,
+	0x43, 0xc4, 0x03, 0x5f, 0xf5, 0x0a, 0x25, 0xf0, 0x49, 0xb7, 0xdb, 0xe9, 0x3e, 0xa9, 0x2a, 0xf4,
+	0x85, 0xb0, 0xfd, 0x93, 0x0e, 0x2d, 0xba, 0xce, 0xec, 0xfd, 0xdb, 0x0e, 0xe4, 0xb9, 0x92, 0xe8,
+	0x1b, 0x91, 0x75, 0xc5, 0xff, 0x4d, 0x00, 0x7d, 0xbe, 0xf4, 0xe9, 0x25, 0xf1, 0xaf, 0x07, 0xf5,
+	0x87, 0x2b, 0xcb, 0x8b, 0x5a, 0x87, 0x2b, 0xe8, 0x4f, 0x15, 0xd8, 0x48, 0xbc, 0x96, 0xa7, 0x7d,
+	0x65, 0x59, 0xf0, 0x5f, 0x09, 0xf5, 0x1f, 0xaf, 0x24, 0x1b, 0xe9, 0xf2, 0x0b, 0x05, 0x4a, 0xb1,
+	0x7a, 0x7c, 0x74, 0x77, 0x95, 0x1a, 0x7e, 0xae, 0xc9, 0xbd, 0xd5, 0xcb, 0xff, 0xd5, 0x2b, 0x9f,
+	0x2a, 0xe8, 0x4f, 0x14, 0x28, 0xc5, 0x2a, 0xd3, 0x53, 0xab, 0x32, 0x5f, 0x47, 0x5f, 0xbf, 0xb7,
+	0x8a, 0x68, 0x64, 0x93, 0x3f, 0x52, 0xa0, 0x18, 0x15, 0x74, 0xa0, 0xdb, 0xcb, 0x97, 0x80, 0x70,
+	0x25, 0xee, 0xac, 0x5a, 0x3b, 0xa2, 0x5e, 0x41, 0x7f, 0x00, 0x05, 0x59, 0x92, 0x8d, 0xd2, 0xee,
+	0xf4, 0x33, 0xf5, 0xde, 0xf5, 0xdb, 0x4b, 0xcb, 0xc5, 0x87, 0x97, 0x75, 0xd2, 0xa9, 0x87, 0x9f,
+	0xa9, 0xe8, 0xae, 0xdf, 0x5e, 0x5a, 0x2e, 0x1a, 0x9e, 0x7a, 0x42, 0xac, 0x9c, 0x3a, 0xb5, 0x27,
+	0xcc, 0xd7, 0x71, 0xd7, 0xef, 0xad, 0x22, 0x9a, 0x50, 0x24, 0x56, 0x90, 0x9d, 0x5a, 0x91, 0xf9,
+	0xa2, 0xef, 0xfa, 0xbd, 0x55, 0x44, 0x23, 0x45, 0x7e, 0xae, 0xc4, 0xcf, 0x60, 0xb7, 0x97, 0xae,
+	0x3b, 0x5e, 0xd2, 0x25, 0xe7, 0x2a, 0x9f, 0xd9, 0x02, 0xfd, 0xb9, 0xb8, 0x31, 0xe2, 0x65, 0xcb,
+	0x68, 0x19, 0xb0, 0x44, 0xa5, 0x73, 0xfd, 0xb3, 0xd5, 0x36, 0x1b, 0xa6, 0xc4, 0x1f, 0x2b, 0x00,
+	0xd3, 0x02, 0xe7, 0xd4, 0x4a, 0xcc, 0x55, 0x56, 0xd7, 0xef, 0xae, 0x20, 0x19, 0x5f, 0x20, 0xb2,
+	0x00, 0x33, 0xf5, 0x02, 0x99, 0x29, 0xc0, 0xae, 0xdf, 0x5e, 0x5a, 0x2e, 0x1a, 0xfe, 0xaf, 0x15,
+	0xd8, 0x9a, 0x2b, 0x00, 0x45, 0x0f, 0x2f, 0x59, 0x03, 0x5c, 0xff, 0x62, 0x75, 0x00, 0xa9, 0xda,
+	0x4d, 0xe5, 0x53, 0x05, 0xfd, 0x99, 0x02, 0xe5, 0x64, 0xcd, 0x52, 0xea, 0x5d, 0x6a, 0x41, 0x29,
+	0x69, 0xfd, 0xfe, 0x6a, 0xc2, 0x91, 0xb5, 0xfe, 0x42, 0x81, 0x8a, 0x58, 0xdf, 0x52, 0x9f, 0xfb,
+	0xcb, 0x85, 0x85, 0x19, 0x85, 0x1e, 0xac, 0x28, 0x9d, 0xd0, 0x28, 0x59, 0x55, 0x99, 0x5a, 0xa3,
+	0x85, 0x25, 0x9d, 0xf5, 0x07, 0x2b, 0x4a, 0x27, 0x22, 0x5d, 0xac, 0x20, 0x72, 0x89, 0xcd, 0x77,
+	0xb6, 0x6c, 0xb3, 0x7e, 0x6f, 0x15, 0xd1, 0x84, 0x69, 0x92, 0x55, 0xa2, 0xa9, 0x4d, 0xb3, 0xb0,
+	0x1a, 0xb5, 0xfe, 0x60, 0x45, 0x69, 0xa9, 0xd1, 0xa3, 0xf5, 0xdf, 0xc9, 0xf1, 0x54, 0x3b, 0xcf,
+	0xfe, 0xfc, 0xf0, 0xff, 0x06, 0x00, 0xe7, 0x93, 0x12, 0x60, 0x7a, 0x3b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3986,6 +4615,19 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// CheckpointTask writes a checkpoint of a running task to a directory so
+	// that it can be restored later, possibly on another node. The task is
+	// stopped once the checkpoint is written. This rpc is only implemented if
+	// the driver sets the checkpoint capability.
+	CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from a checkpoint previously written by
+	// CheckpointTask. This rpc is only implemented if the driver sets the
+	// checkpoint capability.
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
+	// PrefetchImages sets the images the driver pulls ahead of the tasks
+	// using them, replacing any previously set images. This rpc is only
+	// implemented if the driver sets the image_prefetch capability.
+	PrefetchImages(ctx context.Context, in *PrefetchImagesRequest, opts ...grpc.CallOption) (*PrefetchImagesResponse, error)
 }
 
 type driverClient struct {
@@ -4231,6 +4873,33 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error) {
+	out := new(CheckpointTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) PrefetchImages(ctx context.Context, in *PrefetchImagesRequest, opts ...grpc.CallOption) (*PrefetchImagesResponse, error) {
+	out := new(PrefetchImagesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/PrefetchImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4286,6 +4955,19 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// CheckpointTask writes a checkpoint of a running task to a directory so
+	// that it can be restored later, possibly on another node. The task is
+	// stopped once the checkpoint is written. This rpc is only implemented if
+	// the driver sets the checkpoint capability.
+	CheckpointTask(context.Context, *CheckpointTaskRequest) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from a checkpoint previously written by
+	// CheckpointTask. This rpc is only implemented if the driver sets the
+	// checkpoint capability.
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
+	// PrefetchImages sets the images the driver pulls ahead of the tasks
+	// using them, replacing any previously set images. This rpc is only
+	// implemented if the driver sets the image_prefetch capability.
+	PrefetchImages(context.Context, *PrefetchImagesRequest) (*PrefetchImagesResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4340,6 +5022,15 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) CheckpointTask(ctx context.Context, req *CheckpointTaskRequest) (*CheckpointTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointTask not implemented")
+}
+func (*UnimplementedDriverServer) RestoreTask(ctx context.Context, req *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (*UnimplementedDriverServer) PrefetchImages(ctx context.Context, req *PrefetchImagesRequest) (*PrefetchImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrefetchImages not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4650,6 +5341,60 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_CheckpointTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).CheckpointTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).CheckpointTask(ctx, req.(*CheckpointTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_PrefetchImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefetchImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).PrefetchImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/PrefetchImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).PrefetchImages(ctx, req.(*PrefetchImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4702,6 +5447,18 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "CheckpointTask",
+			Handler:    _Driver_CheckpointTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _Driver_RestoreTask_Handler,
+		},
+		{
+			MethodName: "PrefetchImages",
+			Handler:    _Driver_PrefetchImages_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
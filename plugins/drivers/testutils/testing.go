@@ -238,6 +238,9 @@ type MockDriver struct {
 	SignalTaskF        func(string, string) error
 	ExecTaskF          func(string, []string, time.Duration) (*drivers.ExecTaskResult, error)
 	ExecTaskStreamingF func(context.Context, string, *drivers.ExecOptions) (*drivers.ExitResult, error)
+	CheckpointTaskF    func(string, string) error
+	RestoreTaskF       func(*drivers.TaskConfig, string) (*drivers.TaskHandle, *drivers.DriverNetwork, error)
+	PrefetchImagesF    func([]string) error
 	MockNetworkManager
 }
 
@@ -291,6 +294,18 @@ func (d *MockDriver) ExecTaskStreaming(ctx context.Context, taskID string, execO
 	return d.ExecTaskStreamingF(ctx, taskID, execOpts)
 }
 
+func (d *MockDriver) CheckpointTask(taskID string, path string) error {
+	return d.CheckpointTaskF(taskID, path)
+}
+
+func (d *MockDriver) RestoreTask(c *drivers.TaskConfig, path string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.RestoreTaskF(c, path)
+}
+
+func (d *MockDriver) PrefetchImages(images []string) error {
+	return d.PrefetchImagesF(images)
+}
+
 // SetEnvvars sets path and host env vars depending on the FS isolation used.
 func SetEnvvars(envBuilder *taskenv.Builder, fsi drivers.FSIsolation, taskDir *allocdir.TaskDir, conf *config.Config) {
 
@@ -164,6 +164,92 @@ func TestBaseDriver_StartTask(t *testing.T) {
 
 }
 
+func TestBaseDriver_CheckpointTask(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	var checkpointed string
+	impl := &MockDriver{
+		CheckpointTaskF: func(taskID string, path string) error {
+			if taskID != "foo" {
+				return drivers.ErrTaskNotFound
+			}
+			checkpointed = path
+			return nil
+		},
+	}
+
+	harness := NewDriverHarness(t, impl)
+	defer harness.Kill()
+
+	d, ok := harness.DriverPlugin.(drivers.CheckpointDriver)
+	require.True(ok)
+	require.NoError(d.CheckpointTask("foo", "/tmp/checkpoint"))
+	require.Equal("/tmp/checkpoint", checkpointed)
+
+	err := d.CheckpointTask("bar", "/tmp/checkpoint")
+	require.Error(err)
+	require.Contains(err.Error(), drivers.ErrTaskNotFound.Error())
+}
+
+func TestBaseDriver_RestoreTask(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	cfg := &drivers.TaskConfig{
+		ID: "foo",
+	}
+	state := &testDriverState{Pid: 1, Log: "log"}
+	var restoredFrom string
+	impl := &MockDriver{
+		RestoreTaskF: func(c *drivers.TaskConfig, path string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+			restoredFrom = path
+			handle := drivers.NewTaskHandle(1)
+			handle.Config = c
+			handle.State = drivers.TaskStateRunning
+			handle.SetDriverState(state)
+			return handle, &drivers.DriverNetwork{IP: "10.0.0.1"}, nil
+		},
+	}
+
+	harness := NewDriverHarness(t, impl)
+	defer harness.Kill()
+
+	d, ok := harness.DriverPlugin.(drivers.CheckpointDriver)
+	require.True(ok)
+	resp, net, err := d.RestoreTask(cfg, "/tmp/checkpoint")
+	require.NoError(err)
+	require.Equal("/tmp/checkpoint", restoredFrom)
+	require.Equal(cfg.ID, resp.Config.ID)
+	require.Equal(drivers.TaskStateRunning, resp.State)
+	require.Equal("10.0.0.1", net.IP)
+
+	var actualState testDriverState
+	require.NoError(resp.GetDriverState(&actualState))
+	require.Equal(*state, actualState)
+}
+
+func TestBaseDriver_PrefetchImages(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	var prefetched []string
+	impl := &MockDriver{
+		PrefetchImagesF: func(images []string) error {
+			prefetched = images
+			return nil
+		},
+	}
+
+	harness := NewDriverHarness(t, impl)
+	defer harness.Kill()
+
+	d, ok := harness.DriverPlugin.(drivers.ImagePrefetchDriver)
+	require.True(ok)
+	require.NoError(d.PrefetchImages([]string{"redis:7", "nvidia/cuda:11"}))
+	require.Equal([]string{"redis:7", "nvidia/cuda:11"}, prefetched)
+}
+
 func TestBaseDriver_WaitTask(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -277,6 +363,8 @@ func TestBaseDriver_Capabilities(t *testing.T) {
 		SendSignals:         true,
 		Exec:                true,
 		FSIsolation:         drivers.FSIsolationNone,
+		Checkpoint:          true,
+		ImagePrefetch:       true,
 	}
 	d := &MockDriver{
 		CapabilitiesF: func() (*drivers.Capabilities, error) {
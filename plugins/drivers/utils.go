@@ -66,6 +66,7 @@ func taskConfigFromProto(pb *proto.TaskConfig) *TaskConfig {
 		AllocID:          pb.AllocId,
 		NetworkIsolation: NetworkIsolationSpecFromProto(pb.NetworkIsolationSpec),
 		DNS:              dnsConfigFromProto(pb.Dns),
+		UserNamespace:    UserNamespaceSpecFromProto(pb.UserNamespaceSpec),
 	}
 }
 
@@ -91,6 +92,7 @@ func taskConfigToProto(cfg *TaskConfig) *proto.TaskConfig {
 		AllocId:              cfg.AllocID,
 		NetworkIsolationSpec: NetworkIsolationSpecToProto(cfg.NetworkIsolation),
 		Dns:                  dnsConfigToProto(cfg.DNS),
+		UserNamespaceSpec:    UserNamespaceSpecToProto(cfg.UserNamespace),
 	}
 	return pb
 }
@@ -145,6 +147,7 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			CpusetCpus:       pb.LinuxResources.CpusetCpus,
 			CpusetCgroupPath: pb.LinuxResources.CpusetCgroup,
 			PercentTicks:     pb.LinuxResources.PercentTicks,
+			IO:               ioResourcesFromProto(pb.LinuxResources.Io),
 		}
 	}
 
@@ -215,6 +218,7 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 			CpusetCpus:       r.LinuxResources.CpusetCpus,
 			CpusetCgroup:     r.LinuxResources.CpusetCgroupPath,
 			PercentTicks:     r.LinuxResources.PercentTicks,
+			Io:               ioResourcesToProto(r.LinuxResources.IO),
 		}
 	}
 
@@ -235,6 +239,46 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 	return &pb
 }
 
+func ioResourcesFromProto(pb *proto.IOResources) *structs.IOResources {
+	if pb == nil {
+		return nil
+	}
+
+	r := &structs.IOResources{
+		Weight: int(pb.Weight),
+	}
+	for _, d := range pb.Devices {
+		r.Devices = append(r.Devices, &structs.IODeviceLimit{
+			Path:      d.Path,
+			ReadBps:   d.ReadBps,
+			WriteBps:  d.WriteBps,
+			ReadIOPS:  d.ReadIops,
+			WriteIOPS: d.WriteIops,
+		})
+	}
+	return r
+}
+
+func ioResourcesToProto(r *structs.IOResources) *proto.IOResources {
+	if r == nil {
+		return nil
+	}
+
+	pb := &proto.IOResources{
+		Weight: int32(r.Weight),
+	}
+	for _, d := range r.Devices {
+		pb.Devices = append(pb.Devices, &proto.IODeviceLimit{
+			Path:      d.Path,
+			ReadBps:   d.ReadBps,
+			WriteBps:  d.WriteBps,
+			ReadIops:  d.ReadIOPS,
+			WriteIops: d.WriteIOPS,
+		})
+	}
+	return pb
+}
+
 func DevicesFromProto(devices []*proto.Device) []*DeviceConfig {
 	if devices == nil {
 		return nil
@@ -475,9 +519,21 @@ func resourceUsageToProto(ru *ResourceUsage) *proto.TaskResourceUsage {
 		KernelMaxUsage: ru.MemoryStats.KernelMaxUsage,
 	}
 
+	var io *proto.IOUsage
+	if ru.IOStats != nil {
+		io = &proto.IOUsage{
+			MeasuredFields: ioUsageMeasuredFieldsToProto(ru.IOStats.Measured),
+			ReadBytes:      ru.IOStats.ReadBytes,
+			WriteBytes:     ru.IOStats.WriteBytes,
+			ReadOps:        ru.IOStats.ReadOps,
+			WriteOps:       ru.IOStats.WriteOps,
+		}
+	}
+
 	return &proto.TaskResourceUsage{
 		Cpu:    cpu,
 		Memory: memory,
+		Io:     io,
 	}
 }
 
@@ -509,9 +565,21 @@ func resourceUsageFromProto(pb *proto.TaskResourceUsage) *ResourceUsage {
 		}
 	}
 
+	var io *IOStats
+	if pb.Io != nil {
+		io = &IOStats{
+			Measured:   ioUsageMeasuredFieldsFromProto(pb.Io.MeasuredFields),
+			ReadBytes:  pb.Io.ReadBytes,
+			WriteBytes: pb.Io.WriteBytes,
+			ReadOps:    pb.Io.ReadOps,
+			WriteOps:   pb.Io.WriteOps,
+		}
+	}
+
 	return &ResourceUsage{
 		CpuStats:    &cpu,
 		MemoryStats: &memory,
+		IOStats:     io,
 	}
 }
 
@@ -605,6 +673,44 @@ func memoryUsageMeasuredFieldsFromProto(fields []proto.MemoryUsage_Fields) []str
 	return r
 }
 
+var ioUsageMeasuredFieldToProtoMap = map[string]proto.IOUsage_Fields{
+	"Read Bytes":  proto.IOUsage_READ_BYTES,
+	"Write Bytes": proto.IOUsage_WRITE_BYTES,
+	"Read Ops":    proto.IOUsage_READ_OPS,
+	"Write Ops":   proto.IOUsage_WRITE_OPS,
+}
+
+var ioUsageMeasuredFieldFromProtoMap = map[proto.IOUsage_Fields]string{
+	proto.IOUsage_READ_BYTES:  "Read Bytes",
+	proto.IOUsage_WRITE_BYTES: "Write Bytes",
+	proto.IOUsage_READ_OPS:    "Read Ops",
+	proto.IOUsage_WRITE_OPS:   "Write Ops",
+}
+
+func ioUsageMeasuredFieldsToProto(fields []string) []proto.IOUsage_Fields {
+	r := make([]proto.IOUsage_Fields, 0, len(fields))
+
+	for _, f := range fields {
+		if v, ok := ioUsageMeasuredFieldToProtoMap[f]; ok {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}
+
+func ioUsageMeasuredFieldsFromProto(fields []proto.IOUsage_Fields) []string {
+	r := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		if v, ok := ioUsageMeasuredFieldFromProtoMap[f]; ok {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}
+
 func netIsolationModeToProto(mode NetIsolationMode) proto.NetworkIsolationSpec_NetworkIsolationMode {
 	switch mode {
 	case NetIsolationModeHost:
@@ -668,6 +774,26 @@ func NetworkIsolationSpecFromProto(pb *proto.NetworkIsolationSpec) *NetworkIsola
 	}
 }
 
+func UserNamespaceSpecToProto(spec *UserNamespaceSpec) *proto.UserNamespaceSpec {
+	if spec == nil {
+		return nil
+	}
+	return &proto.UserNamespaceSpec{
+		HostId: spec.HostID,
+		Size:   spec.Size,
+	}
+}
+
+func UserNamespaceSpecFromProto(pb *proto.UserNamespaceSpec) *UserNamespaceSpec {
+	if pb == nil {
+		return nil
+	}
+	return &UserNamespaceSpec{
+		HostID: pb.HostId,
+		Size:   pb.Size,
+	}
+}
+
 func hostsConfigToProto(cfg *HostsConfig) *proto.HostsConfig {
 	if cfg == nil {
 		return nil
@@ -30,6 +30,13 @@ func TestResourceUsageRoundTrip(t *testing.T) {
 			KernelMaxUsage: 45,
 			Measured:       []string{"RSS", "Swap"},
 		},
+		IOStats: &IOStats{
+			ReadBytes:  4096,
+			WriteBytes: 8192,
+			ReadOps:    1,
+			WriteOps:   2,
+			Measured:   []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"},
+		},
 	}
 
 	parsed := resourceUsageFromProto(resourceUsageToProto(input))
@@ -57,6 +64,12 @@ func TestTaskConfigRoundTrip(t *testing.T) {
 				MemoryLimitBytes: 300 * 1024 * 1024,
 				CPUShares:        100,
 				PercentTicks:     float64(100) / float64(3200),
+				IO: &structs.IOResources{
+					Weight: 500,
+					Devices: []*structs.IODeviceLimit{
+						{Path: "/dev/sda", ReadBps: 1024, WriteIOPS: 10},
+					},
+				},
 			},
 			Ports: &structs.AllocatedPorts{
 				{
@@ -2,8 +2,8 @@ package scheduler
 
 import (
 	"fmt"
-
 	"math"
+	"sort"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -47,15 +47,10 @@ func (d *deviceAllocator) AssignDevice(ask *structs.RequestedDevice) (out *struc
 	// constraints
 	for id, devInst := range d.Devices {
 		// Check if we have enough unused instances to use this
-		assignable := uint64(0)
-		for _, v := range devInst.Instances {
-			if v == 0 {
-				assignable++
-			}
-		}
+		assignable := assignableInstances(devInst, ask)
 
 		// This device doesn't have enough instances
-		if assignable < ask.Count {
+		if uint64(len(assignable)) < ask.Count {
 			continue
 		}
 
@@ -107,18 +102,8 @@ func (d *deviceAllocator) AssignDevice(ask *structs.RequestedDevice) (out *struc
 			Vendor:    id.Vendor,
 			Type:      id.Type,
 			Name:      id.Name,
-			DeviceIDs: make([]string, 0, ask.Count),
-		}
-
-		assigned := uint64(0)
-		for id, v := range devInst.Instances {
-			if v == 0 && assigned < ask.Count {
-				assigned++
-				offer.DeviceIDs = append(offer.DeviceIDs, id)
-				if assigned == ask.Count {
-					break
-				}
-			}
+			DeviceIDs: assignable[:ask.Count],
+			Shares:    ask.Shares,
 		}
 	}
 
@@ -129,3 +114,33 @@ func (d *deviceAllocator) AssignDevice(ask *structs.RequestedDevice) (out *struc
 
 	return offer, matchedWeights, nil
 }
+
+// assignableInstances returns the IDs of the device instances that can satisfy
+// the ask. Exclusive asks can use any unused instance. Shared asks can use any
+// instance with enough free shares and the returned instances are ordered to
+// pack shares onto the most used instances first.
+func assignableInstances(devInst *structs.DeviceAccounterInstance, ask *structs.RequestedDevice) []string {
+	var ids []string
+	if ask.Shares == 0 {
+		for id, v := range devInst.Instances {
+			if v == 0 {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+
+	for id := range devInst.Instances {
+		if devInst.FreeShares(id) >= ask.Shares {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		fi, fj := devInst.FreeShares(ids[i]), devInst.FreeShares(ids[j])
+		if fi != fj {
+			return fi < fj
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
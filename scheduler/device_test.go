@@ -152,6 +152,51 @@ func TestDeviceAllocator_Allocate_NotEnoughInstances(t *testing.T) {
 	require.Contains(err.Error(), "no devices match request")
 }
 
+// Test that shared device requests are packed onto the most used instance
+func TestDeviceAllocator_Allocate_Shares(t *testing.T) {
+	ci.Parallel(t)
+
+	require := require.New(t)
+	_, ctx := testContext(t)
+	n := devNode()
+	for _, instance := range n.NodeResources.Devices[0].Instances {
+		instance.Shares = 100
+	}
+	d := newDeviceAllocator(ctx, n)
+	require.NotNil(d)
+
+	// Build the request
+	ask := deviceRequest("gpu", 1, nil, nil)
+	ask.Shares = 40
+
+	out, _, err := d.AssignDevice(ask)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Len(out.DeviceIDs, 1)
+	require.EqualValues(40, out.Shares)
+	require.False(d.AddReserved(out))
+	first := out.DeviceIDs[0]
+
+	// The next request shares the same instance
+	out, _, err = d.AssignDevice(ask)
+	require.NoError(err)
+	require.Equal([]string{first}, out.DeviceIDs)
+	require.False(d.AddReserved(out))
+
+	// The instance has no room left so the other instance is used
+	out, _, err = d.AssignDevice(ask)
+	require.NoError(err)
+	require.Len(out.DeviceIDs, 1)
+	require.NotEqual(first, out.DeviceIDs[0])
+	require.False(d.AddReserved(out))
+
+	// An exclusive request can't use the partially used instances
+	out, _, err = d.AssignDevice(deviceRequest("gpu", 1, nil, nil))
+	require.Nil(out)
+	require.Error(err)
+	require.Contains(err.Error(), "no devices match request")
+}
+
 // Test that asking for a device with constraints works
 func TestDeviceAllocator_Allocate_Constraints(t *testing.T) {
 	ci.Parallel(t)
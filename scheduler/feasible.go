@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	version "github.com/hashicorp/go-version"
@@ -28,6 +29,8 @@ const (
 	FilterConstraintDrivers                        = "missing drivers"
 	FilterConstraintDevices                        = "missing devices"
 	FilterConstraintsCSIPluginTopology             = "did not meet topology requirement"
+	FilterConstraintMaintenanceWindow              = "node in maintenance window"
+	FilterConstraintMaintenanceWindowUpcoming      = "node maintenance window opens before expected completion"
 )
 
 var (
@@ -449,6 +452,66 @@ func (c *NetworkChecker) hasNetwork(option *structs.Node) bool {
 	return false
 }
 
+// MaintenanceWindowChecker is a FeasibilityChecker which returns whether a
+// node's maintenance windows allow placing a task group. Service task groups
+// can't be placed while a window is open, and batch task groups are only
+// placed if they are expected to finish before the next window opens.
+type MaintenanceWindowChecker struct {
+	ctx Context
+
+	jobType          string
+	expectedDuration *time.Duration
+
+	// now returns the current time and may be overridden in tests
+	now func() time.Time
+}
+
+// NewMaintenanceWindowChecker creates a MaintenanceWindowChecker
+func NewMaintenanceWindowChecker(ctx Context) *MaintenanceWindowChecker {
+	return &MaintenanceWindowChecker{
+		ctx: ctx,
+		now: time.Now,
+	}
+}
+
+// SetJob sets the type of the job being placed.
+func (c *MaintenanceWindowChecker) SetJob(job *structs.Job) {
+	c.jobType = job.Type
+}
+
+// SetTaskGroup sets the task group being placed.
+func (c *MaintenanceWindowChecker) SetTaskGroup(tg *structs.TaskGroup) {
+	c.expectedDuration = tg.ExpectedDuration
+}
+
+func (c *MaintenanceWindowChecker) Feasible(option *structs.Node) bool {
+	if len(option.MaintenanceWindows) == 0 {
+		return true
+	}
+
+	switch c.jobType {
+	case structs.JobTypeService, structs.JobTypeBatch:
+	default:
+		return true
+	}
+
+	now := c.now()
+	if option.InMaintenanceWindow(now) {
+		c.ctx.Metrics().FilterNode(option, FilterConstraintMaintenanceWindow)
+		return false
+	}
+
+	if c.jobType == structs.JobTypeBatch && c.expectedDuration != nil {
+		next := option.NextMaintenanceWindow(now)
+		if !next.IsZero() && now.Add(*c.expectedDuration).After(next) {
+			c.ctx.Metrics().FilterNode(option, FilterConstraintMaintenanceWindowUpcoming)
+			return false
+		}
+	}
+
+	return true
+}
+
 // DriverChecker is a FeasibilityChecker which returns whether a node has the
 // drivers necessary to scheduler a task group.
 type DriverChecker struct {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -504,6 +505,97 @@ func TestCSIVolumeChecker(t *testing.T) {
 
 }
 
+func TestMaintenanceWindowChecker(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+
+	// The node is in maintenance every day from 02:00 to 04:00
+	node := mock.Node()
+	node.MaintenanceWindows = []*structs.NodeMaintenanceWindow{
+		{
+			Cron:     "0 2 * * *",
+			Duration: 2 * time.Hour,
+		},
+	}
+	day := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name             string
+		jobType          string
+		expectedDuration *time.Duration
+		now              time.Time
+		feasible         bool
+	}{
+		{
+			name:     "service outside window",
+			jobType:  structs.JobTypeService,
+			now:      day.Add(time.Hour),
+			feasible: true,
+		},
+		{
+			name:     "service in window",
+			jobType:  structs.JobTypeService,
+			now:      day.Add(3 * time.Hour),
+			feasible: false,
+		},
+		{
+			name:     "batch in window",
+			jobType:  structs.JobTypeBatch,
+			now:      day.Add(3 * time.Hour),
+			feasible: false,
+		},
+		{
+			name:     "batch without expected duration",
+			jobType:  structs.JobTypeBatch,
+			now:      day.Add(time.Hour),
+			feasible: true,
+		},
+		{
+			name:             "batch finishing before window",
+			jobType:          structs.JobTypeBatch,
+			expectedDuration: helper.TimeToPtr(30 * time.Minute),
+			now:              day.Add(time.Hour),
+			feasible:         true,
+		},
+		{
+			name:             "batch overlapping window",
+			jobType:          structs.JobTypeBatch,
+			expectedDuration: helper.TimeToPtr(90 * time.Minute),
+			now:              day.Add(time.Hour),
+			feasible:         false,
+		},
+		{
+			name:     "system in window",
+			jobType:  structs.JobTypeSystem,
+			now:      day.Add(3 * time.Hour),
+			feasible: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checker := NewMaintenanceWindowChecker(ctx)
+			checker.now = func() time.Time { return c.now }
+
+			job := mock.Job()
+			job.Type = c.jobType
+			tg := job.TaskGroups[0]
+			tg.ExpectedDuration = c.expectedDuration
+
+			checker.SetJob(job)
+			checker.SetTaskGroup(tg)
+			require.Equal(t, c.feasible, checker.Feasible(node))
+		})
+	}
+
+	// Nodes without maintenance windows are always feasible
+	checker := NewMaintenanceWindowChecker(ctx)
+	checker.SetJob(mock.Job())
+	checker.SetTaskGroup(mock.Job().TaskGroups[0])
+	require.True(t, checker.Feasible(mock.Node()))
+}
+
 func TestNetworkChecker(t *testing.T) {
 	ci.Parallel(t)
 
@@ -758,6 +758,95 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// ImageLocalityIterator is used to prefer nodes that already hold the
+// prefetched images used by the tasks in a task group, avoiding long image
+// pulls on nodes that have not prefetched them yet.
+type ImageLocalityIterator struct {
+	ctx    Context
+	source RankIterator
+
+	// prefetched is the set of node attributes of all prefetched images
+	prefetched map[string]struct{}
+
+	// attrs are the node attributes of the prefetched images used by the
+	// task group
+	attrs []string
+}
+
+// NewImageLocalityIterator is used to create an ImageLocalityIterator that
+// scores nodes according to the fraction of the task group's prefetched
+// images they hold.
+func NewImageLocalityIterator(ctx Context, source RankIterator) *ImageLocalityIterator {
+	return &ImageLocalityIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *ImageLocalityIterator) SetJob(*structs.Job) {
+	iter.prefetched = make(map[string]struct{})
+
+	prefetches, err := iter.ctx.State().ImagePrefetches(nil)
+	if err != nil {
+		iter.ctx.Logger().Named("image_locality").Error("failed to get image prefetches", "error", err)
+		return
+	}
+	for raw := prefetches.Next(); raw != nil; raw = prefetches.Next() {
+		prefetch := raw.(*structs.ImagePrefetch)
+		for _, image := range prefetch.Images {
+			iter.prefetched[structs.ImagePrefetchAttribute(prefetch.Driver, image)] = struct{}{}
+		}
+	}
+}
+
+func (iter *ImageLocalityIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.attrs = nil
+	for _, task := range tg.Tasks {
+		image, ok := task.Config["image"].(string)
+		if !ok || image == "" {
+			continue
+		}
+
+		attr := structs.ImagePrefetchAttribute(task.Driver, image)
+		if _, ok := iter.prefetched[attr]; ok {
+			iter.attrs = append(iter.attrs, attr)
+		}
+	}
+}
+
+func (iter *ImageLocalityIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *ImageLocalityIterator) hasImages() bool {
+	return len(iter.attrs) > 0
+}
+
+func (iter *ImageLocalityIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+	if !iter.hasImages() {
+		iter.ctx.Metrics().ScoreNode(option.Node, "image-locality", 0)
+		return option
+	}
+
+	present := 0
+	for _, attr := range iter.attrs {
+		if option.Node.Attributes[attr] == "true" {
+			present++
+		}
+	}
+
+	score := float64(present) / float64(len(iter.attrs))
+	if score != 0.0 {
+		option.Scores = append(option.Scores, score)
+	}
+	iter.ctx.Metrics().ScoreNode(option.Node, "image-locality", score)
+	return option
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
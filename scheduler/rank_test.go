@@ -2021,6 +2021,52 @@ func TestScoreNormalizationIterator(t *testing.T) {
 	require.Equal(out[1].FinalScore, 0.0)
 }
 
+func TestImageLocalityIterator(t *testing.T) {
+	state, ctx := testContext(t)
+	require.NoError(t, state.UpsertImagePrefetches(structs.MsgTypeTestSetup, 1000, []*structs.ImagePrefetch{
+		{Name: "cuda", Driver: "docker", Images: []string{"docker.io/nvidia/cuda:11", "app"}},
+	}))
+
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+	nodes[0].Node.Attributes["driver.docker.image.nvidia/cuda:11"] = "true"
+	nodes[0].Node.Attributes["driver.docker.image.app:latest"] = "true"
+	nodes[1].Node.Attributes["driver.docker.image.app:latest"] = "true"
+
+	// Images that are not prefetched are ignored even if nodes hold them
+	nodes[2].Node.Attributes["driver.docker.image.redis:7"] = "true"
+
+	// References to the same image match whatever their form
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Tasks = []*structs.Task{
+		{Name: "cuda", Driver: "docker", Config: map[string]interface{}{"image": "nvidia/cuda:11"}},
+		{Name: "app", Driver: "docker", Config: map[string]interface{}{"image": "docker.io/library/app:latest"}},
+		{Name: "redis", Driver: "docker", Config: map[string]interface{}{"image": "redis:7"}},
+		{Name: "exec", Driver: "exec", Config: map[string]interface{}{"command": "/bin/date"}},
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	imageLocality := NewImageLocalityIterator(ctx, static)
+	imageLocality.SetJob(job)
+	imageLocality.SetTaskGroup(tg)
+	require.True(t, imageLocality.hasImages())
+
+	out := collectRanked(imageLocality)
+	require.Len(t, out, 3)
+	require.Equal(t, []float64{1}, out[0].Scores)
+	require.Equal(t, []float64{0.5}, out[1].Scores)
+	require.Empty(t, out[2].Scores)
+
+	// Task groups without prefetched images are not scored
+	tg.Tasks = tg.Tasks[2:]
+	imageLocality.SetTaskGroup(tg)
+	require.False(t, imageLocality.hasImages())
+}
+
 func TestNodeAffinityIterator(t *testing.T) {
 	_, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -111,6 +111,9 @@ type State interface {
 
 	// LatestIndex returns the greatest index value for all indexes.
 	LatestIndex() (uint64, error)
+
+	// ImagePrefetches returns an iterator over all the image prefetches
+	ImagePrefetches(ws memdb.WatchSet) (memdb.ResultIterator, error)
 }
 
 // Planner interface is used to submit a task allocation plan.
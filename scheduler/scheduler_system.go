@@ -518,7 +518,7 @@ func (s *SystemScheduler) canHandle(trigger string) bool {
 	default:
 		switch s.sysbatch {
 		case true:
-			return trigger == structs.EvalTriggerPeriodicJob
+			return trigger == structs.EvalTriggerPeriodicJob || trigger == structs.EvalTriggerDispatchRetry
 		case false:
 			return false
 		}
@@ -174,6 +174,36 @@ func TestServiceStack_Select_PreferringNodes(t *testing.T) {
 	require.Equal(t, prefNodes1, selectOptions.PreferredNodes)
 }
 
+func TestServiceStack_Select_ImageLocality(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	require.NoError(t, state.UpsertImagePrefetches(structs.MsgTypeTestSetup, 1000, []*structs.ImagePrefetch{
+		{Name: "cuda", Driver: "docker", Images: []string{"nvidia/cuda:11"}},
+	}))
+
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node(), mock.Node()}
+	imageNode := nodes[2]
+	imageNode.Attributes["driver.docker.image.nvidia/cuda:11"] = "true"
+	for _, node := range nodes {
+		node.Attributes["driver.docker"] = "1"
+	}
+
+	stack := NewGenericStack(false, ctx)
+	stack.SetNodes(nodes)
+
+	job := mock.Job()
+	task := job.TaskGroups[0].Tasks[0]
+	task.Driver = "docker"
+	task.Config = map[string]interface{}{"image": "nvidia/cuda:11"}
+	stack.SetJob(job)
+
+	selectOptions := &SelectOptions{}
+	option := stack.Select(job.TaskGroups[0], selectOptions)
+	require.NotNil(t, option)
+	require.Equal(t, imageNode.ID, option.Node.ID)
+}
+
 func TestServiceStack_Select_MetricsReset(t *testing.T) {
 	ci.Parallel(t)
 